@@ -2,21 +2,28 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"log"
+	"math"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"traq/internal/broadcast"
 	"traq/internal/inference"
 	"traq/internal/lock"
 	"traq/internal/platform"
 	"traq/internal/service"
 	"traq/internal/storage"
 	"traq/internal/tracker"
+	"traq/internal/tray"
 
 	wailsRuntime "github.com/wailsapp/wails/v2/pkg/runtime"
 )
@@ -34,6 +41,7 @@ type App struct {
 	store        *storage.Store
 	daemon       *tracker.Daemon
 	instanceLock *lock.InstanceLock
+	resources    *tracker.ResourceMonitor // Measures Traq's own CPU, memory and disk usage
 
 	// Services (exposed to frontend via Wails bindings)
 	Analytics   *service.AnalyticsService
@@ -47,12 +55,36 @@ type App struct {
 	Projects    *service.ProjectAssignmentService
 	Embeddings  *service.EmbeddingService
 	Draft       *service.DraftService
+	Goals       *service.GoalService
+	Insights    *service.InsightService
+	Obsidian    *service.ObsidianExporter
+	TaskThreads *service.TaskThreadService
 
 	// Inference engine
 	inference *inference.Service
 
 	// Backfill service for applying patterns to historical data
 	backfillService *service.BackfillService
+
+	// Notifies when the daily deep-work goal is reached
+	focusGoalNotifier *service.FocusGoalNotifier
+
+	// Generates scheduled reports and posts them to webhooks
+	reportScheduler *service.ReportScheduler
+
+	// Stops the tray productivity watcher started by watchProductivity
+	productivityStop chan struct{}
+
+	// Global hotkeys currently registered with the platform (action -> shortcut)
+	hotkeyMu sync.Mutex
+	hotkeys  map[string]string
+
+	// Live focus, AFK and screenshot events, streamed to clients of /events/focus
+	events *broadcast.Hub
+
+	// Serves the live event stream; eventStreamURL includes its access token
+	eventServer    *http.Server
+	eventStreamURL string
 }
 
 // NewApp creates a new App application struct
@@ -105,6 +137,7 @@ func (a *App) startup(ctx context.Context) {
 	if err := a.store.Migrate(); err != nil {
 		log.Printf("Failed to run migrations: %v", err)
 	}
+	a.resources = tracker.NewResourceMonitor(a.store, a.platform, dataDir)
 
 	// Initialize services
 	a.Analytics = service.NewAnalyticsService(a.store)
@@ -121,6 +154,18 @@ func (a *App) startup(ctx context.Context) {
 
 	// Initialize daemon with default config
 	daemonConfig := tracker.DefaultDaemonConfig(dataDir)
+	if cfg, err := a.Config.GetConfig(); err == nil {
+		if cfg.DataSources.Clipboard != nil {
+			daemonConfig.ClipboardTracking = cfg.DataSources.Clipboard.Enabled
+		}
+		if cfg.AFK != nil {
+			daemonConfig.MinSessionDurationSeconds = cfg.AFK.MinSessionDurationSeconds
+		}
+		if cfg.Capture != nil {
+			daemonConfig.AdaptiveCaptureQuality = cfg.Capture.AdaptiveQuality
+			daemonConfig.MinCaptureQuality = cfg.Capture.MinQuality
+		}
+	}
 	a.daemon, err = tracker.NewDaemon(daemonConfig, a.store, a.platform)
 	if err != nil {
 		log.Printf("Failed to initialize daemon: %v", err)
@@ -128,12 +173,26 @@ func (a *App) startup(ctx context.Context) {
 		// Link daemon to config service
 		a.Config.SetDaemon(a.daemon)
 
+		// Forward live events to the /events/focus stream
+		a.events = broadcast.NewHub()
+		a.daemon.SetEventHub(a.events)
+		if err := a.startEventServer(); err != nil {
+			log.Printf("Failed to start event stream server: %v", err)
+		}
+
 		// Auto-register current working directory as git repo (if applicable)
 		a.daemon.AutoRegisterGitRepo()
 
 		// Auto-watch Downloads folder for file events
 		a.daemon.AutoWatchDownloads()
 
+		// Show a welcome-back summary after long AFK periods
+		afkNotifier := service.NewAFKReturnNotifier(a.platform, func() bool {
+			cfg, err := a.Config.GetConfig()
+			return err == nil && cfg.AFK != nil && cfg.AFK.ReturnNotificationsEnabled
+		})
+		a.daemon.SetOnAFKEnd(afkNotifier.OnAFKEnd)
+
 		// Start daemon
 		if err := a.daemon.Start(); err != nil {
 			log.Printf("Failed to start daemon: %v", err)
@@ -147,6 +206,11 @@ func (a *App) startup(ctx context.Context) {
 	// Wire up auto-assignment of new activities to projects
 	if a.daemon != nil {
 		a.daemon.SetOnActivitySaved(func(eventType string, eventID int64, appName, windowTitle, gitRepo string) {
+			// New activity invalidates the cached report data of ranges covering it
+			if a.Reports != nil {
+				a.Reports.InvalidateReportCacheAt(time.Now().Unix())
+			}
+
 			// Check if auto-assign is enabled
 			val, err := a.store.GetConfig("projects.auto_assign")
 			if err != nil || val != "true" {
@@ -166,6 +230,14 @@ func (a *App) startup(ctx context.Context) {
 				}
 			}
 		})
+
+		// Link new commits to the Jira, Linear, GitHub and GitLab tickets they mention
+		tickets := service.NewTicketExtractor(a.store)
+		a.daemon.SetOnCommitSaved(func(commit *storage.GitCommit) {
+			if err := tickets.RecordCommit(commit); err != nil {
+				log.Printf("Failed to record tickets for commit %s: %v", commit.ShortHash, err)
+			}
+		})
 	}
 
 	// Initialize embedding service (for semantic similarity-based project assignment)
@@ -180,6 +252,33 @@ func (a *App) startup(ctx context.Context) {
 	// Initialize reports service (after timeline, analytics, and projects services)
 	a.Reports = service.NewReportsService(a.store, a.Timeline, a.Analytics, a.Projects)
 
+	// Initialize insights service (rule-based weekly patterns, uses analytics and reports)
+	a.Insights = service.NewInsightService(a.Analytics, a.Reports)
+
+	// Keep today's Obsidian daily note up to date as sessions end
+	a.Obsidian = service.NewObsidianExporter(a.store)
+	if a.daemon != nil {
+		a.daemon.SetOnSessionEnd(func(sessionID int64) {
+			cfg, err := a.Config.GetConfig()
+			if err != nil || cfg.Obsidian == nil || !cfg.Obsidian.AutoExportNotes || cfg.Obsidian.VaultPath == "" {
+				return
+			}
+			if _, err := a.Obsidian.ExportDailyNote(time.Now().Format("2006-01-02"), cfg.Obsidian.VaultPath); err != nil {
+				log.Printf("Failed to export Obsidian daily note: %v", err)
+			}
+		})
+
+		// Let the frontend follow the session being tracked
+		a.daemon.SetOnActiveSessionChanged(func(sessionID int64) {
+			active, err := a.Timeline.GetActiveSession()
+			if err != nil {
+				log.Printf("Failed to load active session: %v", err)
+				return
+			}
+			wailsRuntime.EventsEmit(a.ctx, "session:active_changed", active)
+		})
+	}
+
 	// Wire up reports service to projects service (for auto-discovery)
 	a.Projects.SetReportsService(a.Reports)
 
@@ -193,20 +292,64 @@ func (a *App) startup(ctx context.Context) {
 
 	// Initialize summary service
 	a.Summary = service.NewSummaryService(a.store, a.inference)
+	a.Summary.SetScreenshotAnnotation(func() bool {
+		cfg, err := a.Config.GetConfig()
+		return err == nil && cfg.AI != nil && cfg.AI.AnnotateScreenshots
+	})
+
+	// Let project suggestions fall back to AI classification
+	a.Projects.SetInference(a.inference)
+
+	// Let reports request an AI-written narrative
+	a.Reports.SetInference(a.inference)
+
+	// Leave other contributors' commits out of reports. Repositories can set their own
+	// user.email, so each tracked repository's effective email counts as the user's.
+	var repoPaths []string
+	if repos, err := a.store.GetAllGitRepositories(); err == nil {
+		for _, repo := range repos {
+			repoPaths = append(repoPaths, repo.Path)
+		}
+	}
+	a.Reports.SetAuthorEmails(tracker.GitUserEmails(repoPaths))
 
 	// Initialize draft service (for AI draft approval workflow)
 	a.Draft = service.NewDraftService(a.store)
 
+	// Initialize goals service (for daily/weekly activity targets)
+	a.Goals = service.NewGoalService(a.store)
+
+	// Initialize task threads (for grouping sessions under multi-day tasks)
+	a.TaskThreads = service.NewTaskThreadService(a.store, a.Timeline)
+
+	// Check the daily deep-work goal in the background
+	a.focusGoalNotifier = service.NewFocusGoalNotifier(a.store, a.Timeline, a.platform, func() *service.FocusGoalConfig {
+		cfg, err := a.Config.GetConfig()
+		if err != nil {
+			return nil
+		}
+		return cfg.FocusGoal
+	})
+	a.focusGoalNotifier.Start()
+
+	// Generate scheduled reports from the daemon's ticks
+	a.reportScheduler = service.NewReportScheduler(a.store, a.Reports)
+	if a.daemon != nil {
+		a.daemon.SetOnScheduledJobs(a.reportScheduler.Check)
+	}
+
 	// Initialize issues service (for crash/manual reporting)
 	a.Issues = service.NewIssueService(a.store, Version)
 
 	// Initialize update service for auto-updates
 	a.Update = service.NewUpdateService(Version, dataDir)
+	a.Update.SetMachineID(a.platform.MachineID())
 
 	// Configure update service from settings
 	if config != nil && config.Update != nil {
 		a.Update.SetEnabled(config.Update.AutoUpdate)
 		a.Update.SetCheckInterval(config.Update.CheckIntervalHours)
+		a.Update.SetCanary(config.Update.CanaryEnabled, config.Update.CanaryFraction)
 		if a.daemon != nil {
 			a.daemon.SetAFKRestartMinutes(config.Update.AFKRestartMinutes)
 		}
@@ -228,6 +371,9 @@ func (a *App) startup(ctx context.Context) {
 	// Start update service (background update checker)
 	a.Update.Start()
 
+	// Register global keyboard shortcuts
+	a.registerHotkeys()
+
 	// Start screenshot server for dev mode (Vite proxies to this)
 	go startScreenshotServer(dataDir)
 
@@ -276,13 +422,59 @@ func startScreenshotServer(dataDir string) {
 	}
 }
 
+// startEventServer serves the live event stream from its own server on a random localhost
+// port. It can't go through the Wails asset handler: the webview's response writer doesn't
+// implement http.Flusher, so events would never reach the client.
+func (a *App) startEventServer() error {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return err
+	}
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		listener.Close()
+		return err
+	}
+
+	handler := &focusEventsHandler{app: a, token: hex.EncodeToString(token)}
+	mux := http.NewServeMux()
+	mux.Handle(focusEventsPath, handler)
+	a.eventServer = &http.Server{Handler: mux}
+	a.eventStreamURL = fmt.Sprintf("http://%s%s?token=%s", listener.Addr(), focusEventsPath, handler.token)
+
+	go func(server *http.Server) {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Event stream server error: %v", err)
+		}
+	}(a.eventServer)
+	return nil
+}
+
 // shutdown is called when the app is closing
 func (a *App) shutdown(ctx context.Context) {
+	// Release global hotkeys
+	a.unregisterHotkeys()
+
+	// Stop the event stream server, dropping connected clients
+	if a.eventServer != nil {
+		a.eventServer.Close()
+	}
+
 	// Stop update service
 	if a.Update != nil {
 		a.Update.Stop()
 	}
 
+	// Stop focus goal checker
+	if a.focusGoalNotifier != nil {
+		a.focusGoalNotifier.Stop()
+	}
+
+	// Stop tray productivity watcher
+	if a.productivityStop != nil {
+		close(a.productivityStop)
+	}
+
 	// Stop daemon gracefully
 	if a.daemon != nil {
 		if err := a.daemon.Stop(); err != nil {
@@ -319,7 +511,7 @@ func (a *App) beforeClose(ctx context.Context) bool {
 // Daemon Control Methods (exposed to frontend)
 // ============================================================================
 
-// GetDaemonStatus returns the current daemon status.
+// GetDaemonStatus returns the current daemon status, including the health of each data source.
 func (a *App) GetDaemonStatus() (result *service.DaemonStatus, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -414,8 +606,85 @@ func (a *App) GetDailyStats(date string) (result *service.DailyStats, err error)
 	return a.Analytics.GetDailyStats(date)
 }
 
-// GetDailyStatsWithComparison returns statistics for a specific date with comparison to previous day.
-func (a *App) GetDailyStatsWithComparison(date string) (result *service.DailyStats, err error) {
+// GetDailyStatsWithComparison returns statistics for a specific date, optionally compared with the previous day.
+func (a *App) GetDailyStatsWithComparison(date string, compareWithPrevious bool) (result *service.DailyStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetDailyStatsWithComparison(date, compareWithPrevious)
+}
+
+// GetTimezoneCorrectedStats returns statistics for a date with day boundaries in displayTZ
+// (e.g. "Asia/Tokyo") instead of the system timezone.
+func (a *App) GetTimezoneCorrectedStats(date string, displayTZ string) (result *service.DailyStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetTimezoneCorrectedStats(date, displayTZ)
+}
+
+// GetFocusEventStream returns the URL of the Server-Sent Events stream of focus, AFK and
+// screenshot events, or "" if the stream is disabled in settings. The URL carries a token
+// that changes every launch.
+func (a *App) GetFocusEventStream() (string, error) {
+	if a.Config == nil || a.eventServer == nil {
+		return "", nil
+	}
+	cfg, err := a.Config.GetConfig()
+	if err != nil {
+		return "", err
+	}
+	if !cfg.System.EnableSSE {
+		return "", nil
+	}
+	return a.eventStreamURL, nil
+}
+
+// GetAdaptiveQualityStats returns the quality screenshots were encoded with between start
+// and end, hour by hour, showing when adaptive capture lowered it.
+func (a *App) GetAdaptiveQualityStats(start, end int64) (*storage.QualityStats, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetScreenshotQualityStats(start, end)
+}
+
+// GetTimezoneHistory returns the recorded system timezone changes, most recent first.
+func (a *App) GetTimezoneHistory() ([]*storage.TimezoneChange, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetTimezoneChanges()
+}
+
+// CompareWithSameDayLastWeek returns statistics for a date compared with the same weekday last week.
+func (a *App) CompareWithSameDayLastWeek(date string) (result *service.DailyStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.CompareWithSameDayLastWeek(date)
+}
+
+// CompareWithSameDayLastMonth returns statistics for a date compared with the same day last month.
+func (a *App) CompareWithSameDayLastMonth(date string) (result *service.DailyStats, err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			result = nil
@@ -425,7 +694,7 @@ func (a *App) GetDailyStatsWithComparison(date string) (result *service.DailySta
 	if a == nil || !a.ready || a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Analytics.GetDailyStatsWithComparison(date, true)
+	return a.Analytics.CompareWithSameDayLastMonth(date)
 }
 
 // GetWeeklyStats returns statistics for a week.
@@ -484,6 +753,48 @@ func (a *App) GetCustomRangeStats(startDate, endDate string) (result *service.Cu
 	return a.Analytics.GetCustomRangeStats(startDate, endDate)
 }
 
+// GetMeetingStats returns meeting load analytics for a time range (Unix seconds).
+func (a *App) GetMeetingStats(start, end int64) (result *service.MeetingStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetMeetingStats(start, end)
+}
+
+// GenerateWeeklyInsights returns rule-based insights for the week starting at weekStart (YYYY-MM-DD).
+func (a *App) GenerateWeeklyInsights(weekStart string) (result []*service.Insight, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Insights == nil {
+		return nil, nil
+	}
+	return a.Insights.GenerateWeeklyInsights(weekStart)
+}
+
+// DetectBurnoutRisk assesses burnout risk from work patterns over the numWeeks weeks before today.
+func (a *App) DetectBurnoutRisk(numWeeks int) (result *service.BurnoutRisk, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Insights == nil {
+		return nil, nil
+	}
+	return a.Insights.DetectBurnoutRisk(numWeeks)
+}
+
 // ExportAnalytics exports analytics data in the specified format.
 // viewMode can be "day", "week", or "month"
 // format can be "csv", "html", or "json"
@@ -548,158 +859,402 @@ func (a *App) GetDataSourceStats(start, end int64) (*service.DataSourceStats, er
 	return a.Analytics.GetDataSourceStats(start, end)
 }
 
-// GetProductivityScore calculates productivity score for a date.
-func (a *App) GetProductivityScore(date string) (*service.ProductivityScore, error) {
+// GetBrowserUsageByProfile returns browser statistics for each browser profile in a time range.
+func (a *App) GetBrowserUsageByProfile(start, end int64) (map[string]*service.BrowserStats, error) {
 	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Analytics.GetProductivityScore(date)
+	return a.Analytics.GetBrowserUsageByProfile(start, end)
 }
 
-// GetFocusDistribution calculates hourly focus quality for a date.
-func (a *App) GetFocusDistribution(date string) ([]*service.HourlyFocus, error) {
+// GetBrowserVisitsByDomain returns a page of visits to a domain in a time range, newest first.
+func (a *App) GetBrowserVisitsByDomain(domain string, start, end int64, offset, limit int) (*service.BrowserVisitPage, error) {
 	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Analytics.GetFocusDistribution(date)
+	return a.Analytics.GetBrowserVisitsByDomain(domain, start, end, offset, limit)
 }
 
-// GetActivityTags extracts and aggregates activity tags for a date.
-func (a *App) GetActivityTags(date string) ([]*service.TagUsage, error) {
+// GetDomainVisitTimeline returns the visits to a domain in a time range, positioned for the timeline.
+func (a *App) GetDomainVisitTimeline(domain string, start, end int64) ([]*service.DomainVisitBlock, error) {
 	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Analytics.GetActivityTags(date)
+	return a.Analytics.GetDomainVisitTimeline(domain, start, end)
 }
 
-// GetTopWindows returns the most used windows for a date, grouped by window title.
-func (a *App) GetTopWindows(date string, limit int) ([]*service.WindowUsage, error) {
+// GetDomainActivityByHour returns the minutes spent on a domain in each hour of the day.
+func (a *App) GetDomainActivityByHour(domain string, start, end int64) ([]*service.HourlyActivity, error) {
 	if a.Analytics == nil {
 		return nil, nil
 	}
+	return a.Analytics.GetDomainActivityByHour(domain, start, end)
+}
 
-	// Parse date to get start/end timestamps
-	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
-	if err != nil {
-		return nil, err
+// GetSocialMediaUsage returns time spent on social media sites and apps for a time range.
+func (a *App) GetSocialMediaUsage(start, end int64) (*service.SocialMediaStats, error) {
+	if a.Analytics == nil {
+		return nil, nil
 	}
-
-	start := t.Unix()
-	end := t.Add(24*time.Hour).Unix() - 1
-
-	return a.Analytics.GetTopWindows(start, end, limit)
+	return a.Analytics.GetSocialMediaUsage(start, end)
 }
 
-// GetTopWindowsRange returns the most used windows for a timestamp range, grouped by window title.
-func (a *App) GetTopWindowsRange(start, end int64, limit int) ([]*service.WindowUsage, error) {
+// GetClipboardActivity returns clipboard activity grouped by hour of day for a time range.
+func (a *App) GetClipboardActivity(start, end int64) ([]*service.ClipboardHourly, error) {
 	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Analytics.GetTopWindows(start, end, limit)
+	return a.Analytics.GetClipboardActivity(start, end)
 }
 
-// ============================================================================
-// Timeline Methods (exposed to frontend)
-// ============================================================================
-
-// GetSessionsForDate returns all sessions for a date.
-func (a *App) GetSessionsForDate(date string) (result []*service.SessionSummary, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = nil
-			err = fmt.Errorf("internal error: %v", r)
-		}
-	}()
-	if a == nil || !a.ready || a.Timeline == nil {
+// GetLanguageStats returns the languages detected in VS Code settings for a time range.
+func (a *App) GetLanguageStats(start, end int64) ([]*service.LanguageUsage, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetSessionsForDate(date)
+	return a.Analytics.GetLanguageStats(start, end)
 }
 
-// GetTimelineGridData returns all data for the v3 timeline grid view.
-func (a *App) GetTimelineGridData(date string) (result *service.TimelineGridData, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = nil
-			err = fmt.Errorf("internal error: %v", r)
-		}
-	}()
-	if a == nil || !a.ready || a.Timeline == nil {
+// GetNetworkStats returns the most connected web hosts and bytes transferred in a time range.
+func (a *App) GetNetworkStats(start, end int64) (*service.NetworkStats, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
+	return a.Analytics.GetNetworkStats(start, end)
+}
 
-	// Get timeline display settings from config
-	opts := service.TimelineOptions{}
-	if a.Config != nil {
-		if config, err := a.Config.GetConfig(); err == nil && config.Timeline != nil {
-			opts.MinDurationSeconds = config.Timeline.MinActivityDurationSeconds
-			opts.AppGrouping = config.Timeline.AppGrouping
-			opts.ContinuityMergeSeconds = config.Timeline.ContinuityMergeSeconds
-		}
+// GetAudioStats returns how long audio played in a time range and the minutes per app.
+func (a *App) GetAudioStats(start, end int64) (*service.AudioStats, error) {
+	if a.Analytics == nil {
+		return nil, nil
 	}
+	return a.Analytics.GetAudioStats(start, end)
+}
 
-	return a.Timeline.GetTimelineGridDataWithOptions(date, opts)
+// GetTicketStats returns the tickets mentioned by commits in a time range, with the time
+// spent on each estimated from commit timestamps.
+func (a *App) GetTicketStats(start, end int64) ([]*service.TicketStat, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetTicketStats(start, end)
 }
 
-// GetWeekTimelineData returns aggregated data for week view.
-func (a *App) GetWeekTimelineData(startDate string) (result *service.WeekTimelineData, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = nil
-			err = fmt.Errorf("internal error: %v", r)
-		}
-	}()
-	if a == nil || !a.ready || a.Timeline == nil {
+// GetFocusSessionLengthDistribution returns a histogram of focus session lengths in a time range.
+func (a *App) GetFocusSessionLengthDistribution(start, end int64) (*service.FocusDistribution, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetWeekTimelineData(startDate)
+	return a.Analytics.GetFocusSessionLengthDistribution(start, end)
 }
 
-// GetScreenshotsForSession returns paginated screenshots for a session.
-func (a *App) GetScreenshotsForSession(sessionID int64, page, perPage int) (*service.ScreenshotPage, error) {
-	if a.Timeline == nil {
+// GetAFKBreakdown returns minutes spent away in a time range, keyed by AFK trigger type.
+func (a *App) GetAFKBreakdown(start, end int64) (map[string]int64, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetScreenshotsForSession(sessionID, page, perPage)
+	return a.Analytics.GetAFKBreakdown(start, end)
 }
 
-// GetScreenshotsForHour returns screenshots for a specific hour.
-func (a *App) GetScreenshotsForHour(date string, hour int) ([]*service.ScreenshotDisplay, error) {
-	if a.Timeline == nil {
+// GetPeakProductivityWindows returns the most productive 90-minute windows of the day over the last numWeeks weeks.
+func (a *App) GetPeakProductivityWindows(numWeeks int) (*service.PeakWindows, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetScreenshotsForHour(date, hour)
+	return a.Analytics.GetPeakProductivityWindows(numWeeks)
 }
 
-// SearchAllDataSources searches across all event types (git, shell, files, browser, screenshots).
-func (a *App) SearchAllDataSources(query string, maxResults int) ([]*service.SearchResult, error) {
-	if a.Timeline == nil {
+// GetWeekdayPatterns profiles each day of the week over the last numWeeks weeks.
+func (a *App) GetWeekdayPatterns(numWeeks int) (*service.WeekdayPatterns, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.SearchAllDataSources(query, maxResults)
+	return a.Analytics.GetWeekdayPatterns(numWeeks)
 }
 
-// GetScreenshotsForDate returns all screenshots for a specific date.
-func (a *App) GetScreenshotsForDate(date string) ([]*service.ScreenshotDisplay, error) {
-	if a.Timeline == nil {
+// GetDistractedHours returns the distraction in each active hour of a date (YYYY-MM-DD).
+func (a *App) GetDistractedHours(date string) ([]*service.DistractedHourEntry, error) {
+	if a.Analytics == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetScreenshotsForDate(date)
+	return a.Analytics.GetDistractedHours(date)
 }
 
-// GetSessionContext returns all context for a session.
-func (a *App) GetSessionContext(sessionID int64) (*service.SessionContext, error) {
-	// Return empty context for not-ready state or invalid session ID
-	if a == nil || !a.ready || a.Timeline == nil || sessionID <= 0 {
-		return &service.SessionContext{}, nil
+// GetMostDistractedDay returns the date with the highest average distraction over the last numWeeks weeks.
+func (a *App) GetMostDistractedDay(numWeeks int) (string, error) {
+	if a.Analytics == nil {
+		return "", nil
 	}
-	return a.Timeline.GetSessionContext(sessionID)
+	return a.Analytics.GetMostDistractedDay(numWeeks)
 }
 
-// GetRecentSessions returns the most recent sessions.
-func (a *App) GetRecentSessions(limit int) (result []*storage.Session, err error) {
-	defer func() {
-		if r := recover(); r != nil {
+// GetShellCommandFrequency returns the most used shell commands (by first word) in a time range.
+func (a *App) GetShellCommandFrequency(start, end int64, limit int) ([]*storage.CommandFrequency, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetShellCommandFrequency(start, end, limit)
+}
+
+// GetTopFileExtensions returns the file extensions with the most file events in a time range.
+func (a *App) GetTopFileExtensions(start, end int64, limit int) ([]*storage.ExtensionCount, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetTopFileExtensions(start, end, limit)
+}
+
+// GetFocusEventsByWindow returns the focus events in a time range whose window title matches
+// a case-insensitive pattern. Patterns without % or _ wildcards match anywhere in the title.
+func (a *App) GetFocusEventsByWindow(windowTitlePattern string, start, end int64) ([]*storage.WindowFocusEvent, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetFocusEventsByWindowTitle(windowTitlePattern, start, end)
+}
+
+// GetWindowTitleFrequency returns the window titles with the most focus time in a time range.
+func (a *App) GetWindowTitleFrequency(start, end int64, limit int) ([]*storage.WindowTitleCount, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetWindowTitleFrequency(start, end, limit)
+}
+
+// GetWindowTitleTrend returns the daily focus minutes of windows matching a title pattern
+// over the last number of days.
+func (a *App) GetWindowTitleTrend(windowTitlePattern string, days int) ([]*service.DailyWindowUsage, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetWindowTitleTrend(windowTitlePattern, days)
+}
+
+// GetShellDirectoryStats returns the number of shell commands run in each working directory.
+func (a *App) GetShellDirectoryStats(start, end int64) (map[string]int64, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetShellCommandsByWorkingDirectory(start, end)
+}
+
+// GetProductivityScore calculates productivity score for a date.
+func (a *App) GetProductivityScore(date string) (*service.ProductivityScore, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetProductivityScore(date)
+}
+
+// GetAbstractivityScore calculates how concrete a date's work was (0-100).
+func (a *App) GetAbstractivityScore(date string) (*service.AbstractivityScore, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetAbstractivityScore(date)
+}
+
+// GetFocusDistribution calculates hourly focus quality for a date.
+func (a *App) GetFocusDistribution(date string) ([]*service.HourlyFocus, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetFocusDistribution(date)
+}
+
+// GetActivityTags extracts and aggregates activity tags for a date.
+func (a *App) GetActivityTags(date string) ([]*service.TagUsage, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetActivityTags(date)
+}
+
+// GetTopWindows returns the most used windows for a date, grouped by window title and app.
+func (a *App) GetTopWindows(date string, limit int) ([]*service.WindowUsage, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+
+	// Parse date to get start/end timestamps
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+
+	start := t.Unix()
+	end := t.Add(24*time.Hour).Unix() - 1
+
+	return a.Analytics.GetTopWindows(start, end, limit)
+}
+
+// GetTopWindowsRange returns the most used windows for a timestamp range, grouped by window title and app.
+func (a *App) GetTopWindowsRange(start, end int64, limit int) ([]*service.WindowUsage, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetTopWindows(start, end, limit)
+}
+
+// GetTopWindowsForApp returns the most used windows of a single app for a timestamp range.
+func (a *App) GetTopWindowsForApp(appName string, start, end int64, limit int) ([]*service.WindowUsage, error) {
+	if a.Analytics == nil {
+		return nil, nil
+	}
+	return a.Analytics.GetTopWindowsForApp(appName, start, end, limit)
+}
+
+// ============================================================================
+// Timeline Methods (exposed to frontend)
+// ============================================================================
+
+// GetSessionsForDate returns all sessions for a date.
+func (a *App) GetSessionsForDate(date string) (result []*service.SessionSummary, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetSessionsForDate(date)
+}
+
+// GetActiveSession returns the session being tracked now, or nil if there is none.
+func (a *App) GetActiveSession() (result *service.SessionSummary, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetActiveSession()
+}
+
+// GetTimelineGridData returns all data for the v3 timeline grid view.
+func (a *App) GetTimelineGridData(date string) (result *service.TimelineGridData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+
+	return a.Timeline.GetTimelineGridDataWithOptions(date, a.timelineOptions())
+}
+
+// GetTimelineGridDataForDomain returns the timeline grid for a date with only the browser
+// visits to one domain.
+func (a *App) GetTimelineGridDataForDomain(date, domain string) (result *service.TimelineGridData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+
+	opts := a.timelineOptions()
+	opts.BrowserDomain = domain
+	return a.Timeline.GetTimelineGridDataWithOptions(date, opts)
+}
+
+// timelineOptions returns the timeline display settings from config.
+func (a *App) timelineOptions() service.TimelineOptions {
+	opts := service.TimelineOptions{}
+	if a.Config != nil {
+		if config, err := a.Config.GetConfig(); err == nil && config.Timeline != nil {
+			opts.MinDurationSeconds = config.Timeline.MinActivityDurationSeconds
+			opts.AppGrouping = config.Timeline.AppGrouping
+			opts.ContinuityMergeSeconds = config.Timeline.ContinuityMergeSeconds
+		}
+	}
+	return opts
+}
+
+// GetWeekTimelineData returns aggregated data for week view.
+func (a *App) GetWeekTimelineData(startDate string) (result *service.WeekTimelineData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetWeekTimelineData(startDate)
+}
+
+// GetProductivityHeatmap returns an hour-by-day productivity matrix for the week containing weekStart.
+func (a *App) GetProductivityHeatmap(weekStart string) (result *service.ProductivityHeatmap, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetProductivityHeatmap(weekStart)
+}
+
+// GetScreenshotsForSession returns paginated screenshots for a session.
+func (a *App) GetScreenshotsForSession(sessionID int64, page, perPage int) (*service.ScreenshotPage, error) {
+	if a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetScreenshotsForSession(sessionID, page, perPage)
+}
+
+// GetScreenshotsForHour returns screenshots for a specific hour.
+func (a *App) GetScreenshotsForHour(date string, hour int) ([]*service.ScreenshotDisplay, error) {
+	if a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetScreenshotsForHour(date, hour)
+}
+
+// SearchAllDataSources searches across all event types (git, shell, files, browser, screenshots).
+func (a *App) SearchAllDataSources(query string, maxResults int) ([]*service.SearchResult, error) {
+	if a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.SearchAllDataSources(query, maxResults)
+}
+
+// GetScreenshotsForDate returns all screenshots for a specific date.
+func (a *App) GetScreenshotsForDate(date string) ([]*service.ScreenshotDisplay, error) {
+	if a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetScreenshotsForDate(date)
+}
+
+// GetSessionContext returns all context for a session.
+func (a *App) GetSessionContext(sessionID int64) (*service.SessionContext, error) {
+	// Return empty context for not-ready state or invalid session ID
+	if a == nil || !a.ready || a.Timeline == nil || sessionID <= 0 {
+		return &service.SessionContext{}, nil
+	}
+	return a.Timeline.GetSessionContext(sessionID)
+}
+
+// GetRecentSessions returns the most recent sessions.
+func (a *App) GetRecentSessions(limit int) (result []*storage.Session, err error) {
+	defer func() {
+		if r := recover(); r != nil {
 			result = nil
 			err = fmt.Errorf("internal error: %v", r)
 		}
@@ -707,353 +1262,1247 @@ func (a *App) GetRecentSessions(limit int) (result []*storage.Session, err error
 	if a == nil || !a.ready || a.Timeline == nil {
 		return nil, nil
 	}
-	return a.Timeline.GetRecentSessions(limit)
+	return a.Timeline.GetRecentSessions(limit)
+}
+
+// GetActivityHeatmapForYear returns per-day activity for a GitHub-style contribution graph.
+func (a *App) GetActivityHeatmapForYear(year int) (result *service.YearlyHeatmap, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetActivityHeatmapForYear(year)
+}
+
+// GetDeepWorkBlocks returns deep-work blocks for a date lasting at least minMinutes (0 = default of 30).
+func (a *App) GetDeepWorkBlocks(date string, minMinutes int) (result []*service.DeepWorkBlock, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetDeepWorkBlocks(date, minMinutes)
+}
+
+// GetAFKSummary returns total away time, the longest AFK period and breaks for a date.
+func (a *App) GetAFKSummary(date string) (result *service.AFKSummary, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetAFKSummary(date)
+}
+
+// GetCodeEditingStats returns file change statistics for project directories on a date.
+func (a *App) GetCodeEditingStats(date string) (result *service.CodeEditingStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetCodeEditingStats(date)
+}
+
+// GetCodeEditingTrend returns daily code editing totals for the last numDays days, for sparklines.
+func (a *App) GetCodeEditingTrend(numDays int) (result []*service.DailyCodeStats, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetCodeEditingTrend(numDays)
+}
+
+// GetFocusFlowData returns the app switches made on a date, for a Sankey diagram.
+func (a *App) GetFocusFlowData(date string) (result *service.FocusFlowData, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetFocusFlowData(date)
+}
+
+// GetHourlyAppTransitions returns the app switches made during an hour of a date.
+func (a *App) GetHourlyAppTransitions(date string, hour int) (result []service.AppTransition, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetHourlyAppTransitions(date, hour)
+}
+
+// GetActivityAtTime returns a snapshot of what was happening at hour:minute on a date (for the timeline scrubber).
+func (a *App) GetActivityAtTime(date string, hour, minute int) (result *service.ActivitySnapshot, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetActivitySummaryForTimeOfDay(date, hour, minute)
+}
+
+// GetSessionsByTag returns sessions in a time range whose summary has the given tag.
+func (a *App) GetSessionsByTag(tag string, start, end int64) ([]*storage.Session, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetSessionsByTag(tag, start, end)
+}
+
+// GetSessionsByTags returns sessions in a time range matching any or all of the given tags.
+func (a *App) GetSessionsByTags(tags []string, operator string, start, end int64) ([]*storage.Session, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetSessionsByTags(tags, operator, start, end)
+}
+
+// GetSessionsByFocusPattern returns sessions in a time range with a focus event in appName
+// (empty for any app) whose window title matches windowPattern.
+func (a *App) GetSessionsByFocusPattern(appName, windowPattern string, start, end int64) ([]*storage.Session, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetSessionsByFocusPattern(appName, windowPattern, start, end)
+}
+
+// GetSessionsContaining returns sessions in a time range whose window titles, commit messages
+// or shell commands contain query.
+func (a *App) GetSessionsContaining(query string, start, end int64) ([]*storage.Session, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetSessionsContaining(query, start, end)
+}
+
+// GetTagTimeline returns per-day time and session counts for a tag in a time range.
+func (a *App) GetTagTimeline(tag string, start, end int64) (result []*service.TagTimelineEntry, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Timeline == nil {
+		return nil, nil
+	}
+	return a.Timeline.GetTagTimeline(tag, start, end)
+}
+
+// DeleteSession deletes a session and all its related data.
+func (a *App) DeleteSession(sessionID int64) error {
+	if a.store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	sess, err := a.store.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := a.store.DeleteSession(sessionID); err != nil {
+		return err
+	}
+	if sess != nil && a.Goals != nil {
+		a.Goals.InvalidateStreaks(sess.StartTime)
+	}
+	return nil
+}
+
+// DeleteShortSessions deletes ended sessions shorter than minDurationSeconds that recorded
+// nothing but focus events. Returns the number of sessions deleted.
+func (a *App) DeleteShortSessions(minDurationSeconds int64) (int64, error) {
+	if a.store == nil {
+		return 0, fmt.Errorf("database not initialized")
+	}
+	deleted, err := a.store.DeleteShortSessions(minDurationSeconds)
+	if deleted > 0 && a.Goals != nil {
+		a.Goals.InvalidateStreaks(0)
+	}
+	return deleted, err
+}
+
+// MergeSessionsManually combines sessions that were wrongly split, e.g. by system sleep,
+// into the earliest-starting one, which is returned. All events and screenshots move to it
+// and the other sessions are deleted.
+func (a *App) MergeSessionsManually(sessionIDs []int64) (*storage.Session, error) {
+	if a.store == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	if len(sessionIDs) < 2 {
+		return nil, fmt.Errorf("at least two sessions are required to merge")
+	}
+
+	var primary *storage.Session
+	for _, id := range sessionIDs {
+		sess, err := a.store.GetSession(id)
+		if err != nil {
+			return nil, err
+		}
+		if sess == nil {
+			return nil, fmt.Errorf("session not found: %d", id)
+		}
+		if primary == nil || sess.StartTime < primary.StartTime {
+			primary = sess
+		}
+	}
+
+	var secondaryIDs []int64
+	for _, id := range sessionIDs {
+		if id != primary.ID {
+			secondaryIDs = append(secondaryIDs, id)
+		}
+	}
+	if err := a.store.MergeSessions(primary.ID, secondaryIDs); err != nil {
+		return nil, err
+	}
+	if a.Goals != nil {
+		a.Goals.InvalidateStreaks(primary.StartTime)
+	}
+	return a.store.GetSession(primary.ID)
+}
+
+// PinEvent pins a timeline event with an optional note. Pinning an already pinned event updates its note.
+// eventType is one of: focus (or activity), screenshot, git, shell, browser, file.
+func (a *App) PinEvent(eventType, note string, eventID int64) error {
+	if a.store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.store.PinEvent(eventType, eventID, note)
+}
+
+// UnpinEvent removes a pin from a timeline event.
+func (a *App) UnpinEvent(eventType string, eventID int64) error {
+	if a.store == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return a.store.UnpinEvent(eventType, eventID)
+}
+
+// GetPinnedEvents returns pinned events that happened within a time range.
+func (a *App) GetPinnedEvents(start, end int64) ([]*storage.PinnedEvent, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetPinnedEvents(start, end)
+}
+
+// ============================================================================
+// Screenshot Methods (exposed to frontend)
+// ============================================================================
+
+// GetScreenshot returns screenshot metadata by ID.
+func (a *App) GetScreenshot(id int64) (*storage.Screenshot, error) {
+	if a.Screenshots == nil {
+		return nil, nil
+	}
+	return a.Screenshots.GetScreenshot(id)
+}
+
+// GetScreenshotInfo returns detailed info about a screenshot.
+func (a *App) GetScreenshotInfo(id int64) (*service.ScreenshotInfo, error) {
+	if a.Screenshots == nil {
+		return nil, nil
+	}
+	return a.Screenshots.GetScreenshotInfo(id)
+}
+
+// GetScreenshotPath returns the filesystem path to a screenshot.
+func (a *App) GetScreenshotPath(id int64) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = ""
+			err = nil
+		}
+	}()
+	if a == nil || !a.ready || a.Screenshots == nil {
+		return "", nil
+	}
+	return a.Screenshots.GetScreenshotPath(id)
+}
+
+// GetThumbnailPath returns the filesystem path to a thumbnail.
+func (a *App) GetThumbnailPath(id int64) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = ""
+			err = nil
+		}
+	}()
+	if a == nil || !a.ready || a.Screenshots == nil {
+		return "", nil
+	}
+	return a.Screenshots.GetThumbnailPath(id)
+}
+
+// DeleteScreenshot deletes a screenshot and its files.
+func (a *App) DeleteScreenshot(id int64) error {
+	if a.Screenshots == nil {
+		return nil
+	}
+	return a.Screenshots.DeleteScreenshot(id)
+}
+
+// BlurScreenshot blurs regions of a screenshot for privacy. This overwrites the image.
+func (a *App) BlurScreenshot(screenshotID int64, regions []service.BlurRegion) error {
+	if a.Screenshots == nil {
+		return fmt.Errorf("screenshot service not initialized")
+	}
+	return a.Screenshots.BlurScreenshot(screenshotID, regions)
+}
+
+// BlurAllScreenshotsForApp blurs every screenshot in a time range taken while an app was
+// in the foreground. Returns the number of screenshots blurred.
+func (a *App) BlurAllScreenshotsForApp(appName string, start, end int64) (int, error) {
+	if a.Screenshots == nil {
+		return 0, fmt.Errorf("screenshot service not initialized")
+	}
+	return a.Screenshots.BlurAllScreenshotsForApp(appName, start, end)
+}
+
+// AnnotateScreenshot adds a text label to a screenshot.
+func (a *App) AnnotateScreenshot(screenshotID int64, label string) error {
+	if a.Screenshots == nil {
+		return fmt.Errorf("screenshot service not initialized")
+	}
+	return a.Screenshots.AnnotateScreenshot(screenshotID, label)
+}
+
+// GetScreenshotAnnotations returns the text labels on a screenshot.
+func (a *App) GetScreenshotAnnotations(screenshotID int64) ([]string, error) {
+	if a.Screenshots == nil {
+		return nil, nil
+	}
+	return a.Screenshots.GetScreenshotAnnotations(screenshotID)
+}
+
+// DeleteScreenshotAnnotation removes a text label from a screenshot.
+func (a *App) DeleteScreenshotAnnotation(screenshotID int64, label string) error {
+	if a.Screenshots == nil {
+		return fmt.Errorf("screenshot service not initialized")
+	}
+	return a.Screenshots.DeleteScreenshotAnnotation(screenshotID, label)
+}
+
+// SearchScreenshotsByAnnotation finds screenshots in a time range carrying a label.
+func (a *App) SearchScreenshotsByAnnotation(label string, start, end int64) ([]*service.ScreenshotSearchResult, error) {
+	if a.Screenshots == nil {
+		return nil, nil
+	}
+	return a.Screenshots.SearchScreenshotsByAnnotation(label, start, end)
+}
+
+// GetScreenshotStrip returns one screenshot per intervalMinutes block of an hour on a date
+// (YYYY-MM-DD), for the thumbnail filmstrip. Blocks without screenshots are placeholders.
+func (a *App) GetScreenshotStrip(date string, hour int, intervalMinutes int) ([]*service.ScreenshotDisplay, error) {
+	if a.Screenshots == nil {
+		return nil, nil
+	}
+	return a.Screenshots.GetScreenshotStrip(date, hour, intervalMinutes)
+}
+
+// ============================================================================
+// Focus Event Methods (exposed to frontend)
+// ============================================================================
+
+// GetFocusEventByID retrieves a single focus event by ID.
+func (a *App) GetFocusEventByID(id int64) (*storage.WindowFocusEvent, error) {
+	if a.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return a.store.GetFocusEventByID(id)
+}
+
+// UpdateFocusEvent updates editable fields of a window focus event.
+func (a *App) UpdateFocusEvent(id int64, windowTitle, appName string, startTime, endTime int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.UpdateFocusEvent(id, windowTitle, appName, startTime, endTime)
+}
+
+// DeleteFocusEvent removes a single focus event.
+func (a *App) DeleteFocusEvent(id int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteFocusEvent(id)
+}
+
+// DeleteFocusEvents removes multiple focus events (bulk delete).
+func (a *App) DeleteFocusEvents(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteFocusEvents(ids)
+}
+
+// DeleteBrowserVisits removes multiple browser visits (bulk delete).
+func (a *App) DeleteBrowserVisits(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteBrowserVisits(ids)
+}
+
+// DeleteGitCommits removes multiple git commits (bulk delete).
+func (a *App) DeleteGitCommits(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteGitCommits(ids)
+}
+
+// DeleteShellCommands removes multiple shell commands (bulk delete).
+func (a *App) DeleteShellCommands(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteShellCommands(ids)
+}
+
+// DeleteFileEvents removes multiple file events (bulk delete).
+func (a *App) DeleteFileEvents(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteFileEvents(ids)
+}
+
+// DeleteAFKEvents removes multiple AFK events (bulk delete).
+func (a *App) DeleteAFKEvents(ids []int64) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	return a.store.DeleteAFKEvents(ids)
+}
+
+// ============================================================================
+// Reports Methods (exposed to frontend)
+// ============================================================================
+
+// GenerateReport generates a new report for the given time range.
+func (a *App) GenerateReport(timeRange, reportType string, includeScreenshots bool) (*service.Report, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GenerateReport(timeRange, reportType, includeScreenshots)
+}
+
+// GenerateDiffReport generates a report comparing two time ranges side by side.
+func (a *App) GenerateDiffReport(range1, range2 string) (*service.Report, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GenerateDiffReport(range1, range2)
+}
+
+// GetCommitQualityReport scores the user's commit messages in a time range.
+func (a *App) GetCommitQualityReport(start, end int64) (*service.CommitQualityReport, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetCommitQualityReport(start, end)
+}
+
+// SetReportGoals replaces the goals that reports show progress against, e.g.
+// {"daily_active_minutes": 360, "weekly_commits": 20}.
+func (a *App) SetReportGoals(goals map[string]int64) error {
+	if a.Reports == nil {
+		return nil
+	}
+	return a.Reports.SetReportGoals(goals)
+}
+
+// GetReportGoals returns the report goal targets by key.
+func (a *App) GetReportGoals() (map[string]int64, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReportGoals()
+}
+
+// GetGoalProgressForRange returns progress towards the report goals between start and end.
+func (a *App) GetGoalProgressForRange(start, end int64) ([]*service.GoalProgress, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetGoalProgressForRange(start, end)
+}
+
+// GenerateProjectReport generates a report for a specific project.
+// If projectID is 0, generates a report for all activities.
+func (a *App) GenerateProjectReport(timeRange, reportType string, includeScreenshots bool, projectID int64) (*service.Report, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GenerateReportWithFilter(timeRange, reportType, includeScreenshots, projectID)
+}
+
+// GetReport returns a report by ID with full content.
+func (a *App) GetReport(id int64) (*service.Report, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReport(id)
+}
+
+// ExportReport exports a report in the specified format.
+func (a *App) ExportReport(reportID int64, format string) (string, error) {
+	if a.Reports == nil {
+		return "", nil
+	}
+	return a.Reports.ExportReport(reportID, format)
+}
+
+// DeleteReport deletes a report by ID.
+func (a *App) DeleteReport(reportID int64) error {
+	if a.Reports == nil {
+		return nil
+	}
+	return a.Reports.DeleteReport(reportID)
+}
+
+// GetReportTemplates returns all report templates.
+func (a *App) GetReportTemplates() ([]*storage.ReportTemplate, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReportTemplates()
+}
+
+// SaveReportTemplate saves a custom template for the summary or standup report.
+func (a *App) SaveReportTemplate(name, reportType, templateHTML, templateMarkdown string) (int64, error) {
+	if a.Reports == nil {
+		return 0, nil
+	}
+	return a.Reports.SaveReportTemplate(name, reportType, templateHTML, templateMarkdown)
+}
+
+// DeleteReportTemplate deletes a report template.
+func (a *App) DeleteReportTemplate(id int64) error {
+	if a.Reports == nil {
+		return nil
+	}
+	return a.Reports.DeleteReportTemplate(id)
+}
+
+// SetDefaultTemplate makes a template the default for its report type.
+func (a *App) SetDefaultTemplate(id int64) error {
+	if a.Reports == nil {
+		return nil
+	}
+	return a.Reports.SetDefaultTemplate(id)
+}
+
+// AddReportSchedule saves a schedule that generates a report on a cron expression
+// ("@daily", "@weekly", "@monthly" or 5-field cron) and posts it to webhook recipients.
+func (a *App) AddReportSchedule(schedule storage.ScheduledReport) (int64, error) {
+	if a.reportScheduler == nil {
+		return 0, fmt.Errorf("report scheduler not initialized")
+	}
+	return a.reportScheduler.AddSchedule(schedule)
+}
+
+// RemoveReportSchedule deletes a report schedule.
+func (a *App) RemoveReportSchedule(id int64) error {
+	if a.reportScheduler == nil {
+		return fmt.Errorf("report scheduler not initialized")
+	}
+	return a.reportScheduler.RemoveSchedule(id)
+}
+
+// GetReportSchedules returns all report schedules.
+func (a *App) GetReportSchedules() ([]*storage.ScheduledReport, error) {
+	if a.reportScheduler == nil {
+		return nil, nil
+	}
+	return a.reportScheduler.GetSchedules()
+}
+
+// GetReportHistory returns past generated reports.
+func (a *App) GetReportHistory() ([]*service.ReportMeta, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReportHistory()
+}
+
+// GetReportTimeline returns the reports created in a time range with the key metrics of
+// each, oldest first.
+func (a *App) GetReportTimeline(start, end int64) (*service.ReportTimeline, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReportTimeline(start, end)
+}
+
+// GetReportTimelineHTML renders the report timeline of a time range as a printable HTML page.
+func (a *App) GetReportTimelineHTML(start, end int64) (string, error) {
+	if a.Reports == nil {
+		return "", fmt.Errorf("reports service not initialized")
+	}
+	return a.Reports.GetReportTimelineHTML(start, end)
+}
+
+// GetReportVersions returns all versions of a report, oldest first.
+// reportID may be the original report or any of its versions.
+func (a *App) GetReportVersions(reportID int64) ([]*service.ReportMeta, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetReportVersions(reportID)
+}
+
+// RestoreReportVersion makes an older report version current by copying it into a new version.
+func (a *App) RestoreReportVersion(versionID int64) error {
+	if a.Reports == nil {
+		return fmt.Errorf("reports service not initialized")
+	}
+	_, err := a.Reports.RestoreReportVersion(versionID)
+	return err
+}
+
+// GetCommitActivity returns a GitHub-style commit heatmap for the last number of weeks.
+// If repoID is 0, commits from all repositories are included.
+func (a *App) GetCommitActivity(repoID int64, weeks int) (*service.CommitHeatmap, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetCommitActivity(repoID, weeks)
+}
+
+// GetDailySummaries returns auto-generated daily summary reports.
+func (a *App) GetDailySummaries(limit int) ([]*service.DailySummary, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.GetDailySummaries(limit)
+}
+
+// ExportObsidianNote writes the Obsidian daily note for a date (YYYY-MM-DD) to the configured
+// vault and returns the file path.
+func (a *App) ExportObsidianNote(date string) (string, error) {
+	if a.Obsidian == nil || a.Config == nil {
+		return "", fmt.Errorf("obsidian exporter not initialized")
+	}
+	return a.Obsidian.ExportDailyNote(date, a.Config.GetObsidianVaultPath())
+}
+
+// ParseTimeRange parses natural language time input.
+func (a *App) ParseTimeRange(input string) (*service.TimeRange, error) {
+	if a.Reports == nil {
+		return nil, nil
+	}
+	return a.Reports.ParseTimeRange(input)
+}
+
+// GenerateWeeklySummaryMarkdown generates a comprehensive weekly summary in Markdown format.
+func (a *App) GenerateWeeklySummaryMarkdown(startDate, endDate string) (string, error) {
+	if a.Reports == nil {
+		return "", nil
+	}
+	return a.Reports.GenerateWeeklySummaryMarkdown(startDate, endDate)
+}
+
+// ============================================================================
+// Summary Methods (exposed to frontend)
+// ============================================================================
+
+// GenerateSummary generates an AI summary for a session.
+func (a *App) GenerateSummary(sessionID int64) (*storage.Summary, error) {
+	if a.Summary == nil {
+		return nil, fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.GenerateSummary(sessionID)
+}
+
+// RegenerateSummary regenerates an AI summary for a session.
+func (a *App) RegenerateSummary(sessionID int64) (*storage.Summary, error) {
+	if a.Summary == nil {
+		return nil, fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.RegenerateSummary(sessionID)
+}
+
+// BatchGenerateSummaries generates summaries for up to maxSessions ended sessions without one.
+// Progress is emitted as "summary:batch:progress" events with done and total counts.
+func (a *App) BatchGenerateSummaries(maxSessions int) error {
+	if a.Summary == nil {
+		return fmt.Errorf("summary service not initialized")
+	}
+	_, err := a.Summary.GenerateBatchSummaries(maxSessions, func(done, total int) {
+		wailsRuntime.EventsEmit(a.ctx, "summary:batch:progress", map[string]interface{}{
+			"done":  done,
+			"total": total,
+		})
+	})
+	return err
+}
+
+// DeleteSummary deletes an AI summary.
+func (a *App) DeleteSummary(summaryID int64) error {
+	if !a.ready {
+		return errors.New("app not ready")
+	}
+	return a.store.DeleteSummary(summaryID)
+}
+
+// GetSummary retrieves a summary by ID.
+func (a *App) GetSummary(summaryID int64) (*storage.Summary, error) {
+	if a.Summary == nil {
+		return nil, nil
+	}
+	return a.Summary.GetSummary(summaryID)
+}
+
+// GetSummaryBySession retrieves a summary by session ID.
+func (a *App) GetSummaryBySession(sessionID int64) (*storage.Summary, error) {
+	if a.Summary == nil {
+		return nil, nil
+	}
+	return a.Summary.GetSummaryBySession(sessionID)
+}
+
+// GetSummaryPrompts returns the session, daily and weekly summary prompts with their
+// current templates and the template variables each supports.
+func (a *App) GetSummaryPrompts() []*service.SummaryPrompt {
+	if a.Summary == nil {
+		return nil
+	}
+	return a.Summary.GetPrompts()
+}
+
+// GetDefaultSummaryPrompt returns the built-in template for a summary prompt type.
+func (a *App) GetDefaultSummaryPrompt(promptType string) string {
+	if a.Summary == nil {
+		return ""
+	}
+	return a.Summary.GetDefaultPrompt(promptType)
+}
+
+// SetCustomSummaryPrompt replaces the template sent to the AI for a summary prompt type.
+func (a *App) SetCustomSummaryPrompt(promptType, template string) error {
+	if a.Summary == nil {
+		return fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.SetCustomPrompt(promptType, template)
+}
+
+// ResetSummaryPromptToDefault removes the custom template for a summary prompt type.
+func (a *App) ResetSummaryPromptToDefault(promptType string) error {
+	if a.Summary == nil {
+		return fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.ResetPromptToDefault(promptType)
+}
+
+// ============================================================================
+// Draft Methods (exposed to frontend)
+// ============================================================================
+
+// AcceptSummaryDraft marks a summary draft as accepted.
+func (a *App) AcceptSummaryDraft(summaryID int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
+	}
+	return a.Draft.AcceptSummaryDraft(summaryID)
+}
+
+// RejectSummaryDraft marks a summary draft as rejected.
+func (a *App) RejectSummaryDraft(summaryID int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
+	}
+	return a.Draft.RejectSummaryDraft(summaryID)
+}
+
+// AcceptAssignmentDraft marks a project assignment draft as accepted.
+func (a *App) AcceptAssignmentDraft(activityID int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
+	}
+	return a.Draft.AcceptAssignmentDraft(activityID)
+}
+
+// RejectAssignmentDraft marks a project assignment draft as rejected.
+func (a *App) RejectAssignmentDraft(activityID int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
+	}
+	return a.Draft.RejectAssignmentDraft(activityID)
 }
 
-// DeleteSession deletes a session and all its related data.
-func (a *App) DeleteSession(sessionID int64) error {
-	if a.store == nil {
-		return fmt.Errorf("database not initialized")
+// BulkAcceptDrafts accepts multiple drafts at once.
+func (a *App) BulkAcceptDrafts(summaryIDs, assignmentIDs []int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
+	}
+	return a.Draft.BulkAcceptDrafts(summaryIDs, assignmentIDs)
+}
+
+// BulkAcceptDraftsBySession accepts drafts using session IDs and activity IDs.
+// Session IDs are resolved to their corresponding summary IDs internally.
+func (a *App) BulkAcceptDraftsBySession(sessionIDs, activityIDs []int64) error {
+	if a.Draft == nil {
+		return fmt.Errorf("draft service not initialized")
 	}
-	return a.store.DeleteSession(sessionID)
+	return a.Draft.BulkAcceptDraftsBySession(sessionIDs, activityIDs)
 }
 
 // ============================================================================
-// Screenshot Methods (exposed to frontend)
+// Goal Methods (exposed to frontend)
 // ============================================================================
 
-// GetScreenshot returns screenshot metadata by ID.
-func (a *App) GetScreenshot(id int64) (*storage.Screenshot, error) {
-	if a.Screenshots == nil {
-		return nil, nil
+// SetGoal sets the target for a goal type ("active_minutes") and period ("daily" or "weekly").
+// Any previously active goal of the same type and period is replaced.
+func (a *App) SetGoal(goalType string, targetValue int64, period string) (*storage.Goal, error) {
+	if a.Goals == nil {
+		return nil, fmt.Errorf("goals service not initialized")
 	}
-	return a.Screenshots.GetScreenshot(id)
+	return a.Goals.SetGoal(goalType, targetValue, period)
 }
 
-// GetScreenshotInfo returns detailed info about a screenshot.
-func (a *App) GetScreenshotInfo(id int64) (*service.ScreenshotInfo, error) {
-	if a.Screenshots == nil {
+// GetGoal returns a goal by ID.
+func (a *App) GetGoal(goalID int64) (*storage.Goal, error) {
+	if a.Goals == nil {
 		return nil, nil
 	}
-	return a.Screenshots.GetScreenshotInfo(id)
+	return a.Goals.GetGoal(goalID)
 }
 
-// GetScreenshotPath returns the filesystem path to a screenshot.
-func (a *App) GetScreenshotPath(id int64) (result string, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = ""
-			err = nil
-		}
-	}()
-	if a == nil || !a.ready || a.Screenshots == nil {
-		return "", nil
+// GetActiveGoal returns the active goal of a type and period, or nil if none is set.
+func (a *App) GetActiveGoal(goalType, period string) (*storage.Goal, error) {
+	if a.Goals == nil {
+		return nil, nil
 	}
-	return a.Screenshots.GetScreenshotPath(id)
+	return a.Goals.GetActiveGoal(goalType, period)
 }
 
-// GetThumbnailPath returns the filesystem path to a thumbnail.
-func (a *App) GetThumbnailPath(id int64) (result string, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = ""
-			err = nil
-		}
-	}()
-	if a == nil || !a.ready || a.Screenshots == nil {
-		return "", nil
+// GetGoalProgress returns progress towards a goal on a date (YYYY-MM-DD).
+func (a *App) GetGoalProgress(goalID int64, date string) (*service.GoalProgress, error) {
+	if a.Goals == nil {
+		return nil, nil
 	}
-	return a.Screenshots.GetThumbnailPath(id)
+	return a.Goals.GetGoalProgress(goalID, date)
 }
 
-// DeleteScreenshot deletes a screenshot and its files.
-func (a *App) DeleteScreenshot(id int64) error {
-	if a.Screenshots == nil {
-		return nil
+// GetGoalAchievements returns the goal achievements recorded in a month (1-12).
+func (a *App) GetGoalAchievements(year, month int) ([]*storage.GoalAchievement, error) {
+	if a.Goals == nil {
+		return nil, nil
 	}
-	return a.Screenshots.DeleteScreenshot(id)
+	return a.Goals.GetGoalAchievements(year, month)
 }
 
-// ============================================================================
-// Focus Event Methods (exposed to frontend)
-// ============================================================================
-
-// GetFocusEventByID retrieves a single focus event by ID.
-func (a *App) GetFocusEventByID(id int64) (*storage.WindowFocusEvent, error) {
-	if a.store == nil {
-		return nil, fmt.Errorf("store not initialized")
+// CreateTaskThread creates an open task thread for grouping sessions under a long-running task.
+func (a *App) CreateTaskThread(name string) (int64, error) {
+	if a.TaskThreads == nil {
+		return 0, fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.GetFocusEventByID(id)
+	return a.TaskThreads.CreateThread(name)
 }
 
-// UpdateFocusEvent updates editable fields of a window focus event.
-func (a *App) UpdateFocusEvent(id int64, windowTitle, appName string, startTime, endTime int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// RenameTaskThread changes a task thread's name.
+func (a *App) RenameTaskThread(threadID int64, name string) error {
+	if a.TaskThreads == nil {
+		return fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.UpdateFocusEvent(id, windowTitle, appName, startTime, endTime)
+	return a.TaskThreads.RenameThread(threadID, name)
 }
 
-// DeleteFocusEvent removes a single focus event.
-func (a *App) DeleteFocusEvent(id int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// GetTaskThreads returns task threads with their time totals, newest first.
+func (a *App) GetTaskThreads(includeClosed bool) ([]*service.TaskThread, error) {
+	if a.TaskThreads == nil {
+		return nil, nil
 	}
-	return a.store.DeleteFocusEvent(id)
+	return a.TaskThreads.GetThreads(includeClosed)
 }
 
-// DeleteFocusEvents removes multiple focus events (bulk delete).
-func (a *App) DeleteFocusEvents(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// GetTaskThreadTimeline returns a task thread with its sessions in chronological order.
+func (a *App) GetTaskThreadTimeline(threadID int64) (*service.TaskThread, error) {
+	if a.TaskThreads == nil {
+		return nil, nil
 	}
-	return a.store.DeleteFocusEvents(ids)
+	return a.TaskThreads.GetThreadTimeline(threadID)
 }
 
-// DeleteBrowserVisits removes multiple browser visits (bulk delete).
-func (a *App) DeleteBrowserVisits(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// AttachSessionToTaskThread adds a session to an open task thread.
+func (a *App) AttachSessionToTaskThread(threadID, sessionID int64) error {
+	if a.TaskThreads == nil {
+		return fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.DeleteBrowserVisits(ids)
+	return a.TaskThreads.AttachSession(threadID, sessionID)
 }
 
-// DeleteGitCommits removes multiple git commits (bulk delete).
-func (a *App) DeleteGitCommits(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// DetachSessionFromTaskThread removes a session from a task thread.
+func (a *App) DetachSessionFromTaskThread(threadID, sessionID int64) error {
+	if a.TaskThreads == nil {
+		return fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.DeleteGitCommits(ids)
+	return a.TaskThreads.DetachSession(threadID, sessionID)
 }
 
-// DeleteShellCommands removes multiple shell commands (bulk delete).
-func (a *App) DeleteShellCommands(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// CloseTaskThread marks a task thread as done.
+func (a *App) CloseTaskThread(threadID int64) error {
+	if a.TaskThreads == nil {
+		return fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.DeleteShellCommands(ids)
+	return a.TaskThreads.CloseThread(threadID)
 }
 
-// DeleteFileEvents removes multiple file events (bulk delete).
-func (a *App) DeleteFileEvents(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// DeleteTaskThread deletes a task thread, leaving its sessions in place.
+func (a *App) DeleteTaskThread(threadID int64) error {
+	if a.TaskThreads == nil {
+		return fmt.Errorf("task thread service not initialized")
 	}
-	return a.store.DeleteFileEvents(ids)
+	return a.TaskThreads.DeleteThread(threadID)
 }
 
-// DeleteAFKEvents removes multiple AFK events (bulk delete).
-func (a *App) DeleteAFKEvents(ids []int64) error {
-	if a.store == nil {
-		return fmt.Errorf("store not initialized")
+// watchProfiles reports the profile list and active profile now and after every profile switch.
+func (a *App) watchProfiles(update func(profiles []*storage.Profile, active *storage.Profile)) {
+	if a.Config == nil {
+		return
 	}
-	return a.store.DeleteAFKEvents(ids)
-}
 
-// ============================================================================
-// Reports Methods (exposed to frontend)
-// ============================================================================
+	notify := func(active *storage.Profile) {
+		profiles, err := a.Config.GetProfiles()
+		if err != nil {
+			log.Printf("Failed to load profiles: %v", err)
+			return
+		}
+		update(profiles, active)
+	}
+	a.Config.SetProfileSwitchHandler(notify)
 
-// GenerateReport generates a new report for the given time range.
-func (a *App) GenerateReport(timeRange, reportType string, includeScreenshots bool) (*service.Report, error) {
-	if a.Reports == nil {
-		return nil, nil
+	active, err := a.Config.GetCurrentProfile()
+	if err != nil {
+		log.Printf("Failed to load active profile: %v", err)
 	}
-	return a.Reports.GenerateReport(timeRange, reportType, includeScreenshots)
+	notify(active)
 }
 
-// GenerateProjectReport generates a report for a specific project.
-// If projectID is 0, generates a report for all activities.
-func (a *App) GenerateProjectReport(timeRange, reportType string, includeScreenshots bool, projectID int64) (*service.Report, error) {
-	if a.Reports == nil {
-		return nil, nil
+// watchGoalProgress reports today's progress towards the daily active-minutes goal
+// after every daemon tick. update receives nil when no goal is set.
+func (a *App) watchGoalProgress(update func(progress *service.GoalProgress)) {
+	if a.daemon == nil || a.Goals == nil {
+		return
 	}
-	return a.Reports.GenerateReportWithFilter(timeRange, reportType, includeScreenshots, projectID)
+
+	a.daemon.SetOnTick(func() {
+		goal, err := a.Goals.GetActiveGoal(service.GoalTypeActiveMinutes, "daily")
+		if err != nil {
+			log.Printf("Failed to load daily goal: %v", err)
+			return
+		}
+		if goal == nil {
+			update(nil)
+			return
+		}
+
+		progress, err := a.Goals.GetGoalProgress(goal.ID, time.Now().Format("2006-01-02"))
+		if err != nil {
+			log.Printf("Failed to compute goal progress: %v", err)
+			return
+		}
+		update(progress)
+	})
 }
 
-// GetReport returns a report by ID with full content.
-func (a *App) GetReport(id int64) (*service.Report, error) {
-	if a.Reports == nil {
-		return nil, nil
+// productivityCheckInterval is how often watchProductivity recalculates today's score.
+const productivityCheckInterval = 15 * time.Minute
+
+// watchProductivity reports today's productive percentage (0-100) now and every
+// productivityCheckInterval until shutdown. update receives tray.NoProductivityData
+// while nothing has been tracked today.
+func (a *App) watchProductivity(update func(score int)) {
+	if a.Analytics == nil {
+		return
 	}
-	return a.Reports.GetReport(id)
+
+	check := func() {
+		score, err := a.Analytics.GetProductivityScore(time.Now().Format("2006-01-02"))
+		if err != nil {
+			log.Printf("Failed to compute productivity score: %v", err)
+			return
+		}
+		if score.TotalMinutes == 0 {
+			update(tray.NoProductivityData)
+			return
+		}
+		update(int(math.Round(score.ProductivePercentage)))
+	}
+
+	a.productivityStop = make(chan struct{})
+	go func(stop <-chan struct{}) {
+		check()
+		ticker := time.NewTicker(productivityCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				check()
+			case <-stop:
+				return
+			}
+		}
+	}(a.productivityStop)
 }
 
-// ExportReport exports a report in the specified format.
-func (a *App) ExportReport(reportID int64, format string) (string, error) {
-	if a.Reports == nil {
-		return "", nil
+// ============================================================================
+// Config Methods (exposed to frontend)
+// ============================================================================
+
+// GetConfig returns the current configuration.
+func (a *App) GetConfig() (result *service.Config, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = nil
+			err = fmt.Errorf("internal error: %v", r)
+		}
+	}()
+	if a == nil || !a.ready || a.Config == nil {
+		// Return minimal default config to avoid JSON parse errors
+		// (nil can't be serialized by Wails)
+		return &service.Config{
+			UI: &service.UIConfig{
+				Theme: "system",
+			},
+		}, nil
 	}
-	return a.Reports.ExportReport(reportID, format)
+	return a.Config.GetConfig()
 }
 
-// DeleteReport deletes a report by ID.
-func (a *App) DeleteReport(reportID int64) error {
-	if a.Reports == nil {
+// UpdateConfig updates configuration values.
+func (a *App) UpdateConfig(updates map[string]interface{}) error {
+	if a.Config == nil {
 		return nil
 	}
-	return a.Reports.DeleteReport(reportID)
+	return a.Config.UpdateConfig(updates)
 }
 
-// GetReportHistory returns past generated reports.
-func (a *App) GetReportHistory() ([]*service.ReportMeta, error) {
-	if a.Reports == nil {
-		return nil, nil
+// ExportConfig returns the full configuration as JSON, with API keys redacted.
+func (a *App) ExportConfig() (string, error) {
+	if a.Config == nil {
+		return "", fmt.Errorf("config service not initialized")
 	}
-	return a.Reports.GetReportHistory()
+	return a.Config.ExportConfig()
 }
 
-// GetDailySummaries returns auto-generated daily summary reports.
-func (a *App) GetDailySummaries(limit int) ([]*service.DailySummary, error) {
-	if a.Reports == nil {
-		return nil, nil
+// ImportConfig loads configuration exported by ExportConfig.
+// Mode is "merge" (only update fields in the JSON) or "replace" (reset everything else to defaults).
+func (a *App) ImportConfig(data string, mode string) error {
+	if a.Config == nil {
+		return fmt.Errorf("config service not initialized")
 	}
-	return a.Reports.GetDailySummaries(limit)
+	return a.Config.ImportConfig(data, mode)
 }
 
-// ParseTimeRange parses natural language time input.
-func (a *App) ParseTimeRange(input string) (*service.TimeRange, error) {
-	if a.Reports == nil {
-		return nil, nil
+// GetKeyboardShortcuts returns the global hotkey bound to each action.
+func (a *App) GetKeyboardShortcuts() (map[string]string, error) {
+	if a.Config == nil {
+		return map[string]string{}, nil
 	}
-	return a.Reports.ParseTimeRange(input)
+	return a.Config.GetKeyboardShortcuts()
 }
 
-// GenerateWeeklySummaryMarkdown generates a comprehensive weekly summary in Markdown format.
-func (a *App) GenerateWeeklySummaryMarkdown(startDate, endDate string) (string, error) {
-	if a.Reports == nil {
-		return "", nil
-	}
-	return a.Reports.GenerateWeeklySummaryMarkdown(startDate, endDate)
+// HotkeysSupported reports whether global hotkeys can be registered on this platform.
+// The UI hides the keyboard shortcut settings where they can't.
+func (a *App) HotkeysSupported() bool {
+	_, ok := a.platform.(platform.HotkeyProvider)
+	return ok
 }
 
-// ============================================================================
-// Summary Methods (exposed to frontend)
-// ============================================================================
-
-// GenerateSummary generates an AI summary for a session.
-func (a *App) GenerateSummary(sessionID int64) (*storage.Summary, error) {
-	if a.Summary == nil {
-		return nil, fmt.Errorf("summary service not initialized")
+// SetKeyboardShortcut binds a global hotkey to an action and re-registers hotkeys.
+// Pass an empty shortcut to disable the action.
+func (a *App) SetKeyboardShortcut(action, shortcut string) error {
+	if a.Config == nil {
+		return fmt.Errorf("config service not initialized")
 	}
-	return a.Summary.GenerateSummary(sessionID)
+	if shortcut != "" && !a.HotkeysSupported() {
+		return fmt.Errorf("global hotkeys are not supported on this platform")
+	}
+	if err := a.Config.SetKeyboardShortcut(action, shortcut); err != nil {
+		return err
+	}
+	a.registerHotkeys()
+	return nil
 }
 
-// RegenerateSummary regenerates an AI summary for a session.
-func (a *App) RegenerateSummary(sessionID int64) (*storage.Summary, error) {
-	if a.Summary == nil {
-		return nil, fmt.Errorf("summary service not initialized")
+// GetObsidianVaultPath returns the folder Obsidian daily notes are exported to.
+func (a *App) GetObsidianVaultPath() string {
+	if a.Config == nil {
+		return ""
 	}
-	return a.Summary.RegenerateSummary(sessionID)
+	return a.Config.GetObsidianVaultPath()
 }
 
-// DeleteSummary deletes an AI summary.
-func (a *App) DeleteSummary(summaryID int64) error {
-	if !a.ready {
-		return errors.New("app not ready")
+// SetObsidianVaultPath sets the folder Obsidian daily notes are exported to.
+func (a *App) SetObsidianVaultPath(path string) error {
+	if a.Config == nil {
+		return fmt.Errorf("config service not initialized")
 	}
-	return a.store.DeleteSummary(summaryID)
+	return a.Config.SetObsidianVaultPath(path)
 }
 
-// GetSummary retrieves a summary by ID.
-func (a *App) GetSummary(summaryID int64) (*storage.Summary, error) {
-	if a.Summary == nil {
+// GetProfiles returns all settings profiles.
+func (a *App) GetProfiles() ([]*storage.Profile, error) {
+	if a.Config == nil {
 		return nil, nil
 	}
-	return a.Summary.GetSummary(summaryID)
+	return a.Config.GetProfiles()
 }
-
-// GetSummaryBySession retrieves a summary by session ID.
-func (a *App) GetSummaryBySession(sessionID int64) (*storage.Summary, error) {
-	if a.Summary == nil {
+
+// GetCurrentProfile returns the active settings profile.
+func (a *App) GetCurrentProfile() (*storage.Profile, error) {
+	if a.Config == nil {
 		return nil, nil
 	}
-	return a.Summary.GetSummaryBySession(sessionID)
+	return a.Config.GetCurrentProfile()
 }
 
-// ============================================================================
-// Draft Methods (exposed to frontend)
-// ============================================================================
-
-// AcceptSummaryDraft marks a summary draft as accepted.
-func (a *App) AcceptSummaryDraft(summaryID int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+// CreateProfile saves the current settings as a new profile.
+func (a *App) CreateProfile(name string) (int64, error) {
+	if a.Config == nil {
+		return 0, fmt.Errorf("config service not initialized")
 	}
-	return a.Draft.AcceptSummaryDraft(summaryID)
+	return a.Config.CreateProfile(name)
 }
 
-// RejectSummaryDraft marks a summary draft as rejected.
-func (a *App) RejectSummaryDraft(summaryID int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+// SwitchProfile activates a settings profile, e.g. to switch between work and personal mode.
+func (a *App) SwitchProfile(profileID int64) error {
+	if a.Config == nil {
+		return fmt.Errorf("config service not initialized")
 	}
-	return a.Draft.RejectSummaryDraft(summaryID)
+	return a.Config.SwitchProfile(profileID)
 }
 
-// AcceptAssignmentDraft marks a project assignment draft as accepted.
-func (a *App) AcceptAssignmentDraft(activityID int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+// DeleteProfile deletes an inactive settings profile.
+func (a *App) DeleteProfile(profileID int64) error {
+	if a.Config == nil {
+		return fmt.Errorf("config service not initialized")
 	}
-	return a.Draft.AcceptAssignmentDraft(activityID)
+	return a.Config.DeleteProfile(profileID)
 }
 
-// RejectAssignmentDraft marks a project assignment draft as rejected.
-func (a *App) RejectAssignmentDraft(activityID int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+// registerHotkeys (re)binds global hotkeys from the stored shortcut config. It does
+// nothing on platforms without hotkey support. Registration failures are logged rather
+// than returned since another app may already own a shortcut.
+func (a *App) registerHotkeys() {
+	hk, ok := a.platform.(platform.HotkeyProvider)
+	if !ok || a.Config == nil {
+		return
 	}
-	return a.Draft.RejectAssignmentDraft(activityID)
-}
 
-// BulkAcceptDrafts accepts multiple drafts at once.
-func (a *App) BulkAcceptDrafts(summaryIDs, assignmentIDs []int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+	shortcuts, err := a.Config.GetKeyboardShortcuts()
+	if err != nil {
+		log.Printf("Failed to load keyboard shortcuts: %v", err)
+		return
 	}
-	return a.Draft.BulkAcceptDrafts(summaryIDs, assignmentIDs)
-}
 
-// BulkAcceptDraftsBySession accepts drafts using session IDs and activity IDs.
-// Session IDs are resolved to their corresponding summary IDs internally.
-func (a *App) BulkAcceptDraftsBySession(sessionIDs, activityIDs []int64) error {
-	if a.Draft == nil {
-		return fmt.Errorf("draft service not initialized")
+	a.unregisterHotkeys()
+
+	a.hotkeyMu.Lock()
+	defer a.hotkeyMu.Unlock()
+
+	a.hotkeys = make(map[string]string)
+	for action, shortcut := range shortcuts {
+		handler := a.hotkeyHandler(action)
+		if shortcut == "" || handler == nil {
+			continue
+		}
+		if err := hk.RegisterHotkey(shortcut, handler); err != nil {
+			log.Printf("Failed to register hotkey %s for %s: %v", shortcut, action, err)
+			continue
+		}
+		a.hotkeys[action] = shortcut
 	}
-	return a.Draft.BulkAcceptDraftsBySession(sessionIDs, activityIDs)
 }
 
-// ============================================================================
-// Config Methods (exposed to frontend)
-// ============================================================================
+// unregisterHotkeys releases all global hotkeys registered by registerHotkeys.
+func (a *App) unregisterHotkeys() {
+	a.hotkeyMu.Lock()
+	defer a.hotkeyMu.Unlock()
 
-// GetConfig returns the current configuration.
-func (a *App) GetConfig() (result *service.Config, err error) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = nil
-			err = fmt.Errorf("internal error: %v", r)
+	hk, ok := a.platform.(platform.HotkeyProvider)
+	if !ok {
+		return
+	}
+	for action, shortcut := range a.hotkeys {
+		if err := hk.UnregisterHotkey(shortcut); err != nil {
+			log.Printf("Failed to unregister hotkey %s for %s: %v", shortcut, action, err)
 		}
-	}()
-	if a == nil || !a.ready || a.Config == nil {
-		// Return minimal default config to avoid JSON parse errors
-		// (nil can't be serialized by Wails)
-		return &service.Config{
-			UI: &service.UIConfig{
-				Theme: "system",
-			},
-		}, nil
 	}
-	return a.Config.GetConfig()
+	a.hotkeys = nil
 }
 
-// UpdateConfig updates configuration values.
-func (a *App) UpdateConfig(updates map[string]interface{}) error {
-	if a.Config == nil {
-		return nil
+// hotkeyHandler returns the function to run when the hotkey for an action is pressed.
+func (a *App) hotkeyHandler(action string) func() {
+	switch action {
+	case "force_capture":
+		return func() {
+			if _, err := a.ForceCapture(); err != nil {
+				log.Printf("Force capture failed: %v", err)
+			}
+		}
+	case "pause_resume":
+		return func() {
+			if status, err := a.GetDaemonStatus(); err == nil && status.Paused {
+				a.ResumeCapture()
+			} else {
+				a.PauseCapture()
+			}
+		}
+	case "show_window":
+		return a.showWindow
+	case "open_timeline":
+		return func() {
+			a.showWindow()
+			wailsRuntime.EventsEmit(a.ctx, "navigate", "/timeline")
+		}
 	}
-	return a.Config.UpdateConfig(updates)
+	return nil
+}
+
+// showWindow brings the main window to the front.
+func (a *App) showWindow() {
+	wailsRuntime.WindowShow(a.ctx)
+	wailsRuntime.WindowSetAlwaysOnTop(a.ctx, true)
+	wailsRuntime.WindowSetAlwaysOnTop(a.ctx, false)
 }
 
 // GetStorageStats returns storage statistics.
@@ -1064,6 +2513,35 @@ func (a *App) GetStorageStats() (*service.StorageStats, error) {
 	return a.Config.GetStorageStats()
 }
 
+// GetUsageStatistics returns lifetime totals over all tracked data.
+func (a *App) GetUsageStatistics() (*service.LifetimeStats, error) {
+	if a.Config == nil {
+		return nil, nil
+	}
+	return a.Config.GetUsageStatistics()
+}
+
+// GetSystemResourceUsage returns Traq's current CPU, memory and disk footprint.
+func (a *App) GetSystemResourceUsage() (*tracker.ResourceUsage, error) {
+	if a.resources == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+	return a.resources.Usage(), nil
+}
+
+// GetHistoricalResourceUsage returns the resource snapshots the daemon recorded
+// over the last hours (default 24), oldest first.
+func (a *App) GetHistoricalResourceUsage(hours int) ([]*storage.ResourceSnapshot, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	if hours <= 0 {
+		hours = 24
+	}
+	end := time.Now().Unix()
+	return a.store.GetResourceSnapshots(end-int64(hours)*3600, end)
+}
+
 // OptimizeDatabase runs VACUUM and ANALYZE to reclaim space and optimize the database.
 // Returns the size reduction in bytes (positive if space was reclaimed).
 func (a *App) OptimizeDatabase() (int64, error) {
@@ -1073,6 +2551,48 @@ func (a *App) OptimizeDatabase() (int64, error) {
 	return a.Config.OptimizeDatabase()
 }
 
+// VacuumIntoCompressedFile archives a compacted, Zstandard-compressed copy of the database
+// (a .db.zst file) without deleting any data. If destPath is empty, a timestamped file is
+// written to the archives folder in the data directory. Returns the size reduction in bytes.
+func (a *App) VacuumIntoCompressedFile(destPath string) (int64, error) {
+	if a.Config == nil {
+		return 0, fmt.Errorf("config service not initialized")
+	}
+	return a.Config.ArchiveDatabase(destPath)
+}
+
+// EstimateCompressedSize estimates the size in bytes of a compressed database archive.
+func (a *App) EstimateCompressedSize() (int64, error) {
+	if a.Config == nil {
+		return 0, fmt.Errorf("config service not initialized")
+	}
+	return a.Config.EstimateCompressedSize()
+}
+
+// GetMigrationStatus returns the database's current schema version and the latest one.
+func (a *App) GetMigrationStatus() (*storage.MigrationStatus, error) {
+	if a.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return a.store.GetMigrationStatus()
+}
+
+// RunMigration migrates the database up or down to a schema version. Rolling back drops
+// tables and columns the trackers still write to, so it's meant for development.
+func (a *App) RunMigration(targetVersion int) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	current, err := a.store.GetCurrentMigrationVersion()
+	if err != nil {
+		return err
+	}
+	if targetVersion != 0 && targetVersion < current {
+		return a.store.MigrateDown(targetVersion)
+	}
+	return a.store.MigrateUp(targetVersion)
+}
+
 // GetCategorizationRules retrieves all app categorization rules.
 func (a *App) GetCategorizationRules() ([]storage.CategorizationRule, error) {
 	if a.store == nil {
@@ -1097,6 +2617,78 @@ func (a *App) DeleteTimelineCategoryRule(appName string) error {
 	return a.store.DeleteTimelineCategoryRule(appName)
 }
 
+// ExportCategorizationRules returns all app categories and timeline categorization rules as JSON.
+func (a *App) ExportCategorizationRules() (string, error) {
+	if a.store == nil {
+		return "", fmt.Errorf("store not initialized")
+	}
+	return a.store.ExportCategorizationRules()
+}
+
+// ImportCategorizationRules loads rules exported by ExportCategorizationRules.
+// Mode is "merge" (add new, skip existing) or "replace" (delete all then insert).
+// Returns the number of rules imported.
+func (a *App) ImportCategorizationRules(data string, mode string) (int, error) {
+	if a.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	return a.store.ImportCategorizationRules(data, mode)
+}
+
+// AddBlocklistedDomain flags a browser domain in reports. The blocklist only annotates
+// reports; it does not block browsing.
+func (a *App) AddBlocklistedDomain(domain, reason string) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	if err := a.store.AddBlocklistedDomain(domain, reason); err != nil {
+		return err
+	}
+	a.clearReportCache()
+	return nil
+}
+
+// RemoveBlocklistedDomain removes a domain from the browser domain blocklist.
+func (a *App) RemoveBlocklistedDomain(domain string) error {
+	if a.store == nil {
+		return fmt.Errorf("store not initialized")
+	}
+	if err := a.store.RemoveBlocklistedDomain(domain); err != nil {
+		return err
+	}
+	a.clearReportCache()
+	return nil
+}
+
+// GetBlocklistedDomains returns all blocklisted browser domains.
+func (a *App) GetBlocklistedDomains() ([]storage.BlocklistedDomain, error) {
+	if a.store == nil {
+		return nil, fmt.Errorf("store not initialized")
+	}
+	return a.store.GetBlocklistedDomains()
+}
+
+// ImportBlocklist adds a list of domains to the browser domain blocklist.
+// Returns the number of domains that were not already blocklisted.
+func (a *App) ImportBlocklist(domains []string) (int, error) {
+	if a.store == nil {
+		return 0, fmt.Errorf("store not initialized")
+	}
+	added, err := a.store.ImportBlocklist(domains)
+	if err != nil {
+		return 0, err
+	}
+	a.clearReportCache()
+	return added, nil
+}
+
+// clearReportCache drops cached report data so blocklist changes show up immediately.
+func (a *App) clearReportCache() {
+	if a.Reports != nil {
+		a.Reports.ClearReportCache()
+	}
+}
+
 // ============================================================================
 // System Methods (exposed to frontend)
 // ============================================================================
@@ -1126,6 +2718,14 @@ func (a *App) GetSystemInfo() map[string]string {
 	}
 }
 
+// GetMachineID returns the ID that places this machine in canary update cohorts.
+func (a *App) GetMachineID() string {
+	if a.platform == nil {
+		return ""
+	}
+	return a.platform.MachineID()
+}
+
 // GetSystemTheme returns the current OS theme ("dark" or "light").
 func (a *App) GetSystemTheme() string {
 	if a.platform == nil {
@@ -1178,6 +2778,14 @@ func (a *App) TriggerUpdate() error {
 	return a.Update.ApplyAndRestart()
 }
 
+// GetUpdateDownloadProgress returns the percent (0-100) of the current update download.
+func (a *App) GetUpdateDownloadProgress() float64 {
+	if a.Update == nil {
+		return 0
+	}
+	return a.Update.GetUpdateDownloadProgress()
+}
+
 // ============================================================================
 // Git Tracking Methods (exposed to frontend)
 // ============================================================================
@@ -1206,6 +2814,22 @@ func (a *App) GetTrackedRepositories() ([]*storage.GitRepository, error) {
 	return a.daemon.GetTrackedRepositories()
 }
 
+// GetStaleRepositories returns tracked repositories with no commits in the last days days.
+func (a *App) GetStaleRepositories(days int) ([]*storage.GitRepository, error) {
+	if a.daemon == nil {
+		return nil, nil
+	}
+	return a.daemon.GetStaleGitRepositories(days)
+}
+
+// MarkRepositoriesInactive stops polling the given repositories for new commits.
+func (a *App) MarkRepositoriesInactive(ids []int64) error {
+	if a.daemon == nil {
+		return nil
+	}
+	return a.daemon.MarkGitRepositoriesInactive(ids)
+}
+
 // DiscoverGitRepositories searches for git repositories in the given paths up to maxDepth.
 // Returns a list of newly discovered repositories.
 func (a *App) DiscoverGitRepositories(searchPaths []string, maxDepth int) ([]*storage.GitRepository, error) {
@@ -1215,6 +2839,14 @@ func (a *App) DiscoverGitRepositories(searchPaths []string, maxDepth int) ([]*st
 	return a.daemon.DiscoverGitRepositories(searchPaths, maxDepth)
 }
 
+// GetGitAuthorStats returns the authors with the most commits in a time range.
+func (a *App) GetGitAuthorStats(start, end int64, limit int) ([]*storage.AuthorStats, error) {
+	if a.store == nil {
+		return nil, nil
+	}
+	return a.store.GetTopGitAuthors(start, end, limit)
+}
+
 // ============================================================================
 // File Tracking Methods (exposed to frontend)
 // ============================================================================
@@ -1243,6 +2875,15 @@ func (a *App) GetWatchedDirectories() []string {
 	return a.daemon.GetWatchedDirectories()
 }
 
+// AutoWatchProjects watches project directories found under the given paths, and keeps
+// rescanning them weekly. Returns the number of newly watched projects.
+func (a *App) AutoWatchProjects(searchPaths []string) (int, error) {
+	if a.daemon == nil {
+		return 0, nil
+	}
+	return a.daemon.AutoWatchProjects(searchPaths)
+}
+
 // SetFileAllowedExtensions sets which file extensions to track.
 // If empty, all extensions are tracked (default behavior).
 func (a *App) SetFileAllowedExtensions(extensions []string) {
@@ -1260,6 +2901,40 @@ func (a *App) GetFileAllowedExtensions() []string {
 	return a.daemon.GetFileAllowedExtensions()
 }
 
+// ShellHookScript is a generated shell hook and where it's installed.
+type ShellHookScript struct {
+	Content     string `json:"content"`
+	InstallPath string `json:"installPath"`
+}
+
+// GenerateShellHook returns the hook script for a shell ("zsh" or "fish").
+func (a *App) GenerateShellHook(shell string) (*ShellHookScript, error) {
+	if a.daemon == nil {
+		return nil, nil
+	}
+	content, path, err := a.daemon.GenerateShellHook(shell)
+	if err != nil {
+		return nil, err
+	}
+	return &ShellHookScript{Content: content, InstallPath: path}, nil
+}
+
+// InstallShellHook installs the hook script for a shell and returns its path.
+func (a *App) InstallShellHook(shell string) (string, error) {
+	if a.daemon == nil {
+		return "", nil
+	}
+	return a.daemon.InstallShellHook(shell)
+}
+
+// GetShellHookStatus returns whether a hook is available and installed for the user's shell.
+func (a *App) GetShellHookStatus() *tracker.ShellHookStatus {
+	if a.daemon == nil {
+		return nil
+	}
+	return a.daemon.GetShellHookStatus()
+}
+
 // ============================================================================
 // App Categorization Methods
 // ============================================================================
@@ -1333,6 +3008,14 @@ func (a *App) GetInferenceStatus() *inference.InferenceStatus {
 	return a.inference.GetStatus()
 }
 
+// GetInferenceBenchmark measures time-to-first-token and generation speed of the configured engine.
+func (a *App) GetInferenceBenchmark() (*inference.BenchmarkResult, error) {
+	if a.inference == nil {
+		return nil, fmt.Errorf("inference service not initialized")
+	}
+	return a.inference.Benchmark(inference.DefaultBenchmarkPromptTokens, inference.DefaultBenchmarkOutputTokens)
+}
+
 // GetInferenceSetupStatus returns detailed setup status with actionable feedback.
 func (a *App) GetInferenceSetupStatus() *inference.SetupStatus {
 	if a.inference == nil {
@@ -1566,6 +3249,24 @@ func (a *App) GetLatestHierarchicalSummaries() (map[string]*storage.Hierarchical
 	return a.store.GetLatestHierarchicalSummaries()
 }
 
+// GenerateDailySummary rolls a date's session summaries up into a daily summary.
+// Returns the existing summary without regenerating if no AI model is available.
+func (a *App) GenerateDailySummary(date string) (*storage.HierarchicalSummary, error) {
+	if a.Summary == nil {
+		return nil, fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.GenerateDailySummary(date)
+}
+
+// GenerateWeeklySummaryFromDailies rolls the daily summaries of the week starting on weekStart
+// up into a weekly summary.
+func (a *App) GenerateWeeklySummaryFromDailies(weekStart string) (*storage.HierarchicalSummary, error) {
+	if a.Summary == nil {
+		return nil, fmt.Errorf("summary service not initialized")
+	}
+	return a.Summary.GenerateWeeklySummaryFromDailies(weekStart)
+}
+
 // UpdateHierarchicalSummary updates an existing summary (marks as user-edited).
 func (a *App) UpdateHierarchicalSummary(id int64, summary string) error {
 	if a.store == nil {
@@ -1706,6 +3407,14 @@ func (a *App) GetProject(projectID int64) (*storage.Project, error) {
 	return a.Projects.GetProject(projectID)
 }
 
+// GetProjectTimeline returns a project's daily focus time, commits and sessions in a time range.
+func (a *App) GetProjectTimeline(projectName string, start, end int64) (*service.ProjectTimeline, error) {
+	if a.Projects == nil {
+		return nil, nil
+	}
+	return a.Projects.GetProjectTimeline(projectName, start, end)
+}
+
 // CreateProject creates a new project.
 func (a *App) CreateProject(name, color, description string) (*storage.Project, error) {
 	if a.Projects == nil {
@@ -1804,6 +3513,41 @@ func (a *App) ApplyRuleToHistory(patternID int64) (int, error) {
 	return a.Projects.ApplyRuleToHistory(patternID)
 }
 
+// GetProjectDetectionRules returns the regex rules that extract project names from activity.
+func (a *App) GetProjectDetectionRules() ([]storage.ProjectDetectionRule, error) {
+	if a.Projects == nil {
+		return nil, fmt.Errorf("projects service not initialized")
+	}
+	return a.Projects.GetDetectionRules()
+}
+
+// CreateProjectDetectionRule creates a regex rule that extracts a project name, e.g. from
+// commit messages. captureGroup 0 uses the full match, 1+ a capture group.
+func (a *App) CreateProjectDetectionRule(sourceType, pattern string, captureGroup int) (*storage.ProjectDetectionRule, error) {
+	if a.Projects == nil {
+		return nil, fmt.Errorf("projects service not initialized")
+	}
+	rule, err := a.Projects.CreateDetectionRule(sourceType, pattern, captureGroup)
+	if err == nil && a.Reports != nil {
+		a.Reports.ClearReportCache()
+	}
+	return rule, err
+}
+
+// DeleteProjectDetectionRule removes a project detection rule.
+func (a *App) DeleteProjectDetectionRule(id int64) error {
+	if a.Projects == nil {
+		return fmt.Errorf("projects service not initialized")
+	}
+	if err := a.Projects.DeleteDetectionRule(id); err != nil {
+		return err
+	}
+	if a.Reports != nil {
+		a.Reports.ClearReportCache()
+	}
+	return nil
+}
+
 // MigrateHardcodedPatterns migrates legacy hardcoded project detection rules to the database.
 // This is idempotent and safe to call multiple times.
 func (a *App) MigrateHardcodedPatterns() (int, error) {
@@ -1863,12 +3607,22 @@ func (a *App) BulkAssignProject(assignments []BulkAssignment) error {
 	return nil
 }
 
-// SuggestProject gets a project suggestion for an event context.
-func (a *App) SuggestProject(ctx *storage.AssignmentContext) *service.AssignmentResult {
+// SuggestProject suggests a project for a focus event using the user assignment,
+// project rules, and AI classification (in that order). Returns nil if nothing matches.
+func (a *App) SuggestProject(eventID int64) (*service.AssignmentResult, error) {
 	if a.Projects == nil {
-		return nil
+		return nil, nil
+	}
+	return a.Projects.SuggestProjectForEvent(eventID)
+}
+
+// BulkSuggestProjects assigns suggested projects to unassigned focus events in a time range.
+// Returns the number of events assigned.
+func (a *App) BulkSuggestProjects(start, end int64) (int, error) {
+	if a.Projects == nil {
+		return 0, nil
 	}
-	return a.Projects.SuggestProject(ctx)
+	return a.Projects.BulkSuggestProjects(start, end)
 }
 
 // GetUnassignedEventCount returns the count of events without project assignment.
@@ -0,0 +1,95 @@
+// Command categorization exports and imports app categorization rules, so admins
+// can pre-configure shared machines without opening the UI.
+//
+// Usage:
+//
+//	categorization export [-o rules.json]
+//	categorization import [-mode merge|replace] rules.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"traq/internal/platform"
+	"traq/internal/storage"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: categorization export [-o file]")
+	fmt.Fprintln(os.Stderr, "       categorization import [-mode merge|replace] file")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	// Initialize platform
+	p := platform.New()
+	dbPath := filepath.Join(p.DataDir(), "data.db")
+
+	// Initialize storage
+	store, err := storage.NewStore(dbPath)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage: %v", err)
+	}
+	defer store.Close()
+
+	// Run migrations
+	if err := store.Migrate(); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	switch os.Args[1] {
+	case "export":
+		fs := flag.NewFlagSet("export", flag.ExitOnError)
+		output := fs.String("o", "", "write to this file instead of stdout")
+		fs.Parse(os.Args[2:])
+
+		data, err := store.ExportCategorizationRules()
+		if err != nil {
+			log.Fatalf("Failed to export rules: %v", err)
+		}
+		if *output == "" {
+			fmt.Println(data)
+			return
+		}
+		if err := os.WriteFile(*output, []byte(data), 0644); err != nil {
+			log.Fatalf("Failed to write %s: %v", *output, err)
+		}
+
+	case "import":
+		fs := flag.NewFlagSet("import", flag.ExitOnError)
+		mode := fs.String("mode", "merge", `"merge" (add new, skip existing) or "replace" (delete all then insert)`)
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 {
+			usage()
+		}
+
+		// "-" reads the document from stdin
+		var data []byte
+		if path := fs.Arg(0); path == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			log.Fatalf("Failed to read rules: %v", err)
+		}
+
+		n, err := store.ImportCategorizationRules(string(data), *mode)
+		if err != nil {
+			log.Fatalf("Failed to import rules: %v", err)
+		}
+		fmt.Fprintf(os.Stderr, "Imported %d rules\n", n)
+
+	default:
+		usage()
+	}
+}
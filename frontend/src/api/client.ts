@@ -13,6 +13,7 @@ import * as App from '@wailsjs/go/main/App';
 import { mockData } from './mockData';
 
 import type {
+  BenchmarkResult,
   InferenceStatus,
   ModelInfo,
   MonitorInfo,
@@ -163,7 +164,7 @@ export const analytics = {
   getDailyStatsWithComparison: async (date: string) => {
     if (isMockMode()) return mockData.getDailyStats(date);
     await waitForReady();
-    return withRetry(() => App.GetDailyStatsWithComparison(date));
+    return withRetry(() => App.GetDailyStatsWithComparison(date, true));
   },
 
   getWeeklyStats: async (startDate: string) => {
@@ -208,6 +209,24 @@ export const analytics = {
     return withRetry(() => App.GetProjectUsage(start, end));
   },
 
+  getFocusEventsByWindow: async (windowTitlePattern: string, start: number, end: number) => {
+    if (isMockMode()) return [];
+    await waitForReady();
+    return withRetry(() => App.GetFocusEventsByWindow(windowTitlePattern, start, end));
+  },
+
+  getWindowTitleFrequency: async (start: number, end: number, limit: number) => {
+    if (isMockMode()) return [];
+    await waitForReady();
+    return withRetry(() => App.GetWindowTitleFrequency(start, end, limit));
+  },
+
+  getWindowTitleTrend: async (windowTitlePattern: string, days: number) => {
+    if (isMockMode()) return [];
+    await waitForReady();
+    return withRetry(() => App.GetWindowTitleTrend(windowTitlePattern, days));
+  },
+
   getHourlyActivity: async (date: string) => {
     if (isMockMode()) return mockData.getHourlyActivity(date);
     await waitForReady();
@@ -239,6 +258,17 @@ export const analytics = {
     return withRetry(() => App.GetProductivityScore(date));
   },
 
+  getAbstractivityScore: async (date: string) => {
+    if (isMockMode()) return {
+      score: 62,
+      concreteMinutes: 240,
+      abstractMinutes: 90,
+      indicators: ['8 git commits (+20)', '120 file edits (+12)', '90 min browser-only'],
+    };
+    await waitForReady();
+    return withRetry(() => App.GetAbstractivityScore(date));
+  },
+
   getFocusDistribution: async (_date: string) => {
     if (isMockMode()) {
       // Return hourly focus data
@@ -305,6 +335,12 @@ export const timeline = {
     return withRetry(() => App.GetSessionsForDate(date));
   },
 
+  getActiveSession: async () => {
+    if (isMockMode()) return null;
+    await waitForReady();
+    return withRetry(() => App.GetActiveSession());
+  },
+
   getScreenshotsForSession: async (
     sessionId: number,
     page: number,
@@ -346,6 +382,16 @@ export const timeline = {
     return withRetry(() => App.GetTimelineGridData(date));
   },
 
+  getCodeEditingStats: async (date: string) => {
+    await waitForReady();
+    return withRetry(() => App.GetCodeEditingStats(date));
+  },
+
+  getCodeEditingTrend: async (numDays: number) => {
+    await waitForReady();
+    return withRetry(() => App.GetCodeEditingTrend(numDays));
+  },
+
   getWeekTimelineData: async (startDate: string) => {
     if (isMockMode()) return null; // No mock data for week view
     await waitForReady();
@@ -410,6 +456,16 @@ export const reports = {
     return withRetry(() => App.GetReportHistory());
   },
 
+  getReportTimeline: async (start: number, end: number) => {
+    await waitForReady();
+    return withRetry(() => App.GetReportTimeline(start, end));
+  },
+
+  getReportTimelineHTML: async (start: number, end: number) => {
+    await waitForReady();
+    return withRetry(() => App.GetReportTimelineHTML(start, end));
+  },
+
   getDailySummaries: async (limit: number = 30) => {
     if (isMockMode()) return mockData.getReportHistory().slice(0, limit);
     await waitForReady();
@@ -507,6 +563,21 @@ export const config = {
     return withRetry(() => App.GetStorageStats());
   },
 
+  getUsageStatistics: async () => {
+    await waitForReady();
+    return withRetry(() => App.GetUsageStatistics());
+  },
+
+  getSystemResourceUsage: async () => {
+    await waitForReady();
+    return withRetry(() => App.GetSystemResourceUsage());
+  },
+
+  getHistoricalResourceUsage: async (hours: number) => {
+    await waitForReady();
+    return withRetry(() => App.GetHistoricalResourceUsage(hours));
+  },
+
   optimizeDatabase: async (): Promise<number> => {
     await waitForReady();
     return App.OptimizeDatabase();
@@ -523,6 +594,11 @@ export const config = {
     };
   },
 
+  getInferenceBenchmark: async (): Promise<BenchmarkResult> => {
+    await waitForReady();
+    return App.GetInferenceBenchmark();
+  },
+
   getAvailableModels: async (): Promise<ModelInfo[]> => {
     if (isMockMode()) return mockData.getAvailableModels();
     await waitForReady();
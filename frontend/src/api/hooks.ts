@@ -53,13 +53,20 @@ export const queryKeys = {
     heatmap: () => ['analytics', 'heatmap'] as const,
     dataSources: (start: number, end: number) => ['analytics', 'dataSources', start, end] as const,
     productivityScore: (date: string) => ['analytics', 'productivityScore', date] as const,
+    abstractivityScore: (date: string) => ['analytics', 'abstractivityScore', date] as const,
     focusDistribution: (date: string) => ['analytics', 'focusDistribution', date] as const,
     activityTags: (date: string) => ['analytics', 'activityTags', date] as const,
     topWindows: (date: string, limit: number) => ['analytics', 'topWindows', date, limit] as const,
+    windowEvents: (pattern: string, start: number, end: number) =>
+      ['analytics', 'windowEvents', pattern, start, end] as const,
+    windowFrequency: (start: number, end: number, limit: number) =>
+      ['analytics', 'windowFrequency', start, end, limit] as const,
+    windowTrend: (pattern: string, days: number) => ['analytics', 'windowTrend', pattern, days] as const,
   },
   timeline: {
     all: ['timeline'] as const,
     sessions: (date: string) => ['timeline', 'sessions', date] as const,
+    activeSession: () => ['timeline', 'activeSession'] as const,
     screenshots: (sessionId: number, page: number, perPage: number) =>
       ['timeline', 'screenshots', sessionId, page, perPage] as const,
     dateScreenshots: (date: string) => ['timeline', 'dateScreenshots', date] as const,
@@ -68,9 +75,12 @@ export const queryKeys = {
     context: (sessionId: number) => ['timeline', 'context', sessionId] as const,
     gridData: (date: string) => ['timeline', 'gridData', date] as const,
     weekGridData: (startDate: string) => ['timeline', 'weekGridData', startDate] as const,
+    codeEditing: (date: string) => ['timeline', 'codeEditing', date] as const,
+    codeEditingTrend: (numDays: number) => ['timeline', 'codeEditingTrend', numDays] as const,
   },
   reports: {
     history: () => ['reports', 'history'] as const,
+    timeline: (start: number, end: number) => ['reports', 'timeline', start, end] as const,
     timeRange: (input: string) => ['reports', 'timeRange', input] as const,
   },
   config: {
@@ -189,6 +199,32 @@ export function useProjectUsage(date: string) {
   });
 }
 
+export function useFocusEventsByWindow(windowTitlePattern: string, start: number, end: number) {
+  return useQuery({
+    queryKey: queryKeys.analytics.windowEvents(windowTitlePattern, start, end),
+    queryFn: () => api.analytics.getFocusEventsByWindow(windowTitlePattern, start, end),
+    enabled: windowTitlePattern.length > 0,
+    staleTime: 60_000,
+  });
+}
+
+export function useWindowTitleFrequency(start: number, end: number, limit: number = 20) {
+  return useQuery({
+    queryKey: queryKeys.analytics.windowFrequency(start, end, limit),
+    queryFn: () => api.analytics.getWindowTitleFrequency(start, end, limit),
+    staleTime: 60_000,
+  });
+}
+
+export function useWindowTitleTrend(windowTitlePattern: string, days: number = 14) {
+  return useQuery({
+    queryKey: queryKeys.analytics.windowTrend(windowTitlePattern, days),
+    queryFn: () => api.analytics.getWindowTitleTrend(windowTitlePattern, days),
+    enabled: windowTitlePattern.length > 0,
+    staleTime: 5 * 60_000,
+  });
+}
+
 export function useHourlyActivity(date: string) {
   return useQuery({
     queryKey: queryKeys.analytics.hourly(date),
@@ -214,6 +250,14 @@ export function useProductivityScore(date: string) {
   });
 }
 
+export function useAbstractivityScore(date: string) {
+  return useQuery({
+    queryKey: queryKeys.analytics.abstractivityScore(date),
+    queryFn: () => api.analytics.getAbstractivityScore(date),
+    staleTime: 60_000,
+  });
+}
+
 export function useFocusDistribution(date: string) {
   return useQuery({
     queryKey: queryKeys.analytics.focusDistribution(date),
@@ -258,6 +302,23 @@ export function useSessionsForDate(date: string) {
   });
 }
 
+// Session being tracked now, updated when the daemon starts or ends a session
+export function useActiveSession() {
+  const queryClient = useQueryClient();
+
+  useEffect(() => {
+    return safeEventsOn('session:active_changed', (session) => {
+      queryClient.setQueryData(queryKeys.timeline.activeSession(), session);
+    });
+  }, [queryClient]);
+
+  return useQuery({
+    queryKey: queryKeys.timeline.activeSession(),
+    queryFn: () => api.timeline.getActiveSession(),
+    staleTime: 30_000,
+  });
+}
+
 export function useScreenshotsForSession(
   sessionId: number,
   page: number = 1,
@@ -296,6 +357,22 @@ export function useTimelineGridData(date: string) {
   });
 }
 
+export function useCodeEditingStats(date: string) {
+  return useQuery({
+    queryKey: queryKeys.timeline.codeEditing(date),
+    queryFn: () => api.timeline.getCodeEditingStats(date),
+    staleTime: 60_000,
+  });
+}
+
+export function useCodeEditingTrend(numDays: number) {
+  return useQuery({
+    queryKey: queryKeys.timeline.codeEditingTrend(numDays),
+    queryFn: () => api.timeline.getCodeEditingTrend(numDays),
+    staleTime: 5 * 60_000,
+  });
+}
+
 export function useWeekTimelineData(startDate: string, enabled = true) {
   return useQuery({
     queryKey: queryKeys.timeline.weekGridData(startDate),
@@ -326,6 +403,14 @@ export function useReportHistory() {
   });
 }
 
+export function useReportTimeline(start: number, end: number) {
+  return useQuery({
+    queryKey: queryKeys.reports.timeline(start, end),
+    queryFn: () => api.reports.getReportTimeline(start, end),
+    staleTime: 60_000,
+  });
+}
+
 export function useDailySummaries(limit: number = 30) {
   return useQuery({
     queryKey: ['reports', 'dailySummaries', limit],
@@ -449,6 +534,12 @@ export function useInferenceStatus() {
   });
 }
 
+export function useInferenceBenchmark() {
+  return useMutation({
+    mutationFn: () => api.config.getInferenceBenchmark(),
+  });
+}
+
 export function useAvailableModels() {
   return useQuery({
     queryKey: queryKeys.config.models(),
@@ -568,6 +659,30 @@ export function useStorageStats() {
   });
 }
 
+export function useUsageStatistics() {
+  return useQuery({
+    queryKey: ['config', 'usage'],
+    queryFn: () => api.config.getUsageStatistics(),
+    staleTime: 5 * 60_000, // Cached for 5 minutes by the backend
+  });
+}
+
+export function useSystemResourceUsage() {
+  return useQuery({
+    queryKey: ['config', 'resources'],
+    queryFn: () => api.config.getSystemResourceUsage(),
+    refetchInterval: 30_000, // 30 seconds
+  });
+}
+
+export function useHistoricalResourceUsage(hours: number = 24) {
+  return useQuery({
+    queryKey: ['config', 'resources', 'history', hours],
+    queryFn: () => api.config.getHistoricalResourceUsage(hours),
+    staleTime: 5 * 60_000, // Snapshots are recorded every 15 minutes
+  });
+}
+
 export function useOpenDataDir() {
   return useMutation({
     mutationFn: () => api.system.openDataDir(),
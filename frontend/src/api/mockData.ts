@@ -470,6 +470,8 @@ export const mockData = {
       gitCommits,
       fileEvents,
       browserVisits,
+      afkEvents: [],
+      afkSeconds: 0,
     };
   },
 
@@ -550,6 +552,7 @@ export const mockData = {
     afk: {
       timeoutSeconds: 180,
       minSessionMinutes: 5,
+      minSessionDurationSeconds: 30,
     },
     inference: {
       engine: 'bundled',
@@ -128,16 +128,16 @@ export function DataSourcesPanel({ data, isLoading }: DataSourcesPanelProps) {
                     </div>
                   ))}
                 </div>
-                {data.files.topExtensions && Object.keys(data.files.topExtensions).length > 0 && (
+                {data.files.topExtensions && data.files.topExtensions.length > 0 && (
                   <div className="space-y-2">
                     <h4 className="text-sm font-medium">Top Extensions</h4>
-                    {Object.entries(data.files.topExtensions).slice(0, 5).map(([ext, count]) => (
+                    {data.files.topExtensions.slice(0, 5).map((ext) => (
                       <div
-                        key={ext}
+                        key={ext.extension}
                         className="flex items-center justify-between p-2 rounded-lg border"
                       >
-                        <code className="text-sm font-mono">{ext || '(no ext)'}</code>
-                        <Badge variant="outline">{count} files</Badge>
+                        <code className="text-sm font-mono">{ext.extension}</code>
+                        <Badge variant="outline">{ext.eventCount} events</Badge>
                       </div>
                     ))}
                   </div>
@@ -11,6 +11,8 @@ interface ProductivityScore {
   distractingMinutes: number;
   totalMinutes: number;
   productivePercentage: number;
+  contextSwitchCount?: number; // Switches into a distracting app
+  contextSwitchPenaltyMinutes?: number; // Productive time lost to refocusing
 }
 
 interface ProductivityScoreCardProps {
@@ -119,7 +121,7 @@ export function ProductivityScoreCard({ score, isLoading }: ProductivityScoreCar
                 <Info className="h-3.5 w-3.5 cursor-help text-muted-foreground" />
               </TooltipTrigger>
               <TooltipContent className="max-w-xs">
-                <p>Score from 1-5 based on time spent in productive vs distracting applications. Calculated from window focus events and application usage patterns.</p>
+                <p>Score from 1-5 based on time spent in productive vs distracting applications. Calculated from window focus events and application usage patterns. Each switch to a distracting app also deducts refocusing time from productive time.</p>
               </TooltipContent>
             </Tooltip>
           </div>
@@ -176,6 +178,15 @@ export function ProductivityScoreCard({ score, isLoading }: ProductivityScoreCar
           </div>
         </div>
 
+        {(score.contextSwitchCount ?? 0) > 0 && (
+          <div className="flex items-center justify-between text-xs text-muted-foreground">
+            <span>
+              {score.contextSwitchCount} distraction{score.contextSwitchCount === 1 ? '' : 's'}
+            </span>
+            <span>-{formatMinutes(score.contextSwitchPenaltyMinutes ?? 0)} refocusing</span>
+          </div>
+        )}
+
         {/* Progress Bar */}
         <div className="space-y-2">
           <Progress value={score.productivePercentage} className="h-2" />
@@ -12,6 +12,7 @@ import {
   FileText,
   Globe,
   Trophy,
+  Hammer,
   TrendingUp,
   TrendingDown,
 } from 'lucide-react';
@@ -200,6 +201,19 @@ export function StatsGrid({ stats, isLoading, className }: StatsGridProps) {
         icon={<Trophy className="h-4 w-4" />}
         description={stats.topApps?.[0] ? `${stats.topApps[0].percentage.toFixed(1)}% of time` : ''}
       />
+      {stats.abstractivityScore && (
+        <StatCard
+          title="Concreteness"
+          value={`${stats.abstractivityScore.score} / 100`}
+          icon={<Hammer className="h-4 w-4" />}
+          description={`${formatDuration(stats.abstractivityScore.concreteMinutes * 60)} hands-on`}
+          tooltip={
+            stats.abstractivityScore.indicators.length > 0
+              ? `How concrete the day's work was. ${stats.abstractivityScore.indicators.join(', ')}.`
+              : "How concrete the day's work was, from commits, file edits, and time spent in browsers and AI tools."
+          }
+        />
+      )}
       </div>
     </TooltipProvider>
   );
@@ -12,6 +12,7 @@ import {
   useConfig,
   useUpdateConfig,
   useInferenceStatus,
+  useInferenceBenchmark,
   useAvailableModels,
   useDownloadModel,
   useServerStatus,
@@ -30,6 +31,7 @@ export function AISettings() {
   const { data: serverStatus } = useServerStatus();
   const { download: downloadServer, progress: serverDownloadProgress, isDownloading: isDownloadingServer, error: serverDownloadError } = useDownloadServer();
   const updateConfig = useUpdateConfig();
+  const benchmark = useInferenceBenchmark();
 
   if (isLoading || !config || !config.inference) {
     return <div className="text-muted-foreground">Loading...</div>;
@@ -322,6 +324,48 @@ export function AISettings() {
         </SettingsCard>
       )}
 
+      <SettingsCard title="Performance" description="Measure response latency of the current engine">
+        <SettingsRow
+          label="Benchmark"
+          description="Sends a short synthetic prompt and times the response"
+        >
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={() => benchmark.mutate()}
+            disabled={benchmark.isPending || !inferenceStatus?.available}
+          >
+            {benchmark.isPending ? 'Running...' : 'Run Benchmark'}
+          </Button>
+        </SettingsRow>
+
+        {benchmark.data && (
+          <div className="rounded-lg bg-muted/50 p-3 text-sm">
+            <p className="font-medium">{benchmark.data.model || benchmark.data.engine}</p>
+            <div className="grid grid-cols-3 gap-2 pt-2 text-muted-foreground">
+              <div>
+                <p className="text-xs">First token</p>
+                <p className="text-foreground">{(benchmark.data.ttft / 1e6).toFixed(0)} ms</p>
+              </div>
+              <div>
+                <p className="text-xs">Speed</p>
+                <p className="text-foreground">{benchmark.data.tokensPerSecond.toFixed(1)} tok/s</p>
+              </div>
+              <div>
+                <p className="text-xs">Total</p>
+                <p className="text-foreground">{(benchmark.data.totalDuration / 1e9).toFixed(1)} s</p>
+              </div>
+            </div>
+            <p className="pt-2 text-xs text-muted-foreground">
+              {benchmark.data.promptTokens} prompt tokens, {benchmark.data.tokensGenerated} generated
+            </p>
+          </div>
+        )}
+        {benchmark.error && (
+          <p className="text-sm text-destructive">{String(benchmark.error)}</p>
+        )}
+      </SettingsCard>
+
       <SettingsCard title="AI Behavior" description="Control how AI features work">
         <SettingsRow
           label="AI Summaries"
@@ -129,7 +129,7 @@ export function CaptureSettings() {
         <SettingsRow label="Capture Mode" vertical>
           <Select
             value={config.capture.monitorMode || 'active_window'}
-            onValueChange={(value: 'active_window' | 'primary' | 'specific') => {
+            onValueChange={(value: 'active_window' | 'primary' | 'specific' | 'all') => {
               updateConfig.mutate({
                 capture: { ...config.capture, monitorMode: value },
               });
@@ -142,6 +142,7 @@ export function CaptureSettings() {
               <SelectItem value="active_window">Follow Active Window</SelectItem>
               <SelectItem value="primary">Always Primary Monitor</SelectItem>
               <SelectItem value="specific">Specific Monitor</SelectItem>
+              <SelectItem value="all">All Monitors</SelectItem>
             </SelectContent>
           </Select>
         </SettingsRow>
@@ -222,6 +223,32 @@ export function CaptureSettings() {
             <span>30 min</span>
           </div>
         </SettingsRow>
+
+        <SettingsRow
+          label="Discard Short Sessions"
+          description={
+            config.afk.minSessionDurationSeconds > 0
+              ? `Sessions shorter than ${config.afk.minSessionDurationSeconds}s with no screenshots or other activity are deleted when they end`
+              : 'All sessions are kept'
+          }
+          vertical
+        >
+          <Slider
+            value={[config.afk.minSessionDurationSeconds]}
+            min={0}
+            max={300}
+            step={10}
+            onValueChange={([value]) =>
+              updateConfig.mutate({
+                afk: { ...config.afk, minSessionDurationSeconds: value },
+              })
+            }
+          />
+          <div className="flex justify-between text-xs text-muted-foreground mt-1">
+            <span>Off</span>
+            <span>5 min</span>
+          </div>
+        </SettingsRow>
       </SettingsCard>
     </div>
   );
@@ -108,7 +108,7 @@ export function DataSourcesSettings() {
           />
         </SettingsRow>
 
-        <SettingsRow label="Exclude Patterns" description="Regex patterns to exclude (one per line)" vertical>
+        <SettingsRow label="Exclude Patterns" description="Regex patterns of commands not to save (one per line). Matching commands are still read from history, just never stored" vertical>
           <textarea
             className="w-full min-h-[80px] px-3 py-2 text-sm rounded-md border border-input bg-background placeholder:text-muted-foreground focus:outline-none focus:ring-2 focus:ring-ring focus:ring-offset-2 font-mono resize-y"
             value={(config.dataSources.shell.excludePatterns || []).join('\n')}
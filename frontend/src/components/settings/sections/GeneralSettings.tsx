@@ -1,4 +1,4 @@
-import { Database, FolderOpen, Image, Sparkles, Clock } from 'lucide-react';
+import { Database, FolderOpen, Image, Sparkles, Clock, Cpu, MemoryStick, Activity, BarChart3 } from 'lucide-react';
 import { AVAILABLE_COLUMNS } from '@/types/timeline';
 import { toast } from 'sonner';
 import { Switch } from '@/components/ui/switch';
@@ -15,6 +15,9 @@ import {
   useConfig,
   useUpdateConfig,
   useStorageStats,
+  useUsageStatistics,
+  useSystemResourceUsage,
+  useHistoricalResourceUsage,
   useOpenDataDir,
   useDataDir,
   useOptimizeDatabase,
@@ -27,6 +30,9 @@ import { SettingsRow } from '../SettingsRow';
 export function GeneralSettings() {
   const { data: config, isLoading } = useConfig();
   const { data: storageStats } = useStorageStats();
+  const { data: usageStats } = useUsageStatistics();
+  const { data: resourceUsage } = useSystemResourceUsage();
+  const { data: resourceHistory } = useHistoricalResourceUsage(24);
   const { data: dataDir } = useDataDir();
   const { data: version } = useVersion();
   const updateConfig = useUpdateConfig();
@@ -63,6 +69,9 @@ export function GeneralSettings() {
     }
   };
 
+  const peakCpu = Math.max(0, ...(resourceHistory ?? []).map((s) => s.cpuPercent));
+  const peakMemory = Math.max(0, ...(resourceHistory ?? []).map((s) => s.memoryMb));
+
   if (isLoading || !config) {
     return <div className="text-muted-foreground">Loading...</div>;
   }
@@ -303,6 +312,77 @@ export function GeneralSettings() {
         </div>
       </SettingsCard>
 
+      <SettingsCard title="Lifetime Usage">
+        {usageStats ? (
+          <div className="space-y-3">
+            {usageStats.oldestDataDate && (
+              <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                <BarChart3 className="h-4 w-4" />
+                {usageStats.oldestDataDate} to {usageStats.newestDataDate}
+              </div>
+            )}
+            {[
+              ['Days tracked', usageStats.daysTracked.toLocaleString()],
+              ['Active time', `${usageStats.totalActiveHours.toFixed(1)} h`],
+              ['Sessions', usageStats.totalSessions.toLocaleString()],
+              ['Screenshots', usageStats.totalScreenshots.toLocaleString()],
+              ['Git commits', usageStats.totalCommits.toLocaleString()],
+              ['Shell commands', usageStats.totalShellCommands.toLocaleString()],
+              ['Browser visits', usageStats.totalBrowserVisits.toLocaleString()],
+              ['Apps tracked', usageStats.uniqueAppsTracked.toLocaleString()],
+              ['Domains visited', usageStats.uniqueDomainsVisited.toLocaleString()],
+            ].map(([label, value]) => (
+              <div key={label} className="flex items-center justify-between">
+                <span className="text-sm text-muted-foreground">{label}</span>
+                <span className="text-sm font-medium">{value}</span>
+              </div>
+            ))}
+          </div>
+        ) : (
+          <div className="text-sm text-muted-foreground">Loading usage statistics...</div>
+        )}
+      </SettingsCard>
+
+      <SettingsCard title="Health">
+        {resourceUsage ? (
+          <div className="space-y-3">
+            <div className="flex items-center justify-between">
+              <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                <Cpu className="h-4 w-4" />
+                CPU
+              </div>
+              <span className="text-sm font-medium">{resourceUsage.cpuPercent.toFixed(1)}%</span>
+            </div>
+            <div className="flex items-center justify-between">
+              <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                <MemoryStick className="h-4 w-4" />
+                Memory
+              </div>
+              <span className="text-sm font-medium">{resourceUsage.memoryMb.toFixed(0)} MB</span>
+            </div>
+            <div className="flex items-center justify-between">
+              <div className="flex items-center gap-2 text-sm text-muted-foreground">
+                <Activity className="h-4 w-4" />
+                Goroutines / Open Files
+              </div>
+              <span className="text-sm font-medium">
+                {resourceUsage.goRoutineCount} / {resourceUsage.openFileDescriptors || '—'}
+              </span>
+            </div>
+            {resourceHistory && resourceHistory.length > 0 && (
+              <div className="border-t pt-2 flex items-center justify-between">
+                <span className="text-sm text-muted-foreground">Peak in last 24 hours</span>
+                <span className="text-sm font-medium">
+                  {peakCpu.toFixed(1)}% CPU, {peakMemory.toFixed(0)} MB
+                </span>
+              </div>
+            )}
+          </div>
+        ) : (
+          <div className="text-sm text-muted-foreground">Loading resource usage...</div>
+        )}
+      </SettingsCard>
+
       <SettingsCard title="Privacy & Data">
         <SettingsRow
           label="Crash Reporting"
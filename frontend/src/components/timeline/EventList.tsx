@@ -5,6 +5,7 @@ import { useState, useCallback, useRef, useMemo, useEffect } from 'react';
 import {
   TimelineGridData,
   ListViewSort,
+  AFK_TRIGGER_LABELS,
 } from '@/types/timeline';
 import type { EventDot, EventDropType } from './timelineTypes';
 import type { EventKey } from '@/utils/eventKeys';
@@ -207,7 +208,7 @@ function gridDataToEvents(data: TimelineGridData | undefined): EventDot[] {
       timestamp: new Date(evt.startTime * 1000),
       type: 'afk',
       row: 'Breaks',
-      label: 'Away from keyboard',
+      label: AFK_TRIGGER_LABELS[evt.triggerType] ?? 'Away from keyboard',
       duration: evt.durationSeconds,
       color: '#f97316',
       metadata: {
@@ -25,7 +25,34 @@ export interface DailyStats {
   gitCommits: number;
   filesModified: number;
   sitesVisited: number;
+  meetingMinutes?: number;
+  abstractivityScore?: AbstractivityScore;
   comparison?: Comparison;
+  previousDay?: DailyStats;
+  delta?: DailyStatsDelta;
+}
+
+export interface AbstractivityScore {
+  score: number; // 0-100, higher is more concrete
+  concreteMinutes: number;
+  abstractMinutes: number;
+  indicators: string[];
+}
+
+export interface StatDelta {
+  value: number;
+  percent: number;
+}
+
+export interface DailyStatsDelta {
+  screenshots: StatDelta;
+  sessions: StatDelta;
+  activeMinutes: StatDelta;
+  shellCommands: StatDelta;
+  gitCommits: StatDelta;
+  filesModified: StatDelta;
+  sitesVisited: StatDelta;
+  meetingMinutes: StatDelta;
 }
 
 export interface WeeklyStats {
@@ -121,6 +148,16 @@ export interface RepositoryStats {
 export interface FileStats {
   totalEvents: number;
   byCategory: CategoryCount[];
+  eventsByType?: Record<string, number>;
+  topExtensions?: ExtensionCount[];
+}
+
+export interface ExtensionCount {
+  extension: string;
+  eventCount: number;
+  createCount: number;
+  modifyCount: number;
+  deleteCount: number;
 }
 
 export interface CategoryCount {
@@ -138,6 +175,13 @@ export interface DomainCount {
   visits: number;
 }
 
+export interface LanguageUsage {
+  language: string;
+  eventCount: number;
+  workspaces: string[];
+  percentage: number;
+}
+
 export interface YearlyStats {
   year: number;
   startDate: string;
@@ -158,3 +202,28 @@ export interface MonthStats {
   sessions: number;
   screenshots: number;
 }
+
+export interface MeetingDetection {
+  platform: string;
+  title: string;
+  windowTitle: string;
+  startTime: number;
+  durationSeconds: number;
+}
+
+export interface MeetingStats {
+  totalMeetings: number;
+  totalMeetingMinutes: number;
+  meetingPercentage: number; // Share of focus time spent in meetings
+  meetingsByPlatform: Record<string, number>; // Platform -> meeting minutes
+  longestMeeting: MeetingDetection;
+  averageDurationMinutes: number;
+  meetingFreeDayStreak: number;
+}
+
+export interface Insight {
+  type: 'peak_hours' | 'distraction_pattern' | 'focus_trend' | 'meeting_overload' | 'productive_day';
+  title: string;
+  body: string;
+  severity: 'info' | 'warning' | 'positive';
+}
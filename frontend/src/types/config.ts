@@ -8,12 +8,31 @@ export interface Config {
   issues?: IssuesConfig;
   timeline?: TimelineConfig;
   ai?: AIConfig;
+  focusGoal?: FocusGoalConfig;
+  obsidian?: ObsidianConfig;
+  productivity?: ProductivityConfig;
+}
+
+export interface ProductivityConfig {
+  contextSwitchPenaltySeconds: number; // Productive time lost per switch into a distracting app
+  maxDailyDistractionMinutes: number; // Daily social media time that triggers an alert; 0 disables it
+}
+
+export interface ObsidianConfig {
+  vaultPath: string; // Set with SetObsidianVaultPath; empty disables export
+  autoExportNotes: boolean; // Re-export today's daily note whenever a session ends
+}
+
+export interface FocusGoalConfig {
+  dailyDeepWorkMinutes: number; // 0 disables the goal
+  notifyOnAchievement: boolean;
 }
 
 export interface AIConfig {
   summaryMode: 'auto_accept' | 'drafts' | 'off';
   summaryChunkMinutes: number;
   assignmentMode: 'auto_accept' | 'drafts' | 'off';
+  annotateScreenshots?: boolean; // Add AI summary tags as screenshot annotations
 }
 
 export interface TimelineConfig {
@@ -34,7 +53,7 @@ export interface CaptureConfig {
   intervalSeconds: number;
   quality: number;
   duplicateThreshold: number;
-  monitorMode: 'active_window' | 'primary' | 'specific';
+  monitorMode: 'active_window' | 'primary' | 'specific' | 'all';
   monitorIndex: number;
 }
 
@@ -51,6 +70,7 @@ export interface MonitorInfo {
 export interface AFKConfig {
   timeoutSeconds: number;
   minSessionMinutes: number;
+  minSessionDurationSeconds: number;
 }
 
 export interface InferenceConfig {
@@ -134,6 +154,16 @@ export interface InferenceStatus {
   error: string | null;
 }
 
+export interface BenchmarkResult {
+  engine: string;
+  model: string;
+  promptTokens: number;
+  tokensGenerated: number;
+  ttft: number; // Nanoseconds
+  totalDuration: number; // Nanoseconds
+  tokensPerSecond: number;
+}
+
 export interface ModelInfo {
   id: string;
   name: string;
@@ -11,6 +11,8 @@ export interface SessionContext {
   gitCommits: GitCommit[];
   fileEvents: FileEvent[];
   browserVisits: BrowserVisit[];
+  afkEvents: AFKEvent[];
+  afkSeconds: number; // AFK time within the session, part of its duration
 }
 
 export interface ShellCommand {
@@ -73,3 +75,20 @@ export interface BrowserVisit {
   sessionId: number | null;
   createdAt: number;
 }
+
+export interface AFKEvent {
+  id: number;
+  startTime: number;
+  endTime: number | null; // null while AFK is ongoing
+  sessionId: number | null;
+  triggerType: string;
+  createdAt: number;
+}
+
+export interface BrowserVisitPage {
+  visits: BrowserVisit[];
+  total: number;
+  offset: number;
+  limit: number;
+  hasMore: boolean;
+}
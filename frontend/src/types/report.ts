@@ -2,13 +2,15 @@ export interface Report {
   id: number;
   title: string;
   timeRange: string;
-  reportType: 'summary' | 'detailed' | 'standup';
+  reportType: 'summary' | 'detailed' | 'standup' | 'journal' | 'ai_narrative';
   format: 'markdown' | 'html' | 'pdf' | 'json';
   content: string | null;
   filepath: string | null;
   startTime: number | null;
   endTime: number | null;
   createdAt: number;
+  version?: number; // 1 for the original report
+  parentId?: number; // original report ID (0 for the original itself)
 }
 
 export interface ReportMeta {
@@ -18,6 +20,8 @@ export interface ReportMeta {
   reportType: string;
   format: string;
   createdAt: number;
+  version?: number;
+  parentId?: number;
 }
 
 export interface TimeRange {
@@ -34,3 +38,13 @@ export interface DailySummary {
   sessionsCount: number;
   createdAt: number;
 }
+
+export interface ReportTemplate {
+  id: number;
+  name: string;
+  reportType: 'summary' | 'standup';
+  templateHtml: string; // html/template source; {{.DefaultHTML}} is the standard layout
+  templateMarkdown: string; // text/template source for Markdown export
+  isDefault: boolean;
+  createdAt: number;
+}
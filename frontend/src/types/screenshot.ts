@@ -14,6 +14,7 @@ export interface Screenshot {
   monitorName: string | null;
   monitorWidth: number | null;
   monitorHeight: number | null;
+  monitorIndex?: number;
   sessionId: number | null;
   createdAt: number;
 }
@@ -21,6 +21,12 @@ export interface SessionSummary extends Session {
   hasGit: boolean;
   hasFiles: boolean;
   hasBrowser: boolean;
+
+  // Only set for the session returned by GetActiveSession
+  isActive?: boolean;
+  currentAppName?: string; // Most recently focused app
+  currentWindowTitle?: string;
+  sessionElapsedSeconds?: number; // Time since startTime
 }
 
 export interface WindowFocusEvent {
@@ -14,6 +14,18 @@ export interface TimelineGridData {
   browserEvents: Record<number, BrowserEventDisplay[]>; // hour -> browser visits
   afkBlocks: Record<number, AFKBlock[]>; // hour -> AFK blocks
   activityStates: ActivityState[]; // unified activity lane states (active/break/afk)
+  pinnedEvents?: PinnedEvent[]; // user-pinned highlights
+}
+
+// Pinned timeline event (from internal/storage PinnedEvent)
+export interface PinnedEvent {
+  id: number;
+  eventType: 'focus' | 'screenshot' | 'git' | 'shell' | 'browser' | 'file';
+  eventId: number;
+  note: string;
+  pinnedAt: number;
+  timestamp: number; // when the pinned event happened
+  title: string;
 }
 
 export interface DayStats {
@@ -28,6 +40,12 @@ export interface DayStats {
   daySpan: DaySpan | null;
   breakdown: Record<string, number>; // category -> seconds
   breakdownPercent: Record<string, number>; // category -> percentage
+  deepWorkMinutes?: number; // total minutes in deep-work blocks
+  afkSummary?: AFKSummary | null; // away-time breakdown
+  focusScoreTrend?: number; // % change in longestFocus vs previous day
+  totalSecondsTrend?: number; // % change in totalSeconds vs previous day
+  previousDayLongestFocus?: number;
+  previousDayTotalSeconds?: number;
 }
 
 export interface DaySpan {
@@ -60,6 +78,7 @@ export interface ActivityBlock {
   projectColor?: string;
   projectSource?: string; // 'manual' | 'rule' | 'ai'
   projectConfidence?: number; // 0-1 confidence score for auto-assignments
+  branch?: string; // Git branch checked out (IDE/terminal events only)
 }
 
 export interface SessionSummaryWithPosition {
@@ -155,13 +174,30 @@ export interface AFKBlock {
   startTime: number; // Unix timestamp
   endTime: number; // Unix timestamp
   durationSeconds: number;
-  triggerType: string; // idle_timeout, system_sleep, manual
+  triggerType: string; // idle_timeout, screen_lock, display_off, sleep, manual
   hourOffset: number; // Hour of day (0-23)
   minuteOffset: number; // Minute within hour (0-59)
   pixelPosition: number; // Vertical position in pixels (0-60)
   pixelHeight: number; // Height in pixels
 }
 
+// AFKSummary describes the time spent away from the keyboard on a day
+export interface AFKSummary {
+  totalAfkMinutes: number;
+  longestAfkMinutes: number;
+  longestAfkStart: number; // Unix timestamp, 0 if there were no AFK periods
+  afkEvents: AFKBlock[];
+  breaks: BreakEvent[]; // AFK periods of 3-30 minutes
+  longBreaks: BreakEvent[]; // AFK periods of 30 minutes or more
+}
+
+export interface BreakEvent {
+  start: number; // Unix timestamp
+  end: number; // Unix timestamp
+  durationMinutes: number;
+  triggerType: string;
+}
+
 // ActivityState represents a unified activity state for the Activity lane
 // States: "active" (focus events), "break" (short gaps), "afk" (explicit AFK events)
 export interface ActivityState {
@@ -192,6 +228,8 @@ export interface WeekTimelineData {
   endDate: string; // Sunday (YYYY-MM-DD)
   days: WeekDayData[];
   weekStats: WeekSummaryStats;
+  gitEvents: Record<string, GitEventDisplay[]>; // date -> commits
+  shellEvents: Record<string, ShellEventDisplay[]>; // date -> long-running or failed commands
 }
 
 export interface WeekDayData {
@@ -204,6 +242,8 @@ export interface WeekDayData {
   hasAiSummary: boolean;
   screenshotCount: number;
   categoryBreakdown: Record<string, number>; // category -> hours
+  commitCount: number;
+  keyCommandCount: number; // Long-running or failed shell commands
 }
 
 export interface WeekTimeBlock {
@@ -259,6 +299,15 @@ export const CATEGORY_LABELS: Record<CategoryType, string> = {
   breaks: 'Breaks',
 };
 
+// Timeline labels for AFK periods by trigger type
+export const AFK_TRIGGER_LABELS: Record<string, string> = {
+  idle_timeout: 'Away from keyboard',
+  screen_lock: 'Screen locked',
+  display_off: 'Display off',
+  sleep: 'System asleep',
+  manual: 'Tracking paused',
+};
+
 // Grid layout constants (Timely-style)
 
 export const GRID_CONSTANTS = {
@@ -388,3 +437,13 @@ export interface ListViewFilter {
   projects?: number[];
   types?: TimelineEventType[];
 }
+
+export interface ActivitySnapshot {
+  timestamp: number;
+  activeApp: string; // Friendly app name; empty if nothing was focused
+  windowTitle: string;
+  sessionId: number; // 0 if outside any session
+  isAfk: boolean;
+  screenshotId: number; // Nearest screenshot within 60 seconds, 0 if none
+  ongoingCommands: string[];
+}
@@ -10,12 +10,16 @@ export function AcceptAssignmentDraft(arg1:number):Promise<void>;
 
 export function AcceptSummaryDraft(arg1:number):Promise<void>;
 
+export function AddReportSchedule(arg1:storage.ScheduledReport):Promise<number>;
+
 export function AddTagToSession(arg1:number,arg2:string):Promise<void>;
 
 export function ApplyRuleToHistory(arg1:number):Promise<number>;
 
 export function AssignEventToProject(arg1:string,arg2:number,arg3:number):Promise<void>;
 
+export function AttachSessionToTaskThread(arg1:number,arg2:number):Promise<void>;
+
 export function AutoDiscoverProjects():Promise<Array<storage.Project>>;
 
 export function AutoInstallOllama():Promise<void>;
@@ -28,12 +32,22 @@ export function BulkAcceptDraftsBySession(arg1:Array<number>,arg2:Array<number>)
 
 export function BulkAssignProject(arg1:Array<main.BulkAssignment>):Promise<void>;
 
+export function BulkSuggestProjects(arg1:number,arg2:number):Promise<number>;
+
 export function CheckForUpdate():Promise<service.UpdateInfo>;
 
+export function CloseTaskThread(arg1:number):Promise<void>;
+
+export function CompareWithSameDayLastMonth(arg1:string):Promise<service.DailyStats>;
+
+export function CompareWithSameDayLastWeek(arg1:string):Promise<service.DailyStats>;
+
 export function CreateProject(arg1:string,arg2:string,arg3:string):Promise<storage.Project>;
 
 export function CreateProjectRule(arg1:service.ProjectRuleInput):Promise<storage.ProjectPattern>;
 
+export function CreateTaskThread(arg1:string):Promise<number>;
+
 export function DeleteAFKEvents(arg1:Array<number>):Promise<void>;
 
 export function DeleteAppCategory(arg1:string):Promise<void>;
@@ -70,8 +84,12 @@ export function DeleteSummary(arg1:number):Promise<void>;
 
 export function DeleteTag(arg1:string):Promise<number>;
 
+export function DeleteTaskThread(arg1:number):Promise<void>;
+
 export function DeleteTimelineCategoryRule(arg1:string):Promise<void>;
 
+export function DetachSessionFromTaskThread(arg1:number,arg2:number):Promise<void>;
+
 export function DiscoverGitRepositories(arg1:Array<string>,arg2:number):Promise<Array<storage.GitRepository>>;
 
 export function DownloadModel(arg1:string):Promise<void>;
@@ -92,6 +110,10 @@ export function GenerateSummary(arg1:number):Promise<storage.Summary>;
 
 export function GenerateWeeklySummaryMarkdown(arg1:string,arg2:string):Promise<string>;
 
+export function GetAbstractivityScore(arg1:string):Promise<service.AbstractivityScore>;
+
+export function GetActiveSession():Promise<service.SessionSummary>;
+
 export function GetActivityTags(arg1:string):Promise<Array<service.TagUsage>>;
 
 export function GetAllApps():Promise<Array<main.AppWithCategory>>;
@@ -114,6 +136,10 @@ export function GetCalendarHeatmap(arg1:number,arg2:number):Promise<service.Cale
 
 export function GetCategorizationRules():Promise<Array<storage.CategorizationRule>>;
 
+export function GetCodeEditingStats(arg1:string):Promise<service.CodeEditingStats>;
+
+export function GetCodeEditingTrend(arg1:number):Promise<Array<service.DailyCodeStats>>;
+
 export function GetConfig():Promise<service.Config>;
 
 export function GetCurrentTime():Promise<number>;
@@ -124,7 +150,7 @@ export function GetDaemonStatus():Promise<service.DaemonStatus>;
 
 export function GetDailyStats(arg1:string):Promise<service.DailyStats>;
 
-export function GetDailyStatsWithComparison(arg1:string):Promise<service.DailyStats>;
+export function GetDailyStatsWithComparison(arg1:string,arg2:boolean):Promise<service.DailyStats>;
 
 export function GetDailySummaries(arg1:number):Promise<Array<service.DailySummary>>;
 
@@ -132,6 +158,8 @@ export function GetDataDir():Promise<string>;
 
 export function GetDataSourceStats(arg1:number,arg2:number):Promise<service.DataSourceStats>;
 
+export function GetDistractedHours(arg1:string):Promise<Array<service.DistractedHourEntry>>;
+
 export function GetEntriesForDate(arg1:string):Promise<Array<service.EntryBlock>>;
 
 export function GetFileAllowedExtensions():Promise<Array<string>>;
@@ -140,12 +168,18 @@ export function GetFocusDistribution(arg1:string):Promise<Array<service.HourlyFo
 
 export function GetFocusEventByID(arg1:number):Promise<storage.WindowFocusEvent>;
 
+export function GetFocusEventsByWindow(arg1:string,arg2:number,arg3:number):Promise<Array<storage.WindowFocusEvent>>;
+
 export function GetHierarchicalSummary(arg1:string,arg2:string):Promise<storage.HierarchicalSummary>;
 
+export function GetHistoricalResourceUsage(arg1:number):Promise<Array<storage.ResourceSnapshot>>;
+
 export function GetHourlyActivity(arg1:string):Promise<Array<service.HourlyActivity>>;
 
 export function GetHourlyActivityHeatmap():Promise<Array<service.HeatmapData>>;
 
+export function GetInferenceBenchmark():Promise<inference.BenchmarkResult>;
+
 export function GetInferenceSetupStatus():Promise<inference.SetupStatus>;
 
 export function GetInferenceStatus():Promise<inference.InferenceStatus>;
@@ -154,10 +188,16 @@ export function GetIssueReport(arg1:number):Promise<service.IssueReport>;
 
 export function GetIssueReports(arg1:number):Promise<Array<service.IssueReport>>;
 
+export function GetKeyboardShortcuts():Promise<Record<string, string>>;
+
 export function GetLatestHierarchicalSummaries():Promise<Record<string, storage.HierarchicalSummary>>;
 
+export function GetMachineID():Promise<string>;
+
 export function GetMonthlyStats(arg1:number,arg2:number):Promise<service.MonthlyStats>;
 
+export function GetMostDistractedDay(arg1:number):Promise<string>;
+
 export function GetOllamaInstallInfo():Promise<Record<string, any>>;
 
 export function GetOllamaSetupStatus():Promise<inference.OllamaSetupStatus>;
@@ -186,6 +226,12 @@ export function GetReportHistory():Promise<Array<service.ReportMeta>>;
 
 export function GetReportIncludeUnassigned():Promise<boolean>;
 
+export function GetReportSchedules():Promise<Array<storage.ScheduledReport>>;
+
+export function GetReportTimeline(arg1:number,arg2:number):Promise<service.ReportTimeline>;
+
+export function GetReportTimelineHTML(arg1:number,arg2:number):Promise<string>;
+
 export function GetScreenshot(arg1:number):Promise<storage.Screenshot>;
 
 export function GetScreenshotInfo(arg1:number):Promise<service.ScreenshotInfo>;
@@ -202,6 +248,10 @@ export function GetServerStatus():Promise<inference.ServerDownloadStatus>;
 
 export function GetSessionContext(arg1:number):Promise<service.SessionContext>;
 
+export function GetSessionsByFocusPattern(arg1:string,arg2:string,arg3:number,arg4:number):Promise<Array<storage.Session>>;
+
+export function GetSessionsContaining(arg1:string,arg2:number,arg3:number):Promise<Array<storage.Session>>;
+
 export function GetSessionsForDate(arg1:string):Promise<Array<service.SessionSummary>>;
 
 export function GetStorageStats():Promise<service.StorageStats>;
@@ -212,8 +262,14 @@ export function GetSummaryBySession(arg1:number):Promise<storage.Summary>;
 
 export function GetSystemInfo():Promise<Record<string, string>>;
 
+export function GetSystemResourceUsage():Promise<tracker.ResourceUsage>;
+
 export function GetSystemTheme():Promise<string>;
 
+export function GetTaskThreadTimeline(arg1:number):Promise<service.TaskThread>;
+
+export function GetTaskThreads(arg1:boolean):Promise<Array<service.TaskThread>>;
+
 export function GetThumbnailPath(arg1:number):Promise<string>;
 
 export function GetTimelineGridData(arg1:string):Promise<service.TimelineGridData>;
@@ -230,22 +286,34 @@ export function GetUnassignedEventCount():Promise<number>;
 
 export function GetUpdateStatus():Promise<service.UpdateStatus>;
 
+export function GetUsageStatistics():Promise<service.LifetimeStats>;
+
 export function GetVersion():Promise<string>;
 
 export function GetWatchedDirectories():Promise<Array<string>>;
 
 export function GetWeekTimelineData(arg1:string):Promise<service.WeekTimelineData>;
 
+export function GetWeekdayPatterns(arg1:number):Promise<service.WeekdayPatterns>;
+
 export function GetWeeklyStats(arg1:string):Promise<service.WeeklyStats>;
 
+export function GetWindowTitleFrequency(arg1:number,arg2:number,arg3:number):Promise<Array<storage.WindowTitleCount>>;
+
+export function GetWindowTitleTrend(arg1:string,arg2:number):Promise<Array<service.DailyWindowUsage>>;
+
 export function GetYearlyStats(arg1:number):Promise<service.YearlyStats>;
 
+export function HotkeysSupported():Promise<boolean>;
+
 export function IgnoreActivities(arg1:string,arg2:Array<number>):Promise<void>;
 
 export function IsReady():Promise<boolean>;
 
 export function ListHierarchicalSummaries(arg1:string,arg2:number):Promise<Array<storage.HierarchicalSummary>>;
 
+export function MergeSessionsManually(arg1:Array<number>):Promise<storage.Session>;
+
 export function MergeTags(arg1:string,arg2:string):Promise<number>;
 
 export function MigrateHardcodedPatterns():Promise<number>;
@@ -272,10 +340,14 @@ export function RejectAssignmentDraft(arg1:number):Promise<void>;
 
 export function RejectSummaryDraft(arg1:number):Promise<void>;
 
+export function RemoveReportSchedule(arg1:number):Promise<void>;
+
 export function RemoveTagFromSession(arg1:number,arg2:string):Promise<void>;
 
 export function RenameTag(arg1:string,arg2:string):Promise<number>;
 
+export function RenameTaskThread(arg1:number,arg2:string):Promise<void>;
+
 export function ReportIssue(arg1:string,arg2:string,arg3:string,arg4:string,arg5:string):Promise<service.IssueReport>;
 
 export function RestartTracking():Promise<void>;
@@ -290,6 +362,8 @@ export function SetAppTimelineCategory(arg1:string,arg2:string):Promise<void>;
 
 export function SetFileAllowedExtensions(arg1:Array<string>):Promise<void>;
 
+export function SetKeyboardShortcut(arg1:string,arg2:string):Promise<void>;
+
 export function SetProjectsAutoAssign(arg1:boolean):Promise<void>;
 
 export function SetReportIncludeUnassigned(arg1:boolean):Promise<void>;
@@ -302,7 +376,7 @@ export function StartTracking():Promise<void>;
 
 export function StopTracking():Promise<void>;
 
-export function SuggestProject(arg1:storage.AssignmentContext):Promise<service.AssignmentResult>;
+export function SuggestProject(arg1:number):Promise<service.AssignmentResult>;
 
 export function TestIssueWebhook():Promise<void>;
 
@@ -10,6 +10,10 @@ export function AcceptSummaryDraft(arg1) {
   return window['go']['main']['App']['AcceptSummaryDraft'](arg1);
 }
 
+export function AddReportSchedule(arg1) {
+  return window['go']['main']['App']['AddReportSchedule'](arg1);
+}
+
 export function AddTagToSession(arg1, arg2) {
   return window['go']['main']['App']['AddTagToSession'](arg1, arg2);
 }
@@ -22,6 +26,10 @@ export function AssignEventToProject(arg1, arg2, arg3) {
   return window['go']['main']['App']['AssignEventToProject'](arg1, arg2, arg3);
 }
 
+export function AttachSessionToTaskThread(arg1, arg2) {
+  return window['go']['main']['App']['AttachSessionToTaskThread'](arg1, arg2);
+}
+
 export function AutoDiscoverProjects() {
   return window['go']['main']['App']['AutoDiscoverProjects']();
 }
@@ -46,10 +54,26 @@ export function BulkAssignProject(arg1) {
   return window['go']['main']['App']['BulkAssignProject'](arg1);
 }
 
+export function BulkSuggestProjects(arg1, arg2) {
+  return window['go']['main']['App']['BulkSuggestProjects'](arg1, arg2);
+}
+
 export function CheckForUpdate() {
   return window['go']['main']['App']['CheckForUpdate']();
 }
 
+export function CloseTaskThread(arg1) {
+  return window['go']['main']['App']['CloseTaskThread'](arg1);
+}
+
+export function CompareWithSameDayLastMonth(arg1) {
+  return window['go']['main']['App']['CompareWithSameDayLastMonth'](arg1);
+}
+
+export function CompareWithSameDayLastWeek(arg1) {
+  return window['go']['main']['App']['CompareWithSameDayLastWeek'](arg1);
+}
+
 export function CreateProject(arg1, arg2, arg3) {
   return window['go']['main']['App']['CreateProject'](arg1, arg2, arg3);
 }
@@ -58,6 +82,10 @@ export function CreateProjectRule(arg1) {
   return window['go']['main']['App']['CreateProjectRule'](arg1);
 }
 
+export function CreateTaskThread(arg1) {
+  return window['go']['main']['App']['CreateTaskThread'](arg1);
+}
+
 export function DeleteAFKEvents(arg1) {
   return window['go']['main']['App']['DeleteAFKEvents'](arg1);
 }
@@ -130,10 +158,18 @@ export function DeleteTag(arg1) {
   return window['go']['main']['App']['DeleteTag'](arg1);
 }
 
+export function DeleteTaskThread(arg1) {
+  return window['go']['main']['App']['DeleteTaskThread'](arg1);
+}
+
 export function DeleteTimelineCategoryRule(arg1) {
   return window['go']['main']['App']['DeleteTimelineCategoryRule'](arg1);
 }
 
+export function DetachSessionFromTaskThread(arg1, arg2) {
+  return window['go']['main']['App']['DetachSessionFromTaskThread'](arg1, arg2);
+}
+
 export function DiscoverGitRepositories(arg1, arg2) {
   return window['go']['main']['App']['DiscoverGitRepositories'](arg1, arg2);
 }
@@ -174,6 +210,14 @@ export function GenerateWeeklySummaryMarkdown(arg1, arg2) {
   return window['go']['main']['App']['GenerateWeeklySummaryMarkdown'](arg1, arg2);
 }
 
+export function GetAbstractivityScore(arg1) {
+  return window['go']['main']['App']['GetAbstractivityScore'](arg1);
+}
+
+export function GetActiveSession() {
+  return window['go']['main']['App']['GetActiveSession']();
+}
+
 export function GetActivityTags(arg1) {
   return window['go']['main']['App']['GetActivityTags'](arg1);
 }
@@ -218,6 +262,14 @@ export function GetCategorizationRules() {
   return window['go']['main']['App']['GetCategorizationRules']();
 }
 
+export function GetCodeEditingStats(arg1) {
+  return window['go']['main']['App']['GetCodeEditingStats'](arg1);
+}
+
+export function GetCodeEditingTrend(arg1) {
+  return window['go']['main']['App']['GetCodeEditingTrend'](arg1);
+}
+
 export function GetConfig() {
   return window['go']['main']['App']['GetConfig']();
 }
@@ -238,8 +290,8 @@ export function GetDailyStats(arg1) {
   return window['go']['main']['App']['GetDailyStats'](arg1);
 }
 
-export function GetDailyStatsWithComparison(arg1) {
-  return window['go']['main']['App']['GetDailyStatsWithComparison'](arg1);
+export function GetDailyStatsWithComparison(arg1, arg2) {
+  return window['go']['main']['App']['GetDailyStatsWithComparison'](arg1, arg2);
 }
 
 export function GetDailySummaries(arg1) {
@@ -254,6 +306,10 @@ export function GetDataSourceStats(arg1, arg2) {
   return window['go']['main']['App']['GetDataSourceStats'](arg1, arg2);
 }
 
+export function GetDistractedHours(arg1) {
+  return window['go']['main']['App']['GetDistractedHours'](arg1);
+}
+
 export function GetEntriesForDate(arg1) {
   return window['go']['main']['App']['GetEntriesForDate'](arg1);
 }
@@ -270,10 +326,18 @@ export function GetFocusEventByID(arg1) {
   return window['go']['main']['App']['GetFocusEventByID'](arg1);
 }
 
+export function GetFocusEventsByWindow(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetFocusEventsByWindow'](arg1, arg2, arg3);
+}
+
 export function GetHierarchicalSummary(arg1, arg2) {
   return window['go']['main']['App']['GetHierarchicalSummary'](arg1, arg2);
 }
 
+export function GetHistoricalResourceUsage(arg1) {
+  return window['go']['main']['App']['GetHistoricalResourceUsage'](arg1);
+}
+
 export function GetHourlyActivity(arg1) {
   return window['go']['main']['App']['GetHourlyActivity'](arg1);
 }
@@ -282,6 +346,10 @@ export function GetHourlyActivityHeatmap() {
   return window['go']['main']['App']['GetHourlyActivityHeatmap']();
 }
 
+export function GetInferenceBenchmark() {
+  return window['go']['main']['App']['GetInferenceBenchmark']();
+}
+
 export function GetInferenceSetupStatus() {
   return window['go']['main']['App']['GetInferenceSetupStatus']();
 }
@@ -298,14 +366,26 @@ export function GetIssueReports(arg1) {
   return window['go']['main']['App']['GetIssueReports'](arg1);
 }
 
+export function GetKeyboardShortcuts() {
+  return window['go']['main']['App']['GetKeyboardShortcuts']();
+}
+
 export function GetLatestHierarchicalSummaries() {
   return window['go']['main']['App']['GetLatestHierarchicalSummaries']();
 }
 
+export function GetMachineID() {
+  return window['go']['main']['App']['GetMachineID']();
+}
+
 export function GetMonthlyStats(arg1, arg2) {
   return window['go']['main']['App']['GetMonthlyStats'](arg1, arg2);
 }
 
+export function GetMostDistractedDay(arg1) {
+  return window['go']['main']['App']['GetMostDistractedDay'](arg1);
+}
+
 export function GetOllamaInstallInfo() {
   return window['go']['main']['App']['GetOllamaInstallInfo']();
 }
@@ -362,6 +442,18 @@ export function GetReportIncludeUnassigned() {
   return window['go']['main']['App']['GetReportIncludeUnassigned']();
 }
 
+export function GetReportSchedules() {
+  return window['go']['main']['App']['GetReportSchedules']();
+}
+
+export function GetReportTimeline(arg1, arg2) {
+  return window['go']['main']['App']['GetReportTimeline'](arg1, arg2);
+}
+
+export function GetReportTimelineHTML(arg1, arg2) {
+  return window['go']['main']['App']['GetReportTimelineHTML'](arg1, arg2);
+}
+
 export function GetScreenshot(arg1) {
   return window['go']['main']['App']['GetScreenshot'](arg1);
 }
@@ -394,6 +486,14 @@ export function GetSessionContext(arg1) {
   return window['go']['main']['App']['GetSessionContext'](arg1);
 }
 
+export function GetSessionsByFocusPattern(arg1, arg2, arg3, arg4) {
+  return window['go']['main']['App']['GetSessionsByFocusPattern'](arg1, arg2, arg3, arg4);
+}
+
+export function GetSessionsContaining(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetSessionsContaining'](arg1, arg2, arg3);
+}
+
 export function GetSessionsForDate(arg1) {
   return window['go']['main']['App']['GetSessionsForDate'](arg1);
 }
@@ -414,10 +514,22 @@ export function GetSystemInfo() {
   return window['go']['main']['App']['GetSystemInfo']();
 }
 
+export function GetSystemResourceUsage() {
+  return window['go']['main']['App']['GetSystemResourceUsage']();
+}
+
 export function GetSystemTheme() {
   return window['go']['main']['App']['GetSystemTheme']();
 }
 
+export function GetTaskThreadTimeline(arg1) {
+  return window['go']['main']['App']['GetTaskThreadTimeline'](arg1);
+}
+
+export function GetTaskThreads(arg1) {
+  return window['go']['main']['App']['GetTaskThreads'](arg1);
+}
+
 export function GetThumbnailPath(arg1) {
   return window['go']['main']['App']['GetThumbnailPath'](arg1);
 }
@@ -450,6 +562,10 @@ export function GetUpdateStatus() {
   return window['go']['main']['App']['GetUpdateStatus']();
 }
 
+export function GetUsageStatistics() {
+  return window['go']['main']['App']['GetUsageStatistics']();
+}
+
 export function GetVersion() {
   return window['go']['main']['App']['GetVersion']();
 }
@@ -462,14 +578,30 @@ export function GetWeekTimelineData(arg1) {
   return window['go']['main']['App']['GetWeekTimelineData'](arg1);
 }
 
+export function GetWeekdayPatterns(arg1) {
+  return window['go']['main']['App']['GetWeekdayPatterns'](arg1);
+}
+
 export function GetWeeklyStats(arg1) {
   return window['go']['main']['App']['GetWeeklyStats'](arg1);
 }
 
+export function GetWindowTitleFrequency(arg1, arg2, arg3) {
+  return window['go']['main']['App']['GetWindowTitleFrequency'](arg1, arg2, arg3);
+}
+
+export function GetWindowTitleTrend(arg1, arg2) {
+  return window['go']['main']['App']['GetWindowTitleTrend'](arg1, arg2);
+}
+
 export function GetYearlyStats(arg1) {
   return window['go']['main']['App']['GetYearlyStats'](arg1);
 }
 
+export function HotkeysSupported() {
+  return window['go']['main']['App']['HotkeysSupported']();
+}
+
 export function IgnoreActivities(arg1, arg2) {
   return window['go']['main']['App']['IgnoreActivities'](arg1, arg2);
 }
@@ -482,6 +614,10 @@ export function ListHierarchicalSummaries(arg1, arg2) {
   return window['go']['main']['App']['ListHierarchicalSummaries'](arg1, arg2);
 }
 
+export function MergeSessionsManually(arg1) {
+  return window['go']['main']['App']['MergeSessionsManually'](arg1);
+}
+
 export function MergeTags(arg1, arg2) {
   return window['go']['main']['App']['MergeTags'](arg1, arg2);
 }
@@ -534,6 +670,10 @@ export function RejectSummaryDraft(arg1) {
   return window['go']['main']['App']['RejectSummaryDraft'](arg1);
 }
 
+export function RemoveReportSchedule(arg1) {
+  return window['go']['main']['App']['RemoveReportSchedule'](arg1);
+}
+
 export function RemoveTagFromSession(arg1, arg2) {
   return window['go']['main']['App']['RemoveTagFromSession'](arg1, arg2);
 }
@@ -542,6 +682,10 @@ export function RenameTag(arg1, arg2) {
   return window['go']['main']['App']['RenameTag'](arg1, arg2);
 }
 
+export function RenameTaskThread(arg1, arg2) {
+  return window['go']['main']['App']['RenameTaskThread'](arg1, arg2);
+}
+
 export function ReportIssue(arg1, arg2, arg3, arg4, arg5) {
   return window['go']['main']['App']['ReportIssue'](arg1, arg2, arg3, arg4, arg5);
 }
@@ -570,6 +714,10 @@ export function SetFileAllowedExtensions(arg1) {
   return window['go']['main']['App']['SetFileAllowedExtensions'](arg1);
 }
 
+export function SetKeyboardShortcut(arg1, arg2) {
+  return window['go']['main']['App']['SetKeyboardShortcut'](arg1, arg2);
+}
+
 export function SetProjectsAutoAssign(arg1) {
   return window['go']['main']['App']['SetProjectsAutoAssign'](arg1);
 }
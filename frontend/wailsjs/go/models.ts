@@ -1,5 +1,29 @@
 export namespace inference {
 	
+	export class BenchmarkResult {
+	    engine: string;
+	    model: string;
+	    promptTokens: number;
+	    tokensGenerated: number;
+	    ttft: number;
+	    totalDuration: number;
+	    tokensPerSecond: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new BenchmarkResult(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.engine = source["engine"];
+	        this.model = source["model"];
+	        this.promptTokens = source["promptTokens"];
+	        this.tokensGenerated = source["tokensGenerated"];
+	        this.ttft = source["ttft"];
+	        this.totalDuration = source["totalDuration"];
+	        this.tokensPerSecond = source["tokensPerSecond"];
+	    }
+	}
 	export class BundledStatus {
 	    available: boolean;
 	    running: boolean;
@@ -619,6 +643,24 @@ export namespace service {
 	        this.sitesVisitedPercent = source["sitesVisitedPercent"];
 	    }
 	}
+	export class Insight {
+	    type: string;
+	    title: string;
+	    body: string;
+	    severity: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new Insight(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.type = source["type"];
+	        this.title = source["title"];
+	        this.body = source["body"];
+	        this.severity = source["severity"];
+	    }
+	}
 	export class TimelineConfig {
 	    minActivityDurationSeconds: number;
 	    titleDisplay: string;
@@ -643,6 +685,8 @@ export namespace service {
 	    autoUpdate: boolean;
 	    checkIntervalHours: number;
 	    afkRestartMinutes: number;
+	    canaryEnabled: boolean;
+	    canaryFraction: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new UpdateConfig(source);
@@ -653,6 +697,8 @@ export namespace service {
 	        this.autoUpdate = source["autoUpdate"];
 	        this.checkIntervalHours = source["checkIntervalHours"];
 	        this.afkRestartMinutes = source["afkRestartMinutes"];
+	        this.canaryEnabled = source["canaryEnabled"];
+	        this.canaryFraction = source["canaryFraction"];
 	    }
 	}
 	export class IssuesConfig {
@@ -959,6 +1005,24 @@ export namespace service {
 	        this.activeMinutes = source["activeMinutes"];
 	    }
 	}
+	export class AbstractivityScore {
+	    score: number;
+	    concreteMinutes: number;
+	    abstractMinutes: number;
+	    indicators: string[];
+	
+	    static createFrom(source: any = {}) {
+	        return new AbstractivityScore(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.score = source["score"];
+	        this.concreteMinutes = source["concreteMinutes"];
+	        this.abstractMinutes = source["abstractMinutes"];
+	        this.indicators = source["indicators"];
+	    }
+	}
 	export class DailyStats {
 	    date: string;
 	    totalScreenshots: number;
@@ -969,6 +1033,7 @@ export namespace service {
 	    gitCommits: number;
 	    filesModified: number;
 	    sitesVisited: number;
+	    abstractivityScore?: AbstractivityScore;
 	    comparison?: Comparison;
 	
 	    static createFrom(source: any = {}) {
@@ -986,6 +1051,7 @@ export namespace service {
 	        this.gitCommits = source["gitCommits"];
 	        this.filesModified = source["filesModified"];
 	        this.sitesVisited = source["sitesVisited"];
+	        this.abstractivityScore = this.convertValues(source["abstractivityScore"], AbstractivityScore);
 	        this.comparison = this.convertValues(source["comparison"], Comparison);
 	    }
 	
@@ -1239,6 +1305,60 @@ export namespace service {
 		}
 	}
 	
+	export class CodeEditingStats {
+	    totalFilesEdited: number;
+	    totalFilesCreated: number;
+	    totalFilesDeleted: number;
+	    editsByExtension: Record<string, number>;
+	    peakEditingHour: number;
+	    activeEditingMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new CodeEditingStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.totalFilesEdited = source["totalFilesEdited"];
+	        this.totalFilesCreated = source["totalFilesCreated"];
+	        this.totalFilesDeleted = source["totalFilesDeleted"];
+	        this.editsByExtension = source["editsByExtension"];
+	        this.peakEditingHour = source["peakEditingHour"];
+	        this.activeEditingMinutes = source["activeEditingMinutes"];
+	    }
+	}
+	export class DailyCodeStats {
+	    date: string;
+	    filesEdited: number;
+	    eventCount: number;
+	    activeEditingMinutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyCodeStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.filesEdited = source["filesEdited"];
+	        this.eventCount = source["eventCount"];
+	        this.activeEditingMinutes = source["activeEditingMinutes"];
+	    }
+	}
+	export class DailyWindowUsage {
+	    date: string;
+	    minutes: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DailyWindowUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.date = source["date"];
+	        this.minutes = source["minutes"];
+	    }
+	}
 	export class DaySpan {
 	    startTime: number;
 	    endTime: number;
@@ -1267,6 +1387,7 @@ export namespace service {
 	    daySpan?: DaySpan;
 	    breakdown: Record<string, number>;
 	    breakdownPercent: Record<string, number>;
+	    codeEditingStats?: CodeEditingStats;
 	
 	    static createFrom(source: any = {}) {
 	        return new DayStats(source);
@@ -1285,6 +1406,7 @@ export namespace service {
 	        this.daySpan = this.convertValues(source["daySpan"], DaySpan);
 	        this.breakdown = source["breakdown"];
 	        this.breakdownPercent = source["breakdownPercent"];
+	        this.codeEditingStats = this.convertValues(source["codeEditingStats"], CodeEditingStats);
 	    }
 	
 		convertValues(a: any, classs: any, asMap: boolean = false): any {
@@ -1306,6 +1428,26 @@ export namespace service {
 		}
 	}
 	
+	export class DistractedHourEntry {
+	    hour: number;
+	    distractingMinutes: number;
+	    distractingApps: string[];
+	    distractingDomains: string[];
+	    distractionScore: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new DistractedHourEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.hour = source["hour"];
+	        this.distractingMinutes = source["distractingMinutes"];
+	        this.distractingApps = source["distractingApps"];
+	        this.distractingDomains = source["distractingDomains"];
+	        this.distractionScore = source["distractionScore"];
+	    }
+	}
 	export class EntryBlock {
 	    id: number;
 	    eventType: string;
@@ -1483,6 +1625,39 @@ export namespace service {
 	    }
 	}
 	
+	export class LifetimeStats {
+	    daysTracked: number;
+	    totalActiveHours: number;
+	    totalScreenshots: number;
+	    totalCommits: number;
+	    totalShellCommands: number;
+	    totalBrowserVisits: number;
+	    totalSessions: number;
+	    oldestDataDate: string;
+	    newestDataDate: string;
+	    uniqueAppsTracked: number;
+	    uniqueDomainsVisited: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new LifetimeStats(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.daysTracked = source["daysTracked"];
+	        this.totalActiveHours = source["totalActiveHours"];
+	        this.totalScreenshots = source["totalScreenshots"];
+	        this.totalCommits = source["totalCommits"];
+	        this.totalShellCommands = source["totalShellCommands"];
+	        this.totalBrowserVisits = source["totalBrowserVisits"];
+	        this.totalSessions = source["totalSessions"];
+	        this.oldestDataDate = source["oldestDataDate"];
+	        this.newestDataDate = source["newestDataDate"];
+	        this.uniqueAppsTracked = source["uniqueAppsTracked"];
+	        this.uniqueDomainsVisited = source["uniqueDomainsVisited"];
+	    }
+	}
+	
 	export class MonthStats {
 	    monthNumber: number;
 	    monthName: string;
@@ -1561,6 +1736,8 @@ export namespace service {
 	    distractingMinutes: number;
 	    totalMinutes: number;
 	    productivePercentage: number;
+	    contextSwitchCount: number;
+	    contextSwitchPenaltyMinutes: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new ProductivityScore(source);
@@ -1574,6 +1751,8 @@ export namespace service {
 	        this.distractingMinutes = source["distractingMinutes"];
 	        this.totalMinutes = source["totalMinutes"];
 	        this.productivePercentage = source["productivePercentage"];
+	        this.contextSwitchCount = source["contextSwitchCount"];
+	        this.contextSwitchPenaltyMinutes = source["contextSwitchPenaltyMinutes"];
 	    }
 	}
 	export class ProjectRuleInput {
@@ -1671,6 +1850,72 @@ export namespace service {
 	        this.createdAt = source["createdAt"];
 	    }
 	}
+	export class ReportTimelineEntry {
+	    id: number;
+	    title: string;
+	    timeRange: string;
+	    reportType: string;
+	    format: string;
+	    createdAt: number;
+	    version: number;
+	    parentId: number;
+	    totalActiveMinutes: number;
+	    commitCount: number;
+	    topProject: string;
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportTimelineEntry(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.title = source["title"];
+	        this.timeRange = source["timeRange"];
+	        this.reportType = source["reportType"];
+	        this.format = source["format"];
+	        this.createdAt = source["createdAt"];
+	        this.version = source["version"];
+	        this.parentId = source["parentId"];
+	        this.totalActiveMinutes = source["totalActiveMinutes"];
+	        this.commitCount = source["commitCount"];
+	        this.topProject = source["topProject"];
+	    }
+	}
+	export class ReportTimeline {
+	    start: number;
+	    end: number;
+	    reports: ReportTimelineEntry[];
+	
+	    static createFrom(source: any = {}) {
+	        return new ReportTimeline(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.start = source["start"];
+	        this.end = source["end"];
+	        this.reports = this.convertValues(source["reports"], ReportTimelineEntry);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class RulePreview {
 	    matchCount: number;
 	    sampleMatches: string[];
@@ -1869,6 +2114,10 @@ export namespace service {
 	    hasBrowser: boolean;
 	    isDraft: boolean;
 	    draftStatus: string;
+	    isActive: boolean;
+	    currentAppName: string;
+	    currentWindowTitle: string;
+	    sessionElapsedSeconds: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new SessionSummary(source);
@@ -1893,6 +2142,10 @@ export namespace service {
 	        this.hasBrowser = source["hasBrowser"];
 	        this.isDraft = source["isDraft"];
 	        this.draftStatus = source["draftStatus"];
+	        this.isActive = source["isActive"];
+	        this.currentAppName = source["currentAppName"];
+	        this.currentWindowTitle = source["currentWindowTitle"];
+	        this.sessionElapsedSeconds = source["sessionElapsedSeconds"];
 	    }
 	}
 	export class SessionSummaryWithPosition {
@@ -1913,6 +2166,10 @@ export namespace service {
 	    hasBrowser: boolean;
 	    isDraft: boolean;
 	    draftStatus: string;
+	    isActive: boolean;
+	    currentAppName: string;
+	    currentWindowTitle: string;
+	    sessionElapsedSeconds: number;
 	    hourOffset: number;
 	    minuteOffset: number;
 	    pixelPosition: number;
@@ -1942,6 +2199,10 @@ export namespace service {
 	        this.hasBrowser = source["hasBrowser"];
 	        this.isDraft = source["isDraft"];
 	        this.draftStatus = source["draftStatus"];
+	        this.isActive = source["isActive"];
+	        this.currentAppName = source["currentAppName"];
+	        this.currentWindowTitle = source["currentWindowTitle"];
+	        this.sessionElapsedSeconds = source["sessionElapsedSeconds"];
 	        this.hourOffset = source["hourOffset"];
 	        this.minuteOffset = source["minuteOffset"];
 	        this.pixelPosition = source["pixelPosition"];
@@ -2028,6 +2289,50 @@ export namespace service {
 	        this.percentage = source["percentage"];
 	    }
 	}
+	export class TaskThread {
+	    id: number;
+	    name: string;
+	    createdAt: number;
+	    closedAt: sql.NullInt64;
+	    isOpen: boolean;
+	    totalMinutes: number;
+	    daySpan: number;
+	    sessions: SessionSummary[];
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskThread(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.createdAt = source["createdAt"];
+	        this.closedAt = this.convertValues(source["closedAt"], sql.NullInt64);
+	        this.isOpen = source["isOpen"];
+	        this.totalMinutes = source["totalMinutes"];
+	        this.daySpan = source["daySpan"];
+	        this.sessions = this.convertValues(source["sessions"], SessionSummary);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class TimeRange {
 	    start: number;
 	    end: number;
@@ -2125,6 +2430,7 @@ export namespace service {
 	    releaseNotes: string;
 	    downloadUrl: string;
 	    publishedAt: string;
+	    canary: boolean;
 	
 	    static createFrom(source: any = {}) {
 	        return new UpdateInfo(source);
@@ -2136,6 +2442,7 @@ export namespace service {
 	        this.releaseNotes = source["releaseNotes"];
 	        this.downloadUrl = source["downloadUrl"];
 	        this.publishedAt = source["publishedAt"];
+	        this.canary = source["canary"];
 	    }
 	}
 	export class UpdateStatus {
@@ -2303,6 +2610,68 @@ export namespace service {
 		    return a;
 		}
 	}
+	export class WeekdayProfile {
+	    weekday: string;
+	    activeDays: number;
+	    averageActiveMinutes: number;
+	    averageCommits: number;
+	    averageMeetingMinutes: number;
+	    averageDeepWorkMinutes: number;
+	    bestHour: number;
+	    typicalStartHour: number;
+	    typicalEndHour: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekdayProfile(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.weekday = source["weekday"];
+	        this.activeDays = source["activeDays"];
+	        this.averageActiveMinutes = source["averageActiveMinutes"];
+	        this.averageCommits = source["averageCommits"];
+	        this.averageMeetingMinutes = source["averageMeetingMinutes"];
+	        this.averageDeepWorkMinutes = source["averageDeepWorkMinutes"];
+	        this.bestHour = source["bestHour"];
+	        this.typicalStartHour = source["typicalStartHour"];
+	        this.typicalEndHour = source["typicalEndHour"];
+	    }
+	}
+	export class WeekdayPatterns {
+	    days: WeekdayProfile[];
+	    dataWeeks: number;
+	    insights: Insight[];
+	
+	    static createFrom(source: any = {}) {
+	        return new WeekdayPatterns(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.days = this.convertValues(source["days"], WeekdayProfile);
+	        this.dataWeeks = source["dataWeeks"];
+	        this.insights = this.convertValues(source["insights"], Insight);
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class WeeklyStats {
 	    startDate: string;
 	    endDate: string;
@@ -2475,30 +2844,6 @@ export namespace storage {
 	        this.updatedAt = source["updatedAt"];
 	    }
 	}
-	export class AssignmentContext {
-	    appName?: string;
-	    windowTitle?: string;
-	    url?: string;
-	    gitRepo?: string;
-	    filePath?: string;
-	    branchName?: string;
-	    domain?: string;
-	
-	    static createFrom(source: any = {}) {
-	        return new AssignmentContext(source);
-	    }
-	
-	    constructor(source: any = {}) {
-	        if ('string' === typeof source) source = JSON.parse(source);
-	        this.appName = source["appName"];
-	        this.windowTitle = source["windowTitle"];
-	        this.url = source["url"];
-	        this.gitRepo = source["gitRepo"];
-	        this.filePath = source["filePath"];
-	        this.branchName = source["branchName"];
-	        this.domain = source["domain"];
-	    }
-	}
 	export class AssignmentMetrics {
 	    periodStart: number;
 	    periodEnd: number;
@@ -2893,6 +3238,76 @@ export namespace storage {
 	        this.patternCount = source["patternCount"];
 	    }
 	}
+	export class ResourceSnapshot {
+	    id: number;
+	    timestamp: number;
+	    cpuPercent: number;
+	    memoryMb: number;
+	    dbSizeMb: number;
+	    screenshotDirSizeMb: number;
+	    openFileDescriptors: number;
+	    goRoutineCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourceSnapshot(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.timestamp = source["timestamp"];
+	        this.cpuPercent = source["cpuPercent"];
+	        this.memoryMb = source["memoryMb"];
+	        this.dbSizeMb = source["dbSizeMb"];
+	        this.screenshotDirSizeMb = source["screenshotDirSizeMb"];
+	        this.openFileDescriptors = source["openFileDescriptors"];
+	        this.goRoutineCount = source["goRoutineCount"];
+	    }
+	}
+	export class ScheduledReport {
+	    id: number;
+	    reportType: string;
+	    timeRange: string;
+	    cronExpression: string;
+	    recipients: string[];
+	    format: string;
+	    lastRunAt: sql.NullInt64;
+	    createdAt: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ScheduledReport(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.reportType = source["reportType"];
+	        this.timeRange = source["timeRange"];
+	        this.cronExpression = source["cronExpression"];
+	        this.recipients = source["recipients"];
+	        this.format = source["format"];
+	        this.lastRunAt = this.convertValues(source["lastRunAt"], sql.NullInt64);
+	        this.createdAt = source["createdAt"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class Screenshot {
 	    id: number;
 	    timestamp: number;
@@ -3121,6 +3536,44 @@ export namespace storage {
 	        this.count = source["count"];
 	    }
 	}
+	export class TaskThread {
+	    id: number;
+	    name: string;
+	    createdAt: number;
+	    closedAt: sql.NullInt64;
+	    isOpen: boolean;
+	
+	    static createFrom(source: any = {}) {
+	        return new TaskThread(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.id = source["id"];
+	        this.name = source["name"];
+	        this.createdAt = source["createdAt"];
+	        this.closedAt = this.convertValues(source["closedAt"], sql.NullInt64);
+	        this.isOpen = source["isOpen"];
+	    }
+	
+		convertValues(a: any, classs: any, asMap: boolean = false): any {
+		    if (!a) {
+		        return a;
+		    }
+		    if (a.slice && a.map) {
+		        return (a as any[]).map(elem => this.convertValues(elem, classs));
+		    } else if ("object" === typeof a) {
+		        if (asMap) {
+		            for (const key of Object.keys(a)) {
+		                a[key] = new classs(a[key]);
+		            }
+		            return a;
+		        }
+		        return new classs(a);
+		    }
+		    return a;
+		}
+	}
 	export class WindowFocusEvent {
 	    id: number;
 	    windowTitle: string;
@@ -3175,7 +3628,24 @@ export namespace storage {
 		    return a;
 		}
 	}
-
+	export class WindowTitleCount {
+	    windowTitle: string;
+	    appName: string;
+	    count: number;
+	    totalSeconds: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new WindowTitleCount(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.windowTitle = source["windowTitle"];
+	        this.appName = source["appName"];
+	        this.count = source["count"];
+	        this.totalSeconds = source["totalSeconds"];
+	    }
+	}
 }
 
 export namespace tracker {
@@ -3204,6 +3674,28 @@ export namespace tracker {
 	        this.isPrimary = source["isPrimary"];
 	    }
 	}
+	export class ResourceUsage {
+	    cpuPercent: number;
+	    memoryMb: number;
+	    dbSizeMb: number;
+	    screenshotDirSizeMb: number;
+	    openFileDescriptors: number;
+	    goRoutineCount: number;
+	
+	    static createFrom(source: any = {}) {
+	        return new ResourceUsage(source);
+	    }
+	
+	    constructor(source: any = {}) {
+	        if ('string' === typeof source) source = JSON.parse(source);
+	        this.cpuPercent = source["cpuPercent"];
+	        this.memoryMb = source["memoryMb"];
+	        this.dbSizeMb = source["dbSizeMb"];
+	        this.screenshotDirSizeMb = source["screenshotDirSizeMb"];
+	        this.openFileDescriptors = source["openFileDescriptors"];
+	        this.goRoutineCount = source["goRoutineCount"];
+	    }
+	}
 
 }
 
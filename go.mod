@@ -13,9 +13,11 @@ require (
 	github.com/getsentry/sentry-go v0.41.0
 	github.com/jezek/xgb v1.3.0
 	github.com/kbinani/screenshot v0.0.0-20250624051815-089614a94018
+	github.com/klauspost/compress v1.18.0
 	github.com/mattn/go-sqlite3 v1.14.33
 	github.com/wailsapp/wails/v2 v2.11.0
 	github.com/yalue/onnxruntime_go v1.25.0
+	golang.org/x/sync v0.11.0
 )
 
 require (
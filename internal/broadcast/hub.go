@@ -0,0 +1,76 @@
+// Package broadcast fans out events to any number of subscribers.
+package broadcast
+
+import (
+	"errors"
+	"sync"
+)
+
+// subscriberBuffer is how many events a subscriber can fall behind before new events
+// are dropped for it.
+const subscriberBuffer = 16
+
+// ErrTooManySubscribers is returned by Subscribe when the subscriber limit is reached.
+var ErrTooManySubscribers = errors.New("too many subscribers")
+
+// Event is a named event with a payload.
+type Event struct {
+	Type string
+	Data any
+}
+
+// Hub delivers published events to every subscriber. Each subscriber has its own
+// buffered channel, so a slow subscriber misses events rather than blocking publishers.
+type Hub struct {
+	mu      sync.Mutex
+	clients map[chan Event]struct{}
+}
+
+// NewHub creates a new Hub with no subscribers.
+func NewHub() *Hub {
+	return &Hub{clients: make(map[chan Event]struct{})}
+}
+
+// Subscribe registers a subscriber, refusing it if limit subscribers are already
+// registered (0 means no limit). The returned function unsubscribes and closes the channel.
+func (h *Hub) Subscribe(limit int) (<-chan Event, func(), error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if limit > 0 && len(h.clients) >= limit {
+		return nil, nil, ErrTooManySubscribers
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	h.clients[ch] = struct{}{}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.clients, ch)
+			close(ch)
+		})
+	}
+	return ch, unsubscribe, nil
+}
+
+// Publish sends an event to every subscriber without blocking.
+func (h *Hub) Publish(eventType string, data any) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.clients {
+		select {
+		case ch <- Event{Type: eventType, Data: data}:
+		default:
+			// Subscriber is full; drop the event for it
+		}
+	}
+}
+
+// SubscriberCount returns the number of current subscribers.
+func (h *Hub) SubscriberCount() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.clients)
+}
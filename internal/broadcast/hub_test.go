@@ -0,0 +1,65 @@
+package broadcast
+
+import "testing"
+
+func TestHub_PublishFansOut(t *testing.T) {
+	hub := NewHub()
+	ch1, unsub1, err := hub.Subscribe(0)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	defer unsub1()
+	ch2, unsub2, err := hub.Subscribe(0)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	hub.Publish("focus", "Firefox")
+	for i, ch := range []<-chan Event{ch1, ch2} {
+		evt := <-ch
+		if evt.Type != "focus" || evt.Data != "Firefox" {
+			t.Errorf("subscriber %d got %+v", i+1, evt)
+		}
+	}
+
+	unsub2()
+	unsub2() // Unsubscribing twice is harmless
+	if _, ok := <-ch2; ok {
+		t.Error("expected channel to be closed after unsubscribe")
+	}
+	if n := hub.SubscriberCount(); n != 1 {
+		t.Errorf("SubscriberCount = %d, want 1", n)
+	}
+}
+
+func TestHub_SubscribeLimit(t *testing.T) {
+	hub := NewHub()
+	_, unsub, err := hub.Subscribe(1)
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	if _, _, err := hub.Subscribe(1); err != ErrTooManySubscribers {
+		t.Errorf("err = %v, want ErrTooManySubscribers", err)
+	}
+	unsub()
+	if _, _, err := hub.Subscribe(1); err != nil {
+		t.Errorf("expected a free slot after unsubscribe, got %v", err)
+	}
+}
+
+func TestHub_SlowSubscriberDropsEvents(t *testing.T) {
+	hub := NewHub()
+	ch, unsub, _ := hub.Subscribe(0)
+	defer unsub()
+
+	// Publishing past the buffer must not block
+	for i := 0; i < subscriberBuffer*2; i++ {
+		hub.Publish("screenshot", i)
+	}
+	if len(ch) != subscriberBuffer {
+		t.Errorf("buffered %d events, want %d", len(ch), subscriberBuffer)
+	}
+	if evt := <-ch; evt.Data != 0 {
+		t.Errorf("first event = %v, want the oldest (0)", evt.Data)
+	}
+}
@@ -0,0 +1,395 @@
+package inference
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultBenchmarkPromptTokens is the synthetic prompt length used by the settings page.
+	DefaultBenchmarkPromptTokens = 512
+	// DefaultBenchmarkOutputTokens is the generation length used by the settings page.
+	DefaultBenchmarkOutputTokens = 128
+)
+
+// Backend streams a completion from an inference engine. onToken is called with each chunk
+// of output as it arrives. Returns the number of tokens generated.
+type Backend interface {
+	Stream(prompt string, maxTokens int, onToken func(chunk string)) (int, error)
+}
+
+// BenchmarkResult contains latency measurements for the configured engine.
+// Durations are serialized as nanoseconds.
+type BenchmarkResult struct {
+	Engine          string        `json:"engine"`
+	Model           string        `json:"model"`
+	PromptTokens    int           `json:"promptTokens"`
+	TokensGenerated int           `json:"tokensGenerated"`
+	TTFT            time.Duration `json:"ttft"`          // Time to first token
+	TotalDuration   time.Duration `json:"totalDuration"` // Request start to last token
+	TokensPerSecond float64       `json:"tokensPerSecond"`
+}
+
+// Benchmark sends a synthetic prompt of about promptTokens tokens to the configured engine
+// and measures time-to-first-token and generation speed for up to maxOutputTokens tokens.
+func (s *Service) Benchmark(promptTokens, maxOutputTokens int) (*BenchmarkResult, error) {
+	if s.config == nil {
+		return nil, fmt.Errorf("inference not configured")
+	}
+	if promptTokens <= 0 || maxOutputTokens <= 0 {
+		return nil, fmt.Errorf("token counts must be positive")
+	}
+
+	backend, model, err := s.backend()
+	if err != nil {
+		return nil, err
+	}
+	return runBenchmark(backend, string(s.config.Engine), model, promptTokens, maxOutputTokens)
+}
+
+// backend returns a streaming Backend for the configured engine and the model it uses.
+func (s *Service) backend() (Backend, string, error) {
+	switch s.config.Engine {
+	case EngineBundled:
+		if s.bundled == nil {
+			return nil, "", fmt.Errorf("bundled engine not initialized")
+		}
+		if !s.bundled.IsRunning() {
+			if err := s.bundled.Start(); err != nil {
+				return nil, "", fmt.Errorf("failed to start bundled server: %w", err)
+			}
+		}
+		return &bundledBackend{client: s.client, port: s.bundled.config.Port}, "bundled:" + filepath.Base(s.config.Bundled.ModelPath), nil
+	case EngineOllama:
+		if s.config.Ollama == nil {
+			return nil, "", fmt.Errorf("Ollama not configured")
+		}
+		return &ollamaBackend{client: s.client, config: s.config.Ollama}, s.config.Ollama.Model, nil
+	case EngineCloud:
+		if s.config.Cloud == nil {
+			return nil, "", fmt.Errorf("Cloud API not configured")
+		}
+		switch s.config.Cloud.Provider {
+		case "anthropic":
+			return &anthropicBackend{client: s.client, config: s.config.Cloud}, s.config.Cloud.Model, nil
+		case "openai":
+			return &openaiBackend{client: s.client, config: s.config.Cloud}, s.config.Cloud.Model, nil
+		default:
+			return nil, "", fmt.Errorf("unknown cloud provider: %s", s.config.Cloud.Provider)
+		}
+	default:
+		return nil, "", fmt.Errorf("unknown inference engine: %s", s.config.Engine)
+	}
+}
+
+// runBenchmark times one streamed completion. Tokens per second covers generation only
+// (after the first token), so prompt processing time doesn't skew it.
+func runBenchmark(backend Backend, engine, model string, promptTokens, maxOutputTokens int) (*BenchmarkResult, error) {
+	prompt := benchmarkPrompt(promptTokens)
+
+	start := time.Now()
+	var ttft time.Duration
+	tokens, err := backend.Stream(prompt, maxOutputTokens, func(chunk string) {
+		if ttft == 0 && chunk != "" {
+			ttft = time.Since(start)
+		}
+	})
+	total := time.Since(start)
+	if err != nil {
+		return nil, fmt.Errorf("benchmark failed: %w", err)
+	}
+	if ttft == 0 {
+		ttft = total
+	}
+
+	result := &BenchmarkResult{
+		Engine:          engine,
+		Model:           model,
+		PromptTokens:    promptTokens,
+		TokensGenerated: tokens,
+		TTFT:            ttft,
+		TotalDuration:   total,
+	}
+	if generation := total - ttft; tokens > 1 && generation > 0 {
+		result.TokensPerSecond = float64(tokens-1) / generation.Seconds()
+	} else if tokens > 0 {
+		result.TokensPerSecond = float64(tokens) / total.Seconds()
+	}
+	return result, nil
+}
+
+// benchmarkPrompt builds a prompt of roughly the given number of tokens.
+// Short common words are about one token each in every tokenizer we support.
+func benchmarkPrompt(tokens int) string {
+	words := []string{"the", "user", "worked", "on", "code", "and", "read", "docs", "in", "a", "browser", "then"}
+	var sb strings.Builder
+	sb.WriteString("Continue this activity log:")
+	for i := 0; i < tokens; i++ {
+		sb.WriteString(" ")
+		sb.WriteString(words[i%len(words)])
+	}
+	return sb.String()
+}
+
+// readSSE calls handle with the payload of each "data:" line in a server-sent event stream
+// until the stream ends or handle returns done.
+func readSSE(r io.Reader, handle func(data []byte) (done bool, err error)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		done, err := handle([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))))
+		if err != nil || done {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// postStream posts a JSON body and returns the response for streaming, or an error for non-200 responses.
+func postStream(client *http.Client, req *http.Request, name string) (*http.Response, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call %s: %w", name, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s returned status %d: %s", name, resp.StatusCode, string(body))
+	}
+	return resp, nil
+}
+
+// newJSONRequest builds a POST request with a JSON body.
+func newJSONRequest(url string, body interface{}) (*http.Request, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonBody))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, nil
+}
+
+// bundledBackend streams from the bundled llama.cpp server's /completion endpoint.
+type bundledBackend struct {
+	client *http.Client
+	port   int
+}
+
+func (b *bundledBackend) Stream(prompt string, maxTokens int, onToken func(string)) (int, error) {
+	req, err := newJSONRequest(fmt.Sprintf("http://localhost:%d/completion", b.port), map[string]interface{}{
+		"prompt":    prompt,
+		"n_predict": maxTokens,
+		"stream":    true,
+	})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := postStream(b.client, req, "bundled server")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	tokens := 0
+	err = readSSE(resp.Body, func(data []byte) (bool, error) {
+		var chunk struct {
+			Content         string `json:"content"`
+			Stop            bool   `json:"stop"`
+			TokensPredicted int    `json:"tokens_predicted"`
+		}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return false, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if chunk.Stop {
+			if chunk.TokensPredicted > 0 {
+				tokens = chunk.TokensPredicted
+			}
+			return true, nil
+		}
+		onToken(chunk.Content)
+		tokens++
+		return false, nil
+	})
+	return tokens, err
+}
+
+// ollamaBackend streams newline-delimited JSON from Ollama's /api/generate endpoint.
+type ollamaBackend struct {
+	client *http.Client
+	config *OllamaConfig
+}
+
+func (b *ollamaBackend) Stream(prompt string, maxTokens int, onToken func(string)) (int, error) {
+	req, err := newJSONRequest(strings.TrimSuffix(b.config.Host, "/")+"/api/generate", map[string]interface{}{
+		"model":   b.config.Model,
+		"prompt":  prompt,
+		"stream":  true,
+		"options": map[string]interface{}{"num_predict": maxTokens},
+	})
+	if err != nil {
+		return 0, err
+	}
+	resp, err := postStream(b.client, req, "Ollama")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	tokens := 0
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var chunk struct {
+			Response  string `json:"response"`
+			Done      bool   `json:"done"`
+			EvalCount int    `json:"eval_count"`
+		}
+		if err := decoder.Decode(&chunk); err == io.EOF {
+			return tokens, nil
+		} else if err != nil {
+			return tokens, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if chunk.Response != "" {
+			onToken(chunk.Response)
+			tokens++
+		}
+		if chunk.Done {
+			if chunk.EvalCount > 0 {
+				tokens = chunk.EvalCount
+			}
+			return tokens, nil
+		}
+	}
+}
+
+// anthropicBackend streams from the Anthropic Messages API.
+type anthropicBackend struct {
+	client *http.Client
+	config *CloudConfig
+}
+
+func (b *anthropicBackend) Stream(prompt string, maxTokens int, onToken func(string)) (int, error) {
+	endpoint := b.config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.anthropic.com/v1/messages"
+	}
+	req, err := newJSONRequest(endpoint, map[string]interface{}{
+		"model":      b.config.Model,
+		"max_tokens": maxTokens,
+		"stream":     true,
+		"messages":   []anthropicMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("x-api-key", b.config.APIKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	resp, err := postStream(b.client, req, "Anthropic")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	tokens := 0
+	err = readSSE(resp.Body, func(data []byte) (bool, error) {
+		var event struct {
+			Type  string `json:"type"`
+			Delta struct {
+				Text string `json:"text"`
+			} `json:"delta"`
+			Usage struct {
+				OutputTokens int `json:"output_tokens"`
+			} `json:"usage"`
+		}
+		if err := json.Unmarshal(data, &event); err != nil {
+			return false, fmt.Errorf("failed to parse response: %w", err)
+		}
+		switch event.Type {
+		case "content_block_delta":
+			onToken(event.Delta.Text)
+		case "message_delta":
+			tokens = event.Usage.OutputTokens
+		case "message_stop":
+			return true, nil
+		}
+		return false, nil
+	})
+	return tokens, err
+}
+
+// openaiBackend streams from the OpenAI chat completions API.
+type openaiBackend struct {
+	client *http.Client
+	config *CloudConfig
+}
+
+func (b *openaiBackend) Stream(prompt string, maxTokens int, onToken func(string)) (int, error) {
+	endpoint := b.config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.openai.com/v1/chat/completions"
+	}
+	req, err := newJSONRequest(endpoint, map[string]interface{}{
+		"model":          b.config.Model,
+		"max_tokens":     maxTokens,
+		"stream":         true,
+		"stream_options": map[string]bool{"include_usage": true},
+		"messages":       []openaiMessage{{Role: "user", Content: prompt}},
+	})
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+b.config.APIKey)
+
+	resp, err := postStream(b.client, req, "OpenAI")
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	chunks, usage := 0, 0
+	err = readSSE(resp.Body, func(data []byte) (bool, error) {
+		if string(data) == "[DONE]" {
+			return true, nil
+		}
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content string `json:"content"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *struct {
+				CompletionTokens int `json:"completion_tokens"`
+			} `json:"usage"`
+		}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return false, fmt.Errorf("failed to parse response: %w", err)
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta.Content != "" {
+			onToken(chunk.Choices[0].Delta.Content)
+			chunks++
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage.CompletionTokens
+		}
+		return false, nil
+	})
+	if usage > 0 {
+		return usage, err
+	}
+	return chunks, err
+}
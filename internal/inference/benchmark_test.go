@@ -0,0 +1,87 @@
+package inference
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockBackend emits a fixed number of tokens after an initial delay.
+type mockBackend struct {
+	firstTokenDelay time.Duration
+	tokenDelay      time.Duration
+	tokens          int
+	err             error
+
+	gotPrompt    string
+	gotMaxTokens int
+}
+
+func (m *mockBackend) Stream(prompt string, maxTokens int, onToken func(string)) (int, error) {
+	m.gotPrompt, m.gotMaxTokens = prompt, maxTokens
+	if m.err != nil {
+		return 0, m.err
+	}
+	time.Sleep(m.firstTokenDelay)
+	for i := 0; i < m.tokens; i++ {
+		if i > 0 {
+			time.Sleep(m.tokenDelay)
+		}
+		onToken("tok ")
+	}
+	return m.tokens, nil
+}
+
+func TestRunBenchmark(t *testing.T) {
+	backend := &mockBackend{firstTokenDelay: 50 * time.Millisecond, tokenDelay: 5 * time.Millisecond, tokens: 11}
+
+	result, err := runBenchmark(backend, "ollama", "gemma3:12b", 100, 16)
+	if err != nil {
+		t.Fatalf("runBenchmark failed: %v", err)
+	}
+
+	if result.Engine != "ollama" || result.Model != "gemma3:12b" {
+		t.Errorf("got engine %q model %q", result.Engine, result.Model)
+	}
+	if result.PromptTokens != 100 || result.TokensGenerated != 11 {
+		t.Errorf("got %d prompt tokens, %d generated; want 100, 11", result.PromptTokens, result.TokensGenerated)
+	}
+	if backend.gotMaxTokens != 16 {
+		t.Errorf("backend got maxTokens %d, want 16", backend.gotMaxTokens)
+	}
+	if words := len(strings.Fields(backend.gotPrompt)); words < 100 {
+		t.Errorf("prompt has %d words, want at least 100", words)
+	}
+
+	if result.TTFT < 50*time.Millisecond {
+		t.Errorf("TTFT %v shorter than first token delay", result.TTFT)
+	}
+	if result.TotalDuration < result.TTFT+50*time.Millisecond {
+		t.Errorf("total %v should include generation after TTFT %v", result.TotalDuration, result.TTFT)
+	}
+	// 10 tokens after the first at ~5ms each: at most 200 tok/s, and generation time excludes TTFT
+	if result.TokensPerSecond <= 0 || result.TokensPerSecond > 200 {
+		t.Errorf("TokensPerSecond = %.1f, want (0, 200]", result.TokensPerSecond)
+	}
+	if overall := 11 / result.TotalDuration.Seconds(); result.TokensPerSecond <= overall {
+		t.Errorf("TokensPerSecond %.1f should exclude TTFT (overall rate %.1f)", result.TokensPerSecond, overall)
+	}
+}
+
+func TestRunBenchmark_Error(t *testing.T) {
+	backend := &mockBackend{err: errors.New("connection refused")}
+	if _, err := runBenchmark(backend, "bundled", "model.gguf", 10, 10); err == nil {
+		t.Fatal("expected error from failing backend")
+	}
+}
+
+func TestBenchmark_Validation(t *testing.T) {
+	s := NewService(&Config{Engine: EngineOllama})
+	if _, err := s.Benchmark(0, 10); err == nil {
+		t.Error("expected error for zero prompt tokens")
+	}
+	if _, err := s.Benchmark(10, 10); err == nil {
+		t.Error("expected error when Ollama is not configured")
+	}
+}
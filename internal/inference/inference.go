@@ -145,13 +145,45 @@ func (s *Service) UpdateConfig(config *Config) {
 
 // GenerateSummary generates a summary for the given context
 func (s *Service) GenerateSummary(context *SessionContext) (*SummaryResult, error) {
+	return s.GenerateSummaryFromPrompt(buildPrompt(context))
+}
+
+// GenerateSummaryFromPrompt sends a fully built session summary prompt to the configured
+// engine and parses the JSON response. Used for user-customized summary prompts.
+func (s *Service) GenerateSummaryFromPrompt(prompt string) (*SummaryResult, error) {
 	if s.config == nil {
 		return nil, fmt.Errorf("inference not configured")
 	}
 
-	prompt := buildPrompt(context)
-
 	start := time.Now()
+	response, modelUsed, err := s.complete(prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	inferenceMs := time.Since(start).Milliseconds()
+
+	// Parse the response
+	result := parseResponse(response)
+	result.ModelUsed = modelUsed
+	result.InferenceMs = inferenceMs
+
+	return result, nil
+}
+
+// Complete sends a free-form prompt to the configured engine and returns the raw response.
+func (s *Service) Complete(prompt string) (string, error) {
+	if s.config == nil {
+		return "", fmt.Errorf("inference not configured")
+	}
+
+	response, _, err := s.complete(prompt)
+	return response, err
+}
+
+// complete dispatches a prompt to the configured engine.
+// Returns the response and the model that produced it.
+func (s *Service) complete(prompt string) (string, string, error) {
 	var response string
 	var modelUsed string
 	var err error
@@ -159,44 +191,37 @@ func (s *Service) GenerateSummary(context *SessionContext) (*SummaryResult, erro
 	switch s.config.Engine {
 	case EngineBundled:
 		if s.bundled == nil {
-			return nil, fmt.Errorf("bundled engine not initialized")
+			return "", "", fmt.Errorf("bundled engine not initialized")
 		}
 		// Start the bundled server if not already running
 		if !s.bundled.IsRunning() {
 			if err := s.bundled.Start(); err != nil {
-				return nil, fmt.Errorf("failed to start bundled server: %w", err)
+				return "", "", fmt.Errorf("failed to start bundled server: %w", err)
 			}
 		}
 		response, err = s.bundled.Complete(prompt)
 		modelUsed = "bundled:" + filepath.Base(s.config.Bundled.ModelPath)
 	case EngineOllama:
 		if s.config.Ollama == nil {
-			return nil, fmt.Errorf("Ollama not configured")
+			return "", "", fmt.Errorf("Ollama not configured")
 		}
 		response, err = s.callOllama(prompt)
 		modelUsed = s.config.Ollama.Model
 	case EngineCloud:
 		if s.config.Cloud == nil {
-			return nil, fmt.Errorf("Cloud API not configured")
+			return "", "", fmt.Errorf("Cloud API not configured")
 		}
 		response, err = s.callCloudAPI(prompt)
 		modelUsed = s.config.Cloud.Model
 	default:
-		return nil, fmt.Errorf("unknown inference engine: %s", s.config.Engine)
+		return "", "", fmt.Errorf("unknown inference engine: %s", s.config.Engine)
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("inference failed: %w", err)
+		return "", "", fmt.Errorf("inference failed: %w", err)
 	}
 
-	inferenceMs := time.Since(start).Milliseconds()
-
-	// Parse the response
-	result := parseResponse(response)
-	result.ModelUsed = modelUsed
-	result.InferenceMs = inferenceMs
-
-	return result, nil
+	return response, modelUsed, nil
 }
 
 // SessionContext contains data for summary generation
@@ -221,9 +246,13 @@ type FocusEvent struct {
 }
 
 func buildPrompt(ctx *SessionContext) string {
-	var sb strings.Builder
+	return "Analyze this work session and provide a detailed summary.\n\n" + SessionActivity(ctx) + SummaryInstructions
+}
 
-	sb.WriteString("Analyze this work session and provide a detailed summary.\n\n")
+// SessionActivity formats a session's duration, apps and windows, meetings, commits,
+// commands, files and browser activity as the data section of a summary prompt.
+func SessionActivity(ctx *SessionContext) string {
+	var sb strings.Builder
 
 	// Duration info
 	duration := ctx.DurationSeconds
@@ -364,8 +393,12 @@ func buildPrompt(ctx *SessionContext) string {
 		sb.WriteString("\n")
 	}
 
-	// === ENHANCED PROMPT INSTRUCTIONS ===
-	sb.WriteString(`Respond in this exact JSON format:
+	return sb.String()
+}
+
+// SummaryInstructions tells the model the JSON format GenerateSummary parses and how to
+// describe projects and activities. It ends the default session summary prompt.
+const SummaryInstructions = `Respond in this exact JSON format:
 {
   "summary": "2-3 sentences describing what was accomplished.",
   "explanation": "A paragraph explaining the work themes.",
@@ -416,10 +449,7 @@ INFER SPECIFICS FROM CONTEXT:
 - YouTube "Microsoft Factory Agent" + domain "sl2619" context → "Researched Microsoft Factory Operations patterns for Synaptics demo"
 
 If you cannot infer a specific activity, OMIT IT rather than writing something generic.
-`)
-
-	return sb.String()
-}
+`
 
 func parseResponse(response string) *SummaryResult {
 	result := &SummaryResult{
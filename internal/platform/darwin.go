@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -98,6 +99,47 @@ func (d *Darwin) GetLastInputTime() (time.Time, error) {
 	return time.Now(), nil
 }
 
+// ProcessCPUTime returns the CPU time used by this process, as reported by ps.
+func (d *Darwin) ProcessCPUTime() (time.Duration, error) {
+	out, err := exec.Command("ps", "-o", "time=", "-p", strconv.Itoa(os.Getpid())).Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to query process CPU time: %w", err)
+	}
+	return parsePSTime(strings.TrimSpace(string(out)))
+}
+
+// parsePSTime parses ps's cumulative CPU time, e.g. "0:01.52", "75:03.10" or "1-02:03:04".
+func parsePSTime(s string) (time.Duration, error) {
+	var days int64
+	if i := strings.Index(s, "-"); i >= 0 {
+		n, err := strconv.ParseInt(s[:i], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU time %q", s)
+		}
+		days, s = n, s[i+1:]
+	}
+
+	var seconds float64
+	for _, part := range strings.Split(s, ":") {
+		n, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU time %q", s)
+		}
+		seconds = seconds*60 + n
+	}
+	return time.Duration(days)*24*time.Hour + time.Duration(seconds*float64(time.Second)), nil
+}
+
+// OpenFileDescriptors returns the number of file descriptors this process has open.
+func (d *Darwin) OpenFileDescriptors() (int, error) {
+	entries, err := os.ReadDir("/dev/fd")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list file descriptors: %w", err)
+	}
+	// Reading the directory holds one descriptor open itself
+	return len(entries) - 1, nil
+}
+
 // GetShellHistoryPath returns the path to the shell history file.
 func (d *Darwin) GetShellHistoryPath() string {
 	home, _ := os.UserHomeDir()
@@ -270,6 +312,24 @@ func (d *Darwin) IsAutoStartEnabled() (bool, error) {
 	return true, nil
 }
 
+// MachineID returns the hardware UUID (IOPlatformUUID), falling back to the hostname.
+func (d *Darwin) MachineID() string {
+	out, err := exec.Command("ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+	if err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			if !strings.Contains(line, `"IOPlatformUUID"`) {
+				continue
+			}
+			if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
+				if id := strings.Trim(strings.TrimSpace(parts[1]), `"`); id != "" {
+					return id
+				}
+			}
+		}
+	}
+	return fallbackMachineID()
+}
+
 // GetSystemTheme detects if the system is using dark or light theme.
 func (d *Darwin) GetSystemTheme() string {
 	// Use AppleScript to check dark mode
@@ -0,0 +1,66 @@
+//go:build darwin
+
+package platform
+
+/*
+#cgo LDFLAGS: -framework CoreGraphics -framework CoreFoundation
+#include <CoreGraphics/CoreGraphics.h>
+
+// screenIsLocked returns 1 if the console session's screen is locked, 0 if not, and -1 if
+// there is no session dictionary (e.g. not running in a GUI session).
+static int screenIsLocked(void) {
+	CFDictionaryRef session = CGSessionCopyCurrentDictionary();
+	if (session == NULL) {
+		return -1;
+	}
+	int locked = 0;
+	CFTypeRef value = CFDictionaryGetValue(session, CFSTR("CGSSessionScreenIsLocked"));
+	if (value != NULL && CFGetTypeID(value) == CFBooleanGetTypeID()) {
+		locked = CFBooleanGetValue((CFBooleanRef)value) ? 1 : 0;
+	}
+	CFRelease(session);
+	return locked;
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+)
+
+// IsScreenLocked reports whether the screen is locked, from the current session dictionary.
+func (d *Darwin) IsScreenLocked() (bool, error) {
+	switch C.screenIsLocked() {
+	case -1:
+		return false, fmt.Errorf("no console session")
+	case 1:
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// displayPowerStateRe finds the display wrangler's power state in ioreg output, where it is
+// nested in the IOPowerManagement dictionary.
+var displayPowerStateRe = regexp.MustCompile(`"CurrentPowerState"=(\d+)`)
+
+// IsDisplayOff reports whether the display has been put to sleep, using the display
+// wrangler's power state (4 is fully on).
+func (d *Darwin) IsDisplayOff() (bool, error) {
+	out, err := exec.Command("ioreg", "-n", "IODisplayWrangler", "-r", "-d", "1").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to query display power state: %w", err)
+	}
+	match := displayPowerStateRe.FindSubmatch(out)
+	if match == nil {
+		return false, fmt.Errorf("display power state not found")
+	}
+	state, err := strconv.Atoi(string(match[1]))
+	if err != nil {
+		return false, err
+	}
+	return state < 4, nil
+}
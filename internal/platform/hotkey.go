@@ -0,0 +1,113 @@
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Hotkey is a parsed global keyboard shortcut such as "Ctrl+Shift+P".
+type Hotkey struct {
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Super bool   // Cmd on macOS, Win on Windows
+	Key   string // Normalized key name: "A"-"Z", "0"-"9", "F1"-"F24", "Space", etc.
+}
+
+// namedHotkeyKeys maps lowercase key aliases to their normalized names.
+var namedHotkeyKeys = map[string]string{
+	"space":    "Space",
+	"enter":    "Enter",
+	"return":   "Enter",
+	"tab":      "Tab",
+	"esc":      "Escape",
+	"escape":   "Escape",
+	"home":     "Home",
+	"end":      "End",
+	"pageup":   "PageUp",
+	"pagedown": "PageDown",
+	"insert":   "Insert",
+	"delete":   "Delete",
+	"up":       "Up",
+	"down":     "Down",
+	"left":     "Left",
+	"right":    "Right",
+}
+
+// ParseHotkey parses a shortcut string like "Ctrl+Shift+P".
+// Modifier names are case-insensitive; Cmd, Meta and Win are treated as Super.
+// At least one modifier is required so a global hotkey can't swallow normal typing.
+func ParseHotkey(shortcut string) (*Hotkey, error) {
+	parts := strings.Split(shortcut, "+")
+	if len(parts) < 2 {
+		return nil, fmt.Errorf("invalid shortcut %q: expected modifiers and a key, e.g. Ctrl+Shift+P", shortcut)
+	}
+
+	hk := &Hotkey{}
+	for _, part := range parts[:len(parts)-1] {
+		switch strings.ToLower(strings.TrimSpace(part)) {
+		case "ctrl", "control":
+			hk.Ctrl = true
+		case "shift":
+			hk.Shift = true
+		case "alt", "option":
+			hk.Alt = true
+		case "super", "cmd", "command", "meta", "win":
+			hk.Super = true
+		default:
+			return nil, fmt.Errorf("invalid shortcut %q: unknown modifier %q", shortcut, part)
+		}
+	}
+
+	key, err := normalizeHotkeyKey(strings.TrimSpace(parts[len(parts)-1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid shortcut %q: %w", shortcut, err)
+	}
+	hk.Key = key
+
+	return hk, nil
+}
+
+// normalizeHotkeyKey validates a key name and returns its canonical form.
+func normalizeHotkeyKey(key string) (string, error) {
+	if len(key) == 1 {
+		c := key[0]
+		if c >= 'a' && c <= 'z' {
+			return strings.ToUpper(key), nil
+		}
+		if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			return key, nil
+		}
+	}
+
+	lower := strings.ToLower(key)
+	if name, ok := namedHotkeyKeys[lower]; ok {
+		return name, nil
+	}
+	if strings.HasPrefix(lower, "f") {
+		if n, err := strconv.Atoi(lower[1:]); err == nil && n >= 1 && n <= 24 {
+			return "F" + strconv.Itoa(n), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported key %q", key)
+}
+
+// String returns the canonical form of the hotkey, e.g. "Ctrl+Shift+P".
+func (h *Hotkey) String() string {
+	var parts []string
+	if h.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if h.Shift {
+		parts = append(parts, "Shift")
+	}
+	if h.Alt {
+		parts = append(parts, "Alt")
+	}
+	if h.Super {
+		parts = append(parts, "Super")
+	}
+	return strings.Join(append(parts, h.Key), "+")
+}
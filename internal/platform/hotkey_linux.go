@@ -0,0 +1,220 @@
+//go:build linux
+
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/xproto"
+)
+
+// x11Hotkey is a key grab registered on the root window.
+type x11Hotkey struct {
+	keycode   xproto.Keycode
+	modifiers uint16
+	handler   func()
+}
+
+// x11IgnoredModifiers are lock modifiers that shouldn't prevent a hotkey from firing.
+// Each grab is registered once per combination of CapsLock and NumLock.
+var x11IgnoredModifiers = []uint16{
+	0,
+	xproto.ModMaskLock,
+	xproto.ModMask2,
+	xproto.ModMaskLock | xproto.ModMask2,
+}
+
+// x11Keysyms maps named keys to their X11 keysyms.
+var x11Keysyms = map[string]xproto.Keysym{
+	"Space":    0x0020,
+	"Enter":    0xff0d,
+	"Tab":      0xff09,
+	"Escape":   0xff1b,
+	"Home":     0xff50,
+	"Left":     0xff51,
+	"Up":       0xff52,
+	"Right":    0xff53,
+	"Down":     0xff54,
+	"PageUp":   0xff55,
+	"PageDown": 0xff56,
+	"End":      0xff57,
+	"Insert":   0xff63,
+	"Delete":   0xffff,
+}
+
+// RegisterHotkey grabs a global keyboard shortcut on the X11 root window.
+// The handler is called in its own goroutine each time the shortcut is pressed.
+func (l *Linux) RegisterHotkey(shortcut string, handler func()) error {
+	hk, err := ParseHotkey(shortcut)
+	if err != nil {
+		return err
+	}
+	name := hk.String()
+
+	l.hotkeyMu.Lock()
+	defer l.hotkeyMu.Unlock()
+
+	if _, exists := l.hotkeys[name]; exists {
+		return fmt.Errorf("hotkey %s is already registered", name)
+	}
+	if err := l.initHotkeyConn(); err != nil {
+		return err
+	}
+
+	keysym, err := x11KeysymFor(hk.Key)
+	if err != nil {
+		return err
+	}
+	keycode, err := l.keycodeFor(keysym)
+	if err != nil {
+		return err
+	}
+
+	var modifiers uint16
+	if hk.Ctrl {
+		modifiers |= xproto.ModMaskControl
+	}
+	if hk.Shift {
+		modifiers |= xproto.ModMaskShift
+	}
+	if hk.Alt {
+		modifiers |= xproto.ModMask1
+	}
+	if hk.Super {
+		modifiers |= xproto.ModMask4
+	}
+
+	for i, ignored := range x11IgnoredModifiers {
+		err := xproto.GrabKeyChecked(l.hotkeyConn, true, l.hotkeyRoot, modifiers|ignored, keycode,
+			xproto.GrabModeAsync, xproto.GrabModeAsync).Check()
+		if err != nil {
+			// Roll back the grabs that succeeded so the shortcut isn't half-registered
+			for _, done := range x11IgnoredModifiers[:i] {
+				xproto.UngrabKey(l.hotkeyConn, keycode, l.hotkeyRoot, modifiers|done)
+			}
+			return fmt.Errorf("failed to grab hotkey %s (it may be in use by another application): %w", name, err)
+		}
+	}
+
+	l.hotkeys[name] = &x11Hotkey{keycode: keycode, modifiers: modifiers, handler: handler}
+	return nil
+}
+
+// UnregisterHotkey releases a global keyboard shortcut registered with RegisterHotkey.
+func (l *Linux) UnregisterHotkey(shortcut string) error {
+	hk, err := ParseHotkey(shortcut)
+	if err != nil {
+		return err
+	}
+	name := hk.String()
+
+	l.hotkeyMu.Lock()
+	defer l.hotkeyMu.Unlock()
+
+	registered, ok := l.hotkeys[name]
+	if !ok {
+		return fmt.Errorf("hotkey %s is not registered", name)
+	}
+
+	for _, ignored := range x11IgnoredModifiers {
+		if err := xproto.UngrabKeyChecked(l.hotkeyConn, registered.keycode, l.hotkeyRoot, registered.modifiers|ignored).Check(); err != nil {
+			return fmt.Errorf("failed to release hotkey %s: %w", name, err)
+		}
+	}
+
+	delete(l.hotkeys, name)
+	return nil
+}
+
+// initHotkeyConn opens a dedicated X11 connection for key grabs and starts the event loop.
+// A separate connection keeps key events from interleaving with idle-time queries.
+// Must be called with hotkeyMu held.
+func (l *Linux) initHotkeyConn() error {
+	if l.hotkeyConn != nil {
+		return nil
+	}
+
+	conn, err := xgb.NewConn()
+	if err != nil {
+		return fmt.Errorf("failed to connect to X11: %w", err)
+	}
+
+	l.hotkeyConn = conn
+	l.hotkeyRoot = xproto.Setup(conn).DefaultScreen(conn).Root
+	l.hotkeys = make(map[string]*x11Hotkey)
+
+	go l.listenHotkeys(conn)
+	return nil
+}
+
+// listenHotkeys dispatches key press events to registered hotkey handlers.
+func (l *Linux) listenHotkeys(conn *xgb.Conn) {
+	for {
+		ev, err := conn.WaitForEvent()
+		if ev == nil && err == nil {
+			return // Connection closed
+		}
+		press, ok := ev.(xproto.KeyPressEvent)
+		if !ok {
+			continue
+		}
+
+		state := press.State &^ (xproto.ModMaskLock | xproto.ModMask2)
+
+		l.hotkeyMu.Lock()
+		var handler func()
+		for _, hk := range l.hotkeys {
+			if hk.keycode == press.Detail && hk.modifiers == state {
+				handler = hk.handler
+				break
+			}
+		}
+		l.hotkeyMu.Unlock()
+
+		if handler != nil {
+			go handler()
+		}
+	}
+}
+
+// keycodeFor looks up the keycode that produces the given keysym on the current keyboard layout.
+func (l *Linux) keycodeFor(keysym xproto.Keysym) (xproto.Keycode, error) {
+	setup := xproto.Setup(l.hotkeyConn)
+	count := int(setup.MaxKeycode) - int(setup.MinKeycode) + 1
+
+	mapping, err := xproto.GetKeyboardMapping(l.hotkeyConn, setup.MinKeycode, byte(count)).Reply()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get keyboard mapping: %w", err)
+	}
+
+	perKeycode := int(mapping.KeysymsPerKeycode)
+	for i := 0; i < count; i++ {
+		for j := 0; j < perKeycode; j++ {
+			if mapping.Keysyms[i*perKeycode+j] == keysym {
+				return xproto.Keycode(int(setup.MinKeycode) + i), nil
+			}
+		}
+	}
+
+	return 0, fmt.Errorf("no keycode found for keysym 0x%x", keysym)
+}
+
+// x11KeysymFor converts a normalized hotkey key name to an X11 keysym.
+func x11KeysymFor(key string) (xproto.Keysym, error) {
+	if sym, ok := x11Keysyms[key]; ok {
+		return sym, nil
+	}
+	if len(key) == 1 {
+		// Latin-1 keysyms match ASCII; letters are grabbed by their lowercase keysym
+		return xproto.Keysym(strings.ToLower(key)[0]), nil
+	}
+	if strings.HasPrefix(key, "F") {
+		if n, err := strconv.Atoi(key[1:]); err == nil {
+			return xproto.Keysym(0xffbe + n - 1), nil // XK_F1 = 0xffbe
+		}
+	}
+	return 0, fmt.Errorf("unsupported key %q", key)
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/jezek/xgb"
+	"github.com/jezek/xgb/dpms"
 	"github.com/jezek/xgb/screensaver"
 	"github.com/jezek/xgb/xproto"
 )
@@ -24,6 +25,13 @@ type Linux struct {
 	x11Root     xproto.Window
 	x11InitOnce sync.Once
 	x11InitErr  error
+	x11DPMS     bool // DPMS extension available, for display power state
+
+	// Global hotkeys use their own X11 connection (see hotkey_linux.go)
+	hotkeyMu   sync.Mutex
+	hotkeyConn *xgb.Conn
+	hotkeyRoot xproto.Window
+	hotkeys    map[string]*x11Hotkey
 }
 
 // New returns the platform implementation for Linux.
@@ -196,6 +204,7 @@ func (l *Linux) initX11() {
 		setup := xproto.Setup(conn)
 		l.x11Root = setup.DefaultScreen(conn).Root
 		l.x11Conn = conn
+		l.x11DPMS = dpms.Init(conn) == nil
 	})
 }
 
@@ -222,6 +231,73 @@ func (l *Linux) GetLastInputTime() (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to detect idle time: X11 screensaver extension unavailable and xprintidle not installed")
 }
 
+// IsScreenLocked reports whether the login session is locked, using logind's LockedHint.
+func (l *Linux) IsScreenLocked() (bool, error) {
+	sessionID := os.Getenv("XDG_SESSION_ID")
+	if sessionID == "" {
+		sessionID = "self"
+	}
+	out, err := exec.Command("loginctl", "show-session", sessionID, "--property=LockedHint").Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to query session lock state: %w", err)
+	}
+	return strings.TrimSpace(string(out)) == "LockedHint=yes", nil
+}
+
+// IsDisplayOff reports whether the display has been powered down by DPMS.
+func (l *Linux) IsDisplayOff() (bool, error) {
+	l.initX11()
+	if l.x11Conn == nil || !l.x11DPMS {
+		return false, fmt.Errorf("DPMS extension unavailable")
+	}
+	info, err := dpms.Info(l.x11Conn).Reply()
+	if err != nil {
+		return false, fmt.Errorf("failed to query DPMS state: %w", err)
+	}
+	return info.State && info.PowerLevel != dpms.DPMSModeOn, nil
+}
+
+// linuxClockTicks is USER_HZ, the unit of the CPU times in /proc/[pid]/stat. It is 100
+// on every mainstream architecture.
+const linuxClockTicks = 100
+
+// ProcessCPUTime returns the CPU time used by this process, from /proc/self/stat.
+func (l *Linux) ProcessCPUTime() (time.Duration, error) {
+	data, err := os.ReadFile("/proc/self/stat")
+	if err != nil {
+		return 0, fmt.Errorf("failed to read process stats: %w", err)
+	}
+	// The command name may contain spaces, so fields are counted from its closing paren
+	end := bytes.LastIndexByte(data, ')')
+	if end < 0 {
+		return 0, fmt.Errorf("unexpected /proc/self/stat format")
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 13 {
+		return 0, fmt.Errorf("unexpected /proc/self/stat format")
+	}
+	// utime and stime are fields 14 and 15 of the full line
+	utime, err := strconv.ParseInt(fields[11], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid utime: %w", err)
+	}
+	stime, err := strconv.ParseInt(fields[12], 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid stime: %w", err)
+	}
+	return time.Duration(utime+stime) * time.Second / linuxClockTicks, nil
+}
+
+// OpenFileDescriptors returns the number of file descriptors this process has open.
+func (l *Linux) OpenFileDescriptors() (int, error) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, fmt.Errorf("failed to list file descriptors: %w", err)
+	}
+	// Reading the directory holds one descriptor open itself
+	return len(entries) - 1, nil
+}
+
 // GetShellHistoryPath returns the path to the shell history file.
 func (l *Linux) GetShellHistoryPath() string {
 	home, _ := os.UserHomeDir()
@@ -302,6 +378,58 @@ func (l *Linux) GetBrowserHistoryPaths() map[string]string {
 	return paths
 }
 
+// GetBrowserProfileHistoryPaths returns the history database of every profile of each
+// browser: Chromium-based browsers' "Default" and "Profile N" directories, and each Firefox
+// profile.
+func (l *Linux) GetBrowserProfileHistoryPaths() map[string][]string {
+	home, _ := os.UserHomeDir()
+
+	paths := map[string][]string{}
+	chromiumDirs := map[string]string{
+		"chrome":   filepath.Join(home, ".config", "google-chrome"),
+		"chromium": filepath.Join(home, ".config", "chromium"),
+		"brave":    filepath.Join(home, ".config", "BraveSoftware", "Brave-Browser"),
+		"edge":     filepath.Join(home, ".config", "microsoft-edge"),
+	}
+	for browser, dir := range chromiumDirs {
+		if found := profileHistoryPaths(dir, "History", isChromiumProfileDir); len(found) > 0 {
+			paths[browser] = found
+		}
+	}
+	firefoxDir := filepath.Join(home, ".mozilla", "firefox")
+	if found := profileHistoryPaths(firefoxDir, "places.sqlite", nil); len(found) > 0 {
+		paths["firefox"] = found
+	}
+
+	return paths
+}
+
+// profileHistoryPaths returns dir/<profile>/historyFile for each profile directory that has
+// one, in name order. If isProfile is set, only directories it accepts are considered.
+func profileHistoryPaths(dir, historyFile string, isProfile func(name string) bool) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		if !entry.IsDir() || (isProfile != nil && !isProfile(entry.Name())) {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name(), historyFile)
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// isChromiumProfileDir reports whether a directory in a Chromium user data directory holds a
+// user's profile, rather than the guest or system profile.
+func isChromiumProfileDir(name string) bool {
+	return name == "Default" || strings.HasPrefix(name, "Profile ")
+}
+
 // OpenURL opens a URL in the default browser.
 func (l *Linux) OpenURL(url string) error {
 	cmd := exec.Command("xdg-open", url)
@@ -388,6 +516,18 @@ func (l *Linux) IsAutoStartEnabled() (bool, error) {
 	return true, nil
 }
 
+// MachineID returns the systemd/D-Bus machine ID, falling back to the hostname.
+func (l *Linux) MachineID() string {
+	for _, path := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+		if data, err := os.ReadFile(path); err == nil {
+			if id := strings.TrimSpace(string(data)); id != "" {
+				return id
+			}
+		}
+	}
+	return fallbackMachineID()
+}
+
 // GetSystemTheme detects if the system is using dark or light theme.
 func (l *Linux) GetSystemTheme() string {
 	// Try GNOME/GTK color-scheme setting first
@@ -1,6 +1,7 @@
 package platform
 
 import (
+	"os"
 	"time"
 )
 
@@ -34,6 +35,35 @@ type Platform interface {
 
 	// Theme
 	GetSystemTheme() string // Returns "dark" or "light"
+
+	// Identity
+	MachineID() string // Stable identifier of this machine, e.g. for staged update rollouts
+}
+
+// HotkeyProvider is implemented by platforms that can register global keyboard shortcuts.
+type HotkeyProvider interface {
+	RegisterHotkey(shortcut string, handler func()) error // shortcut like "Ctrl+Shift+P"
+	UnregisterHotkey(shortcut string) error
+}
+
+// ScreenStateProvider is implemented by platforms that can report whether the screen is
+// locked or the display is powered off. The AFK detector uses it to classify away periods.
+type ScreenStateProvider interface {
+	IsScreenLocked() (bool, error)
+	IsDisplayOff() (bool, error)
+}
+
+// BrowserProfilesProvider is implemented by platforms that can find every browser profile,
+// not just each browser's main one returned by GetBrowserHistoryPaths.
+type BrowserProfilesProvider interface {
+	GetBrowserProfileHistoryPaths() map[string][]string // Browser -> history database of each profile
+}
+
+// ProcessStatsProvider is implemented by platforms that can report Traq's own CPU time and
+// open file descriptors, for monitoring its resource footprint.
+type ProcessStatsProvider interface {
+	ProcessCPUTime() (time.Duration, error) // User and system CPU time used since the process started
+	OpenFileDescriptors() (int, error)
 }
 
 // WindowInfo contains information about a window.
@@ -50,3 +80,12 @@ type WindowInfo struct {
 
 // New returns the platform implementation for the current OS.
 // This is implemented in platform-specific files.
+
+// fallbackMachineID identifies the machine by hostname when the OS machine ID can't be read.
+func fallbackMachineID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		return ""
+	}
+	return host
+}
@@ -163,6 +163,21 @@ func (w *Windows) IsAutoStartEnabled() (bool, error) {
 	return true, nil
 }
 
+// MachineID returns the Windows MachineGuid, falling back to the hostname.
+func (w *Windows) MachineID() string {
+	// reg query "HKLM\SOFTWARE\Microsoft\Cryptography" /v MachineGuid
+	out, err := exec.Command("reg", "query", `HKLM\SOFTWARE\Microsoft\Cryptography`, "/v", "MachineGuid").Output()
+	if err == nil {
+		for _, line := range strings.Split(string(out), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) == 3 && fields[0] == "MachineGuid" {
+				return fields[2]
+			}
+		}
+	}
+	return fallbackMachineID()
+}
+
 // GetSystemTheme detects if the system is using dark or light theme.
 func (w *Windows) GetSystemTheme() string {
 	// Check registry for AppsUseLightTheme
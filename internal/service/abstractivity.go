@@ -0,0 +1,115 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// aiChatKeywords identify AI/chat assistants, matched against the app name of desktop
+// clients and the window title of browser tabs.
+var aiChatKeywords = []string{"chatgpt", "claude", "gemini", "copilot", "perplexity"}
+
+// AbstractivityScore measures how concrete a day's work was: commits and file edits
+// raise the score, time spent reading in a browser or talking to AI tools lowers it.
+type AbstractivityScore struct {
+	Score           int      `json:"score"`           // 0-100, higher is more concrete
+	ConcreteMinutes int64    `json:"concreteMinutes"` // Focus time in productive non-browser apps
+	AbstractMinutes int64    `json:"abstractMinutes"` // Browser and AI/chat tool time
+	Indicators      []string `json:"indicators"`      // Human-readable contributions to the score
+}
+
+// GetAbstractivityScore calculates the abstractivity score for a date (YYYY-MM-DD).
+func (s *AnalyticsService) GetAbstractivityScore(date string) (*AbstractivityScore, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	start := t.Unix()
+	end := t.AddDate(0, 0, 1).Unix() - 1
+
+	focusEvents, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := s.store.CountGitCommitsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	edits, err := s.store.CountFileEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return s.abstractivityScore(focusEvents, start, end, commits, edits), nil
+}
+
+// abstractivityScore scores a day from its focus events and output. Every 5 commits add
+// 20 points and every 10 file edits add 1. Tracked focus time contributes up to 50 points,
+// less the share of it spent in browsers and AI/chat tools.
+func (s *AnalyticsService) abstractivityScore(focusEvents []*storage.WindowFocusEvent, start, end, commits, edits int64) *AbstractivityScore {
+	score := &AbstractivityScore{Indicators: []string{}}
+
+	var browserSeconds, aiSeconds, concreteSeconds float64
+	for _, evt := range focusEvents {
+		seconds := clampedEventDuration(evt, start, end)
+		switch {
+		case isAIChat(evt):
+			aiSeconds += seconds
+		case isBrowser(evt.AppName):
+			browserSeconds += seconds
+		case s.CategorizeApp(evt.AppName) == CategoryProductive:
+			concreteSeconds += seconds
+		}
+	}
+	score.ConcreteMinutes = int64(concreteSeconds / 60)
+	score.AbstractMinutes = int64((browserSeconds + aiSeconds) / 60)
+
+	points := 0
+	if commitPoints := int(commits/5) * 20; commits > 0 {
+		points += commitPoints
+		score.Indicators = append(score.Indicators, fmt.Sprintf("%d git commits (+%d)", commits, commitPoints))
+	}
+	if editPoints := int(edits / 10); edits > 0 {
+		points += editPoints
+		score.Indicators = append(score.Indicators, fmt.Sprintf("%d file edits (+%d)", edits, editPoints))
+	}
+
+	if tracked := score.ConcreteMinutes + score.AbstractMinutes; tracked > 0 {
+		abstractShare := float64(score.AbstractMinutes) / float64(tracked)
+		penalty := int(math.Round(50 * abstractShare))
+		points += 50 - penalty
+		if score.ConcreteMinutes > 0 {
+			score.Indicators = append(score.Indicators, fmt.Sprintf("%d min in editors and terminals", score.ConcreteMinutes))
+		}
+		if minutes := int64(browserSeconds / 60); minutes > 0 {
+			score.Indicators = append(score.Indicators, fmt.Sprintf("%d min browser-only", minutes))
+		}
+		if minutes := int64(aiSeconds / 60); minutes > 0 {
+			score.Indicators = append(score.Indicators, fmt.Sprintf("%d min in AI/chat tools", minutes))
+		}
+		if penalty > 0 {
+			score.Indicators = append(score.Indicators, fmt.Sprintf("%.0f%% of focus time abstract (-%d)", abstractShare*100, penalty))
+		}
+	}
+
+	score.Score = max(0, min(100, points))
+	return score
+}
+
+// isAIChat reports whether a focus event was spent in an AI/chat assistant, either its
+// desktop app or a browser tab.
+func isAIChat(evt *storage.WindowFocusEvent) bool {
+	haystack := strings.ToLower(evt.AppName)
+	if isBrowser(evt.AppName) {
+		haystack = strings.ToLower(evt.WindowTitle)
+	}
+	for _, keyword := range aiChatKeywords {
+		if strings.Contains(haystack, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+)
+
+// afkReturnThreshold is the minimum AFK duration that triggers a welcome-back notification.
+const afkReturnThreshold = 30 * time.Minute
+
+// Notifier shows desktop notifications. platform.Platform satisfies this interface.
+type Notifier interface {
+	ShowNotification(title, body string) error
+}
+
+// AFKReturnNotifier shows a summary notification when the user returns from a long break.
+type AFKReturnNotifier struct {
+	notifier  Notifier
+	enabled   func() bool // Checked on each return so config changes apply immediately
+	threshold time.Duration
+}
+
+// NewAFKReturnNotifier creates a new AFKReturnNotifier.
+// If enabled is nil, notifications are always shown.
+func NewAFKReturnNotifier(notifier Notifier, enabled func() bool) *AFKReturnNotifier {
+	return &AFKReturnNotifier{
+		notifier:  notifier,
+		enabled:   enabled,
+		threshold: afkReturnThreshold,
+	}
+}
+
+// OnAFKEnd notifies the user about their time away if it exceeded the threshold.
+func (n *AFKReturnNotifier) OnAFKEnd(afkDuration time.Duration, sessionCount int) {
+	if n.notifier == nil || afkDuration <= n.threshold {
+		return
+	}
+	if n.enabled != nil && !n.enabled() {
+		return
+	}
+
+	if err := n.notifier.ShowNotification("Welcome back!", formatAFKReturnMessage(afkDuration, sessionCount)); err != nil {
+		log.Printf("Failed to show AFK return notification: %v", err)
+	}
+}
+
+// formatAFKReturnMessage builds the notification body, e.g.
+// "You were away for 1h 5m. 2 sessions were active since you left."
+func formatAFKReturnMessage(afkDuration time.Duration, sessionCount int) string {
+	hours := int(afkDuration.Hours())
+	minutes := int(afkDuration.Minutes()) % 60
+
+	sessions := fmt.Sprintf("%d sessions were", sessionCount)
+	if sessionCount == 1 {
+		sessions = "1 session was"
+	}
+
+	return fmt.Sprintf("You were away for %dh %dm. %s active since you left.", hours, minutes, sessions)
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+// mockNotifier records notifications for testing.
+type mockNotifier struct {
+	calls []struct{ Title, Body string }
+}
+
+func (m *mockNotifier) ShowNotification(title, body string) error {
+	m.calls = append(m.calls, struct{ Title, Body string }{title, body})
+	return nil
+}
+
+func TestAFKReturnNotifier_Threshold(t *testing.T) {
+	tests := []struct {
+		name        string
+		afkDuration time.Duration
+		expectFired bool
+	}{
+		{"short break", 5 * time.Minute, false},
+		{"exactly at threshold", 30 * time.Minute, false},
+		{"just over threshold", 31 * time.Minute, true},
+		{"long break", 2*time.Hour + 15*time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &mockNotifier{}
+			notifier := NewAFKReturnNotifier(mock, nil)
+
+			notifier.OnAFKEnd(tt.afkDuration, 0)
+
+			if fired := len(mock.calls) > 0; fired != tt.expectFired {
+				t.Errorf("expected fired=%v, got %v", tt.expectFired, fired)
+			}
+		})
+	}
+}
+
+func TestAFKReturnNotifier_Message(t *testing.T) {
+	mock := &mockNotifier{}
+	notifier := NewAFKReturnNotifier(mock, nil)
+
+	notifier.OnAFKEnd(2*time.Hour+15*time.Minute, 3)
+
+	if len(mock.calls) != 1 {
+		t.Fatalf("expected 1 notification, got %d", len(mock.calls))
+	}
+	if mock.calls[0].Title != "Welcome back!" {
+		t.Errorf("unexpected title %q", mock.calls[0].Title)
+	}
+	expected := "You were away for 2h 15m. 3 sessions were active since you left."
+	if mock.calls[0].Body != expected {
+		t.Errorf("expected body %q, got %q", expected, mock.calls[0].Body)
+	}
+
+	if got := formatAFKReturnMessage(45*time.Minute, 1); got != "You were away for 0h 45m. 1 session was active since you left." {
+		t.Errorf("unexpected singular message %q", got)
+	}
+}
+
+func TestAFKReturnNotifier_Disabled(t *testing.T) {
+	mock := &mockNotifier{}
+	enabled := false
+	notifier := NewAFKReturnNotifier(mock, func() bool { return enabled })
+
+	notifier.OnAFKEnd(time.Hour, 0)
+	if len(mock.calls) != 0 {
+		t.Error("expected no notification when disabled")
+	}
+
+	enabled = true
+	notifier.OnAFKEnd(time.Hour, 0)
+	if len(mock.calls) != 1 {
+		t.Errorf("expected notification after enabling, got %d", len(mock.calls))
+	}
+}
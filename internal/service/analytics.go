@@ -3,6 +3,9 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -29,7 +32,8 @@ func clampedEventDuration(evt *storage.WindowFocusEvent, rangeStart, rangeEnd in
 
 // AnalyticsService provides analytics and statistics.
 type AnalyticsService struct {
-	store *storage.Store
+	store    *storage.Store
+	meetings meetingDetector
 }
 
 // NewAnalyticsService creates a new AnalyticsService.
@@ -39,16 +43,38 @@ func NewAnalyticsService(store *storage.Store) *AnalyticsService {
 
 // DailyStats contains statistics for a single day.
 type DailyStats struct {
-	Date             string       `json:"date"`
-	TotalScreenshots int64        `json:"totalScreenshots"`
-	TotalSessions    int64        `json:"totalSessions"`
-	ActiveMinutes    int64        `json:"activeMinutes"`
-	TopApps          []*AppUsage  `json:"topApps"`
-	ShellCommands    int64        `json:"shellCommands"`
-	GitCommits       int64        `json:"gitCommits"`
-	FilesModified    int64        `json:"filesModified"`
-	SitesVisited     int64        `json:"sitesVisited"`
-	Comparison       *Comparison  `json:"comparison,omitempty"`
+	Date               string              `json:"date"`
+	TotalScreenshots   int64               `json:"totalScreenshots"`
+	TotalSessions      int64               `json:"totalSessions"`
+	ActiveMinutes      int64               `json:"activeMinutes"`
+	TopApps            []*AppUsage         `json:"topApps"`
+	ShellCommands      int64               `json:"shellCommands"`
+	GitCommits         int64               `json:"gitCommits"`
+	FilesModified      int64               `json:"filesModified"`
+	SitesVisited       int64               `json:"sitesVisited"`
+	MeetingMinutes     int64               `json:"meetingMinutes"`
+	AbstractivityScore *AbstractivityScore `json:"abstractivityScore,omitempty"`
+	Comparison         *Comparison         `json:"comparison,omitempty"`
+	PreviousDay        *DailyStats         `json:"previousDay,omitempty"` // The day compared against
+	Delta              *DailyStatsDelta    `json:"delta,omitempty"`
+}
+
+// StatDelta is the change in one statistic relative to the compared day.
+type StatDelta struct {
+	Value   int64   `json:"value"`
+	Percent float64 `json:"percent"`
+}
+
+// DailyStatsDelta contains the change in each numeric daily statistic.
+type DailyStatsDelta struct {
+	Screenshots    StatDelta `json:"screenshots"`
+	Sessions       StatDelta `json:"sessions"`
+	ActiveMinutes  StatDelta `json:"activeMinutes"`
+	ShellCommands  StatDelta `json:"shellCommands"`
+	GitCommits     StatDelta `json:"gitCommits"`
+	FilesModified  StatDelta `json:"filesModified"`
+	SitesVisited   StatDelta `json:"sitesVisited"`
+	MeetingMinutes StatDelta `json:"meetingMinutes"`
 }
 
 // Comparison contains comparison data vs previous period.
@@ -167,10 +193,11 @@ type CalendarData struct {
 
 // DataSourceStats contains statistics from all data sources.
 type DataSourceStats struct {
-	Shell   *ShellStats   `json:"shell"`
-	Git     *GitStats     `json:"git"`
-	Files   *FileStats    `json:"files"`
-	Browser *BrowserStats `json:"browser"`
+	Shell     *ShellStats     `json:"shell"`
+	Git       *GitStats       `json:"git"`
+	Files     *FileStats      `json:"files"`
+	Browser   *BrowserStats   `json:"browser"`
+	Clipboard *ClipboardStats `json:"clipboard"`
 }
 
 // ShellStats contains shell command statistics.
@@ -205,9 +232,9 @@ type RepoUsage struct {
 
 // FileStats contains file event statistics.
 type FileStats struct {
-	TotalEvents    int64            `json:"totalEvents"`
-	EventsByType   map[string]int64 `json:"eventsByType"`
-	TopExtensions  map[string]int64 `json:"topExtensions"`
+	TotalEvents   int64                     `json:"totalEvents"`
+	EventsByType  map[string]int64          `json:"eventsByType"`
+	TopExtensions []*storage.ExtensionCount `json:"topExtensions"`
 }
 
 // BrowserStats contains browser history statistics.
@@ -218,6 +245,63 @@ type BrowserStats struct {
 	BrowserCounts  map[string]int64 `json:"browserCounts"`
 }
 
+// ClipboardStats contains clipboard usage statistics.
+// Only content sizes are tracked, never the clipboard content itself.
+type ClipboardStats struct {
+	TotalEvents   int64            `json:"totalEvents"`
+	TotalLength   int64            `json:"totalLength"`
+	AverageLength float64          `json:"averageLength"`
+	EventsByType  map[string]int64 `json:"eventsByType"`
+}
+
+// ClipboardHourly represents clipboard activity for an hour of the day.
+type ClipboardHourly struct {
+	Hour        int   `json:"hour"` // 0-23
+	EventCount  int64 `json:"eventCount"`
+	TotalLength int64 `json:"totalLength"`
+}
+
+// LanguageUsage represents how often a language was detected in VS Code settings.
+type LanguageUsage struct {
+	Language   string   `json:"language"`
+	EventCount int64    `json:"eventCount"`
+	Workspaces []string `json:"workspaces"` // Distinct workspace folders the language was seen in
+	Percentage float64  `json:"percentage"` // Share of all language events
+}
+
+// NetworkStats summarizes connections to external web hosts in a time range.
+type NetworkStats struct {
+	TopHosts           []*HostUsage `json:"topHosts"` // Most connected hosts, at most topNetworkHosts
+	TotalConnections   int64        `json:"totalConnections"`
+	TotalBytesSent     int64        `json:"totalBytesSent"`
+	TotalBytesReceived int64        `json:"totalBytesReceived"`
+}
+
+// HostUsage represents connections to a single host.
+type HostUsage struct {
+	Hostname        string   `json:"hostname"`
+	ConnectionCount int64    `json:"connectionCount"`
+	BytesSent       int64    `json:"bytesSent"`
+	BytesReceived   int64    `json:"bytesReceived"`
+	Processes       []string `json:"processes"` // Distinct processes that connected to the host
+}
+
+// AudioStats summarizes audio playback in a time range.
+type AudioStats struct {
+	TotalListeningMinutes int64            `json:"totalListeningMinutes"` // Time any audio was playing
+	TopApps               map[string]int64 `json:"topApps"`               // App name -> minutes playing audio
+}
+
+// topNetworkHosts is the number of hosts returned in NetworkStats.TopHosts.
+const topNetworkHosts = 10
+
+// topFileExtensions is the number of extensions returned in FileStats.TopExtensions.
+const topFileExtensions = 10
+
+// DefaultContextSwitchPenaltySeconds is the productive time lost each time the user
+// switches to a distracting app, used unless productivity.contextSwitchPenaltySeconds is set.
+const DefaultContextSwitchPenaltySeconds = 120
+
 // DomainUsage represents visits to a domain.
 type DomainUsage struct {
 	Domain     string `json:"domain"`
@@ -235,12 +319,14 @@ const (
 
 // ProductivityScore represents productivity analysis for a time period.
 type ProductivityScore struct {
-	Score                int     `json:"score"`                // 1-5 rating
-	ProductiveMinutes    int64   `json:"productiveMinutes"`
-	NeutralMinutes       int64   `json:"neutralMinutes"`
-	DistractingMinutes   int64   `json:"distractingMinutes"`
-	TotalMinutes         int64   `json:"totalMinutes"`
-	ProductivePercentage float64 `json:"productivePercentage"`
+	Score                       int     `json:"score"`             // 1-5 rating
+	ProductiveMinutes           int64   `json:"productiveMinutes"` // Before the context-switch penalty
+	NeutralMinutes              int64   `json:"neutralMinutes"`
+	DistractingMinutes          int64   `json:"distractingMinutes"`
+	TotalMinutes                int64   `json:"totalMinutes"`
+	ProductivePercentage        float64 `json:"productivePercentage"`        // After the context-switch penalty
+	ContextSwitchCount          int     `json:"contextSwitchCount"`          // Switches into a distracting app
+	ContextSwitchPenaltyMinutes int64   `json:"contextSwitchPenaltyMinutes"` // Productive time lost to refocusing
 }
 
 // HourlyFocus represents focus quality for a specific hour.
@@ -251,6 +337,36 @@ type HourlyFocus struct {
 	FocusLabel      string  `json:"focusLabel"`      // "Excellent", "Good", "Fair", "Poor", "Very Poor"
 }
 
+// FocusDistribution is a histogram of focus session lengths.
+type FocusDistribution struct {
+	Buckets []*FocusBucket `json:"buckets"`
+}
+
+// FocusBucket counts focus sessions within a length range.
+type FocusBucket struct {
+	Label        string  `json:"label"`        // e.g. "15-30m"
+	Count        int     `json:"count"`        // Number of focus sessions
+	TotalMinutes int64   `json:"totalMinutes"` // Total length of those sessions
+	Percentage   float64 `json:"percentage"`   // Percentage of all focus sessions
+}
+
+// focusSessionBuckets are the lower bounds (minutes) and labels of the focus length histogram.
+var focusSessionBuckets = []struct {
+	minMinutes float64
+	label      string
+}{
+	{0, "0-5m"},
+	{5, "5-15m"},
+	{15, "15-30m"},
+	{30, "30-60m"},
+	{60, "60-90m"},
+	{90, "90-120m"},
+	{120, "120m+"},
+}
+
+// focusSessionMaxGapSeconds is the longest gap between focus events within one focus session.
+const focusSessionMaxGapSeconds = 5 * 60
+
 // TagUsage represents usage statistics for an activity tag.
 type TagUsage struct {
 	Tag          string  `json:"tag"`
@@ -268,6 +384,37 @@ type WindowUsage struct {
 	FocusCount      int64   `json:"focusCount"`
 }
 
+// sessionActiveSeconds sums session durations clamped to a time range.
+// Ongoing sessions count up to the current time.
+func sessionActiveSeconds(sessions []*storage.Session, start, end int64) int64 {
+	currentTime := time.Now().Unix()
+	var totalActiveSeconds int64
+	for _, session := range sessions {
+		// Calculate session duration, clamping to range boundaries
+		sessionStart := session.StartTime
+		if sessionStart < start {
+			sessionStart = start
+		}
+
+		var sessionEnd int64
+		if !session.EndTime.Valid {
+			// Ongoing session - use current time
+			sessionEnd = currentTime
+		} else {
+			sessionEnd = session.EndTime.Int64
+		}
+		if sessionEnd > end {
+			sessionEnd = end
+		}
+
+		duration := sessionEnd - sessionStart
+		if duration > 0 {
+			totalActiveSeconds += duration
+		}
+	}
+	return totalActiveSeconds
+}
+
 // GetDailyStats returns statistics for a specific date.
 func (s *AnalyticsService) GetDailyStats(date string) (*DailyStats, error) {
 	return s.GetDailyStatsWithComparison(date, false)
@@ -281,8 +428,39 @@ func (s *AnalyticsService) GetDailyStatsWithComparison(date string, withComparis
 		return nil, err
 	}
 
-	start := t.Unix()
-	end := t.AddDate(0, 0, 1).Unix() - 1
+	stats := s.dailyStatsForDay(date, t)
+
+	// Add comparison if requested
+	if withComparison {
+		s.compareWithDay(stats, t.AddDate(0, 0, -1))
+	}
+
+	return stats, nil
+}
+
+// GetTimezoneCorrectedStats returns statistics for a date (YYYY-MM-DD) whose day boundaries
+// are midnight in displayTZ (an IANA name such as "Asia/Tokyo") rather than the system
+// timezone, e.g. to show a trip's days as they were lived. An empty displayTZ uses local time.
+func (s *AnalyticsService) GetTimezoneCorrectedStats(date string, displayTZ string) (*DailyStats, error) {
+	loc := time.Local
+	if displayTZ != "" {
+		var err error
+		loc, err = time.LoadLocation(displayTZ)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", displayTZ, err)
+		}
+	}
+	t, err := time.ParseInLocation("2006-01-02", date, loc)
+	if err != nil {
+		return nil, err
+	}
+	return s.dailyStatsForDay(date, t), nil
+}
+
+// dailyStatsForDay computes the statistics for the day starting at dayStart.
+func (s *AnalyticsService) dailyStatsForDay(date string, dayStart time.Time) *DailyStats {
+	start := dayStart.Unix()
+	end := dayStart.AddDate(0, 0, 1).Unix() - 1
 
 	stats := &DailyStats{Date: date}
 
@@ -295,32 +473,7 @@ func (s *AnalyticsService) GetDailyStatsWithComparison(date string, withComparis
 
 	// Calculate active minutes from session durations (matches Timeline behavior)
 	// This ensures Analytics Day tab shows the same metric as Timeline
-	currentTime := time.Now().Unix()
-	var totalActiveSeconds int64
-	for _, session := range sessions {
-		// Calculate session duration, clamping to day boundaries
-		sessionStart := session.StartTime
-		if sessionStart < start {
-			sessionStart = start
-		}
-
-		var sessionEnd int64
-		if !session.EndTime.Valid {
-			// Ongoing session - use current time
-			sessionEnd = currentTime
-		} else {
-			sessionEnd = session.EndTime.Int64
-		}
-		if sessionEnd > end {
-			sessionEnd = end
-		}
-
-		duration := sessionEnd - sessionStart
-		if duration > 0 {
-			totalActiveSeconds += duration
-		}
-	}
-	stats.ActiveMinutes = totalActiveSeconds / 60
+	stats.ActiveMinutes = sessionActiveSeconds(sessions, start, end) / 60
 
 	// Get top apps from focus events for app usage tracking
 	// Clamp durations to day boundaries for events spanning midnight
@@ -339,18 +492,51 @@ func (s *AnalyticsService) GetDailyStatsWithComparison(date string, withComparis
 	stats.FilesModified, _ = s.store.CountFileEventsByTimeRange(start, end)
 	stats.SitesVisited, _ = s.store.CountUniqueDomainsByTimeRange(start, end)
 
-	// Add comparison if requested
-	if withComparison {
-		prevDate := t.AddDate(0, 0, -1).Format("2006-01-02")
-		prevStats, err := s.GetDailyStats(prevDate)
-		if err == nil && prevStats != nil {
-			stats.Comparison = s.calculateComparison(stats, prevStats)
-		}
+	if meetingStats, err := s.GetMeetingStats(start, end); err == nil {
+		stats.MeetingMinutes = meetingStats.TotalMeetingMinutes
+	}
+
+	stats.AbstractivityScore = s.abstractivityScore(focusEvents, start, end, stats.GitCommits, stats.FilesModified)
+
+	return stats
+}
+
+// CompareWithSameDayLastWeek returns statistics for a date compared with the same weekday a week earlier.
+func (s *AnalyticsService) CompareWithSameDayLastWeek(date string) (*DailyStats, error) {
+	stats, err := s.GetDailyStats(date)
+	if err != nil {
+		return nil, err
 	}
+	t, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+	s.compareWithDay(stats, t.AddDate(0, 0, -7))
+	return stats, nil
+}
 
+// CompareWithSameDayLastMonth returns statistics for a date compared with the same day of the
+// previous month. Days past the end of a shorter month compare with its last day (Mar 31 vs Feb 28).
+func (s *AnalyticsService) CompareWithSameDayLastMonth(date string) (*DailyStats, error) {
+	stats, err := s.GetDailyStats(date)
+	if err != nil {
+		return nil, err
+	}
+	t, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+	prev := time.Date(t.Year(), t.Month()-1, 1, 0, 0, 0, 0, time.Local)
+	lastDay := prev.AddDate(0, 1, -1).Day()
+	s.compareWithDay(stats, prev.AddDate(0, 0, min(t.Day(), lastDay)-1))
 	return stats, nil
 }
 
+// compareWithDay fills in stats' comparison fields against the stats for day.
+func (s *AnalyticsService) compareWithDay(stats *DailyStats, day time.Time) {
+	prevStats, err := s.GetDailyStats(day.Format("2006-01-02"))
+	if err != nil || prevStats == nil {
+		return
+	}
+	stats.PreviousDay = prevStats
+	stats.Comparison = s.calculateComparison(stats, prevStats)
+	stats.Delta = calculateDelta(stats, prevStats)
+}
+
 // percentChange calculates the percentage change from prev to curr.
 // When prev is 0 and curr is non-zero, returns 100 (all new activity).
 // When both are 0, returns 0.
@@ -392,6 +578,23 @@ func (s *AnalyticsService) calculateComparison(current, previous *DailyStats) *C
 	return comp
 }
 
+// calculateDelta calculates the change in each numeric statistic between previous and current.
+func calculateDelta(current, previous *DailyStats) *DailyStatsDelta {
+	delta := func(curr, prev int64) StatDelta {
+		return StatDelta{Value: curr - prev, Percent: percentChange(curr-prev, prev)}
+	}
+	return &DailyStatsDelta{
+		Screenshots:    delta(current.TotalScreenshots, previous.TotalScreenshots),
+		Sessions:       delta(current.TotalSessions, previous.TotalSessions),
+		ActiveMinutes:  delta(current.ActiveMinutes, previous.ActiveMinutes),
+		ShellCommands:  delta(current.ShellCommands, previous.ShellCommands),
+		GitCommits:     delta(current.GitCommits, previous.GitCommits),
+		FilesModified:  delta(current.FilesModified, previous.FilesModified),
+		SitesVisited:   delta(current.SitesVisited, previous.SitesVisited),
+		MeetingMinutes: delta(current.MeetingMinutes, previous.MeetingMinutes),
+	}
+}
+
 // GetWeeklyStats returns statistics for a week starting from the given date.
 func (s *AnalyticsService) GetWeeklyStats(startDate string) (*WeeklyStats, error) {
 	t, err := time.ParseInLocation("2006-01-02", startDate, time.Local)
@@ -731,6 +934,35 @@ func (s *AnalyticsService) GetProjectUsage(start, end int64) ([]*ProjectUsage, e
 	return result, nil
 }
 
+// DailyWindowUsage is one day of GetWindowTitleTrend.
+type DailyWindowUsage struct {
+	Date    string  `json:"date"` // YYYY-MM-DD
+	Minutes float64 `json:"minutes"`
+}
+
+// GetWindowTitleTrend returns the daily focus minutes of windows whose title matches
+// pattern (a case-insensitive LIKE pattern, or a substring) over the last days days,
+// oldest first and ending today.
+func (s *AnalyticsService) GetWindowTitleTrend(pattern string, days int) ([]*DailyWindowUsage, error) {
+	if days <= 0 {
+		return []*DailyWindowUsage{}, nil
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	first := today.AddDate(0, 0, -(days - 1))
+
+	seconds, err := s.store.GetWindowTitleSecondsByDay(pattern, first.Unix(), today.AddDate(0, 0, 1).Unix()-1)
+	if err != nil {
+		return nil, err
+	}
+	trend := make([]*DailyWindowUsage, 0, days)
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		trend = append(trend, &DailyWindowUsage{Date: date, Minutes: math.Round(seconds[date]/60*10) / 10})
+	}
+	return trend, nil
+}
+
 // GetHourlyActivity returns hourly activity breakdown for a date.
 func (s *AnalyticsService) GetHourlyActivity(date string) ([]*HourlyActivity, error) {
 	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
@@ -894,16 +1126,13 @@ func (s *AnalyticsService) GetDataSourceStats(start, end int64) (*DataSourceStat
 	// File stats
 	fileEvents, _ := s.store.GetFileEventsByTimeRange(start, end)
 	fileStats := &FileStats{
-		TotalEvents:   int64(len(fileEvents)),
-		EventsByType:  make(map[string]int64),
-		TopExtensions: make(map[string]int64),
+		TotalEvents:  int64(len(fileEvents)),
+		EventsByType: make(map[string]int64),
 	}
 	for _, evt := range fileEvents {
 		fileStats.EventsByType[evt.EventType]++
-		if evt.FileExtension.Valid {
-			fileStats.TopExtensions[evt.FileExtension.String]++
-		}
 	}
+	fileStats.TopExtensions, _ = s.store.GetTopFileExtensions(start, end, topFileExtensions)
 	stats.Files = fileStats
 
 	// Browser stats
@@ -921,6 +1150,323 @@ func (s *AnalyticsService) GetDataSourceStats(start, end int64) (*DataSourceStat
 	}
 	stats.Browser = browserStats
 
+	// Clipboard stats
+	clipboardEvents, _ := s.store.GetClipboardEventsByTimeRange(start, end)
+	clipboardStats := &ClipboardStats{
+		TotalEvents:  int64(len(clipboardEvents)),
+		EventsByType: make(map[string]int64),
+	}
+	for _, evt := range clipboardEvents {
+		clipboardStats.TotalLength += evt.ContentLength
+		clipboardStats.EventsByType[evt.ContentType]++
+	}
+	if clipboardStats.TotalEvents > 0 {
+		clipboardStats.AverageLength = float64(clipboardStats.TotalLength) / float64(clipboardStats.TotalEvents)
+	}
+	stats.Clipboard = clipboardStats
+
+	return stats, nil
+}
+
+// GetBrowserUsageByProfile returns browser statistics for each browser profile in a time range.
+// This lets work and personal browsing be viewed separately.
+func (s *AnalyticsService) GetBrowserUsageByProfile(start, end int64) (map[string]*BrowserStats, error) {
+	visits, err := s.store.GetBrowserVisitsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	profiles := make(map[string]*BrowserStats)
+	domainCounts := make(map[string]map[string]int64)
+	for _, visit := range visits {
+		stats, ok := profiles[visit.BrowserProfile]
+		if !ok {
+			stats = &BrowserStats{BrowserCounts: make(map[string]int64)}
+			profiles[visit.BrowserProfile] = stats
+			domainCounts[visit.BrowserProfile] = make(map[string]int64)
+		}
+		stats.TotalVisits++
+		stats.BrowserCounts[visit.Browser]++
+		domainCounts[visit.BrowserProfile][visit.Domain]++
+	}
+
+	for profile, stats := range profiles {
+		counts := domainCounts[profile]
+		stats.UniqueDomains = int64(len(counts))
+		for domain, count := range counts {
+			stats.TopDomains = append(stats.TopDomains, &DomainUsage{
+				Domain:     domain,
+				VisitCount: count,
+			})
+		}
+		sort.Slice(stats.TopDomains, func(i, j int) bool {
+			if stats.TopDomains[i].VisitCount != stats.TopDomains[j].VisitCount {
+				return stats.TopDomains[i].VisitCount > stats.TopDomains[j].VisitCount
+			}
+			return stats.TopDomains[i].Domain < stats.TopDomains[j].Domain
+		})
+		// Match GetDataSourceStats, which returns the top 10 domains
+		if len(stats.TopDomains) > 10 {
+			stats.TopDomains = stats.TopDomains[:10]
+		}
+	}
+
+	return profiles, nil
+}
+
+// BrowserVisitPage contains one page of a domain's browser visits.
+type BrowserVisitPage struct {
+	Visits  []*storage.BrowserVisit `json:"visits"`
+	Total   int64                   `json:"total"`
+	Offset  int                     `json:"offset"`
+	Limit   int                     `json:"limit"`
+	HasMore bool                    `json:"hasMore"`
+}
+
+// GetBrowserVisitsByDomain returns a page of visits to a domain in a time range, newest first,
+// for drilling down into a single domain.
+func (s *AnalyticsService) GetBrowserVisitsByDomain(domain string, start, end int64, offset, limit int) (*BrowserVisitPage, error) {
+	if offset < 0 {
+		offset = 0
+	}
+	if limit < 1 || limit > 500 {
+		limit = 50
+	}
+
+	visits, total, err := s.store.GetBrowserVisitsByDomain(domain, start, end, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	if visits == nil {
+		visits = []*storage.BrowserVisit{}
+	}
+
+	return &BrowserVisitPage{
+		Visits:  visits,
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+		HasMore: int64(offset+len(visits)) < total,
+	}, nil
+}
+
+// DomainVisitBlock is a visit to a domain positioned on the timeline.
+type DomainVisitBlock struct {
+	Timestamp       int64   `json:"timestamp"`
+	Title           string  `json:"title"`
+	URL             string  `json:"url"`
+	DurationSeconds int64   `json:"durationSeconds"`
+	HourOffset      int     `json:"hourOffset"`    // Hour of day (0-23)
+	MinuteOffset    int     `json:"minuteOffset"`  // Minute within the hour (0-59)
+	PixelPosition   float64 `json:"pixelPosition"` // Position within the hour row (0-60)
+}
+
+// GetDomainVisitTimeline returns the visits to a domain in a time range, oldest first,
+// positioned like the browser events in the timeline grid.
+func (s *AnalyticsService) GetDomainVisitTimeline(domain string, start, end int64) ([]*DomainVisitBlock, error) {
+	visits, err := s.store.GetDomainVisitsByTimeRange(domain, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*DomainVisitBlock, 0, len(visits))
+	for _, visit := range visits {
+		visitTime := time.Unix(visit.Timestamp, 0).In(time.Local)
+		block := &DomainVisitBlock{
+			Timestamp:     visit.Timestamp,
+			URL:           visit.URL,
+			HourOffset:    visitTime.Hour(),
+			MinuteOffset:  visitTime.Minute(),
+			PixelPosition: (float64(visitTime.Minute()) / 60.0) * 60.0,
+		}
+		if visit.Title.Valid {
+			block.Title = visit.Title.String
+		}
+		if visit.VisitDurationSeconds.Valid {
+			block.DurationSeconds = visit.VisitDurationSeconds.Int64
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// GetDomainActivityByHour returns the minutes spent on a domain in each hour of the day,
+// summed over every day in a time range. Visits that run past the end of an hour are
+// split across the hours they overlap. ScreenshotCount is always zero.
+func (s *AnalyticsService) GetDomainActivityByHour(domain string, start, end int64) ([]*HourlyActivity, error) {
+	visits, err := s.store.GetDomainVisitsByTimeRange(domain, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var hourSeconds [24]int64
+	for _, visit := range visits {
+		if !visit.VisitDurationSeconds.Valid {
+			continue
+		}
+		addSecondsByHour(&hourSeconds, visit.Timestamp, visit.Timestamp+visit.VisitDurationSeconds.Int64)
+	}
+
+	activity := make([]*HourlyActivity, 24)
+	for hour := range activity {
+		activity[hour] = &HourlyActivity{
+			Hour:          hour,
+			ActiveMinutes: hourSeconds[hour] / 60,
+		}
+	}
+	return activity, nil
+}
+
+// addSecondsByHour splits the time from start to end across the local hours of the day it falls in.
+func addSecondsByHour(hourSeconds *[24]int64, start, end int64) {
+	endTime := time.Unix(end, 0).In(time.Local)
+	for t := time.Unix(start, 0).In(time.Local); t.Before(endTime); {
+		nextHour := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.Local).Add(time.Hour)
+		if nextHour.After(endTime) {
+			nextHour = endTime
+		}
+		hourSeconds[t.Hour()] += int64(nextHour.Sub(t) / time.Second)
+		t = nextHour
+	}
+}
+
+// GetClipboardActivity returns clipboard activity grouped by hour of day for a time range.
+func (s *AnalyticsService) GetClipboardActivity(start, end int64) ([]*ClipboardHourly, error) {
+	events, err := s.store.GetClipboardEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	hourly := make([]*ClipboardHourly, 24)
+	for hour := 0; hour < 24; hour++ {
+		hourly[hour] = &ClipboardHourly{Hour: hour}
+	}
+	for _, evt := range events {
+		hour := time.Unix(evt.Timestamp, 0).In(time.Local).Hour()
+		hourly[hour].EventCount++
+		hourly[hour].TotalLength += evt.ContentLength
+	}
+
+	return hourly, nil
+}
+
+// GetLanguageStats returns the languages detected in VS Code settings for a time range,
+// most frequent first.
+func (s *AnalyticsService) GetLanguageStats(start, end int64) ([]*LanguageUsage, error) {
+	events, err := s.store.GetVSCodeLanguageEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	byLanguage := make(map[string]*LanguageUsage)
+	seenWorkspaces := make(map[string]map[string]bool)
+	for _, evt := range events {
+		usage, ok := byLanguage[evt.Language]
+		if !ok {
+			usage = &LanguageUsage{Language: evt.Language, Workspaces: []string{}}
+			byLanguage[evt.Language] = usage
+			seenWorkspaces[evt.Language] = make(map[string]bool)
+		}
+		usage.EventCount++
+		if evt.Workspace.Valid && !seenWorkspaces[evt.Language][evt.Workspace.String] {
+			seenWorkspaces[evt.Language][evt.Workspace.String] = true
+			usage.Workspaces = append(usage.Workspaces, evt.Workspace.String)
+		}
+	}
+
+	result := make([]*LanguageUsage, 0, len(byLanguage))
+	for _, usage := range byLanguage {
+		usage.Percentage = float64(usage.EventCount) / float64(len(events)) * 100
+		result = append(result, usage)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].EventCount != result[j].EventCount {
+			return result[i].EventCount > result[j].EventCount
+		}
+		return result[i].Language < result[j].Language
+	})
+
+	return result, nil
+}
+
+// GetNetworkStats returns the hosts connected to most often in a time range and the total
+// bytes transferred.
+func (s *AnalyticsService) GetNetworkStats(start, end int64) (*NetworkStats, error) {
+	events, err := s.store.GetNetworkEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &NetworkStats{TopHosts: []*HostUsage{}}
+	byHost := make(map[string]*HostUsage)
+	seenProcesses := make(map[string]map[string]bool)
+	for _, evt := range events {
+		stats.TotalConnections++
+		stats.TotalBytesSent += evt.BytesSent
+		stats.TotalBytesReceived += evt.BytesReceived
+
+		usage, ok := byHost[evt.Hostname]
+		if !ok {
+			usage = &HostUsage{Hostname: evt.Hostname, Processes: []string{}}
+			byHost[evt.Hostname] = usage
+			seenProcesses[evt.Hostname] = make(map[string]bool)
+		}
+		usage.ConnectionCount++
+		usage.BytesSent += evt.BytesSent
+		usage.BytesReceived += evt.BytesReceived
+		if evt.ProcessName.Valid && !seenProcesses[evt.Hostname][evt.ProcessName.String] {
+			seenProcesses[evt.Hostname][evt.ProcessName.String] = true
+			usage.Processes = append(usage.Processes, evt.ProcessName.String)
+		}
+	}
+
+	for _, usage := range byHost {
+		stats.TopHosts = append(stats.TopHosts, usage)
+	}
+	sort.Slice(stats.TopHosts, func(i, j int) bool {
+		if stats.TopHosts[i].ConnectionCount != stats.TopHosts[j].ConnectionCount {
+			return stats.TopHosts[i].ConnectionCount > stats.TopHosts[j].ConnectionCount
+		}
+		return stats.TopHosts[i].Hostname < stats.TopHosts[j].Hostname
+	})
+	if len(stats.TopHosts) > topNetworkHosts {
+		stats.TopHosts = stats.TopHosts[:topNetworkHosts]
+	}
+
+	return stats, nil
+}
+
+// GetAudioStats returns how long audio was playing in a time range and the minutes each app
+// played for. Each audio event stands for the time since the previous poll.
+func (s *AnalyticsService) GetAudioStats(start, end int64) (*AudioStats, error) {
+	events, err := s.store.GetAudioEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &AudioStats{TopApps: make(map[string]int64)}
+	appSeconds := make(map[string]int64)
+	polls := make(map[int64]int64) // Poll timestamp -> seconds it covers
+	for _, evt := range events {
+		// Several apps can play during the same poll
+		polls[evt.Timestamp] = max(polls[evt.Timestamp], evt.DurationSeconds)
+		appName := evt.AppName
+		if appName == "" {
+			appName = "Unknown"
+		}
+		appSeconds[appName] += evt.DurationSeconds
+	}
+
+	var totalSeconds int64
+	for _, seconds := range polls {
+		totalSeconds += seconds
+	}
+	stats.TotalListeningMinutes = totalSeconds / 60
+	for appName, seconds := range appSeconds {
+		stats.TopApps[appName] = seconds / 60
+	}
+
 	return stats, nil
 }
 
@@ -1002,6 +1548,7 @@ func (s *AnalyticsService) GetProductivityScore(date string) (*ProductivityScore
 	score := &ProductivityScore{}
 
 	// Categorize and sum durations, clamping to day boundaries
+	var prevCategory AppCategory
 	for _, evt := range focusEvents {
 		minutes := int64(clampedEventDuration(evt, start, end) / 60)
 
@@ -1011,16 +1558,26 @@ func (s *AnalyticsService) GetProductivityScore(date string) (*ProductivityScore
 			score.ProductiveMinutes += minutes
 		case CategoryDistracting:
 			score.DistractingMinutes += minutes
+			// Each interruption also costs the time it takes to get back into focused work
+			if prevCategory != "" && prevCategory != CategoryDistracting {
+				score.ContextSwitchCount++
+			}
 		case CategoryNeutral:
 			score.NeutralMinutes += minutes
 		}
+		prevCategory = category
 	}
 
 	score.TotalMinutes = score.ProductiveMinutes + score.NeutralMinutes + score.DistractingMinutes
+	score.ContextSwitchPenaltyMinutes = min(
+		int64(score.ContextSwitchCount)*s.contextSwitchPenaltySeconds()/60,
+		score.ProductiveMinutes,
+	)
 
-	// Calculate percentage
+	// Calculate percentage, counting the penalty against productive time
 	if score.TotalMinutes > 0 {
-		score.ProductivePercentage = (float64(score.ProductiveMinutes) / float64(score.TotalMinutes)) * 100
+		effective := score.ProductiveMinutes - score.ContextSwitchPenaltyMinutes
+		score.ProductivePercentage = (float64(effective) / float64(score.TotalMinutes)) * 100
 	}
 
 	// Calculate score (1-5) based on productive percentage
@@ -1041,6 +1598,17 @@ func (s *AnalyticsService) GetProductivityScore(date string) (*ProductivityScore
 	return score, nil
 }
 
+// contextSwitchPenaltySeconds returns the productive time lost per switch into a
+// distracting app, from productivity.contextSwitchPenaltySeconds.
+func (s *AnalyticsService) contextSwitchPenaltySeconds() int64 {
+	if val, err := s.store.GetConfig("productivity.contextSwitchPenaltySeconds"); err == nil {
+		if v, e := strconv.ParseInt(val, 10, 64); e == nil && v >= 0 {
+			return v
+		}
+	}
+	return DefaultContextSwitchPenaltySeconds
+}
+
 // sortAppUsage converts duration map to sorted slice with percentages.
 func (s *AnalyticsService) sortAppUsage(appDurations map[string]float64) []*AppUsage {
 	return s.sortAppUsageWithCounts(appDurations, nil)
@@ -1182,6 +1750,124 @@ func (s *AnalyticsService) GetFocusDistribution(date string) ([]*HourlyFocus, er
 	return result, nil
 }
 
+// GetFocusSessionLengthDistribution buckets focus sessions in a time range by length.
+// A focus session is a run of "focus"-category events with no gap over 5 minutes; it's
+// computed from focus events rather than tracking sessions.
+func (s *AnalyticsService) GetFocusSessionLengthDistribution(start, end int64) (*FocusDistribution, error) {
+	events, err := s.store.GetFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	appNames := make(map[string]bool)
+	for _, evt := range events {
+		appNames[evt.AppName] = true
+	}
+	appNamesList := make([]string, 0, len(appNames))
+	for name := range appNames {
+		appNamesList = append(appNamesList, name)
+	}
+	categories, err := s.store.GetAppTimelineCategories(appNamesList)
+	if err != nil {
+		return nil, err
+	}
+
+	dist := &FocusDistribution{Buckets: make([]*FocusBucket, len(focusSessionBuckets))}
+	for i, b := range focusSessionBuckets {
+		dist.Buckets[i] = &FocusBucket{Label: b.label}
+	}
+
+	total := 0
+	for _, seconds := range focusSessionLengths(events, categories, start, end) {
+		minutes := seconds / 60
+		i := len(focusSessionBuckets) - 1
+		for i > 0 && minutes < focusSessionBuckets[i].minMinutes {
+			i--
+		}
+		dist.Buckets[i].Count++
+		dist.Buckets[i].TotalMinutes += int64(minutes)
+		total++
+	}
+
+	if total > 0 {
+		for _, bucket := range dist.Buckets {
+			bucket.Percentage = float64(bucket.Count) / float64(total) * 100
+		}
+	}
+	return dist, nil
+}
+
+// focusSessionLengths returns the length in seconds of each focus session, clamped to the range.
+// Events are ordered by start time, as returned by the store.
+func focusSessionLengths(events []*storage.WindowFocusEvent, categories map[string]string, rangeStart, rangeEnd int64) []float64 {
+	var lengths []float64
+	var first, last *storage.WindowFocusEvent
+
+	flush := func() {
+		if first != nil {
+			sessionStart, sessionEnd := first.StartTime, last.EndTime
+			if sessionStart < rangeStart {
+				sessionStart = rangeStart
+			}
+			if sessionEnd > rangeEnd {
+				sessionEnd = rangeEnd
+			}
+			if sessionEnd > sessionStart {
+				lengths = append(lengths, float64(sessionEnd-sessionStart))
+			}
+		}
+		first, last = nil, nil
+	}
+
+	for _, evt := range events {
+		if categories[evt.AppName] != "focus" {
+			flush()
+			continue
+		}
+		if last != nil && evt.StartTime-last.EndTime > focusSessionMaxGapSeconds {
+			flush()
+		}
+		if first == nil {
+			first = evt
+		}
+		if last == nil || evt.EndTime > last.EndTime {
+			last = evt
+		}
+	}
+	flush()
+
+	return lengths
+}
+
+// GetAFKBreakdown returns the minutes spent away in a time range, keyed by what triggered
+// each away period (idle_timeout, screen_lock, display_off, sleep or manual).
+// Periods are clipped to the range; an ongoing period counts up to now.
+func (s *AnalyticsService) GetAFKBreakdown(start, end int64) (map[string]int64, error) {
+	events, err := s.store.GetAFKEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().Unix()
+	seconds := make(map[string]int64)
+	for _, event := range events {
+		eventEnd := now
+		if event.EndTime.Valid {
+			eventEnd = event.EndTime.Int64
+		}
+		from, to := max(event.StartTime, start), min(eventEnd, end)
+		if to > from {
+			seconds[event.TriggerType] += to - from
+		}
+	}
+
+	breakdown := make(map[string]int64, len(seconds))
+	for trigger, secs := range seconds {
+		breakdown[trigger] = secs / 60
+	}
+	return breakdown, nil
+}
+
 // GetActivityTags extracts and aggregates tags from session summaries for a given date.
 // Returns top tags sorted by total time spent, useful for understanding activity distribution.
 func (s *AnalyticsService) GetActivityTags(date string) ([]*TagUsage, error) {
@@ -1263,8 +1949,8 @@ func (s *AnalyticsService) GetActivityTags(date string) ([]*TagUsage, error) {
 	return tags, nil
 }
 
-// GetTopWindows returns the most used windows for a time range, grouped by window title.
-// Windows are ranked by total duration and include the app name, total time, and occurrence count.
+// GetTopWindows returns the most used windows for a time range, grouped by window title and app.
+// Windows are ranked by total duration and include the app name, total time, and focus switch count.
 func (s *AnalyticsService) GetTopWindows(start, end int64, limit int) ([]*WindowUsage, error) {
 	// Get all window focus events for the time range
 	focusEvents, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
@@ -1272,56 +1958,76 @@ func (s *AnalyticsService) GetTopWindows(start, end int64, limit int) ([]*Window
 		return nil, err
 	}
 
-	// Aggregate durations and counts by window title, clamping to query range
-	windowDurations := make(map[string]float64)
-	windowCounts := make(map[string]int64)
-	windowApps := make(map[string]string) // window title -> app name (most recent)
+	return aggregateWindowUsage(focusEvents, start, end, limit), nil
+}
+
+// GetTopWindowsForApp returns the most used windows of a single app for a time range.
+// appName may be either the raw or the friendly app name. Percentages are relative to the app's total time.
+func (s *AnalyticsService) GetTopWindowsForApp(appName string, start, end int64, limit int) ([]*WindowUsage, error) {
+	focusEvents, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
 
+	var appEvents []*storage.WindowFocusEvent
 	for _, evt := range focusEvents {
-		// Use window title as key (windows can have different apps theoretically)
-		key := evt.WindowTitle
-		windowDurations[key] += clampedEventDuration(evt, start, end)
-		windowCounts[key]++
-		windowApps[key] = evt.AppName // Keep track of the app (use last seen)
+		if evt.AppName == appName || GetFriendlyAppName(evt.AppName) == appName {
+			appEvents = append(appEvents, evt)
+		}
 	}
 
-	// Calculate total duration for percentage calculation
-	var totalDuration float64
-	for _, dur := range windowDurations {
-		totalDuration += dur
+	return aggregateWindowUsage(appEvents, start, end, limit), nil
+}
+
+// aggregateWindowUsage groups focus events by window title and app, clamping durations to the query range.
+// Results are sorted by duration descending and truncated to limit (0 = no limit).
+func aggregateWindowUsage(focusEvents []*storage.WindowFocusEvent, start, end int64, limit int) []*WindowUsage {
+	type windowKey struct {
+		title string
+		app   string
 	}
 
-	// Convert to slice
-	var windows []*WindowUsage
-	for windowTitle, duration := range windowDurations {
-		percentage := 0.0
-		if totalDuration > 0 {
-			percentage = (duration / totalDuration) * 100
+	usageByWindow := make(map[windowKey]*WindowUsage)
+	var totalDuration float64
+	for _, evt := range focusEvents {
+		// The same title can appear in different apps (e.g. "README.md" in an editor and a browser)
+		key := windowKey{title: evt.WindowTitle, app: evt.AppName}
+		usage, ok := usageByWindow[key]
+		if !ok {
+			usage = &WindowUsage{
+				WindowTitle: evt.WindowTitle,
+				AppName:     GetFriendlyAppName(evt.AppName),
+			}
+			usageByWindow[key] = usage
 		}
 
-		windows = append(windows, &WindowUsage{
-			WindowTitle:     windowTitle,
-			AppName:         GetFriendlyAppName(windowApps[windowTitle]),
-			DurationSeconds: duration,
-			Percentage:      percentage,
-			FocusCount:      windowCounts[windowTitle],
-		})
+		duration := clampedEventDuration(evt, start, end)
+		usage.DurationSeconds += duration
+		usage.FocusCount++
+		totalDuration += duration
 	}
 
-	// Sort by duration descending
-	for i := 0; i < len(windows); i++ {
-		for j := i + 1; j < len(windows); j++ {
-			if windows[j].DurationSeconds > windows[i].DurationSeconds {
-				windows[i], windows[j] = windows[j], windows[i]
-			}
+	windows := make([]*WindowUsage, 0, len(usageByWindow))
+	for _, usage := range usageByWindow {
+		if totalDuration > 0 {
+			usage.Percentage = (usage.DurationSeconds / totalDuration) * 100
 		}
+		windows = append(windows, usage)
 	}
 
+	// Sort by duration descending, then by title for a stable order
+	sort.Slice(windows, func(i, j int) bool {
+		if windows[i].DurationSeconds != windows[j].DurationSeconds {
+			return windows[i].DurationSeconds > windows[j].DurationSeconds
+		}
+		return windows[i].WindowTitle < windows[j].WindowTitle
+	})
+
 	// Return top N
 	if limit > 0 && len(windows) > limit {
-		return windows[:limit], nil
+		return windows[:limit]
 	}
-	return windows, nil
+	return windows
 }
 
 // ExportAnalytics exports analytics data in the specified format.
@@ -1373,7 +2079,11 @@ func (s *AnalyticsService) exportDailyAnalytics(date, format string) (string, er
 	case "csv":
 		return s.exportDailyCSV(stats, appUsage, hourlyActivity), nil
 	case "html":
-		return s.exportDailyHTML(stats, appUsage, hourlyActivity), nil
+		score, err := s.GetProductivityScore(date)
+		if err != nil {
+			return "", err
+		}
+		return s.exportDailyHTML(stats, score, appUsage, hourlyActivity), nil
 	case "json":
 		return s.exportDailyJSON(stats, appUsage, hourlyActivity), nil
 	default:
@@ -1529,55 +2239,47 @@ func (s *AnalyticsService) exportMonthlyJSON(stats *MonthlyStats) string {
 
 // Helper functions for HTML export
 
-func (s *AnalyticsService) exportDailyHTML(stats *DailyStats, appUsage []*AppUsage, hourlyActivity []*HourlyActivity) string {
+func (s *AnalyticsService) exportDailyHTML(stats *DailyStats, score *ProductivityScore, appUsage []*AppUsage, hourlyActivity []*HourlyActivity) string {
 	var html strings.Builder
-	
+
 	html.WriteString("<!DOCTYPE html><html><head>")
 	html.WriteString("<meta charset='UTF-8'>")
+	html.WriteString("<meta name='viewport' content='width=device-width, initial-scale=1'>")
 	html.WriteString("<title>Daily Analytics - " + stats.Date + "</title>")
-	html.WriteString("<style>")
-	html.WriteString("body { font-family: Arial, sans-serif; max-width: 1200px; margin: 40px auto; padding: 20px; }")
-	html.WriteString("h1 { color: #333; border-bottom: 3px solid #4a9eff; padding-bottom: 10px; }")
-	html.WriteString("h2 { color: #666; margin-top: 30px; }")
-	html.WriteString("table { width: 100%; border-collapse: collapse; margin-top: 15px; }")
-	html.WriteString("th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }")
-	html.WriteString("th { background-color: #4a9eff; color: white; }")
-	html.WriteString("tr:hover { background-color: #f5f5f5; }")
-	html.WriteString(".summary { background: #f8f9fa; padding: 20px; border-radius: 8px; margin: 20px 0; }")
-	html.WriteString(".stat { display: inline-block; margin: 10px 20px 10px 0; }")
-	html.WriteString(".stat-label { color: #666; font-size: 14px; }")
-	html.WriteString(".stat-value { font-size: 24px; font-weight: bold; color: #333; }")
-	html.WriteString("</style></head><body>")
-	
+	html.WriteString(analyticsExportStyle)
+	html.WriteString("</head><body>")
+
 	html.WriteString(fmt.Sprintf("<h1>Daily Analytics - %s</h1>", stats.Date))
-	
-	html.WriteString("<div class='summary'>")
-	html.WriteString(fmt.Sprintf("<div class='stat'><div class='stat-label'>Active Time</div><div class='stat-value'>%d min</div></div>", stats.ActiveMinutes))
-	html.WriteString(fmt.Sprintf("<div class='stat'><div class='stat-label'>Sessions</div><div class='stat-value'>%d</div></div>", stats.TotalSessions))
-	html.WriteString(fmt.Sprintf("<div class='stat'><div class='stat-label'>Screenshots</div><div class='stat-value'>%d</div></div>", stats.TotalScreenshots))
-	html.WriteString(fmt.Sprintf("<div class='stat'><div class='stat-label'>Shell Commands</div><div class='stat-value'>%d</div></div>", stats.ShellCommands))
-	html.WriteString(fmt.Sprintf("<div class='stat'><div class='stat-label'>Git Commits</div><div class='stat-value'>%d</div></div>", stats.GitCommits))
-	html.WriteString("</div>")
-	
-	html.WriteString("<h2>Application Usage</h2>")
-	html.WriteString("<table><thead><tr><th>Application</th><th>Duration</th><th>Percentage</th><th>Focus Count</th></tr></thead><tbody>")
-	for _, app := range appUsage {
-		minutes := int(app.DurationSeconds / 60)
-		html.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%d min</td><td>%.1f%%</td><td>%d</td></tr>",
-			esc(app.AppName), minutes, app.Percentage, app.FocusCount))
+
+	html.WriteString("<h2>Productivity</h2>")
+	html.WriteString(productivityGaugeSVG(score))
+
+	html.WriteString("<h2>Daily Stats</h2>")
+	html.WriteString("<table><thead><tr><th>Metric</th><th>Value</th></tr></thead><tbody>")
+	for _, row := range [][2]string{
+		{"Active Time", fmt.Sprintf("%d min", stats.ActiveMinutes)},
+		{"Sessions", fmt.Sprintf("%d", stats.TotalSessions)},
+		{"Screenshots", fmt.Sprintf("%d", stats.TotalScreenshots)},
+		{"Shell Commands", fmt.Sprintf("%d", stats.ShellCommands)},
+		{"Git Commits", fmt.Sprintf("%d", stats.GitCommits)},
+		{"Files Modified", fmt.Sprintf("%d", stats.FilesModified)},
+		{"Sites Visited", fmt.Sprintf("%d", stats.SitesVisited)},
+		{"Meeting Time", fmt.Sprintf("%d min", stats.MeetingMinutes)},
+	} {
+		html.WriteString(fmt.Sprintf("<tr><td>%s</td><td>%s</td></tr>", row[0], row[1]))
 	}
 	html.WriteString("</tbody></table>")
-	
-	html.WriteString("<h2>Hourly Activity</h2>")
-	html.WriteString("<table><thead><tr><th>Hour</th><th>Screenshots</th><th>Active Minutes</th></tr></thead><tbody>")
-	for _, hour := range hourlyActivity {
-		if hour.ScreenshotCount > 0 || hour.ActiveMinutes > 0 {
-			html.WriteString(fmt.Sprintf("<tr><td>%d:00</td><td>%d</td><td>%d</td></tr>", 
-				hour.Hour, hour.ScreenshotCount, hour.ActiveMinutes))
-		}
+
+	html.WriteString("<h2>Application Usage</h2>")
+	if chart := appUsageChartSVG(appUsage, 10); chart != "" {
+		html.WriteString(chart)
+	} else {
+		html.WriteString("<p class='muted'>No application usage recorded.</p>")
 	}
-	html.WriteString("</tbody></table>")
-	
+
+	html.WriteString("<h2>Hourly Activity</h2>")
+	html.WriteString(hourlyHeatmapSVG(hourlyActivity))
+
 	html.WriteString("</body></html>")
 	return html.String()
 }
@@ -1588,19 +2290,8 @@ func (s *AnalyticsService) exportWeeklyHTML(stats *WeeklyStats) string {
 	html.WriteString("<!DOCTYPE html><html><head>")
 	html.WriteString("<meta charset='UTF-8'>")
 	html.WriteString(fmt.Sprintf("<title>Weekly Analytics - %s to %s</title>", stats.StartDate, stats.EndDate))
-	html.WriteString("<style>")
-	html.WriteString("body { font-family: Arial, sans-serif; max-width: 1200px; margin: 40px auto; padding: 20px; }")
-	html.WriteString("h1 { color: #333; border-bottom: 3px solid #4a9eff; padding-bottom: 10px; }")
-	html.WriteString("h2 { color: #666; margin-top: 30px; }")
-	html.WriteString("table { width: 100%; border-collapse: collapse; margin-top: 15px; }")
-	html.WriteString("th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }")
-	html.WriteString("th { background-color: #4a9eff; color: white; }")
-	html.WriteString("tr:hover { background-color: #f5f5f5; }")
-	html.WriteString(".summary { background: #f8f9fa; padding: 20px; border-radius: 8px; margin: 20px 0; }")
-	html.WriteString(".stat { display: inline-block; margin: 10px 20px 10px 0; }")
-	html.WriteString(".stat-label { color: #666; font-size: 14px; }")
-	html.WriteString(".stat-value { font-size: 24px; font-weight: bold; color: #333; }")
-	html.WriteString("</style></head><body>")
+	html.WriteString(analyticsExportStyle)
+	html.WriteString("</head><body>")
 	
 	html.WriteString(fmt.Sprintf("<h1>Weekly Analytics - %s to %s</h1>", stats.StartDate, stats.EndDate))
 	
@@ -1630,19 +2321,8 @@ func (s *AnalyticsService) exportMonthlyHTML(stats *MonthlyStats) string {
 	html.WriteString("<!DOCTYPE html><html><head>")
 	html.WriteString("<meta charset='UTF-8'>")
 	html.WriteString(fmt.Sprintf("<title>Monthly Analytics - %d-%02d</title>", stats.Year, stats.Month))
-	html.WriteString("<style>")
-	html.WriteString("body { font-family: Arial, sans-serif; max-width: 1200px; margin: 40px auto; padding: 20px; }")
-	html.WriteString("h1 { color: #333; border-bottom: 3px solid #4a9eff; padding-bottom: 10px; }")
-	html.WriteString("h2 { color: #666; margin-top: 30px; }")
-	html.WriteString("table { width: 100%; border-collapse: collapse; margin-top: 15px; }")
-	html.WriteString("th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }")
-	html.WriteString("th { background-color: #4a9eff; color: white; }")
-	html.WriteString("tr:hover { background-color: #f5f5f5; }")
-	html.WriteString(".summary { background: #f8f9fa; padding: 20px; border-radius: 8px; margin: 20px 0; }")
-	html.WriteString(".stat { display: inline-block; margin: 10px 20px 10px 0; }")
-	html.WriteString(".stat-label { color: #666; font-size: 14px; }")
-	html.WriteString(".stat-value { font-size: 24px; font-weight: bold; color: #333; }")
-	html.WriteString("</style></head><body>")
+	html.WriteString(analyticsExportStyle)
+	html.WriteString("</head><body>")
 	
 	html.WriteString(fmt.Sprintf("<h1>Monthly Analytics - %d-%02d</h1>", stats.Year, stats.Month))
 	
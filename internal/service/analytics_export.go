@@ -0,0 +1,191 @@
+package service
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math"
+)
+
+// analyticsExportStyle is the stylesheet shared by the standalone HTML exports. Chart
+// colours are set through classes so the dark-mode rules restyle the SVGs too.
+const analyticsExportStyle = `<style>
+body { font-family: Arial, sans-serif; max-width: 1200px; margin: 40px auto; padding: 20px; color: #333; background: #fff; }
+h1 { color: #333; border-bottom: 3px solid #4a9eff; padding-bottom: 10px; }
+h2 { color: #666; margin-top: 30px; }
+table { width: 100%; border-collapse: collapse; margin-top: 15px; }
+th, td { padding: 12px; text-align: left; border-bottom: 1px solid #ddd; }
+th { background-color: #4a9eff; color: white; }
+tr:hover { background-color: #f5f5f5; }
+.summary { background: #f8f9fa; padding: 20px; border-radius: 8px; margin: 20px 0; }
+.stat { display: inline-block; margin: 10px 20px 10px 0; }
+.stat-label { color: #666; font-size: 14px; }
+.stat-value { font-size: 24px; font-weight: bold; color: #333; }
+.muted { color: #666; }
+svg { display: block; width: 100%; height: auto; margin-top: 15px; }
+svg text { fill: #333; font-family: Arial, sans-serif; font-size: 12px; }
+svg .label { fill: #666; font-size: 10px; }
+.gauge { max-width: 320px; }
+.gauge-track { stroke: #e5e7eb; }
+.bar { fill: #4a9eff; }
+.heat-cell { fill: #4a9eff; }
+.heat-empty { fill: #eef2f7; }
+@media (prefers-color-scheme: dark) {
+body { color: #e5e7eb; background: #111827; }
+h1, .stat-value { color: #f3f4f6; }
+h2, .stat-label, .muted { color: #9ca3af; }
+th, td { border-bottom-color: #374151; }
+th { background-color: #1d4ed8; }
+tr:hover { background-color: #1f2937; }
+.summary { background: #1f2937; }
+svg text { fill: #e5e7eb; }
+svg .label { fill: #9ca3af; }
+.gauge-track { stroke: #374151; }
+.heat-empty { fill: #1f2937; }
+}
+</style>`
+
+// svgNode is an SVG element marshalled with encoding/xml.
+type svgNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Children []*svgNode
+}
+
+// svgElem creates an element with attributes given as name/value pairs.
+func svgElem(name string, attrs ...string) *svgNode {
+	n := &svgNode{XMLName: xml.Name{Local: name}}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		n.Attrs = append(n.Attrs, xml.Attr{Name: xml.Name{Local: attrs[i]}, Value: attrs[i+1]})
+	}
+	return n
+}
+
+// add appends children and returns n.
+func (n *svgNode) add(children ...*svgNode) *svgNode {
+	n.Children = append(n.Children, children...)
+	return n
+}
+
+// text sets the element's text content and returns n.
+func (n *svgNode) text(s string) *svgNode {
+	n.Text = s
+	return n
+}
+
+// String returns the element's markup.
+func (n *svgNode) String() string {
+	out, err := xml.Marshal(n)
+	if err != nil {
+		return ""
+	}
+	return string(out)
+}
+
+// svgNum formats a coordinate with at most one decimal place.
+func svgNum(v float64) string {
+	return fmt.Sprintf("%g", math.Round(v*10)/10)
+}
+
+// productivityGaugeSVG draws the productive percentage as a half-circle gauge with the
+// 1-5 score underneath.
+func productivityGaugeSVG(score *ProductivityScore) string {
+	const cx, cy, r = 100.0, 100.0, 80.0
+	pct := math.Max(0, math.Min(100, score.ProductivePercentage))
+	color := "#ef4444"
+	switch {
+	case score.Score >= 4:
+		color = "#22c55e"
+	case score.Score == 3:
+		color = "#eab308"
+	}
+
+	svg := svgElem("svg", "class", "gauge", "viewBox", "0 0 200 130", "role", "img",
+		"aria-label", fmt.Sprintf("Productivity %.0f%%", pct))
+	svg.add(svgElem("path", "class", "gauge-track", "fill", "none", "stroke-width", "16",
+		"d", fmt.Sprintf("M %s %s A %s %s 0 0 1 %s %s", svgNum(cx-r), svgNum(cy), svgNum(r), svgNum(r), svgNum(cx+r), svgNum(cy))))
+	if pct > 0 {
+		angle := math.Pi * (1 - pct/100)
+		svg.add(svgElem("path", "fill", "none", "stroke", color, "stroke-width", "16",
+			"d", fmt.Sprintf("M %s %s A %s %s 0 0 1 %s %s", svgNum(cx-r), svgNum(cy), svgNum(r), svgNum(r),
+				svgNum(cx+r*math.Cos(angle)), svgNum(cy-r*math.Sin(angle)))))
+	}
+	svg.add(
+		svgElem("text", "x", "100", "y", "92", "text-anchor", "middle", "font-size", "28", "font-weight", "bold").
+			text(fmt.Sprintf("%.0f%%", pct)),
+		svgElem("text", "class", "label", "x", "100", "y", "122", "text-anchor", "middle").
+			text(fmt.Sprintf("Score %d/5 · %d productive min", score.Score, score.ProductiveMinutes)),
+	)
+	return svg.String()
+}
+
+// appUsageChartSVG draws a horizontal bar per app, scaled to the most used app. At most
+// limit apps are drawn; returns "" if there is no usage.
+func appUsageChartSVG(apps []*AppUsage, limit int) string {
+	if len(apps) > limit {
+		apps = apps[:limit]
+	}
+	var maxSeconds float64
+	for _, app := range apps {
+		maxSeconds = math.Max(maxSeconds, app.DurationSeconds)
+	}
+	if maxSeconds == 0 {
+		return ""
+	}
+
+	const rowHeight, labelWidth, barWidth = 28.0, 160.0, 320.0
+	height := rowHeight*float64(len(apps)) + 8
+	svg := svgElem("svg", "viewBox", "0 0 600 "+svgNum(height), "role", "img", "aria-label", "Application usage")
+	for i, app := range apps {
+		y := 4 + rowHeight*float64(i)
+		width := math.Max(1, app.DurationSeconds/maxSeconds*barWidth)
+		svg.add(
+			svgElem("text", "x", svgNum(labelWidth-8), "y", svgNum(y+16), "text-anchor", "end").text(truncate(app.AppName, 24)),
+			svgElem("rect", "class", "bar", "x", svgNum(labelWidth), "y", svgNum(y+2), "width", svgNum(width), "height", "18", "rx", "3"),
+			svgElem("text", "class", "label", "x", svgNum(labelWidth+width+8), "y", svgNum(y+15)).
+				text(fmt.Sprintf("%d min (%.1f%%)", int(app.DurationSeconds/60), app.Percentage)),
+		)
+	}
+	return svg.String()
+}
+
+// hourlyHeatmapSVG draws hourly activity as a table of cells: one row each for active
+// minutes and screenshots, one column per hour. Cell opacity is relative to the row's
+// busiest hour.
+func hourlyHeatmapSVG(hours []*HourlyActivity) string {
+	const labelWidth, cell, gap = 110.0, 24.0, 2.0
+	rows := []struct {
+		label string
+		value func(*HourlyActivity) int64
+	}{
+		{"Active minutes", func(h *HourlyActivity) int64 { return h.ActiveMinutes }},
+		{"Screenshots", func(h *HourlyActivity) int64 { return h.ScreenshotCount }},
+	}
+
+	width := labelWidth + (cell+gap)*24
+	height := (cell+gap)*float64(len(rows)) + 18
+	svg := svgElem("svg", "viewBox", "0 0 "+svgNum(width)+" "+svgNum(height), "role", "img", "aria-label", "Hourly activity")
+	for r, row := range rows {
+		y := (cell + gap) * float64(r)
+		var maxValue int64
+		for _, h := range hours {
+			maxValue = max(maxValue, row.value(h))
+		}
+		svg.add(svgElem("text", "x", "0", "y", svgNum(y+16)).text(row.label))
+		for _, h := range hours {
+			x := labelWidth + (cell+gap)*float64(h.Hour)
+			v := row.value(h)
+			rect := svgElem("rect", "class", "heat-empty", "x", svgNum(x), "y", svgNum(y), "width", svgNum(cell), "height", svgNum(cell), "rx", "3")
+			if v > 0 && maxValue > 0 {
+				rect = svgElem("rect", "class", "heat-cell", "x", svgNum(x), "y", svgNum(y), "width", svgNum(cell), "height", svgNum(cell), "rx", "3",
+					"fill-opacity", svgNum(0.2+0.8*float64(v)/float64(maxValue)))
+			}
+			svg.add(rect.add(svgElem("title").text(fmt.Sprintf("%02d:00 - %d %s", h.Hour, v, row.label))))
+		}
+	}
+	for hour := 0; hour < 24; hour += 3 {
+		x := labelWidth + (cell+gap)*float64(hour) + cell/2
+		svg.add(svgElem("text", "class", "label", "x", svgNum(x), "y", svgNum(height-4), "text-anchor", "middle").text(fmt.Sprintf("%02d", hour)))
+	}
+	return svg.String()
+}
@@ -1,8 +1,14 @@
 package service
 
 import (
+	"fmt"
 	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
+
+	"traq/internal/storage"
 )
 
 // TestSortAppUsage_PercentageCalculation tests that percentages are calculated correctly.
@@ -398,3 +404,1191 @@ func TestCalculateComparison(t *testing.T) {
 		})
 	}
 }
+
+func TestGetTimezoneCorrectedStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewAnalyticsService(store)
+
+	// 23:30 UTC on March 12 is March 13 in Tokyo (UTC+9)
+	if _, err := store.SaveShellCommand(&storage.ShellCommand{
+		Timestamp: time.Date(2024, 3, 12, 23, 30, 0, 0, time.UTC).Unix(),
+		Command:   "make",
+		ShellType: "bash",
+	}); err != nil {
+		t.Fatalf("SaveShellCommand failed: %v", err)
+	}
+
+	tests := []struct {
+		date string
+		tz   string
+		want int64
+	}{
+		{"2024-03-12", "UTC", 1},
+		{"2024-03-13", "UTC", 0},
+		{"2024-03-12", "Asia/Tokyo", 0},
+		{"2024-03-13", "Asia/Tokyo", 1},
+	}
+	for _, tt := range tests {
+		stats, err := svc.GetTimezoneCorrectedStats(tt.date, tt.tz)
+		if err != nil {
+			t.Fatalf("GetTimezoneCorrectedStats(%s, %s) failed: %v", tt.date, tt.tz, err)
+		}
+		if stats.ShellCommands != tt.want {
+			t.Errorf("%s in %s: shell commands = %d, want %d", tt.date, tt.tz, stats.ShellCommands, tt.want)
+		}
+	}
+
+	if _, err := svc.GetTimezoneCorrectedStats("2024-03-13", "Mars/Olympus"); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
+
+func TestDailyStatsComparisons(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewAnalyticsService(store)
+
+	// Shell commands per day, saved at noon
+	commands := map[string]int{
+		"2024-03-13": 6, // Wednesday
+		"2024-03-12": 3, // Previous day
+		"2024-03-06": 2, // Same weekday last week
+		"2024-02-13": 4, // Same day last month
+		"2024-02-29": 1, // Last day of February, compared with March 31
+	}
+	for date, count := range commands {
+		day, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+		for i := 0; i < count; i++ {
+			if _, err := store.SaveShellCommand(&storage.ShellCommand{
+				Timestamp: day.Add(12*time.Hour + time.Duration(i)*time.Minute).Unix(),
+				Command:   "make",
+				ShellType: "bash",
+			}); err != nil {
+				t.Fatalf("SaveShellCommand failed: %v", err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name         string
+		get          func(string) (*DailyStats, error)
+		date         string
+		wantPrevDate string
+		wantDelta    StatDelta
+	}{
+		{"previous day", func(d string) (*DailyStats, error) { return svc.GetDailyStatsWithComparison(d, true) }, "2024-03-13", "2024-03-12", StatDelta{Value: 3, Percent: 100}},
+		{"last week", svc.CompareWithSameDayLastWeek, "2024-03-13", "2024-03-06", StatDelta{Value: 4, Percent: 200}},
+		{"last month", svc.CompareWithSameDayLastMonth, "2024-03-13", "2024-02-13", StatDelta{Value: 2, Percent: 50}},
+		{"last month clamped", svc.CompareWithSameDayLastMonth, "2024-03-31", "2024-02-29", StatDelta{Value: -1, Percent: -100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats, err := tt.get(tt.date)
+			if err != nil {
+				t.Fatalf("failed: %v", err)
+			}
+			if stats.PreviousDay == nil || stats.Delta == nil {
+				t.Fatalf("expected comparison data, got %+v", stats)
+			}
+			if stats.PreviousDay.Date != tt.wantPrevDate {
+				t.Errorf("compared with %s, want %s", stats.PreviousDay.Date, tt.wantPrevDate)
+			}
+			if stats.Delta.ShellCommands != tt.wantDelta {
+				t.Errorf("shell commands delta = %+v, want %+v", stats.Delta.ShellCommands, tt.wantDelta)
+			}
+			if stats.Delta.GitCommits != (StatDelta{}) {
+				t.Errorf("expected no git commit change, got %+v", stats.Delta.GitCommits)
+			}
+		})
+	}
+
+	stats, err := svc.GetDailyStatsWithComparison("2024-03-13", false)
+	if err != nil {
+		t.Fatalf("GetDailyStatsWithComparison failed: %v", err)
+	}
+	if stats.PreviousDay != nil || stats.Delta != nil || stats.Comparison != nil {
+		t.Error("expected no comparison when not requested")
+	}
+	if _, err := svc.CompareWithSameDayLastWeek("bad-date"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestGetLanguageStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	events := []struct {
+		language, workspace string
+	}{
+		{"go", "/home/user/traq"},
+		{"go", "/home/user/api"},
+		{"go", "/home/user/traq"},
+		{"typescript", "/home/user/traq"},
+		{"markdown", ""},
+	}
+	for i, e := range events {
+		if _, err := store.SaveVSCodeLanguageEvent(&storage.VSCodeLanguageEvent{
+			Timestamp: now + int64(i),
+			Language:  e.language,
+			Workspace: storage.NullString(e.workspace),
+		}); err != nil {
+			t.Fatalf("failed to save language event: %v", err)
+		}
+	}
+
+	stats, err := svc.GetLanguageStats(now, now+10)
+	if err != nil {
+		t.Fatalf("GetLanguageStats failed: %v", err)
+	}
+	if len(stats) != 3 {
+		t.Fatalf("expected 3 languages, got %d", len(stats))
+	}
+
+	goStats := stats[0]
+	if goStats.Language != "go" || goStats.EventCount != 3 || goStats.Percentage != 60 {
+		t.Errorf("expected go first with 3 events (60%%), got %+v", goStats)
+	}
+	if len(goStats.Workspaces) != 2 {
+		t.Errorf("expected go in 2 workspaces, got %v", goStats.Workspaces)
+	}
+	// Ties are ordered by name; events without a workspace list none
+	if stats[1].Language != "markdown" || len(stats[1].Workspaces) != 0 || stats[2].Language != "typescript" {
+		t.Errorf("unexpected order: %s, %s", stats[1].Language, stats[2].Language)
+	}
+	if got := formatWorkspaceNames(goStats.Workspaces); got != " (traq, api)" {
+		t.Errorf("formatWorkspaceNames = %q", got)
+	}
+}
+
+func TestGetBrowserUsageByProfile(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	visits := []*storage.BrowserVisit{
+		{Timestamp: now, URL: "https://github.com/a", Domain: "github.com", Browser: "chrome", BrowserProfile: "Work"},
+		{Timestamp: now + 1, URL: "https://github.com/b", Domain: "github.com", Browser: "chrome", BrowserProfile: "Work"},
+		{Timestamp: now + 2, URL: "https://jira.example.com", Domain: "jira.example.com", Browser: "chrome", BrowserProfile: "Work"},
+		{Timestamp: now + 3, URL: "https://youtube.com", Domain: "youtube.com", Browser: "firefox"},
+	}
+	for _, v := range visits {
+		if _, err := store.SaveBrowserVisit(v); err != nil {
+			t.Fatalf("failed to save visit: %v", err)
+		}
+	}
+
+	profiles, err := svc.GetBrowserUsageByProfile(now, now+10)
+	if err != nil {
+		t.Fatalf("GetBrowserUsageByProfile failed: %v", err)
+	}
+	if len(profiles) != 2 {
+		t.Fatalf("expected 2 profiles, got %d", len(profiles))
+	}
+
+	work := profiles["Work"]
+	if work == nil {
+		t.Fatal("expected stats for Work profile")
+	}
+	if work.TotalVisits != 3 || work.UniqueDomains != 2 {
+		t.Errorf("Work: expected 3 visits across 2 domains, got %d across %d", work.TotalVisits, work.UniqueDomains)
+	}
+	if len(work.TopDomains) == 0 || work.TopDomains[0].Domain != "github.com" || work.TopDomains[0].VisitCount != 2 {
+		t.Errorf("Work: expected github.com as top domain with 2 visits, got %+v", work.TopDomains)
+	}
+
+	// Visits saved without a profile belong to the default profile
+	personal := profiles["default"]
+	if personal == nil {
+		t.Fatal("expected stats for default profile")
+	}
+	if personal.TotalVisits != 1 || personal.BrowserCounts["firefox"] != 1 {
+		t.Errorf("default: expected 1 firefox visit, got %d visits, counts %v", personal.TotalVisits, personal.BrowserCounts)
+	}
+}
+
+func TestGetTopWindows(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	events := []struct {
+		app, title string
+		offset     int64
+		duration   int64
+	}{
+		{"code", "main.go - traq", 0, 600},
+		{"code", "app.go - traq", 600, 300},
+		{"code", "main.go - traq", 900, 300},
+		{"firefox", "GitHub", 1200, 200},
+		{"firefox", "main.go - traq", 1400, 100}, // Same title in a different app
+	}
+	for _, e := range events {
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         e.app,
+			WindowTitle:     e.title,
+			StartTime:       now + e.offset,
+			EndTime:         now + e.offset + e.duration,
+			DurationSeconds: float64(e.duration),
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	windows, err := svc.GetTopWindows(now, now+3600, 0)
+	if err != nil {
+		t.Fatalf("GetTopWindows failed: %v", err)
+	}
+	if len(windows) != 4 {
+		t.Fatalf("expected 4 windows, got %d", len(windows))
+	}
+
+	top := windows[0]
+	if top.WindowTitle != "main.go - traq" || top.DurationSeconds != 900 || top.FocusCount != 2 {
+		t.Errorf("expected main.go with 900s across 2 focuses first, got %+v", top)
+	}
+	for i := 1; i < len(windows); i++ {
+		if windows[i].DurationSeconds > windows[i-1].DurationSeconds {
+			t.Errorf("windows not sorted by duration at index %d", i)
+		}
+	}
+
+	var totalPct float64
+	for _, w := range windows {
+		totalPct += w.Percentage
+	}
+	if math.Abs(totalPct-100) > 0.01 {
+		t.Errorf("expected percentages to sum to 100, got %.4f", totalPct)
+	}
+
+	limited, err := svc.GetTopWindows(now, now+3600, 2)
+	if err != nil {
+		t.Fatalf("GetTopWindows with limit failed: %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("expected 2 windows with limit, got %d", len(limited))
+	}
+}
+
+func TestGetWindowTitleTrend(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	for _, e := range []struct {
+		title   string
+		daysAgo int
+		minutes int
+	}{
+		{"main.go - traq", 0, 30},
+		{"Main.go - traq", 2, 45},
+		{"README.md - traq", 0, 20},
+		{"main.go - traq", 10, 60}, // Outside the trend
+	} {
+		start := today.AddDate(0, 0, -e.daysAgo).Add(9 * time.Hour)
+		store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         "code",
+			WindowTitle:     e.title,
+			StartTime:       start.Unix(),
+			EndTime:         start.Add(time.Duration(e.minutes) * time.Minute).Unix(),
+			DurationSeconds: float64(e.minutes * 60),
+		})
+	}
+
+	trend, err := svc.GetWindowTitleTrend("main.go", 3)
+	if err != nil {
+		t.Fatalf("GetWindowTitleTrend failed: %v", err)
+	}
+	if len(trend) != 3 || trend[2].Date != today.Format("2006-01-02") {
+		t.Fatalf("expected 3 days ending today, got %+v", trend)
+	}
+	if trend[0].Minutes != 45 || trend[1].Minutes != 0 || trend[2].Minutes != 30 {
+		t.Errorf("unexpected minutes: %.0f, %.0f, %.0f", trend[0].Minutes, trend[1].Minutes, trend[2].Minutes)
+	}
+}
+
+func TestGetTopWindowsForApp(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	titles := []string{"main.go", "app.go", "main.go", "README.md"}
+	for i, title := range titles {
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         "code",
+			WindowTitle:     title,
+			StartTime:       now + int64(i)*100,
+			EndTime:         now + int64(i)*100 + 100,
+			DurationSeconds: 100,
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+	if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+		AppName: "firefox", WindowTitle: "GitHub", StartTime: now + 500, EndTime: now + 1500, DurationSeconds: 1000,
+	}); err != nil {
+		t.Fatalf("failed to save focus event: %v", err)
+	}
+
+	windows, err := svc.GetTopWindowsForApp("code", now, now+3600, 0)
+	if err != nil {
+		t.Fatalf("GetTopWindowsForApp failed: %v", err)
+	}
+	if len(windows) != 3 {
+		t.Fatalf("expected 3 code windows, got %d", len(windows))
+	}
+	if windows[0].WindowTitle != "main.go" || windows[0].FocusCount != 2 {
+		t.Errorf("expected main.go with 2 focuses first, got %+v", windows[0])
+	}
+
+	// Percentages are relative to the app's own time
+	var totalPct float64
+	for _, w := range windows {
+		if w.AppName != GetFriendlyAppName("code") {
+			t.Errorf("unexpected app %q in drill-down", w.AppName)
+		}
+		totalPct += w.Percentage
+	}
+	if math.Abs(totalPct-100) > 0.01 {
+		t.Errorf("expected percentages to sum to 100, got %.4f", totalPct)
+	}
+
+	// Friendly names work too
+	friendly, err := svc.GetTopWindowsForApp(GetFriendlyAppName("code"), now, now+3600, 1)
+	if err != nil {
+		t.Fatalf("GetTopWindowsForApp with friendly name failed: %v", err)
+	}
+	if len(friendly) != 1 || friendly[0].WindowTitle != "main.go" {
+		t.Errorf("expected main.go for friendly app name, got %+v", friendly)
+	}
+}
+
+func TestGetMeetingStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	save := func(app, title string, start, duration int64) {
+		t.Helper()
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle: title, AppName: app, StartTime: start, EndTime: start + duration, DurationSeconds: float64(duration),
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	// Mon: a 30m Zoom call and 90m of coding. Tue: a 60m Zoom call with the same title and
+	// a 30m Slack huddle. Wed and Thu: no meetings.
+	mon := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.Local)
+	tue := mon.AddDate(0, 0, 1)
+	wed := mon.AddDate(0, 0, 2)
+	thu := mon.AddDate(0, 0, 3)
+	save("zoom.us", "Zoom Meeting", mon.Unix(), 1800)
+	save("code", "main.go - traq - Visual Studio Code", mon.Unix()+1800, 5400)
+	save("zoom.us", "Zoom Meeting", tue.Unix(), 3600)
+	save("slack", "Huddle: #eng - Acme - Slack", tue.Unix()+3600, 1800)
+	save("code", "main.go - traq - Visual Studio Code", wed.Unix(), 3600)
+	save("code", "main.go - traq - Visual Studio Code", thu.Unix(), 1800)
+
+	start := mon.Add(-10 * time.Hour).Unix()
+	end := thu.Add(12*time.Hour).Unix() - 1
+	stats, err := svc.GetMeetingStats(start, end)
+	if err != nil {
+		t.Fatalf("GetMeetingStats failed: %v", err)
+	}
+
+	if stats.TotalMeetings != 3 {
+		t.Errorf("expected 3 meetings (recurring call counted per day), got %d", stats.TotalMeetings)
+	}
+	if stats.TotalMeetingMinutes != 120 {
+		t.Errorf("expected 120 meeting minutes, got %d", stats.TotalMeetingMinutes)
+	}
+	if math.Abs(stats.MeetingPercentage-40) > 0.01 {
+		t.Errorf("expected 40%% meeting time, got %.2f", stats.MeetingPercentage)
+	}
+	if stats.MeetingsByPlatform["Zoom"] != 90 || stats.MeetingsByPlatform["Slack"] != 30 {
+		t.Errorf("unexpected platform breakdown: %v", stats.MeetingsByPlatform)
+	}
+	if stats.LongestMeeting.Platform != "Zoom" || stats.LongestMeeting.DurationSeconds != 3600 {
+		t.Errorf("unexpected longest meeting: %+v", stats.LongestMeeting)
+	}
+	if stats.AverageDurationMinutes != 40 {
+		t.Errorf("expected 40 minute average, got %.2f", stats.AverageDurationMinutes)
+	}
+	if stats.MeetingFreeDayStreak != 2 {
+		t.Errorf("expected 2 meeting-free days, got %d", stats.MeetingFreeDayStreak)
+	}
+
+	// Today is still in progress, so a range ending today only counts up to yesterday
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	current, err := svc.GetMeetingStats(today.AddDate(0, 0, -1).Unix(), today.AddDate(0, 0, 1).Unix()-1)
+	if err != nil {
+		t.Fatalf("GetMeetingStats failed: %v", err)
+	}
+	if current.MeetingFreeDayStreak != 1 {
+		t.Errorf("expected only yesterday to count as meeting-free, got %d", current.MeetingFreeDayStreak)
+	}
+
+	daily, err := svc.GetDailyStats(tue.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetDailyStats failed: %v", err)
+	}
+	if daily.MeetingMinutes != 90 {
+		t.Errorf("expected 90 meeting minutes on Tuesday, got %d", daily.MeetingMinutes)
+	}
+}
+
+func TestGetNetworkStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	events := []struct {
+		hostname, process string
+		sent, received    int64
+	}{
+		{"github.com", "firefox", 100, 2000},
+		{"github.com", "git", 50, 500},
+		{"github.com", "firefox", 10, 100},
+		{"example.com", "", 0, 0},
+	}
+	for i, e := range events {
+		if _, err := store.SaveNetworkEvent(&storage.NetworkEvent{
+			Timestamp:     now + int64(i),
+			Hostname:      e.hostname,
+			ProcessName:   storage.NullString(e.process),
+			BytesSent:     e.sent,
+			BytesReceived: e.received,
+		}); err != nil {
+			t.Fatalf("failed to save network event: %v", err)
+		}
+	}
+
+	stats, err := svc.GetNetworkStats(now, now+10)
+	if err != nil {
+		t.Fatalf("GetNetworkStats failed: %v", err)
+	}
+	if stats.TotalConnections != 4 || stats.TotalBytesSent != 160 || stats.TotalBytesReceived != 2600 {
+		t.Errorf("unexpected totals: %+v", stats)
+	}
+	if len(stats.TopHosts) != 2 {
+		t.Fatalf("expected 2 hosts, got %d", len(stats.TopHosts))
+	}
+	github := stats.TopHosts[0]
+	if github.Hostname != "github.com" || github.ConnectionCount != 3 || github.BytesReceived != 2600 {
+		t.Errorf("expected github.com first with 3 connections, got %+v", github)
+	}
+	if len(github.Processes) != 2 {
+		t.Errorf("expected 2 processes for github.com, got %v", github.Processes)
+	}
+	if len(stats.TopHosts[1].Processes) != 0 {
+		t.Errorf("expected no processes for unknown owner, got %v", stats.TopHosts[1].Processes)
+	}
+}
+
+func TestGetAudioStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	now := int64(1700000000)
+
+	events := []struct {
+		offset, duration int64
+		app              string
+	}{
+		{0, 30, "spotify"},
+		{30, 30, "spotify"},
+		{30, 30, "firefox"}, // Same poll as the second spotify event
+		{90, 60, "spotify"}, // Polled a minute after the previous one
+		{120, 30, ""},
+	}
+	for _, e := range events {
+		if _, err := store.SaveAudioEvent(&storage.AudioEvent{
+			Timestamp:       now + e.offset,
+			AppName:         e.app,
+			DeviceName:      "HDA Intel PCH",
+			DurationSeconds: e.duration,
+		}); err != nil {
+			t.Fatalf("failed to save audio event: %v", err)
+		}
+	}
+
+	stats, err := svc.GetAudioStats(now, now+130)
+	if err != nil {
+		t.Fatalf("GetAudioStats failed: %v", err)
+	}
+	// 4 distinct polls covering 30 + 30 + 60 + 30 seconds
+	if stats.TotalListeningMinutes != 2 {
+		t.Errorf("expected 2 listening minutes, got %d", stats.TotalListeningMinutes)
+	}
+	if stats.TopApps["spotify"] != 2 {
+		t.Errorf("expected 2 minutes for spotify, got %d", stats.TopApps["spotify"])
+	}
+	if _, ok := stats.TopApps["Unknown"]; !ok {
+		t.Errorf("expected events without an app to be reported as Unknown, got %v", stats.TopApps)
+	}
+}
+
+func TestGetFocusSessionLengthDistribution(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	store.SetAppTimelineCategory("code", "focus")
+	store.SetAppTimelineCategory("terminal", "focus")
+	store.SetAppTimelineCategory("slack", "comms")
+
+	save := func(app string, start, duration int64) {
+		t.Helper()
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle: app, AppName: app, StartTime: start, EndTime: start + duration, DurationSeconds: float64(duration),
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	base := int64(1700000000)
+	save("code", base, 120) // 2m session
+	// 10 minute gap, then 20m code + 20m terminal after a 5 minute gap (45m session)
+	save("code", base+720, 1200)
+	save("terminal", base+720+1200+300, 1200)
+	// Slack ends the session; 10m of code before and 100m after
+	save("code", base+4000, 600)
+	save("slack", base+4600, 300)
+	save("code", base+4900, 6000)
+	// A gap over 5 minutes starts a new 150m session
+	save("code", base+10900+301, 9000)
+
+	dist, err := svc.GetFocusSessionLengthDistribution(base, base+30000)
+	if err != nil {
+		t.Fatalf("GetFocusSessionLengthDistribution failed: %v", err)
+	}
+	if len(dist.Buckets) != 7 {
+		t.Fatalf("expected 7 buckets, got %d", len(dist.Buckets))
+	}
+
+	expected := []struct {
+		label   string
+		count   int
+		minutes int64
+	}{
+		{"0-5m", 1, 2},
+		{"5-15m", 1, 10},
+		{"15-30m", 0, 0},
+		{"30-60m", 1, 45},
+		{"60-90m", 0, 0},
+		{"90-120m", 1, 100},
+		{"120m+", 1, 150},
+	}
+	for i, want := range expected {
+		got := dist.Buckets[i]
+		if got.Label != want.label || got.Count != want.count || got.TotalMinutes != want.minutes {
+			t.Errorf("bucket %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+	if dist.Buckets[0].Percentage != 20 {
+		t.Errorf("expected 20%% of sessions under 5m, got %v", dist.Buckets[0].Percentage)
+	}
+
+	// Sessions are clamped to the range
+	dist, _ = svc.GetFocusSessionLengthDistribution(base, base+60)
+	if dist.Buckets[0].Count != 1 || dist.Buckets[0].TotalMinutes != 1 {
+		t.Errorf("expected one clamped 1m session, got %+v", dist.Buckets[0])
+	}
+}
+
+func TestGetAFKBreakdown(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	base := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.Local).Unix()
+	save := func(trigger string, start, minutes int64) {
+		t.Helper()
+		id, err := store.CreateAFKEvent(&storage.AFKEvent{StartTime: start, TriggerType: trigger})
+		if err != nil {
+			t.Fatalf("failed to save AFK event: %v", err)
+		}
+		store.UpdateAFKEventEnd(id, start+minutes*60)
+	}
+	save("idle_timeout", base, 10)
+	save("idle_timeout", base+3600, 5)
+	save("screen_lock", base+7200, 30)
+	save("sleep", base-1800, 60)   // Starts before the range, so only 30 minutes count
+	save("manual", base+86400, 20) // After the range
+
+	breakdown, err := svc.GetAFKBreakdown(base, base+4*3600)
+	if err != nil {
+		t.Fatalf("GetAFKBreakdown failed: %v", err)
+	}
+	want := map[string]int64{"idle_timeout": 15, "screen_lock": 30, "sleep": 30}
+	if len(breakdown) != len(want) {
+		t.Errorf("expected %v, got %v", want, breakdown)
+	}
+	for trigger, minutes := range want {
+		if breakdown[trigger] != minutes {
+			t.Errorf("%s: expected %d minutes, got %d", trigger, minutes, breakdown[trigger])
+		}
+	}
+}
+
+func TestPeakProductivityWindows(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	day1 := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 1)
+	save := func(day time.Time, app string, hour, minute, minutes int) {
+		t.Helper()
+		start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, time.Local).Unix()
+		end := start + int64(minutes*60)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName: app, WindowTitle: app, StartTime: start, EndTime: end, DurationSeconds: float64(end - start),
+		}); err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+	save(day1, "code", 9, 0, 90)
+	save(day1, "Slack", 11, 0, 60) // Distracting, never part of a peak
+	save(day1, "code", 14, 0, 30)
+	save(day2, "code", 9, 0, 90)
+	save(day2, "code", 14, 0, 60)
+
+	peaks, err := svc.peakProductivityWindows(day2.AddDate(0, 0, 1).Unix(), 1)
+	if err != nil {
+		t.Fatalf("peakProductivityWindows failed: %v", err)
+	}
+	if peaks.DataWeeks != 1 {
+		t.Errorf("expected 1 data week, got %d", peaks.DataWeeks)
+	}
+	if len(peaks.PeakWindows) != 2 {
+		t.Fatalf("expected 2 peak windows, got %+v", peaks.PeakWindows)
+	}
+
+	first, second := peaks.PeakWindows[0], peaks.PeakWindows[1]
+	if first.Rank != 1 || first.StartHour != 9 || first.StartMinute != 0 || first.EndHour != 10 || first.EndMinute != 30 {
+		t.Errorf("unexpected first window: %+v", first)
+	}
+	if first.AverageProductiveMinutes != 90 {
+		t.Errorf("expected 90 productive minutes per day, got %.1f", first.AverageProductiveMinutes)
+	}
+	if second.Rank != 2 || second.StartHour != 13 || second.StartMinute != 30 || second.AverageProductiveMinutes != 45 {
+		t.Errorf("unexpected second window: %+v", second)
+	}
+
+	empty, err := svc.peakProductivityWindows(day1.AddDate(0, 0, -30).Unix(), 1)
+	if err != nil {
+		t.Fatalf("peakProductivityWindows failed: %v", err)
+	}
+	if len(empty.PeakWindows) != 0 {
+		t.Errorf("expected no peak windows without data, got %+v", empty.PeakWindows)
+	}
+}
+
+func TestGetProductivityScore_ContextSwitchPenalty(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	save := func(app string, hour, minute, minutes int) {
+		t.Helper()
+		start := day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Unix()
+		end := start + int64(minutes*60)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName: app, WindowTitle: app, StartTime: start, EndTime: end, DurationSeconds: float64(end - start),
+		}); err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+	// Two hours of coding, interrupted by Reddit for 2 minutes every 30 minutes
+	for i := 0; i < 4; i++ {
+		save("code", 9, i*30, 28)
+		save("Reddit", 9, i*30+28, 2)
+	}
+	save("YouTube", 11, 0, 2) // Straight after Reddit, not a new interruption
+
+	score, err := svc.GetProductivityScore("2026-03-10")
+	if err != nil {
+		t.Fatalf("GetProductivityScore failed: %v", err)
+	}
+	if score.ContextSwitchCount != 4 {
+		t.Errorf("expected 4 context switches, got %d", score.ContextSwitchCount)
+	}
+	if score.ContextSwitchPenaltyMinutes != 8 {
+		t.Errorf("expected 8 penalty minutes at the default 120s per switch, got %d", score.ContextSwitchPenaltyMinutes)
+	}
+	if score.ProductiveMinutes != 112 || score.TotalMinutes != 122 {
+		t.Errorf("expected 112 of 122 minutes productive, got %d of %d", score.ProductiveMinutes, score.TotalMinutes)
+	}
+	if want := float64(112-8) / 122 * 100; math.Abs(score.ProductivePercentage-want) > 0.01 {
+		t.Errorf("expected %.2f%% productive after the penalty, got %.2f%%", want, score.ProductivePercentage)
+	}
+	if score.Score != 5 {
+		t.Errorf("expected score 5, got %d", score.Score)
+	}
+
+	// A larger configured penalty lowers the score
+	store.SetConfig("productivity.contextSwitchPenaltySeconds", "900")
+	score, err = svc.GetProductivityScore("2026-03-10")
+	if err != nil {
+		t.Fatalf("GetProductivityScore failed: %v", err)
+	}
+	if score.ContextSwitchPenaltyMinutes != 60 {
+		t.Errorf("expected 60 penalty minutes at 900s per switch, got %d", score.ContextSwitchPenaltyMinutes)
+	}
+	if score.Score != 3 {
+		t.Errorf("expected score 3 with the larger penalty, got %d (%.1f%%)", score.Score, score.ProductivePercentage)
+	}
+
+	// Disabling the penalty leaves productive time as is
+	store.SetConfig("productivity.contextSwitchPenaltySeconds", "0")
+	score, _ = svc.GetProductivityScore("2026-03-10")
+	if score.ContextSwitchPenaltyMinutes != 0 || score.ContextSwitchCount != 4 {
+		t.Errorf("expected switches counted without a penalty, got %+v", score)
+	}
+}
+
+func TestGetDomainVisitTimeline(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	start := time.Date(2024, 3, 5, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) int64 {
+		return start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Unix()
+	}
+
+	visits := []*storage.BrowserVisit{
+		{Timestamp: at(9, 30), URL: "https://github.com/a", Title: storage.NullString("Repo A"), Domain: "github.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(600)},
+		{Timestamp: at(9, 50), URL: "https://github.com/b", Domain: "github.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(1200)}, // Runs 10 minutes into 10:00
+		{Timestamp: at(10, 15), URL: "https://example.com", Domain: "example.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(300)},
+		{Timestamp: at(24+9, 0), URL: "https://github.com/c", Domain: "github.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(300)}, // Next day
+	}
+	for _, v := range visits {
+		if _, err := store.SaveBrowserVisit(v); err != nil {
+			t.Fatalf("failed to save visit: %v", err)
+		}
+	}
+
+	blocks, err := svc.GetDomainVisitTimeline("github.com", start.Unix(), at(48, 0))
+	if err != nil {
+		t.Fatalf("GetDomainVisitTimeline failed: %v", err)
+	}
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 github.com visits, got %d", len(blocks))
+	}
+	if b := blocks[0]; b.Title != "Repo A" || b.DurationSeconds != 600 || b.HourOffset != 9 || b.MinuteOffset != 30 || b.PixelPosition != 30 {
+		t.Errorf("unexpected first block: %+v", b)
+	}
+
+	hourly, err := svc.GetDomainActivityByHour("github.com", start.Unix(), at(48, 0))
+	if err != nil {
+		t.Fatalf("GetDomainActivityByHour failed: %v", err)
+	}
+	if len(hourly) != 24 {
+		t.Fatalf("expected 24 hours, got %d", len(hourly))
+	}
+	// 10 + 10 minutes on the first day and 5 on the second
+	if hourly[9].ActiveMinutes != 25 || hourly[10].ActiveMinutes != 10 {
+		t.Errorf("expected 25 minutes at 9:00 and 10 at 10:00, got %d and %d", hourly[9].ActiveMinutes, hourly[10].ActiveMinutes)
+	}
+}
+
+func TestGetSocialMediaUsage(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	start := time.Date(2024, 3, 6, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) int64 {
+		return start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Unix()
+	}
+
+	visits := []*storage.BrowserVisit{
+		{Timestamp: at(20, 0), URL: "https://youtube.com/watch", Domain: "youtube.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(1800)},
+		{Timestamp: at(20, 30), URL: "https://www.reddit.com/r/golang", Domain: "www.reddit.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(600)},
+		{Timestamp: at(12, 0), URL: "https://amazon.com/cart", Domain: "amazon.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(900)},
+		{Timestamp: at(10, 0), URL: "https://github.com/a", Domain: "github.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(600)},         // Not social media
+		{Timestamp: at(-24, 0), URL: "https://youtube.com/watch", Domain: "youtube.com", Browser: "chrome", VisitDurationSeconds: storage.NullInt64(3000)}, // Previous period
+	}
+	for _, v := range visits {
+		if _, err := store.SaveBrowserVisit(v); err != nil {
+			t.Fatalf("failed to save visit: %v", err)
+		}
+	}
+	store.SaveFocusEvent(&storage.WindowFocusEvent{AppName: "discord", WindowTitle: "general", StartTime: at(21, 0), EndTime: at(21, 20), DurationSeconds: 1200})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{AppName: "slack", WindowTitle: "team", StartTime: at(9, 0), EndTime: at(9, 10), DurationSeconds: 600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{AppName: "code", WindowTitle: "main.go", StartTime: at(10, 0), EndTime: at(11, 0), DurationSeconds: 3600})
+
+	stats, err := svc.GetSocialMediaUsage(start.Unix(), at(48, 0))
+	if err != nil {
+		t.Fatalf("GetSocialMediaUsage failed: %v", err)
+	}
+	if stats.TotalMinutes != 85 {
+		t.Errorf("expected 85 minutes, got %d", stats.TotalMinutes)
+	}
+	if stats.DomainBreakdown["youtube.com"] != 30 || stats.DomainBreakdown["reddit.com"] != 10 || stats.DomainBreakdown["amazon.com"] != 15 {
+		t.Errorf("unexpected domain breakdown: %v", stats.DomainBreakdown)
+	}
+	if _, ok := stats.DomainBreakdown["github.com"]; ok {
+		t.Error("expected github.com to be left out")
+	}
+	if len(stats.DomainBreakdown) != 5 {
+		t.Errorf("expected 3 domains and 2 apps, got %v", stats.DomainBreakdown)
+	}
+	if stats.PeakHour != 20 {
+		t.Errorf("expected peak hour 20, got %d", stats.PeakHour)
+	}
+	if stats.AverageDailyMinutes != 42.5 {
+		t.Errorf("expected 42.5 minutes a day over 2 days, got %v", stats.AverageDailyMinutes)
+	}
+	if stats.TrendPercent != 70 {
+		t.Errorf("expected 70%% more than the previous 2 days, got %v", stats.TrendPercent)
+	}
+}
+
+func TestExportAnalyticsHTML(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewAnalyticsService(store)
+
+	day := time.Date(2026, 3, 10, 0, 0, 0, 0, time.Local)
+	for i, app := range []string{"code", "firefox"} {
+		start := day.Add(time.Duration(9+i) * time.Hour)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         app,
+			WindowTitle:     "<untitled>",
+			StartTime:       start.Unix(),
+			EndTime:         start.Add(45 * time.Minute).Unix(),
+			DurationSeconds: 45 * 60,
+		}); err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+		store.SaveScreenshot(&storage.Screenshot{Timestamp: start.Unix(), Filepath: app + ".webp", DHash: "d:1"})
+	}
+
+	html, err := svc.ExportAnalytics("2026-03-10", "day", "html")
+	if err != nil {
+		t.Fatalf("ExportAnalytics failed: %v", err)
+	}
+
+	// Gauge, app usage chart and hourly heatmap
+	if n := strings.Count(html, `<svg `); n != 3 {
+		t.Errorf("found %d SVGs, want 3", n)
+	}
+	if n := strings.Count(html, `viewBox="0 0 `); n != 3 {
+		t.Errorf("found %d viewBox attributes, want 3", n)
+	}
+	if n := strings.Count(html, "<tr><td>"); n != 8 {
+		t.Errorf("found %d daily stats rows, want 8", n)
+	}
+	if !strings.Contains(html, "@media (prefers-color-scheme: dark)") {
+		t.Error("missing dark mode styles")
+	}
+	if !strings.Contains(html, ">Firefox</text>") || !strings.Contains(html, ">45 min (50.0%)</text>") {
+		t.Error("app usage chart missing Firefox bar")
+	}
+	for _, external := range []string{"<script", "<link", "src=", "href="} {
+		if strings.Contains(html, external) {
+			t.Errorf("export should be self-contained, found %q", external)
+		}
+	}
+}
+
+func TestGetAbstractivityScore(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	focus := func(app, title string, hour, minutes int) {
+		t.Helper()
+		start := day.Add(time.Duration(hour) * time.Hour).Unix()
+		end := start + int64(minutes*60)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName: app, WindowTitle: title, StartTime: start, EndTime: end, DurationSeconds: float64(end - start),
+		}); err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	// No activity at all
+	score, err := svc.GetAbstractivityScore("2026-03-10")
+	if err != nil {
+		t.Fatalf("GetAbstractivityScore failed: %v", err)
+	}
+	if score.Score != 0 || len(score.Indicators) != 0 {
+		t.Errorf("expected an empty day to score 0, got %+v", score)
+	}
+
+	// 2h coding, 40m reading docs in a browser, 20m in ChatGPT
+	focus("code", "main.go - traq", 9, 120)
+	focus("Google Chrome", "Go documentation - Google Chrome", 11, 40)
+	focus("Google Chrome", "ChatGPT - Google Chrome", 12, 20)
+
+	score, err = svc.GetAbstractivityScore("2026-03-10")
+	if err != nil {
+		t.Fatalf("GetAbstractivityScore failed: %v", err)
+	}
+	if score.ConcreteMinutes != 120 || score.AbstractMinutes != 60 {
+		t.Errorf("expected 120 concrete and 60 abstract minutes, got %d and %d", score.ConcreteMinutes, score.AbstractMinutes)
+	}
+	// 50 less a third abstract (-17)
+	if score.Score != 33 {
+		t.Errorf("expected score 33 from focus time alone, got %d", score.Score)
+	}
+
+	// 12 commits (+40) and 35 file edits (+3)
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	for i := 0; i < 12; i++ {
+		hash := fmt.Sprintf("c%02d", i)
+		store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: day.Add(10*time.Hour + time.Duration(i)*time.Minute).Unix(), CommitHash: hash, ShortHash: hash,
+			RepositoryID: repoID, Message: "Work",
+		})
+	}
+	for i := 0; i < 35; i++ {
+		store.SaveFileEvent(&storage.FileEvent{
+			Timestamp: day.Add(9*time.Hour + time.Duration(i)*time.Minute).Unix(), EventType: "modify",
+			FilePath: "/src/traq/main.go", FileName: "main.go", WatchCategory: "projects",
+		})
+	}
+
+	stats, err := svc.GetDailyStats("2026-03-10")
+	if err != nil {
+		t.Fatalf("GetDailyStats failed: %v", err)
+	}
+	score = stats.AbstractivityScore
+	if score == nil {
+		t.Fatal("expected DailyStats to include the abstractivity score")
+	}
+	if score.Score != 40+3+33 {
+		t.Errorf("expected score 76, got %d", score.Score)
+	}
+	want := []string{
+		"12 git commits (+40)",
+		"35 file edits (+3)",
+		"120 min in editors and terminals",
+		"40 min browser-only",
+		"20 min in AI/chat tools",
+		"33% of focus time abstract (-17)",
+	}
+	if !reflect.DeepEqual(score.Indicators, want) {
+		t.Errorf("unexpected indicators:\n got %q\nwant %q", score.Indicators, want)
+	}
+
+	// The score is capped at 100
+	for i := 12; i < 30; i++ {
+		hash := fmt.Sprintf("c%02d", i)
+		store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: day.Add(13*time.Hour + time.Duration(i)*time.Minute).Unix(), CommitHash: hash, ShortHash: hash,
+			RepositoryID: repoID, Message: "Work",
+		})
+	}
+	if score, _ = svc.GetAbstractivityScore("2026-03-10"); score.Score != 100 {
+		t.Errorf("expected score capped at 100, got %d", score.Score)
+	}
+}
+
+func TestGetWeekdayPatterns(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	store.SetAppTimelineCategory("code", "focus")
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+
+	at := func(day time.Time, hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+	session := func(from, to time.Time) {
+		t.Helper()
+		id, err := store.CreateSession(from.Unix())
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		store.EndSession(id, to.Unix())
+	}
+	focus := func(app, title string, from time.Time, minutes int) {
+		t.Helper()
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName: app, WindowTitle: title, StartTime: from.Unix(), EndTime: from.Add(time.Duration(minutes) * time.Minute).Unix(),
+			DurationSeconds: float64(minutes * 60),
+		}); err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+	}
+
+	// Four weeks: long focused Tuesdays, meeting-heavy Mondays and commits on Fridays
+	end := time.Date(2024, time.April, 1, 0, 0, 0, 0, time.Local) // Monday
+	for week := 0; week < 4; week++ {
+		monday := end.AddDate(0, 0, 7*(week-4))
+		for i := 0; i < 5; i++ {
+			day := monday.AddDate(0, 0, i)
+			switch day.Weekday() {
+			case time.Tuesday:
+				session(at(day, 8, 30), at(day, 18, 0))
+				focus("code", "main.go", at(day, 9, 0), 180)
+			case time.Monday:
+				session(at(day, 9, 0), at(day, 17, 0))
+				focus("code", "main.go", at(day, 10, 0), 60)
+				focus("zoom", "Zoom Meeting", at(day, 13, 0), 120)
+			default:
+				session(at(day, 9, 0), at(day, 17, 0))
+				focus("code", "main.go", at(day, 10, 0), 60)
+			}
+			if day.Weekday() == time.Friday {
+				for c := 0; c < 3; c++ {
+					hash := fmt.Sprintf("w%dc%d", week, c)
+					store.SaveGitCommit(&storage.GitCommit{
+						Timestamp: at(day, 15, c).Unix(), CommitHash: hash, ShortHash: hash, RepositoryID: repoID, Message: "Work",
+					})
+				}
+			}
+		}
+	}
+
+	patterns, err := svc.weekdayPatterns(end, 4)
+	if err != nil {
+		t.Fatalf("weekdayPatterns failed: %v", err)
+	}
+	if patterns.DataWeeks != 4 {
+		t.Errorf("expected 4 weeks analyzed, got %d", patterns.DataWeeks)
+	}
+
+	tue := patterns.Days[time.Tuesday]
+	if tue.Weekday != "Tuesday" || tue.ActiveDays != 4 {
+		t.Errorf("expected 4 active Tuesdays, got %+v", tue)
+	}
+	if tue.AverageActiveMinutes != 570 || tue.AverageDeepWorkMinutes != 180 {
+		t.Errorf("expected 570 active and 180 deep-work minutes on Tuesdays, got %.1f and %.1f", tue.AverageActiveMinutes, tue.AverageDeepWorkMinutes)
+	}
+	if tue.TypicalStartHour != 8.5 || tue.TypicalEndHour != 18 || tue.BestHour != 9 {
+		t.Errorf("expected Tuesdays 8.5-18 with best hour 9, got %.2f-%.2f and %d", tue.TypicalStartHour, tue.TypicalEndHour, tue.BestHour)
+	}
+
+	mon := patterns.Days[time.Monday]
+	if mon.AverageMeetingMinutes != 120 || mon.BestHour != 10 {
+		t.Errorf("expected 120 meeting minutes and best hour 10 on Mondays, got %.1f and %d", mon.AverageMeetingMinutes, mon.BestHour)
+	}
+	if fri := patterns.Days[time.Friday]; fri.AverageCommits != 3 {
+		t.Errorf("expected 3 commits on an average Friday, got %.1f", fri.AverageCommits)
+	}
+	if sat := patterns.Days[time.Saturday]; sat.ActiveDays != 0 || sat.AverageActiveMinutes != 0 || sat.BestHour != -1 {
+		t.Errorf("expected an empty Saturday, got %+v", sat)
+	}
+
+	titles := make(map[string]string)
+	for _, insight := range patterns.Insights {
+		titles[insight.Type] = insight.Title
+	}
+	if titles[InsightWeekdayProductive] != "You're most productive on Tuesdays" {
+		t.Errorf("unexpected productive-day insight %q", titles[InsightWeekdayProductive])
+	}
+	if titles[InsightWeekdayMeetings] != "Mondays are your most meeting-heavy day" {
+		t.Errorf("unexpected meeting-day insight %q", titles[InsightWeekdayMeetings])
+	}
+}
+
+func TestGetDistractedHours(t *testing.T) {
+	reports, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	day := time.Date(2024, 3, 6, 0, 0, 0, 0, time.Local)
+	at := func(hour, minute int) int64 {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Unix()
+	}
+	focus := func(app string, start, end int64) {
+		t.Helper()
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{AppName: app, WindowTitle: app, StartTime: start, EndTime: end, DurationSeconds: float64(end - start)}); err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+	visit := func(domain string, timestamp, seconds int64) {
+		t.Helper()
+		if _, err := store.SaveBrowserVisit(&storage.BrowserVisit{Timestamp: timestamp, URL: "https://" + domain + "/", Domain: domain, Browser: "chrome", VisitDurationSeconds: storage.NullInt64(seconds)}); err != nil {
+			t.Fatalf("failed to save visit: %v", err)
+		}
+	}
+
+	focus("code", at(9, 0), at(10, 0))   // Undistracted hour
+	focus("code", at(14, 0), at(14, 40)) // A third of 14:00 in Discord
+	focus("Discord", at(14, 40), at(15, 0))
+	focus("chrome", at(20, 0), at(20, 30)) // Two thirds of 20:00 on YouTube
+	visit("youtube.com", at(20, 0), 1200)
+	visit("github.com", at(20, 20), 600)   // Not distracting
+	focus("chrome", at(-4, 0), at(-3, 10)) // The day before: all Reddit
+	visit("reddit.com", at(-4, 0), 4200)
+
+	entries, err := svc.GetDistractedHours("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetDistractedHours failed: %v", err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("expected 3 hours with activity, got %d", len(entries))
+	}
+	expected := []DistractedHourEntry{
+		{Hour: 9, DistractingApps: []string{}, DistractingDomains: []string{}},
+		{Hour: 14, DistractingMinutes: 20, DistractingApps: []string{GetFriendlyAppName("Discord")}, DistractingDomains: []string{}, DistractionScore: 33.3},
+		{Hour: 20, DistractingMinutes: 20, DistractingApps: []string{}, DistractingDomains: []string{"youtube.com"}, DistractionScore: 66.7},
+	}
+	for i, want := range expected {
+		if !reflect.DeepEqual(*entries[i], want) {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want, *entries[i])
+		}
+	}
+
+	if _, err := svc.GetDistractedHours("not a date"); err == nil {
+		t.Error("expected an error for an invalid date")
+	}
+
+	// The day before averages 100 across its two hours, above 2024-03-06's 33.3
+	mostDistracted, err := svc.mostDistractedDay(day.AddDate(0, 0, 1), 1)
+	if err != nil {
+		t.Fatalf("mostDistractedDay failed: %v", err)
+	}
+	if mostDistracted != "2024-03-05" {
+		t.Errorf("expected 2024-03-05 as the most distracted day, got %q", mostDistracted)
+	}
+	if none, _ := svc.mostDistractedDay(day.AddDate(0, 0, -7), 1); none != "" {
+		t.Errorf("expected no distracted day without activity, got %q", none)
+	}
+
+	// The weekly summary charts the most distracted hours, highest score first
+	markdown, err := reports.GenerateWeeklySummaryMarkdown("2024-03-04", "2024-03-10")
+	if err != nil {
+		t.Fatalf("GenerateWeeklySummaryMarkdown failed: %v", err)
+	}
+	start := strings.Index(markdown, "## Most Distracted Hours")
+	if start < 0 {
+		t.Fatalf("expected a most distracted hours section in the weekly summary, got:\n%s", markdown)
+	}
+	section := markdown[start:]
+	if strings.Index(section, "**20:00**") > strings.Index(section, "**14:00**") ||
+		strings.Contains(section, "**09:00**") {
+		t.Errorf("expected the most distracted hours in the weekly summary, got:\n%s", section)
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// bsdiffMagic is the header of patches produced by bsdiff 4.x.
+const bsdiffMagic = "BSDIFF40"
+
+// maxPatchedSize caps the size of a patched file, so a malformed header can't make bspatch
+// allocate an arbitrary amount of memory.
+const maxPatchedSize = 512 << 20
+
+// errCorruptPatch is returned when a bsdiff patch is malformed or doesn't fit the old file.
+var errCorruptPatch = errors.New("corrupt patch")
+
+// bspatch applies a bsdiff 4.x patch to old and returns the new file.
+//
+// The patch is a 32-byte header (magic, control block length, diff block length, new file
+// size) followed by three bzip2 streams: control triples (diff length, extra length, old
+// seek), bytes added to old, and new bytes copied verbatim.
+func bspatch(old, patch []byte) ([]byte, error) {
+	if len(patch) < 32 || string(patch[:8]) != bsdiffMagic {
+		return nil, fmt.Errorf("%w: bad header", errCorruptPatch)
+	}
+	ctrlLen := offtin(patch[8:16])
+	diffLen := offtin(patch[16:24])
+	newSize := offtin(patch[24:32])
+	// Compare against the remaining length rather than summing, which could overflow
+	bodyLen := int64(len(patch) - 32)
+	if ctrlLen < 0 || diffLen < 0 || ctrlLen > bodyLen || diffLen > bodyLen-ctrlLen {
+		return nil, fmt.Errorf("%w: bad block lengths", errCorruptPatch)
+	}
+	if newSize < 0 || newSize > maxPatchedSize {
+		return nil, fmt.Errorf("%w: bad new file size %d", errCorruptPatch, newSize)
+	}
+
+	body := patch[32:]
+	ctrl := bzip2.NewReader(bytes.NewReader(body[:ctrlLen]))
+	diff := bzip2.NewReader(bytes.NewReader(body[ctrlLen : ctrlLen+diffLen]))
+	extra := bzip2.NewReader(bytes.NewReader(body[ctrlLen+diffLen:]))
+
+	newData := make([]byte, newSize)
+	var oldPos, newPos int64
+	var triple [24]byte
+	for newPos < newSize {
+		if _, err := io.ReadFull(ctrl, triple[:]); err != nil {
+			return nil, fmt.Errorf("%w: reading control block: %v", errCorruptPatch, err)
+		}
+		addLen, copyLen, seek := offtin(triple[0:8]), offtin(triple[8:16]), offtin(triple[16:24])
+		remaining := newSize - newPos
+		if addLen < 0 || copyLen < 0 || addLen > remaining || copyLen > remaining-addLen ||
+			seek > maxPatchedSize || seek < -maxPatchedSize {
+			return nil, fmt.Errorf("%w: control entry out of range", errCorruptPatch)
+		}
+
+		// Add diff bytes to the old data
+		if _, err := io.ReadFull(diff, newData[newPos:newPos+addLen]); err != nil {
+			return nil, fmt.Errorf("%w: reading diff block: %v", errCorruptPatch, err)
+		}
+		for i := int64(0); i < addLen; i++ {
+			if oldPos+i >= 0 && oldPos+i < int64(len(old)) {
+				newData[newPos+i] += old[oldPos+i]
+			}
+		}
+		newPos += addLen
+		oldPos += addLen
+
+		// Copy extra bytes verbatim
+		if _, err := io.ReadFull(extra, newData[newPos:newPos+copyLen]); err != nil {
+			return nil, fmt.Errorf("%w: reading extra block: %v", errCorruptPatch, err)
+		}
+		newPos += copyLen
+		oldPos += seek
+	}
+
+	return newData, nil
+}
+
+// offtin decodes bsdiff's 8-byte sign-magnitude little-endian integer.
+func offtin(b []byte) int64 {
+	v := int64(b[7] & 0x7f)
+	for i := 6; i >= 0; i-- {
+		v = v<<8 | int64(b[i])
+	}
+	if b[7]&0x80 != 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// Burnout risk levels.
+const (
+	BurnoutRiskLow      = "low"
+	BurnoutRiskModerate = "moderate"
+	BurnoutRiskHigh     = "high"
+)
+
+const (
+	// defaultBurnoutWeeks is the number of weeks assessed when none is given.
+	defaultBurnoutWeeks = 4
+	// maxBurnoutWeeks caps how far back the assessment looks.
+	maxBurnoutWeeks = 52
+
+	// burnoutLateHour is the hour of the day after which work counts as a late night.
+	burnoutLateHour = 21
+	// burnoutLateNightRun is the number of consecutive late nights that counts as a risk.
+	burnoutLateNightRun = 5
+	// burnoutLongWeekHours is the weekly focus time that counts as a long week.
+	burnoutLongWeekHours = 50
+	// burnoutWeekendRun is the number of consecutive worked weekends that counts as a risk.
+	burnoutWeekendRun = 3
+	// burnoutWeekendMinMinutes is the weekend focus time that counts as working the weekend.
+	burnoutWeekendMinMinutes = 30
+	// burnoutBreakMinutes is the shortest AFK period or gap in activity that counts as a break.
+	burnoutBreakMinutes = 30
+	// burnoutWorkDayHours is the focus time a day needs before missing breaks is a concern.
+	burnoutWorkDayHours = 4
+	// burnoutDeepWorkDropPercent is the drop in last week's deep work, compared with the
+	// average of the weeks before, that counts as a declining trend.
+	burnoutDeepWorkDropPercent = 25
+	// burnoutMinDeepWorkMinutes ignores deep-work trends when earlier weeks had little of it.
+	burnoutMinDeepWorkMinutes = 60
+
+	// burnoutModerateScore and burnoutHighScore are the lowest scores for each level.
+	burnoutModerateScore = 25
+	burnoutHighScore     = 50
+)
+
+// Score added by each burnout risk factor. They add up to 100.
+const (
+	burnoutLateNightScore = 25
+	burnoutLongWeekScore  = 25
+	burnoutWeekendScore   = 20
+	burnoutNoBreakScore   = 15
+	burnoutDeepWorkScore  = 15
+)
+
+// burnoutLevelColors are the report colors for each burnout risk level.
+var burnoutLevelColors = map[string]string{
+	BurnoutRiskLow:      "#22c55e",
+	BurnoutRiskModerate: "#f59e0b",
+	BurnoutRiskHigh:     "#ef4444",
+}
+
+// BurnoutRisk is a heuristic assessment of burnout risk from recent work patterns.
+type BurnoutRisk struct {
+	Score          float64  `json:"score"`   // 0-100
+	Level          string   `json:"level"`   // low, moderate, high
+	Factors        []string `json:"factors"` // e.g. "Worked after 9pm on 6 consecutive days"
+	Recommendation string   `json:"recommendation"`
+}
+
+// DetectBurnoutRisk assesses burnout risk over the numWeeks weeks before today.
+// If numWeeks <= 0, the last 4 weeks are assessed.
+func (s *InsightService) DetectBurnoutRisk(numWeeks int) (*BurnoutRisk, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return assessBurnoutRisk(s.analytics, today, numWeeks)
+}
+
+// burnoutDay holds a day's activity for the burnout assessment.
+type burnoutDay struct {
+	start, end int64
+	lateStart  int64 // 9pm
+	seconds    float64
+	lateNight  bool
+	hadBreak   bool
+	events     []*storage.WindowFocusEvent
+}
+
+// overlappingDays returns the days that overlap [from, to).
+func overlappingDays(days []*burnoutDay, from, to int64) []*burnoutDay {
+	first := sort.Search(len(days), func(i int) bool { return days[i].end > from })
+	last := sort.Search(len(days), func(i int) bool { return days[i].start >= to })
+	if first >= last {
+		return nil
+	}
+	return days[first:last]
+}
+
+// assessBurnoutRisk applies the burnout heuristics to the numWeeks weeks before end (a midnight).
+// Today is left out by callers so a partly worked day doesn't look like a decline.
+func assessBurnoutRisk(analytics *AnalyticsService, end time.Time, numWeeks int) (*BurnoutRisk, error) {
+	if numWeeks <= 0 {
+		numWeeks = defaultBurnoutWeeks
+	}
+	if numWeeks > maxBurnoutWeeks {
+		numWeeks = maxBurnoutWeeks
+	}
+	start := end.AddDate(0, 0, -7*numWeeks)
+
+	events, err := analytics.store.GetWindowFocusEventsByTimeRange(start.Unix(), end.Unix()-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load focus events: %w", err)
+	}
+	afkEvents, err := analytics.store.GetAFKEventsByTimeRange(start.Unix(), end.Unix()-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AFK events: %w", err)
+	}
+
+	days := make([]*burnoutDay, 7*numWeeks)
+	for i := range days {
+		dayStart := start.AddDate(0, 0, i)
+		days[i] = &burnoutDay{
+			start:     dayStart.Unix(),
+			end:       dayStart.AddDate(0, 0, 1).Unix(),
+			lateStart: time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), burnoutLateHour, 0, 0, 0, time.Local).Unix(),
+		}
+	}
+	appNames := make(map[string]bool)
+	for _, evt := range events {
+		appNames[evt.AppName] = true
+		for _, day := range overlappingDays(days, evt.StartTime, evt.EndTime) {
+			day.seconds += clampedEventDuration(evt, day.start, day.end)
+			day.events = append(day.events, evt)
+			if evt.EndTime > day.lateStart {
+				day.lateNight = true
+			}
+		}
+	}
+	for _, afk := range afkEvents {
+		afkEnd := end.Unix()
+		if afk.EndTime.Valid {
+			afkEnd = afk.EndTime.Int64
+		}
+		for _, day := range overlappingDays(days, afk.StartTime, afkEnd) {
+			if min(afkEnd, day.end)-max(afk.StartTime, day.start) >= burnoutBreakMinutes*60 {
+				day.hadBreak = true
+			}
+		}
+	}
+
+	appNamesList := make([]string, 0, len(appNames))
+	for name := range appNames {
+		appNamesList = append(appNamesList, name)
+	}
+	categories, err := analytics.store.GetAppTimelineCategories(appNamesList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load app categories: %w", err)
+	}
+
+	risk := &BurnoutRisk{Factors: []string{}}
+	var recommendation string
+	addFactor := func(score float64, factor, advice string) {
+		// The heaviest factor comes first and sets the recommendation
+		if risk.Score == 0 {
+			recommendation = advice
+		}
+		risk.Score += score
+		risk.Factors = append(risk.Factors, factor)
+	}
+
+	// Late nights: the longest run of consecutive days with work after 9pm
+	run, longestRun := 0, 0
+	for _, day := range days {
+		if day.lateNight {
+			run++
+			longestRun = max(longestRun, run)
+		} else {
+			run = 0
+		}
+	}
+	if longestRun >= burnoutLateNightRun {
+		addFactor(burnoutLateNightScore,
+			fmt.Sprintf("Worked after 9pm on %d consecutive days", longestRun),
+			"Set an evening cut-off time and stop working when you reach it.")
+	}
+
+	// Long weeks: every week over 50 hours
+	weekHours := make([]float64, numWeeks)
+	weekDeepWork := make([]int64, numWeeks)
+	for i, day := range days {
+		weekHours[i/7] += day.seconds / 3600
+		for _, block := range detectDeepWorkBlocks(day.events, categories, defaultDeepWorkMinutes, day.start, day.end-1) {
+			weekDeepWork[i/7] += block.DurationMinutes
+		}
+	}
+	longWeeks := 0
+	for _, hours := range weekHours {
+		if hours > burnoutLongWeekHours {
+			longWeeks++
+		}
+	}
+	if longWeeks == numWeeks {
+		factor := fmt.Sprintf("Worked over %d hours in each of the last %d weeks", burnoutLongWeekHours, numWeeks)
+		if numWeeks == 1 {
+			factor = fmt.Sprintf("Worked over %d hours last week", burnoutLongWeekHours)
+		}
+		addFactor(burnoutLongWeekScore, factor,
+			"Your weeks are consistently long. Protect some time off and drop low-value work.")
+	}
+
+	// Weekend work: the longest run of consecutive weekends with work
+	run, longestRun = 0, 0
+	for i, day := range days {
+		if time.Unix(day.start, 0).Weekday() != time.Saturday {
+			continue
+		}
+		weekendSeconds := day.seconds
+		if i+1 < len(days) {
+			weekendSeconds += days[i+1].seconds
+		}
+		if weekendSeconds >= burnoutWeekendMinMinutes*60 {
+			run++
+			longestRun = max(longestRun, run)
+		} else {
+			run = 0
+		}
+	}
+	if longestRun >= burnoutWeekendRun {
+		addFactor(burnoutWeekendScore,
+			fmt.Sprintf("Worked %d weekends in a row", longestRun),
+			"Take at least one full day off every weekend.")
+	}
+
+	// No breaks: working days without an AFK period or gap in activity of 30+ minutes
+	workDays, noBreakDays := 0, 0
+	for _, day := range days {
+		if day.seconds < burnoutWorkDayHours*3600 {
+			continue
+		}
+		workDays++
+		hadBreak := day.hadBreak
+		for i := 1; i < len(day.events) && !hadBreak; i++ {
+			if day.events[i].StartTime-day.events[i-1].EndTime >= burnoutBreakMinutes*60 {
+				hadBreak = true
+			}
+		}
+		if !hadBreak {
+			noBreakDays++
+		}
+	}
+	if noBreakDays > 0 {
+		addFactor(burnoutNoBreakScore,
+			fmt.Sprintf("No break of %d+ minutes on %d of %d working days", burnoutBreakMinutes, noBreakDays, workDays),
+			"Step away for a proper break of at least 30 minutes every day.")
+	}
+
+	// Declining deep work: last week well below the average of the weeks before
+	if numWeeks >= 2 {
+		var earlier int64
+		for _, minutes := range weekDeepWork[:numWeeks-1] {
+			earlier += minutes
+		}
+		average := float64(earlier) / float64(numWeeks-1)
+		last := float64(weekDeepWork[numWeeks-1])
+		if average >= burnoutMinDeepWorkMinutes && last <= average*(1-burnoutDeepWorkDropPercent/100.0) {
+			addFactor(burnoutDeepWorkScore,
+				fmt.Sprintf("Deep work fell to %s last week from an average of %s", formatMinutes(int64(last)), formatMinutes(int64(average))),
+				"Your deep work is declining. Block out uninterrupted focus time and cut back on meetings.")
+		}
+	}
+
+	switch {
+	case risk.Score >= burnoutHighScore:
+		risk.Level = BurnoutRiskHigh
+	case risk.Score >= burnoutModerateScore:
+		risk.Level = BurnoutRiskModerate
+	default:
+		risk.Level = BurnoutRiskLow
+	}
+	risk.Recommendation = recommendation
+	if recommendation == "" {
+		risk.Recommendation = "Your work patterns look sustainable. Keep taking breaks and time off."
+	}
+	return risk, nil
+}
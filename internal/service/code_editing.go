@@ -0,0 +1,125 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// codeWatchCategory is the watch category of file events in project directories.
+const codeWatchCategory = "projects"
+
+// codeEditingWindow is the bucket size used for ActiveEditingMinutes: any window with at
+// least one file event counts as active.
+const codeEditingWindow = 5 * time.Minute
+
+// CodeEditingStats summarizes file changes in project directories over a day.
+type CodeEditingStats struct {
+	TotalFilesEdited     int            `json:"totalFilesEdited"`     // Distinct files modified or renamed
+	TotalFilesCreated    int            `json:"totalFilesCreated"`    // Distinct files created
+	TotalFilesDeleted    int            `json:"totalFilesDeleted"`    // Distinct files deleted
+	EditsByExtension     map[string]int `json:"editsByExtension"`     // Extension (e.g. ".go") -> created/modified/renamed events
+	PeakEditingHour      int            `json:"peakEditingHour"`      // Hour (0-23) with the most file events, -1 if none
+	ActiveEditingMinutes int64          `json:"activeEditingMinutes"` // Minutes in 5-minute windows with at least one file event
+}
+
+// DailyCodeStats is one day of GetCodeEditingTrend.
+type DailyCodeStats struct {
+	Date                 string `json:"date"` // YYYY-MM-DD
+	FilesEdited          int    `json:"filesEdited"`
+	EventCount           int    `json:"eventCount"`
+	ActiveEditingMinutes int64  `json:"activeEditingMinutes"`
+}
+
+// GetCodeEditingStats returns file change statistics for a date (YYYY-MM-DD). Only file
+// events from watched project directories are counted.
+func (s *TimelineService) GetCodeEditingStats(date string) (*CodeEditingStats, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+
+	events, err := s.store.GetFileEventsByTimeRange(dayStart.Unix(), dayEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch file events: %w", err)
+	}
+	return summarizeCodeEditing(events), nil
+}
+
+// GetCodeEditingTrend returns per-day code editing totals for the last numDays days,
+// oldest first and ending today. Days without file events are included with zeros.
+func (s *TimelineService) GetCodeEditingTrend(numDays int) ([]*DailyCodeStats, error) {
+	if numDays <= 0 {
+		return []*DailyCodeStats{}, nil
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	first := today.AddDate(0, 0, -(numDays - 1))
+
+	events, err := s.store.GetFileEventsByTimeRange(first.Unix(), today.AddDate(0, 0, 1).Unix()-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch file events: %w", err)
+	}
+	byDay := make(map[string][]*storage.FileEvent)
+	for _, evt := range events {
+		date := time.Unix(evt.Timestamp, 0).Format("2006-01-02")
+		byDay[date] = append(byDay[date], evt)
+	}
+
+	trend := make([]*DailyCodeStats, 0, numDays)
+	for day := first; !day.After(today); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		stats := summarizeCodeEditing(byDay[date])
+		daily := &DailyCodeStats{Date: date, FilesEdited: stats.TotalFilesEdited, ActiveEditingMinutes: stats.ActiveEditingMinutes}
+		for _, evt := range byDay[date] {
+			if evt.WatchCategory == codeWatchCategory {
+				daily.EventCount++
+			}
+		}
+		trend = append(trend, daily)
+	}
+	return trend, nil
+}
+
+// summarizeCodeEditing aggregates the project file events among events.
+func summarizeCodeEditing(events []*storage.FileEvent) *CodeEditingStats {
+	stats := &CodeEditingStats{EditsByExtension: make(map[string]int), PeakEditingHour: -1}
+	edited := make(map[string]bool)
+	created := make(map[string]bool)
+	deleted := make(map[string]bool)
+	windows := make(map[int64]bool)
+	var hourCounts [24]int
+
+	for _, evt := range events {
+		if evt.WatchCategory != codeWatchCategory {
+			continue
+		}
+		switch evt.EventType {
+		case "create":
+			created[evt.FilePath] = true
+		case "delete":
+			deleted[evt.FilePath] = true
+		default:
+			edited[evt.FilePath] = true
+		}
+		if evt.EventType != "delete" && evt.FileExtension.Valid && evt.FileExtension.String != "" {
+			stats.EditsByExtension[evt.FileExtension.String]++
+		}
+		hourCounts[time.Unix(evt.Timestamp, 0).Hour()]++
+		windows[evt.Timestamp/int64(codeEditingWindow.Seconds())] = true
+	}
+
+	stats.TotalFilesEdited = len(edited)
+	stats.TotalFilesCreated = len(created)
+	stats.TotalFilesDeleted = len(deleted)
+	stats.ActiveEditingMinutes = int64(len(windows)) * int64(codeEditingWindow.Minutes())
+	for hour, count := range hourCounts {
+		if count > 0 && (stats.PeakEditingHour < 0 || count > hourCounts[stats.PeakEditingHour]) {
+			stats.PeakEditingHour = hour
+		}
+	}
+	return stats
+}
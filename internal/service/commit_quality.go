@@ -0,0 +1,202 @@
+package service
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+const (
+	// lowCommitQualityScore is the score below which a commit is listed as low quality.
+	lowCommitQualityScore = 60
+	// maxLowQualityCommits bounds the low-quality commits listed in a report.
+	maxLowQualityCommits = 10
+	// Commit subject lengths that score well; 72 is where git tools start truncating.
+	minCommitSubjectLength = 20
+	maxCommitSubjectLength = 72
+)
+
+// Commit quality issues, in the order suggestions are given for them.
+const (
+	commitIssueWIP           = "WIP commit"
+	commitIssueTooShort      = "Too short"
+	commitIssueTooLong       = "Too long"
+	commitIssueNotImperative = "Not in imperative mood"
+	commitIssueNoPrefix      = "No conventional commit prefix"
+	commitIssueNoTicket      = "No ticket reference"
+)
+
+// commitIssueSuggestions are the suggestions given for each commit quality issue.
+var commitIssueSuggestions = map[string]string{
+	commitIssueWIP:           "Squash work-in-progress and typo commits into the change they belong to before merging.",
+	commitIssueTooShort:      "Describe what the change does and why in at least 20 characters.",
+	commitIssueTooLong:       "Keep the subject line under 72 characters and put details in the body.",
+	commitIssueNotImperative: `Write the subject as a command, e.g. "Add" rather than "Added" or "Adds".`,
+	commitIssueNoPrefix:      `Start with a conventional commit type such as "feat:" or "fix:".`,
+	commitIssueNoTicket:      "Mention the ticket the change is for, e.g. PROJ-123 or #42.",
+}
+
+var (
+	// conventionalCommitRe matches a conventional commit prefix, e.g. "feat(api)!: ".
+	conventionalCommitRe = regexp.MustCompile(`^(feat|fix|chore|docs|test|refactor|style|perf|ci|build|revert)(\([^)]*\))?!?: *`)
+	// wipCommitRe matches work-in-progress, fixup and typo commits.
+	wipCommitRe = regexp.MustCompile(`(?i)^(fixup!|squash!)|\b(wip|work in progress|typo|oops)\b`)
+)
+
+// CommitScore rates a commit message against common conventions.
+type CommitScore struct {
+	Score      int      `json:"score"`      // 0-100, 20 for each convention followed
+	Issues     []string `json:"issues"`     // Conventions not followed
+	Suggestion string   `json:"suggestion"` // How to fix the most important issue, "" if there are none
+}
+
+// CommitQualityAnalyzer scores commit messages.
+type CommitQualityAnalyzer struct {
+	tickets *TicketExtractor
+}
+
+// NewCommitQualityAnalyzer creates a new CommitQualityAnalyzer.
+func NewCommitQualityAnalyzer() *CommitQualityAnalyzer {
+	return &CommitQualityAnalyzer{tickets: NewTicketExtractor(nil)}
+}
+
+// ScoreCommit scores a commit message's subject line. Each of these is worth 20 points:
+// a conventional commit prefix, a length of 20-72 characters, a ticket reference, not being
+// a WIP or typo fix, and an imperative first word.
+func (a *CommitQualityAnalyzer) ScoreCommit(message string) *CommitScore {
+	subject, _, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	subject = strings.TrimSpace(subject)
+	score := &CommitScore{Issues: []string{}}
+
+	if wipCommitRe.MatchString(subject) {
+		score.Issues = append(score.Issues, commitIssueWIP)
+	} else {
+		score.Score += 20
+	}
+
+	switch length := len([]rune(subject)); {
+	case length < minCommitSubjectLength:
+		score.Issues = append(score.Issues, commitIssueTooShort)
+	case length > maxCommitSubjectLength:
+		score.Issues = append(score.Issues, commitIssueTooLong)
+	default:
+		score.Score += 20
+	}
+
+	description := subject
+	if prefix := conventionalCommitRe.FindString(subject); prefix != "" {
+		description = subject[len(prefix):]
+	}
+	if isImperative(description) {
+		score.Score += 20
+	} else {
+		score.Issues = append(score.Issues, commitIssueNotImperative)
+	}
+
+	if description != subject {
+		score.Score += 20
+	} else {
+		score.Issues = append(score.Issues, commitIssueNoPrefix)
+	}
+
+	if refs, _ := a.tickets.ExtractFromCommit(&storage.GitCommit{Message: message}); len(refs) > 0 {
+		score.Score += 20
+	} else {
+		score.Issues = append(score.Issues, commitIssueNoTicket)
+	}
+
+	if len(score.Issues) > 0 {
+		score.Suggestion = commitIssueSuggestions[score.Issues[0]]
+	}
+	return score
+}
+
+// isImperative guesses whether a description starts with an imperative verb, treating
+// past tense ("Added"), gerunds ("Adding") and third person ("Adds") as not imperative.
+func isImperative(description string) bool {
+	fields := strings.Fields(description)
+	if len(fields) == 0 {
+		return false
+	}
+	word := strings.ToLower(strings.Trim(fields[0], ".,:;!"))
+	switch {
+	case strings.HasSuffix(word, "ed"), strings.HasSuffix(word, "ing"):
+		return false
+	case strings.HasSuffix(word, "s"):
+		// "Process", "Focus" and "Alias" are imperative
+		return strings.HasSuffix(word, "ss") || strings.HasSuffix(word, "us") || strings.HasSuffix(word, "as")
+	}
+	return true
+}
+
+// CommitQualityReport summarizes commit message quality over a time range.
+type CommitQualityReport struct {
+	CommitCount       int                  `json:"commitCount"` // Scored commits; merges are skipped
+	AverageScore      float64              `json:"averageScore"`
+	TopIssues         []string             `json:"topIssues"` // Most common issues first
+	LowQualityCommits []*storage.GitCommit `json:"lowQualityCommits"`
+}
+
+// GetCommitQualityReport scores the user's commit messages between start and end.
+func (s *ReportsService) GetCommitQualityReport(start, end int64) (*CommitQualityReport, error) {
+	commits, err := s.userGitCommits(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return commitQualityReport(commits), nil
+}
+
+// commitQualityReport scores non-merge commits. Low-quality commits are listed lowest
+// score first, up to maxLowQualityCommits.
+func commitQualityReport(commits []*storage.GitCommit) *CommitQualityReport {
+	analyzer := NewCommitQualityAnalyzer()
+	report := &CommitQualityReport{TopIssues: []string{}, LowQualityCommits: []*storage.GitCommit{}}
+	issueCounts := make(map[string]int)
+	scores := make(map[*storage.GitCommit]int)
+
+	var total int
+	for _, commit := range commits {
+		if commit.IsMerge {
+			continue
+		}
+		message := commit.Message
+		if message == "" {
+			message = commit.MessageSubject
+		}
+		score := analyzer.ScoreCommit(message)
+		total += score.Score
+		report.CommitCount++
+		for _, issue := range score.Issues {
+			issueCounts[issue]++
+		}
+		if score.Score < lowCommitQualityScore {
+			scores[commit] = score.Score
+			report.LowQualityCommits = append(report.LowQualityCommits, commit)
+		}
+	}
+	if report.CommitCount == 0 {
+		return report
+	}
+	report.AverageScore = float64(total) / float64(report.CommitCount)
+
+	for issue := range issueCounts {
+		report.TopIssues = append(report.TopIssues, issue)
+	}
+	sort.Slice(report.TopIssues, func(i, j int) bool {
+		a, b := report.TopIssues[i], report.TopIssues[j]
+		if issueCounts[a] != issueCounts[b] {
+			return issueCounts[a] > issueCounts[b]
+		}
+		return a < b
+	})
+
+	sort.SliceStable(report.LowQualityCommits, func(i, j int) bool {
+		return scores[report.LowQualityCommits[i]] < scores[report.LowQualityCommits[j]]
+	})
+	if len(report.LowQualityCommits) > maxLowQualityCommits {
+		report.LowQualityCommits = report.LowQualityCommits[:maxLowQualityCommits]
+	}
+	return report
+}
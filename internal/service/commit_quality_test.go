@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestCommitQualityAnalyzer_ScoreCommit(t *testing.T) {
+	analyzer := NewCommitQualityAnalyzer()
+
+	tests := []struct {
+		message    string
+		wantScore  int
+		wantIssues []string
+	}{
+		{"feat(api): add pagination to the sessions endpoint PROJ-12", 100, nil},
+		{"fix: handle empty responses from the sync API (#42)\n\nLonger body.", 100, nil},
+		{"Add pagination to the sessions endpoint", 60, []string{commitIssueNoPrefix, commitIssueNoTicket}},
+		{"feat: added pagination to the sessions endpoint", 60, []string{commitIssueNotImperative, commitIssueNoTicket}},
+		{"wip", 20, []string{commitIssueWIP, commitIssueTooShort, commitIssueNoPrefix, commitIssueNoTicket}},
+		{"fix: typo in the sessions endpoint docs PROJ-3", 80, []string{commitIssueWIP}},
+		{"Process " + strings.Repeat("long ", 20), 40, []string{commitIssueTooLong, commitIssueNoPrefix, commitIssueNoTicket}},
+	}
+	for _, tt := range tests {
+		score := analyzer.ScoreCommit(tt.message)
+		if score.Score != tt.wantScore {
+			t.Errorf("ScoreCommit(%q) score = %d, want %d (issues %v)", tt.message, score.Score, tt.wantScore, score.Issues)
+		}
+		if strings.Join(score.Issues, ",") != strings.Join(tt.wantIssues, ",") {
+			t.Errorf("ScoreCommit(%q) issues = %v, want %v", tt.message, score.Issues, tt.wantIssues)
+		}
+		if (score.Suggestion == "") != (len(tt.wantIssues) == 0) {
+			t.Errorf("ScoreCommit(%q) suggestion = %q", tt.message, score.Suggestion)
+		}
+	}
+}
+
+func TestGetCommitQualityReport(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	day := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local).Unix()
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/web", Name: "web", IsActive: true})
+	commits := []struct {
+		hash, message string
+		merge         bool
+	}{
+		{"a", "feat: add CSV export for weekly reports PROJ-1", false},
+		{"b", "wip", false},
+		{"c", "Fixed stuff", false},
+		{"d", "Merge branch 'main'", true},
+	}
+	for i, c := range commits {
+		store.SaveGitCommit(&storage.GitCommit{
+			CommitHash: c.hash, ShortHash: c.hash, RepositoryID: repoID, Message: c.message, MessageSubject: c.message,
+			IsMerge: c.merge, Timestamp: day + int64(i*60),
+		})
+	}
+
+	report, err := svc.GetCommitQualityReport(day, day+3600)
+	if err != nil {
+		t.Fatalf("GetCommitQualityReport failed: %v", err)
+	}
+	if report.CommitCount != 3 {
+		t.Errorf("expected merges to be skipped, got %d commits", report.CommitCount)
+	}
+	// 100, 20 and 20 ("Fixed stuff" is short, not imperative, unprefixed and has no ticket)
+	if report.AverageScore != float64(140)/3 {
+		t.Errorf("expected average score %.2f, got %.2f", float64(140)/3, report.AverageScore)
+	}
+	if len(report.LowQualityCommits) != 2 || report.LowQualityCommits[0].CommitHash != "b" {
+		t.Errorf("expected 2 low-quality commits, got %d", len(report.LowQualityCommits))
+	}
+	if len(report.TopIssues) == 0 || report.TopIssues[0] != commitIssueNoPrefix {
+		t.Errorf("expected the most common issue first, got %v", report.TopIssues)
+	}
+
+	data, err := svc.buildWeeklySummaryData(context.Background(), day, day+3600, "2024-03-05", "2024-03-05")
+	if err != nil {
+		t.Fatalf("buildWeeklySummaryData failed: %v", err)
+	}
+	if data.CommitQuality == nil || data.CommitQuality.CommitCount != 3 {
+		t.Errorf("expected commit quality in the weekly summary, got %+v", data.CommitQuality)
+	}
+	if md := svc.formatWeeklySummaryMarkdown(data); !strings.Contains(md, "## Commit Quality") {
+		t.Error("expected the weekly summary to include a commit quality section")
+	}
+}
@@ -3,9 +3,12 @@ package service
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"traq/internal/platform"
@@ -19,6 +22,11 @@ type ConfigService struct {
 	platform        platform.Platform
 	daemon          *tracker.Daemon
 	updateInference func(*Config)
+	onProfileSwitch func(*storage.Profile)
+
+	usageMu          sync.Mutex
+	usageStats       *LifetimeStats // Cached by GetUsageStatistics
+	usageStatsExpiry time.Time
 }
 
 // NewConfigService creates a new ConfigService.
@@ -69,18 +77,32 @@ func (s *ConfigService) SyncAutoStart() error {
 
 // Config represents the full application configuration.
 type Config struct {
-	Capture     *CaptureConfig     `json:"capture"`
-	AFK         *AFKConfig         `json:"afk"`
-	Inference   *InferenceConfig   `json:"inference"`
-	DataSources *DataSourcesConfig `json:"dataSources"`
-	UI          *UIConfig          `json:"ui"`
-	System      *SystemConfig      `json:"system"`
-	Issues      *IssuesConfig      `json:"issues"`
-	Update      *UpdateConfig      `json:"update"`
-	Timeline    *TimelineConfig    `json:"timeline"`
-	AI          *AIConfig          `json:"ai"`
+	Capture      *CaptureConfig      `json:"capture"`
+	AFK          *AFKConfig          `json:"afk"`
+	Inference    *InferenceConfig    `json:"inference"`
+	DataSources  *DataSourcesConfig  `json:"dataSources"`
+	UI           *UIConfig           `json:"ui"`
+	System       *SystemConfig       `json:"system"`
+	Issues       *IssuesConfig       `json:"issues"`
+	Update       *UpdateConfig       `json:"update"`
+	Timeline     *TimelineConfig     `json:"timeline"`
+	AI           *AIConfig           `json:"ai"`
+	FocusGoal    *FocusGoalConfig    `json:"focusGoal"`
+	Obsidian     *ObsidianConfig     `json:"obsidian"`
+	Productivity *ProductivityConfig `json:"productivity"`
+
+	// KeyboardShortcuts maps action names (see KeyboardShortcutActions) to global hotkeys like "Ctrl+Shift+P".
+	// An empty shortcut disables the action.
+	KeyboardShortcuts map[string]string `json:"keyboardShortcuts"`
+
+	// SummaryPrompts maps prompt types (see SummaryPromptTypes) to custom AI prompt templates.
+	// Types without an entry use the default prompt; set them through SummaryService.SetCustomPrompt.
+	SummaryPrompts map[string]string `json:"summaryPrompts"`
 }
 
+// KeyboardShortcutActions lists the actions that can be bound to a global hotkey.
+var KeyboardShortcutActions = []string{"force_capture", "pause_resume", "show_window", "open_timeline"}
+
 // TimelineConfig contains timeline display settings.
 type TimelineConfig struct {
 	MinActivityDurationSeconds int      `json:"minActivityDurationSeconds"` // Filter activities shorter than this (0 = show all)
@@ -95,6 +117,25 @@ type AIConfig struct {
 	SummaryMode         string `json:"summaryMode"`         // "auto_accept", "drafts", "off"
 	SummaryChunkMinutes int    `json:"summaryChunkMinutes"` // 15, 30, 60
 	AssignmentMode      string `json:"assignmentMode"`      // "auto_accept", "drafts", "off"
+	AnnotateScreenshots bool   `json:"annotateScreenshots"` // Add AI summary tags as screenshot annotations
+}
+
+// FocusGoalConfig contains the daily deep-work goal settings.
+type FocusGoalConfig struct {
+	DailyDeepWorkMinutes int  `json:"dailyDeepWorkMinutes"` // 0 disables the goal
+	NotifyOnAchievement  bool `json:"notifyOnAchievement"`  // Show a notification when the goal is first reached each day
+}
+
+// ProductivityConfig contains productivity score settings.
+type ProductivityConfig struct {
+	ContextSwitchPenaltySeconds int `json:"contextSwitchPenaltySeconds"` // Productive time lost per switch into a distracting app
+	MaxDailyDistractionMinutes  int `json:"maxDailyDistractionMinutes"`  // Daily social media time that triggers an alert; 0 disables it
+}
+
+// ObsidianConfig contains Obsidian daily note export settings.
+type ObsidianConfig struct {
+	VaultPath       string `json:"vaultPath"`       // Folder daily notes are written to; empty disables export
+	AutoExportNotes bool   `json:"autoExportNotes"` // Re-export today's note whenever a session ends
 }
 
 // UpdateConfig contains auto-update settings.
@@ -102,6 +143,10 @@ type UpdateConfig struct {
 	AutoUpdate         bool `json:"autoUpdate"`         // Default: true
 	CheckIntervalHours int  `json:"checkIntervalHours"` // Default: 5
 	AFKRestartMinutes  int  `json:"afkRestartMinutes"`  // Default: 10
+
+	// Staged rollouts: opted-in machines may receive canary builds before the release
+	CanaryEnabled  bool    `json:"canaryEnabled"`  // Default: false
+	CanaryFraction float64 `json:"canaryFraction"` // Largest rollout fraction (0-1) to accept. Default: 1
 }
 
 // IssuesConfig contains issue reporting settings.
@@ -144,22 +189,30 @@ type CaptureConfig struct {
 	IntervalSeconds    int    `json:"intervalSeconds"`
 	Quality            int    `json:"quality"`
 	DuplicateThreshold int    `json:"duplicateThreshold"`
-	MonitorMode        string `json:"monitorMode"`  // "active_window", "primary", "specific"
+	MonitorMode        string `json:"monitorMode"`  // "active_window", "primary", "specific", "all"
 	MonitorIndex       int    `json:"monitorIndex"` // Only used when MonitorMode is "specific"
+
+	AdaptiveQuality bool `json:"adaptiveQuality"` // Lower quality while the CPU is under load
+	MinQuality      int  `json:"minQuality"`      // Lowest quality adaptive capture goes down to
 }
 
 // AFKConfig contains AFK detection settings.
 type AFKConfig struct {
-	TimeoutSeconds    int `json:"timeoutSeconds"`
-	MinSessionMinutes int `json:"minSessionMinutes"`
+	TimeoutSeconds             int  `json:"timeoutSeconds"`
+	MinSessionMinutes          int  `json:"minSessionMinutes"`
+	MinSessionDurationSeconds  int  `json:"minSessionDurationSeconds"`  // Sessions shorter than this with only focus events are discarded; 0 keeps all
+	ReturnNotificationsEnabled bool `json:"returnNotificationsEnabled"` // Notify on return from a long AFK period
 }
 
 // DataSourcesConfig contains settings for data sources.
 type DataSourcesConfig struct {
-	Shell   *ShellConfig   `json:"shell"`
-	Git     *GitConfig     `json:"git"`
-	Files   *FilesConfig   `json:"files"`
-	Browser *BrowserConfig `json:"browser"`
+	Shell     *ShellConfig     `json:"shell"`
+	Git       *GitConfig       `json:"git"`
+	Files     *FilesConfig     `json:"files"`
+	Browser   *BrowserConfig   `json:"browser"`
+	Clipboard *ClipboardConfig `json:"clipboard"`
+	Network   *NetworkConfig   `json:"network"`
+	Audio     *AudioConfig     `json:"audio"`
 }
 
 // ShellConfig contains shell history settings.
@@ -200,6 +253,21 @@ type BrowserConfig struct {
 	HistoryLimitDays int      `json:"historyLimitDays"` // Limit how far back to read browser history (0 = unlimited)
 }
 
+// ClipboardConfig contains clipboard tracking settings.
+type ClipboardConfig struct {
+	Enabled bool `json:"enabled"` // Only content length and type are recorded
+}
+
+// NetworkConfig contains network activity tracking settings.
+type NetworkConfig struct {
+	Enabled bool `json:"enabled"` // Records new connections to web hosts (ports 80/443)
+}
+
+// AudioConfig contains audio playback tracking settings.
+type AudioConfig struct {
+	Enabled bool `json:"enabled"` // Records apps playing audio every 30 seconds
+}
+
 // UIConfig contains UI settings.
 type UIConfig struct {
 	Theme             string `json:"theme"` // "light", "dark", "system"
@@ -212,8 +280,14 @@ type SystemConfig struct {
 	AutoStart    bool   `json:"autoStart"`
 	StartOnLogin bool   `json:"startOnLogin"`
 	DataDir      string `json:"dataDir"`
+
+	EnableSSE         bool `json:"enableSSE"`         // Serve live events at /events/focus
+	MaxSSEConnections int  `json:"maxSSEConnections"` // Concurrent /events/focus clients allowed
 }
 
+// DefaultMaxSSEConnections is the default limit on concurrent live event stream clients.
+const DefaultMaxSSEConnections = 3
+
 // DaemonStatus represents the current daemon status.
 type DaemonStatus struct {
 	Running         bool  `json:"running"`
@@ -222,21 +296,37 @@ type DaemonStatus struct {
 	SessionID       int64 `json:"sessionId"`
 	SessionDuration int64 `json:"sessionDuration"` // seconds
 	IdleDuration    int64 `json:"idleDuration"`    // seconds
+
+	// Health of each data source
+	ScreenshotCapture tracker.StatusDetail `json:"screenshotCapture"`
+	GitPoller         tracker.StatusDetail `json:"gitPoller"`
+	FileWatcher       tracker.StatusDetail `json:"fileWatcher"`
+	BrowserSync       tracker.StatusDetail `json:"browserSync"`
+	ShellTracker      tracker.StatusDetail `json:"shellTracker"`
 }
 
 // GetConfig returns the current configuration.
 func (s *ConfigService) GetConfig() (*Config, error) {
 	config := &Config{
-		Capture:     s.getDefaultCaptureConfig(),
-		AFK:         s.getDefaultAFKConfig(),
-		Inference:   s.getDefaultInferenceConfig(),
-		DataSources: s.getDefaultDataSourcesConfig(),
-		UI:          s.getDefaultUIConfig(),
-		System:      s.getDefaultSystemConfig(),
-		Update:      s.getDefaultUpdateConfig(),
-		Timeline:    s.getDefaultTimelineConfig(),
-		AI:          s.getDefaultAIConfig(),
-	}
+		Capture:           s.getDefaultCaptureConfig(),
+		AFK:               s.getDefaultAFKConfig(),
+		Inference:         s.getDefaultInferenceConfig(),
+		DataSources:       s.getDefaultDataSourcesConfig(),
+		UI:                s.getDefaultUIConfig(),
+		System:            s.getDefaultSystemConfig(),
+		Update:            s.getDefaultUpdateConfig(),
+		Timeline:          s.getDefaultTimelineConfig(),
+		AI:                s.getDefaultAIConfig(),
+		FocusGoal:         s.getDefaultFocusGoalConfig(),
+		Obsidian:          &ObsidianConfig{},
+		Productivity:      &ProductivityConfig{ContextSwitchPenaltySeconds: DefaultContextSwitchPenaltySeconds},
+		KeyboardShortcuts: s.getDefaultKeyboardShortcuts(),
+	}
+
+	if shortcuts, err := s.GetKeyboardShortcuts(); err == nil {
+		config.KeyboardShortcuts = shortcuts
+	}
+	config.SummaryPrompts = loadCustomPrompts(s.store)
 
 	// Load from database
 	if val, err := s.store.GetConfig("capture.enabled"); err == nil {
@@ -265,6 +355,14 @@ func (s *ConfigService) GetConfig() (*Config, error) {
 			config.Capture.MonitorIndex = v
 		}
 	}
+	if val, err := s.store.GetConfig("capture.adaptiveQuality"); err == nil && val != "" {
+		config.Capture.AdaptiveQuality = val == "true"
+	}
+	if val, err := s.store.GetConfig("capture.minQuality"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil && v > 0 && v <= 100 {
+			config.Capture.MinQuality = v
+		}
+	}
 	if val, err := s.store.GetConfig("afk.timeout"); err == nil {
 		if v, e := strconv.Atoi(val); e == nil {
 			config.AFK.TimeoutSeconds = v
@@ -275,6 +373,14 @@ func (s *ConfigService) GetConfig() (*Config, error) {
 			config.AFK.MinSessionMinutes = v
 		}
 	}
+	if val, err := s.store.GetConfig("afk.minSessionDurationSeconds"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil {
+			config.AFK.MinSessionDurationSeconds = v
+		}
+	}
+	if val, err := s.store.GetConfig("afk.returnNotifications"); err == nil && val != "" {
+		config.AFK.ReturnNotificationsEnabled = val == "true"
+	}
 	if val, err := s.store.GetConfig("ui.theme"); err == nil && val != "" {
 		config.UI.Theme = val
 	}
@@ -324,6 +430,15 @@ func (s *ConfigService) GetConfig() (*Config, error) {
 			config.DataSources.Browser.HistoryLimitDays = v
 		}
 	}
+	if val, err := s.store.GetConfig("clipboard.enabled"); err == nil {
+		config.DataSources.Clipboard.Enabled = val == "true"
+	}
+	if val, err := s.store.GetConfig("network.enabled"); err == nil {
+		config.DataSources.Network.Enabled = val == "true"
+	}
+	if val, err := s.store.GetConfig("audio.enabled"); err == nil {
+		config.DataSources.Audio.Enabled = val == "true"
+	}
 
 	// Issues settings
 	config.Issues = &IssuesConfig{
@@ -381,6 +496,14 @@ func (s *ConfigService) GetConfig() (*Config, error) {
 			config.Update.AFKRestartMinutes = v
 		}
 	}
+	if val, err := s.store.GetConfig("update.canaryEnabled"); err == nil && val != "" {
+		config.Update.CanaryEnabled = val == "true"
+	}
+	if val, err := s.store.GetConfig("update.canaryFraction"); err == nil {
+		if v, e := strconv.ParseFloat(val, 64); e == nil && v >= 0 && v <= 1 {
+			config.Update.CanaryFraction = v
+		}
+	}
 
 	// Timeline settings
 	if val, err := s.store.GetConfig("timeline.minActivityDurationSeconds"); err == nil {
@@ -415,15 +538,139 @@ func (s *ConfigService) GetConfig() (*Config, error) {
 	if val, err := s.store.GetConfig("ai.assignmentMode"); err == nil && val != "" {
 		config.AI.AssignmentMode = val
 	}
+	if val, err := s.store.GetConfig("ai.annotateScreenshots"); err == nil && val != "" {
+		config.AI.AnnotateScreenshots = val == "true"
+	}
+
+	// Focus goal settings
+	if val, err := s.store.GetConfig("focusGoal.dailyDeepWorkMinutes"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil {
+			config.FocusGoal.DailyDeepWorkMinutes = v
+		}
+	}
+	if val, err := s.store.GetConfig("focusGoal.notifyOnAchievement"); err == nil && val != "" {
+		config.FocusGoal.NotifyOnAchievement = val == "true"
+	}
+
+	// Productivity settings
+	if val, err := s.store.GetConfig("productivity.contextSwitchPenaltySeconds"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil {
+			config.Productivity.ContextSwitchPenaltySeconds = v
+		}
+	}
+	if val, err := s.store.GetConfig("productivity.maxDailyDistractionMinutes"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil {
+			config.Productivity.MaxDailyDistractionMinutes = v
+		}
+	}
+
+	// Obsidian settings
+	config.Obsidian.VaultPath = s.GetObsidianVaultPath()
+	if val, err := s.store.GetConfig("obsidian.autoExportNotes"); err == nil && val != "" {
+		config.Obsidian.AutoExportNotes = val == "true"
+	}
 
 	// System settings - only override if explicitly set in database
 	if val, err := s.store.GetConfig("system.startOnLogin"); err == nil && val != "" {
 		config.System.StartOnLogin = val == "true"
 	}
+	if val, err := s.store.GetConfig("system.enableSSE"); err == nil && val != "" {
+		config.System.EnableSSE = val == "true"
+	}
+	if val, err := s.store.GetConfig("system.maxSSEConnections"); err == nil {
+		if v, e := strconv.Atoi(val); e == nil && v > 0 {
+			config.System.MaxSSEConnections = v
+		}
+	}
 
 	return config, nil
 }
 
+// GetKeyboardShortcuts returns the global hotkey bound to each action.
+// Actions without a stored shortcut fall back to the defaults.
+func (s *ConfigService) GetKeyboardShortcuts() (map[string]string, error) {
+	shortcuts := s.getDefaultKeyboardShortcuts()
+
+	all, err := s.store.GetAllConfig()
+	if err != nil {
+		return nil, err
+	}
+	for _, action := range KeyboardShortcutActions {
+		// Check presence rather than non-empty so a cleared shortcut stays disabled
+		if val, ok := all["shortcuts."+action]; ok {
+			shortcuts[action] = val
+		}
+	}
+
+	return shortcuts, nil
+}
+
+// SetKeyboardShortcut binds a global hotkey to an action.
+// Pass an empty shortcut to disable the action.
+func (s *ConfigService) SetKeyboardShortcut(action, shortcut string) error {
+	if !isKeyboardShortcutAction(action) {
+		return fmt.Errorf("unknown shortcut action: %s", action)
+	}
+
+	shortcut = strings.TrimSpace(shortcut)
+	if shortcut != "" {
+		hk, err := platform.ParseHotkey(shortcut)
+		if err != nil {
+			return err
+		}
+		shortcut = hk.String()
+
+		current, err := s.GetKeyboardShortcuts()
+		if err != nil {
+			return err
+		}
+		for other, existing := range current {
+			if other != action && strings.EqualFold(existing, shortcut) {
+				return fmt.Errorf("shortcut %s is already assigned to %s", shortcut, other)
+			}
+		}
+	}
+
+	return s.store.SetConfig("shortcuts."+action, shortcut)
+}
+
+// GetObsidianVaultPath returns the folder Obsidian daily notes are exported to, or "" if unset.
+func (s *ConfigService) GetObsidianVaultPath() string {
+	val, _ := s.store.GetConfig("obsidian.vaultPath")
+	return val
+}
+
+// SetObsidianVaultPath sets the folder Obsidian daily notes are exported to.
+// The folder must exist. Pass an empty path to disable export.
+func (s *ConfigService) SetObsidianVaultPath(path string) error {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return s.store.DeleteConfig("obsidian.vaultPath")
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("vault folder not found: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a folder", path)
+	}
+	return s.store.SetConfig("obsidian.vaultPath", path)
+}
+
+func isKeyboardShortcutAction(action string) bool {
+	for _, a := range KeyboardShortcutActions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateConfig updates configuration values.
 // It handles nested objects by flattening them to dot-notation keys.
 func (s *ConfigService) UpdateConfig(updates map[string]interface{}) error {
@@ -445,7 +692,11 @@ func (s *ConfigService) UpdateConfig(updates map[string]interface{}) error {
 		case bool:
 			strVal = strconv.FormatBool(v)
 		case float64:
-			strVal = strconv.Itoa(int(v))
+			if v == math.Trunc(v) {
+				strVal = strconv.Itoa(int(v))
+			} else {
+				strVal = strconv.FormatFloat(v, 'f', -1, 64)
+			}
 		case int:
 			strVal = strconv.Itoa(v)
 		case []string:
@@ -483,6 +734,23 @@ func (s *ConfigService) handleConfigSideEffect(key string, value interface{}) er
 		if err := s.platform.SetAutoStart(enabled); err != nil {
 			return fmt.Errorf("failed to set autostart: %w", err)
 		}
+	case "capture.interval":
+		// Apply the new interval to the running daemon without a restart
+		if s.daemon == nil {
+			return nil
+		}
+		var seconds int
+		switch v := value.(type) {
+		case float64:
+			seconds = int(v)
+		case int:
+			seconds = v
+		case string:
+			seconds, _ = strconv.Atoi(v)
+		}
+		if err := s.daemon.SetCaptureInterval(seconds); err != nil {
+			return fmt.Errorf("failed to set capture interval: %w", err)
+		}
 	case "inference.engine", "inference.bundled.model", "inference.ollama.host", "inference.ollama.model", "inference.cloud.provider", "inference.cloud.apiKey", "inference.cloud.model", "inference.cloud.endpoint":
 		if s.updateInference != nil {
 			config, err := s.GetConfig()
@@ -511,80 +779,105 @@ func flattenUpdates(prefix string, input map[string]interface{}, output map[stri
 	}
 }
 
+// configStorageKeys maps frontend config keys to storage keys.
+var configStorageKeys = map[string]string{
+	// Capture settings
+	"capture.enabled":            "capture.enabled",
+	"capture.intervalSeconds":    "capture.interval",
+	"tracking.captureInterval":   "capture.interval",
+	"capture.quality":            "capture.quality",
+	"capture.duplicateThreshold": "capture.duplicateThreshold",
+	"capture.monitorMode":        "capture.monitorMode",
+	"capture.monitorIndex":       "capture.monitorIndex",
+	"capture.adaptiveQuality":    "capture.adaptiveQuality",
+	"capture.minQuality":         "capture.minQuality",
+
+	// AFK settings
+	"afk.timeoutSeconds":             "afk.timeout",
+	"afk.minSessionMinutes":          "afk.minSessionMinutes",
+	"afk.minSessionDurationSeconds":  "afk.minSessionDurationSeconds",
+	"afk.returnNotificationsEnabled": "afk.returnNotifications",
+
+	// UI settings
+	"ui.theme":             "ui.theme",
+	"ui.showNotifications": "ui.showNotifications",
+	"ui.startMinimized":    "ui.startMinimized",
+
+	// System settings
+	"system.startOnLogin":      "system.startOnLogin",
+	"system.autoStart":         "system.autoStart",
+	"system.enableSSE":         "system.enableSSE",
+	"system.maxSSEConnections": "system.maxSSEConnections",
+
+	// Data sources
+	"dataSources.shell.enabled":            "shell.enabled",
+	"dataSources.shell.shellType":          "shell.shellType",
+	"dataSources.shell.historyPath":        "shell.historyPath",
+	"dataSources.shell.excludePatterns":    "shell.excludePatterns",
+	"dataSources.git.enabled":              "git.enabled",
+	"dataSources.git.searchPaths":          "git.searchPaths",
+	"dataSources.git.maxDepth":             "git.maxDepth",
+	"dataSources.files.enabled":            "files.enabled",
+	"dataSources.files.excludePatterns":    "files.excludePatterns",
+	"dataSources.browser.enabled":          "browser.enabled",
+	"dataSources.browser.browsers":         "browser.browsers",
+	"dataSources.browser.excludedDomains":  "browser.excludedDomains",
+	"dataSources.browser.historyLimitDays": "browser.historyLimitDays",
+	"dataSources.clipboard.enabled":        "clipboard.enabled",
+	"dataSources.network.enabled":          "network.enabled",
+	"dataSources.audio.enabled":            "audio.enabled",
+
+	// Inference settings
+	"inference.engine":         "inference.engine",
+	"inference.bundled.model":  "inference.bundled.model",
+	"inference.ollama.host":    "inference.ollama.host",
+	"inference.ollama.model":   "inference.ollama.model",
+	"inference.cloud.provider": "inference.cloud.provider",
+	"inference.cloud.apiKey":   "inference.cloud.apiKey",
+	"inference.cloud.model":    "inference.cloud.model",
+	"inference.cloud.endpoint": "inference.cloud.endpoint",
+
+	// Issues settings
+	"issues.crashReportingEnabled": "issues.crashReportingEnabled",
+	"issues.webhookEnabled":        "issues.webhookEnabled",
+	"issues.webhookUrl":            "issues.webhookUrl",
+
+	// Update settings
+	"update.autoUpdate":         "update.autoUpdate",
+	"update.checkIntervalHours": "update.checkIntervalHours",
+	"update.afkRestartMinutes":  "update.afkRestartMinutes",
+	"update.canaryEnabled":      "update.canaryEnabled",
+	"update.canaryFraction":     "update.canaryFraction",
+
+	// Timeline settings
+	"timeline.minActivityDurationSeconds": "timeline.minActivityDurationSeconds",
+	"timeline.titleDisplay":               "timeline.titleDisplay",
+	"timeline.appGrouping":                "timeline.appGrouping",
+	"timeline.continuityMergeSeconds":     "timeline.continuityMergeSeconds",
+	"timeline.visibleColumns":             "timeline.visibleColumns",
+
+	// AI settings
+	"ai.summaryMode":         "ai.summaryMode",
+	"ai.summaryChunkMinutes": "ai.summaryChunkMinutes",
+	"ai.assignmentMode":      "ai.assignmentMode",
+	"ai.annotateScreenshots": "ai.annotateScreenshots",
+
+	// Focus goal settings
+	"focusGoal.dailyDeepWorkMinutes": "focusGoal.dailyDeepWorkMinutes",
+	"focusGoal.notifyOnAchievement":  "focusGoal.notifyOnAchievement",
+
+	// Productivity settings
+	"productivity.contextSwitchPenaltySeconds": "productivity.contextSwitchPenaltySeconds",
+	"productivity.maxDailyDistractionMinutes":  "productivity.maxDailyDistractionMinutes",
+
+	// Obsidian settings (the vault path is set with SetObsidianVaultPath)
+	"obsidian.autoExportNotes": "obsidian.autoExportNotes",
+}
+
 // mapToStorageKey maps frontend config keys to storage keys.
 // Returns empty string for unknown keys.
 func mapToStorageKey(frontendKey string) string {
-	keyMap := map[string]string{
-		// Capture settings
-		"capture.enabled":            "capture.enabled",
-		"capture.intervalSeconds":    "capture.interval",
-		"capture.quality":            "capture.quality",
-		"capture.duplicateThreshold": "capture.duplicateThreshold",
-		"capture.monitorMode":        "capture.monitorMode",
-		"capture.monitorIndex":       "capture.monitorIndex",
-
-		// AFK settings
-		"afk.timeoutSeconds":    "afk.timeout",
-		"afk.minSessionMinutes": "afk.minSessionMinutes",
-
-		// UI settings
-		"ui.theme":             "ui.theme",
-		"ui.showNotifications": "ui.showNotifications",
-		"ui.startMinimized":    "ui.startMinimized",
-
-		// System settings
-		"system.startOnLogin": "system.startOnLogin",
-		"system.autoStart":    "system.autoStart",
-
-		// Data sources
-		"dataSources.shell.enabled":            "shell.enabled",
-		"dataSources.shell.shellType":          "shell.shellType",
-		"dataSources.shell.historyPath":        "shell.historyPath",
-		"dataSources.shell.excludePatterns":    "shell.excludePatterns",
-		"dataSources.git.enabled":              "git.enabled",
-		"dataSources.git.searchPaths":          "git.searchPaths",
-		"dataSources.git.maxDepth":             "git.maxDepth",
-		"dataSources.files.enabled":            "files.enabled",
-		"dataSources.files.excludePatterns":    "files.excludePatterns",
-		"dataSources.browser.enabled":          "browser.enabled",
-		"dataSources.browser.browsers":         "browser.browsers",
-		"dataSources.browser.excludedDomains":  "browser.excludedDomains",
-		"dataSources.browser.historyLimitDays": "browser.historyLimitDays",
-
-		// Inference settings
-		"inference.engine":         "inference.engine",
-		"inference.bundled.model":  "inference.bundled.model",
-		"inference.ollama.host":    "inference.ollama.host",
-		"inference.ollama.model":   "inference.ollama.model",
-		"inference.cloud.provider": "inference.cloud.provider",
-		"inference.cloud.apiKey":   "inference.cloud.apiKey",
-		"inference.cloud.model":    "inference.cloud.model",
-		"inference.cloud.endpoint": "inference.cloud.endpoint",
-
-		// Issues settings
-		"issues.crashReportingEnabled": "issues.crashReportingEnabled",
-		"issues.webhookEnabled":        "issues.webhookEnabled",
-		"issues.webhookUrl":            "issues.webhookUrl",
-
-		// Update settings
-		"update.autoUpdate":         "update.autoUpdate",
-		"update.checkIntervalHours": "update.checkIntervalHours",
-		"update.afkRestartMinutes":  "update.afkRestartMinutes",
-
-		// Timeline settings
-		"timeline.minActivityDurationSeconds": "timeline.minActivityDurationSeconds",
-		"timeline.titleDisplay":               "timeline.titleDisplay",
-		"timeline.appGrouping":                "timeline.appGrouping",
-		"timeline.continuityMergeSeconds":     "timeline.continuityMergeSeconds",
-		"timeline.visibleColumns":             "timeline.visibleColumns",
-
-		// AI settings
-		"ai.summaryMode":         "ai.summaryMode",
-		"ai.summaryChunkMinutes": "ai.summaryChunkMinutes",
-		"ai.assignmentMode":      "ai.assignmentMode",
-	}
-
-	if storageKey, ok := keyMap[frontendKey]; ok {
+	if storageKey, ok := configStorageKeys[frontendKey]; ok {
 		return storageKey
 	}
 	return ""
@@ -609,6 +902,13 @@ func (s *ConfigService) GetDaemonStatus() (*DaemonStatus, error) {
 	}
 	daemonStatus.SessionDuration = int64(status.SessionDuration.Seconds())
 
+	health := s.daemon.GetComponentHealthSummary()
+	daemonStatus.ScreenshotCapture = health[storage.DataSourceScreenshots]
+	daemonStatus.GitPoller = health[storage.DataSourceGit]
+	daemonStatus.FileWatcher = health[storage.DataSourceFiles]
+	daemonStatus.BrowserSync = health[storage.DataSourceBrowser]
+	daemonStatus.ShellTracker = health[storage.DataSourceShell]
+
 	return daemonStatus, nil
 }
 
@@ -673,6 +973,24 @@ func (s *ConfigService) RestartDaemon() error {
 		DataDir:            s.platform.DataDir(),
 		MonitorMode:        config.Capture.MonitorMode,
 		MonitorIndex:       config.Capture.MonitorIndex,
+
+		CompressionEnabled:          true,
+		CompressionThresholdSeconds: tracker.DefaultCompressionThresholdSeconds,
+
+		MinSessionDurationSeconds: config.AFK.MinSessionDurationSeconds,
+
+		AdaptiveCaptureQuality: config.Capture.AdaptiveQuality,
+		MinCaptureQuality:      config.Capture.MinQuality,
+		CPULoadThreshold:       tracker.DefaultCPULoadThreshold,
+	}
+	if config.DataSources != nil && config.DataSources.Clipboard != nil {
+		daemonConfig.ClipboardTracking = config.DataSources.Clipboard.Enabled
+	}
+	if config.DataSources != nil && config.DataSources.Network != nil {
+		daemonConfig.NetworkTrackingEnabled = config.DataSources.Network.Enabled
+	}
+	if config.DataSources != nil && config.DataSources.Audio != nil {
+		daemonConfig.AudioTrackingEnabled = config.DataSources.Audio.Enabled
 	}
 	s.daemon.UpdateConfig(daemonConfig)
 
@@ -734,6 +1052,56 @@ func (s *ConfigService) GetStorageStats() (*StorageStats, error) {
 	return stats, nil
 }
 
+// usageStatsCacheTTL is how long GetUsageStatistics results are reused.
+const usageStatsCacheTTL = 5 * time.Minute
+
+// GetUsageStatistics returns aggregate counts over the whole database. The counts change
+// slowly, so results are cached for five minutes.
+func (s *ConfigService) GetUsageStatistics() (*LifetimeStats, error) {
+	s.usageMu.Lock()
+	defer s.usageMu.Unlock()
+	if s.usageStats != nil && time.Now().Before(s.usageStatsExpiry) {
+		return s.usageStats, nil
+	}
+
+	stats := &LifetimeStats{}
+	days, err := s.store.CountTrackedDays()
+	if err != nil {
+		return nil, err
+	}
+	stats.DaysTracked = int(days)
+
+	focusSeconds, err := s.store.GetTotalFocusSeconds()
+	if err != nil {
+		return nil, err
+	}
+	stats.TotalActiveHours = focusSeconds / 3600
+
+	stats.TotalScreenshots, _ = s.store.CountScreenshots()
+	stats.TotalCommits, _ = s.store.CountGitCommits()
+	stats.TotalShellCommands, _ = s.store.CountShellCommands()
+	stats.TotalBrowserVisits, _ = s.store.CountBrowserVisits()
+	stats.TotalSessions, _ = s.store.CountSessions()
+
+	oldest, newest, err := s.store.GetDataTimeRange()
+	if err != nil {
+		return nil, err
+	}
+	if oldest > 0 {
+		stats.OldestDataDate = time.Unix(oldest, 0).Format("2006-01-02")
+		stats.NewestDataDate = time.Unix(newest, 0).Format("2006-01-02")
+	}
+
+	apps, _ := s.store.CountUniqueApps()
+	stats.UniqueAppsTracked = int(apps)
+	domains, _ := s.store.CountUniqueDomains()
+	stats.UniqueDomainsVisited = int(domains)
+
+	s.usageStats = stats
+	s.usageStatsExpiry = time.Now().Add(usageStatsCacheTTL)
+	return stats, nil
+}
+
 // calculateDirSize recursively calculates the total size of a directory.
 func calculateDirSize(path string) int64 {
 	var size int64
@@ -755,6 +1123,22 @@ func (s *ConfigService) OptimizeDatabase() (int64, error) {
 	return s.store.Optimize()
 }
 
+// ArchiveDatabase writes a compacted, Zstandard-compressed copy of the database to destPath.
+// If destPath is empty, the archive is written to an "archives" folder next to the database.
+// Returns the size reduction in bytes compared to the live database.
+func (s *ConfigService) ArchiveDatabase(destPath string) (int64, error) {
+	if destPath == "" {
+		name := fmt.Sprintf("traq-%s.db.zst", time.Now().Format("2006-01-02-150405"))
+		destPath = filepath.Join(filepath.Dir(s.store.Path()), "archives", name)
+	}
+	return s.store.VacuumIntoCompressedFile(destPath)
+}
+
+// EstimateCompressedSize estimates the size of a compressed database archive in bytes.
+func (s *ConfigService) EstimateCompressedSize() (int64, error) {
+	return s.store.EstimateCompressedSize()
+}
+
 // StorageStats contains database statistics.
 type StorageStats struct {
 	ScreenshotCount   int64 `json:"screenshotCount"`
@@ -768,6 +1152,21 @@ type StorageStats struct {
 	ScreenshotsSize   int64 `json:"screenshotsSize"` // bytes
 }
 
+// LifetimeStats contains aggregate counts over all tracked data.
+type LifetimeStats struct {
+	DaysTracked          int     `json:"daysTracked"` // Days with at least one session
+	TotalActiveHours     float64 `json:"totalActiveHours"`
+	TotalScreenshots     int64   `json:"totalScreenshots"`
+	TotalCommits         int64   `json:"totalCommits"`
+	TotalShellCommands   int64   `json:"totalShellCommands"`
+	TotalBrowserVisits   int64   `json:"totalBrowserVisits"`
+	TotalSessions        int64   `json:"totalSessions"`
+	OldestDataDate       string  `json:"oldestDataDate"` // YYYY-MM-DD, "" if nothing is tracked
+	NewestDataDate       string  `json:"newestDataDate"`
+	UniqueAppsTracked    int     `json:"uniqueAppsTracked"`
+	UniqueDomainsVisited int     `json:"uniqueDomainsVisited"`
+}
+
 func (s *ConfigService) getDefaultCaptureConfig() *CaptureConfig {
 	return &CaptureConfig{
 		Enabled:            true,
@@ -776,13 +1175,16 @@ func (s *ConfigService) getDefaultCaptureConfig() *CaptureConfig {
 		DuplicateThreshold: 3,
 		MonitorMode:        "active_window", // Default: follow active window
 		MonitorIndex:       0,
+		MinQuality:         tracker.DefaultMinCaptureQuality,
 	}
 }
 
 func (s *ConfigService) getDefaultAFKConfig() *AFKConfig {
 	return &AFKConfig{
-		TimeoutSeconds:    180,
-		MinSessionMinutes: 5,
+		TimeoutSeconds:             180,
+		MinSessionMinutes:          5,
+		MinSessionDurationSeconds:  tracker.DefaultMinSessionDurationSeconds,
+		ReturnNotificationsEnabled: true,
 	}
 }
 
@@ -810,6 +1212,15 @@ func (s *ConfigService) getDefaultDataSourcesConfig() *DataSourcesConfig {
 			Browsers:         []string{"chrome", "firefox"},
 			HistoryLimitDays: 7, // Default to 7 days of history
 		},
+		Clipboard: &ClipboardConfig{
+			Enabled: false, // Opt-in for privacy
+		},
+		Network: &NetworkConfig{
+			Enabled: false, // Opt-in for privacy
+		},
+		Audio: &AudioConfig{
+			Enabled: false, // Opt-in for privacy
+		},
 	}
 }
 
@@ -823,9 +1234,10 @@ func (s *ConfigService) getDefaultUIConfig() *UIConfig {
 
 func (s *ConfigService) getDefaultSystemConfig() *SystemConfig {
 	return &SystemConfig{
-		AutoStart:    true,
-		StartOnLogin: true,
-		DataDir:      s.platform.DataDir(),
+		AutoStart:         true,
+		StartOnLogin:      true,
+		DataDir:           s.platform.DataDir(),
+		MaxSSEConnections: DefaultMaxSSEConnections,
 	}
 }
 
@@ -853,6 +1265,7 @@ func (s *ConfigService) getDefaultUpdateConfig() *UpdateConfig {
 		AutoUpdate:         true,
 		CheckIntervalHours: 5,
 		AFKRestartMinutes:  10,
+		CanaryFraction:     1,
 	}
 }
 
@@ -866,10 +1279,28 @@ func (s *ConfigService) getDefaultTimelineConfig() *TimelineConfig {
 	}
 }
 
+// getDefaultKeyboardShortcuts returns every action unbound. Global hotkeys are opt-in,
+// since any default would take the keys from other apps.
+func (s *ConfigService) getDefaultKeyboardShortcuts() map[string]string {
+	shortcuts := make(map[string]string, len(KeyboardShortcutActions))
+	for _, action := range KeyboardShortcutActions {
+		shortcuts[action] = ""
+	}
+	return shortcuts
+}
+
+func (s *ConfigService) getDefaultFocusGoalConfig() *FocusGoalConfig {
+	return &FocusGoalConfig{
+		DailyDeepWorkMinutes: 120, // Default: two hours of deep work per day
+		NotifyOnAchievement:  true,
+	}
+}
+
 func (s *ConfigService) getDefaultAIConfig() *AIConfig {
 	return &AIConfig{
 		SummaryMode:         "drafts", // Default: require approval for AI summaries
 		SummaryChunkMinutes: 15,       // Default: summarize in 15-minute chunks
 		AssignmentMode:      "drafts", // Default: require approval for project assignments
+		AnnotateScreenshots: false,    // Default: only user annotations
 	}
 }
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"traq/internal/platform"
+)
+
+// redactedConfigValue replaces sensitive values in exported config.
+const redactedConfigValue = "***"
+
+// sensitiveConfigKeys are the storage keys redacted by ExportConfig. On import a
+// redacted value is ignored, so the existing secret is kept.
+var sensitiveConfigKeys = map[string]bool{
+	"inference.cloud.apiKey": true,
+	"issues.webhookUrl":      true,
+}
+
+// ExportConfig returns the full configuration as indented JSON for backup.
+// API keys and webhook URLs are replaced with "***".
+func (s *ConfigService) ExportConfig() (string, error) {
+	config, err := s.GetConfig()
+	if err != nil {
+		return "", err
+	}
+
+	if config.Inference != nil && config.Inference.Cloud != nil && config.Inference.Cloud.APIKey != "" {
+		config.Inference.Cloud.APIKey = redactedConfigValue
+	}
+	if config.Issues != nil && config.Issues.WebhookUrl != "" {
+		config.Issues.WebhookUrl = redactedConfigValue
+	}
+
+	data, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal config: %w", err)
+	}
+	return string(data), nil
+}
+
+// ImportConfig loads configuration exported by ExportConfig.
+// Mode is "merge" (only fields present in the JSON are updated) or "replace"
+// (all settings are reset to their defaults before the JSON is applied).
+// The JSON must match the Config schema; unknown fields are rejected.
+// The Obsidian vault path is only imported if the folder exists on this machine.
+// The tracking daemon is restarted if capture, AFK or data source settings changed.
+func (s *ConfigService) ImportConfig(data string, mode string) error {
+	if mode != "merge" && mode != "replace" {
+		return fmt.Errorf("invalid import mode: %s (must be merge or replace)", mode)
+	}
+
+	// Validate against the Config schema before anything is written
+	var typed Config
+	dec := json.NewDecoder(strings.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&typed); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	shortcuts, err := normalizeImportedShortcuts(typed.KeyboardShortcuts)
+	if err != nil {
+		return err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &raw); err != nil {
+		return fmt.Errorf("invalid config: %w", err)
+	}
+	delete(raw, "keyboardShortcuts")
+	vaultPath, hasVaultPath := popObsidianVaultPath(raw)
+
+	updates := make(map[string]interface{})
+	flattenUpdates("", raw, updates)
+	for key, value := range updates {
+		if sensitiveConfigKeys[mapToStorageKey(key)] && value == redactedConfigValue {
+			delete(updates, key)
+		}
+	}
+
+	before, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+
+	if mode == "replace" {
+		if err := s.resetConfig(updates); err != nil {
+			return err
+		}
+	}
+
+	if err := s.UpdateConfig(updates); err != nil {
+		return err
+	}
+	for action, shortcut := range shortcuts {
+		if err := s.store.SetConfig("shortcuts."+action, shortcut); err != nil {
+			return err
+		}
+	}
+	if hasVaultPath {
+		if info, err := os.Stat(vaultPath); vaultPath == "" || (err == nil && info.IsDir()) {
+			if err := s.SetObsidianVaultPath(vaultPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	after, err := s.GetConfig()
+	if err != nil {
+		return err
+	}
+	if trackingConfigChanged(before, after) {
+		return s.RestartDaemon()
+	}
+	return nil
+}
+
+// resetConfig deletes every stored setting and shortcut so defaults apply, keeping
+// sensitive values the import doesn't set.
+func (s *ConfigService) resetConfig(updates map[string]interface{}) error {
+	imported := make(map[string]bool, len(updates))
+	for key := range updates {
+		imported[mapToStorageKey(key)] = true
+	}
+
+	for _, storageKey := range configStorageKeys {
+		if sensitiveConfigKeys[storageKey] && !imported[storageKey] {
+			continue
+		}
+		if err := s.store.DeleteConfig(storageKey); err != nil {
+			return err
+		}
+	}
+	for _, action := range KeyboardShortcutActions {
+		if err := s.store.DeleteConfig("shortcuts." + action); err != nil {
+			return err
+		}
+	}
+	return s.store.DeleteConfig("obsidian.vaultPath")
+}
+
+// normalizeImportedShortcuts validates imported hotkeys and returns them in canonical form.
+func normalizeImportedShortcuts(shortcuts map[string]string) (map[string]string, error) {
+	normalized := make(map[string]string, len(shortcuts))
+	used := make(map[string]string)
+	for action, shortcut := range shortcuts {
+		if !isKeyboardShortcutAction(action) {
+			return nil, fmt.Errorf("unknown shortcut action: %s", action)
+		}
+		shortcut = strings.TrimSpace(shortcut)
+		if shortcut != "" {
+			hk, err := platform.ParseHotkey(shortcut)
+			if err != nil {
+				return nil, err
+			}
+			shortcut = hk.String()
+			if other, ok := used[strings.ToLower(shortcut)]; ok {
+				return nil, fmt.Errorf("shortcut %s is assigned to both %s and %s", shortcut, other, action)
+			}
+			used[strings.ToLower(shortcut)] = action
+		}
+		normalized[action] = shortcut
+	}
+	return normalized, nil
+}
+
+// popObsidianVaultPath removes obsidian.vaultPath from raw config, which is set
+// with SetObsidianVaultPath rather than UpdateConfig.
+func popObsidianVaultPath(raw map[string]interface{}) (string, bool) {
+	obsidian, ok := raw["obsidian"].(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	val, ok := obsidian["vaultPath"]
+	if !ok {
+		return "", false
+	}
+	delete(obsidian, "vaultPath")
+	path, _ := val.(string)
+	return path, true
+}
+
+// trackingConfigChanged reports whether settings used by the tracking daemon differ.
+func trackingConfigChanged(before, after *Config) bool {
+	return !reflect.DeepEqual(before.Capture, after.Capture) ||
+		!reflect.DeepEqual(before.AFK, after.AFK) ||
+		!reflect.DeepEqual(before.DataSources, after.DataSources)
+}
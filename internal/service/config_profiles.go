@@ -0,0 +1,186 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+// profileConfigKeys are the storage config keys saved in a profile. Settings outside
+// this list, such as inference or UI preferences, are shared by all profiles.
+var profileConfigKeys = []string{
+	"capture.enabled",
+	"capture.interval",
+	"capture.quality",
+	"capture.monitorMode",
+	"capture.monitorIndex",
+	"afk.timeout",
+	"shell.enabled",
+	"shell.excludePatterns",
+	"git.enabled",
+	"files.enabled",
+	"files.excludePatterns",
+	"browser.enabled",
+	"browser.excludedDomains",
+	"clipboard.enabled",
+	"network.enabled",
+	"focusGoal.dailyDeepWorkMinutes",
+}
+
+// ProfileConfig is the config_json document stored with a profile.
+type ProfileConfig struct {
+	// Settings maps storage config keys to values. An empty value means the default.
+	Settings map[string]string `json:"settings,omitempty"`
+	// CategoryRules is a categorization export document (app categories and timeline rules).
+	CategoryRules json.RawMessage `json:"categoryRules,omitempty"`
+}
+
+// SetProfileSwitchHandler registers a callback invoked after the active profile changes.
+func (s *ConfigService) SetProfileSwitchHandler(handler func(*storage.Profile)) {
+	s.onProfileSwitch = handler
+}
+
+// GetProfiles returns all profiles.
+func (s *ConfigService) GetProfiles() ([]*storage.Profile, error) {
+	return s.store.GetProfiles()
+}
+
+// GetCurrentProfile returns the active profile, or nil if none is active.
+func (s *ConfigService) GetCurrentProfile() (*storage.Profile, error) {
+	return s.store.GetActiveProfile()
+}
+
+// CreateProfile creates a profile from the current settings. It doesn't become active.
+func (s *ConfigService) CreateProfile(name string) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, fmt.Errorf("profile name is required")
+	}
+
+	configJSON, err := s.snapshotProfileConfig()
+	if err != nil {
+		return 0, err
+	}
+	return s.store.CreateProfile(name, configJSON)
+}
+
+// SwitchProfile activates a profile. The live settings are first saved to the outgoing
+// profile, then the new profile's settings are merged into the live config.
+func (s *ConfigService) SwitchProfile(profileID int64) error {
+	profile, err := s.store.GetProfile(profileID)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("profile %d not found", profileID)
+	}
+	if profile.IsActive {
+		return nil
+	}
+
+	current, err := s.store.GetActiveProfile()
+	if err != nil {
+		return err
+	}
+	if current != nil {
+		configJSON, err := s.snapshotProfileConfig()
+		if err != nil {
+			return err
+		}
+		if err := s.store.UpdateProfileConfig(current.ID, configJSON); err != nil {
+			return err
+		}
+	}
+
+	if err := s.applyProfileConfig(profile.ConfigJSON); err != nil {
+		return fmt.Errorf("failed to apply profile %s: %w", profile.Name, err)
+	}
+	if err := s.store.SetActiveProfile(profile.ID); err != nil {
+		return err
+	}
+
+	profile.IsActive = true
+	if s.onProfileSwitch != nil {
+		s.onProfileSwitch(profile)
+	}
+	return nil
+}
+
+// DeleteProfile deletes an inactive profile.
+func (s *ConfigService) DeleteProfile(profileID int64) error {
+	profile, err := s.store.GetProfile(profileID)
+	if err != nil {
+		return err
+	}
+	if profile == nil {
+		return fmt.Errorf("profile %d not found", profileID)
+	}
+	if profile.IsActive {
+		return fmt.Errorf("cannot delete the active profile")
+	}
+	return s.store.DeleteProfile(profileID)
+}
+
+// snapshotProfileConfig captures the live profile settings and category rules as config_json.
+func (s *ConfigService) snapshotProfileConfig() (string, error) {
+	pc := ProfileConfig{Settings: make(map[string]string, len(profileConfigKeys))}
+	for _, key := range profileConfigKeys {
+		val, err := s.store.GetConfig(key)
+		if err != nil {
+			return "", err
+		}
+		pc.Settings[key] = val
+	}
+
+	rules, err := s.store.ExportCategorizationRules()
+	if err != nil {
+		return "", err
+	}
+	pc.CategoryRules = json.RawMessage(rules)
+
+	data, err := json.Marshal(pc)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal profile config: %w", err)
+	}
+	return string(data), nil
+}
+
+// applyProfileConfig merges a profile's config_json into the live config.
+// Only settings present in the profile are replaced.
+func (s *ConfigService) applyProfileConfig(configJSON string) error {
+	var pc ProfileConfig
+	if err := json.Unmarshal([]byte(configJSON), &pc); err != nil {
+		return fmt.Errorf("invalid profile config: %w", err)
+	}
+
+	for _, key := range profileConfigKeys {
+		val, ok := pc.Settings[key]
+		if !ok {
+			continue
+		}
+		if val == "" {
+			if err := s.store.DeleteConfig(key); err != nil {
+				return err
+			}
+		} else if err := s.store.SetConfig(key, val); err != nil {
+			return err
+		}
+	}
+
+	if len(pc.CategoryRules) > 0 {
+		if _, err := s.store.ImportCategorizationRules(string(pc.CategoryRules), "replace"); err != nil {
+			return err
+		}
+	}
+
+	// Apply the capture interval to the running daemon, using the default if the profile reset it
+	if val, ok := pc.Settings["capture.interval"]; ok {
+		if val == "" {
+			return s.handleConfigSideEffect("capture.interval", s.getDefaultCaptureConfig().IntervalSeconds)
+		}
+		return s.handleConfigSideEffect("capture.interval", val)
+	}
+	return nil
+}
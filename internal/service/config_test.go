@@ -0,0 +1,352 @@
+package service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/platform"
+	"traq/internal/storage"
+)
+
+func setupConfigTest(t *testing.T) (*ConfigService, *storage.Store, func()) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "traq-test-*")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+
+	store, err := storage.NewStore(filepath.Join(dir, "test.db"))
+	if err != nil {
+		os.RemoveAll(dir)
+		t.Fatalf("failed to create store: %v", err)
+	}
+
+	service := NewConfigService(store, nil, nil)
+
+	cleanup := func() {
+		store.Close()
+		os.RemoveAll(dir)
+	}
+
+	return service, store, cleanup
+}
+
+func TestKeyboardShortcutDefaults(t *testing.T) {
+	service, _, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	shortcuts, err := service.GetKeyboardShortcuts()
+	if err != nil {
+		t.Fatalf("GetKeyboardShortcuts failed: %v", err)
+	}
+
+	// Hotkeys are opt-in, so every action is listed unbound
+	for _, action := range KeyboardShortcutActions {
+		if val, ok := shortcuts[action]; !ok || val != "" {
+			t.Errorf("expected %s to be listed without a shortcut, got %q", action, val)
+		}
+	}
+}
+
+func TestSetKeyboardShortcut(t *testing.T) {
+	service, _, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	// Shortcuts are normalized before being stored
+	if err := service.SetKeyboardShortcut("show_window", "alt+shift+w"); err != nil {
+		t.Fatalf("SetKeyboardShortcut failed: %v", err)
+	}
+
+	shortcuts, err := service.GetKeyboardShortcuts()
+	if err != nil {
+		t.Fatalf("GetKeyboardShortcuts failed: %v", err)
+	}
+	if shortcuts["show_window"] != "Shift+Alt+W" {
+		t.Errorf("expected show_window shortcut Shift+Alt+W, got %q", shortcuts["show_window"])
+	}
+
+	// Clearing a shortcut disables the action
+	if err := service.SetKeyboardShortcut("open_timeline", ""); err != nil {
+		t.Fatalf("SetKeyboardShortcut failed: %v", err)
+	}
+	shortcuts, _ = service.GetKeyboardShortcuts()
+	if shortcuts["open_timeline"] != "" {
+		t.Errorf("expected open_timeline to be disabled, got %q", shortcuts["open_timeline"])
+	}
+}
+
+func TestSetKeyboardShortcutValidation(t *testing.T) {
+	service, _, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	if err := service.SetKeyboardShortcut("launch_rockets", "Ctrl+Shift+R"); err == nil {
+		t.Error("expected error for unknown action")
+	}
+	if err := service.SetKeyboardShortcut("force_capture", "P"); err == nil {
+		t.Error("expected error for shortcut without modifiers")
+	}
+	if err := service.SetKeyboardShortcut("force_capture", "Hyper+P"); err == nil {
+		t.Error("expected error for unknown modifier")
+	}
+	if err := service.SetKeyboardShortcut("show_window", "Ctrl+Shift+T"); err != nil {
+		t.Fatalf("SetKeyboardShortcut failed: %v", err)
+	}
+	if err := service.SetKeyboardShortcut("force_capture", "ctrl+shift+t"); err == nil {
+		t.Error("expected error for shortcut already assigned to another action")
+	}
+}
+
+func TestSwitchProfile(t *testing.T) {
+	service, store, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	work, err := service.GetCurrentProfile()
+	if err != nil || work == nil || work.Name != "work" {
+		t.Fatalf("expected work to be the active profile, got %+v (err %v)", work, err)
+	}
+	var personal *storage.Profile
+	profiles, _ := service.GetProfiles()
+	for _, p := range profiles {
+		if p.Name == "personal" {
+			personal = p
+		}
+	}
+	if personal == nil {
+		t.Fatal("expected seeded personal profile")
+	}
+
+	var switched []string
+	service.SetProfileSwitchHandler(func(p *storage.Profile) { switched = append(switched, p.Name) })
+
+	store.SetConfig("capture.interval", "60")
+
+	// Personal has no settings yet, so it starts from the live config
+	if err := service.SwitchProfile(personal.ID); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	store.SetConfig("capture.interval", "300")
+	store.SetConfig("shell.excludePatterns", `["^ssh "]`)
+
+	// Switching back restores work's settings and resets ones it never set
+	if err := service.SwitchProfile(work.ID); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	if val, _ := store.GetConfig("capture.interval"); val != "60" {
+		t.Errorf("expected work interval 60, got %q", val)
+	}
+	if val, _ := store.GetConfig("shell.excludePatterns"); val != "" {
+		t.Errorf("expected work exclude patterns to be reset, got %q", val)
+	}
+
+	if err := service.SwitchProfile(personal.ID); err != nil {
+		t.Fatalf("SwitchProfile failed: %v", err)
+	}
+	if val, _ := store.GetConfig("capture.interval"); val != "300" {
+		t.Errorf("expected personal interval 300, got %q", val)
+	}
+	if len(switched) != 3 || switched[2] != "personal" {
+		t.Errorf("expected switch handler calls for each switch, got %v", switched)
+	}
+
+	// New profiles snapshot the live settings
+	id, err := service.CreateProfile("travel")
+	if err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	if _, err := service.CreateProfile("  "); err == nil {
+		t.Error("expected error for empty profile name")
+	}
+
+	if err := service.DeleteProfile(personal.ID); err == nil {
+		t.Error("expected error deleting the active profile")
+	}
+	if err := service.DeleteProfile(id); err != nil {
+		t.Errorf("DeleteProfile failed: %v", err)
+	}
+}
+
+// configTestPlatform provides the platform calls config import and export make.
+type configTestPlatform struct {
+	platform.Platform
+	dataDir string
+}
+
+func (p *configTestPlatform) DataDir() string                 { return p.dataDir }
+func (p *configTestPlatform) SetAutoStart(enabled bool) error { return nil }
+
+func TestExportImportConfigRoundTrip(t *testing.T) {
+	source, _, cleanup := setupConfigTest(t)
+	defer cleanup()
+	source.platform = &configTestPlatform{dataDir: "/data"}
+
+	err := source.UpdateConfig(map[string]interface{}{
+		"capture":   map[string]interface{}{"intervalSeconds": float64(90), "quality": float64(65), "monitorMode": "primary"},
+		"afk":       map[string]interface{}{"timeoutSeconds": float64(240), "minSessionDurationSeconds": float64(60)},
+		"ui":        map[string]interface{}{"theme": "dark"},
+		"inference": map[string]interface{}{"engine": "cloud", "cloud": map[string]interface{}{"apiKey": "sk-secret", "model": "test-model"}},
+		"dataSources": map[string]interface{}{
+			"shell":   map[string]interface{}{"excludePatterns": []interface{}{"^ls", "^cd"}},
+			"browser": map[string]interface{}{"excludedDomains": []interface{}{"example.com"}, "historyLimitDays": float64(14)},
+		},
+		"timeline":  map[string]interface{}{"visibleColumns": []interface{}{"git", "shell"}, "appGrouping": true},
+		"focusGoal": map[string]interface{}{"dailyDeepWorkMinutes": float64(90)},
+	})
+	if err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	if err := source.SetKeyboardShortcut("show_window", "Ctrl+Alt+T"); err != nil {
+		t.Fatalf("SetKeyboardShortcut failed: %v", err)
+	}
+
+	exported, err := source.ExportConfig()
+	if err != nil {
+		t.Fatalf("ExportConfig failed: %v", err)
+	}
+	if strings.Contains(exported, "sk-secret") || !strings.Contains(exported, `"apiKey": "***"`) {
+		t.Error("expected the API key to be redacted")
+	}
+
+	target, _, cleanupTarget := setupConfigTest(t)
+	defer cleanupTarget()
+	target.platform = &configTestPlatform{dataDir: "/data"}
+	if err := target.UpdateConfig(map[string]interface{}{"ui": map[string]interface{}{"theme": "light"}}); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	if err := target.ImportConfig(exported, "replace"); err != nil {
+		t.Fatalf("ImportConfig failed: %v", err)
+	}
+
+	want, _ := source.GetConfig()
+	got, _ := target.GetConfig()
+	want.Inference.Cloud.APIKey = ""
+	if !reflect.DeepEqual(want, got) {
+		wantJSON, _ := json.Marshal(want)
+		gotJSON, _ := json.Marshal(got)
+		t.Errorf("config did not survive round trip:\nwant %s\n got %s", wantJSON, gotJSON)
+	}
+
+	// Importing a redacted export keeps the existing key
+	if err := source.ImportConfig(exported, "replace"); err != nil {
+		t.Fatalf("ImportConfig failed: %v", err)
+	}
+	if cfg, _ := source.GetConfig(); cfg.Inference.Cloud.APIKey != "sk-secret" {
+		t.Errorf("expected API key to be kept, got %q", cfg.Inference.Cloud.APIKey)
+	}
+}
+
+func TestImportConfigMergeAndValidation(t *testing.T) {
+	service, _, cleanup := setupConfigTest(t)
+	defer cleanup()
+	service.platform = &configTestPlatform{dataDir: "/data"}
+
+	if err := service.UpdateConfig(map[string]interface{}{
+		"capture": map[string]interface{}{"intervalSeconds": float64(90)},
+		"ui":      map[string]interface{}{"theme": "dark"},
+	}); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+
+	// Merge only touches fields present in the JSON
+	if err := service.ImportConfig(`{"ui": {"theme": "light"}}`, "merge"); err != nil {
+		t.Fatalf("ImportConfig(merge) failed: %v", err)
+	}
+	cfg, _ := service.GetConfig()
+	if cfg.UI.Theme != "light" || cfg.Capture.IntervalSeconds != 90 {
+		t.Errorf("expected theme light and interval 90, got %q and %d", cfg.UI.Theme, cfg.Capture.IntervalSeconds)
+	}
+
+	// Replace resets everything not in the JSON
+	if err := service.ImportConfig(`{"ui": {"theme": "dark"}}`, "replace"); err != nil {
+		t.Fatalf("ImportConfig(replace) failed: %v", err)
+	}
+	cfg, _ = service.GetConfig()
+	if cfg.UI.Theme != "dark" || cfg.Capture.IntervalSeconds != service.getDefaultCaptureConfig().IntervalSeconds {
+		t.Errorf("expected theme dark and default interval, got %q and %d", cfg.UI.Theme, cfg.Capture.IntervalSeconds)
+	}
+
+	invalid := []string{
+		`not json`,
+		`{"ui": {"colour": "red"}}`,
+		`{"capture": {"intervalSeconds": "fast"}}`,
+		`{"keyboardShortcuts": {"launch_rockets": "Ctrl+R"}}`,
+	}
+	for _, data := range invalid {
+		if err := service.ImportConfig(data, "merge"); err == nil {
+			t.Errorf("expected error importing %s", data)
+		}
+	}
+	if err := service.ImportConfig(`{}`, "overwrite"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+	if cfg, _ := service.GetConfig(); cfg.UI.Theme != "dark" {
+		t.Errorf("expected failed imports to leave config unchanged, got theme %q", cfg.UI.Theme)
+	}
+}
+
+func TestGetUsageStatistics(t *testing.T) {
+	service, store, cleanup := setupConfigTest(t)
+	defer cleanup()
+
+	stats, err := service.GetUsageStatistics()
+	if err != nil {
+		t.Fatalf("GetUsageStatistics failed: %v", err)
+	}
+	if stats.TotalSessions != 0 || stats.OldestDataDate != "" {
+		t.Errorf("expected empty stats for an empty database, got %+v", stats)
+	}
+	service.usageStats = nil // Drop the cached result
+
+	day1 := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.Local)
+	day2 := day1.AddDate(0, 0, 3)
+	for _, day := range []time.Time{day1, day1.Add(2 * time.Hour), day2} {
+		id, _ := store.CreateSession(day.Unix())
+		store.EndSession(id, day.Add(time.Hour).Unix())
+	}
+	for i, app := range []string{"code", "firefox", "code"} {
+		start := day1.Add(time.Duration(i) * time.Hour)
+		store.SaveFocusEvent(&storage.WindowFocusEvent{AppName: app, WindowTitle: "w", StartTime: start.Unix(), EndTime: start.Add(30 * time.Minute).Unix(), DurationSeconds: 1800})
+	}
+	store.SaveScreenshot(&storage.Screenshot{Timestamp: day1.Unix(), Filepath: "a.webp", DHash: "d:1"})
+	store.SaveScreenshot(&storage.Screenshot{Timestamp: day2.Unix(), Filepath: "b.webp", DHash: "d:2"})
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	store.SaveGitCommit(&storage.GitCommit{Timestamp: day1.Unix(), CommitHash: "abc", ShortHash: "abc", RepositoryID: repoID, Message: "Init"})
+	for i := 0; i < 4; i++ {
+		store.SaveShellCommand(&storage.ShellCommand{Timestamp: day1.Unix() + int64(i), Command: "make", ShellType: "bash"})
+	}
+	for i, domain := range []string{"github.com", "github.com", "go.dev"} {
+		store.SaveBrowserVisit(&storage.BrowserVisit{Timestamp: day2.Add(time.Duration(i) * time.Minute).Unix(), URL: "https://" + domain, Domain: domain, Browser: "chrome"})
+	}
+
+	stats, err = service.GetUsageStatistics()
+	if err != nil {
+		t.Fatalf("GetUsageStatistics failed: %v", err)
+	}
+	want := &LifetimeStats{
+		DaysTracked:          2,
+		TotalActiveHours:     1.5,
+		TotalScreenshots:     2,
+		TotalCommits:         1,
+		TotalShellCommands:   4,
+		TotalBrowserVisits:   3,
+		TotalSessions:        3,
+		OldestDataDate:       "2026-03-02",
+		NewestDataDate:       "2026-03-05",
+		UniqueAppsTracked:    2,
+		UniqueDomainsVisited: 2,
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("GetUsageStatistics = %+v, want %+v", stats, want)
+	}
+
+	// Results are cached
+	store.SaveShellCommand(&storage.ShellCommand{Timestamp: day2.Unix(), Command: "ls", ShellType: "bash"})
+	if cached, _ := service.GetUsageStatistics(); cached.TotalShellCommands != 4 {
+		t.Errorf("expected cached result, got %d shell commands", cached.TotalShellCommands)
+	}
+}
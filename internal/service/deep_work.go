@@ -0,0 +1,156 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"traq/internal/storage"
+)
+
+const (
+	// defaultDeepWorkMinutes is the minimum block length counted towards DayStats.DeepWorkMinutes.
+	defaultDeepWorkMinutes = 30
+	// deepWorkSwitchWindowSeconds is the window in which context switches are counted.
+	deepWorkSwitchWindowSeconds = 30 * 60
+	// deepWorkMaxSwitches is the number of switches within the window that breaks a block.
+	deepWorkMaxSwitches = 3
+	// deepWorkMaxGapSeconds is the longest gap between events that still continues a block.
+	deepWorkMaxGapSeconds = 5 * 60
+)
+
+// DeepWorkBlock is a stretch of focused work with few context switches.
+type DeepWorkBlock struct {
+	StartTime       int64  `json:"startTime"`
+	EndTime         int64  `json:"endTime"`
+	DurationMinutes int64  `json:"durationMinutes"`
+	PrimaryApp      string `json:"primaryApp"` // Friendly name of the app with the most time in the block
+	ContextSwitches int    `json:"contextSwitches"`
+}
+
+// GetDeepWorkBlocks detects deep-work blocks for a date (YYYY-MM-DD) lasting at least minMinutes.
+// A block is a contiguous run of "focus"-category events with fewer than 3 app switches
+// in any 30-minute window. If minMinutes <= 0, the default of 30 minutes is used.
+func (s *TimelineService) GetDeepWorkBlocks(date string, minMinutes int) ([]*DeepWorkBlock, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	if minMinutes <= 0 {
+		minMinutes = defaultDeepWorkMinutes
+	}
+
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+
+	focusEvents, err := s.store.GetFocusEventsByTimeRange(dayStart.Unix(), dayEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+
+	appNames := make(map[string]bool)
+	for _, evt := range focusEvents {
+		appNames[evt.AppName] = true
+	}
+	appNamesList := make([]string, 0, len(appNames))
+	for name := range appNames {
+		appNamesList = append(appNamesList, name)
+	}
+	categories, err := s.store.GetAppTimelineCategories(appNamesList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app categories: %w", err)
+	}
+
+	return detectDeepWorkBlocks(focusEvents, categories, minMinutes, dayStart.Unix(), dayEnd.Unix()), nil
+}
+
+// detectDeepWorkBlocks groups focus-category events into deep-work blocks.
+// A block ends at a non-focus event, a gap of 5+ minutes, or a third app switch within
+// 30 minutes (the switching event then starts a new block). Event times are clamped to the day.
+func detectDeepWorkBlocks(events []*storage.WindowFocusEvent, categories map[string]string, minMinutes int, dayStart, dayEnd int64) []*DeepWorkBlock {
+	sorted := make([]*storage.WindowFocusEvent, len(events))
+	copy(sorted, events)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartTime < sorted[j].StartTime })
+
+	var blocks []*DeepWorkBlock
+	var current []*storage.WindowFocusEvent
+	var switchTimes []int64
+
+	flush := func() {
+		if block := buildDeepWorkBlock(current, len(switchTimes), dayStart, dayEnd); block != nil && block.DurationMinutes >= int64(minMinutes) {
+			blocks = append(blocks, block)
+		}
+		current = nil
+		switchTimes = nil
+	}
+
+	for _, evt := range sorted {
+		if categories[evt.AppName] != "focus" {
+			flush()
+			continue
+		}
+
+		if len(current) > 0 {
+			last := current[len(current)-1]
+			if evt.StartTime-last.EndTime >= deepWorkMaxGapSeconds {
+				flush()
+			} else if evt.AppName != last.AppName {
+				// Count switches in the trailing window, including this one
+				recent := 1
+				for _, ts := range switchTimes {
+					if evt.StartTime-ts < deepWorkSwitchWindowSeconds {
+						recent++
+					}
+				}
+				if recent >= deepWorkMaxSwitches {
+					flush()
+				} else {
+					switchTimes = append(switchTimes, evt.StartTime)
+				}
+			}
+		}
+		current = append(current, evt)
+	}
+	flush()
+
+	return blocks
+}
+
+// buildDeepWorkBlock summarizes a run of events, or returns nil for an empty run.
+func buildDeepWorkBlock(events []*storage.WindowFocusEvent, switches int, dayStart, dayEnd int64) *DeepWorkBlock {
+	if len(events) == 0 {
+		return nil
+	}
+
+	start := events[0].StartTime
+	if start < dayStart {
+		start = dayStart
+	}
+	end := events[len(events)-1].EndTime
+	if end > dayEnd {
+		end = dayEnd
+	}
+	if end <= start {
+		return nil
+	}
+
+	appDurations := make(map[string]float64)
+	for _, evt := range events {
+		appDurations[evt.AppName] += clampedEventDuration(evt, dayStart, dayEnd)
+	}
+	var primaryApp string
+	var primaryDuration float64
+	for app, duration := range appDurations {
+		if duration > primaryDuration || (duration == primaryDuration && app < primaryApp) {
+			primaryApp, primaryDuration = app, duration
+		}
+	}
+
+	return &DeepWorkBlock{
+		StartTime:       start,
+		EndTime:         end,
+		DurationMinutes: (end - start) / 60,
+		PrimaryApp:      GetFriendlyAppName(primaryApp),
+		ContextSwitches: switches,
+	}
+}
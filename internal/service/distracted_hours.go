@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// defaultDistractionWeeks is the history searched by GetMostDistractedDay when no number of
+// weeks is given.
+const defaultDistractionWeeks = 4
+
+// reportDistractedHoursLimit is the number of hours shown in the weekly summary chart.
+const reportDistractedHoursLimit = 5
+
+// DistractedHourEntry describes the distraction within one hour of the day.
+type DistractedHourEntry struct {
+	Hour               int      `json:"hour"` // 0-23
+	DistractingMinutes int64    `json:"distractingMinutes"`
+	DistractingApps    []string `json:"distractingApps"`    // Most time first
+	DistractingDomains []string `json:"distractingDomains"` // Most time first
+	DistractionScore   float64  `json:"distractionScore"`   // 0-100, share of tracked time spent distracted
+}
+
+// GetDistractedHours returns the distraction in each hour of a date (YYYY-MM-DD) with tracked
+// activity, combining focus time in distracting apps and visits to social/entertainment sites.
+func (s *AnalyticsService) GetDistractedHours(date string) ([]*DistractedHourEntry, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	return s.distractedHours(t.Unix(), t.AddDate(0, 0, 1).Unix()-1)
+}
+
+// GetMostDistractedDay returns the date (YYYY-MM-DD), within the last numWeeks weeks ending
+// yesterday, with the highest average hourly distraction score. Returns "" if no day had any
+// distraction. If numWeeks <= 0, four weeks are searched.
+func (s *AnalyticsService) GetMostDistractedDay(numWeeks int) (string, error) {
+	now := time.Now()
+	return s.mostDistractedDay(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), numWeeks)
+}
+
+// mostDistractedDay searches the numWeeks weeks before end (a local midnight).
+func (s *AnalyticsService) mostDistractedDay(end time.Time, numWeeks int) (string, error) {
+	if numWeeks <= 0 {
+		numWeeks = defaultDistractionWeeks
+	}
+
+	best, bestScore := "", 0.0
+	for day := end.AddDate(0, 0, -7*numWeeks); day.Before(end); day = day.AddDate(0, 0, 1) {
+		entries, err := s.distractedHours(day.Unix(), day.AddDate(0, 0, 1).Unix()-1)
+		if err != nil {
+			return "", err
+		}
+		if len(entries) == 0 {
+			continue
+		}
+		var total float64
+		for _, entry := range entries {
+			total += entry.DistractionScore
+		}
+		if score := total / float64(len(entries)); score > bestScore {
+			best, bestScore = day.Format("2006-01-02"), score
+		}
+	}
+	return best, nil
+}
+
+// distractedHours buckets the activity between start and end by hour of day. Ranges longer
+// than a day are combined, so each entry covers that hour across all the days.
+func (s *AnalyticsService) distractedHours(start, end int64) ([]*DistractedHourEntry, error) {
+	events, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get focus events: %w", err)
+	}
+	visits, err := s.store.GetBrowserVisitsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get browser visits: %w", err)
+	}
+
+	var trackedSeconds, distractingSeconds [24]int64
+	var appSeconds, domainSeconds [24]map[string]int64
+	addDistraction := func(breakdown *[24]map[string]int64, name string, from, to int64) {
+		var seconds [24]int64
+		addSecondsByHour(&seconds, from, to)
+		for hour, n := range seconds {
+			if n == 0 {
+				continue
+			}
+			if breakdown[hour] == nil {
+				breakdown[hour] = make(map[string]int64)
+			}
+			breakdown[hour][name] += n
+			distractingSeconds[hour] += n
+		}
+	}
+
+	categories := make(map[string]AppCategory)
+	for _, evt := range events {
+		from, to := max(evt.StartTime, start), min(evt.EndTime, end)
+		if to <= from {
+			continue
+		}
+		addSecondsByHour(&trackedSeconds, from, to)
+
+		category, ok := categories[evt.AppName]
+		if !ok {
+			category = s.CategorizeApp(evt.AppName)
+			categories[evt.AppName] = category
+		}
+		if category == CategoryDistracting {
+			addDistraction(&appSeconds, GetFriendlyAppName(evt.AppName), from, to)
+		}
+	}
+
+	for _, visit := range visits {
+		domain := storage.NormalizeDomain(visit.Domain)
+		if !visit.VisitDurationSeconds.Valid || inferDomainTopic(domain) != "Social" {
+			continue
+		}
+		addDistraction(&domainSeconds, domain, visit.Timestamp, min(visit.Timestamp+visit.VisitDurationSeconds.Int64, end))
+	}
+
+	entries := []*DistractedHourEntry{}
+	for hour := 0; hour < 24; hour++ {
+		// Browser visits overlap the browser's focus time, so they can exceed what was tracked
+		tracked := max(trackedSeconds[hour], distractingSeconds[hour])
+		if tracked == 0 {
+			continue
+		}
+		entries = append(entries, &DistractedHourEntry{
+			Hour:               hour,
+			DistractingMinutes: distractingSeconds[hour] / 60,
+			DistractingApps:    namesByTime(appSeconds[hour]),
+			DistractingDomains: namesByTime(domainSeconds[hour]),
+			DistractionScore:   math.Round(float64(distractingSeconds[hour])/float64(tracked)*1000) / 10,
+		})
+	}
+	return entries, nil
+}
+
+// namesByTime returns the names in a time breakdown, most time first.
+func namesByTime(seconds map[string]int64) []string {
+	names := make([]string, 0, len(seconds))
+	for name := range seconds {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if seconds[names[i]] != seconds[names[j]] {
+			return seconds[names[i]] > seconds[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// mostDistractedHours returns up to limit hours with distraction, highest score first.
+func mostDistractedHours(entries []*DistractedHourEntry, limit int) []*DistractedHourEntry {
+	var hours []*DistractedHourEntry
+	for _, entry := range entries {
+		if entry.DistractingMinutes > 0 {
+			hours = append(hours, entry)
+		}
+	}
+	sort.SliceStable(hours, func(i, j int) bool {
+		return hours[i].DistractionScore > hours[j].DistractionScore
+	})
+	if len(hours) > limit {
+		hours = hours[:limit]
+	}
+	return hours
+}
+
+// distractionSources lists the top apps and domains of an hour, e.g. "youtube.com, Discord".
+func distractionSources(entry *DistractedHourEntry) string {
+	sources := append(append([]string{}, entry.DistractingDomains...), entry.DistractingApps...)
+	if len(sources) > 3 {
+		sources = sources[:3]
+	}
+	return strings.Join(sources, ", ")
+}
+
+// formatDistractedHoursHTML renders the most distracted hours as a bar chart of their scores.
+func formatDistractedHoursHTML(entries []*DistractedHourEntry) string {
+	var sb strings.Builder
+	sb.WriteString(`<div style="margin-bottom: 24px;">
+			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Most Distracted Hours</div>`)
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf(`
+			<div style="display: flex; align-items: center; margin-bottom: 6px;" title="%s">
+				<div style="width: 50px; font-size: 0.8rem; color: #e2e8f0;">%02d:00</div>
+				<div style="flex: 1; height: 16px; background: rgba(30, 41, 59, 0.5); border-radius: 4px; margin: 0 12px; overflow: hidden;">
+					<div style="height: 100%%; width: %.0f%%; background: #ef4444; border-radius: 4px;"></div>
+				</div>
+				<div style="width: 90px; text-align: right; font-size: 0.8rem; color: #94a3b8;">%s · %.0f%%</div>
+			</div>`, esc(distractionSources(entry)), entry.Hour, entry.DistractionScore, formatMinutes(entry.DistractingMinutes), entry.DistractionScore))
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+// formatDistractedHoursMarkdown renders the most distracted hours as a list with text bars.
+func formatDistractedHoursMarkdown(entries []*DistractedHourEntry) string {
+	var sb strings.Builder
+	sb.WriteString("## Most Distracted Hours\n\n")
+	for _, entry := range entries {
+		filled := int(entry.DistractionScore / 10)
+		sb.WriteString(fmt.Sprintf("- **%02d:00** `%s%s` %.0f%% — %s", entry.Hour,
+			strings.Repeat("█", filled), strings.Repeat("░", 10-filled), entry.DistractionScore, formatMinutes(entry.DistractingMinutes)))
+		if sources := distractionSources(entry); sources != "" {
+			sb.WriteString(fmt.Sprintf(" (%s)", sources))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
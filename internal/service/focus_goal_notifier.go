@@ -0,0 +1,116 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// GoalTypeDeepWork tracks daily deep-work minutes, computed from deep-work blocks.
+const GoalTypeDeepWork = "deep_work"
+
+// focusGoalCheckInterval is how often FocusGoalNotifier re-checks today's deep work.
+const focusGoalCheckInterval = 15 * time.Minute
+
+// FocusGoalNotifier periodically checks today's deep-work total and notifies the user
+// the first time it reaches the daily goal. Achievements are recorded so the
+// notification fires at most once per day, even across restarts.
+type FocusGoalNotifier struct {
+	store    *storage.Store
+	timeline *TimelineService
+	notifier Notifier
+	config   func() *FocusGoalConfig // Checked on each tick so config changes apply immediately
+	interval time.Duration
+
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewFocusGoalNotifier creates a new FocusGoalNotifier.
+func NewFocusGoalNotifier(store *storage.Store, timeline *TimelineService, notifier Notifier, config func() *FocusGoalConfig) *FocusGoalNotifier {
+	return &FocusGoalNotifier{
+		store:    store,
+		timeline: timeline,
+		notifier: notifier,
+		config:   config,
+		interval: focusGoalCheckInterval,
+		stopCh:   make(chan struct{}),
+		doneCh:   make(chan struct{}),
+	}
+}
+
+// Start begins the background goal checker.
+func (n *FocusGoalNotifier) Start() {
+	go n.run()
+}
+
+// Stop stops the background goal checker.
+func (n *FocusGoalNotifier) Stop() {
+	close(n.stopCh)
+	<-n.doneCh
+}
+
+// run checks the goal every interval until stopped.
+func (n *FocusGoalNotifier) run() {
+	defer close(n.doneCh)
+
+	ticker := time.NewTicker(n.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := n.check(time.Now()); err != nil {
+				log.Printf("Focus goal check failed: %v", err)
+			}
+		case <-n.stopCh:
+			return
+		}
+	}
+}
+
+// check records an achievement if the deep-work total for now's day has reached the goal,
+// and notifies the user if enabled. Returns true only when the goal was newly achieved.
+func (n *FocusGoalNotifier) check(now time.Time) (bool, error) {
+	cfg := n.config()
+	if cfg == nil || cfg.DailyDeepWorkMinutes <= 0 {
+		return false, nil
+	}
+
+	date := now.Format("2006-01-02")
+	blocks, err := n.timeline.GetDeepWorkBlocks(date, defaultDeepWorkMinutes)
+	if err != nil {
+		return false, err
+	}
+	var total int64
+	for _, block := range blocks {
+		total += block.DurationMinutes
+	}
+	if total < int64(cfg.DailyDeepWorkMinutes) {
+		return false, nil
+	}
+
+	recorded, err := n.store.RecordGoalAchievement(date, GoalTypeDeepWork, now.Unix())
+	if err != nil || !recorded {
+		return false, err
+	}
+
+	if cfg.NotifyOnAchievement && n.notifier != nil {
+		if err := n.notifier.ShowNotification("Focus goal reached! 🎯", formatFocusGoalMessage(total, len(blocks))); err != nil {
+			log.Printf("Failed to show focus goal notification: %v", err)
+		}
+	}
+	return true, nil
+}
+
+// formatFocusGoalMessage builds the notification body, e.g.
+// "Great work! You've done 2h 10m of deep work today across 3 blocks."
+func formatFocusGoalMessage(totalMinutes int64, blockCount int) string {
+	blocks := fmt.Sprintf("%d blocks", blockCount)
+	if blockCount == 1 {
+		blocks = "1 block"
+	}
+	return fmt.Sprintf("Great work! You've done %dh %dm of deep work today across %s.", totalMinutes/60, totalMinutes%60, blocks)
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestFocusGoalNotifier_NotifiesOncePerDay(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	store.SetAppTimelineCategory("code", "focus")
+	cfg := &FocusGoalConfig{DailyDeepWorkMinutes: 60, NotifyOnAchievement: true}
+	mock := &mockNotifier{}
+	notifier := NewFocusGoalNotifier(store, NewTimelineService(store), mock, func() *FocusGoalConfig { return cfg })
+
+	save := func(start, duration int64) {
+		t.Helper()
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle: "code", AppName: "code", StartTime: start, EndTime: start + duration, DurationSeconds: float64(duration),
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	morning := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local)
+
+	// 45 minutes of deep work is below the 60-minute goal
+	save(morning.Unix(), 2700)
+	achieved, err := notifier.check(morning.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if achieved || len(mock.calls) != 0 {
+		t.Fatalf("expected no achievement below goal, got achieved=%v calls=%d", achieved, len(mock.calls))
+	}
+
+	// Another 45-minute block reaches the goal
+	save(morning.Add(2*time.Hour).Unix(), 2700)
+	achieved, err = notifier.check(morning.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !achieved || len(mock.calls) != 1 {
+		t.Fatalf("expected one notification, got achieved=%v calls=%d", achieved, len(mock.calls))
+	}
+	expected := "Great work! You've done 1h 30m of deep work today across 2 blocks."
+	if mock.calls[0].Body != expected {
+		t.Errorf("expected body %q, got %q", expected, mock.calls[0].Body)
+	}
+
+	// Later checks on the same day don't notify again
+	achieved, err = notifier.check(morning.Add(4 * time.Hour))
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if achieved || len(mock.calls) != 1 {
+		t.Errorf("expected no repeat notification, got achieved=%v calls=%d", achieved, len(mock.calls))
+	}
+
+	achievements, err := NewGoalService(store).GetGoalAchievements(2024, 3)
+	if err != nil {
+		t.Fatalf("GetGoalAchievements failed: %v", err)
+	}
+	if len(achievements) != 1 || achievements[0].Date != "2024-03-05" || achievements[0].GoalType != GoalTypeDeepWork {
+		t.Errorf("unexpected achievements: %+v", achievements)
+	}
+
+	if _, err := NewGoalService(store).GetGoalAchievements(2024, 13); err == nil {
+		t.Error("expected error for invalid month")
+	}
+}
+
+func TestFocusGoalNotifier_Disabled(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	store.SetAppTimelineCategory("code", "focus")
+	start := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local)
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		WindowTitle: "code", AppName: "code", StartTime: start.Unix(), EndTime: start.Unix() + 7200, DurationSeconds: 7200,
+	})
+
+	// A zero goal is never achieved
+	mock := &mockNotifier{}
+	cfg := &FocusGoalConfig{DailyDeepWorkMinutes: 0, NotifyOnAchievement: true}
+	notifier := NewFocusGoalNotifier(store, NewTimelineService(store), mock, func() *FocusGoalConfig { return cfg })
+	if achieved, _ := notifier.check(start.Add(3 * time.Hour)); achieved {
+		t.Error("expected no achievement with goal disabled")
+	}
+
+	// With notifications off the achievement is still recorded, silently
+	cfg.DailyDeepWorkMinutes = 60
+	cfg.NotifyOnAchievement = false
+	achieved, err := notifier.check(start.Add(3 * time.Hour))
+	if err != nil {
+		t.Fatalf("check failed: %v", err)
+	}
+	if !achieved {
+		t.Error("expected achievement to be recorded")
+	}
+	if len(mock.calls) != 0 {
+		t.Errorf("expected no notification, got %d", len(mock.calls))
+	}
+}
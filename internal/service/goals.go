@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// GoalTypeActiveMinutes tracks active minutes, computed from session durations like the Timeline.
+const GoalTypeActiveMinutes = "active_minutes"
+
+// maxGoalStreakPeriods bounds how far back DaysOnTrack looks for a streak.
+const maxGoalStreakPeriods = 365
+
+// GoalService provides goal management and progress tracking.
+type GoalService struct {
+	store *storage.Store
+
+	// Streaks of completed periods, keyed by goal and the period they end before. They're
+	// computed once per period, since the tray asks for progress on every daemon tick.
+	streakMu    sync.Mutex
+	streakCache map[goalStreakKey]int
+}
+
+// goalStreakKey identifies the streak of a goal's periods ending just before periodStart.
+type goalStreakKey struct {
+	goalID      int64
+	periodStart int64
+}
+
+// NewGoalService creates a new GoalService.
+func NewGoalService(store *storage.Store) *GoalService {
+	return &GoalService{
+		store:       store,
+		streakCache: make(map[goalStreakKey]int),
+	}
+}
+
+// GoalProgress contains progress towards a goal for a day (or the week containing it).
+type GoalProgress struct {
+	GoalID      int64   `json:"goalId"`
+	GoalType    string  `json:"goalType"`
+	Period      string  `json:"period"`
+	Date        string  `json:"date"`
+	Target      int64   `json:"target"`
+	Actual      int64   `json:"actual"`
+	Percentage  float64 `json:"percentage"` // Actual / Target * 100, not capped at 100
+	Achieved    bool    `json:"achieved"`
+	DaysOnTrack int     `json:"daysOnTrack"` // Consecutive periods (days, or weeks for weekly goals) the goal was met
+}
+
+// SetGoal sets the target for a goal type and period, replacing any previously active goal.
+func (s *GoalService) SetGoal(goalType string, targetValue int64, period string) (*storage.Goal, error) {
+	if goalType != GoalTypeActiveMinutes {
+		return nil, fmt.Errorf("unsupported goal type: %s", goalType)
+	}
+	if targetValue <= 0 {
+		return nil, fmt.Errorf("goal target must be positive, got %d", targetValue)
+	}
+	if period != "daily" && period != "weekly" {
+		return nil, fmt.Errorf("invalid goal period: %s (must be daily or weekly)", period)
+	}
+
+	id, err := s.store.ReplaceActiveGoal(&storage.Goal{
+		GoalType:    goalType,
+		TargetValue: targetValue,
+		Period:      period,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.store.GetGoal(id)
+}
+
+// GetGoal returns a goal by ID, or nil if it doesn't exist.
+func (s *GoalService) GetGoal(goalID int64) (*storage.Goal, error) {
+	return s.store.GetGoal(goalID)
+}
+
+// GetActiveGoal returns the active goal of a type and period, or nil if none is set.
+func (s *GoalService) GetActiveGoal(goalType, period string) (*storage.Goal, error) {
+	return s.store.GetActiveGoal(goalType, period)
+}
+
+// GetGoalProgress returns progress towards a goal on a date (YYYY-MM-DD).
+// Weekly goals measure the Monday-Sunday week containing the date.
+func (s *GoalService) GetGoalProgress(goalID int64, date string) (*GoalProgress, error) {
+	goal, err := s.store.GetGoal(goalID)
+	if err != nil {
+		return nil, err
+	}
+	if goal == nil {
+		return nil, fmt.Errorf("goal not found: %d", goalID)
+	}
+
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	start, end := goalPeriodBounds(goal.Period, t)
+	actual, err := s.goalActualValue(goal, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := &GoalProgress{
+		GoalID:   goal.ID,
+		GoalType: goal.GoalType,
+		Period:   goal.Period,
+		Date:     date,
+		Target:   goal.TargetValue,
+		Actual:   actual,
+		Achieved: actual >= goal.TargetValue,
+	}
+	if goal.TargetValue > 0 {
+		progress.Percentage = float64(actual) / float64(goal.TargetValue) * 100
+	}
+
+	// Count the streak ending at this period. If the current period isn't met yet
+	// (e.g. today is still in progress), the streak ending at the previous period still counts.
+	streak, err := s.previousStreak(goal, start)
+	if err != nil {
+		return nil, err
+	}
+	progress.DaysOnTrack = streak
+	if progress.Achieved {
+		progress.DaysOnTrack++
+	}
+
+	return progress, nil
+}
+
+// InvalidateStreaks drops the cached streaks that cover activity at or after since, for when
+// past sessions change (merged, deleted or imported).
+func (s *GoalService) InvalidateStreaks(since int64) {
+	s.streakMu.Lock()
+	defer s.streakMu.Unlock()
+	for key := range s.streakCache {
+		if key.periodStart > since {
+			delete(s.streakCache, key)
+		}
+	}
+}
+
+// previousStreak counts the consecutive periods before the one starting at start in which
+// the goal was met. Streaks made only of elapsed periods are cached.
+func (s *GoalService) previousStreak(goal *storage.Goal, start time.Time) (int, error) {
+	key := goalStreakKey{goalID: goal.ID, periodStart: start.Unix()}
+	cacheable := !start.After(time.Now())
+	if cacheable {
+		s.streakMu.Lock()
+		streak, ok := s.streakCache[key]
+		s.streakMu.Unlock()
+		if ok {
+			return streak, nil
+		}
+	}
+
+	streak := 0
+	for i := 1; i < maxGoalStreakPeriods; i++ {
+		prevStart, prevEnd := goalPeriodBounds(goal.Period, previousGoalPeriod(goal.Period, start, i))
+		value, err := s.goalActualValue(goal, prevStart, prevEnd)
+		if err != nil {
+			return 0, err
+		}
+		if value < goal.TargetValue {
+			break
+		}
+		streak++
+	}
+
+	if cacheable {
+		s.streakMu.Lock()
+		s.streakCache[key] = streak
+		s.streakMu.Unlock()
+	}
+	return streak, nil
+}
+
+// goalActualValue measures a goal's metric over a time range.
+func (s *GoalService) goalActualValue(goal *storage.Goal, start, end time.Time) (int64, error) {
+	switch goal.GoalType {
+	case GoalTypeActiveMinutes:
+		sessions, err := s.store.GetSessionsByTimeRange(start.Unix(), end.Unix()-1)
+		if err != nil {
+			return 0, err
+		}
+		return sessionActiveSeconds(sessions, start.Unix(), end.Unix()-1) / 60, nil
+	default:
+		return 0, fmt.Errorf("unsupported goal type: %s", goal.GoalType)
+	}
+}
+
+// goalPeriodBounds returns the [start, end) range of the daily or weekly period containing t.
+func goalPeriodBounds(period string, t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	if period != "weekly" {
+		return day, day.AddDate(0, 0, 1)
+	}
+
+	// Normalize to Monday of the week
+	weekday := int(day.Weekday())
+	if weekday == 0 {
+		weekday = 7 // Sunday becomes 7
+	}
+	monday := day.AddDate(0, 0, -(weekday - 1))
+	return monday, monday.AddDate(0, 0, 7)
+}
+
+// previousGoalPeriod returns a time within the period n periods before the one starting at start.
+func previousGoalPeriod(period string, start time.Time, n int) time.Time {
+	if period == "weekly" {
+		return start.AddDate(0, 0, -7*n)
+	}
+	return start.AddDate(0, 0, -n)
+}
+
+// GetGoalAchievements returns the goal achievements recorded in a month.
+func (s *GoalService) GetGoalAchievements(year, month int) ([]*storage.GoalAchievement, error) {
+	if month < 1 || month > 12 {
+		return nil, fmt.Errorf("invalid month: %d", month)
+	}
+	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.Local)
+	end := start.AddDate(0, 1, -1)
+	return s.store.GetGoalAchievements(start.Format("2006-01-02"), end.Format("2006-01-02"))
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGoalService_SetGoal(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewGoalService(store)
+
+	first, err := svc.SetGoal(GoalTypeActiveMinutes, 120, "daily")
+	if err != nil {
+		t.Fatalf("SetGoal failed: %v", err)
+	}
+	if !first.Active || first.TargetValue != 120 || first.Period != "daily" {
+		t.Errorf("unexpected goal: %+v", first)
+	}
+
+	// Setting a new target replaces the active goal
+	second, err := svc.SetGoal(GoalTypeActiveMinutes, 180, "daily")
+	if err != nil {
+		t.Fatalf("SetGoal failed: %v", err)
+	}
+	active, err := svc.GetActiveGoal(GoalTypeActiveMinutes, "daily")
+	if err != nil {
+		t.Fatalf("GetActiveGoal failed: %v", err)
+	}
+	if active == nil || active.ID != second.ID {
+		t.Errorf("expected goal %d to be active, got %+v", second.ID, active)
+	}
+	old, err := svc.GetGoal(first.ID)
+	if err != nil {
+		t.Fatalf("GetGoal failed: %v", err)
+	}
+	if old == nil || old.Active {
+		t.Errorf("expected first goal to be inactive, got %+v", old)
+	}
+
+	// A failed insert keeps the previous goal active
+	store.DB().Exec(`CREATE TEMP TRIGGER fail_goal_insert BEFORE INSERT ON goals BEGIN SELECT RAISE(ABORT, 'insert failed'); END`)
+	if _, err := svc.SetGoal(GoalTypeActiveMinutes, 240, "daily"); err == nil {
+		t.Error("expected SetGoal to fail")
+	}
+	store.DB().Exec(`DROP TRIGGER temp.fail_goal_insert`)
+	active, _ = svc.GetActiveGoal(GoalTypeActiveMinutes, "daily")
+	if active == nil || active.ID != second.ID {
+		t.Errorf("expected goal %d to stay active after a failed SetGoal, got %+v", second.ID, active)
+	}
+
+	// Invalid input
+	if _, err := svc.SetGoal("steps", 100, "daily"); err == nil {
+		t.Error("expected error for unsupported goal type")
+	}
+	if _, err := svc.SetGoal(GoalTypeActiveMinutes, 0, "daily"); err == nil {
+		t.Error("expected error for zero target")
+	}
+	if _, err := svc.SetGoal(GoalTypeActiveMinutes, 60, "monthly"); err == nil {
+		t.Error("expected error for invalid period")
+	}
+}
+
+func TestGoalService_GetGoalProgress(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewGoalService(store)
+	goal, err := svc.SetGoal(GoalTypeActiveMinutes, 60, "daily")
+	if err != nil {
+		t.Fatalf("SetGoal failed: %v", err)
+	}
+
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	addSession := func(d time.Time, hour int, minutes int) int64 {
+		start := d.Add(time.Duration(hour) * time.Hour)
+		id, err := store.CreateSession(start.Unix())
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		if err := store.EndSession(id, start.Add(time.Duration(minutes)*time.Minute).Unix()); err != nil {
+			t.Fatalf("EndSession failed: %v", err)
+		}
+		return id
+	}
+
+	// Goal met on the two previous days, broken three days ago, partially met today
+	addSession(day, 9, 45)
+	yesterday := addSession(day.AddDate(0, 0, -1), 9, 70)
+	addSession(day.AddDate(0, 0, -2), 9, 30)
+	addSession(day.AddDate(0, 0, -2), 14, 30)
+	addSession(day.AddDate(0, 0, -3), 9, 20)
+
+	progress, err := svc.GetGoalProgress(goal.ID, day.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetGoalProgress failed: %v", err)
+	}
+	if progress.Target != 60 || progress.Actual != 45 {
+		t.Errorf("expected 45/60, got %d/%d", progress.Actual, progress.Target)
+	}
+	if progress.Achieved {
+		t.Error("expected goal not achieved today")
+	}
+	if progress.Percentage != 75 {
+		t.Errorf("expected 75%%, got %.2f", progress.Percentage)
+	}
+	if progress.DaysOnTrack != 2 {
+		t.Errorf("expected 2 days on track, got %d", progress.DaysOnTrack)
+	}
+
+	// Later checks the same day reuse the streak but still measure today's progress
+	addSession(day, 11, 20)
+	progress, err = svc.GetGoalProgress(goal.ID, day.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetGoalProgress failed: %v", err)
+	}
+	if !progress.Achieved || progress.DaysOnTrack != 3 {
+		t.Errorf("expected achieved with 3 days on track, got %+v", progress)
+	}
+	if len(svc.streakCache) != 1 {
+		t.Errorf("expected one cached streak, got %d", len(svc.streakCache))
+	}
+
+	// The previous day counts itself in the streak
+	progress, err = svc.GetGoalProgress(goal.ID, day.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetGoalProgress failed: %v", err)
+	}
+	if !progress.Achieved || progress.DaysOnTrack != 2 {
+		t.Errorf("expected achieved with 2 days on track, got %+v", progress)
+	}
+
+	// Deleting a past session breaks the streak once the cached streaks are invalidated
+	if err := store.DeleteSession(yesterday); err != nil {
+		t.Fatalf("DeleteSession failed: %v", err)
+	}
+	svc.InvalidateStreaks(day.AddDate(0, 0, -1).Unix())
+	progress, err = svc.GetGoalProgress(goal.ID, day.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetGoalProgress failed: %v", err)
+	}
+	if progress.DaysOnTrack != 1 {
+		t.Errorf("expected only today on track after deleting yesterday's session, got %d", progress.DaysOnTrack)
+	}
+
+	if _, err := svc.GetGoalProgress(9999, day.Format("2006-01-02")); err == nil {
+		t.Error("expected error for missing goal")
+	}
+}
+
+func TestGoalService_WeeklyProgress(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewGoalService(store)
+	goal, err := svc.SetGoal(GoalTypeActiveMinutes, 120, "weekly")
+	if err != nil {
+		t.Fatalf("SetGoal failed: %v", err)
+	}
+
+	// Monday and Sunday of the same week
+	monday := time.Date(2026, time.March, 9, 10, 0, 0, 0, time.Local)
+	for _, start := range []time.Time{monday, monday.AddDate(0, 0, 6)} {
+		id, err := store.CreateSession(start.Unix())
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		if err := store.EndSession(id, start.Add(time.Hour).Unix()); err != nil {
+			t.Fatalf("EndSession failed: %v", err)
+		}
+	}
+
+	progress, err := svc.GetGoalProgress(goal.ID, "2026-03-11")
+	if err != nil {
+		t.Fatalf("GetGoalProgress failed: %v", err)
+	}
+	if progress.Actual != 120 || !progress.Achieved {
+		t.Errorf("expected 120 minutes achieved for the week, got %+v", progress)
+	}
+	if progress.DaysOnTrack != 1 {
+		t.Errorf("expected streak of 1, got %d", progress.DaysOnTrack)
+	}
+}
@@ -0,0 +1,309 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// Insight types.
+const (
+	InsightPeakHours          = "peak_hours"
+	InsightDistractionPattern = "distraction_pattern"
+	InsightFocusTrend         = "focus_trend"
+	InsightMeetingOverload    = "meeting_overload"
+	InsightProductiveDay      = "productive_day"
+	InsightWeekdayProductive  = "weekday_productive"
+	InsightWeekdayMeetings    = "weekday_meetings"
+)
+
+// Insight severities.
+const (
+	InsightInfo     = "info"
+	InsightWarning  = "warning"
+	InsightPositive = "positive"
+)
+
+const (
+	// insightMinFocusMinutes is the productive time a week needs before peak hours are reported.
+	insightMinFocusMinutes = 60
+	// insightTrendPercent is the week-over-week change in productive time worth reporting.
+	insightTrendPercent = 15
+	// insightDistractionPercent is the week-over-week increase in a distracting app worth reporting.
+	insightDistractionPercent = 25
+	// insightMinDistractionMinutes ignores distracting apps used only briefly this week.
+	insightMinDistractionMinutes = 30
+	// insightMeetingOverloadPercent is the share of focus time in meetings that counts as overload.
+	insightMeetingOverloadPercent = 30
+)
+
+// Insight is a rule-based observation about a week of activity.
+type Insight struct {
+	Type     string `json:"type"`     // peak_hours, distraction_pattern, focus_trend, meeting_overload, productive_day, weekday_productive, weekday_meetings
+	Title    string `json:"title"`    // e.g. "Your peak focus hours are 9–11am"
+	Body     string `json:"body"`     // Supporting detail
+	Severity string `json:"severity"` // info, warning, positive
+}
+
+// InsightService surfaces weekly behavioral patterns using simple heuristics (no AI).
+type InsightService struct {
+	analytics *AnalyticsService
+	reports   *ReportsService
+}
+
+// NewInsightService creates a new InsightService.
+func NewInsightService(analytics *AnalyticsService, reports *ReportsService) *InsightService {
+	return &InsightService{
+		analytics: analytics,
+		reports:   reports,
+	}
+}
+
+// GenerateWeeklyInsights returns insights for the week starting at weekStart (YYYY-MM-DD).
+// Insights are built with the weekly summary data, so they match the weekly report.
+func (s *InsightService) GenerateWeeklyInsights(weekStart string) ([]*Insight, error) {
+	start, err := time.ParseInLocation("2006-01-02", weekStart, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	end := start.AddDate(0, 0, 7).Add(-time.Second)
+
+	data, err := s.reports.buildWeeklySummaryData(context.Background(), start.Unix(), end.Unix(), weekStart, end.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	insights := make([]*Insight, len(data.Insights))
+	for i := range data.Insights {
+		insights[i] = &data.Insights[i]
+	}
+	return insights, nil
+}
+
+// buildWeeklyInsights applies the insight rules to a week of focus events, comparing against
+// the previous week where needed. daily is the week's per-day breakdown.
+func buildWeeklyInsights(analytics *AnalyticsService, startUnix, endUnix int64, focusEvents []*storage.WindowFocusEvent, daily []DailySummaryStats) []Insight {
+	if analytics == nil {
+		return nil
+	}
+
+	prevEvents, err := analytics.store.GetWindowFocusEventsByTimeRange(startUnix-7*24*3600, startUnix-1)
+	if err != nil {
+		prevEvents = nil
+	}
+	current := categorizeWeek(analytics, focusEvents, startUnix, endUnix)
+	previous := categorizeWeek(analytics, prevEvents, startUnix-7*24*3600, startUnix-1)
+
+	var insights []Insight
+	if insight := peakHoursInsight(current); insight != nil {
+		insights = append(insights, *insight)
+	}
+	if insight := focusTrendInsight(current, previous); insight != nil {
+		insights = append(insights, *insight)
+	}
+	if insight := distractionInsight(current, previous); insight != nil {
+		insights = append(insights, *insight)
+	}
+	if stats, err := analytics.GetMeetingStats(startUnix, endUnix); err == nil {
+		if insight := meetingOverloadInsight(stats); insight != nil {
+			insights = append(insights, *insight)
+		}
+	}
+	if insight := productiveDayInsight(analytics.meetings, focusEvents, daily); insight != nil {
+		insights = append(insights, *insight)
+	}
+	return insights
+}
+
+// weekActivity holds a week's focus time by productivity category.
+type weekActivity struct {
+	productiveSeconds  float64
+	hourlyProductive   [24]float64        // Productive seconds by hour of day
+	distractingSeconds map[string]float64 // App name -> seconds
+}
+
+// categorizeWeek sums clamped focus time by category, splitting productive time across hours.
+func categorizeWeek(analytics *AnalyticsService, events []*storage.WindowFocusEvent, start, end int64) *weekActivity {
+	week := &weekActivity{distractingSeconds: make(map[string]float64)}
+	categories := make(map[string]AppCategory)
+
+	for _, evt := range events {
+		category, ok := categories[evt.AppName]
+		if !ok {
+			category = analytics.CategorizeApp(evt.AppName)
+			categories[evt.AppName] = category
+		}
+
+		switch category {
+		case CategoryProductive:
+			week.productiveSeconds += clampedEventDuration(evt, start, end)
+			// Spread the event over the hours it covers
+			from := max(evt.StartTime, start)
+			to := min(evt.EndTime, end)
+			for from < to {
+				t := time.Unix(from, 0)
+				hourEnd := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, time.Local).Unix()
+				week.hourlyProductive[t.Hour()] += float64(min(hourEnd, to) - from)
+				from = hourEnd
+			}
+		case CategoryDistracting:
+			week.distractingSeconds[evt.AppName] += clampedEventDuration(evt, start, end)
+		}
+	}
+	return week
+}
+
+// peakHoursInsight reports the two-hour window with the most productive time.
+func peakHoursInsight(week *weekActivity) *Insight {
+	if week.productiveSeconds < insightMinFocusMinutes*60 {
+		return nil
+	}
+
+	bestHour, bestSeconds := 0, 0.0
+	for hour := 0; hour < 23; hour++ {
+		if seconds := week.hourlyProductive[hour] + week.hourlyProductive[hour+1]; seconds > bestSeconds {
+			bestHour, bestSeconds = hour, seconds
+		}
+	}
+
+	return &Insight{
+		Type:     InsightPeakHours,
+		Title:    fmt.Sprintf("Your peak focus hours are %s", formatHourRange(bestHour, bestHour+2)),
+		Body:     fmt.Sprintf("%.0f%% of your productive time this week fell in this window.", bestSeconds/week.productiveSeconds*100),
+		Severity: InsightInfo,
+	}
+}
+
+// focusTrendInsight reports a notable week-over-week change in productive time.
+func focusTrendInsight(current, previous *weekActivity) *Insight {
+	if previous.productiveSeconds < insightMinFocusMinutes*60 {
+		return nil
+	}
+
+	change := (current.productiveSeconds - previous.productiveSeconds) / previous.productiveSeconds * 100
+	body := fmt.Sprintf("%s of productive time this week vs %s last week.",
+		formatMinutes(int64(current.productiveSeconds/60)), formatMinutes(int64(previous.productiveSeconds/60)))
+
+	switch {
+	case change >= insightTrendPercent:
+		return &Insight{
+			Type:     InsightFocusTrend,
+			Title:    fmt.Sprintf("Focus time up %.0f%% from last week", change),
+			Body:     body,
+			Severity: InsightPositive,
+		}
+	case change <= -insightTrendPercent:
+		return &Insight{
+			Type:     InsightFocusTrend,
+			Title:    fmt.Sprintf("Focus time down %.0f%% from last week", math.Abs(change)),
+			Body:     body,
+			Severity: InsightWarning,
+		}
+	}
+	return nil
+}
+
+// distractionInsight reports the distracting app whose usage grew the most since last week.
+func distractionInsight(current, previous *weekActivity) *Insight {
+	apps := make([]string, 0, len(current.distractingSeconds))
+	for app := range current.distractingSeconds {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps) // Deterministic tie-breaking
+
+	var worstApp string
+	var worstChange float64
+	for _, app := range apps {
+		now, before := current.distractingSeconds[app], previous.distractingSeconds[app]
+		if now < insightMinDistractionMinutes*60 || before <= 0 {
+			continue
+		}
+		if change := (now - before) / before * 100; change >= insightDistractionPercent && change > worstChange {
+			worstApp, worstChange = app, change
+		}
+	}
+	if worstApp == "" {
+		return nil
+	}
+
+	return &Insight{
+		Type:  InsightDistractionPattern,
+		Title: fmt.Sprintf("%s usage increased %.0f%% this week", GetFriendlyAppName(worstApp), worstChange),
+		Body: fmt.Sprintf("%s this week vs %s last week.",
+			formatMinutes(int64(current.distractingSeconds[worstApp]/60)), formatMinutes(int64(previous.distractingSeconds[worstApp]/60))),
+		Severity: InsightWarning,
+	}
+}
+
+// meetingOverloadInsight warns when meetings take a large share of focus time.
+func meetingOverloadInsight(stats *MeetingStats) *Insight {
+	if stats == nil || stats.MeetingPercentage < insightMeetingOverloadPercent {
+		return nil
+	}
+	return &Insight{
+		Type:     InsightMeetingOverload,
+		Title:    fmt.Sprintf("Meetings took %.0f%% of your week", stats.MeetingPercentage),
+		Body:     fmt.Sprintf("%d meetings totaling %s.", stats.TotalMeetings, formatMinutes(stats.TotalMeetingMinutes)),
+		Severity: InsightWarning,
+	}
+}
+
+// productiveDayInsight points out when the week's busiest day had no meetings,
+// as long as other days did.
+func productiveDayInsight(meetings meetingDetector, focusEvents []*storage.WindowFocusEvent, daily []DailySummaryStats) *Insight {
+	var best *DailySummaryStats
+	for i := range daily {
+		if daily[i].Hours > 0 && (best == nil || daily[i].Hours > best.Hours) {
+			best = &daily[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+
+	meetingDays := make(map[string]bool)
+	for _, evt := range focusEvents {
+		if isMeeting, _ := meetings.detectFromTitle(evt.WindowTitle); isMeeting {
+			meetingDays[time.Unix(evt.StartTime, 0).Format("2006-01-02")] = true
+		}
+	}
+	if len(meetingDays) == 0 || meetingDays[best.Date] {
+		return nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", best.Date, time.Local)
+	if err != nil {
+		return nil
+	}
+	return &Insight{
+		Type:     InsightProductiveDay,
+		Title:    fmt.Sprintf("You had no meetings on %s — your most productive day", day.Format("Monday")),
+		Body:     fmt.Sprintf("%.1f hours of activity with no meetings.", best.Hours),
+		Severity: InsightPositive,
+	}
+}
+
+// formatHourRange formats hours of the day like "9–11am" or "11am–1pm".
+func formatHourRange(from, to int) string {
+	hour12 := func(h int) int {
+		if h%12 == 0 {
+			return 12
+		}
+		return h % 12
+	}
+	suffix := func(h int) string {
+		if h%24 < 12 {
+			return "am"
+		}
+		return "pm"
+	}
+
+	if suffix(from) == suffix(to) {
+		return fmt.Sprintf("%d–%d%s", hour12(from), hour12(to), suffix(to))
+	}
+	return fmt.Sprintf("%d%s–%d%s", hour12(from), suffix(from), hour12(to), suffix(to))
+}
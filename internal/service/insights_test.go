@@ -0,0 +1,185 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestGenerateWeeklyInsights(t *testing.T) {
+	reports, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	insights := NewInsightService(reports.analytics, reports)
+
+	weekStart := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.Local) // Monday
+	save := func(day time.Time, hour, minutes int, app, title string) {
+		start := day.Add(time.Duration(hour) * time.Hour)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         app,
+			WindowTitle:     title,
+			StartTime:       start.Unix(),
+			EndTime:         start.Add(time.Duration(minutes) * time.Minute).Unix(),
+			DurationSeconds: float64(minutes * 60),
+		}); err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+	}
+
+	// Previous week: an hour of coding and 30 minutes of Reddit a day
+	for i := 0; i < 5; i++ {
+		day := weekStart.AddDate(0, 0, i-7)
+		save(day, 14, 60, "code", "main.go")
+		save(day, 16, 30, "Reddit", "r/golang")
+	}
+	// This week: two morning hours of coding and 45 minutes of Reddit a day,
+	// with a meeting every day except Friday, which gets extra work
+	for i := 0; i < 5; i++ {
+		day := weekStart.AddDate(0, 0, i)
+		save(day, 9, 120, "code", "main.go")
+		save(day, 16, 45, "Reddit", "r/golang")
+		if i < 4 {
+			save(day, 13, 30, "zoom", "Zoom Meeting")
+		} else {
+			save(day, 13, 120, "firefox", "Docs")
+		}
+	}
+
+	got, err := insights.GenerateWeeklyInsights("2024-03-04")
+	if err != nil {
+		t.Fatalf("GenerateWeeklyInsights failed: %v", err)
+	}
+
+	byType := make(map[string]*Insight)
+	for _, insight := range got {
+		byType[insight.Type] = insight
+	}
+
+	want := map[string]struct{ title, severity string }{
+		InsightPeakHours:          {"Your peak focus hours are 9–11am", InsightInfo},
+		InsightFocusTrend:         {"Focus time up 100% from last week", InsightPositive},
+		InsightDistractionPattern: {"Reddit usage increased 50% this week", InsightWarning},
+		InsightProductiveDay:      {"You had no meetings on Friday — your most productive day", InsightPositive},
+	}
+	for typ, w := range want {
+		insight, ok := byType[typ]
+		if !ok {
+			t.Errorf("expected a %s insight, got %+v", typ, got)
+			continue
+		}
+		if insight.Title != w.title || insight.Severity != w.severity {
+			t.Errorf("%s: got %q (%s), want %q (%s)", typ, insight.Title, insight.Severity, w.title, w.severity)
+		}
+	}
+	// 2h of meetings out of ~16h of focus time is not overload
+	if insight, ok := byType[InsightMeetingOverload]; ok {
+		t.Errorf("unexpected meeting overload insight: %+v", insight)
+	}
+
+	if _, err := insights.GenerateWeeklyInsights("not-a-date"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestFormatHourRange(t *testing.T) {
+	tests := []struct {
+		from, to int
+		want     string
+	}{
+		{9, 11, "9–11am"},
+		{11, 13, "11am–1pm"},
+		{14, 16, "2–4pm"},
+		{0, 2, "12–2am"},
+		{22, 24, "10pm–12am"},
+	}
+	for _, tt := range tests {
+		if got := formatHourRange(tt.from, tt.to); got != tt.want {
+			t.Errorf("formatHourRange(%d, %d) = %q, want %q", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestAssessBurnoutRisk(t *testing.T) {
+	reports, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	end := time.Date(2024, time.March, 25, 0, 0, 0, 0, time.Local) // Monday
+	start := end.AddDate(0, 0, -28)
+	save := func(day time.Time, hour, hours int, app string) {
+		from := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+		if _, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         app,
+			WindowTitle:     "work",
+			StartTime:       from.Unix(),
+			EndTime:         from.Add(time.Duration(hours) * time.Hour).Unix(),
+			DurationSeconds: float64(hours * 3600),
+		}); err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+	}
+
+	// 11-hour weekdays without breaks, coding until the last week; an hour every Saturday;
+	// and the last 6 days running past 9pm
+	for i := 0; i < 28; i++ {
+		day := start.AddDate(0, 0, i)
+		app := "code"
+		if i >= 21 {
+			app = "firefox"
+		}
+		switch day.Weekday() {
+		case time.Saturday:
+			save(day, 10, 1, app)
+		case time.Sunday:
+		default:
+			save(day, 9, 11, app)
+		}
+		if i >= 22 {
+			save(day, 20, 2, app)
+		}
+	}
+
+	risk, err := assessBurnoutRisk(reports.analytics, end, 4)
+	if err != nil {
+		t.Fatalf("assessBurnoutRisk failed: %v", err)
+	}
+	if risk.Score != 100 || risk.Level != BurnoutRiskHigh {
+		t.Errorf("expected high risk scoring 100, got %s at %.0f: %v", risk.Level, risk.Score, risk.Factors)
+	}
+	want := []string{
+		"Worked after 9pm on 6 consecutive days",
+		"Worked over 50 hours in each of the last 4 weeks",
+		"Worked 4 weekends in a row",
+		"No break of 30+ minutes on 20 of 20 working days",
+		"Deep work fell to 0m last week from an average of 56h",
+	}
+	if len(risk.Factors) != len(want) {
+		t.Fatalf("expected factors %v, got %v", want, risk.Factors)
+	}
+	for i := range want {
+		if risk.Factors[i] != want[i] {
+			t.Errorf("factor %d: expected %q, got %q", i, want[i], risk.Factors[i])
+		}
+	}
+
+	// An AFK break on a day takes it off the no-break list
+	store.CreateAFKEvent(&storage.AFKEvent{StartTime: start.Add(12 * time.Hour).Unix(), EndTime: storage.NullInt64(start.Add(13 * time.Hour).Unix()), TriggerType: "idle_timeout"})
+	risk, _ = assessBurnoutRisk(reports.analytics, end, 4)
+	if got := risk.Factors[3]; got != "No break of 30+ minutes on 19 of 20 working days" {
+		t.Errorf("expected the AFK break to count, got %q", got)
+	}
+
+	md := reports.formatWeeklySummaryMarkdown(&WeeklySummaryData{StartDate: "2024-03-18", EndDate: "2024-03-24", BurnoutRisk: risk})
+	if !strings.Contains(md, "Risk is **high** (100/100).") || !strings.Contains(md, "- Worked 4 weekends in a row") {
+		t.Error("expected the weekly summary to include the burnout risk")
+	}
+
+	// A quiet period is low risk
+	risk, err = assessBurnoutRisk(reports.analytics, start, 2)
+	if err != nil {
+		t.Fatalf("assessBurnoutRisk failed: %v", err)
+	}
+	if risk.Score != 0 || risk.Level != BurnoutRiskLow || len(risk.Factors) != 0 || risk.Recommendation == "" {
+		t.Errorf("expected low risk with a recommendation, got %+v", risk)
+	}
+}
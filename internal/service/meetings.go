@@ -0,0 +1,210 @@
+package service
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// meetingDetector recognizes video calls and huddles from window titles.
+// It is shared by ReportsService and AnalyticsService.
+type meetingDetector struct{}
+
+// detectFromTitle checks for meeting patterns in window title.
+func (d meetingDetector) detectFromTitle(title string) (bool, string) {
+	lower := strings.ToLower(title)
+
+	// Slack patterns
+	if strings.Contains(lower, "huddle") || strings.Contains(lower, "slack | huddle") {
+		return true, "Slack"
+	}
+
+	// Zoom patterns
+	if strings.Contains(lower, "zoom meeting") || strings.Contains(lower, "zoom.us") {
+		return true, "Zoom"
+	}
+
+	// Google Meet patterns
+	if strings.Contains(lower, "meet.google.com") || strings.Contains(lower, "meet -") {
+		return true, "Google Meet"
+	}
+
+	// Microsoft Teams patterns
+	if strings.Contains(lower, "microsoft teams") && strings.Contains(lower, "meeting") {
+		return true, "Teams"
+	}
+
+	return false, ""
+}
+
+// detect aggregates meeting durations from all focus events.
+func (d meetingDetector) detect(events []*storage.WindowFocusEvent) []MeetingDetection {
+	agg := d.newAggregator()
+	for _, evt := range events {
+		agg.add(evt)
+	}
+	return agg.result()
+}
+
+// meetingAggregator collects meetings one focus event at a time, so large ranges can be
+// aggregated while streaming.
+type meetingAggregator struct {
+	d          meetingDetector
+	meetingMap map[string]*MeetingDetection // platform:cleanTitle -> meeting
+}
+
+func (d meetingDetector) newAggregator() *meetingAggregator {
+	return &meetingAggregator{d: d, meetingMap: make(map[string]*MeetingDetection)}
+}
+
+// add adds a focus event's duration to its meeting, grouped by platform and cleaned title.
+func (a *meetingAggregator) add(evt *storage.WindowFocusEvent) {
+	isMeeting, platform := a.d.detectFromTitle(evt.WindowTitle)
+	if !isMeeting {
+		return
+	}
+
+	cleanTitle := a.d.cleanTitle(evt.WindowTitle, platform)
+	key := platform + ":" + cleanTitle
+
+	if existing, ok := a.meetingMap[key]; ok {
+		existing.DurationSeconds += evt.DurationSeconds
+	} else {
+		a.meetingMap[key] = &MeetingDetection{
+			Platform:        platform,
+			Title:           cleanTitle,
+			WindowTitle:     evt.WindowTitle,
+			StartTime:       evt.StartTime,
+			DurationSeconds: evt.DurationSeconds,
+		}
+	}
+}
+
+// result returns the meetings, longest first.
+func (a *meetingAggregator) result() []MeetingDetection {
+	var meetings []MeetingDetection
+	for _, m := range a.meetingMap {
+		meetings = append(meetings, *m)
+	}
+	sort.Slice(meetings, func(i, j int) bool {
+		return meetings[i].DurationSeconds > meetings[j].DurationSeconds
+	})
+
+	return meetings
+}
+
+// cleanTitle extracts clean meeting title from raw window title.
+func (d meetingDetector) cleanTitle(windowTitle, platform string) string {
+	switch platform {
+	case "Slack":
+		// "Huddle: #eng-mv - Arcturus in Slackspace - Slack" → "#eng-mv"
+		if strings.Contains(windowTitle, "Huddle:") {
+			parts := strings.Split(windowTitle, "Huddle:")
+			if len(parts) > 1 {
+				afterHuddle := strings.TrimSpace(parts[1])
+				// Take up to first " - "
+				if idx := strings.Index(afterHuddle, " - "); idx > 0 {
+					return strings.TrimSpace(afterHuddle[:idx])
+				}
+				return afterHuddle
+			}
+		}
+		return "Slack Huddle"
+
+	case "Zoom":
+		// "Zoom Meeting" or specific meeting name
+		if strings.Contains(strings.ToLower(windowTitle), "zoom meeting") {
+			return "Zoom Meeting"
+		}
+		return windowTitle
+
+	case "Google Meet":
+		// "Meet - abc-defg-hij" → "abc-defg-hij"
+		if strings.HasPrefix(windowTitle, "Meet - ") {
+			return strings.TrimPrefix(windowTitle, "Meet - ")
+		}
+		return "Google Meet"
+
+	case "Teams":
+		// Extract meeting name if possible
+		return "Teams Meeting"
+	}
+
+	return windowTitle
+}
+
+// MeetingStats summarizes meeting load over a time range.
+type MeetingStats struct {
+	TotalMeetings          int              `json:"totalMeetings"`
+	TotalMeetingMinutes    int64            `json:"totalMeetingMinutes"`
+	MeetingPercentage      float64          `json:"meetingPercentage"`  // Share of focus time spent in meetings
+	MeetingsByPlatform     map[string]int64 `json:"meetingsByPlatform"` // Platform -> meeting minutes
+	LongestMeeting         MeetingDetection `json:"longestMeeting"`
+	AverageDurationMinutes float64          `json:"averageDurationMinutes"`
+	MeetingFreeDayStreak   int              `json:"meetingFreeDayStreak"` // Consecutive days without a meeting, ending at the last completed day in the range
+}
+
+// GetMeetingStats returns meeting analytics for a time range.
+// Meetings are detected per day, so a recurring call with the same title counts once per day it happens.
+func (s *AnalyticsService) GetMeetingStats(start, end int64) (*MeetingStats, error) {
+	events, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &MeetingStats{MeetingsByPlatform: make(map[string]int64)}
+
+	// Group events by local day
+	eventsByDay := make(map[string][]*storage.WindowFocusEvent)
+	var totalSeconds, meetingSeconds float64
+	for _, evt := range events {
+		day := time.Unix(evt.StartTime, 0).Format("2006-01-02")
+		eventsByDay[day] = append(eventsByDay[day], evt)
+		totalSeconds += evt.DurationSeconds
+	}
+
+	meetingDays := make(map[string]bool)
+	for day, dayEvents := range eventsByDay {
+		meetings := s.meetings.detect(dayEvents)
+		if len(meetings) > 0 {
+			meetingDays[day] = true
+		}
+		for _, m := range meetings {
+			stats.TotalMeetings++
+			meetingSeconds += m.DurationSeconds
+			stats.MeetingsByPlatform[m.Platform] += int64(m.DurationSeconds / 60)
+			if m.DurationSeconds > stats.LongestMeeting.DurationSeconds {
+				stats.LongestMeeting = m
+			}
+		}
+	}
+
+	stats.TotalMeetingMinutes = int64(meetingSeconds / 60)
+	if totalSeconds > 0 {
+		stats.MeetingPercentage = meetingSeconds / totalSeconds * 100
+	}
+	if stats.TotalMeetings > 0 {
+		stats.AverageDurationMinutes = meetingSeconds / 60 / float64(stats.TotalMeetings)
+	}
+
+	// Count meeting-free days back from the last completed day in the range. Today is
+	// still in progress, so it doesn't count until a meeting can no longer start in it.
+	first := time.Unix(start, 0)
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+	last := time.Unix(end, 0)
+	lastDay := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.Local)
+	now := time.Now()
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local); !lastDay.Before(today) {
+		lastDay = today.AddDate(0, 0, -1)
+	}
+	for day := lastDay; !day.Before(firstDay); day = day.AddDate(0, 0, -1) {
+		if meetingDays[day.Format("2006-01-02")] {
+			break
+		}
+		stats.MeetingFreeDayStreak++
+	}
+
+	return stats, nil
+}
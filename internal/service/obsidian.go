@@ -0,0 +1,241 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// obsidianTopDomains is how many domains are listed in a daily note's Browser section.
+const obsidianTopDomains = 10
+
+// obsidianSourceMarker is the frontmatter line marking a note as written by Traq. Only notes
+// carrying it are overwritten.
+const obsidianSourceMarker = "source: traq"
+
+// ObsidianExporter writes daily notes in Obsidian's format from the day's sessions,
+// commits, browsing and meetings.
+type ObsidianExporter struct {
+	store    *storage.Store
+	meetings meetingDetector
+}
+
+// NewObsidianExporter creates a new ObsidianExporter.
+func NewObsidianExporter(store *storage.Store) *ObsidianExporter {
+	return &ObsidianExporter{store: store}
+}
+
+// ExportDailyNote writes the daily note for a date (YYYY-MM-DD) to destDir/YYYY-MM-DD.md,
+// replacing any previous export. A note at that path not written by Traq is left alone and
+// an error is returned. Returns the path of the written file.
+func (e *ObsidianExporter) ExportDailyNote(date string, destDir string) (string, error) {
+	if destDir == "" {
+		return "", fmt.Errorf("obsidian vault path is not set")
+	}
+
+	note, err := e.buildDailyNote(date)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(destDir, date+".md")
+	if existing, err := os.ReadFile(path); err == nil && !isTraqNote(string(existing)) {
+		return "", fmt.Errorf("daily note %s already exists and wasn't written by Traq", path)
+	} else if err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to read existing daily note: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(note), 0644); err != nil {
+		return "", fmt.Errorf("failed to write daily note: %w", err)
+	}
+	return path, nil
+}
+
+// buildDailyNote renders the daily note: YAML frontmatter followed by Sessions, Commits,
+// Browser and Meetings sections.
+func (e *ObsidianExporter) buildDailyNote(date string) (string, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return "", fmt.Errorf("invalid date format: %w", err)
+	}
+	dayStart := t.Unix()
+	dayEnd := t.AddDate(0, 0, 1).Unix() - 1
+
+	sessions, err := e.store.GetSessionsByTimeRange(dayStart, dayEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sessions: %w", err)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime < sessions[j].StartTime })
+
+	sessionIDs := make([]int64, len(sessions))
+	for i, sess := range sessions {
+		sessionIDs[i] = sess.ID
+	}
+	summaries, _ := e.store.GetSummariesForSessions(sessionIDs)
+
+	commits, err := e.store.GetGitCommitsByTimeRange(dayStart, dayEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commits: %w", err)
+	}
+	domains, err := e.store.GetTopDomains(dayStart, dayEnd, obsidianTopDomains)
+	if err != nil {
+		return "", fmt.Errorf("failed to get browser history: %w", err)
+	}
+	focusEvents, err := e.store.GetWindowFocusEventsByTimeRange(dayStart, dayEnd)
+	if err != nil {
+		return "", fmt.Errorf("failed to get focus events: %w", err)
+	}
+	meetings := e.meetings.detect(focusEvents)
+	sort.Slice(meetings, func(i, j int) bool { return meetings[i].StartTime < meetings[j].StartTime })
+
+	var totalSeconds int64
+	var tags []string
+	seenTags := make(map[string]bool)
+	for _, sess := range sessions {
+		totalSeconds += sessionLengthSeconds(sess)
+		if summary := summaries[sess.ID]; summary != nil {
+			for _, tag := range summary.Tags {
+				tag = obsidianTag(tag)
+				if tag != "" && !seenTags[tag] {
+					seenTags[tag] = true
+					tags = append(tags, tag)
+				}
+			}
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(formatObsidianFrontmatter(date, totalSeconds, len(commits), tags))
+
+	sb.WriteString("\n## Sessions\n\n")
+	if len(sessions) == 0 {
+		sb.WriteString("_No sessions recorded._\n")
+	}
+	for _, sess := range sessions {
+		line := fmt.Sprintf("- %s (%s)", time.Unix(sess.StartTime, 0).Format("15:04"), formatMinutes(sessionLengthSeconds(sess)/60))
+		if summary := summaries[sess.ID]; summary != nil && summary.Summary != "" {
+			line += " " + strings.Join(strings.Fields(summary.Summary), " ")
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("\n## Commits\n\n")
+	if len(commits) == 0 {
+		sb.WriteString("_No commits._\n")
+	}
+	repoNames := make(map[int64]string)
+	for _, commit := range commits {
+		name, ok := repoNames[commit.RepositoryID]
+		if !ok {
+			if repo, err := e.store.GetGitRepository(commit.RepositoryID); err == nil && repo != nil {
+				name = repo.Name
+			}
+			repoNames[commit.RepositoryID] = name
+		}
+		line := fmt.Sprintf("- %s `%s` %s", time.Unix(commit.Timestamp, 0).Format("15:04"), commit.ShortHash, commit.MessageSubject)
+		if name != "" {
+			line += fmt.Sprintf(" (%s)", name)
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	sb.WriteString("\n## Browser\n\n")
+	if len(domains) == 0 {
+		sb.WriteString("_No browsing recorded._\n")
+	}
+	for _, d := range domains {
+		sb.WriteString(fmt.Sprintf("- %s (%s)\n", d.Domain, formatVisitCount(d.VisitCount)))
+	}
+
+	sb.WriteString("\n## Meetings\n\n")
+	if len(meetings) == 0 {
+		sb.WriteString("_No meetings._\n")
+	}
+	for _, m := range meetings {
+		sb.WriteString(fmt.Sprintf("- %s %s (%s, %s)\n", time.Unix(m.StartTime, 0).Format("15:04"), m.Title, m.Platform, formatMinutes(int64(m.DurationSeconds/60))))
+	}
+
+	return sb.String(), nil
+}
+
+// formatObsidianFrontmatter renders the YAML frontmatter of a daily note.
+func formatObsidianFrontmatter(date string, totalSeconds int64, commits int, tags []string) string {
+	var sb strings.Builder
+	sb.WriteString("---\n")
+	sb.WriteString(fmt.Sprintf("date: %s\n", date))
+	sb.WriteString(fmt.Sprintf("total_time: %s\n", yamlString(formatMinutes(totalSeconds/60))))
+	sb.WriteString(fmt.Sprintf("commits: %d\n", commits))
+	if len(tags) == 0 {
+		sb.WriteString("tags: []\n")
+	} else {
+		sb.WriteString("tags:\n")
+		for _, tag := range tags {
+			sb.WriteString(fmt.Sprintf("  - %s\n", yamlString(tag)))
+		}
+	}
+	sb.WriteString(obsidianSourceMarker + "\n")
+	sb.WriteString("---\n")
+	return sb.String()
+}
+
+// isTraqNote reports whether a note's frontmatter carries obsidianSourceMarker.
+func isTraqNote(note string) bool {
+	if !strings.HasPrefix(note, "---\n") {
+		return false
+	}
+	for _, line := range strings.Split(note, "\n")[1:] {
+		line = strings.TrimSpace(line)
+		if line == "---" {
+			return false
+		}
+		if line == obsidianSourceMarker {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionLengthSeconds returns a session's length, counting an open session up to now.
+func sessionLengthSeconds(sess *storage.Session) int64 {
+	if sess.DurationSeconds.Valid {
+		return sess.DurationSeconds.Int64
+	}
+	end := time.Now().Unix()
+	if sess.EndTime.Valid {
+		end = sess.EndTime.Int64
+	}
+	if end < sess.StartTime {
+		return 0
+	}
+	return end - sess.StartTime
+}
+
+// obsidianTag converts a summary tag to a valid Obsidian tag, which can't contain spaces or '#'.
+func obsidianTag(tag string) string {
+	tag = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+	return strings.Join(strings.Fields(tag), "-")
+}
+
+// yamlString returns s as a YAML scalar, quoting it when it would otherwise be parsed as
+// something other than a plain string.
+func yamlString(s string) string {
+	if s == "" || strings.ContainsAny(s, ":#,[]{}&*!|>'\"%@`") || strings.TrimSpace(s) != s {
+		return strconv.Quote(s)
+	}
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	if _, err := strconv.ParseFloat(s, 64); err == nil {
+		return strconv.Quote(s)
+	}
+	return s
+}
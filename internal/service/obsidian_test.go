@@ -0,0 +1,119 @@
+package service
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestObsidianExporter_ExportDailyNote(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	exporter := NewObsidianExporter(store)
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	morning := day.Add(9 * time.Hour)
+	afternoon := day.Add(14 * time.Hour)
+
+	morningID, _ := store.CreateSession(morning.Unix())
+	store.EndSession(morningID, morning.Add(90*time.Minute).Unix())
+	afternoonID, _ := store.CreateSession(afternoon.Unix())
+	store.EndSession(afternoonID, afternoon.Add(45*time.Minute).Unix())
+	store.SaveSummary(&storage.Summary{
+		SessionID: sql.NullInt64{Int64: morningID, Valid: true},
+		Summary:   "Refactored the report cache",
+		Tags:      []string{"coding", "code review"},
+	})
+	store.SaveSummary(&storage.Summary{
+		SessionID: sql.NullInt64{Int64: afternoonID, Valid: true},
+		Summary:   "Planning",
+		Tags:      []string{"#coding", "planning:q2"},
+	})
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	store.SaveGitCommit(&storage.GitCommit{
+		Timestamp:      morning.Add(30 * time.Minute).Unix(),
+		CommitHash:     "abc1234def",
+		ShortHash:      "abc1234",
+		RepositoryID:   repoID,
+		Message:        "Add report cache",
+		MessageSubject: "Add report cache",
+	})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		WindowTitle:     "Zoom Meeting",
+		AppName:         "zoom",
+		StartTime:       afternoon.Unix(),
+		EndTime:         afternoon.Add(30 * time.Minute).Unix(),
+		DurationSeconds: 1800,
+	})
+
+	dir := filepath.Join(t.TempDir(), "vault")
+	path, err := exporter.ExportDailyNote("2026-03-10", dir)
+	if err != nil {
+		t.Fatalf("ExportDailyNote failed: %v", err)
+	}
+	if path != filepath.Join(dir, "2026-03-10.md") {
+		t.Errorf("expected note named after the date, got %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read note: %v", err)
+	}
+	note := string(data)
+
+	frontmatter := "---\n" +
+		"date: 2026-03-10\n" +
+		"total_time: 2h 15m\n" +
+		"commits: 1\n" +
+		"tags:\n" +
+		"  - coding\n" +
+		"  - code-review\n" +
+		"  - \"planning:q2\"\n" +
+		"source: traq\n" +
+		"---\n"
+	if !strings.HasPrefix(note, frontmatter) {
+		t.Errorf("expected frontmatter:\n%s\ngot:\n%s", frontmatter, note)
+	}
+
+	for _, want := range []string{
+		"## Sessions\n\n- 09:00 (1h 30m) Refactored the report cache\n- 14:00 (45m) Planning\n",
+		"## Commits\n\n- 09:30 `abc1234` Add report cache (traq)\n",
+		"## Browser\n\n_No browsing recorded._\n",
+		"## Meetings\n\n- 14:00 Zoom Meeting (Zoom, 30m)\n",
+	} {
+		if !strings.Contains(note, want) {
+			t.Errorf("expected note to contain %q, got:\n%s", want, note)
+		}
+	}
+
+	// A previous export is replaced, but a note the user wrote isn't
+	if _, err := exporter.ExportDailyNote("2026-03-10", dir); err != nil {
+		t.Errorf("expected re-export to replace the note, got %v", err)
+	}
+	userNote := "---\ndate: 2026-03-11\n---\nMy own notes\n"
+	userPath := filepath.Join(dir, "2026-03-11.md")
+	os.WriteFile(userPath, []byte(userNote), 0644)
+	if _, err := exporter.ExportDailyNote("2026-03-11", dir); err == nil {
+		t.Error("expected an error exporting over a user's note")
+	}
+	if data, _ := os.ReadFile(userPath); string(data) != userNote {
+		t.Errorf("expected the user's note to be untouched, got:\n%s", data)
+	}
+}
+
+func TestFormatObsidianFrontmatter_NoTags(t *testing.T) {
+	got := formatObsidianFrontmatter("2026-03-11", 0, 0, nil)
+	want := "---\ndate: 2026-03-11\ntotal_time: 0m\ncommits: 0\ntags: []\nsource: traq\n---\n"
+	if got != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, got)
+	}
+
+	if _, err := NewObsidianExporter(nil).ExportDailyNote("2026-03-11", ""); err == nil {
+		t.Error("expected error without a vault path")
+	}
+}
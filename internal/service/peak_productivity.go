@@ -0,0 +1,157 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// peakSlotMinutes is the size of the time-of-day slots productivity is averaged over.
+	peakSlotMinutes = 30
+	// peakSlotsPerDay is the number of slots in a day.
+	peakSlotsPerDay = 24 * 60 / peakSlotMinutes
+	// peakWindowSlots is the number of consecutive slots in a peak window (90 minutes).
+	peakWindowSlots = 3
+	// peakWindowCount is how many peak windows are returned.
+	peakWindowCount = 3
+	// defaultPeakWindowWeeks is the history analyzed when no number of weeks is given.
+	defaultPeakWindowWeeks = 4
+)
+
+// PeakWindows lists the times of day that are usually the most productive.
+type PeakWindows struct {
+	PeakWindows []*TimeWindow `json:"peakWindows"` // Best first
+	DataWeeks   int           `json:"dataWeeks"`   // Weeks of history analyzed
+}
+
+// TimeWindow is a 90-minute stretch of the day ranked by average productivity.
+type TimeWindow struct {
+	StartHour                int     `json:"startHour"`
+	StartMinute              int     `json:"startMinute"`
+	EndHour                  int     `json:"endHour"`
+	EndMinute                int     `json:"endMinute"`
+	AverageProductiveMinutes float64 `json:"averageProductiveMinutes"` // Per active day
+	AverageContextSwitches   float64 `json:"averageContextSwitches"`   // Per active day
+	Rank                     int     `json:"rank"`                     // 1 is the most productive
+}
+
+// GetPeakProductivityWindows analyzes the last numWeeks of focus data and returns the
+// three non-overlapping 90-minute windows of the day with the most productive time.
+// If numWeeks <= 0, four weeks are analyzed.
+func (s *AnalyticsService) GetPeakProductivityWindows(numWeeks int) (*PeakWindows, error) {
+	return s.peakProductivityWindows(time.Now().Unix(), numWeeks)
+}
+
+// peakProductivityWindows computes peak windows for the numWeeks weeks ending at end.
+func (s *AnalyticsService) peakProductivityWindows(end int64, numWeeks int) (*PeakWindows, error) {
+	if numWeeks <= 0 {
+		numWeeks = defaultPeakWindowWeeks
+	}
+	start := time.Unix(end, 0).AddDate(0, 0, -7*numWeeks).Unix()
+
+	events, err := s.store.GetFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+
+	var productiveSeconds [peakSlotsPerDay]float64
+	var switches [peakSlotsPerDay]int
+	activeDays := make(map[string]bool)
+	categories := make(map[string]AppCategory)
+
+	for _, evt := range events {
+		from, to := max(evt.StartTime, start), min(evt.EndTime, end)
+		if to <= from {
+			continue
+		}
+		fromTime := time.Unix(from, 0).In(time.Local)
+		activeDays[fromTime.Format("2006-01-02")] = true
+		switches[peakSlotIndex(fromTime)]++
+
+		category, ok := categories[evt.AppName]
+		if !ok {
+			category = s.CategorizeApp(evt.AppName)
+			categories[evt.AppName] = category
+		}
+		if category != CategoryProductive {
+			continue
+		}
+
+		// Spread the event over the slots it covers
+		for cursor := from; cursor < to; {
+			t := time.Unix(cursor, 0).In(time.Local)
+			slotStart := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()/peakSlotMinutes*peakSlotMinutes, 0, 0, time.Local)
+			next := min(slotStart.Add(peakSlotMinutes*time.Minute).Unix(), to)
+			productiveSeconds[peakSlotIndex(t)] += float64(next - cursor)
+			cursor = next
+		}
+	}
+
+	result := &PeakWindows{PeakWindows: []*TimeWindow{}, DataWeeks: numWeeks}
+	days := float64(len(activeDays))
+	if days == 0 {
+		return result, nil
+	}
+
+	candidates := make([]*TimeWindow, 0, peakSlotsPerDay-peakWindowSlots+1)
+	for slot := 0; slot+peakWindowSlots <= peakSlotsPerDay; slot++ {
+		var seconds float64
+		var count int
+		for i := slot; i < slot+peakWindowSlots; i++ {
+			seconds += productiveSeconds[i]
+			count += switches[i]
+		}
+		if seconds == 0 {
+			continue
+		}
+		startMinutes := slot * peakSlotMinutes
+		endMinutes := (startMinutes + peakWindowSlots*peakSlotMinutes) % (24 * 60)
+		candidates = append(candidates, &TimeWindow{
+			StartHour:                startMinutes / 60,
+			StartMinute:              startMinutes % 60,
+			EndHour:                  endMinutes / 60,
+			EndMinute:                endMinutes % 60,
+			AverageProductiveMinutes: seconds / 60 / days,
+			AverageContextSwitches:   float64(count) / days,
+		})
+	}
+
+	// Most productive first; fewer context switches breaks ties
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].AverageProductiveMinutes != candidates[j].AverageProductiveMinutes {
+			return candidates[i].AverageProductiveMinutes > candidates[j].AverageProductiveMinutes
+		}
+		return candidates[i].AverageContextSwitches < candidates[j].AverageContextSwitches
+	})
+
+	for _, candidate := range candidates {
+		if len(result.PeakWindows) == peakWindowCount {
+			break
+		}
+		if overlapsPeakWindow(candidate, result.PeakWindows) {
+			continue
+		}
+		candidate.Rank = len(result.PeakWindows) + 1
+		result.PeakWindows = append(result.PeakWindows, candidate)
+	}
+
+	return result, nil
+}
+
+// peakSlotIndex returns the time-of-day slot containing t.
+func peakSlotIndex(t time.Time) int {
+	return (t.Hour()*60 + t.Minute()) / peakSlotMinutes
+}
+
+// overlapsPeakWindow reports whether w shares any slot with one of the chosen windows.
+func overlapsPeakWindow(w *TimeWindow, chosen []*TimeWindow) bool {
+	start := w.StartHour*60 + w.StartMinute
+	for _, c := range chosen {
+		cStart := c.StartHour*60 + c.StartMinute
+		if start < cStart+peakWindowSlots*peakSlotMinutes && cStart < start+peakWindowSlots*peakSlotMinutes {
+			return true
+		}
+	}
+	return false
+}
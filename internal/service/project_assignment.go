@@ -17,6 +17,7 @@ type ProjectAssignmentService struct {
 	store        *storage.Store
 	patternCache *PatternCache
 	reports      *ReportsService // Set after creation to avoid circular dependency
+	completer    TextCompleter   // Optional AI fallback for SuggestProjectForFocusEvent
 }
 
 // PatternCache holds in-memory pattern rules for fast matching.
@@ -273,6 +274,7 @@ func (s *ProjectAssignmentService) CreateProject(name, color, description string
 	if err != nil {
 		return nil, err
 	}
+	s.clearSuggestionCache()
 	return project, nil
 }
 
@@ -288,7 +290,11 @@ func (s *ProjectAssignmentService) GetProject(id int64) (*storage.Project, error
 
 // UpdateProject updates a project.
 func (s *ProjectAssignmentService) UpdateProject(id int64, name, color, description string) error {
-	return s.store.UpdateProject(id, name, color, description)
+	err := s.store.UpdateProject(id, name, color, description)
+	if err == nil {
+		s.clearSuggestionCache()
+	}
+	return err
 }
 
 // DeleteProject deletes a project and clears its assignments.
@@ -296,6 +302,7 @@ func (s *ProjectAssignmentService) DeleteProject(id int64) error {
 	err := s.store.DeleteProject(id)
 	if err == nil {
 		s.refreshPatternCache()
+		s.clearSuggestionCache()
 	}
 	return err
 }
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+// DetectionSourceCommitMessage is the rule source type matched against git commit messages.
+const DetectionSourceCommitMessage = "commit_message"
+
+// CreateDetectionRule creates a rule that extracts a project name with a regex. captureGroup
+// selects the text used as the name: 0 for the full match, 1+ for a capture group.
+func (s *ProjectAssignmentService) CreateDetectionRule(sourceType, pattern string, captureGroup int) (*storage.ProjectDetectionRule, error) {
+	if sourceType != DetectionSourceCommitMessage {
+		return nil, errInvalidInput("invalid sourceType: " + sourceType)
+	}
+	if pattern == "" {
+		return nil, errInvalidInput("pattern is required")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errInvalidInput("invalid regex pattern: " + err.Error())
+	}
+	if captureGroup < 0 || captureGroup > re.NumSubexp() {
+		return nil, errInvalidInput("captureGroup is out of range for pattern")
+	}
+
+	id, err := s.store.CreateProjectDetectionRule(sourceType, pattern, captureGroup)
+	if err != nil {
+		return nil, err
+	}
+	return &storage.ProjectDetectionRule{
+		ID:           id,
+		SourceType:   sourceType,
+		Pattern:      pattern,
+		CaptureGroup: captureGroup,
+	}, nil
+}
+
+// GetDetectionRules returns all project detection rules.
+func (s *ProjectAssignmentService) GetDetectionRules() ([]storage.ProjectDetectionRule, error) {
+	return s.store.GetProjectDetectionRules("")
+}
+
+// DeleteDetectionRule removes a project detection rule.
+func (s *ProjectAssignmentService) DeleteDetectionRule(id int64) error {
+	return s.store.DeleteProjectDetectionRule(id)
+}
+
+// compiledDetectionRule is a ProjectDetectionRule with its regex compiled.
+type compiledDetectionRule struct {
+	re           *regexp.Regexp
+	captureGroup int
+}
+
+// projectDetector extracts project names from text using detection rules, in creation order.
+type projectDetector []compiledDetectionRule
+
+// newProjectDetector loads the detection rules for a source type. Rules whose pattern no
+// longer compiles are skipped.
+func newProjectDetector(store *storage.Store, sourceType string) projectDetector {
+	rules, err := store.GetProjectDetectionRules(sourceType)
+	if err != nil {
+		log.Printf("Failed to load project detection rules: %v", err)
+		return nil
+	}
+
+	var detector projectDetector
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil || rule.CaptureGroup < 0 || rule.CaptureGroup > re.NumSubexp() {
+			log.Printf("Skipping invalid project detection rule %d: %q", rule.ID, rule.Pattern)
+			continue
+		}
+		detector = append(detector, compiledDetectionRule{re: re, captureGroup: rule.CaptureGroup})
+	}
+	return detector
+}
+
+// detect returns the project name extracted by the first matching rule, or "" if none match.
+func (d projectDetector) detect(text string) string {
+	for _, rule := range d {
+		match := rule.re.FindStringSubmatch(text)
+		if match == nil {
+			continue
+		}
+		if name := strings.TrimSpace(match[rule.captureGroup]); name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// detectProjectFromCommit returns the project for a commit, preferring commit message
+// rules over the repository.
+func (s *ReportsService) detectProjectFromCommit(detector projectDetector, commit *storage.GitCommit, repoPath string) string {
+	if name := detector.detect(commit.Message); name != "" {
+		return name
+	}
+	return s.DetectProjectFromGitRepo(repoPath)
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+// aiSuggestionConfidence is the confidence assigned to AI classifications.
+// The model doesn't report its own confidence, so a fixed moderate value is used.
+const aiSuggestionConfidence = 0.5
+
+// TextCompleter sends a prompt to a language model. inference.Service satisfies this interface.
+type TextCompleter interface {
+	Complete(prompt string) (string, error)
+}
+
+// SetInference sets the model used to classify events that no rule matches.
+// If never set (or nil), suggestions fall back to rules only.
+func (s *ProjectAssignmentService) SetInference(completer TextCompleter) {
+	s.completer = completer
+}
+
+// SuggestProjectForFocusEvent suggests a project for a focus event.
+// The pipeline checks, in order: an explicit user assignment, the rule engine, and AI classification.
+// Returns an empty project name if nothing matches. Source is "user", "rule" or "ai".
+func (s *ProjectAssignmentService) SuggestProjectForFocusEvent(evt *storage.WindowFocusEvent) (projectName string, confidence float64, source string, err error) {
+	if evt == nil {
+		return "", 0, "", fmt.Errorf("focus event is nil")
+	}
+
+	// 1. Explicit user assignment always wins
+	if evt.ProjectID.Valid && evt.ProjectSource.Valid && evt.ProjectSource.String == "user" {
+		project, err := s.store.GetProject(evt.ProjectID.Int64)
+		if err != nil {
+			return "", 0, "", err
+		}
+		if project != nil {
+			return project.Name, 1.0, "user", nil
+		}
+	}
+
+	// 2. Rule engine
+	ctx := &storage.AssignmentContext{
+		AppName:     evt.AppName,
+		WindowTitle: evt.WindowTitle,
+	}
+	if isBrowser(evt.AppName) {
+		ctx.URL = extractURLFromTitle(evt.WindowTitle)
+		ctx.Domain = extractDomain(ctx.URL)
+	}
+	if match := s.matchPatterns(ctx); match != nil {
+		return match.ProjectName, match.Confidence, "rule", nil
+	}
+
+	// 3. AI classification, cached by window title
+	if s.completer == nil || strings.TrimSpace(evt.WindowTitle) == "" {
+		return "", 0, "", nil
+	}
+
+	cached, err := s.store.GetProjectSuggestionCache(evt.WindowTitle)
+	if err != nil {
+		return "", 0, "", err
+	}
+	if cached != nil {
+		if cached.ProjectName == "" {
+			return "", 0, "", nil
+		}
+		return cached.ProjectName, cached.Confidence, "ai", nil
+	}
+
+	projects, err := s.store.GetProjects()
+	if err != nil {
+		return "", 0, "", err
+	}
+	if len(projects) == 0 {
+		return "", 0, "", nil
+	}
+
+	response, err := s.completer.Complete(buildProjectClassificationPrompt(evt, projects))
+	if err != nil {
+		return "", 0, "", fmt.Errorf("failed to classify project: %w", err)
+	}
+
+	projectName = matchProjectName(response, projects)
+	if projectName != "" {
+		confidence = aiSuggestionConfidence
+	}
+
+	// Cache misses too, so titles without a project aren't re-sent to the model
+	if err := s.store.SaveProjectSuggestionCache(evt.WindowTitle, projectName, confidence); err != nil {
+		log.Printf("Failed to cache project suggestion: %v", err)
+	}
+
+	if projectName == "" {
+		return "", 0, "", nil
+	}
+	return projectName, confidence, "ai", nil
+}
+
+// SuggestProjectForEvent suggests a project for a focus event by ID.
+func (s *ProjectAssignmentService) SuggestProjectForEvent(eventID int64) (*AssignmentResult, error) {
+	evt, err := s.store.GetFocusEventByID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	name, confidence, source, err := s.SuggestProjectForFocusEvent(evt)
+	if err != nil || name == "" {
+		return nil, err
+	}
+
+	projects, err := s.store.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		if project.Name == name {
+			return &AssignmentResult{
+				ProjectID:   project.ID,
+				ProjectName: project.Name,
+				Color:       project.Color,
+				Confidence:  confidence,
+				Source:      source,
+				Reason:      fmt.Sprintf("Suggested by %s", source),
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// BulkSuggestProjects assigns suggested projects to unassigned focus events in a time range.
+// Events the user already assigned are left alone. Returns the number of events assigned.
+func (s *ProjectAssignmentService) BulkSuggestProjects(start, end int64) (int, error) {
+	events, err := s.store.GetFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	projects, err := s.store.GetProjects()
+	if err != nil {
+		return 0, err
+	}
+	projectIDs := make(map[string]int64, len(projects))
+	for _, p := range projects {
+		projectIDs[p.Name] = p.ID
+	}
+
+	assigned := 0
+	for _, evt := range events {
+		if evt.ProjectID.Valid {
+			continue
+		}
+
+		name, confidence, source, err := s.SuggestProjectForFocusEvent(evt)
+		if err != nil {
+			// Keep going: one failed AI call shouldn't abort the whole range
+			log.Printf("Failed to suggest project for focus event %d: %v", evt.ID, err)
+			continue
+		}
+		projectID, ok := projectIDs[name]
+		if name == "" || !ok {
+			continue
+		}
+
+		if err := s.store.SetEventProject("focus", evt.ID, projectID, confidence, source); err != nil {
+			return assigned, err
+		}
+		assigned++
+	}
+
+	return assigned, nil
+}
+
+// clearSuggestionCache drops cached AI classifications after the project list changes,
+// since cached answers (including "no project") may no longer be valid.
+func (s *ProjectAssignmentService) clearSuggestionCache() {
+	if err := s.store.ClearProjectSuggestionCache(); err != nil {
+		log.Printf("Failed to clear project suggestion cache: %v", err)
+	}
+}
+
+// buildProjectClassificationPrompt asks the model to pick one of the known projects for an event.
+func buildProjectClassificationPrompt(evt *storage.WindowFocusEvent, projects []storage.Project) string {
+	var sb strings.Builder
+	sb.WriteString("Classify this computer activity into one of the user's projects.\n\n")
+	sb.WriteString(fmt.Sprintf("App: %s\n", evt.AppName))
+	sb.WriteString(fmt.Sprintf("Window title: %s\n\n", evt.WindowTitle))
+	sb.WriteString("Projects:\n")
+	for _, p := range projects {
+		if p.Description != "" {
+			sb.WriteString(fmt.Sprintf("- %s: %s\n", p.Name, p.Description))
+		} else {
+			sb.WriteString(fmt.Sprintf("- %s\n", p.Name))
+		}
+	}
+	sb.WriteString("\nRespond with only the project name from the list, or \"none\" if no project fits.")
+	return sb.String()
+}
+
+// matchProjectName maps a model response to a known project name (case-insensitive).
+// Returns an empty string if the response doesn't name a known project.
+func matchProjectName(response string, projects []storage.Project) string {
+	answer := strings.TrimSpace(strings.SplitN(strings.TrimSpace(response), "\n", 2)[0])
+	answer = strings.TrimPrefix(answer, "- ")
+	answer = strings.Trim(answer, "\"'`*. ")
+
+	for _, p := range projects {
+		if strings.EqualFold(answer, p.Name) {
+			return p.Name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// mockCompleter returns a fixed response and counts calls.
+type mockCompleter struct {
+	response string
+	calls    int
+	prompt   string // Last prompt received
+}
+
+func (m *mockCompleter) Complete(prompt string) (string, error) {
+	m.calls++
+	m.prompt = prompt
+	return m.response, nil
+}
+
+func TestSuggestProjectForFocusEvent(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	// Create rules before the service so its pattern cache picks them up
+	traq, err := store.CreateProject("traq", "#3b82f6", "Activity tracker")
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	blog, err := store.CreateProject("blog", "#10b981", "Personal website")
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := store.CreatePattern(traq.ID, "window_title", "traq", "contains", 1.0); err != nil {
+		t.Fatalf("CreatePattern failed: %v", err)
+	}
+	svc := NewProjectAssignmentService(store)
+
+	completer := &mockCompleter{response: "Blog\n"}
+	svc.SetInference(completer)
+
+	// Rule match
+	name, _, source, err := svc.SuggestProjectForFocusEvent(&storage.WindowFocusEvent{
+		AppName:     "code",
+		WindowTitle: "main.go - traq",
+	})
+	if err != nil {
+		t.Fatalf("SuggestProjectForFocusEvent failed: %v", err)
+	}
+	if name != "traq" || source != "rule" {
+		t.Errorf("expected traq from rule, got %q from %q", name, source)
+	}
+
+	// User assignment wins over rules
+	evt := &storage.WindowFocusEvent{AppName: "code", WindowTitle: "main.go - traq"}
+	evt.ProjectID.Int64, evt.ProjectID.Valid = blog.ID, true
+	evt.ProjectSource.String, evt.ProjectSource.Valid = "user", true
+	name, confidence, source, err := svc.SuggestProjectForFocusEvent(evt)
+	if err != nil {
+		t.Fatalf("SuggestProjectForFocusEvent failed: %v", err)
+	}
+	if name != "blog" || source != "user" || confidence != 1.0 {
+		t.Errorf("expected blog from user, got %q from %q (%.2f)", name, source, confidence)
+	}
+
+	// AI fallback, cached by window title
+	aiEvent := &storage.WindowFocusEvent{AppName: "firefox", WindowTitle: "Draft post - Ghost"}
+	for i := 0; i < 2; i++ {
+		name, _, source, err = svc.SuggestProjectForFocusEvent(aiEvent)
+		if err != nil {
+			t.Fatalf("SuggestProjectForFocusEvent failed: %v", err)
+		}
+		if name != "blog" || source != "ai" {
+			t.Errorf("expected blog from ai, got %q from %q", name, source)
+		}
+	}
+	if completer.calls != 1 {
+		t.Errorf("expected 1 model call, got %d", completer.calls)
+	}
+
+	// "none" is cached as no match
+	completer.response = "none"
+	for i := 0; i < 2; i++ {
+		name, _, _, err = svc.SuggestProjectForFocusEvent(&storage.WindowFocusEvent{AppName: "slack", WindowTitle: "general"})
+		if err != nil {
+			t.Fatalf("SuggestProjectForFocusEvent failed: %v", err)
+		}
+		if name != "" {
+			t.Errorf("expected no suggestion, got %q", name)
+		}
+	}
+	if completer.calls != 2 {
+		t.Errorf("expected 2 model calls, got %d", completer.calls)
+	}
+}
+
+func TestBulkSuggestProjects(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	traq, err := store.CreateProject("traq", "#3b82f6", "")
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	if _, err := store.CreatePattern(traq.ID, "window_title", "traq", "contains", 1.0); err != nil {
+		t.Fatalf("CreatePattern failed: %v", err)
+	}
+	svc := NewProjectAssignmentService(store)
+
+	now := time.Now().Unix()
+	titles := []string{"main.go - traq", "app.go - traq", "Inbox - Mail"}
+	var ids []int64
+	for i, title := range titles {
+		id, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         "code",
+			WindowTitle:     title,
+			StartTime:       now + int64(i*100),
+			EndTime:         now + int64(i*100) + 60,
+			DurationSeconds: 60,
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	// Already-assigned events are skipped
+	if err := svc.ManualAssign("focus", ids[1], traq.ID); err != nil {
+		t.Fatalf("ManualAssign failed: %v", err)
+	}
+
+	assigned, err := svc.BulkSuggestProjects(now-10, now+1000)
+	if err != nil {
+		t.Fatalf("BulkSuggestProjects failed: %v", err)
+	}
+	if assigned != 1 {
+		t.Errorf("expected 1 assigned event, got %d", assigned)
+	}
+
+	evt, err := store.GetFocusEventByID(ids[0])
+	if err != nil {
+		t.Fatalf("GetFocusEventByID failed: %v", err)
+	}
+	if !evt.ProjectID.Valid || evt.ProjectID.Int64 != traq.ID || evt.ProjectSource.String != "rule" {
+		t.Errorf("expected event assigned to traq by rule, got %+v", evt)
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// ProjectTimeline is the activity of one project over a time range, day by day.
+type ProjectTimeline struct {
+	Project           ProjectGroup          `json:"project"`
+	DailyActivity     []*ProjectDayActivity `json:"dailyActivity"` // Every day in the range, oldest first
+	SessionIDs        []int64               `json:"sessionIds"`    // Sessions with focus time on the project
+	TotalFocusSeconds float64               `json:"totalFocusSeconds"`
+}
+
+// ProjectDayActivity is a project's activity on one day.
+type ProjectDayActivity struct {
+	Date       string  `json:"date"`
+	Hours      float64 `json:"hours"`
+	Commits    int     `json:"commits"`
+	PrimaryApp string  `json:"primaryApp"` // App with the most focus time on the project, "" if none
+}
+
+// GetProjectTimeline returns a project's focus time, commits and sessions between start
+// and end. Only focus events and commits assigned to the project are counted; the
+// project name is matched case-insensitively.
+func (s *ProjectAssignmentService) GetProjectTimeline(projectName string, start, end int64) (*ProjectTimeline, error) {
+	project, err := s.projectByName(projectName)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.store.GetFocusEventsByProjectName(project.Name, start, end)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := s.store.GetGitCommitsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch git commits: %w", err)
+	}
+	allEvents, err := s.store.GetFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &ProjectTimeline{
+		Project: ProjectGroup{
+			Name:           project.Name,
+			Description:    project.Description,
+			Commits:        []*storage.GitCommit{},
+			Apps:           []string{},
+			Activities:     []string{},
+			DailyBreakdown: make(map[string]*ReportDailyStats),
+		},
+		DailyActivity: []*ProjectDayActivity{},
+		SessionIDs:    []int64{},
+	}
+
+	appSeconds := make(map[string]float64)
+	sessions := make(map[int64]bool)
+	for _, evt := range events {
+		seconds := clampedEventDuration(evt, start, end)
+		timeline.TotalFocusSeconds += seconds
+		appSeconds[evt.AppName] += seconds
+		if evt.SessionID.Valid && !sessions[evt.SessionID.Int64] {
+			sessions[evt.SessionID.Int64] = true
+			timeline.SessionIDs = append(timeline.SessionIDs, evt.SessionID.Int64)
+		}
+	}
+	sort.Slice(timeline.SessionIDs, func(i, j int) bool { return timeline.SessionIDs[i] < timeline.SessionIDs[j] })
+	timeline.Project.Apps = appsByTime(appSeconds)
+
+	for _, commit := range commits {
+		if commit.ProjectID.Valid && commit.ProjectID.Int64 == project.ID {
+			timeline.Project.Commits = append(timeline.Project.Commits, commit)
+		}
+	}
+
+	var allSeconds float64
+	for _, evt := range allEvents {
+		allSeconds += clampedEventDuration(evt, start, end)
+	}
+	timeline.Project.DurationSeconds = timeline.TotalFocusSeconds
+	timeline.Project.DurationMinutes = int64(timeline.TotalFocusSeconds / 60)
+	timeline.Project.CommitCount = len(timeline.Project.Commits)
+	if allSeconds > 0 {
+		timeline.Project.Percentage = timeline.TotalFocusSeconds / allSeconds * 100
+	}
+
+	first := time.Unix(start, 0)
+	first = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+	for day := first; day.Unix() <= end; day = day.AddDate(0, 0, 1) {
+		dayStart, dayEnd := day.Unix(), day.AddDate(0, 0, 1).Unix()
+		activity := &ProjectDayActivity{Date: day.Format("2006-01-02")}
+
+		dayApps := make(map[string]float64)
+		for _, evt := range events {
+			if seconds := clampedEventDuration(evt, max(start, dayStart), min(end, dayEnd)); seconds > 0 {
+				activity.Hours += seconds / 3600
+				dayApps[evt.AppName] += seconds
+			}
+		}
+		if apps := appsByTime(dayApps); len(apps) > 0 {
+			activity.PrimaryApp = apps[0]
+		}
+		for _, commit := range timeline.Project.Commits {
+			if commit.Timestamp >= dayStart && commit.Timestamp < dayEnd {
+				activity.Commits++
+			}
+		}
+
+		timeline.DailyActivity = append(timeline.DailyActivity, activity)
+		timeline.Project.DailyBreakdown[activity.Date] = &ReportDailyStats{
+			Date:            activity.Date,
+			DayOfWeek:       day.Weekday().String(),
+			DurationMinutes: int64(activity.Hours * 60),
+			CommitCount:     activity.Commits,
+			PrimaryFocus:    activity.PrimaryApp,
+		}
+	}
+
+	return timeline, nil
+}
+
+// projectByName finds a project by name, ignoring case.
+func (s *ProjectAssignmentService) projectByName(name string) (*storage.Project, error) {
+	projects, err := s.store.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	for i := range projects {
+		if strings.EqualFold(projects[i].Name, name) {
+			return &projects[i], nil
+		}
+	}
+	return nil, fmt.Errorf("project not found: %s", name)
+}
+
+// appsByTime returns app names ordered by time spent, most first.
+func appsByTime(seconds map[string]float64) []string {
+	apps := make([]string, 0, len(seconds))
+	for app, s := range seconds {
+		if s > 0 {
+			apps = append(apps, app)
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		if seconds[apps[i]] != seconds[apps[j]] {
+			return seconds[apps[i]] > seconds[apps[j]]
+		}
+		return apps[i] < apps[j]
+	})
+	return apps
+}
+
+// projectTimelines returns the timelines of all projects with focus time between start
+// and end, most focus time first.
+func (s *ProjectAssignmentService) projectTimelines(start, end int64) ([]*ProjectTimeline, error) {
+	projects, err := s.store.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	var timelines []*ProjectTimeline
+	for _, project := range projects {
+		timeline, err := s.GetProjectTimeline(project.Name, start, end)
+		if err != nil {
+			return nil, err
+		}
+		if timeline.TotalFocusSeconds > 0 {
+			timelines = append(timelines, timeline)
+		}
+	}
+	sort.SliceStable(timelines, func(i, j int) bool {
+		return timelines[i].TotalFocusSeconds > timelines[j].TotalFocusSeconds
+	})
+	return timelines, nil
+}
+
+// formatProjectTimelinesHTML renders each project's daily hours as a bar chart.
+func formatProjectTimelinesHTML(timelines []*ProjectTimeline) string {
+	var sb strings.Builder
+	sb.WriteString(`<div style="margin-bottom: 24px;">
+			<div class="report-card-title">Project Timelines</div>`)
+	for _, tl := range timelines {
+		var maxHours float64
+		for _, day := range tl.DailyActivity {
+			maxHours = math.Max(maxHours, day.Hours)
+		}
+
+		sb.WriteString(fmt.Sprintf(`
+			<div style="margin-bottom: 16px;">
+				<div style="display: flex; justify-content: space-between; font-size: 0.875rem; margin-bottom: 6px;">
+					<span class="report-project-title">%s</span>
+					<span class="report-stat-meta">%s, %d commits</span>
+				</div>
+				<div style="display: flex; align-items: flex-end; gap: 4px; height: 48px;">`,
+			esc(tl.Project.Name), formatHoursMinutesShort(tl.TotalFocusSeconds/3600), tl.Project.CommitCount))
+		for _, day := range tl.DailyActivity {
+			height := 0.0
+			if maxHours > 0 {
+				height = day.Hours / maxHours * 100
+			}
+			sb.WriteString(fmt.Sprintf(`<div title="%s: %s, %d commits" style="flex: 1; height: %.0f%%; min-height: 2px; background: #3b82f6; border-radius: 2px;"></div>`,
+				day.Date, formatHoursMinutesShort(day.Hours), day.Commits, height))
+		}
+		sb.WriteString(`</div>
+				<div style="display: flex; gap: 4px;">`)
+		for _, day := range tl.DailyActivity {
+			label := day.Date
+			if t, err := time.Parse("2006-01-02", day.Date); err == nil {
+				label = t.Format("Mon")
+			}
+			sb.WriteString(fmt.Sprintf(`<div class="report-stat-meta" style="flex: 1; text-align: center; font-size: 0.7rem;">%s</div>`, label))
+		}
+		sb.WriteString(`</div></div>`)
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
+
+// formatProjectTimelinesMarkdown renders each project's daily hours as a table row.
+func formatProjectTimelinesMarkdown(timelines []*ProjectTimeline) string {
+	var sb strings.Builder
+	sb.WriteString("## Project Timelines\n\n| Project |")
+	for _, day := range timelines[0].DailyActivity {
+		label := day.Date
+		if t, err := time.Parse("2006-01-02", day.Date); err == nil {
+			label = t.Format("Mon")
+		}
+		sb.WriteString(" " + label + " |")
+	}
+	sb.WriteString(" Total |\n|---------|")
+	for range timelines[0].DailyActivity {
+		sb.WriteString("-----|")
+	}
+	sb.WriteString("-------|\n")
+
+	for _, tl := range timelines {
+		sb.WriteString("| " + tl.Project.Name + " |")
+		for _, day := range tl.DailyActivity {
+			cell := "-"
+			if day.Hours > 0 {
+				cell = formatHoursMinutesShort(day.Hours)
+			}
+			sb.WriteString(" " + cell + " |")
+		}
+		sb.WriteString(" " + formatHoursMinutesShort(tl.TotalFocusSeconds/3600) + " |\n")
+	}
+	sb.WriteString("\n---\n\n")
+	return sb.String()
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestGetProjectTimeline(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewProjectAssignmentService(store)
+
+	traq, err := store.CreateProject("Traq", "#3b82f6", "Activity tracker")
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+
+	monday := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.Local)
+	sessionID, _ := store.CreateSession(monday.Add(9 * time.Hour).Unix())
+	save := func(app string, start time.Time, minutes int, projectID int64) {
+		t.Helper()
+		id, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         app,
+			WindowTitle:     "main.go - traq",
+			StartTime:       start.Unix(),
+			EndTime:         start.Add(time.Duration(minutes) * time.Minute).Unix(),
+			DurationSeconds: float64(minutes * 60),
+			SessionID:       sql.NullInt64{Int64: sessionID, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+		if projectID > 0 {
+			store.SetEventProject("focus", id, projectID, 1, "user")
+		}
+	}
+	save("code", monday.Add(9*time.Hour), 90, traq.ID)
+	save("firefox", monday.Add(11*time.Hour), 30, traq.ID)
+	save("slack", monday.Add(12*time.Hour), 60, 0)
+	save("firefox", monday.AddDate(0, 0, 2).Add(10*time.Hour), 60, traq.ID)
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	commitID, _ := store.SaveGitCommit(&storage.GitCommit{
+		Timestamp:      monday.Add(10 * time.Hour).Unix(),
+		CommitHash:     "abc123",
+		ShortHash:      "abc123",
+		RepositoryID:   repoID,
+		Message:        "Add project timeline",
+		MessageSubject: "Add project timeline",
+	})
+	store.SetEventProject("git", commitID, traq.ID, 1, "user")
+
+	end := monday.AddDate(0, 0, 7).Unix() - 1
+	timeline, err := svc.GetProjectTimeline("traq", monday.Unix(), end)
+	if err != nil {
+		t.Fatalf("GetProjectTimeline failed: %v", err)
+	}
+
+	if timeline.Project.Name != "Traq" || timeline.Project.CommitCount != 1 {
+		t.Errorf("project = %s with %d commits, want Traq with 1", timeline.Project.Name, timeline.Project.CommitCount)
+	}
+	if timeline.TotalFocusSeconds != 180*60 {
+		t.Errorf("TotalFocusSeconds = %.0f, want %d", timeline.TotalFocusSeconds, 180*60)
+	}
+	if timeline.Project.Percentage != 75 {
+		t.Errorf("Percentage = %.1f, want 75", timeline.Project.Percentage)
+	}
+	if len(timeline.SessionIDs) != 1 || timeline.SessionIDs[0] != sessionID {
+		t.Errorf("SessionIDs = %v, want [%d]", timeline.SessionIDs, sessionID)
+	}
+	if len(timeline.DailyActivity) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(timeline.DailyActivity))
+	}
+
+	mon, wed := timeline.DailyActivity[0], timeline.DailyActivity[2]
+	if mon.Date != "2026-03-02" || mon.Hours != 2 || mon.Commits != 1 || mon.PrimaryApp != "code" {
+		t.Errorf("Monday = %+v, want 2h, 1 commit, primary app code", mon)
+	}
+	if wed.Hours != 1 || wed.Commits != 0 || wed.PrimaryApp != "firefox" {
+		t.Errorf("Wednesday = %+v, want 1h in firefox", wed)
+	}
+	if tue := timeline.DailyActivity[1]; tue.Hours != 0 || tue.PrimaryApp != "" {
+		t.Errorf("Tuesday = %+v, want no activity", tue)
+	}
+
+	md := formatProjectTimelinesMarkdown([]*ProjectTimeline{timeline})
+	if !strings.Contains(md, "| Traq | 2h | - | 1h |") {
+		t.Errorf("markdown missing project row:\n%s", md)
+	}
+
+	if _, err := svc.GetProjectTimeline("missing", monday.Unix(), end); err == nil {
+		t.Error("expected error for unknown project")
+	}
+}
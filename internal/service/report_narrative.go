@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+)
+
+// narrativeTimeout bounds how long an AI narrative report waits for the model.
+const narrativeTimeout = 30 * time.Second
+
+// narrativeSystemPrompt instructs the model how to write an "ai_narrative" report.
+const narrativeSystemPrompt = `You are writing a work report for the person whose activity is described below.
+Write a concise narrative in Markdown, in the first person, covering:
+- An opening paragraph summarizing the period: total time, main focus and overall shape of the work.
+- A "## Highlights" section drawing on the key accomplishments.
+- A "## Projects" section with a short paragraph per significant project, in order of time spent.
+- A "## Meetings" section only if meetings were held.
+Use only facts present in the data. Do not invent projects, numbers or outcomes, and do not
+mention that the report was generated from data. Respond with the report only.`
+
+// narrativeContext is the structured activity data sent to the model for a narrative report.
+type narrativeContext struct {
+	StartDate          string              `json:"startDate"`
+	EndDate            string              `json:"endDate"`
+	TotalHours         float64             `json:"totalHours"`
+	KeyAccomplishments []string            `json:"keyAccomplishments"`
+	Projects           []ProjectSummary    `json:"projects"`
+	DailyStats         []DailySummaryStats `json:"dailyStats"`
+	Meetings           []MeetingDetection  `json:"meetings"`
+	GitCommitCount     int                 `json:"gitCommitCount"`
+}
+
+// SetInference sets the model used for "ai_narrative" reports.
+// If never set (or nil), those reports fall back to the summary report.
+func (s *ReportsService) SetInference(completer TextCompleter) {
+	s.completer = completer
+}
+
+// generateNarrativeReport asks the model to write the period's report from its weekly summary
+// data. Falls back to the HTML summary report if no model is set or the model fails or times out.
+// Returns the content and its format.
+func (s *ReportsService) generateNarrativeReport(tr *TimeRange, includeScreenshots bool) (string, string, error) {
+	data, err := s.buildWeeklySummaryData(context.Background(), tr.Start, tr.End, tr.StartDate, tr.EndDate)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build summary data: %w", err)
+	}
+
+	if s.completer != nil {
+		prompt, err := buildNarrativePrompt(data)
+		if err != nil {
+			return "", "", err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), narrativeTimeout)
+		defer cancel()
+		narrative, err := completeWithContext(ctx, s.completer, prompt)
+		if err == nil && strings.TrimSpace(narrative) != "" {
+			return strings.TrimSpace(narrative), "markdown", nil
+		}
+		log.Printf("AI narrative report unavailable, falling back to summary: %v", err)
+	}
+
+	content, err := s.generateSummaryReport(tr, includeScreenshots)
+	return content, "html", err
+}
+
+// buildNarrativePrompt renders the system prompt followed by the period's data as JSON.
+func buildNarrativePrompt(data *WeeklySummaryData) (string, error) {
+	payload, err := json.MarshalIndent(narrativeContext{
+		StartDate:          data.StartDate,
+		EndDate:            data.EndDate,
+		TotalHours:         data.TotalHours,
+		KeyAccomplishments: data.KeyAccomplishments,
+		Projects:           data.Projects,
+		DailyStats:         data.DailyStats,
+		Meetings:           data.Meetings,
+		GitCommitCount:     data.GitCommitCount,
+	}, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode report data: %w", err)
+	}
+	return narrativeSystemPrompt + "\n\nActivity data:\n" + string(payload), nil
+}
+
+// completeWithContext runs a completion, giving up when ctx is done. The completion itself
+// can't be cancelled, so it finishes in the background and its result is discarded.
+func completeWithContext(ctx context.Context, completer TextCompleter, prompt string) (string, error) {
+	type result struct {
+		text string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		text, err := completer.Complete(prompt)
+		done <- result{text, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.text, r.err
+	case <-ctx.Done():
+		return "", ctx.Err()
+	}
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// failingCompleter is a model that always errors.
+type failingCompleter struct{}
+
+func (failingCompleter) Complete(prompt string) (string, error) {
+	return "", errors.New("model unavailable")
+}
+
+// slowCompleter is a model that takes longer than callers are willing to wait.
+type slowCompleter struct{ delay time.Duration }
+
+func (c slowCompleter) Complete(prompt string) (string, error) {
+	time.Sleep(c.delay)
+	return "too late", nil
+}
+
+func TestGenerateReport_AINarrative(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	now := time.Now()
+	sessionID, _ := store.CreateSession(now.Add(-2 * time.Hour).Unix())
+	store.EndSession(sessionID, now.Add(-time.Hour).Unix())
+
+	completer := &mockCompleter{response: "  This week I shipped the report cache.\n\n## Highlights\n- Report cache\n"}
+	svc.SetInference(completer)
+
+	report, err := svc.GenerateReport("today", "ai_narrative", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if completer.calls != 1 {
+		t.Fatalf("expected 1 model call, got %d", completer.calls)
+	}
+	for _, want := range []string{"\"keyAccomplishments\"", "\"projects\"", "\"dailyStats\"", "\"meetings\"", "\"gitCommitCount\""} {
+		if !strings.Contains(completer.prompt, want) {
+			t.Errorf("expected prompt to contain %s", want)
+		}
+	}
+	if report.Format != "markdown" || !strings.HasPrefix(report.Content, "This week I shipped the report cache.") {
+		t.Errorf("expected markdown narrative, got %s: %q", report.Format, report.Content)
+	}
+	if !strings.HasPrefix(report.Title, "AI Narrative Report:") {
+		t.Errorf("unexpected title %q", report.Title)
+	}
+
+	// Falls back to the summary report when the model fails
+	svc.SetInference(failingCompleter{})
+	report, err = svc.GenerateReport("today", "ai_narrative", false)
+	if err != nil {
+		t.Fatalf("GenerateReport fallback failed: %v", err)
+	}
+	if report.Format != "html" || report.Content == "" {
+		t.Errorf("expected HTML summary fallback, got %s", report.Format)
+	}
+}
+
+func TestCompleteWithContext_Timeout(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if _, err := completeWithContext(ctx, slowCompleter{delay: time.Second}, "prompt"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
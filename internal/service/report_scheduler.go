@@ -0,0 +1,322 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// cronLookback bounds how far back cronSchedule.prev searches for a due time. It covers
+// expressions that only match on February 29th.
+const cronLookback = 8
+
+// scheduledReportTypes are the report types a schedule may generate.
+var scheduledReportTypes = map[string]bool{
+	"summary": true, "detailed": true, "standup": true, "journal": true, "ai_narrative": true,
+}
+
+// ReportScheduler generates reports on cron schedules and posts them to webhook recipients
+// as multipart/form-data. It is driven by the tracking daemon's ticks through Check. Each
+// schedule fires at most once per due time, and runs missed while Traq wasn't running are
+// caught up with a single run at the next check.
+type ReportScheduler struct {
+	store   *storage.Store
+	reports *ReportsService
+	client  *http.Client
+
+	mu sync.Mutex // Serializes checks so a slow dispatch can't fire a schedule twice
+}
+
+// NewReportScheduler creates a new ReportScheduler.
+func NewReportScheduler(store *storage.Store, reports *ReportsService) *ReportScheduler {
+	return &ReportScheduler{
+		store:   store,
+		reports: reports,
+		client:  &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// AddSchedule validates and saves a report schedule. An empty format defaults to html.
+func (s *ReportScheduler) AddSchedule(schedule storage.ScheduledReport) (int64, error) {
+	if !scheduledReportTypes[schedule.ReportType] {
+		return 0, fmt.Errorf("unsupported report type: %q", schedule.ReportType)
+	}
+	if _, err := s.reports.ParseTimeRange(schedule.TimeRange); err != nil {
+		return 0, fmt.Errorf("invalid time range: %w", err)
+	}
+	if _, err := parseCron(schedule.CronExpression); err != nil {
+		return 0, err
+	}
+	if len(schedule.Recipients) == 0 {
+		return 0, fmt.Errorf("at least one recipient is required")
+	}
+	for _, recipient := range schedule.Recipients {
+		u, err := url.Parse(recipient)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return 0, fmt.Errorf("invalid recipient URL: %q", recipient)
+		}
+	}
+	switch schedule.Format {
+	case "":
+		schedule.Format = "html"
+	case "html", "markdown":
+	default:
+		return 0, fmt.Errorf("unsupported format: %q", schedule.Format)
+	}
+
+	return s.store.CreateScheduledReport(&schedule)
+}
+
+// RemoveSchedule deletes a report schedule.
+func (s *ReportScheduler) RemoveSchedule(id int64) error {
+	return s.store.DeleteScheduledReport(id)
+}
+
+// GetSchedules returns all report schedules.
+func (s *ReportScheduler) GetSchedules() ([]*storage.ScheduledReport, error) {
+	return s.store.GetScheduledReports()
+}
+
+// Check runs any schedules that are due at now. It is called after every daemon tick.
+func (s *ReportScheduler) Check(now time.Time) {
+	if _, err := s.runDue(now); err != nil {
+		log.Printf("Scheduled reports failed: %v", err)
+	}
+}
+
+// runDue generates and dispatches every schedule whose most recent due time is after its
+// last run, or after its creation if it has never run. Returns the number of schedules that fired.
+func (s *ReportScheduler) runDue(now time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules, err := s.store.GetScheduledReports()
+	if err != nil {
+		return 0, err
+	}
+
+	fired := 0
+	var errs []error
+	for _, schedule := range schedules {
+		cron, err := parseCron(schedule.CronExpression)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+			continue
+		}
+		due, ok := cron.prev(now)
+		if !ok {
+			continue
+		}
+		since := schedule.CreatedAt
+		if schedule.LastRunAt.Valid {
+			since = schedule.LastRunAt.Int64
+		}
+		if due.Unix() <= since {
+			continue
+		}
+
+		// Record the run first so a failing recipient doesn't cause a retry every tick
+		if err := s.store.SetScheduledReportLastRun(schedule.ID, due.Unix()); err != nil {
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+			continue
+		}
+		fired++
+		if err := s.dispatch(schedule); err != nil {
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+		}
+	}
+	return fired, errors.Join(errs...)
+}
+
+// dispatch generates the schedule's report and posts it to each recipient.
+func (s *ReportScheduler) dispatch(schedule *storage.ScheduledReport) error {
+	report, err := s.reports.GenerateReport(schedule.TimeRange, schedule.ReportType, false)
+	if err != nil {
+		return fmt.Errorf("failed to generate report: %w", err)
+	}
+	content, err := s.reports.ExportReport(report.ID, schedule.Format)
+	if err != nil {
+		return fmt.Errorf("failed to export report: %w", err)
+	}
+
+	ext := ".html"
+	if schedule.Format == "markdown" {
+		ext = ".md"
+	}
+	filename := fmt.Sprintf("traq-%s-%s%s", schedule.ReportType, time.Unix(report.StartTime, 0).Format("2006-01-02"), ext)
+
+	var errs []error
+	for _, recipient := range schedule.Recipients {
+		if err := s.postReport(recipient, report, filename, content); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", recipient, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postReport posts a report to a recipient as multipart/form-data, with the report metadata
+// as form fields and the content as the "report" file.
+func (s *ReportScheduler) postReport(recipient string, report *Report, filename, content string) error {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	writer.WriteField("report_type", report.ReportType)
+	writer.WriteField("time_range", report.TimeRange)
+	writer.WriteField("title", report.Title)
+	part, err := writer.CreateFormFile("report", filename)
+	if err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
+	}
+	part.Write([]byte(content))
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("failed to write form: %w", err)
+	}
+
+	resp, err := s.client.Post(recipient, writer.FormDataContentType(), &body)
+	if err != nil {
+		return fmt.Errorf("failed to send report: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("recipient returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// cronSchedule is a parsed 5-field cron expression. Each field is a bitset of allowed values.
+type cronSchedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+	dayOfMonthAny, dayOfWeekAny                bool
+}
+
+// cronMacros maps the supported shorthands to their 5-field equivalents.
+var cronMacros = map[string]string{
+	"@daily":   "0 0 * * *",
+	"@weekly":  "0 0 * * 0",
+	"@monthly": "0 0 1 * *",
+}
+
+// parseCron parses "@daily", "@weekly", "@monthly" or a 5-field cron expression
+// (minute hour day-of-month month day-of-week) with *, lists, ranges and steps.
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := cronMacros[expr]; ok {
+		expr = macro
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields", expr)
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	var sets [5]uint64
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+		}
+		sets[i] = set
+	}
+	// Sunday may be written as 0 or 7
+	if sets[4]&(1<<7) != 0 {
+		sets[4] |= 1
+	}
+
+	return &cronSchedule{
+		minute:        sets[0],
+		hour:          sets[1],
+		dayOfMonth:    sets[2],
+		month:         sets[3],
+		dayOfWeek:     sets[4],
+		dayOfMonthAny: strings.HasPrefix(fields[2], "*"),
+		dayOfWeekAny:  strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses one comma-separated cron field into a bitset of values in [lo, hi].
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], n
+		}
+
+		start, end := lo, hi
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = strconv.Atoi(bounds[0]); err != nil {
+				return 0, fmt.Errorf("invalid value in %q", part)
+			}
+			end = start
+			if len(bounds) == 2 {
+				if end, err = strconv.Atoi(bounds[1]); err != nil {
+					return 0, fmt.Errorf("invalid value in %q", part)
+				}
+			} else if step > 1 {
+				end = hi // "5/15" means every 15 starting at 5
+			}
+		}
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("%q is out of range %d-%d", part, lo, hi)
+		}
+		for v := start; v <= end; v += step {
+			set |= 1 << v
+		}
+	}
+	return set, nil
+}
+
+// matches reports whether the schedule fires in t's minute. As in standard cron, when both
+// day-of-month and day-of-week are restricted, a day matching either one fires.
+func (c *cronSchedule) matches(t time.Time) bool {
+	return c.minute&(1<<t.Minute()) != 0 && c.hour&(1<<t.Hour()) != 0 && c.month&(1<<int(t.Month())) != 0 && c.matchesDay(t)
+}
+
+// matchesDay reports whether the schedule fires on t's day, ignoring the month.
+func (c *cronSchedule) matchesDay(t time.Time) bool {
+	domMatch := c.dayOfMonth&(1<<t.Day()) != 0
+	dowMatch := c.dayOfWeek&(1<<int(t.Weekday())) != 0
+	if c.dayOfMonthAny || c.dayOfWeekAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// prev returns the most recent minute at or before t that the schedule fires in. It skips
+// whole months, days and hours that can't match, and gives up after cronLookback years.
+func (c *cronSchedule) prev(t time.Time) (time.Time, bool) {
+	t = t.Truncate(time.Minute)
+	limit := t.AddDate(-cronLookback, 0, 0)
+	for t.After(limit) {
+		loc := t.Location()
+		switch {
+		case c.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, loc).Add(-time.Minute)
+		case !c.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc).Add(-time.Minute)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, loc).Add(-time.Minute)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = t.Add(-time.Minute)
+		default:
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestReportScheduler_DispatchesDueReports(t *testing.T) {
+	reports, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	type delivery struct {
+		reportType, filename, content string
+	}
+	var deliveries []delivery
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("report")
+		if err != nil {
+			t.Errorf("expected a multipart report file: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, _ := io.ReadAll(file)
+		deliveries = append(deliveries, delivery{r.FormValue("report_type"), header.Filename, string(content)})
+	}))
+	defer server.Close()
+
+	scheduler := NewReportScheduler(store, reports)
+
+	invalid := []storage.ScheduledReport{
+		{ReportType: "unknown", TimeRange: "today", CronExpression: "@daily", Recipients: []string{server.URL}},
+		{ReportType: "summary", TimeRange: "today", CronExpression: "0 25 * * *", Recipients: []string{server.URL}},
+		{ReportType: "summary", TimeRange: "today", CronExpression: "@daily", Recipients: []string{"ftp://example.com"}},
+		{ReportType: "summary", TimeRange: "today", CronExpression: "@daily", Recipients: []string{server.URL}, Format: "pdf"},
+	}
+	for _, schedule := range invalid {
+		if _, err := scheduler.AddSchedule(schedule); err == nil {
+			t.Errorf("expected an error adding %+v", schedule)
+		}
+	}
+
+	// Weekdays at 9:00, and a schedule that never comes due
+	id, err := scheduler.AddSchedule(storage.ScheduledReport{
+		ReportType: "standup", TimeRange: "today", CronExpression: "0 9 * * 1-5", Recipients: []string{server.URL},
+	})
+	if err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+	if _, err := scheduler.AddSchedule(storage.ScheduledReport{
+		ReportType: "summary", TimeRange: "today", CronExpression: "0 0 31 2 *", Recipients: []string{server.URL}, Format: "markdown",
+	}); err != nil {
+		t.Fatalf("AddSchedule failed: %v", err)
+	}
+
+	// The first Monday 9:00 after the schedules were created
+	now := time.Now()
+	monday := time.Date(now.Year(), now.Month(), now.Day(), 9, 0, 20, 0, time.Local)
+	for monday.Weekday() != time.Monday || !monday.Truncate(time.Minute).After(now) {
+		monday = monday.AddDate(0, 0, 1)
+	}
+	fired, err := scheduler.runDue(monday)
+	if err != nil {
+		t.Fatalf("runDue failed: %v", err)
+	}
+	if fired != 1 || len(deliveries) != 1 {
+		t.Fatalf("expected one report dispatched, got fired=%d deliveries=%d", fired, len(deliveries))
+	}
+	if d := deliveries[0]; d.reportType != "standup" || !strings.HasSuffix(d.filename, ".html") || !strings.Contains(d.content, "<html>") {
+		t.Errorf("unexpected delivery: %+v", d)
+	}
+	saved, err := reports.GetReportHistory()
+	if err != nil {
+		t.Fatalf("GetReportHistory failed: %v", err)
+	}
+	if len(saved) != 1 || saved[0].ReportType != "standup" {
+		t.Errorf("expected the generated standup report to be saved, got %+v", saved)
+	}
+
+	// Later checks the same day don't fire again
+	if fired, _ := scheduler.runDue(monday.Add(30 * time.Second)); fired != 0 {
+		t.Errorf("expected no re-fire in the same minute, got %d", fired)
+	}
+	if fired, _ := scheduler.runDue(monday.Add(3 * time.Hour)); fired != 0 {
+		t.Errorf("expected no re-fire later the same day, got %d", fired)
+	}
+
+	// Missed runs are caught up once, for the most recent due time (Thursday 9:00)
+	friday := time.Date(monday.Year(), monday.Month(), monday.Day()+4, 8, 0, 0, 0, time.Local)
+	if fired, _ := scheduler.runDue(friday); fired != 1 || len(deliveries) != 2 {
+		t.Errorf("expected one catch-up run, got fired=%d deliveries=%d", fired, len(deliveries))
+	}
+	if fired, _ := scheduler.runDue(friday.Add(time.Minute)); fired != 0 {
+		t.Errorf("expected no further catch-up runs, got %d", fired)
+	}
+
+	schedules, err := scheduler.GetSchedules()
+	if err != nil {
+		t.Fatalf("GetSchedules failed: %v", err)
+	}
+	thursday := time.Date(monday.Year(), monday.Month(), monday.Day()+3, 9, 0, 0, 0, time.Local)
+	if len(schedules) != 2 || schedules[0].LastRunAt.Int64 != thursday.Unix() ||
+		schedules[1].LastRunAt.Valid || schedules[1].Format != "markdown" || schedules[0].Format != "html" {
+		t.Errorf("unexpected schedules: %+v", schedules)
+	}
+
+	if err := scheduler.RemoveSchedule(id); err != nil {
+		t.Fatalf("RemoveSchedule failed: %v", err)
+	}
+	if err := scheduler.RemoveSchedule(id); err == nil {
+		t.Error("expected an error removing a missing schedule")
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		expr  string
+		time  time.Time
+		match bool
+	}{
+		{"@daily", at(time.March, 4, 0, 0), true},
+		{"@daily", at(time.March, 4, 0, 1), false},
+		{"@weekly", at(time.March, 1, 0, 0), true}, // Sunday
+		{"@weekly", at(time.March, 2, 0, 0), false},
+		{"@monthly", at(time.April, 1, 0, 0), true},
+		{"*/15 9-17 * * *", at(time.March, 4, 13, 45), true},
+		{"*/15 9-17 * * *", at(time.March, 4, 18, 0), false},
+		{"30 8 * * 1,3,5", at(time.March, 4, 8, 30), true}, // Wednesday
+		{"30 8 * * 1,3,5", at(time.March, 3, 8, 30), false},
+		{"0 0 * * 7", at(time.March, 1, 0, 0), true},  // 7 is also Sunday
+		{"0 0 15 * 1", at(time.March, 2, 0, 0), true}, // Either day field matches
+		{"0 0 15 * 1", at(time.March, 15, 0, 0), true},
+		{"0 0 15 * 1", at(time.March, 4, 0, 0), false},
+		{"0 12 1 1-6/2 *", at(time.May, 1, 12, 0), true},
+		{"0 12 1 1-6/2 *", at(time.June, 1, 12, 0), false},
+	}
+	for _, tt := range tests {
+		cron, err := parseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCron(%q) failed: %v", tt.expr, err)
+		}
+		if got := cron.matches(tt.time); got != tt.match {
+			t.Errorf("parseCron(%q).matches(%s) = %v, want %v", tt.expr, tt.time.Format(time.RFC1123), got, tt.match)
+		}
+	}
+
+	prevTests := []struct {
+		expr string
+		time time.Time
+		want time.Time
+	}{
+		{"@daily", at(time.March, 4, 13, 45), at(time.March, 4, 0, 0)},
+		{"@daily", at(time.March, 4, 0, 0), at(time.March, 4, 0, 0)},
+		{"30 8 * * 1,3,5", at(time.March, 3, 8, 0), at(time.March, 2, 8, 30)}, // Tuesday -> Monday
+		{"*/15 9-17 * * *", at(time.March, 4, 8, 59), at(time.March, 3, 17, 45)},
+		{"@monthly", at(time.March, 15, 12, 0), at(time.March, 1, 0, 0)},
+		{"0 12 1 1-6/2 *", at(time.April, 20, 0, 0), at(time.March, 1, 12, 0)},
+		{"0 0 29 2 *", at(time.March, 4, 0, 0), time.Date(2024, time.February, 29, 0, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range prevTests {
+		cron, err := parseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCron(%q) failed: %v", tt.expr, err)
+		}
+		if got, ok := cron.prev(tt.time.Add(20 * time.Second)); !ok || !got.Equal(tt.want) {
+			t.Errorf("parseCron(%q).prev(%s) = %s, %v, want %s", tt.expr, tt.time.Format(time.RFC1123), got.Format(time.RFC1123), ok, tt.want.Format(time.RFC1123))
+		}
+	}
+	if cron, _ := parseCron("0 0 31 2 *"); cron != nil {
+		if _, ok := cron.prev(at(time.March, 4, 0, 0)); ok {
+			t.Error("expected February 31st never to be due")
+		}
+	}
+
+	for _, expr := range []string{"", "@hourly", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("expected an error parsing %q", expr)
+		}
+	}
+}
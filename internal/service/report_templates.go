@@ -0,0 +1,266 @@
+package service
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"traq/internal/storage"
+)
+
+// templatedReportTypes are the report types whose layout can be replaced with a ReportTemplate.
+var templatedReportTypes = map[string]bool{
+	"summary": true,
+	"standup": true,
+}
+
+// TemplateData is what report templates are executed with. The EnhancedReportContext
+// fields are promoted, so templates can use {{.TotalMinutes}} or {{range .GitCommits}}.
+// The built-in templates only use Label and the layout for their report type.
+type TemplateData struct {
+	*EnhancedReportContext
+	ReportType      string
+	Label           string         // Time range label, e.g. "This Week"
+	Summary         *SummaryLayout // Summary reports only
+	Standup         *StandupLayout // Standup reports only
+	DefaultHTML     template.HTML  // The standard layout for the report type
+	DefaultMarkdown string         // The standard Markdown export, empty for report types without one
+}
+
+// reportTemplateFuncs are the helper functions available in report templates.
+var reportTemplateFuncs = map[string]interface{}{
+	"formatMinutes":           formatMinutes,
+	"formatHoursMinutes":      formatHoursMinutes,
+	"formatHoursMinutesShort": formatHoursMinutesShort,
+	"formatNumber":            formatNumber,
+	"formatVisitCount":        formatVisitCount,
+	"wholeMinutes":            func(seconds float64) int64 { return int64(seconds / 60) },
+	"appName":                 GetFriendlyAppName,
+	"burnoutColor":            func(level string) string { return burnoutLevelColors[level] },
+	"projectTimelinesHTML": func(timelines []*ProjectTimeline) template.HTML {
+		return template.HTML(formatProjectTimelinesHTML(timelines))
+	},
+	"commitHeatmapHTML": func(heatmap *CommitHeatmap) template.HTML {
+		if heatmap == nil || heatmap.totalCommits() == 0 {
+			return ""
+		}
+		return template.HTML(formatCommitHeatmapHTML(heatmap))
+	},
+	"distractedHoursHTML": func(entries []*DistractedHourEntry) template.HTML {
+		return template.HTML(formatDistractedHoursHTML(entries))
+	},
+}
+
+// builtinReportLayouts are the parsed built-in templates, which render the standard layout
+// of each templated report type.
+var builtinReportLayouts = map[string]*template.Template{
+	"summary": parseBuiltinReportLayout("summary"),
+	"standup": parseBuiltinReportLayout("standup"),
+}
+
+// parseBuiltinReportLayout parses the built-in HTML template for a report type.
+func parseBuiltinReportLayout(reportType string) *template.Template {
+	return template.Must(template.New("Built-in " + reportType).Funcs(reportTemplateFuncs).
+		Parse(storage.BuiltinReportTemplateHTML(reportType)))
+}
+
+// SummaryLayout is the summary report data as laid out by the built-in summary template.
+// The WeeklySummaryData fields are promoted.
+type SummaryLayout struct {
+	*WeeklySummaryData
+	Title          string                 // e.g. "Activity Summary: March 2 - 6, 2026"
+	PrimaryProject string                 // First project with meaningful content, "" if none
+	ShownProjects  []SummaryProject       // Projects with enough time, commits or accomplishments
+	CommitRepos    []SummaryRepo          // Notable commit messages per repository
+	TopDomains     []BrowserDomainSummary // The 10 most visited domains
+}
+
+// SummaryProject is a project card in the summary layout.
+type SummaryProject struct {
+	Number      int
+	Name        string
+	Hours       float64
+	Percentage  float64
+	CommitCount int
+	Days        []SummaryProjectDay // Days in the report with accomplishments, in order
+}
+
+// SummaryProjectDay is one day's accomplishments on a project card.
+type SummaryProjectDay struct {
+	Label           string // e.g. "Mon Mar 2"
+	Accomplishments []string
+}
+
+// SummaryRepo lists a repository's notable commit messages in the summary layout.
+type SummaryRepo struct {
+	Name        string
+	CommitCount int
+	Messages    []string // Up to 5, skipping boring and repeated messages
+	More        int      // Commits beyond the listed ones
+}
+
+// StandupLayout is the standup report data as laid out by the built-in standup template.
+type StandupLayout struct {
+	TotalMinutes     int64
+	SessionCount     int
+	Accomplishments  []string
+	CommitHighlights []string // Up to 5 distinct commit messages, shown without accomplishments
+	Meetings         []StandupMeeting
+	Commits          []*storage.GitCommit // One commit per distinct message
+	Threads          []StandupThread
+	NextTask         string // Work in progress to continue, "" if none was found
+	TopApps          []StandupApp
+}
+
+// StandupMeeting is a meeting of at least a minute in the standup layout.
+type StandupMeeting struct {
+	Icon     string
+	Platform string
+	Title    string
+	Minutes  int64
+}
+
+// StandupThread is a task thread in the standup layout, e.g. "2h 30m over 3 days".
+type StandupThread struct {
+	Name   string
+	Detail string
+}
+
+// StandupApp is a bar in the standup time summary.
+type StandupApp struct {
+	Name     string
+	BarWidth int    // Percentage of the most used app's time
+	BarColor string // By productivity category
+	Minutes  int64
+}
+
+// GetReportTemplates returns all report templates, including the built-in ones.
+func (s *ReportsService) GetReportTemplates() ([]*storage.ReportTemplate, error) {
+	return s.store.GetReportTemplates()
+}
+
+// SaveReportTemplate validates and saves a new template for a report type.
+// Use SetDefaultTemplate to use it for new reports.
+func (s *ReportsService) SaveReportTemplate(name, reportType, templateHTML, templateMarkdown string) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, fmt.Errorf("template name is required")
+	}
+	if !templatedReportTypes[reportType] {
+		return 0, fmt.Errorf("report type %q does not support templates", reportType)
+	}
+	if strings.TrimSpace(templateHTML) == "" {
+		return 0, fmt.Errorf("HTML template is required")
+	}
+	if _, err := template.New(name).Funcs(reportTemplateFuncs).Parse(templateHTML); err != nil {
+		return 0, fmt.Errorf("invalid HTML template: %w", err)
+	}
+	if _, err := texttemplate.New(name).Funcs(reportTemplateFuncs).Parse(templateMarkdown); err != nil {
+		return 0, fmt.Errorf("invalid Markdown template: %w", err)
+	}
+
+	return s.store.SaveReportTemplate(&storage.ReportTemplate{
+		Name:             name,
+		ReportType:       reportType,
+		TemplateHTML:     templateHTML,
+		TemplateMarkdown: templateMarkdown,
+	})
+}
+
+// DeleteReportTemplate deletes a report template.
+func (s *ReportsService) DeleteReportTemplate(id int64) error {
+	return s.store.DeleteReportTemplate(id)
+}
+
+// SetDefaultTemplate makes a template the one used for new reports of its type.
+func (s *ReportsService) SetDefaultTemplate(id int64) error {
+	return s.store.SetDefaultReportTemplate(id)
+}
+
+// renderReportHTML renders a report through the default template for its report type. The
+// built-in template, or no default at all, renders the standard layout without building
+// the enhanced report context.
+func (s *ReportsService) renderReportHTML(tr *TimeRange, data *TemplateData) (string, error) {
+	standard, err := renderBuiltinReportLayout(data)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := s.store.GetDefaultReportTemplate(data.ReportType)
+	if err != nil || tmpl == nil || tmpl.TemplateHTML == "" ||
+		tmpl.TemplateHTML == storage.BuiltinReportTemplateHTML(data.ReportType) {
+		return standard, err
+	}
+
+	parsed, err := template.New(tmpl.Name).Funcs(reportTemplateFuncs).Parse(tmpl.TemplateHTML)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", tmpl.Name, err)
+	}
+	if data.EnhancedReportContext == nil {
+		ctx, err := s.buildEnhancedReportContext(tr)
+		if err != nil {
+			return "", fmt.Errorf("failed to build report context: %w", err)
+		}
+		data.EnhancedReportContext = ctx
+	}
+	data.DefaultHTML = template.HTML(standard)
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", tmpl.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// renderBuiltinReportLayout renders the standard layout for data's report type.
+func renderBuiltinReportLayout(data *TemplateData) (string, error) {
+	layout, ok := builtinReportLayouts[data.ReportType]
+	if !ok {
+		return "", fmt.Errorf("report type %q has no built-in layout", data.ReportType)
+	}
+	var buf bytes.Buffer
+	if err := layout.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s layout: %w", data.ReportType, err)
+	}
+	return buf.String(), nil
+}
+
+// applyMarkdownTemplate renders the Markdown half of the default template for a
+// report type, or returns defaultMarkdown unchanged if there is none.
+func (s *ReportsService) applyMarkdownTemplate(reportType string, tr *TimeRange, defaultMarkdown string) (string, error) {
+	tmpl, err := s.store.GetDefaultReportTemplate(reportType)
+	if err != nil || tmpl == nil || tmpl.TemplateMarkdown == "" {
+		return defaultMarkdown, err
+	}
+
+	parsed, err := texttemplate.New(tmpl.Name).Funcs(reportTemplateFuncs).Parse(tmpl.TemplateMarkdown)
+	if err != nil {
+		return "", fmt.Errorf("invalid template %s: %w", tmpl.Name, err)
+	}
+	data, err := s.reportTemplateData(reportType, tr)
+	if err != nil {
+		return "", err
+	}
+	data.DefaultMarkdown = defaultMarkdown
+
+	var buf bytes.Buffer
+	if err := parsed.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render template %s: %w", tmpl.Name, err)
+	}
+	return buf.String(), nil
+}
+
+// reportTemplateData builds the data a report template is executed with.
+func (s *ReportsService) reportTemplateData(reportType string, tr *TimeRange) (*TemplateData, error) {
+	ctx, err := s.buildEnhancedReportContext(tr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build report context: %w", err)
+	}
+	return &TemplateData{
+		EnhancedReportContext: ctx,
+		ReportType:            reportType,
+		Label:                 tr.Label,
+	}, nil
+}
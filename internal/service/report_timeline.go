@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReportTimeline is the history of reports generated over a period, oldest first.
+type ReportTimeline struct {
+	Start   int64                  `json:"start"`
+	End     int64                  `json:"end"`
+	Reports []*ReportTimelineEntry `json:"reports"`
+}
+
+// ReportTimelineEntry is a generated report with the key metrics of the period it covers.
+// Metrics are zero for reports without a stored time range.
+type ReportTimelineEntry struct {
+	ReportMeta
+	TotalActiveMinutes int64  `json:"totalActiveMinutes"`
+	CommitCount        int64  `json:"commitCount"`
+	TopProject         string `json:"topProject"` // Project with the most focus time; "" if none was assigned
+}
+
+// reportTimelineMetrics holds the metrics shared by reports covering the same time range.
+type reportTimelineMetrics struct {
+	activeMinutes int64
+	commits       int64
+	topProject    string
+}
+
+// GetReportTimeline returns the reports created between start and end, oldest first,
+// each with the active time, commits and top project of the range it covers. Metrics
+// come from the cached report data, so re-generated versions of a report are cheap.
+func (s *ReportsService) GetReportTimeline(start, end int64) (*ReportTimeline, error) {
+	reports, err := s.store.GetReportsCreatedBetween(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &ReportTimeline{Start: start, End: end, Reports: make([]*ReportTimelineEntry, 0, len(reports))}
+	metrics := make(map[[2]int64]*reportTimelineMetrics)
+	for _, r := range reports {
+		entry := &ReportTimelineEntry{ReportMeta: *toReportMeta(r)}
+		if r.StartTime.Valid && r.EndTime.Valid {
+			key := [2]int64{r.StartTime.Int64, r.EndTime.Int64}
+			m, ok := metrics[key]
+			if !ok {
+				m, err = s.reportTimelineMetrics(r.StartTime.Int64, r.EndTime.Int64)
+				if err != nil {
+					return nil, err
+				}
+				metrics[key] = m
+			}
+			entry.TotalActiveMinutes = m.activeMinutes
+			entry.CommitCount = m.commits
+			entry.TopProject = m.topProject
+		}
+		timeline.Reports = append(timeline.Reports, entry)
+	}
+	return timeline, nil
+}
+
+// reportTimelineMetrics computes the timeline metrics of a report's time range.
+func (s *ReportsService) reportTimelineMetrics(start, end int64) (*reportTimelineMetrics, error) {
+	ctx, err := s.buildEnhancedReportContext(&TimeRange{Start: start, End: end})
+	if err != nil {
+		return nil, err
+	}
+	m := &reportTimelineMetrics{activeMinutes: ctx.TotalMinutes, commits: int64(len(ctx.GitCommits))}
+
+	if s.analytics != nil {
+		usage, err := s.analytics.GetProjectUsage(start, end)
+		if err != nil {
+			return nil, err
+		}
+		// Sorted by focus time, so the first assigned project is the top one
+		for _, p := range usage {
+			if p.ProjectID != 0 {
+				m.topProject = p.ProjectName
+				break
+			}
+		}
+	}
+	return m, nil
+}
+
+// reportTimelineStyle is the stylesheet of the printable report timeline.
+const reportTimelineStyle = `<style>
+body { font-family: Arial, sans-serif; max-width: 900px; margin: 40px auto; padding: 0 20px; color: #333; }
+h1 { border-bottom: 3px solid #4a9eff; padding-bottom: 10px; }
+.range { color: #666; margin-bottom: 24px; }
+.timeline { border-left: 2px solid #d1d5db; margin-left: 8px; padding-left: 20px; }
+.entry { position: relative; margin-bottom: 18px; page-break-inside: avoid; }
+.entry::before { content: ""; position: absolute; left: -27px; top: 4px; width: 10px; height: 10px; border-radius: 50%; background: #4a9eff; }
+.date { color: #666; font-size: 13px; }
+.title { font-weight: bold; margin: 2px 0; }
+.meta { color: #666; font-size: 13px; }
+.metrics { font-size: 14px; margin-top: 4px; }
+.metrics span { margin-right: 16px; }
+.empty { color: #666; }
+@media print {
+body { margin: 0; max-width: none; }
+.entry::before { -webkit-print-color-adjust: exact; print-color-adjust: exact; }
+}
+</style>`
+
+// GetReportTimelineHTML renders the report timeline between start and end as a
+// standalone, printable HTML page.
+func (s *ReportsService) GetReportTimelineHTML(start, end int64) (string, error) {
+	timeline, err := s.GetReportTimeline(start, end)
+	if err != nil {
+		return "", err
+	}
+	return formatReportTimelineHTML(timeline), nil
+}
+
+// formatReportTimelineHTML renders a report timeline as an HTML page.
+func formatReportTimelineHTML(timeline *ReportTimeline) string {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"UTF-8\">\n<title>Report Timeline</title>\n")
+	sb.WriteString(reportTimelineStyle)
+	sb.WriteString("\n</head>\n<body>\n<h1>Report Timeline</h1>\n")
+	sb.WriteString(fmt.Sprintf("<div class=\"range\">%s – %s · %d reports</div>\n",
+		time.Unix(timeline.Start, 0).Format("Jan 2, 2006"), time.Unix(timeline.End, 0).Format("Jan 2, 2006"), len(timeline.Reports)))
+
+	if len(timeline.Reports) == 0 {
+		sb.WriteString("<p class=\"empty\">No reports were generated in this period.</p>\n")
+	} else {
+		sb.WriteString("<div class=\"timeline\">\n")
+		for _, r := range timeline.Reports {
+			meta := esc(r.ReportType)
+			if r.TimeRange != "" {
+				meta = esc(r.TimeRange) + " · " + meta
+			}
+			if r.Version > 1 {
+				meta += fmt.Sprintf(" · v%d", r.Version)
+			}
+			sb.WriteString("<div class=\"entry\">\n")
+			sb.WriteString(fmt.Sprintf("<div class=\"date\">%s</div>\n", time.Unix(r.CreatedAt, 0).Format("Mon, Jan 2, 2006 15:04")))
+			sb.WriteString(fmt.Sprintf("<div class=\"title\">%s</div>\n", esc(r.Title)))
+			sb.WriteString(fmt.Sprintf("<div class=\"meta\">%s</div>\n", meta))
+			sb.WriteString(fmt.Sprintf("<div class=\"metrics\"><span>Active: %s</span><span>Commits: %d</span>",
+				formatHoursMinutesShort(float64(r.TotalActiveMinutes)/60), r.CommitCount))
+			if r.TopProject != "" {
+				sb.WriteString(fmt.Sprintf("<span>Top project: %s</span>", esc(r.TopProject)))
+			}
+			sb.WriteString("</div>\n</div>\n")
+		}
+		sb.WriteString("</div>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestGetReportTimeline(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	traq, _ := store.CreateProject("Traq", "#3b82f6", "")
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.Local)
+	for i, app := range []string{"code", "slack"} {
+		id, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName:         app,
+			WindowTitle:     app,
+			StartTime:       day.Add(time.Duration(9+i) * time.Hour).Unix(),
+			EndTime:         day.Add(time.Duration(9+i)*time.Hour + time.Duration(90-60*i)*time.Minute).Unix(),
+			DurationSeconds: float64((90 - 60*i) * 60),
+		})
+		if err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+		if app == "code" {
+			store.SetEventProject("focus", id, traq.ID, 1, "user")
+		}
+	}
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	store.SaveGitCommit(&storage.GitCommit{Timestamp: day.Add(10 * time.Hour).Unix(), CommitHash: "abc123", ShortHash: "abc123", RepositoryID: repoID, Message: "Fix", MessageSubject: "Fix"})
+
+	save := func(title string, created time.Time, withRange bool) {
+		t.Helper()
+		r := &storage.Report{Title: title, TimeRange: "today", ReportType: "summary", Format: "markdown"}
+		if withRange {
+			r.StartTime = sql.NullInt64{Int64: day.Unix(), Valid: true}
+			r.EndTime = sql.NullInt64{Int64: day.AddDate(0, 0, 1).Unix() - 1, Valid: true}
+		}
+		id, err := store.SaveReport(r)
+		if err != nil {
+			t.Fatalf("SaveReport failed: %v", err)
+		}
+		store.DB().Exec("UPDATE reports SET created_at = ? WHERE id = ?", created.Unix(), id)
+	}
+	save("Evening <report>", day.Add(18*time.Hour), true)
+	save("Morning report", day.Add(8*time.Hour), true)
+	save("Legacy report", day.Add(12*time.Hour), false)
+	save("Next week", day.AddDate(0, 0, 7), true)
+
+	timeline, err := svc.GetReportTimeline(day.Unix(), day.AddDate(0, 0, 1).Unix())
+	if err != nil {
+		t.Fatalf("GetReportTimeline failed: %v", err)
+	}
+	if len(timeline.Reports) != 3 {
+		t.Fatalf("expected 3 reports, got %d", len(timeline.Reports))
+	}
+	morning, legacy, evening := timeline.Reports[0], timeline.Reports[1], timeline.Reports[2]
+	if morning.Title != "Morning report" || legacy.Title != "Legacy report" || evening.Title != "Evening <report>" {
+		t.Errorf("reports not sorted by creation time: %s, %s, %s", morning.Title, legacy.Title, evening.Title)
+	}
+	if evening.TotalActiveMinutes != 120 || evening.CommitCount != 1 || evening.TopProject != "Traq" {
+		t.Errorf("evening = %+v, want 120 min, 1 commit, top project Traq", evening)
+	}
+	if morning.TotalActiveMinutes != evening.TotalActiveMinutes || morning.TopProject != evening.TopProject {
+		t.Errorf("reports over the same range have different metrics: %+v vs %+v", morning, evening)
+	}
+	if legacy.TotalActiveMinutes != 0 || legacy.CommitCount != 0 || legacy.TopProject != "" {
+		t.Errorf("report without a time range has metrics: %+v", legacy)
+	}
+
+	html, err := svc.GetReportTimelineHTML(day.Unix(), day.AddDate(0, 0, 1).Unix())
+	if err != nil {
+		t.Fatalf("GetReportTimelineHTML failed: %v", err)
+	}
+	for _, want := range []string{"<!DOCTYPE html>", "@media print", "Evening &lt;report&gt;", "Active: 2h", "Top project: Traq", "3 reports"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package service
 
 import (
+	"context"
 	"fmt"
 	"html"
 	"math"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"traq/internal/storage"
@@ -18,12 +22,42 @@ func esc(s string) string {
 	return html.EscapeString(s)
 }
 
+// reportCacheTTL is how long built report data stays cached.
+const reportCacheTTL = 5 * time.Minute
+
+// focusStreamThreshold is the focus event count above which report builders stream
+// focus events from the database instead of loading the whole range into memory.
+const focusStreamThreshold = 100_000
+
+// domainSampleTitles is how many distinct page titles are kept per domain in reports.
+const domainSampleTitles = 3
+
 // ReportsService provides report generation.
 type ReportsService struct {
 	store     *storage.Store
 	timeline  *TimelineService
 	analytics *AnalyticsService
 	projects  *ProjectAssignmentService
+	meetings  meetingDetector
+	completer TextCompleter // Optional model for "ai_narrative" reports
+
+	// authorEmails holds the user's git emails. If set, reports leave out other
+	// authors' commits in shared repositories.
+	authorEmails []string
+
+	// Report data caches keyed by range and dates (see reportCacheKey), so the same range
+	// isn't rebuilt for each export format. New activity drops the entries covering it.
+	enhancedCache sync.Map // string -> *reportCacheEntry
+	weeklyCache   sync.Map // string -> *reportCacheEntry
+	cacheHits     int64
+	cacheMisses   int64
+}
+
+// reportCacheEntry holds cached report data with its time range and expiry time.
+type reportCacheEntry struct {
+	value      interface{}
+	start, end int64
+	expiresAt  time.Time
 }
 
 // NewReportsService creates a new ReportsService.
@@ -53,6 +87,8 @@ type ReportMeta struct {
 	ReportType string `json:"reportType"`
 	Format     string `json:"format"`
 	CreatedAt  int64  `json:"createdAt"`
+	Version    int    `json:"version"`  // 1-indexed; re-generating a report creates a new version
+	ParentID   int64  `json:"parentId"` // ID of the original report (0 for the original itself)
 }
 
 // Report is the service-layer report type with plain strings (not sql.Null types).
@@ -67,6 +103,8 @@ type Report struct {
 	StartTime  int64  `json:"startTime"`
 	EndTime    int64  `json:"endTime"`
 	CreatedAt  int64  `json:"createdAt"`
+	Version    int    `json:"version"`
+	ParentID   int64  `json:"parentId"`
 }
 
 // WindowBreakdown shows time spent per window within an app
@@ -93,10 +131,12 @@ type AppDetailedUsage struct {
 // DomainGroup represents browser activity grouped by domain
 type DomainGroup struct {
 	Domain          string   `json:"domain"`
+	Profile         string   `json:"profile"` // Browser profile, so work and personal browsing stay separate
 	DurationSeconds float64  `json:"durationSeconds"`
 	VisitCount      int64    `json:"visitCount"`
 	TopicLabel      string   `json:"topicLabel"`
 	SampleTitles    []string `json:"sampleTitles"`
+	IsBlocklisted   bool     `json:"isBlocklisted"` // Domain is on the user's browser domain blocklist
 }
 
 // MeetingDetection represents a detected meeting from window titles
@@ -207,8 +247,74 @@ func formatHoursMinutesShort(hours float64) string {
 	return fmt.Sprintf("%dh %dm", h, m)
 }
 
-// buildEnhancedReportContext fetches all data needed for reports and aggregates it.
+// reportCacheKey builds the cache key for a time range and the dates it was resolved to.
+func reportCacheKey(start, end int64, startDate, endDate string) string {
+	return fmt.Sprintf("%d:%d:%s:%s", start, end, startDate, endDate)
+}
+
+// getCachedReportData returns a cached value if present and not expired.
+func (s *ReportsService) getCachedReportData(cache *sync.Map, key string) (interface{}, bool) {
+	if v, ok := cache.Load(key); ok {
+		entry := v.(*reportCacheEntry)
+		if time.Now().Before(entry.expiresAt) {
+			atomic.AddInt64(&s.cacheHits, 1)
+			return entry.value, true
+		}
+		cache.Delete(key)
+	}
+	atomic.AddInt64(&s.cacheMisses, 1)
+	return nil, false
+}
+
+// InvalidateReportCacheAt discards the cached report data of ranges containing timestamp.
+// Call this when new activity is recorded so reports covering it reflect it.
+func (s *ReportsService) InvalidateReportCacheAt(timestamp int64) {
+	for _, cache := range []*sync.Map{&s.enhancedCache, &s.weeklyCache} {
+		cache.Range(func(key, v interface{}) bool {
+			if entry := v.(*reportCacheEntry); entry.start <= timestamp && timestamp <= entry.end {
+				cache.Delete(key)
+			}
+			return true
+		})
+	}
+}
+
+// ClearReportCache discards all cached report data.
+// Call this when changes affect reports of any range, e.g. new detection rules.
+func (s *ReportsService) ClearReportCache() {
+	s.enhancedCache.Range(func(key, _ interface{}) bool {
+		s.enhancedCache.Delete(key)
+		return true
+	})
+	s.weeklyCache.Range(func(key, _ interface{}) bool {
+		s.weeklyCache.Delete(key)
+		return true
+	})
+}
+
+// GetReportCacheStats returns the number of report cache hits and misses.
+func (s *ReportsService) GetReportCacheStats() (hits, misses int64) {
+	return atomic.LoadInt64(&s.cacheHits), atomic.LoadInt64(&s.cacheMisses)
+}
+
+// buildEnhancedReportContext returns the aggregated report data for a time range,
+// using the cached copy when the same range was built recently.
 func (s *ReportsService) buildEnhancedReportContext(tr *TimeRange) (*EnhancedReportContext, error) {
+	key := reportCacheKey(tr.Start, tr.End, tr.StartDate, tr.EndDate)
+	if cached, ok := s.getCachedReportData(&s.enhancedCache, key); ok {
+		return cached.(*EnhancedReportContext), nil
+	}
+
+	ctx, err := s.loadEnhancedReportContext(tr)
+	if err != nil {
+		return nil, err
+	}
+	s.enhancedCache.Store(key, &reportCacheEntry{value: ctx, start: tr.Start, end: tr.End, expiresAt: time.Now().Add(reportCacheTTL)})
+	return ctx, nil
+}
+
+// loadEnhancedReportContext fetches all data needed for reports and aggregates it.
+func (s *ReportsService) loadEnhancedReportContext(tr *TimeRange) (*EnhancedReportContext, error) {
 	ctx := &EnhancedReportContext{
 		TimeRange:    tr,
 		SummariesMap: make(map[int64]*storage.Summary),
@@ -231,25 +337,28 @@ func (s *ReportsService) buildEnhancedReportContext(tr *TimeRange) (*EnhancedRep
 		ctx.SummariesMap, _ = s.store.GetSummariesForSessions(sessionIDs)
 	}
 
-	// Get focus events and aggregate with window breakdown
-	focusEvents, err := s.store.GetWindowFocusEventsByTimeRange(tr.Start, tr.End)
+	// Aggregate focus events with window breakdown and detect meetings, streaming
+	// ranges too large to load at once
+	apps, meetings := s.newAppUsageAggregator(), s.meetings.newAggregator()
+	err = s.eachFocusEvent(context.Background(), tr.Start, tr.End, func(evt *storage.WindowFocusEvent) error {
+		apps.add(evt)
+		meetings.add(evt)
+		return nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get focus events: %w", err)
 	}
-	ctx.AppUsage = s.aggregateAppUsageWithWindows(focusEvents)
-
-	// Detect meetings from focus events
-	ctx.Meetings = s.detectMeetings(focusEvents)
+	ctx.AppUsage = apps.result()
+	ctx.Meetings = meetings.result()
 
-	// Get browser history and aggregate by domain
-	browserVisits, err := s.store.GetBrowserVisitsByTimeRange(tr.Start, tr.End)
+	// Get browser history aggregated by domain
+	ctx.DomainGroups, err = s.aggregateBrowserByDomain(tr.Start, tr.End)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get browser history: %w", err)
 	}
-	ctx.DomainGroups = s.aggregateBrowserByDomain(browserVisits, focusEvents)
 
 	// Get git commits
-	ctx.GitCommits, _ = s.store.GetGitCommitsByTimeRange(tr.Start, tr.End)
+	ctx.GitCommits, _ = s.userGitCommits(tr.Start, tr.End)
 
 	// Get shell commands
 	ctx.ShellCommands, _ = s.store.GetShellCommandsByTimeRange(tr.Start, tr.End)
@@ -282,57 +391,101 @@ func (s *ReportsService) buildEnhancedReportContext(tr *TimeRange) (*EnhancedRep
 	return ctx, nil
 }
 
+// eachFocusEvent calls fn for each focus event overlapping a time range. Ranges with more
+// than focusStreamThreshold events are streamed row by row rather than loaded at once.
+func (s *ReportsService) eachFocusEvent(ctx context.Context, start, end int64, fn func(*storage.WindowFocusEvent) error) error {
+	count, err := s.store.CountFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+	if count > focusStreamThreshold {
+		return s.store.StreamWindowFocusEvents(ctx, start, end, fn)
+	}
+
+	events, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return err
+	}
+	for _, evt := range events {
+		if err := fn(evt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // aggregateAppUsageWithWindows groups focus events by app, then by window title.
 // Uses friendly name as key to deduplicate app variants (e.g., "traq" and "traq-dev-linux-amd64").
 func (s *ReportsService) aggregateAppUsageWithWindows(events []*storage.WindowFocusEvent) []*AppDetailedUsage {
-	// First pass: aggregate by FRIENDLY name to deduplicate app variants
-	appMap := make(map[string]*AppDetailedUsage)
-	windowMap := make(map[string]map[string]*WindowBreakdown) // friendlyName -> window -> breakdown
-
-	var totalDuration float64
+	agg := s.newAppUsageAggregator()
 	for _, evt := range events {
-		totalDuration += evt.DurationSeconds
-
-		// Use friendly name as key to deduplicate related app variants
-		friendlyName := GetFriendlyAppName(evt.AppName)
-
-		// Get or create app entry using friendly name as key
-		if _, ok := appMap[friendlyName]; !ok {
-			appMap[friendlyName] = &AppDetailedUsage{
-				AppName:      evt.AppName,
-				FriendlyName: friendlyName,
-				Category:     string(s.analytics.CategorizeApp(evt.AppName)),
-				Windows:      []WindowBreakdown{},
-			}
-			windowMap[friendlyName] = make(map[string]*WindowBreakdown)
-		}
-		appMap[friendlyName].DurationSeconds += evt.DurationSeconds
-
-		// Get or create window entry
-		if _, ok := windowMap[friendlyName][evt.WindowTitle]; !ok {
-			isMeeting, platform := s.detectMeetingFromTitle(evt.WindowTitle)
-			windowMap[friendlyName][evt.WindowTitle] = &WindowBreakdown{
-				WindowTitle:     evt.WindowTitle,
-				IsMeeting:       isMeeting,
-				MeetingPlatform: platform,
-				ProjectPath:     s.extractVSCodeProject(evt.WindowTitle),
-			}
-			if isMeeting {
-				appMap[friendlyName].MeetingCount++
-			}
+		agg.add(evt)
+	}
+	return agg.result()
+}
+
+// appUsageAggregator builds the app usage with window breakdown one focus event at a time,
+// so large ranges can be aggregated while streaming.
+type appUsageAggregator struct {
+	s             *ReportsService
+	appMap        map[string]*AppDetailedUsage
+	windowMap     map[string]map[string]*WindowBreakdown // friendlyName -> window -> breakdown
+	totalDuration float64
+}
+
+func (s *ReportsService) newAppUsageAggregator() *appUsageAggregator {
+	return &appUsageAggregator{
+		s:         s,
+		appMap:    make(map[string]*AppDetailedUsage),
+		windowMap: make(map[string]map[string]*WindowBreakdown),
+	}
+}
+
+// add aggregates one focus event by FRIENDLY name to deduplicate app variants.
+func (a *appUsageAggregator) add(evt *storage.WindowFocusEvent) {
+	a.totalDuration += evt.DurationSeconds
+
+	// Use friendly name as key to deduplicate related app variants
+	friendlyName := GetFriendlyAppName(evt.AppName)
+
+	// Get or create app entry using friendly name as key
+	if _, ok := a.appMap[friendlyName]; !ok {
+		a.appMap[friendlyName] = &AppDetailedUsage{
+			AppName:      evt.AppName,
+			FriendlyName: friendlyName,
+			Category:     string(a.s.analytics.CategorizeApp(evt.AppName)),
+			Windows:      []WindowBreakdown{},
+		}
+		a.windowMap[friendlyName] = make(map[string]*WindowBreakdown)
+	}
+	a.appMap[friendlyName].DurationSeconds += evt.DurationSeconds
+
+	// Get or create window entry
+	if _, ok := a.windowMap[friendlyName][evt.WindowTitle]; !ok {
+		isMeeting, platform := a.s.meetings.detectFromTitle(evt.WindowTitle)
+		a.windowMap[friendlyName][evt.WindowTitle] = &WindowBreakdown{
+			WindowTitle:     evt.WindowTitle,
+			IsMeeting:       isMeeting,
+			MeetingPlatform: platform,
+			ProjectPath:     a.s.extractVSCodeProject(evt.WindowTitle),
+		}
+		if isMeeting {
+			a.appMap[friendlyName].MeetingCount++
 		}
-		windowMap[friendlyName][evt.WindowTitle].DurationSeconds += evt.DurationSeconds
 	}
+	a.windowMap[friendlyName][evt.WindowTitle].DurationSeconds += evt.DurationSeconds
+}
 
-	// Second pass: calculate percentages and sort
+// result calculates percentages and returns apps and their windows, longest first.
+func (a *appUsageAggregator) result() []*AppDetailedUsage {
 	var result []*AppDetailedUsage
-	for appName, app := range appMap {
-		if totalDuration > 0 {
-			app.Percentage = (app.DurationSeconds / totalDuration) * 100
+	for appName, app := range a.appMap {
+		if a.totalDuration > 0 {
+			app.Percentage = (app.DurationSeconds / a.totalDuration) * 100
 		}
 
 		// Convert window map to slice and calculate percentages
-		for _, wb := range windowMap[appName] {
+		for _, wb := range a.windowMap[appName] {
 			if app.DurationSeconds > 0 {
 				wb.Percentage = (wb.DurationSeconds / app.DurationSeconds) * 100
 			}
@@ -355,33 +508,6 @@ func (s *ReportsService) aggregateAppUsageWithWindows(events []*storage.WindowFo
 	return result
 }
 
-// detectMeetingFromTitle checks for meeting patterns in window title.
-func (s *ReportsService) detectMeetingFromTitle(title string) (bool, string) {
-	lower := strings.ToLower(title)
-
-	// Slack patterns
-	if strings.Contains(lower, "huddle") || strings.Contains(lower, "slack | huddle") {
-		return true, "Slack"
-	}
-
-	// Zoom patterns
-	if strings.Contains(lower, "zoom meeting") || strings.Contains(lower, "zoom.us") {
-		return true, "Zoom"
-	}
-
-	// Google Meet patterns
-	if strings.Contains(lower, "meet.google.com") || strings.Contains(lower, "meet -") {
-		return true, "Google Meet"
-	}
-
-	// Microsoft Teams patterns
-	if strings.Contains(lower, "microsoft teams") && strings.Contains(lower, "meeting") {
-		return true, "Teams"
-	}
-
-	return false, ""
-}
-
 // extractVSCodeProject parses VS Code window title to extract project name.
 // Format: "file - Project (Workspace) - Visual Studio Code"
 func (s *ReportsService) extractVSCodeProject(title string) string {
@@ -401,136 +527,79 @@ func (s *ReportsService) extractVSCodeProject(title string) string {
 	return ""
 }
 
-// detectMeetings aggregates meeting durations from all focus events.
-func (s *ReportsService) detectMeetings(events []*storage.WindowFocusEvent) []MeetingDetection {
-	// Group by platform + cleaned title
-	meetingMap := make(map[string]*MeetingDetection)
-
-	for _, evt := range events {
-		isMeeting, platform := s.detectMeetingFromTitle(evt.WindowTitle)
-		if !isMeeting {
-			continue
-		}
-
-		cleanTitle := s.cleanMeetingTitle(evt.WindowTitle, platform)
-		key := platform + ":" + cleanTitle
-
-		if existing, ok := meetingMap[key]; ok {
-			existing.DurationSeconds += evt.DurationSeconds
-		} else {
-			meetingMap[key] = &MeetingDetection{
-				Platform:        platform,
-				Title:           cleanTitle,
-				WindowTitle:     evt.WindowTitle,
-				StartTime:       evt.StartTime,
-				DurationSeconds: evt.DurationSeconds,
-			}
-		}
+// aggregateBrowserByDomain groups the browser visits in a time range by browser profile and domain.
+// Browser visits are URL context only - duration tracking comes from window focus events.
+func (s *ReportsService) aggregateBrowserByDomain(start, end int64) ([]DomainGroup, error) {
+	counts, err := s.store.GetUniqueDomainVisitCounts(start, end)
+	if err != nil {
+		return nil, err
 	}
-
-	// Convert to slice and sort by duration
-	var meetings []MeetingDetection
-	for _, m := range meetingMap {
-		meetings = append(meetings, *m)
+	titles, err := s.store.GetDomainSampleTitles(start, end, domainSampleTitles)
+	if err != nil {
+		return nil, err
 	}
-	sort.Slice(meetings, func(i, j int) bool {
-		return meetings[i].DurationSeconds > meetings[j].DurationSeconds
-	})
-
-	return meetings
-}
-
-// cleanMeetingTitle extracts clean meeting title from raw window title.
-func (s *ReportsService) cleanMeetingTitle(windowTitle, platform string) string {
-	switch platform {
-	case "Slack":
-		// "Huddle: #eng-mv - Arcturus in Slackspace - Slack" → "#eng-mv"
-		if strings.Contains(windowTitle, "Huddle:") {
-			parts := strings.Split(windowTitle, "Huddle:")
-			if len(parts) > 1 {
-				afterHuddle := strings.TrimSpace(parts[1])
-				// Take up to first " - "
-				if idx := strings.Index(afterHuddle, " - "); idx > 0 {
-					return strings.TrimSpace(afterHuddle[:idx])
-				}
-				return afterHuddle
-			}
-		}
-		return "Slack Huddle"
+	blocklist := s.loadDomainBlocklist()
 
-	case "Zoom":
-		// "Zoom Meeting" or specific meeting name
-		if strings.Contains(strings.ToLower(windowTitle), "zoom meeting") {
-			return "Zoom Meeting"
+	result := make([]DomainGroup, 0, len(counts))
+	for _, c := range counts {
+		dg := DomainGroup{
+			Domain:          c.Domain,
+			Profile:         c.BrowserProfile,
+			VisitCount:      c.VisitCount,
+			TopicLabel:      inferDomainTopic(c.Domain),
+			SampleTitles:    titles[c.Domain][c.BrowserProfile],
+			IsBlocklisted:   isBlocklistedDomain(c.Domain, blocklist),
 		}
-		return windowTitle
-
-	case "Google Meet":
-		// "Meet - abc-defg-hij" → "abc-defg-hij"
-		if strings.HasPrefix(windowTitle, "Meet - ") {
-			return strings.TrimPrefix(windowTitle, "Meet - ")
+		if dg.SampleTitles == nil {
+			dg.SampleTitles = []string{}
 		}
-		return "Google Meet"
-
-	case "Teams":
-		// Extract meeting name if possible
-		return "Teams Meeting"
+		result = append(result, dg)
 	}
 
-	return windowTitle
+	return result, nil
 }
 
-// aggregateBrowserByDomain groups browser visits by domain.
-// Browser visits are URL context only - duration tracking comes from window focus events.
-func (s *ReportsService) aggregateBrowserByDomain(visits []*storage.BrowserVisit, focusEvents []*storage.WindowFocusEvent) []DomainGroup {
-	domainMap := make(map[string]*DomainGroup)
+// loadDomainBlocklist returns the set of blocklisted domains. Errors yield an empty set,
+// since the blocklist only annotates reports.
+func (s *ReportsService) loadDomainBlocklist() map[string]bool {
+	blocklist := make(map[string]bool)
+	domains, err := s.store.GetBlocklistedDomains()
+	if err != nil {
+		return blocklist
+	}
+	for _, d := range domains {
+		blocklist[d.Domain] = true
+	}
+	return blocklist
+}
 
-	for _, visit := range visits {
-		if existing, ok := domainMap[visit.Domain]; ok {
-			existing.VisitCount++
-			// Keep first 3 sample titles
-			if len(existing.SampleTitles) < 3 && visit.Title.Valid && visit.Title.String != "" {
-				// Check if title is already in samples
-				found := false
-				for _, t := range existing.SampleTitles {
-					if t == visit.Title.String {
-						found = true
-						break
-					}
-				}
-				if !found {
-					existing.SampleTitles = append(existing.SampleTitles, visit.Title.String)
-				}
-			}
-		} else {
-			dg := &DomainGroup{
-				Domain:          visit.Domain,
-				VisitCount:      1,
-				TopicLabel:      s.inferDomainTopic(visit.Domain),
-				SampleTitles:    []string{},
-				DurationSeconds: 0, // Duration tracked by focus events, not browser visits
-			}
-			if visit.Title.Valid && visit.Title.String != "" {
-				dg.SampleTitles = append(dg.SampleTitles, visit.Title.String)
-			}
-			domainMap[visit.Domain] = dg
+// isBlocklistedDomain reports whether a domain or any parent domain is blocklisted,
+// so "old.reddit.com" matches a "reddit.com" entry.
+func isBlocklistedDomain(domain string, blocklist map[string]bool) bool {
+	d := storage.NormalizeDomain(domain)
+	for d != "" {
+		if blocklist[d] {
+			return true
 		}
+		idx := strings.Index(d, ".")
+		if idx < 0 {
+			break
+		}
+		d = d[idx+1:]
 	}
+	return false
+}
 
-	// Convert to slice and sort by visit count (since duration is always 0)
-	var result []DomainGroup
-	for _, dg := range domainMap {
-		result = append(result, *dg)
+// formatVisitCount formats a visit count like "1 visit" or "3 visits".
+func formatVisitCount(count int64) string {
+	if count == 1 {
+		return "1 visit"
 	}
-	sort.Slice(result, func(i, j int) bool {
-		return result[i].VisitCount > result[j].VisitCount
-	})
-
-	return result
+	return fmt.Sprintf("%d visits", count)
 }
 
 // inferDomainTopic applies heuristic rules to categorize domains.
-func (s *ReportsService) inferDomainTopic(domain string) string {
+func inferDomainTopic(domain string) string {
 	lower := strings.ToLower(domain)
 
 	// Development
@@ -716,6 +785,7 @@ func (s *ReportsService) groupActivitiesByProject(ctx *EnhancedReportContext) []
 	}
 
 	// Group git commits
+	commitDetector := newProjectDetector(s.store, DetectionSourceCommitMessage)
 	for _, commit := range ctx.GitCommits {
 		// Get repo path from cache or lookup
 		repoPath, ok := repoPathCache[commit.RepositoryID]
@@ -728,7 +798,7 @@ func (s *ReportsService) groupActivitiesByProject(ctx *EnhancedReportContext) []
 			}
 			repoPathCache[commit.RepositoryID] = repoPath
 		}
-		projectName := s.DetectProjectFromGitRepo(repoPath)
+		projectName := s.detectProjectFromCommit(commitDetector, commit, repoPath)
 		project := getProject(projectName)
 		project.Commits = append(project.Commits, commit)
 		project.CommitCount++
@@ -1115,6 +1185,22 @@ func toServiceReport(r *storage.Report) *Report {
 		StartTime:  r.StartTime.Int64,
 		EndTime:    r.EndTime.Int64,
 		CreatedAt:  r.CreatedAt,
+		Version:    r.Version,
+		ParentID:   r.ParentID.Int64,
+	}
+}
+
+// toReportMeta converts a storage report to report metadata (without content).
+func toReportMeta(r *storage.Report) *ReportMeta {
+	return &ReportMeta{
+		ID:         r.ID,
+		Title:      r.Title,
+		TimeRange:  r.TimeRange,
+		ReportType: r.ReportType,
+		Format:     r.Format,
+		CreatedAt:  r.CreatedAt,
+		Version:    r.Version,
+		ParentID:   r.ParentID.Int64,
 	}
 }
 
@@ -1128,11 +1214,17 @@ func (s *ReportsService) GenerateReport(timeRange, reportType string, includeScr
 
 	// Build report content
 	var content string
+	format := "html"
 	switch reportType {
 	case "standup":
 		content, err = s.generateStandupReport(tr, includeScreenshots)
 	case "detailed":
 		content, err = s.generateDetailedReport(tr, includeScreenshots)
+	case "journal":
+		content, err = s.generateJournalReport(tr)
+		format = "markdown"
+	case "ai_narrative":
+		content, format, err = s.generateNarrativeReport(tr, includeScreenshots)
 	default: // "summary"
 		content, err = s.generateSummaryReport(tr, includeScreenshots)
 	}
@@ -1141,26 +1233,56 @@ func (s *ReportsService) GenerateReport(timeRange, reportType string, includeScr
 		return nil, err
 	}
 
+	// Pinned events go at the top of every report
+	content = s.withHighlights(tr, content, format)
+	// Report goal progress goes at the bottom
+	content = s.withGoals(tr, content, format)
+
 	// Save report
 	storageReport := &storage.Report{
-		Title:      fmt.Sprintf("%s Report: %s", strings.Title(reportType), tr.Label),
+		Title:      fmt.Sprintf("%s Report: %s", reportTypeTitle(reportType), tr.Label),
 		TimeRange:  timeRange,
 		ReportType: reportType,
-		Format:     "html",
+		Format:     format,
 		Content:    storage.NullString(content),
 		StartTime:  storage.NullInt64(tr.Start),
 		EndTime:    storage.NullInt64(tr.End),
 	}
 
+	// Re-generating an existing report saves a new version instead of replacing it
+	original, err := s.store.FindOriginalReport(storageReport.Title, reportType, tr.Start, tr.End)
+	if err != nil {
+		return nil, err
+	}
+	if original != nil {
+		latest, err := s.store.GetLatestReportVersion(original.ID)
+		if err != nil {
+			return nil, err
+		}
+		storageReport.ParentID = storage.NullInt64(original.ID)
+		storageReport.Version = latest + 1
+	}
+
 	id, err := s.store.SaveReport(storageReport)
 	if err != nil {
 		return nil, err
 	}
 	storageReport.ID = id
+	if storageReport.Version == 0 {
+		storageReport.Version = 1
+	}
 
 	return toServiceReport(storageReport), nil
 }
 
+// reportTypeTitle returns the display name of a report type used in report titles.
+func reportTypeTitle(reportType string) string {
+	if reportType == "ai_narrative" {
+		return "AI Narrative"
+	}
+	return strings.Title(reportType)
+}
+
 // GenerateReportWithFilter generates a report filtered by project ID.
 // If projectID is 0, returns all activities (same as GenerateReport).
 func (s *ReportsService) GenerateReportWithFilter(timeRange, reportType string, includeScreenshots bool, projectID int64) (*Report, error) {
@@ -1290,23 +1412,23 @@ func (s *ReportsService) buildProjectReport(projectName string, tr *TimeRange, e
 // generateSummaryReport creates a visual HTML summary report using the unified weekly summary data.
 func (s *ReportsService) generateSummaryReport(tr *TimeRange, includeScreenshots bool) (string, error) {
 	// Use the same data building as the CLI weekly summary
-	data, err := s.buildWeeklySummaryData(tr.Start, tr.End, tr.StartDate, tr.EndDate)
+	data, err := s.buildWeeklySummaryData(context.Background(), tr.Start, tr.End, tr.StartDate, tr.EndDate)
 	if err != nil {
 		return "", fmt.Errorf("failed to build summary data: %w", err)
 	}
 
-	// Format as HTML for display in the UI
-	return s.formatWeeklySummaryHTML(data), nil
+	// Format as HTML for display in the UI, through the default summary template
+	return s.renderReportHTML(tr, &TemplateData{ReportType: "summary", Label: tr.Label, Summary: newSummaryLayout(data)})
 }
 
 // generateSummaryReportMarkdown generates a markdown version of the summary report.
 // This is used for markdown export.
 func (s *ReportsService) generateSummaryReportMarkdown(tr *TimeRange) (string, error) {
-	data, err := s.buildWeeklySummaryData(tr.Start, tr.End, tr.StartDate, tr.EndDate)
+	data, err := s.buildWeeklySummaryData(context.Background(), tr.Start, tr.End, tr.StartDate, tr.EndDate)
 	if err != nil {
 		return "", fmt.Errorf("failed to build summary data: %w", err)
 	}
-	return s.formatWeeklySummaryMarkdown(data), nil
+	return s.applyMarkdownTemplate("summary", tr, s.formatWeeklySummaryMarkdown(data))
 }
 
 // HourlyActivityData represents activity for a single hour.
@@ -1509,7 +1631,7 @@ func (s *ReportsService) generateDetailedReport(tr *TimeRange, includeScreenshot
 	var timelineEvents []TimelineEvent
 
 	// Get git commits
-	gitCommits, _ := s.store.GetGitCommitsByTimeRange(tr.Start, tr.End)
+	gitCommits, _ := s.userGitCommits(tr.Start, tr.End)
 	for _, commit := range gitCommits {
 		timelineEvents = append(timelineEvents, TimelineEvent{
 			Timestamp: commit.Timestamp,
@@ -1575,6 +1697,17 @@ func (s *ReportsService) generateDetailedReport(tr *TimeRange, includeScreenshot
 	}
 	sb.WriteString(`</div>`)
 
+	// Languages Section
+	if languages, _ := s.analytics.GetLanguageStats(tr.Start, tr.End); len(languages) > 0 {
+		sb.WriteString(`<div style="margin-bottom: 32px;">
+			<div style="font-size: 1.1rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px; padding-bottom: 8px; border-bottom: 2px solid rgba(148, 163, 184, 0.2);">💻 Languages</div>
+			<div style="display: flex; gap: 8px; flex-wrap: wrap;">`)
+		for _, lang := range languages {
+			sb.WriteString(fmt.Sprintf(`<span style="background: rgba(168, 85, 247, 0.15); color: #c084fc; padding: 4px 10px; border-radius: 4px; font-size: 0.85rem;">%s <span style="color: #94a3b8;">%.0f%%</span></span>`, esc(lang.Language), lang.Percentage))
+		}
+		sb.WriteString(`</div></div>`)
+	}
+
 	// Sessions Section
 	sessions, _ := s.store.GetSessionsByTimeRange(tr.Start, tr.End)
 
@@ -1582,8 +1715,15 @@ func (s *ReportsService) generateDetailedReport(tr *TimeRange, includeScreenshot
 		sb.WriteString(`<div style="margin-bottom: 32px;">
 			<div style="font-size: 1.1rem; font-weight: 600; color: #f1f5f9; margin-bottom: 16px; padding-bottom: 8px; border-bottom: 2px solid rgba(148, 163, 184, 0.2);">🎯 Sessions</div>`)
 
+		// Load context for all sessions at once instead of querying per session
+		sessionIDs := make([]int64, len(sessions))
+		for i, sess := range sessions {
+			sessionIDs[i] = sess.ID
+		}
+		contexts, _ := s.timeline.GetSessionContextBatch(sessionIDs)
+
 		for _, sess := range sessions {
-			ctx, _ := s.timeline.GetSessionContext(sess.ID)
+			ctx := contexts[sess.ID]
 			if ctx == nil {
 				continue
 			}
@@ -1644,7 +1784,7 @@ func (s *ReportsService) generateDetailedReport(tr *TimeRange, includeScreenshot
 				sb.WriteString(`</tbody></table></div></div>`)
 
 				// === WINDOW DETAILS FOR THIS SESSION ===
-				sessionFocusEvents, _ := s.store.GetWindowFocusEventsBySession(sess.ID)
+				sessionFocusEvents := ctx.FocusEvents
 				if len(sessionFocusEvents) > 0 {
 					sb.WriteString(`<div style="margin-top: 12px;">
 						<div style="font-size: 0.75rem; font-weight: 600; color: #94a3b8; margin-bottom: 8px; text-transform: uppercase;">Window Details</div>`)
@@ -1736,17 +1876,19 @@ func (s *ReportsService) generateDetailedReport(tr *TimeRange, includeScreenshot
 
 // generateStandupReport creates an HTML standup-style report following the standard 3-question format.
 func (s *ReportsService) generateStandupReport(tr *TimeRange, includeScreenshots bool) (string, error) {
-	var sb strings.Builder
-
-	// Get sessions first (needed for batch loading summaries)
+	// Get sessions first (needed for batch loading session context)
 	sessions, _ := s.store.GetSessionsByTimeRange(tr.Start, tr.End)
 
-	// Batch load all summaries for these sessions (optimization)
+	// Batch load context for these sessions (optimization)
 	sessionIDs := make([]int64, len(sessions))
 	for i, sess := range sessions {
 		sessionIDs[i] = sess.ID
 	}
-	summariesMap, _ := s.store.GetSummariesForSessions(sessionIDs)
+	contexts, _ := s.timeline.GetSessionContextBatch(sessionIDs)
+	summariesMap := make(map[int64]*storage.Summary, len(contexts))
+	for id, ctx := range contexts {
+		summariesMap[id] = ctx.Summary
+	}
 
 	// Get app usage and calculate total time
 	appUsage, _ := s.analytics.GetAppUsage(tr.Start, tr.End)
@@ -1756,48 +1898,30 @@ func (s *ReportsService) generateStandupReport(tr *TimeRange, includeScreenshots
 	}
 
 	// Get commits
-	commits, _ := s.store.GetGitCommitsByTimeRange(tr.Start, tr.End)
+	commits, _ := s.userGitCommits(tr.Start, tr.End)
 
-	// === START HTML ===
-	sb.WriteString(`<div style="font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; max-width: 100%; color: #e2e8f0;">`)
-
-	// Header with summary
-	sb.WriteString(fmt.Sprintf(`<div style="margin-bottom: 20px;">
-		<h1 style="font-size: 1.5rem; font-weight: 700; margin: 0 0 8px 0; color: #f1f5f9;">Standup Report: %s</h1>
-		<p style="color: #94a3b8; margin: 0; font-size: 0.9rem;">%s tracked across %d sessions</p>
-	</div>`, tr.Label, formatMinutes(totalMinutes), len(sessions)))
-
-	// === WHAT I ACCOMPLISHED ===
-	sb.WriteString(`<div style="margin-bottom: 20px; padding: 16px; background: rgba(34, 197, 94, 0.1); border-radius: 8px; border-left: 3px solid #22c55e;">
-		<div style="font-size: 0.85rem; font-weight: 600; color: #22c55e; margin-bottom: 12px;">✓ What I accomplished</div>`)
+	layout := &StandupLayout{
+		TotalMinutes:    totalMinutes,
+		SessionCount:    len(sessions),
+		Accomplishments: s.extractAccomplishmentsOptimized(sessions, summariesMap),
+	}
 
-	accomplishments := s.extractAccomplishmentsOptimized(sessions, summariesMap)
-	if len(accomplishments) > 0 {
-		for _, acc := range accomplishments {
-			sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.85rem; color: #cbd5e1; margin-bottom: 6px; padding-left: 8px;">• %s</div>`, esc(acc)))
+	// === COMMITS ===
+	seen := make(map[string]bool)
+	for _, commit := range commits {
+		if seen[commit.Message] {
+			continue
 		}
-	} else if len(commits) > 0 {
-		sb.WriteString(`<div style="font-size: 0.75rem; color: #64748b; margin-bottom: 6px;">Based on commits:</div>`)
-		seen := make(map[string]bool)
-		count := 0
-		for _, commit := range commits {
-			if !seen[commit.Message] && count < 5 {
-				seen[commit.Message] = true
-				sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.85rem; color: #cbd5e1; margin-bottom: 6px; padding-left: 8px;">• %s</div>`, esc(commit.Message)))
-				count++
-			}
+		seen[commit.Message] = true
+		layout.Commits = append(layout.Commits, commit)
+		if len(layout.CommitHighlights) < 5 {
+			layout.CommitHighlights = append(layout.CommitHighlights, commit.Message)
 		}
-	} else {
-		sb.WriteString(`<div style="font-size: 0.85rem; color: #64748b; font-style: italic;">No specific accomplishments recorded</div>`)
 	}
-	sb.WriteString(`</div>`)
 
 	// === MEETINGS SECTION ===
 	enhancedCtx, err := s.buildEnhancedReportContext(tr)
-	if err == nil && len(enhancedCtx.Meetings) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 20px; padding: 16px; background: rgba(59, 130, 246, 0.1); border-radius: 8px; border-left: 3px solid #3b82f6;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #3b82f6; margin-bottom: 12px;">📅 Meetings</div>`)
-
+	if err == nil {
 		for _, meeting := range enhancedCtx.Meetings {
 			mins := int64(meeting.DurationSeconds / 60)
 			if mins < 1 {
@@ -1815,92 +1939,63 @@ func (s *ReportsService) generateStandupReport(tr *TimeRange, includeScreenshots
 			case "Teams":
 				icon = "👥"
 			}
-
-			sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.85rem; color: #cbd5e1; margin-bottom: 6px; padding-left: 8px;">
-				%s %s: %s (%s)
-			</div>`, icon, esc(meeting.Platform), esc(meeting.Title), formatMinutes(mins)))
-		}
-
-		sb.WriteString(`</div>`)
-	}
-
-	// === COMMITS ===
-	if len(commits) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 20px; padding: 16px; background: rgba(249, 115, 22, 0.1); border-radius: 8px; border-left: 3px solid #f97316;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f97316; margin-bottom: 12px;">📝 Commits</div>`)
-		seen := make(map[string]bool)
-		for _, commit := range commits {
-			if !seen[commit.Message] {
-				seen[commit.Message] = true
-				sb.WriteString(fmt.Sprintf(`<div style="display: flex; gap: 8px; margin-bottom: 6px; align-items: baseline;">
-					<code style="font-size: 0.7rem; color: #f97316; background: rgba(249, 115, 22, 0.15); padding: 2px 6px; border-radius: 4px; flex-shrink: 0;">%s</code>
-					<span style="font-size: 0.85rem; color: #cbd5e1;">%s</span>
-				</div>`, esc(commit.ShortHash), esc(commit.Message)))
+			layout.Meetings = append(layout.Meetings, StandupMeeting{
+				Icon:     icon,
+				Platform: meeting.Platform,
+				Title:    meeting.Title,
+				Minutes:  mins,
+			})
+		}
+	}
+
+	// === WHAT I'M WORKING ON ===
+	threads, _ := NewTaskThreadService(s.store, s.timeline).GetThreads(false)
+	for _, thread := range threads {
+		detail := "not started"
+		if thread.DaySpan > 0 {
+			detail = fmt.Sprintf("%s over %d days", formatMinutes(thread.TotalMinutes), thread.DaySpan)
+			if thread.DaySpan == 1 {
+				detail = fmt.Sprintf("%s in 1 day", formatMinutes(thread.TotalMinutes))
 			}
 		}
-		sb.WriteString(`</div>`)
+		layout.Threads = append(layout.Threads, StandupThread{Name: thread.Name, Detail: detail})
 	}
 
 	// === WHAT'S NEXT ===
-	sb.WriteString(`<div style="margin-bottom: 20px; padding: 16px; background: rgba(59, 130, 246, 0.1); border-radius: 8px; border-left: 3px solid #3b82f6;">
-		<div style="font-size: 0.85rem; font-weight: 600; color: #3b82f6; margin-bottom: 12px;">🎯 What's next</div>`)
-
 	if len(commits) > 0 {
 		lastCommit := commits[len(commits)-1]
 		if strings.Contains(strings.ToLower(lastCommit.Message), "wip") ||
 			strings.Contains(strings.ToLower(lastCommit.Message), "in progress") {
-			sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.85rem; color: #cbd5e1; padding-left: 8px;">• Continue work on: %s</div>`, esc(lastCommit.Message)))
-		} else {
-			sb.WriteString(`<div style="font-size: 0.85rem; color: #64748b; font-style: italic; padding-left: 8px;">Add your planned tasks here</div>`)
+			layout.NextTask = lastCommit.Message
 		}
-	} else {
-		sb.WriteString(`<div style="font-size: 0.85rem; color: #64748b; font-style: italic; padding-left: 8px;">Add your planned tasks here</div>`)
 	}
-	sb.WriteString(`</div>`)
-
-	// === BLOCKERS ===
-	sb.WriteString(`<div style="margin-bottom: 20px; padding: 16px; background: rgba(100, 116, 139, 0.1); border-radius: 8px; border-left: 3px solid #64748b;">
-		<div style="font-size: 0.85rem; font-weight: 600; color: #94a3b8; margin-bottom: 12px;">🚧 Blockers</div>
-		<div style="font-size: 0.85rem; color: #64748b; padding-left: 8px;">• None identified</div>
-	</div>`)
 
 	// === TIME SUMMARY ===
-	if len(appUsage) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 20px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">⏱️ Time Summary</div>`)
-
-		maxDuration := appUsage[0].DurationSeconds
-		count := 0
-		for _, app := range appUsage {
-			if count >= 5 {
-				break
-			}
-			appName := GetFriendlyAppName(app.AppName)
-			category := s.analytics.CategorizeApp(app.AppName)
-			barWidth := int(app.DurationSeconds / maxDuration * 100)
-			barColor := "#64748b"
-			if category == CategoryProductive {
-				barColor = "#22c55e"
-			} else if category == CategoryDistracting {
-				barColor = "#ef4444"
-			}
-
-			sb.WriteString(fmt.Sprintf(`
-			<div style="display: flex; align-items: center; margin-bottom: 6px;">
-				<div style="width: 80px; font-size: 0.8rem; color: #e2e8f0; white-space: nowrap; overflow: hidden; text-overflow: ellipsis;">%s</div>
-				<div style="flex: 1; height: 16px; background: rgba(30, 41, 59, 0.5); border-radius: 4px; margin: 0 12px; overflow: hidden;">
-					<div style="height: 100%%; width: %d%%; background: %s; border-radius: 4px;"></div>
-				</div>
-				<div style="width: 45px; text-align: right; font-size: 0.8rem; color: #94a3b8;">%s</div>
-			</div>`, esc(appName), barWidth, barColor, formatMinutes(int64(app.DurationSeconds/60))))
-			count++
+	for i, app := range appUsage {
+		if i >= 5 {
+			break
 		}
-		sb.WriteString(`</div>`)
+		category := s.analytics.CategorizeApp(app.AppName)
+		barColor := "#64748b"
+		if category == CategoryProductive {
+			barColor = "#22c55e"
+		} else if category == CategoryDistracting {
+			barColor = "#ef4444"
+		}
+		layout.TopApps = append(layout.TopApps, StandupApp{
+			Name:     GetFriendlyAppName(app.AppName),
+			BarWidth: int(app.DurationSeconds / appUsage[0].DurationSeconds * 100),
+			BarColor: barColor,
+			Minutes:  int64(app.DurationSeconds / 60),
+		})
 	}
 
-	sb.WriteString(`</div>`) // End main container
-
-	return sb.String(), nil
+	return s.renderReportHTML(tr, &TemplateData{
+		EnhancedReportContext: enhancedCtx,
+		ReportType:            "standup",
+		Label:                 tr.Label,
+		Standup:               layout,
+	})
 }
 
 // ExportReport exports a report in the specified format.
@@ -1915,6 +2010,15 @@ func (s *ReportsService) ExportReport(reportID int64, format string) (string, er
 		content = report.Content.String
 	}
 
+	// Journal and AI narrative reports are stored as Markdown
+	if report.ReportType == "journal" || report.Format == "markdown" {
+		if format == "html" {
+			content = fmt.Sprintf(`<div style="white-space: pre-wrap;">%s</div>`, esc(content))
+		} else {
+			return content, nil
+		}
+	}
+
 	switch format {
 	case "html":
 		// The content is already HTML from the new generateSummaryReport
@@ -1949,7 +2053,11 @@ body { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-
 			EndDate:   endDate,
 		}
 
-		return s.generateSummaryReportMarkdown(tr)
+		markdown, err := s.generateSummaryReportMarkdown(tr)
+		if err != nil {
+			return "", err
+		}
+		return s.withHighlights(tr, markdown, "markdown"), nil
 
 	default:
 		// Default to HTML content
@@ -1971,7 +2079,7 @@ type DailySummary struct {
 	ID          int64  `json:"id"`
 	Date        string `json:"date"`        // YYYY-MM-DD
 	Summary     string `json:"summary"`     // Preview text (first ~200 chars)
-	TotalTime   int64  `json:"totalTime"`   // Total active time in seconds
+	TotalTime   int64  `json:"totalTime"`   // Total focus time in seconds
 	SessionsCount int  `json:"sessionsCount"`
 	CreatedAt   int64  `json:"createdAt"`
 }
@@ -1985,19 +2093,87 @@ func (s *ReportsService) GetReportHistory() ([]*ReportMeta, error) {
 
 	var metas []*ReportMeta
 	for _, r := range reports {
-		metas = append(metas, &ReportMeta{
-			ID:         r.ID,
-			Title:      r.Title,
-			TimeRange:  r.TimeRange,
-			ReportType: r.ReportType,
-			Format:     r.Format,
-			CreatedAt:  r.CreatedAt,
-		})
+		metas = append(metas, toReportMeta(r))
 	}
 
 	return metas, nil
 }
 
+// GetReportVersions returns all versions of a report, oldest first.
+// reportID may be the original report or any of its versions.
+func (s *ReportsService) GetReportVersions(reportID int64) ([]*ReportMeta, error) {
+	originalID, err := s.originalReportID(reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.store.GetReportVersions(originalID)
+	if err != nil {
+		return nil, err
+	}
+
+	metas := make([]*ReportMeta, 0, len(versions))
+	for _, r := range versions {
+		metas = append(metas, toReportMeta(r))
+	}
+	return metas, nil
+}
+
+// RestoreReportVersion copies a version's content into a new latest version of the report.
+func (s *ReportsService) RestoreReportVersion(versionID int64) (*Report, error) {
+	version, err := s.store.GetReport(versionID)
+	if err != nil {
+		return nil, err
+	}
+	if version == nil {
+		return nil, fmt.Errorf("report not found: %d", versionID)
+	}
+
+	originalID := versionID
+	if version.ParentID.Valid {
+		originalID = version.ParentID.Int64
+	}
+	latest, err := s.store.GetLatestReportVersion(originalID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := &storage.Report{
+		Title:      version.Title,
+		TimeRange:  version.TimeRange,
+		ReportType: version.ReportType,
+		Format:     version.Format,
+		Content:    version.Content,
+		Filepath:   version.Filepath,
+		StartTime:  version.StartTime,
+		EndTime:    version.EndTime,
+		ParentID:   storage.NullInt64(originalID),
+		Version:    latest + 1,
+	}
+	id, err := s.store.SaveReport(restored)
+	if err != nil {
+		return nil, err
+	}
+	restored.ID = id
+
+	return toServiceReport(restored), nil
+}
+
+// originalReportID resolves a report or version ID to the ID of the original report.
+func (s *ReportsService) originalReportID(reportID int64) (int64, error) {
+	report, err := s.store.GetReport(reportID)
+	if err != nil {
+		return 0, err
+	}
+	if report == nil {
+		return 0, fmt.Errorf("report not found: %d", reportID)
+	}
+	if report.ParentID.Valid {
+		return report.ParentID.Int64, nil
+	}
+	return report.ID, nil
+}
+
 // GetDailySummaries returns auto-generated daily summary reports.
 // Returns summaries for days with activity, most recent first.
 func (s *ReportsService) GetDailySummaries(limit int) ([]*DailySummary, error) {
@@ -2011,7 +2187,10 @@ func (s *ReportsService) GetDailySummaries(limit int) ([]*DailySummary, error) {
 		return nil, err
 	}
 
-	var summaries []*DailySummary
+	// Collect single-day summary reports, keeping the latest version for each date
+	// (reports are ordered newest first)
+	var daily []*storage.Report
+	seenDates := make(map[string]bool)
 	for _, r := range reports {
 		// Filter to only summary reports for single days
 		if r.ReportType != "summary" {
@@ -2023,38 +2202,51 @@ func (s *ReportsService) GetDailySummaries(limit int) ([]*DailySummary, error) {
 			continue
 		}
 
-		startTime := time.Unix(r.StartTime.Int64, 0)
-		endTime := time.Unix(r.EndTime.Int64, 0)
+		date := time.Unix(r.StartTime.Int64, 0).Format("2006-01-02")
 
 		// Check if start and end are on the same day
-		if startTime.Format("2006-01-02") != endTime.Format("2006-01-02") {
+		if date != time.Unix(r.EndTime.Int64, 0).Format("2006-01-02") || seenDates[date] {
 			continue
 		}
+		seenDates[date] = true
 
-		// Get sessions for this day to calculate total time
-		sessions, _ := s.store.GetSessionsByTimeRange(r.StartTime.Int64, r.EndTime.Int64)
-		var totalTime int64
-		for _, sess := range sessions {
-			if sess.DurationSeconds.Valid {
-				totalTime += sess.DurationSeconds.Int64
-			}
+		daily = append(daily, r)
+		if len(daily) >= limit {
+			break
 		}
+	}
+	if len(daily) == 0 {
+		return nil, nil
+	}
+
+	// Load sessions for all days at once instead of one query per report
+	ranges := make([][2]int64, len(daily))
+	for i, r := range daily {
+		ranges[i] = [2]int64{r.StartTime.Int64, r.EndTime.Int64}
+	}
+	sessionsByRange, err := s.store.GetSessionsByTimeRanges(ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	// Total time comes from focus events (like the Timeline), summed per day in SQL
+	focusTime, err := s.store.GetFocusDurationByTimeRanges(ranges)
+	if err != nil {
+		return nil, err
+	}
 
-		// Extract preview text (first paragraph or ~200 chars)
-		preview := extractPreview(r.Content.String)
+	summaries := make([]*DailySummary, 0, len(daily))
+	for i, r := range daily {
+		totalTime := focusTime[ranges[i]]
 
 		summaries = append(summaries, &DailySummary{
 			ID:            r.ID,
-			Date:          startTime.Format("2006-01-02"),
-			Summary:       preview,
-			TotalTime:     totalTime,
-			SessionsCount: len(sessions),
+			Date:          time.Unix(r.StartTime.Int64, 0).Format("2006-01-02"),
+			Summary:       extractPreview(r.Content.String), // First paragraph or ~200 chars
+			TotalTime:     int64(totalTime),
+			SessionsCount: len(sessionsByRange[ranges[i]]),
 			CreatedAt:     r.CreatedAt,
 		})
-
-		if len(summaries) >= limit {
-			break
-		}
 	}
 
 	return summaries, nil
@@ -2103,8 +2295,8 @@ func extractPreview(content string) string {
 }
 
 // parseDateRange attempts to parse a date range string like "jan 5, 2026 - jan 12, 2026"
-// Returns start date, end date, label, and success bool
-func parseDateRange(input string) (time.Time, time.Time, string, bool) {
+// Returns start date, end date (at midnight in loc), label, and success bool
+func parseDateRange(input string, loc *time.Location) (time.Time, time.Time, string, bool) {
 	// Split on common separators
 	parts := strings.Split(input, " - ")
 	if len(parts) != 2 {
@@ -2125,8 +2317,8 @@ func parseDateRange(input string) (time.Time, time.Time, string, bool) {
 	}
 
 	// Normalize to start of day
-	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, time.Local)
-	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, time.Local)
+	startDate = time.Date(startDate.Year(), startDate.Month(), startDate.Day(), 0, 0, 0, 0, loc)
+	endDate = time.Date(endDate.Year(), endDate.Month(), endDate.Day(), 0, 0, 0, 0, loc)
 
 	// Generate label
 	label := fmt.Sprintf("%s - %s", startDate.Format("Jan 2, 2006"), endDate.Format("Jan 2, 2006"))
@@ -2195,20 +2387,36 @@ func parseFlexibleDate(input string) (time.Time, bool) {
 
 // ParseTimeRange parses natural language time input.
 func (s *ReportsService) ParseTimeRange(input string) (*TimeRange, error) {
-	now := time.Now()
-	input = strings.ToLower(strings.TrimSpace(input))
+	return parseTimeRangeAt(input, time.Now())
+}
+
+// weekdaysByName maps lowercase weekday names to time.Weekday.
+var weekdaysByName = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// parseTimeRangeAt parses natural language time input relative to now. An IANA timezone
+// suffix, e.g. "yesterday America/New_York" or "2026-01-05 UTC", sets the timezone day
+// boundaries are taken in; otherwise they're local.
+func parseTimeRangeAt(input string, now time.Time) (*TimeRange, error) {
+	input, loc := splitTimeRangeZone(strings.TrimSpace(input))
+	if loc != time.Local {
+		now = now.In(loc)
+	}
+	input = strings.ToLower(input)
 
 	var start, end time.Time
 	var label string
 
 	switch input {
 	case "today":
-		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
 		end = start.AddDate(0, 0, 1)
 		label = "Today"
 
 	case "yesterday":
-		start = time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month(), now.Day()-1, 0, 0, 0, 0, loc)
 		end = start.AddDate(0, 0, 1)
 		label = "Yesterday"
 
@@ -2217,7 +2425,7 @@ func (s *ReportsService) ParseTimeRange(input string) (*TimeRange, error) {
 		if weekday == 0 {
 			weekday = 7
 		}
-		start = time.Date(now.Year(), now.Month(), now.Day()-weekday+1, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month(), now.Day()-weekday+1, 0, 0, 0, 0, loc)
 		end = start.AddDate(0, 0, 7)
 		label = "This Week"
 
@@ -2226,45 +2434,75 @@ func (s *ReportsService) ParseTimeRange(input string) (*TimeRange, error) {
 		if weekday == 0 {
 			weekday = 7
 		}
-		start = time.Date(now.Year(), now.Month(), now.Day()-weekday-6, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month(), now.Day()-weekday-6, 0, 0, 0, 0, loc)
 		end = start.AddDate(0, 0, 7)
 		label = "Last Week"
 
 	case "this month":
-		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
 		end = start.AddDate(0, 1, 0)
 		label = "This Month"
 
 	case "last month":
-		start = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, time.Local)
-		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+		start = time.Date(now.Year(), now.Month()-1, 1, 0, 0, 0, 0, loc)
+		end = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
 		label = "Last Month"
 
 	default:
 		// Try "past N days" or "last N days"
 		pastDaysRe := regexp.MustCompile(`(?:past|last)\s+(\d+)\s+days?`)
+		// "monday" is the most recent Monday (today if it's Monday), "last monday" the one before
+		weekdayRe := regexp.MustCompile(`^(?:(last)\s+)?(monday|tuesday|wednesday|thursday|friday|saturday|sunday)$`)
+		relativeQuarterRe := regexp.MustCompile(`^(this|last)\s+quarter$`)
+		quarterRe := regexp.MustCompile(`^q([1-4])(?:\s+(\d{4}))?$`)
 		if matches := pastDaysRe.FindStringSubmatch(input); len(matches) == 2 {
 			days, _ := strconv.Atoi(matches[1])
-			start = time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, time.Local)
-			end = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.Local)
+			start = time.Date(now.Year(), now.Month(), now.Day()-days+1, 0, 0, 0, 0, loc)
+			end = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
 			label = fmt.Sprintf("Past %d Days", days)
-		} else if parsedStart, parsedEnd, rangeLabel, ok := parseDateRange(input); ok {
+		} else if matches := weekdayRe.FindStringSubmatch(input); len(matches) == 3 {
+			daysAgo := (int(now.Weekday()) - int(weekdaysByName[matches[2]]) + 7) % 7
+			if matches[1] == "last" {
+				daysAgo += 7
+			}
+			start = time.Date(now.Year(), now.Month(), now.Day()-daysAgo, 0, 0, 0, 0, loc)
+			end = start.AddDate(0, 0, 1)
+			label = start.Format("Monday, January 2, 2006")
+		} else if matches := relativeQuarterRe.FindStringSubmatch(input); len(matches) == 2 {
+			quarterMonth := time.Month((int(now.Month())-1)/3*3 + 1)
+			start = time.Date(now.Year(), quarterMonth, 1, 0, 0, 0, 0, loc)
+			label = "This Quarter"
+			if matches[1] == "last" {
+				start = start.AddDate(0, -3, 0)
+				label = "Last Quarter"
+			}
+			end = start.AddDate(0, 3, 0)
+		} else if matches := quarterRe.FindStringSubmatch(input); len(matches) == 3 {
+			quarter, _ := strconv.Atoi(matches[1])
+			year := now.Year()
+			if matches[2] != "" {
+				year, _ = strconv.Atoi(matches[2])
+			}
+			start = time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, loc)
+			end = start.AddDate(0, 3, 0)
+			label = fmt.Sprintf("Q%d %d", quarter, year)
+		} else if parsedStart, parsedEnd, rangeLabel, ok := parseDateRange(input, loc); ok {
 			// Try parsing as date range (e.g., "jan 5, 2026 - jan 12, 2026")
 			start = parsedStart
 			end = parsedEnd.AddDate(0, 0, 1) // Include the end date
 			label = rangeLabel
 		} else {
 			// Try parsing as date
-			parsed, err := time.ParseInLocation("2006-01-02", input, time.Local)
+			parsed, err := time.ParseInLocation("2006-01-02", input, loc)
 			if err != nil {
 				// Try month name
-				parsed, err = time.ParseInLocation("January 2006", input, time.Local)
+				parsed, err = time.ParseInLocation("January 2006", input, loc)
 				if err != nil {
-					parsed, err = time.ParseInLocation("January", input, time.Local)
+					parsed, err = time.ParseInLocation("January", input, loc)
 					if err != nil {
 						return nil, fmt.Errorf("could not parse time range: %s", input)
 					}
-					parsed = time.Date(now.Year(), parsed.Month(), 1, 0, 0, 0, 0, time.Local)
+					parsed = time.Date(now.Year(), parsed.Month(), 1, 0, 0, 0, 0, loc)
 				}
 				start = parsed
 				end = start.AddDate(0, 1, 0)
@@ -2277,6 +2515,10 @@ func (s *ReportsService) ParseTimeRange(input string) (*TimeRange, error) {
 		}
 	}
 
+	if loc != time.Local {
+		label += " (" + loc.String() + ")"
+	}
+
 	return &TimeRange{
 		Start:     start.Unix(),
 		End:       end.Unix() - 1,
@@ -2286,6 +2528,27 @@ func (s *ReportsService) ParseTimeRange(input string) (*TimeRange, error) {
 	}, nil
 }
 
+// splitTimeRangeZone splits an IANA timezone suffix such as "Europe/Berlin" or "UTC" off a
+// time range. Returns the input unchanged and time.Local if there's none.
+func splitTimeRangeZone(input string) (string, *time.Location) {
+	idx := strings.LastIndex(input, " ")
+	if idx < 0 {
+		return input, time.Local
+	}
+	name := input[idx+1:]
+	if strings.EqualFold(name, "utc") {
+		return strings.TrimSpace(input[:idx]), time.UTC
+	}
+	if !strings.Contains(name, "/") {
+		return input, time.Local
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return input, time.Local
+	}
+	return strings.TrimSpace(input[:idx]), loc
+}
+
 // DeleteReport deletes a report by ID.
 func (s *ReportsService) DeleteReport(reportID int64) error {
 	return s.store.DeleteReport(reportID)
@@ -2306,6 +2569,9 @@ type WeeklySummaryData struct {
 	// Project distribution
 	Projects []ProjectSummary
 
+	// Day-by-day activity of projects with assigned focus time
+	ProjectTimelines []*ProjectTimeline
+
 	// Daily breakdown
 	DailyStats []DailySummaryStats
 
@@ -2313,6 +2579,8 @@ type WeeklySummaryData struct {
 	TotalInsertions int64
 	TotalDeletions  int64
 	CommitsByRepo   []*CommitsByRepo
+	CommitActivity  *CommitHeatmap // All repos, for the weeks leading up to the report
+	CommitQuality   *CommitQualityReport
 
 	// Meetings
 	Meetings []MeetingDetection
@@ -2335,6 +2603,21 @@ type WeeklySummaryData struct {
 	// Key accomplishments (top-level highlights)
 	KeyAccomplishments []string
 
+	// Rule-based behavioral insights
+	Insights []Insight
+
+	// Languages detected in VS Code settings
+	Languages []*LanguageUsage
+
+	// Most productive times of day over the weeks leading up to the report
+	PeakWindows *PeakWindows
+
+	// Burnout risk over the weeks leading up to the end of the report
+	BurnoutRisk *BurnoutRisk
+
+	// Hours of the day with the most distraction, highest score first
+	DistractedHours []*DistractedHourEntry
+
 	// Total communication time
 	TotalSlackMins int64
 	TotalZoomMins  int64
@@ -2378,6 +2661,7 @@ type BrowserDomainSummary struct {
 	VisitCount    int64
 	Category      string
 	SampleTitles  []string
+	IsBlocklisted bool
 }
 
 // FileSummary represents a downloaded file
@@ -2412,7 +2696,7 @@ func (s *ReportsService) GenerateWeeklySummaryMarkdown(startDate, endDate string
 	endUnix := end.Unix()
 
 	// Build all the data
-	data, err := s.buildWeeklySummaryData(startUnix, endUnix, startDate, endDate)
+	data, err := s.buildWeeklySummaryData(context.Background(), startUnix, endUnix, startDate, endDate)
 	if err != nil {
 		return "", err
 	}
@@ -2421,24 +2705,90 @@ func (s *ReportsService) GenerateWeeklySummaryMarkdown(startDate, endDate string
 	return s.formatWeeklySummaryMarkdown(data), nil
 }
 
-// buildWeeklySummaryData aggregates all data needed for the weekly summary.
-func (s *ReportsService) buildWeeklySummaryData(startUnix, endUnix int64, startDate, endDate string) (*WeeklySummaryData, error) {
+// SetAuthorEmails sets the user's git emails, used to leave other authors' commits out of
+// reports. Clears cached report data.
+func (s *ReportsService) SetAuthorEmails(emails []string) {
+	s.authorEmails = emails
+	s.ClearReportCache()
+}
+
+// userGitCommits returns the user's commits between start and end (see commitsByAuthor).
+func (s *ReportsService) userGitCommits(start, end int64) ([]*storage.GitCommit, error) {
+	commits, err := s.store.GetGitCommitsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return commitsByAuthor(commits, s.authorEmails), nil
+}
+
+// commitsByAuthor returns the commits whose author email is one of emails (case-insensitive),
+// keeping commits with no recorded email. If emails is empty, all commits are returned.
+func commitsByAuthor(commits []*storage.GitCommit, emails []string) []*storage.GitCommit {
+	if len(emails) == 0 {
+		return commits
+	}
+	var filtered []*storage.GitCommit
+	for _, commit := range commits {
+		if !commit.AuthorEmail.Valid || commit.AuthorEmail.String == "" {
+			filtered = append(filtered, commit)
+			continue
+		}
+		for _, email := range emails {
+			if strings.EqualFold(commit.AuthorEmail.String, email) {
+				filtered = append(filtered, commit)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// buildWeeklySummaryData returns the weekly summary data for a time range,
+// using the cached copy when the same range was built recently.
+func (s *ReportsService) buildWeeklySummaryData(ctx context.Context, startUnix, endUnix int64, startDate, endDate string) (*WeeklySummaryData, error) {
+	key := reportCacheKey(startUnix, endUnix, startDate, endDate)
+	if cached, ok := s.getCachedReportData(&s.weeklyCache, key); ok {
+		return cached.(*WeeklySummaryData), nil
+	}
+
+	data, err := s.loadWeeklySummaryData(ctx, startUnix, endUnix, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	s.weeklyCache.Store(key, &reportCacheEntry{value: data, start: startUnix, end: endUnix, expiresAt: time.Now().Add(reportCacheTTL)})
+	return data, nil
+}
+
+// loadWeeklySummaryData aggregates all data needed for the weekly summary.
+// Event sources are loaded in parallel; cancelling ctx stops loading them, and it's checked
+// again before the aggregation work starts.
+func (s *ReportsService) loadWeeklySummaryData(ctx context.Context, startUnix, endUnix int64, startDate, endDate string) (*WeeklySummaryData, error) {
 	data := &WeeklySummaryData{
 		StartDate: startDate,
 		EndDate:   endDate,
 	}
 
-	// Get sessions
-	sessions, _ := s.store.GetSessionsByTimeRange(startUnix, endUnix)
-	data.SessionCount = len(sessions)
-
-	// Get screenshots
-	screenshots, _ := s.store.GetScreenshotsByTimeRange(startUnix, endUnix)
-	data.ScreenshotCount = len(screenshots)
+	focusCount, err := s.store.CountFocusEventsByTimeRange(startUnix, endUnix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count focus events: %w", err)
+	}
+	events, err := s.loadWeeklyEvents(ctx, startUnix, endUnix, focusCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	sessions, focusEvents := events.Sessions, events.FocusEvents
+	gitCommits := commitsByAuthor(events.GitCommits, s.authorEmails)
+	fileEvents, browserVisits := events.FileEvents, events.BrowserVisits
 
-	// Get focus events
-	focusEvents, _ := s.store.GetWindowFocusEventsByTimeRange(startUnix, endUnix)
-	data.FocusEventCount = len(focusEvents)
+	data.SessionCount = len(sessions)
+	data.ScreenshotCount = len(events.Screenshots)
+	data.FocusEventCount = int(focusCount)
+	data.GitCommitCount = len(gitCommits)
+	data.ShellCmdCount = len(events.ShellCommands)
+	data.FileEventCount = len(fileEvents)
 
 	// Calculate total hours from focus events
 	var totalSeconds float64
@@ -2447,10 +2797,6 @@ func (s *ReportsService) buildWeeklySummaryData(startUnix, endUnix int64, startD
 	}
 	data.TotalHours = totalSeconds / 3600
 
-	// Get git commits
-	gitCommits, _ := s.store.GetGitCommitsByTimeRange(startUnix, endUnix)
-	data.GitCommitCount = len(gitCommits)
-
 	// Calculate git stats
 	for _, commit := range gitCommits {
 		if commit.Insertions.Valid {
@@ -2463,26 +2809,17 @@ func (s *ReportsService) buildWeeklySummaryData(startUnix, endUnix int64, startD
 
 	// Group commits by repo
 	data.CommitsByRepo = s.groupCommitsByRepo(gitCommits)
-
-	// Get shell commands
-	shellCmds, _ := s.store.GetShellCommandsByTimeRange(startUnix, endUnix)
-	data.ShellCmdCount = len(shellCmds)
-
-	// Get file events
-	fileEvents, _ := s.store.GetFileEventsByTimeRange(startUnix, endUnix)
-	data.FileEventCount = len(fileEvents)
+	data.CommitActivity, _ = s.commitActivity(0, time.Unix(endUnix, 0), reportCommitActivityWeeks)
+	data.CommitQuality = commitQualityReport(gitCommits)
 
 	// Extract downloads from file events
 	data.Downloads = s.extractDownloads(fileEvents)
 
-	// Get browser visits
-	browserVisits, _ := s.store.GetBrowserVisitsByTimeRange(startUnix, endUnix)
-
 	// Aggregate app usage with window breakdown
 	data.AppUsage = s.aggregateAppUsageWithWindows(focusEvents)
 
 	// Detect meetings
-	data.Meetings = s.detectMeetings(focusEvents)
+	data.Meetings = s.meetings.detect(focusEvents)
 
 	// Aggregate browser by domain with research topics
 	data.BrowserDomains, data.ResearchTopics = s.aggregateBrowserForWeekly(browserVisits, focusEvents)
@@ -2490,6 +2827,9 @@ func (s *ReportsService) buildWeeklySummaryData(startUnix, endUnix int64, startD
 	// Build project summaries from AI-detected projects (preferred)
 	// Fallback to heuristic detection if AI summaries not available
 	data.Projects = s.buildProjectSummariesFromAI(sessions, focusEvents, gitCommits, browserVisits)
+	if s.projects != nil {
+		data.ProjectTimelines, _ = s.projects.projectTimelines(startUnix, endUnix)
+	}
 
 	// Build daily stats
 	data.DailyStats = s.buildDailyStatsForWeekly(startUnix, endUnix, sessions, gitCommits, focusEvents)
@@ -2500,9 +2840,68 @@ func (s *ReportsService) buildWeeklySummaryData(startUnix, endUnix int64, startD
 	// Extract key accomplishments from commits
 	data.KeyAccomplishments = s.extractKeyAccomplishments(gitCommits)
 
+	// Surface behavioral patterns
+	data.Insights = buildWeeklyInsights(s.analytics, startUnix, endUnix, focusEvents, data.DailyStats)
+
+	// Languages worked on in VS Code
+	if s.analytics != nil {
+		data.Languages, _ = s.analytics.GetLanguageStats(startUnix, endUnix)
+		data.PeakWindows, _ = s.analytics.peakProductivityWindows(endUnix, defaultPeakWindowWeeks)
+		data.BurnoutRisk, _ = assessBurnoutRisk(s.analytics, time.Unix(endUnix+1, 0), defaultBurnoutWeeks)
+		if hours, err := s.analytics.distractedHours(startUnix, endUnix); err == nil {
+			data.DistractedHours = mostDistractedHours(hours, reportDistractedHoursLimit)
+		}
+	}
+
 	return data, nil
 }
 
+// loadWeeklyEvents loads all events for the weekly summary. Above focusStreamThreshold focus
+// events, focus events are streamed and consecutive events in the same window and session
+// are merged as they arrive, so only the merged events are held in memory.
+func (s *ReportsService) loadWeeklyEvents(ctx context.Context, startUnix, endUnix, focusCount int64) (*storage.AllEvents, error) {
+	if focusCount <= focusStreamThreshold {
+		return s.store.GetAllEventsForRange(ctx, startUnix, endUnix)
+	}
+
+	events, err := s.store.GetAllEventsExceptFocusForRange(ctx, startUnix, endUnix)
+	if err != nil {
+		return nil, err
+	}
+	err = s.store.StreamWindowFocusEvents(ctx, startUnix, endUnix, func(evt *storage.WindowFocusEvent) error {
+		events.FocusEvents = appendMergedFocusEvent(events.FocusEvents, evt)
+		return nil
+	})
+	return events, err
+}
+
+// appendMergedFocusEvent appends evt, or extends the last event instead if evt continues it
+// in the same app, window, session and project.
+func appendMergedFocusEvent(events []*storage.WindowFocusEvent, evt *storage.WindowFocusEvent) []*storage.WindowFocusEvent {
+	if n := len(events); n > 0 {
+		last := events[n-1]
+		if last.AppName == evt.AppName && last.WindowTitle == evt.WindowTitle &&
+			last.SessionID == evt.SessionID && last.ProjectID == evt.ProjectID && evt.StartTime <= last.EndTime {
+			last.EndTime = max(last.EndTime, evt.EndTime)
+			last.DurationSeconds += evt.DurationSeconds
+			return events
+		}
+	}
+	return append(events, evt)
+}
+
+// formatWorkspaceNames formats workspace folders as " (name1, name2)", or "" if there are none.
+func formatWorkspaceNames(workspaces []string) string {
+	if len(workspaces) == 0 {
+		return ""
+	}
+	names := make([]string, len(workspaces))
+	for i, ws := range workspaces {
+		names[i] = filepath.Base(ws)
+	}
+	return " (" + strings.Join(names, ", ") + ")"
+}
+
 // extractDownloads extracts downloaded files from file events
 func (s *ReportsService) extractDownloads(events []*storage.FileEvent) []FileSummary {
 	var downloads []FileSummary
@@ -2562,15 +2961,17 @@ func (s *ReportsService) inferFileCategory(filename string) string {
 func (s *ReportsService) aggregateBrowserForWeekly(visits []*storage.BrowserVisit, focusEvents []*storage.WindowFocusEvent) ([]BrowserDomainSummary, []ResearchTopic) {
 	domainMap := make(map[string]*BrowserDomainSummary)
 	topicMap := make(map[string]*ResearchTopic)
+	blocklist := s.loadDomainBlocklist()
 
 	// From browser visits (URL context only, no duration)
 	for _, visit := range visits {
 		domain := visit.Domain
 		if _, ok := domainMap[domain]; !ok {
 			domainMap[domain] = &BrowserDomainSummary{
-				Domain:       domain,
-				Category:     s.inferDomainTopic(domain),
-				SampleTitles: []string{},
+				Domain:        domain,
+				Category:      inferDomainTopic(domain),
+				SampleTitles:  []string{},
+				IsBlocklisted: isBlocklistedDomain(domain, blocklist),
 			}
 		}
 		domainMap[domain].VisitCount++
@@ -2620,7 +3021,10 @@ func (s *ReportsService) aggregateBrowserForWeekly(visits []*storage.BrowserVisi
 		domains = append(domains, *d)
 	}
 	sort.Slice(domains, func(i, j int) bool {
-		return domains[i].DurationMins > domains[j].DurationMins
+		if domains[i].DurationMins != domains[j].DurationMins {
+			return domains[i].DurationMins > domains[j].DurationMins
+		}
+		return domains[i].VisitCount > domains[j].VisitCount
 	})
 
 	var topics []ResearchTopic
@@ -2658,6 +3062,7 @@ func (s *ReportsService) buildProjectSummaries(focusEvents []*storage.WindowFocu
 	}
 
 	// From git commits - most reliable project detection
+	commitDetector := newProjectDetector(s.store, DetectionSourceCommitMessage)
 	for _, commit := range commits {
 		repoPath, ok := repoPathCache[commit.RepositoryID]
 		if !ok {
@@ -2670,7 +3075,7 @@ func (s *ReportsService) buildProjectSummaries(focusEvents []*storage.WindowFocu
 			repoPathCache[commit.RepositoryID] = repoPath
 		}
 
-		projectName := s.DetectProjectFromGitRepo(repoPath)
+		projectName := s.detectProjectFromCommit(commitDetector, commit, repoPath)
 		project := getProject(projectName)
 		project.CommitCount++
 
@@ -2787,6 +3192,7 @@ func (s *ReportsService) buildProjectSummariesFromAI(sessions []*storage.Session
 	}
 
 	// From git commits - project detection + time proxy
+	commitDetector := newProjectDetector(s.store, DetectionSourceCommitMessage)
 	for _, commit := range commits {
 		repoPath, ok := repoPathCache[commit.RepositoryID]
 		if !ok {
@@ -2799,7 +3205,7 @@ func (s *ReportsService) buildProjectSummariesFromAI(sessions []*storage.Session
 			repoPathCache[commit.RepositoryID] = repoPath
 		}
 
-		projectName := s.DetectProjectFromGitRepo(repoPath)
+		projectName := s.detectProjectFromCommit(commitDetector, commit, repoPath)
 		project := getProject(projectName)
 		project.CommitCount++
 
@@ -3130,103 +3536,38 @@ func (s *ReportsService) inferPrimaryFocusFromAccomplishments(accomplishments []
 	return "Development"
 }
 
-// formatWeeklySummaryHTML formats the weekly summary data as styled HTML
-func (s *ReportsService) formatWeeklySummaryHTML(data *WeeklySummaryData) string {
-	var sb strings.Builder
-
-	// Parse dates for display
-	startDate, _ := time.Parse("2006-01-02", data.StartDate)
-	endDate, _ := time.Parse("2006-01-02", data.EndDate)
+// formatWeeklySummaryHTML formats the weekly summary data as styled HTML, using the
+// built-in summary template.
+func (s *ReportsService) formatWeeklySummaryHTML(data *WeeklySummaryData) (string, error) {
+	return renderBuiltinReportLayout(&TemplateData{ReportType: "summary", Summary: newSummaryLayout(data)})
+}
 
-	// Add theme-aware CSS
-	sb.WriteString(`<style>
-		.report-container { font-family: -apple-system, BlinkMacSystemFont, 'Segoe UI', Roboto, sans-serif; max-width: 100%; }
-		.report-title { font-size: 1.5rem; font-weight: 700; margin: 0 0 16px 0; }
-		.report-card { margin-bottom: 24px; padding: 16px; border-radius: 12px; border: 1px solid; }
-		.report-card-title { font-size: 0.85rem; font-weight: 600; margin-bottom: 8px; }
-		.report-text { font-size: 0.9rem; line-height: 1.5; }
-		.report-stats-grid { display: grid; grid-template-columns: repeat(auto-fit, minmax(140px, 1fr)); gap: 12px; margin-bottom: 24px; }
-		.report-stat-card { border-radius: 12px; padding: 16px; border: 1px solid; }
-		.report-stat-label { font-size: 0.75rem; text-transform: uppercase; letter-spacing: 0.05em; margin-bottom: 8px; }
-		.report-stat-value { font-size: 2rem; font-weight: 700; line-height: 1; }
-		.report-stat-meta { font-size: 0.8rem; margin-top: 4px; }
-		.report-table { width: 100%; border-collapse: collapse; font-size: 0.8rem; }
-		.report-table th { text-align: left; padding: 8px 4px; }
-		.report-table td { padding: 8px 4px; }
-		.report-table tr { border-bottom: 1px solid; }
-		.report-project-card { margin-bottom: 16px; padding: 12px; border-radius: 8px; border-left: 3px solid; }
-		.report-project-title { font-size: 0.95rem; font-weight: 600; }
-		.report-project-stats { font-size: 0.8rem; }
-		.report-accomplishment { padding-left: 12px; margin-bottom: 2px; }
-
-		/* Light mode colors */
-		.report-container { color: #1e293b; }
-		.report-title { color: #0f172a; }
-		.report-card { background: linear-gradient(135deg, rgba(241, 245, 249, 0.8), rgba(241, 245, 249, 0.4)); border-color: rgba(148, 163, 184, 0.2); }
-		.report-card-title { color: #0f172a; }
-		.report-text { color: #475569; }
-		.report-stat-card { background: linear-gradient(135deg, rgba(241, 245, 249, 0.8), rgba(241, 245, 249, 0.4)); border-color: rgba(148, 163, 184, 0.2); }
-		.report-stat-label { color: #64748b; }
-		.report-stat-value { color: #0f172a; }
-		.report-stat-meta { color: #94a3b8; }
-		.report-table th { color: #64748b; }
-		.report-table td { color: #1e293b; }
-		.report-table tr { border-color: rgba(148, 163, 184, 0.2); }
-		.report-project-card { background: rgba(241, 245, 249, 0.5); border-left-color: #3b82f6; }
-		.report-project-title { color: #0f172a; }
-		.report-project-stats { color: #64748b; }
-		.report-accomplishment { color: #64748b; }
-
-		/* Dark mode colors */
-		.dark .report-container { color: #e2e8f0; }
-		.dark .report-title { color: #f1f5f9; }
-		.dark .report-card { background: linear-gradient(135deg, rgba(30, 41, 59, 0.8), rgba(30, 41, 59, 0.4)); border-color: rgba(148, 163, 184, 0.1); }
-		.dark .report-card-title { color: #f1f5f9; }
-		.dark .report-text { color: #cbd5e1; }
-		.dark .report-stat-card { background: linear-gradient(135deg, rgba(30, 41, 59, 0.8), rgba(30, 41, 59, 0.4)); border-color: rgba(148, 163, 184, 0.1); }
-		.dark .report-stat-label { color: #94a3b8; }
-		.dark .report-stat-value { color: #f1f5f9; }
-		.dark .report-stat-meta { color: #64748b; }
-		.dark .report-table th { color: #94a3b8; }
-		.dark .report-table td { color: #e2e8f0; }
-		.dark .report-table tr { border-color: rgba(148, 163, 184, 0.1); }
-		.dark .report-project-card { background: rgba(30, 41, 59, 0.5); border-left-color: #3b82f6; }
-		.dark .report-project-title { color: #f1f5f9; }
-		.dark .report-project-stats { color: #94a3b8; }
-		.dark .report-accomplishment { color: #94a3b8; }
-	</style>`)
-
-	// Main container
-	sb.WriteString(`<div class="report-container">`)
+// newSummaryLayout picks out what the summary layout shows from the weekly summary data.
+func newSummaryLayout(data *WeeklySummaryData) *SummaryLayout {
+	layout := &SummaryLayout{WeeklySummaryData: data}
 
 	// Title
-	isSingleDay := data.StartDate == data.EndDate
-	var title string
-	if isSingleDay {
-		title = fmt.Sprintf("Activity Summary: %s", startDate.Format("Monday, January 2, 2006"))
+	startDate, _ := time.Parse("2006-01-02", data.StartDate)
+	endDate, _ := time.Parse("2006-01-02", data.EndDate)
+	if data.StartDate == data.EndDate {
+		layout.Title = fmt.Sprintf("Activity Summary: %s", startDate.Format("Monday, January 2, 2006"))
 	} else if startDate.Month() == endDate.Month() && startDate.Year() == endDate.Year() {
-		title = fmt.Sprintf("Activity Summary: %s - %s, %d",
+		layout.Title = fmt.Sprintf("Activity Summary: %s - %s, %d",
 			startDate.Format("January 2"),
 			endDate.Format("2"),
 			startDate.Year())
 	} else {
-		title = fmt.Sprintf("Activity Summary: %s - %s",
+		layout.Title = fmt.Sprintf("Activity Summary: %s - %s",
 			startDate.Format("January 2, 2006"),
 			endDate.Format("January 2, 2006"))
 	}
-	sb.WriteString(fmt.Sprintf(`<h1 class="report-title">%s</h1>`, title))
 
-	// Executive Summary
-	sb.WriteString(`<div class="report-card">`)
-	sb.WriteString(`<div class="report-card-title">Executive Summary</div>`)
-
-	// Build executive summary - use first project that has meaningful content
-	primaryProject := "development work"
+	// Projects & Themes
 	for _, project := range data.Projects {
-		// Skip projects with < 0.5 hours and no commits (same filter as Projects section)
 		if project.Hours < 0.5 && project.CommitCount == 0 {
 			continue
 		}
+
 		// Check if project has meaningful content (accomplishments or commits)
 		hasAccomplishments := false
 		for _, accs := range project.DailyAccomplishments {
@@ -3235,309 +3576,84 @@ func (s *ReportsService) formatWeeklySummaryHTML(data *WeeklySummaryData) string
 				break
 			}
 		}
+		hasCommits := project.CommitCount > 0
 		hasSignificantTime := project.Hours >= 0.5
-		if !hasAccomplishments && project.CommitCount == 0 && !hasSignificantTime {
+
+		// Skip empty project shells - must have accomplishments, commits, OR significant time
+		// This ensures research/browser work still appears even without git activity
+		if !hasAccomplishments && !hasCommits && !hasSignificantTime {
 			continue
 		}
-		// Found a meaningful project
-		primaryProject = fmt.Sprintf("<strong>%s</strong>", esc(project.Name))
-		break
-	}
-	execSummary := fmt.Sprintf("This period was focused on %s.", primaryProject)
-	sb.WriteString(fmt.Sprintf(`<p class="report-text" style="margin: 0 0 12px 0;">%s</p>`, execSummary))
-
-	// Stats line
-	sb.WriteString(fmt.Sprintf(`<div class="report-stat-meta">
-		<strong class="report-stat-value" style="font-size: 0.85rem; font-weight: 600;">%s</strong> active time across <strong class="report-stat-value" style="font-size: 0.85rem; font-weight: 600;">%d sessions</strong>`,
-		formatHoursMinutes(data.TotalHours), data.SessionCount))
-	if data.GitCommitCount > 0 {
-		sb.WriteString(fmt.Sprintf(` • <strong style="color: #f97316;">%d commits</strong>`, data.GitCommitCount))
-	}
-	sb.WriteString(`</div></div>`)
-
-	// Stats Grid
-	sb.WriteString(`<div class="report-stats-grid">`)
-
-	// Total Time Card
-	sb.WriteString(fmt.Sprintf(`
-		<div class="report-stat-card">
-			<div class="report-stat-label">Active Time</div>
-			<div class="report-stat-value">%s</div>
-			<div class="report-stat-meta">%d sessions</div>
-		</div>`, formatHoursMinutesShort(data.TotalHours), data.SessionCount))
-
-	// Commits Card
-	if data.GitCommitCount > 0 {
-		sb.WriteString(fmt.Sprintf(`
-			<div class="report-stat-card">
-				<div class="report-stat-label">Commits</div>
-				<div class="report-stat-value" style="color: #f97316;">%d</div>
-				<div class="report-stat-meta">+%s -%s lines</div>
-			</div>`, data.GitCommitCount, formatNumber(data.TotalInsertions), formatNumber(data.TotalDeletions)))
-	}
-
-	// Screenshots Card
-	if data.ScreenshotCount > 0 {
-		sb.WriteString(fmt.Sprintf(`
-			<div class="report-stat-card">
-				<div class="report-stat-label">Screenshots</div>
-				<div class="report-stat-value" style="color: #3b82f6;">%d</div>
-				<div class="report-stat-meta">captured</div>
-			</div>`, data.ScreenshotCount))
-	}
-
-	sb.WriteString(`</div>`) // End stats grid
-
-	// Time Distribution by Day (for multi-day reports)
-	if len(data.DailyStats) > 1 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div class="report-card-title">Time Distribution by Day</div>
-			<div style="overflow-x: auto;">
-			<table class="report-table">
-				<thead>
-					<tr>
-						<th style="text-align: left;">Day</th>
-						<th style="text-align: right;">Hours</th>
-						<th style="text-align: right;">Sessions</th>
-						<th style="text-align: left;">Primary Focus</th>
-					</tr>
-				</thead>
-				<tbody>`)
-
-		for _, day := range data.DailyStats {
-			if day.Hours > 0 || day.SessionCount > 0 {
-				focus := day.PrimaryFocus
-				if focus == "" {
-					focus = "-"
-				}
-				sb.WriteString(fmt.Sprintf(`
-					<tr>
-						<td style="text-align: left;">%s</td>
-						<td style="text-align: right;">%s</td>
-						<td style="text-align: right;" class="report-stat-meta">%d</td>
-						<td style="text-align: left;" class="report-stat-meta">%s</td>
-					</tr>`, esc(day.DayName), formatHoursMinutesShort(day.Hours), day.SessionCount, esc(focus)))
-			}
+		if layout.PrimaryProject == "" {
+			layout.PrimaryProject = project.Name
 		}
-		sb.WriteString(`</tbody></table></div></div>`)
-	}
-
-	// Projects & Themes
-	if len(data.Projects) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div class="report-card-title">Projects & Themes</div>`)
 
-		projectNum := 0
-		for _, project := range data.Projects {
-			if project.Hours < 0.5 && project.CommitCount == 0 {
+		var days []string
+		for d := range project.DailyAccomplishments {
+			// Filter out days outside the report's date range
+			if d < data.StartDate || d > data.EndDate {
 				continue
 			}
+			days = append(days, d)
+		}
+		sort.Strings(days)
 
-			// Check if project has meaningful content (accomplishments or commits)
-			hasAccomplishments := false
-			for _, accs := range project.DailyAccomplishments {
-				if len(accs) > 0 {
-					hasAccomplishments = true
-					break
-				}
-			}
-			hasCommits := project.CommitCount > 0
-			hasSignificantTime := project.Hours >= 0.5
-
-			// Skip empty project shells - must have accomplishments, commits, OR significant time
-			// This ensures research/browser work still appears even without git activity
-			if !hasAccomplishments && !hasCommits && !hasSignificantTime {
+		shown := SummaryProject{
+			Number:      len(layout.ShownProjects) + 1,
+			Name:        project.Name,
+			Hours:       project.Hours,
+			Percentage:  project.Percentage,
+			CommitCount: project.CommitCount,
+		}
+		for _, day := range days {
+			accs := project.DailyAccomplishments[day]
+			if len(accs) == 0 {
 				continue
 			}
-
-			projectNum++
-
-			// Project card
-			sb.WriteString(fmt.Sprintf(`
-				<div class="report-project-card">
-					<div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 8px;">
-						<span class="report-project-title">%d. %s</span>
-						<span class="report-project-stats">~%.0fh (%.0f%%)</span>
-					</div>`, projectNum, esc(project.Name), project.Hours, project.Percentage))
-
-			// Commit count
-			if project.CommitCount > 0 {
-				sb.WriteString(fmt.Sprintf(`
-					<div style="font-size: 0.8rem; color: #f97316; margin-bottom: 8px;">
-						%d commits
-					</div>`, project.CommitCount))
-			}
-
-			// Daily accomplishments
-			if len(project.DailyAccomplishments) > 0 {
-				sb.WriteString(`<div class="report-project-stats">`)
-				var days []string
-				for d := range project.DailyAccomplishments {
-					// Filter out days outside the report's date range
-					if d < data.StartDate || d > data.EndDate {
-						continue
-					}
-					days = append(days, d)
-				}
-				sort.Strings(days)
-
-				for _, day := range days {
-					accs := project.DailyAccomplishments[day]
-					if len(accs) == 0 {
-						continue
-					}
-					dayTime, _ := time.Parse("2006-01-02", day)
-					sb.WriteString(fmt.Sprintf(`<div style="margin-bottom: 6px;"><strong class="report-project-title" style="font-size: 0.8rem;">%s:</strong></div>`, dayTime.Format("Mon Jan 2")))
-					cleanedAccs := consolidateAccomplishments(accs)
-					for _, acc := range cleanedAccs {
-						if len(acc) > 100 {
-							acc = acc[:97] + "..."
-						}
-						sb.WriteString(fmt.Sprintf(`<div class="report-accomplishment">• %s</div>`, esc(acc)))
-					}
+			dayTime, _ := time.Parse("2006-01-02", day)
+			projectDay := SummaryProjectDay{Label: dayTime.Format("Mon Jan 2")}
+			for _, acc := range consolidateAccomplishments(accs) {
+				if len(acc) > 100 {
+					acc = acc[:97] + "..."
 				}
-				sb.WriteString(`</div>`)
+				projectDay.Accomplishments = append(projectDay.Accomplishments, acc)
 			}
-
-			sb.WriteString(`</div>`) // End project card
+			shown.Days = append(shown.Days, projectDay)
 		}
-		sb.WriteString(`</div>`)
-	}
-
-	// Meetings & Communication
-	hasCommunication := len(data.Meetings) > 0 || len(data.SlackChannels) > 0 || data.TotalZoomMins > 0
-	if hasCommunication {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Meetings & Communication</div>`)
-
-		// Slack
-		if data.TotalSlackMins > 0 || len(data.SlackChannels) > 0 {
-			sb.WriteString(fmt.Sprintf(`
-				<div style="margin-bottom: 12px; padding: 10px; background: rgba(138, 43, 226, 0.1); border-radius: 6px; border-left: 3px solid #8b5cf6;">
-					<div style="font-size: 0.85rem; font-weight: 500; color: #8b5cf6; margin-bottom: 6px;">💬 Slack: ~%d minutes</div>`, data.TotalSlackMins))
-			for _, ch := range data.SlackChannels {
-				huddle := ""
-				if ch.IsHuddle {
-					huddle = " (huddle)"
-				}
-				sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.8rem; color: #94a3b8; padding-left: 8px;">• %s%s: %dm</div>`, esc(ch.Name), huddle, ch.DurationMins))
-			}
-			sb.WriteString(`</div>`)
-		}
-
-		// Zoom/Meetings
-		if data.TotalZoomMins > 0 || len(data.Meetings) > 0 {
-			sb.WriteString(fmt.Sprintf(`
-				<div style="margin-bottom: 12px; padding: 10px; background: rgba(59, 130, 246, 0.1); border-radius: 6px; border-left: 3px solid #3b82f6;">
-					<div style="font-size: 0.85rem; font-weight: 500; color: #3b82f6; margin-bottom: 6px;">📹 Video Calls: ~%d minutes</div>`, data.TotalZoomMins))
-			for _, m := range data.Meetings {
-				mins := int64(m.DurationSeconds / 60)
-				sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.8rem; color: #94a3b8; padding-left: 8px;">• %s (%s): %dm</div>`, esc(m.Title), esc(m.Platform), mins))
-			}
-			sb.WriteString(`</div>`)
-		}
-
-		sb.WriteString(`</div>`)
-	}
-
-	// Top Applications section removed - was filler that duplicated info available in Analytics
-
-	// Key Accomplishments
-	if len(data.KeyAccomplishments) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Key Accomplishments</div>`)
-		for _, acc := range data.KeyAccomplishments {
-			sb.WriteString(fmt.Sprintf(`<div style="display: flex; gap: 8px; margin-bottom: 8px;">
-				<div style="color: #22c55e; font-size: 0.9rem;">✓</div>
-				<div style="font-size: 0.85rem; color: #cbd5e1;">%s</div>
-			</div>`, esc(acc)))
-		}
-		sb.WriteString(`</div>`)
+		layout.ShownProjects = append(layout.ShownProjects, shown)
 	}
 
 	// Git Commits by Repo
-	if len(data.CommitsByRepo) > 0 {
-		sb.WriteString(fmt.Sprintf(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Commits (%d total)</div>`, data.GitCommitCount))
-
-		for _, repo := range data.CommitsByRepo {
-			sb.WriteString(fmt.Sprintf(`
-				<div style="margin-bottom: 12px;">
-					<div style="font-size: 0.8rem; font-weight: 500; color: #3b82f6; margin-bottom: 6px;">%s (%d commits)</div>`, esc(repo.RepoName), repo.CommitCount))
-
-			shown := 0
-			seen := make(map[string]bool)
-			for _, commit := range repo.Commits {
-				if shown >= 5 {
-					remaining := repo.CommitCount - 5
-					if remaining > 0 {
-						sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.75rem; color: #64748b; padding-left: 8px;">... and %d more</div>`, remaining))
-					}
-					break
-				}
-				if isBoringCommit(commit.Message) || seen[commit.Message] {
-					continue
-				}
-				seen[commit.Message] = true
-				msg := commit.Message
-				if len(msg) > 60 {
-					msg = msg[:57] + "..."
+	for _, repo := range data.CommitsByRepo {
+		shown := SummaryRepo{Name: repo.RepoName, CommitCount: repo.CommitCount}
+		seen := make(map[string]bool)
+		for _, commit := range repo.Commits {
+			if len(shown.Messages) >= 5 {
+				if remaining := repo.CommitCount - 5; remaining > 0 {
+					shown.More = remaining
 				}
-				sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.8rem; color: #94a3b8; padding-left: 8px; margin-bottom: 4px;">• %s</div>`, esc(msg)))
-				shown++
-			}
-			sb.WriteString(`</div>`)
-		}
-		sb.WriteString(`</div>`)
-	}
-
-	// Research & Learning (from Claude/AI) - simplified, no time tracking
-	if len(data.ResearchTopics) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Research & Learning</div>`)
-		for _, topic := range data.ResearchTopics {
-			sb.WriteString(fmt.Sprintf(`
-				<div style="margin-bottom: 6px; padding: 6px 0; border-bottom: 1px solid rgba(148, 163, 184, 0.1);">
-					<span style="font-size: 0.8rem; color: #e2e8f0;">%s</span>
-				</div>`, esc(topic.Topic)))
-		}
-		sb.WriteString(`</div>`)
-	}
-
-	// Browser Activity
-	if len(data.BrowserDomains) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Browser Activity</div>`)
-		for i, domain := range data.BrowserDomains {
-			if i >= 10 {
 				break
 			}
-			mins := int64(domain.DurationMins)
-			if mins < 1 {
+			if isBoringCommit(commit.Message) || seen[commit.Message] {
 				continue
 			}
-			sb.WriteString(fmt.Sprintf(`
-				<div style="display: flex; justify-content: space-between; align-items: center; margin-bottom: 6px; padding: 6px 0; border-bottom: 1px solid rgba(148, 163, 184, 0.1);">
-					<span style="font-size: 0.8rem; color: #e2e8f0;">%s</span>
-					<span style="font-size: 0.75rem; color: #94a3b8;">%dm</span>
-				</div>`, esc(domain.Domain), mins))
+			seen[commit.Message] = true
+			msg := commit.Message
+			if len(msg) > 60 {
+				msg = msg[:57] + "..."
+			}
+			shown.Messages = append(shown.Messages, msg)
 		}
-		sb.WriteString(`</div>`)
+		layout.CommitRepos = append(layout.CommitRepos, shown)
 	}
 
-	// Downloads
-	if len(data.Downloads) > 0 {
-		sb.WriteString(`<div style="margin-bottom: 24px;">
-			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Files Downloaded</div>`)
-		for _, dl := range data.Downloads {
-			sb.WriteString(fmt.Sprintf(`<div style="font-size: 0.8rem; color: #94a3b8; margin-bottom: 4px;">• %s</div>`, esc(dl.FileName)))
-		}
-		sb.WriteString(`</div>`)
+	// Browser Activity
+	layout.TopDomains = data.BrowserDomains
+	if len(layout.TopDomains) > 10 {
+		layout.TopDomains = layout.TopDomains[:10]
 	}
 
-	sb.WriteString(`</div>`) // End main container
-
-	return sb.String()
+	return layout
 }
 
 // formatWeeklySummaryMarkdown formats the weekly summary data as Markdown
@@ -3797,6 +3913,11 @@ func (s *ReportsService) formatWeeklySummaryMarkdown(data *WeeklySummaryData) st
 		sb.WriteString("---\n\n")
 	}
 
+	// Project Timelines
+	if len(data.ProjectTimelines) > 0 {
+		sb.WriteString(formatProjectTimelinesMarkdown(data.ProjectTimelines))
+	}
+
 	// Meetings & Communication
 	hasCommunication := len(data.Meetings) > 0 || len(data.SlackChannels) > 0 || data.TotalZoomMins > 0
 	if hasCommunication {
@@ -3850,6 +3971,60 @@ func (s *ReportsService) formatWeeklySummaryMarkdown(data *WeeklySummaryData) st
 		sb.WriteString("\n---\n\n")
 	}
 
+	// Commit message quality
+	if data.CommitQuality != nil && data.CommitQuality.CommitCount > 0 {
+		sb.WriteString("## Commit Quality\n\n")
+		sb.WriteString(fmt.Sprintf("Average score **%.0f/100** across %d commits.\n\n", data.CommitQuality.AverageScore, data.CommitQuality.CommitCount))
+		for _, issue := range data.CommitQuality.TopIssues {
+			sb.WriteString(fmt.Sprintf("- %s\n", issue))
+		}
+		sb.WriteString("\n---\n\n")
+	}
+
+	// Insights
+	if len(data.Insights) > 0 {
+		sb.WriteString("## Insights\n\n")
+		for _, insight := range data.Insights {
+			sb.WriteString(fmt.Sprintf("- **%s** — %s\n", insight.Title, insight.Body))
+		}
+		sb.WriteString("\n---\n\n")
+	}
+
+	// Burnout risk
+	if data.BurnoutRisk != nil && len(data.BurnoutRisk.Factors) > 0 {
+		sb.WriteString("## Burnout Risk\n\n")
+		sb.WriteString(fmt.Sprintf("Risk is **%s** (%.0f/100).\n\n", data.BurnoutRisk.Level, data.BurnoutRisk.Score))
+		for _, factor := range data.BurnoutRisk.Factors {
+			sb.WriteString(fmt.Sprintf("- %s\n", factor))
+		}
+		sb.WriteString(fmt.Sprintf("\n%s\n\n---\n\n", data.BurnoutRisk.Recommendation))
+	}
+
+	// Languages
+	if len(data.Languages) > 0 {
+		sb.WriteString("## Languages\n\n")
+		for _, lang := range data.Languages {
+			sb.WriteString(fmt.Sprintf("- **%s** — %.0f%%%s\n", lang.Language, lang.Percentage, formatWorkspaceNames(lang.Workspaces)))
+		}
+		sb.WriteString("\n---\n\n")
+	}
+
+	// Peak productivity windows
+	if data.PeakWindows != nil && len(data.PeakWindows.PeakWindows) > 0 {
+		sb.WriteString("## Peak Hours\n\n")
+		for _, w := range data.PeakWindows.PeakWindows {
+			sb.WriteString(fmt.Sprintf("%d. %02d:%02d–%02d:%02d — %.0f productive min/day\n",
+				w.Rank, w.StartHour, w.StartMinute, w.EndHour, w.EndMinute, w.AverageProductiveMinutes))
+		}
+		sb.WriteString("\n---\n\n")
+	}
+
+	// Most distracted hours
+	if len(data.DistractedHours) > 0 {
+		sb.WriteString(formatDistractedHoursMarkdown(data.DistractedHours))
+		sb.WriteString("\n---\n\n")
+	}
+
 	// Research & Learning - simplified to just topics without time tracking noise
 	if len(data.ResearchTopics) > 0 {
 		sb.WriteString("## Research & Learning\n\n")
@@ -3860,6 +4035,22 @@ func (s *ReportsService) formatWeeklySummaryMarkdown(data *WeeklySummaryData) st
 		sb.WriteString("\n---\n\n")
 	}
 
+	// Browser Activity - blocklisted domains are flagged
+	if len(data.BrowserDomains) > 0 {
+		sb.WriteString("## Browser Activity\n\n")
+		for i, domain := range data.BrowserDomains {
+			if i >= 10 {
+				break
+			}
+			marker := ""
+			if domain.IsBlocklisted {
+				marker = "⚠️ "
+			}
+			sb.WriteString(fmt.Sprintf("- %s%s (%s)\n", marker, domain.Domain, formatVisitCount(domain.VisitCount)))
+		}
+		sb.WriteString("\n---\n\n")
+	}
+
 	// Files Downloaded - only include if there are actual downloads
 	if len(data.Downloads) > 0 {
 		sb.WriteString("## Files Downloaded\n\n")
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultCommitActivityWeeks is the heatmap length when no number of weeks is given.
+	defaultCommitActivityWeeks = 52
+	// reportCommitActivityWeeks is the heatmap length shown in the summary report.
+	reportCommitActivityWeeks = 12
+)
+
+// CommitHeatmap contains per-day commit activity for a GitHub-style contribution graph.
+type CommitHeatmap struct {
+	Weeks []CommitWeek `json:"weeks"` // Oldest first; each week starts on Sunday
+}
+
+// CommitWeek is one column of the commit heatmap.
+type CommitWeek struct {
+	Days []CommitDay `json:"days"` // Sunday to Saturday; the current week stops at today
+}
+
+// CommitDay represents commit activity for a single day.
+type CommitDay struct {
+	Date        string `json:"date"` // YYYY-MM-DD
+	CommitCount int    `json:"commitCount"`
+	Insertions  int64  `json:"insertions"`
+	Deletions   int64  `json:"deletions"`
+	Intensity   int    `json:"intensity"` // 0-4, scaled to the day with the most commits
+}
+
+// GetCommitActivity returns per-day commits for the last number of weeks, up to today.
+// If repoID is 0, commits from all repositories are included.
+func (s *ReportsService) GetCommitActivity(repoID int64, weeks int) (*CommitHeatmap, error) {
+	return s.commitActivity(repoID, time.Now(), weeks)
+}
+
+// commitActivity builds the commit heatmap for the weeks up to and including end's day.
+// Commit stats are loaded with a single grouped query.
+func (s *ReportsService) commitActivity(repoID int64, end time.Time, weeks int) (*CommitHeatmap, error) {
+	if weeks <= 0 {
+		weeks = defaultCommitActivityWeeks
+	}
+
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, time.Local)
+	start := end.AddDate(0, 0, -int(end.Weekday())-7*(weeks-1))
+	stats, err := s.store.GetCommitStatsByDay(repoID, start.Unix(), end.AddDate(0, 0, 1).Unix()-1, s.authorEmails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load commit activity: %w", err)
+	}
+
+	heatmap := &CommitHeatmap{}
+	maxCommits := 0
+	for weekStart := start; !weekStart.After(end); weekStart = weekStart.AddDate(0, 0, 7) {
+		week := CommitWeek{}
+		for day := weekStart; day.Before(weekStart.AddDate(0, 0, 7)) && !day.After(end); day = day.AddDate(0, 0, 1) {
+			cd := CommitDay{Date: day.Format("2006-01-02")}
+			if st, ok := stats[cd.Date]; ok {
+				cd.CommitCount = st.CommitCount
+				cd.Insertions = st.Insertions
+				cd.Deletions = st.Deletions
+			}
+			if cd.CommitCount > maxCommits {
+				maxCommits = cd.CommitCount
+			}
+			week.Days = append(week.Days, cd)
+		}
+		heatmap.Weeks = append(heatmap.Weeks, week)
+	}
+
+	for _, week := range heatmap.Weeks {
+		for i := range week.Days {
+			day := &week.Days[i]
+			if maxCommits > 0 {
+				day.Intensity = int(float64(day.CommitCount) / float64(maxCommits) * 4)
+			}
+			// Any commit should be visible, even if it rounds down to zero
+			if day.Intensity == 0 && day.CommitCount > 0 {
+				day.Intensity = 1
+			}
+		}
+	}
+
+	return heatmap, nil
+}
+
+// totalCommits returns the number of commits in the heatmap.
+func (h *CommitHeatmap) totalCommits() int {
+	total := 0
+	for _, week := range h.Weeks {
+		for _, day := range week.Days {
+			total += day.CommitCount
+		}
+	}
+	return total
+}
+
+// commitIntensityColors are the heatmap cell colors for intensities 0-4.
+var commitIntensityColors = [5]string{
+	"rgba(148, 163, 184, 0.15)",
+	"#9be9a8",
+	"#40c463",
+	"#30a14e",
+	"#216e39",
+}
+
+// formatCommitHeatmapHTML renders a commit heatmap as a grid with one column per week.
+func formatCommitHeatmapHTML(heatmap *CommitHeatmap) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<div style="margin-bottom: 24px;">
+			<div style="font-size: 0.85rem; font-weight: 600; color: #f1f5f9; margin-bottom: 12px;">Commit Activity (last %d weeks)</div>
+			<div style="display: grid; grid-auto-flow: column; grid-template-rows: repeat(7, 10px); grid-auto-columns: 10px; gap: 3px; overflow-x: auto;">`, len(heatmap.Weeks)))
+
+	for _, week := range heatmap.Weeks {
+		for _, day := range week.Days {
+			sb.WriteString(fmt.Sprintf(`<div title="%s: %d commits, +%d -%d" style="border-radius: 2px; background: %s;"></div>`,
+				day.Date, day.CommitCount, day.Insertions, day.Deletions, commitIntensityColors[day.Intensity]))
+		}
+	}
+
+	sb.WriteString(`</div></div>`)
+	return sb.String()
+}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+const (
+	// diffHighlightPercent is the change between ranges that a diff report highlights.
+	diffHighlightPercent = 20
+	// diffTopApps is the number of top apps listed for each range.
+	diffTopApps = 5
+)
+
+// Diff report change classes.
+const (
+	diffImprovement = "improvement"
+	diffRegression  = "regression"
+)
+
+// diffChangeColors are the report colors for each change class.
+var diffChangeColors = map[string]string{
+	diffImprovement: "#22c55e",
+	diffRegression:  "#ef4444",
+}
+
+// diffMetrics holds the metrics compared for one range of a diff report.
+type diffMetrics struct {
+	Label             string
+	ActiveMinutes     int64
+	ProductivityScore int
+	TopApps           []string
+	CommitCount       int
+	MeetingMinutes    int64
+	BrowserMinutes    int64
+	apps              map[string]bool // Friendly names of every app used
+}
+
+// GenerateDiffReport compares two time ranges side by side and saves the result
+// as a "diff" report. Both ranges accept anything ParseTimeRange does.
+func (s *ReportsService) GenerateDiffReport(range1, range2 string) (*Report, error) {
+	tr1, err := s.ParseTimeRange(range1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse first time range: %w", err)
+	}
+	tr2, err := s.ParseTimeRange(range2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse second time range: %w", err)
+	}
+
+	before, err := s.loadDiffMetrics(tr1)
+	if err != nil {
+		return nil, err
+	}
+	after, err := s.loadDiffMetrics(tr2)
+	if err != nil {
+		return nil, err
+	}
+
+	storageReport := &storage.Report{
+		Title:      fmt.Sprintf("Diff Report: %s vs %s", tr1.Label, tr2.Label),
+		TimeRange:  range1 + " vs " + range2,
+		ReportType: "diff",
+		Format:     "html",
+		Content:    storage.NullString(formatDiffReportHTML(before, after)),
+		StartTime:  storage.NullInt64(min(tr1.Start, tr2.Start)),
+		EndTime:    storage.NullInt64(max(tr1.End, tr2.End)),
+	}
+	id, err := s.store.SaveReport(storageReport)
+	if err != nil {
+		return nil, err
+	}
+	storageReport.ID = id
+	storageReport.Version = 1
+
+	return toServiceReport(storageReport), nil
+}
+
+// loadDiffMetrics computes the compared metrics for a time range.
+func (s *ReportsService) loadDiffMetrics(tr *TimeRange) (*diffMetrics, error) {
+	ctx, err := s.buildEnhancedReportContext(tr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &diffMetrics{
+		Label:             tr.Label,
+		ActiveMinutes:     ctx.TotalMinutes,
+		ProductivityScore: ctx.ProductivityScore,
+		CommitCount:       len(ctx.GitCommits),
+		apps:              make(map[string]bool),
+	}
+	for _, app := range ctx.AppUsage { // Sorted by duration
+		if len(m.TopApps) < diffTopApps {
+			m.TopApps = append(m.TopApps, app.FriendlyName)
+		}
+		m.apps[app.FriendlyName] = true
+		if isBrowser(app.AppName) {
+			m.BrowserMinutes += int64(app.DurationSeconds / 60)
+		}
+	}
+	var meetingSeconds float64
+	for _, meeting := range ctx.Meetings {
+		meetingSeconds += meeting.DurationSeconds
+	}
+	m.MeetingMinutes = int64(meetingSeconds / 60)
+	return m, nil
+}
+
+// diffChange classifies the change from before to after. Changes of more than 20%
+// are an improvement or a regression depending on whether higher is better; smaller
+// changes return "".
+func diffChange(before, after float64, higherIsBetter bool) string {
+	if before == after {
+		return ""
+	}
+	if before != 0 && math.Abs(after-before)/math.Abs(before)*100 <= diffHighlightPercent {
+		return ""
+	}
+	if (after > before) == higherIsBetter {
+		return diffImprovement
+	}
+	return diffRegression
+}
+
+// formatDiffChange formats the change from before to after, e.g. "+25%".
+func formatDiffChange(before, after float64) string {
+	switch {
+	case before == after:
+		return "-"
+	case before == 0:
+		return "new"
+	}
+	return fmt.Sprintf("%+.0f%%", (after-before)/math.Abs(before)*100)
+}
+
+// formatDiffReportHTML renders the side-by-side comparison of two ranges.
+func formatDiffReportHTML(before, after *diffMetrics) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf(`<div style="margin-bottom: 24px;">
+		<div class="report-card-title">%s vs %s</div>
+		<div style="overflow-x: auto;">
+		<table class="report-table">
+			<thead>
+				<tr>
+					<th style="text-align: left;">Metric</th>
+					<th style="text-align: right;">%s</th>
+					<th style="text-align: right;">%s</th>
+					<th style="text-align: right;">Change</th>
+				</tr>
+			</thead>
+			<tbody>`, esc(before.Label), esc(after.Label), esc(before.Label), esc(after.Label)))
+
+	rows := []struct {
+		name           string
+		before, after  float64
+		format         func(float64) string
+		higherIsBetter bool
+	}{
+		{"Active Time", float64(before.ActiveMinutes), float64(after.ActiveMinutes), formatDiffMinutes, true},
+		{"Productivity Score", float64(before.ProductivityScore), float64(after.ProductivityScore), formatDiffCount, true},
+		{"Commits", float64(before.CommitCount), float64(after.CommitCount), formatDiffCount, true},
+		{"Meeting Time", float64(before.MeetingMinutes), float64(after.MeetingMinutes), formatDiffMinutes, false},
+		{"Browser Time", float64(before.BrowserMinutes), float64(after.BrowserMinutes), formatDiffMinutes, false},
+	}
+	for _, row := range rows {
+		style := "text-align: right;"
+		if color, ok := diffChangeColors[diffChange(row.before, row.after, row.higherIsBetter)]; ok {
+			style += fmt.Sprintf(" color: %s; font-weight: 600;", color)
+		}
+		sb.WriteString(fmt.Sprintf(`
+				<tr>
+					<td style="text-align: left;">%s</td>
+					<td style="text-align: right;">%s</td>
+					<td style="text-align: right;">%s</td>
+					<td style="%s">%s</td>
+				</tr>`, row.name, row.format(row.before), row.format(row.after), style, formatDiffChange(row.before, row.after)))
+	}
+
+	sb.WriteString(fmt.Sprintf(`
+				<tr>
+					<td style="text-align: left; vertical-align: top;">Top Apps</td>
+					<td style="text-align: right;">%s</td>
+					<td style="text-align: right;">%s</td>
+					<td></td>
+				</tr>`, formatDiffApps(before.TopApps, after.apps), formatDiffApps(after.TopApps, before.apps)))
+
+	sb.WriteString(`</tbody></table></div>
+		<div class="report-stat-meta" style="margin-top: 8px;">Changes of more than 20% are highlighted. Apps in bold weren't used in the other range.</div>
+	</div>`)
+	return sb.String()
+}
+
+// formatDiffApps lists top apps one per line, in bold if they weren't used in the other range.
+func formatDiffApps(apps []string, otherApps map[string]bool) string {
+	if len(apps) == 0 {
+		return "-"
+	}
+	lines := make([]string, len(apps))
+	for i, app := range apps {
+		lines[i] = esc(app)
+		if !otherApps[app] {
+			lines[i] = fmt.Sprintf(`<strong style="color: #3b82f6;">%s</strong>`, lines[i])
+		}
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// formatDiffMinutes and formatDiffCount format diff report values.
+func formatDiffMinutes(minutes float64) string {
+	return formatMinutes(int64(minutes))
+}
+
+func formatDiffCount(count float64) string {
+	return fmt.Sprintf("%.0f", count)
+}
@@ -0,0 +1,224 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Report goal metrics. Report goal keys combine a period and a metric, e.g. "daily_commits".
+const (
+	reportGoalCommits         = "commits"
+	reportGoalDeepWorkMinutes = "deep_work_minutes"
+)
+
+// reportGoalKeys are the supported report goal keys, in the order reports list them.
+var reportGoalKeys = []string{
+	"daily_" + GoalTypeActiveMinutes,
+	"daily_" + reportGoalCommits,
+	"daily_" + reportGoalDeepWorkMinutes,
+	"weekly_" + GoalTypeActiveMinutes,
+	"weekly_" + reportGoalCommits,
+	"weekly_" + reportGoalDeepWorkMinutes,
+}
+
+// reportGoalLabels are the display names of report goal metrics.
+var reportGoalLabels = map[string]string{
+	GoalTypeActiveMinutes:     "Active Time",
+	reportGoalCommits:         "Commits",
+	reportGoalDeepWorkMinutes: "Deep Work",
+}
+
+// SetReportGoals replaces the goals shown in the "Goals Progress" section of reports.
+// Keys are "daily_" or "weekly_" followed by "active_minutes", "commits" or
+// "deep_work_minutes"; targets must be positive. An empty map clears all goals.
+func (s *ReportsService) SetReportGoals(goals map[string]int64) error {
+	for key, target := range goals {
+		if _, _, ok := parseReportGoalKey(key); !ok {
+			return fmt.Errorf("unsupported report goal: %s", key)
+		}
+		if target <= 0 {
+			return fmt.Errorf("goal target must be positive, got %d for %s", target, key)
+		}
+	}
+	return s.store.SetReportGoals(goals)
+}
+
+// GetReportGoals returns the report goal targets by key.
+func (s *ReportsService) GetReportGoals() (map[string]int64, error) {
+	return s.store.GetReportGoals()
+}
+
+// GetGoalProgressForRange measures the report goals between start and end. Targets are
+// scaled to the length of the range, so a daily goal over a week is seven times its
+// target and a weekly goal over a day is a seventh of it.
+func (s *ReportsService) GetGoalProgressForRange(start, end int64) ([]*GoalProgress, error) {
+	goals, err := s.store.GetReportGoals()
+	if err != nil {
+		return nil, err
+	}
+	if len(goals) == 0 {
+		return nil, nil
+	}
+
+	days := math.Max(1, math.Ceil(float64(end-start)/86400))
+	actuals := make(map[string]int64)
+	var progress []*GoalProgress
+	for _, key := range reportGoalKeys {
+		goalTarget, ok := goals[key]
+		if !ok {
+			continue
+		}
+		period, metric, _ := parseReportGoalKey(key)
+
+		actual, measured := actuals[metric]
+		if !measured {
+			actual, err = s.reportGoalActualValue(metric, start, end)
+			if err != nil {
+				return nil, err
+			}
+			actuals[metric] = actual
+		}
+
+		periodDays := 1.0
+		if period == "weekly" {
+			periodDays = 7
+		}
+		target := max(1, int64(math.Round(float64(goalTarget)*days/periodDays)))
+
+		progress = append(progress, &GoalProgress{
+			GoalType:   metric,
+			Period:     period,
+			Date:       time.Unix(start, 0).Format("2006-01-02"),
+			Target:     target,
+			Actual:     actual,
+			Percentage: float64(actual) / float64(target) * 100,
+			Achieved:   actual >= target,
+		})
+	}
+	return progress, nil
+}
+
+// parseReportGoalKey splits a report goal key into its period and metric.
+func parseReportGoalKey(key string) (period, metric string, ok bool) {
+	period, metric, found := strings.Cut(key, "_")
+	if !found || (period != "daily" && period != "weekly") {
+		return "", "", false
+	}
+	if _, ok := reportGoalLabels[metric]; !ok {
+		return "", "", false
+	}
+	return period, metric, true
+}
+
+// reportGoalActualValue measures a report goal metric between start and end.
+func (s *ReportsService) reportGoalActualValue(metric string, start, end int64) (int64, error) {
+	switch metric {
+	case GoalTypeActiveMinutes:
+		sessions, err := s.store.GetSessionsByTimeRange(start, end)
+		if err != nil {
+			return 0, err
+		}
+		return sessionActiveSeconds(sessions, start, end) / 60, nil
+	case reportGoalCommits:
+		commits, err := s.userGitCommits(start, end)
+		if err != nil {
+			return 0, err
+		}
+		return int64(len(commits)), nil
+	case reportGoalDeepWorkMinutes:
+		events, err := s.store.GetFocusEventsByTimeRange(start, end)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch focus events: %w", err)
+		}
+		appNames := make(map[string]bool)
+		for _, evt := range events {
+			appNames[evt.AppName] = true
+		}
+		appNamesList := make([]string, 0, len(appNames))
+		for name := range appNames {
+			appNamesList = append(appNamesList, name)
+		}
+		categories, err := s.store.GetAppTimelineCategories(appNamesList)
+		if err != nil {
+			return 0, fmt.Errorf("failed to fetch app categories: %w", err)
+		}
+		var minutes int64
+		for _, block := range detectDeepWorkBlocks(events, categories, defaultDeepWorkMinutes, start, end) {
+			minutes += block.DurationMinutes
+		}
+		return minutes, nil
+	default:
+		return 0, fmt.Errorf("unsupported goal type: %s", metric)
+	}
+}
+
+// withGoals adds a "Goals Progress" section to the end of a report. Content is returned
+// unchanged if no report goals are set or progress can't be measured.
+func (s *ReportsService) withGoals(tr *TimeRange, content, format string) string {
+	progress, err := s.GetGoalProgressForRange(tr.Start, tr.End)
+	if err != nil || len(progress) == 0 {
+		return content
+	}
+	if format == "markdown" {
+		return strings.TrimRight(content, "\n") + "\n\n" + formatGoalsMarkdown(progress)
+	}
+	return content + formatGoalsHTML(progress)
+}
+
+// formatGoalValue formats a goal's actual or target value for its metric.
+func formatGoalValue(metric string, value int64) string {
+	if metric == reportGoalCommits {
+		return fmt.Sprintf("%d", value)
+	}
+	return formatMinutes(value)
+}
+
+// goalProgressLabel returns the display name of a goal, e.g. "Daily Commits".
+func goalProgressLabel(p *GoalProgress) string {
+	return strings.ToUpper(p.Period[:1]) + p.Period[1:] + " " + reportGoalLabels[p.GoalType]
+}
+
+// formatGoalsMarkdown renders goal progress as a Markdown list with text progress bars.
+func formatGoalsMarkdown(progress []*GoalProgress) string {
+	var sb strings.Builder
+	sb.WriteString("## 📊 Goals Progress\n\n")
+	for _, p := range progress {
+		filled := int(math.Min(p.Percentage, 100) / 10)
+		sb.WriteString(fmt.Sprintf("- **%s**: %s / %s `%s%s` %.0f%%", goalProgressLabel(p),
+			formatGoalValue(p.GoalType, p.Actual), formatGoalValue(p.GoalType, p.Target),
+			strings.Repeat("█", filled), strings.Repeat("░", 10-filled), p.Percentage))
+		if p.Achieved {
+			sb.WriteString(" ✅")
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// formatGoalsHTML renders goal progress as an HTML section with percentage bars.
+func formatGoalsHTML(progress []*GoalProgress) string {
+	var sb strings.Builder
+	sb.WriteString(`<div style="margin-top: 24px;">`)
+	sb.WriteString(`<div class="report-card-title">📊 Goals Progress</div>`)
+	for _, p := range progress {
+		color := "#3b82f6"
+		if p.Achieved {
+			color = "#22c55e"
+		}
+		sb.WriteString(fmt.Sprintf(`
+		<div style="margin-bottom: 12px;">
+			<div style="display: flex; justify-content: space-between; font-size: 0.875rem; margin-bottom: 4px;">
+				<span>%s</span>
+				<span class="report-stat-meta">%s / %s (%.0f%%)</span>
+			</div>
+			<div style="height: 8px; background: rgba(148, 163, 184, 0.2); border-radius: 4px; overflow: hidden;">
+				<div style="width: %.0f%%; height: 100%%; background: %s;"></div>
+			</div>
+		</div>`, goalProgressLabel(p), formatGoalValue(p.GoalType, p.Actual), formatGoalValue(p.GoalType, p.Target),
+			p.Percentage, math.Min(p.Percentage, 100), color))
+	}
+	sb.WriteString(`</div>`)
+	return sb.String()
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// highlightTypeLabels are the display labels for pinned event types.
+var highlightTypeLabels = map[string]string{
+	"focus":      "Activity",
+	"screenshot": "Screenshot",
+	"git":        "Git",
+	"shell":      "Shell",
+	"browser":    "Browser",
+	"file":       "File",
+}
+
+// withHighlights adds a "Highlights" section listing pinned events to the top of a report.
+// Markdown reports get the section after their title heading. Content is returned unchanged
+// if nothing in the time range is pinned.
+func (s *ReportsService) withHighlights(tr *TimeRange, content, format string) string {
+	pins, err := s.store.GetPinnedEvents(tr.Start, tr.End)
+	if err != nil || len(pins) == 0 {
+		return content
+	}
+
+	if format != "markdown" {
+		return formatHighlightsHTML(pins) + content
+	}
+
+	highlights := formatHighlightsMarkdown(pins)
+	if strings.HasPrefix(content, "# ") {
+		if idx := strings.Index(content, "\n\n"); idx >= 0 {
+			return content[:idx+2] + highlights + content[idx+2:]
+		}
+	}
+	return highlights + content
+}
+
+// formatHighlightsMarkdown renders pinned events as a Markdown list.
+func formatHighlightsMarkdown(pins []*storage.PinnedEvent) string {
+	var sb strings.Builder
+	sb.WriteString("## Highlights\n\n")
+	for _, pin := range pins {
+		sb.WriteString(fmt.Sprintf("- **%s** [%s] %s", highlightTime(pin.Timestamp), highlightTypeLabels[pin.EventType], pin.Title))
+		if pin.Note != "" {
+			sb.WriteString(fmt.Sprintf(" — %s", pin.Note))
+		}
+		sb.WriteString("\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// formatHighlightsHTML renders pinned events as an HTML section matching the report styles.
+func formatHighlightsHTML(pins []*storage.PinnedEvent) string {
+	var sb strings.Builder
+	sb.WriteString(`<div style="margin-bottom: 24px; padding: 16px; background: rgba(234, 179, 8, 0.08); border-left: 3px solid #eab308; border-radius: 8px;">`)
+	sb.WriteString(`<h2 style="font-size: 1.1rem; font-weight: 600; margin: 0 0 12px 0; color: #f1f5f9;">📌 Highlights</h2>`)
+	sb.WriteString(`<ul style="margin: 0; padding-left: 20px; color: #e2e8f0;">`)
+	for _, pin := range pins {
+		sb.WriteString(fmt.Sprintf(`<li style="margin-bottom: 6px;"><span style="color: #94a3b8;">%s</span> <span style="font-weight: 600; color: #eab308;">[%s]</span> %s`,
+			esc(highlightTime(pin.Timestamp)), esc(highlightTypeLabels[pin.EventType]), esc(pin.Title)))
+		if pin.Note != "" {
+			sb.WriteString(fmt.Sprintf(` <span style="color: #94a3b8; font-style: italic;">— %s</span>`, esc(pin.Note)))
+		}
+		sb.WriteString(`</li>`)
+	}
+	sb.WriteString(`</ul></div>`)
+	return sb.String()
+}
+
+// highlightTime formats an event time like "Mon Jan 2, 3:04pm".
+func highlightTime(ts int64) string {
+	return time.Unix(ts, 0).Format("Mon Jan 2, 3:04pm")
+}
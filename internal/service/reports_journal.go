@@ -0,0 +1,203 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+const (
+	// journalMajorSwitchSeconds is the minimum focus duration for an app switch to be mentioned.
+	journalMajorSwitchSeconds = 10 * 60
+	// journalMaxSwitchesPerSession caps how many app switches are narrated per session.
+	journalMaxSwitchesPerSession = 3
+)
+
+// generateJournalReport generates a first-person Markdown narrative of the time range.
+func (s *ReportsService) generateJournalReport(tr *TimeRange) (string, error) {
+	sessions, err := s.store.GetSessionsByTimeRange(tr.Start, tr.End)
+	if err != nil {
+		return "", fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	sessionIDs := make([]int64, len(sessions))
+	for i, sess := range sessions {
+		sessionIDs[i] = sess.ID
+	}
+	summariesMap, _ := s.store.GetSummariesForSessions(sessionIDs)
+	focusBySession, _ := s.store.GetWindowFocusEventsBySessions(sessionIDs)
+
+	commits, err := s.userGitCommits(tr.Start, tr.End)
+	if err != nil {
+		return "", fmt.Errorf("failed to get commits: %w", err)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Journal: %s\n\n", tr.Label))
+	sb.WriteString(formatJournalMarkdown(sessions, summariesMap, focusBySession, commits))
+	return sb.String(), nil
+}
+
+// formatJournalMarkdown renders sessions as chronological first-person paragraphs.
+// Each paragraph starts with the session start time and includes the AI summary (if any),
+// the top app and major app switches (from focusBySession), and commits made during the session.
+// Commits that don't fall within any session are listed at the end.
+func formatJournalMarkdown(sessions []*storage.Session, summariesMap map[int64]*storage.Summary, focusBySession map[int64][]*storage.WindowFocusEvent, commits []*storage.GitCommit) string {
+	var sb strings.Builder
+
+	if len(sessions) == 0 && len(commits) == 0 {
+		sb.WriteString("_Nothing was recorded for this period._\n")
+		return sb.String()
+	}
+
+	sorted := make([]*storage.Session, len(sessions))
+	copy(sorted, sessions)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].StartTime < sorted[j].StartTime })
+
+	// Assign commits to sessions by session ID, falling back to the session's time span
+	commitsBySession := make(map[int64][]*storage.GitCommit)
+	var unassigned []*storage.GitCommit
+	for _, commit := range commits {
+		if sess := journalSessionForCommit(sorted, commit); sess != nil {
+			commitsBySession[sess.ID] = append(commitsBySession[sess.ID], commit)
+		} else {
+			unassigned = append(unassigned, commit)
+		}
+	}
+
+	currentDay := ""
+	for _, sess := range sorted {
+		start := time.Unix(sess.StartTime, 0)
+		if day := start.Format("2006-01-02"); day != currentDay {
+			currentDay = day
+			sb.WriteString(fmt.Sprintf("## %s\n\n", start.Format("Monday, January 2")))
+		}
+
+		var sentences []string
+
+		// Opening sentence: start time and duration
+		if sess.EndTime.Valid {
+			minutes := (sess.EndTime.Int64 - sess.StartTime) / 60
+			sentences = append(sentences, fmt.Sprintf("At %s I started a %s session.", journalTime(start), formatMinutes(minutes)))
+		} else {
+			sentences = append(sentences, fmt.Sprintf("At %s I started a session that is still in progress.", journalTime(start)))
+		}
+
+		if summary := summariesMap[sess.ID]; summary != nil && strings.TrimSpace(summary.Summary) != "" {
+			sentences = append(sentences, journalSentence(summary.Summary))
+		}
+
+		events := focusBySession[sess.ID]
+		if topApp := journalTopApp(events); topApp != "" {
+			sentences = append(sentences, fmt.Sprintf("I spent most of it in %s.", topApp))
+		}
+		sentences = append(sentences, journalAppSwitches(events)...)
+
+		if sessionCommits := commitsBySession[sess.ID]; len(sessionCommits) > 0 {
+			sentences = append(sentences, journalCommitSentence(sessionCommits))
+		}
+
+		sb.WriteString(strings.Join(sentences, " "))
+		sb.WriteString("\n\n")
+	}
+
+	if len(unassigned) > 0 {
+		sb.WriteString("## Outside tracked sessions\n\n")
+		sb.WriteString(journalCommitSentence(unassigned))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// journalSessionForCommit finds the session a commit belongs to, or nil if none.
+func journalSessionForCommit(sessions []*storage.Session, commit *storage.GitCommit) *storage.Session {
+	if commit.SessionID.Valid {
+		for _, sess := range sessions {
+			if sess.ID == commit.SessionID.Int64 {
+				return sess
+			}
+		}
+	}
+	for _, sess := range sessions {
+		end := time.Now().Unix()
+		if sess.EndTime.Valid {
+			end = sess.EndTime.Int64
+		}
+		if commit.Timestamp >= sess.StartTime && commit.Timestamp <= end {
+			return sess
+		}
+	}
+	return nil
+}
+
+// journalTopApp returns the friendly name of the app with the most focus time.
+func journalTopApp(events []*storage.WindowFocusEvent) string {
+	durations := make(map[string]float64)
+	for _, evt := range events {
+		durations[GetFriendlyAppName(evt.AppName)] += evt.DurationSeconds
+	}
+
+	var topApp string
+	var topDuration float64
+	for app, duration := range durations {
+		if duration > topDuration || (duration == topDuration && app < topApp) {
+			topApp, topDuration = app, duration
+		}
+	}
+	return topApp
+}
+
+// journalAppSwitches describes long stretches in a different app than the previous one.
+func journalAppSwitches(events []*storage.WindowFocusEvent) []string {
+	var sentences []string
+	lastApp := ""
+	for _, evt := range events {
+		if evt.DurationSeconds < journalMajorSwitchSeconds {
+			continue
+		}
+		app := GetFriendlyAppName(evt.AppName)
+		if lastApp != "" && app != lastApp {
+			sentences = append(sentences, fmt.Sprintf("Around %s I switched to %s.", journalTime(time.Unix(evt.StartTime, 0)), app))
+			if len(sentences) >= journalMaxSwitchesPerSession {
+				break
+			}
+		}
+		lastApp = app
+	}
+	return sentences
+}
+
+// journalCommitSentence lists commits with their times.
+func journalCommitSentence(commits []*storage.GitCommit) string {
+	parts := make([]string, 0, len(commits))
+	for _, commit := range commits {
+		subject := commit.MessageSubject
+		if subject == "" {
+			subject = strings.SplitN(commit.Message, "\n", 2)[0]
+		}
+		parts = append(parts, fmt.Sprintf("%q (`%s`) at %s", subject, commit.ShortHash, journalTime(time.Unix(commit.Timestamp, 0))))
+	}
+
+	if len(parts) == 1 {
+		return fmt.Sprintf("I committed %s.", parts[0])
+	}
+	return fmt.Sprintf("I committed %s and %s.", strings.Join(parts[:len(parts)-1], ", "), parts[len(parts)-1])
+}
+
+// journalTime formats a time like "9:15am".
+func journalTime(t time.Time) string {
+	return t.Format("3:04pm")
+}
+
+// journalSentence trims text and makes sure it ends with punctuation.
+func journalSentence(text string) string {
+	text = strings.TrimSpace(text)
+	if strings.HasSuffix(text, ".") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?") {
+		return text
+	}
+	return text + "."
+}
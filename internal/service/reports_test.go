@@ -1,6 +1,9 @@
 package service
 
 import (
+	"context"
+	"database/sql"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -9,7 +12,7 @@ import (
 	"traq/internal/storage"
 )
 
-func setupReportsTest(t *testing.T) (*ReportsService, *storage.Store, func()) {
+func setupReportsTest(t testing.TB) (*ReportsService, *storage.Store, func()) {
 	t.Helper()
 
 	dir, err := os.MkdirTemp("", "traq-test-*")
@@ -138,6 +141,74 @@ func TestParseTimeRangeDateRange(t *testing.T) {
 	}
 }
 
+func TestParseTimeRangeNamedRanges(t *testing.T) {
+	// Wednesday, February 11, 2026
+	wednesday := time.Date(2026, time.February, 11, 15, 30, 0, 0, time.Local)
+	// Monday, February 9, 2026
+	monday := time.Date(2026, time.February, 9, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		input     string
+		now       time.Time
+		wantStart string
+		wantEnd   string
+		wantLabel string
+	}{
+		{"weekday earlier this week", "monday", wednesday, "2026-02-09", "2026-02-09", "Monday, February 9, 2026"},
+		{"weekday is today", "wednesday", wednesday, "2026-02-11", "2026-02-11", "Wednesday, February 11, 2026"},
+		{"weekday later in week wraps back", "friday", wednesday, "2026-02-06", "2026-02-06", "Friday, February 6, 2026"},
+		{"monday on a monday", "monday", monday, "2026-02-09", "2026-02-09", "Monday, February 9, 2026"},
+		{"sunday on a monday", "sunday", monday, "2026-02-08", "2026-02-08", "Sunday, February 8, 2026"},
+		{"last weekday", "last monday", wednesday, "2026-02-02", "2026-02-02", "Monday, February 2, 2026"},
+		{"last monday on a monday", "last monday", monday, "2026-02-02", "2026-02-02", "Monday, February 2, 2026"},
+		{"last weekday is today", "last wednesday", wednesday, "2026-02-04", "2026-02-04", "Wednesday, February 4, 2026"},
+		{"mixed case with spaces", "  Last  Tuesday ", wednesday, "2026-02-03", "2026-02-03", "Tuesday, February 3, 2026"},
+		{"this quarter", "this quarter", wednesday, "2026-01-01", "2026-03-31", "This Quarter"},
+		{"last quarter crosses year", "last quarter", wednesday, "2025-10-01", "2025-12-31", "Last Quarter"},
+		{"last quarter mid-year", "last quarter", time.Date(2026, time.August, 1, 0, 0, 0, 0, time.Local), "2026-04-01", "2026-06-30", "Last Quarter"},
+		{"quarter defaults to current year", "q2", wednesday, "2026-04-01", "2026-06-30", "Q2 2026"},
+		{"quarter with year", "q1 2025", wednesday, "2025-01-01", "2025-03-31", "Q1 2025"},
+		{"fourth quarter", "Q4 2025", wednesday, "2025-10-01", "2025-12-31", "Q4 2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := parseTimeRangeAt(tt.input, tt.now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.StartDate != tt.wantStart || result.EndDate != tt.wantEnd {
+				t.Errorf("range = %s..%s, want %s..%s", result.StartDate, result.EndDate, tt.wantStart, tt.wantEnd)
+			}
+			if result.Label != tt.wantLabel {
+				t.Errorf("label = %q, want %q", result.Label, tt.wantLabel)
+			}
+		})
+	}
+
+	for _, input := range []string{"q5", "q0 2025", "next monday", "last quarters"} {
+		if _, err := parseTimeRangeAt(input, wednesday); err == nil {
+			t.Errorf("expected error for %q", input)
+		}
+	}
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skipf("timezone data unavailable: %v", err)
+	}
+	result, err := parseTimeRangeAt("2026-02-11 Asia/Tokyo", wednesday)
+	if err != nil {
+		t.Fatalf("unexpected error with timezone: %v", err)
+	}
+	if want := time.Date(2026, time.February, 11, 0, 0, 0, 0, tokyo).Unix(); result.Start != want {
+		t.Errorf("start = %d, want midnight in Tokyo (%d)", result.Start, want)
+	}
+	if result.Label != "February 11, 2026 (Asia/Tokyo)" {
+		t.Errorf("label = %q, want timezone suffix", result.Label)
+	}
+}
+
 func TestGenerateDetailedReportHTML(t *testing.T) {
 	service, store, cleanup := setupReportsTest(t)
 	defer cleanup()
@@ -197,3 +268,822 @@ func TestGenerateDetailedReportHTML(t *testing.T) {
 	t.Logf("Report content length: %d", len(report.Content))
 	t.Logf("Report preview: %s", report.Content[:min(500, len(report.Content))])
 }
+
+func TestWeeklySummaryDataIsCached(t *testing.T) {
+	service, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	start := time.Now().Add(-2 * time.Hour).Unix()
+	end := time.Now().Unix()
+
+	_, _ = store.SaveShellCommand(&storage.ShellCommand{
+		Command:   "go build ./...",
+		ShellType: "bash",
+		Timestamp: start + 60,
+	})
+
+	first, err := service.buildWeeklySummaryData(context.Background(), start, end, "", "")
+	if err != nil {
+		t.Fatalf("failed to build weekly summary data: %v", err)
+	}
+
+	// Data written after the first build must not be visible until the cache is cleared,
+	// proving the second build didn't query the store again.
+	_, _ = store.SaveShellCommand(&storage.ShellCommand{
+		Command:   "go test ./...",
+		ShellType: "bash",
+		Timestamp: start + 120,
+	})
+
+	second, err := service.buildWeeklySummaryData(context.Background(), start, end, "", "")
+	if err != nil {
+		t.Fatalf("failed to build weekly summary data: %v", err)
+	}
+	if second != first {
+		t.Error("expected second build to return the cached data")
+	}
+	if second.ShellCmdCount != 1 {
+		t.Errorf("expected cached command count 1, got %d", second.ShellCmdCount)
+	}
+
+	hits, misses := service.GetReportCacheStats()
+	if hits != 1 || misses != 1 {
+		t.Errorf("expected 1 hit and 1 miss, got %d hits and %d misses", hits, misses)
+	}
+
+	service.ClearReportCache()
+
+	third, err := service.buildWeeklySummaryData(context.Background(), start, end, "", "")
+	if err != nil {
+		t.Fatalf("failed to build weekly summary data: %v", err)
+	}
+	if third.ShellCmdCount != 2 {
+		t.Errorf("expected command count 2 after clearing cache, got %d", third.ShellCmdCount)
+	}
+}
+
+func TestEnhancedReportContextIsCached(t *testing.T) {
+	service, _, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	tr := &TimeRange{
+		Start: time.Now().Add(-24 * time.Hour).Unix(),
+		End:   time.Now().Unix(),
+	}
+
+	first, err := service.buildEnhancedReportContext(tr)
+	if err != nil {
+		t.Fatalf("failed to build report context: %v", err)
+	}
+	second, err := service.buildEnhancedReportContext(tr)
+	if err != nil {
+		t.Fatalf("failed to build report context: %v", err)
+	}
+	if first != second {
+		t.Error("expected second build to return the cached context")
+	}
+
+	// A different range must not share the cache entry
+	other, _ := service.buildEnhancedReportContext(&TimeRange{Start: tr.Start - 1, End: tr.End})
+	if other == first {
+		t.Error("expected a different range to build a new context")
+	}
+
+	hits, misses := service.GetReportCacheStats()
+	if hits != 1 || misses != 2 {
+		t.Errorf("expected 1 hit and 2 misses, got %d hits and %d misses", hits, misses)
+	}
+
+	// New activity only drops the ranges containing it
+	service.InvalidateReportCacheAt(tr.End + 60)
+	if again, _ := service.buildEnhancedReportContext(tr); again != first {
+		t.Error("expected activity after the range to keep its cached context")
+	}
+	service.InvalidateReportCacheAt(tr.End - 60)
+	rebuilt, _ := service.buildEnhancedReportContext(tr)
+	if rebuilt == first {
+		t.Error("expected activity inside the range to drop its cached context")
+	}
+
+	// Ranges resolved to other dates don't share the cache entry
+	dated := *tr
+	dated.StartDate, dated.EndDate = "2026-01-01", "2026-01-02"
+	if ctx, _ := service.buildEnhancedReportContext(&dated); ctx == rebuilt {
+		t.Error("expected different dates to build a new context")
+	}
+}
+
+func TestFormatJournalMarkdown(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	day := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+	morning := day.Add(9*time.Hour + 15*time.Minute)
+	afternoon := day.Add(14 * time.Hour)
+
+	morningID, _ := store.CreateSession(morning.Unix())
+	afternoonID, _ := store.CreateSession(afternoon.Unix())
+	sessions := []*storage.Session{
+		{ID: afternoonID, StartTime: afternoon.Unix(), EndTime: sql.NullInt64{Int64: afternoon.Add(30 * time.Minute).Unix(), Valid: true}},
+		{ID: morningID, StartTime: morning.Unix(), EndTime: sql.NullInt64{Int64: morning.Add(90 * time.Minute).Unix(), Valid: true}},
+	}
+	summaries := map[int64]*storage.Summary{
+		morningID: {Summary: "Refactored the report cache"},
+	}
+
+	// Mostly in the editor, with a long stretch in the browser
+	focus := []struct {
+		app          string
+		offset, secs int64
+	}{
+		{"code", 0, 3600},
+		{"firefox", 3600, 1200},
+		{"slack", 4800, 60}, // Too short to count as a switch
+	}
+	focusBySession := make(map[int64][]*storage.WindowFocusEvent)
+	for _, f := range focus {
+		focusBySession[morningID] = append(focusBySession[morningID], &storage.WindowFocusEvent{
+			AppName:         f.app,
+			WindowTitle:     f.app,
+			StartTime:       morning.Unix() + f.offset,
+			EndTime:         morning.Unix() + f.offset + f.secs,
+			DurationSeconds: float64(f.secs),
+			SessionID:       sql.NullInt64{Int64: morningID, Valid: true},
+		})
+	}
+	commits := []*storage.GitCommit{
+		{ShortHash: "abc1234", MessageSubject: "Add report cache", Timestamp: morning.Add(45 * time.Minute).Unix()},
+		{ShortHash: "def5678", MessageSubject: "Late fix", Timestamp: day.Add(22 * time.Hour).Unix()},
+	}
+
+	md := formatJournalMarkdown(sessions, summaries, focusBySession, commits)
+	if md == "" {
+		t.Fatal("expected non-empty journal")
+	}
+
+	for _, want := range []string{
+		"## Tuesday, March 10",
+		"At 9:15am I started a 1h 30m session.",
+		"Refactored the report cache.",
+		"I spent most of it in " + GetFriendlyAppName("code") + ".",
+		"Around 10:15am I switched to " + GetFriendlyAppName("firefox") + ".",
+		`I committed "Add report cache" (` + "`abc1234`" + `) at 10:00am.`,
+		"At 2:00pm I started a 30m session.",
+		"## Outside tracked sessions",
+		"10:00pm",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("expected journal to contain %q, got:\n%s", want, md)
+		}
+	}
+
+	// Sessions are narrated chronologically regardless of input order
+	if strings.Index(md, "9:15am") > strings.Index(md, "2:00pm") {
+		t.Error("expected morning session before afternoon session")
+	}
+
+	if empty := formatJournalMarkdown(nil, nil, nil, nil); !strings.Contains(empty, "Nothing was recorded") {
+		t.Errorf("expected placeholder for empty journal, got %q", empty)
+	}
+}
+
+func TestWithHighlights(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	tr := &TimeRange{Start: now - 3600, End: now + 3600}
+
+	// No pins leaves content unchanged
+	if got := svc.withHighlights(tr, "# Journal\n\nBody\n", "markdown"); got != "# Journal\n\nBody\n" {
+		t.Errorf("expected unchanged content, got %q", got)
+	}
+
+	shellID, err := store.SaveShellCommand(&storage.ShellCommand{Timestamp: now, Command: "make release", ShellType: "bash"})
+	if err != nil {
+		t.Fatalf("SaveShellCommand failed: %v", err)
+	}
+	if err := store.PinEvent("shell", shellID, "Shipped <v1>"); err != nil {
+		t.Fatalf("PinEvent failed: %v", err)
+	}
+
+	md := svc.withHighlights(tr, "# Journal\n\nBody\n", "markdown")
+	if !strings.HasPrefix(md, "# Journal\n\n## Highlights\n\n") {
+		t.Errorf("expected highlights after the title, got %q", md)
+	}
+	if !strings.Contains(md, "[Shell] make release — Shipped <v1>") {
+		t.Errorf("expected pinned command in highlights, got %q", md)
+	}
+
+	html := svc.withHighlights(tr, "<div>Body</div>", "html")
+	if !strings.Contains(html, "Highlights") || !strings.HasSuffix(html, "<div>Body</div>") {
+		t.Errorf("expected highlights before the report body, got %q", html)
+	}
+	if !strings.Contains(html, "Shipped &lt;v1&gt;") {
+		t.Errorf("expected escaped note, got %q", html)
+	}
+}
+
+func TestBlocklistedDomainsInReports(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	visits := []struct{ domain, url string }{
+		{"old.reddit.com", "https://old.reddit.com/r/golang"},
+		{"old.reddit.com", "https://old.reddit.com/r/rust"},
+		{"github.com", "https://github.com/hmahadik/traq"},
+	}
+	for i, v := range visits {
+		_, err := store.SaveBrowserVisit(&storage.BrowserVisit{
+			Timestamp: now - int64(i*60), URL: v.url, Domain: v.domain, Browser: "chrome",
+		})
+		if err != nil {
+			t.Fatalf("failed to save browser visit: %v", err)
+		}
+	}
+
+	groups, _ := svc.aggregateBrowserByDomain(now-7200, now-3601)
+	if len(groups) != 0 {
+		t.Fatalf("expected no groups without visits, got %d", len(groups))
+	}
+	groups, err := svc.aggregateBrowserByDomain(now-3600, now+60)
+	if err != nil {
+		t.Fatalf("aggregateBrowserByDomain failed: %v", err)
+	}
+	for _, g := range groups {
+		if want := g.Domain == "old.reddit.com"; g.IsBlocklisted != want {
+			t.Errorf("domain %s: expected IsBlocklisted=%v", g.Domain, want)
+		}
+	}
+
+	tr := &TimeRange{
+		Start:     now - 3600,
+		End:       now + 60,
+		StartDate: time.Unix(now-3600, 0).Format("2006-01-02"),
+		EndDate:   time.Unix(now, 0).Format("2006-01-02"),
+	}
+	md, err := svc.generateSummaryReportMarkdown(tr)
+	if err != nil {
+		t.Fatalf("generateSummaryReportMarkdown failed: %v", err)
+	}
+	if !strings.Contains(md, "- ⚠️ old.reddit.com (2 visits)") {
+		t.Errorf("expected blocklisted domain marker in markdown, got:\n%s", md)
+	}
+	if !strings.Contains(md, "- github.com (1 visit)") {
+		t.Errorf("expected unflagged github.com in markdown, got:\n%s", md)
+	}
+
+	svc.ClearReportCache()
+	html, err := svc.generateSummaryReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateSummaryReport failed: %v", err)
+	}
+	if !strings.Contains(html, "⚠️</span> old.reddit.com") {
+		t.Error("expected blocklisted domain marker in HTML report")
+	}
+	if strings.Contains(html, "⚠️</span> github.com") {
+		t.Error("expected github.com not to be flagged in HTML report")
+	}
+}
+
+func TestReportVersioning(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	first, err := svc.GenerateReport("yesterday", "standup", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if first.Version != 1 || first.ParentID != 0 {
+		t.Errorf("first report: got version %d parent %d, want version 1 parent 0", first.Version, first.ParentID)
+	}
+
+	svc.ClearReportCache()
+	second, err := svc.GenerateReport("yesterday", "standup", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if second.Version != 2 || second.ParentID != first.ID {
+		t.Errorf("second report: got version %d parent %d, want version 2 parent %d", second.Version, second.ParentID, first.ID)
+	}
+
+	// A different report type is a separate report, not a version
+	other, err := svc.GenerateReport("yesterday", "summary", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if other.Version != 1 || other.ParentID != 0 {
+		t.Errorf("summary report: got version %d parent %d, want version 1 parent 0", other.Version, other.ParentID)
+	}
+
+	// Versions can be listed from either the original or a later version
+	for _, id := range []int64{first.ID, second.ID} {
+		versions, err := svc.GetReportVersions(id)
+		if err != nil {
+			t.Fatalf("GetReportVersions(%d) failed: %v", id, err)
+		}
+		if len(versions) != 2 {
+			t.Fatalf("GetReportVersions(%d): got %d versions, want 2", id, len(versions))
+		}
+	}
+
+	restored, err := svc.RestoreReportVersion(first.ID)
+	if err != nil {
+		t.Fatalf("RestoreReportVersion failed: %v", err)
+	}
+	if restored.Version != 3 || restored.ParentID != first.ID {
+		t.Errorf("restored report: got version %d parent %d, want version 3 parent %d", restored.Version, restored.ParentID, first.ID)
+	}
+	if restored.Content != first.Content {
+		t.Error("restored report should have the first version's content")
+	}
+
+	// Deleting the original removes all of its versions
+	if err := store.DeleteReport(first.ID); err != nil {
+		t.Fatalf("DeleteReport failed: %v", err)
+	}
+	for _, id := range []int64{second.ID, restored.ID} {
+		r, err := store.GetReport(id)
+		if err != nil {
+			t.Fatalf("GetReport failed: %v", err)
+		}
+		if r != nil {
+			t.Errorf("version %d should be deleted with the original report", id)
+		}
+	}
+}
+
+func TestGetDailySummaries(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	day := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.Local)
+	dayStart, dayEnd := day.Unix(), day.AddDate(0, 0, 1).Unix()-1
+
+	sessionID, _ := store.CreateSession(dayStart + 9*3600)
+	store.EndSession(sessionID, dayStart+12*3600)
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		AppName: "code", WindowTitle: "main.go", StartTime: dayStart + 9*3600, EndTime: dayStart + 10*3600, DurationSeconds: 3600,
+	})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		AppName: "code", WindowTitle: "main.go", StartTime: dayStart + 11*3600, EndTime: dayStart + 11*3600 + 1800, DurationSeconds: 1800,
+	})
+
+	// Two versions of the same day's summary
+	for _, content := range []string{"# Summary\n\nFirst draft.", "# Summary\n\nSecond draft."} {
+		_, err := store.SaveReport(&storage.Report{
+			Title:      "Daily Summary",
+			TimeRange:  "2024-03-05",
+			ReportType: "summary",
+			Format:     "markdown",
+			Content:    storage.NullString(content),
+			StartTime:  storage.NullInt64(dayStart),
+			EndTime:    storage.NullInt64(dayEnd),
+		})
+		if err != nil {
+			t.Fatalf("SaveReport failed: %v", err)
+		}
+		time.Sleep(1100 * time.Millisecond) // created_at has one-second resolution
+	}
+
+	summaries, err := svc.GetDailySummaries(30)
+	if err != nil {
+		t.Fatalf("GetDailySummaries failed: %v", err)
+	}
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 summary per day, got %d", len(summaries))
+	}
+	got := summaries[0]
+	if got.Date != "2024-03-05" || got.Summary != "Second draft." {
+		t.Errorf("expected latest summary for 2024-03-05, got %+v", got)
+	}
+	if got.TotalTime != 5400 {
+		t.Errorf("expected 5400s of focus time, got %d", got.TotalTime)
+	}
+	if got.SessionsCount != 1 {
+		t.Errorf("expected 1 session, got %d", got.SessionsCount)
+	}
+}
+
+func TestCommitMessageProjectDetection(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	if _, err := svc.projects.CreateDetectionRule(DetectionSourceCommitMessage, `^feat\((\w+)\)`, 1); err != nil {
+		t.Fatalf("CreateDetectionRule failed: %v", err)
+	}
+	if _, err := svc.projects.CreateDetectionRule(DetectionSourceCommitMessage, `^feat\((\w+)\)`, 2); err == nil {
+		t.Error("expected error for out-of-range capture group")
+	}
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/monorepo", Name: "monorepo", IsActive: true})
+	now := time.Now().Unix()
+	commits := []*storage.GitCommit{
+		{RepositoryID: repoID, Timestamp: now, CommitHash: "a1", Message: "feat(traq): add commit rules"},
+		{RepositoryID: repoID, Timestamp: now, CommitHash: "a2", Message: "fix typo in readme"},
+	}
+
+	counts := make(map[string]int)
+	for _, p := range svc.buildProjectSummaries(nil, commits, nil) {
+		counts[p.Name] = p.CommitCount
+	}
+	if counts["traq"] != 1 || counts["monorepo"] != 1 {
+		t.Errorf("expected one commit each for traq and monorepo, got %v", counts)
+	}
+
+	groups := svc.groupActivitiesByProject(&EnhancedReportContext{GitCommits: commits})
+	counts = make(map[string]int)
+	for _, g := range groups {
+		counts[g.Name] = g.CommitCount
+	}
+	if counts["traq"] != 1 || counts["monorepo"] != 1 {
+		t.Errorf("expected grouped commits for traq and monorepo, got %v", counts)
+	}
+}
+
+func TestReportsLeaveOutOtherAuthorsCommits(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/shared", Name: "shared", IsActive: true})
+	day := time.Date(2025, 3, 10, 14, 0, 0, 0, time.Local).Unix()
+	for i, email := range []string{"me@work.example", "Me@Home.example", "colleague@work.example", ""} {
+		store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: day + int64(i*60), CommitHash: fmt.Sprintf("c%d", i), ShortHash: fmt.Sprintf("c%d", i),
+			RepositoryID: repoID, Message: "work", MessageSubject: "work",
+			AuthorEmail: sql.NullString{String: email, Valid: email != ""},
+		})
+	}
+
+	// One email from the global config, one set by a repository
+	svc.SetAuthorEmails([]string{"me@work.example", "me@home.example"})
+	tr := &TimeRange{Start: day - 3600, End: day + 3600}
+
+	ctx, err := svc.buildEnhancedReportContext(tr)
+	if err != nil {
+		t.Fatalf("buildEnhancedReportContext failed: %v", err)
+	}
+	if len(ctx.GitCommits) != 3 {
+		t.Errorf("expected the user's 2 commits and the one without an email, got %d", len(ctx.GitCommits))
+	}
+	if n, _ := svc.reportGoalActualValue(reportGoalCommits, tr.Start, tr.End); n != 3 {
+		t.Errorf("expected 3 commits toward the goal, got %d", n)
+	}
+	heatmap, err := svc.commitActivity(0, time.Unix(day, 0), 1)
+	if err != nil {
+		t.Fatalf("commitActivity failed: %v", err)
+	}
+	if heatmap.totalCommits() != 3 {
+		t.Errorf("expected 3 commits in the heatmap, got %d", heatmap.totalCommits())
+	}
+}
+
+func TestCommitActivity(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	repoA, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/a", Name: "a", IsActive: true})
+	repoB, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/b", Name: "b", IsActive: true})
+	at := func(day int) int64 { return time.Date(2025, 3, day, 14, 0, 0, 0, time.Local).Unix() }
+	commits := []*storage.GitCommit{
+		{RepositoryID: repoA, Timestamp: at(10), CommitHash: "c1", Insertions: storage.NullInt64(10)},
+		{RepositoryID: repoA, Timestamp: at(10) + 60, CommitHash: "c2", Insertions: storage.NullInt64(5)},
+		{RepositoryID: repoB, Timestamp: at(4), CommitHash: "c3", Deletions: storage.NullInt64(7)},
+	}
+	for _, c := range commits {
+		c.Message, c.MessageSubject = "work", "work"
+		if _, err := store.SaveGitCommit(c); err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+	}
+
+	// Wednesday March 12th; two weeks start on Sunday March 2nd
+	end := time.Date(2025, 3, 12, 9, 0, 0, 0, time.Local)
+	heatmap, err := svc.commitActivity(0, end, 2)
+	if err != nil {
+		t.Fatalf("commitActivity failed: %v", err)
+	}
+	if len(heatmap.Weeks) != 2 || len(heatmap.Weeks[0].Days) != 7 || len(heatmap.Weeks[1].Days) != 4 {
+		t.Fatalf("expected a full week and a week up to Wednesday, got %+v", heatmap.Weeks)
+	}
+	if heatmap.Weeks[0].Days[0].Date != "2025-03-02" {
+		t.Errorf("expected heatmap to start on Sunday 2025-03-02, got %s", heatmap.Weeks[0].Days[0].Date)
+	}
+	busiest := heatmap.Weeks[1].Days[1]
+	if busiest.Date != "2025-03-10" || busiest.CommitCount != 2 || busiest.Insertions != 15 || busiest.Intensity != 4 {
+		t.Errorf("unexpected busiest day: %+v", busiest)
+	}
+	if day := heatmap.Weeks[0].Days[2]; day.CommitCount != 1 || day.Deletions != 7 || day.Intensity != 2 {
+		t.Errorf("unexpected stats for 2025-03-04: %+v", day)
+	}
+	if heatmap.totalCommits() != 3 {
+		t.Errorf("expected 3 commits, got %d", heatmap.totalCommits())
+	}
+
+	// A single repository
+	heatmap, _ = svc.commitActivity(repoB, end, 2)
+	if heatmap.totalCommits() != 1 || heatmap.Weeks[0].Days[2].Intensity != 4 {
+		t.Errorf("expected only repo b's commit, got %+v", heatmap.Weeks)
+	}
+
+	html, err := svc.formatWeeklySummaryHTML(&WeeklySummaryData{
+		StartDate:      "2025-03-10",
+		EndDate:        "2025-03-12",
+		CommitActivity: heatmap,
+	})
+	if err != nil {
+		t.Fatalf("formatWeeklySummaryHTML failed: %v", err)
+	}
+	if !strings.Contains(html, "Commit Activity (last 2 weeks)") || !strings.Contains(html, `title="2025-03-04: 1 commits`) {
+		t.Error("expected the summary report to include the commit heatmap")
+	}
+}
+
+func TestReportTemplates(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	tr := &TimeRange{
+		Start:     now - 3600,
+		End:       now + 60,
+		StartDate: time.Unix(now-3600, 0).Format("2006-01-02"),
+		EndDate:   time.Unix(now, 0).Format("2006-01-02"),
+		Label:     "Today",
+	}
+
+	// The built-in template renders the standard layout unchanged
+	data, err := svc.buildWeeklySummaryData(context.Background(), tr.Start, tr.End, tr.StartDate, tr.EndDate)
+	if err != nil {
+		t.Fatalf("buildWeeklySummaryData failed: %v", err)
+	}
+	standard, err := svc.formatWeeklySummaryHTML(data)
+	if err != nil {
+		t.Fatalf("formatWeeklySummaryHTML failed: %v", err)
+	}
+	if !strings.Contains(standard, `<h1 class="report-title">Activity Summary: `) {
+		t.Errorf("expected the standard summary layout, got:\n%s", standard)
+	}
+	html, err := svc.generateSummaryReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateSummaryReport failed: %v", err)
+	}
+	if html != standard {
+		t.Error("expected built-in template to render the standard summary HTML")
+	}
+
+	if _, err := svc.SaveReportTemplate("Broken", "summary", "{{.TotalMinutes", ""); err == nil {
+		t.Error("expected error for invalid template")
+	}
+	if _, err := svc.SaveReportTemplate("Daily", "daily", "<p></p>", ""); err == nil {
+		t.Error("expected error for report type without templates")
+	}
+
+	id, err := svc.SaveReportTemplate("Wrapped", "summary",
+		`<h1>{{.Label}}</h1><p>{{formatMinutes .TotalMinutes}}</p>{{.DefaultHTML}}`,
+		"# {{.Label}}\n\n{{.DefaultMarkdown}}")
+	if err != nil {
+		t.Fatalf("SaveReportTemplate failed: %v", err)
+	}
+	if err := svc.SetDefaultTemplate(id); err != nil {
+		t.Fatalf("SetDefaultTemplate failed: %v", err)
+	}
+
+	html, err = svc.generateSummaryReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateSummaryReport failed: %v", err)
+	}
+	if !strings.HasPrefix(html, "<h1>Today</h1><p>") || !strings.HasSuffix(html, standard) {
+		t.Errorf("expected custom template to wrap the standard layout, got:\n%s", html)
+	}
+
+	md, err := svc.generateSummaryReportMarkdown(tr)
+	if err != nil {
+		t.Fatalf("generateSummaryReportMarkdown failed: %v", err)
+	}
+	if !strings.HasPrefix(md, "# Today\n\n") {
+		t.Errorf("expected custom markdown template, got:\n%s", md)
+	}
+
+	// Without a default the standard layout is used
+	if err := store.DeleteReportTemplate(id); err != nil {
+		t.Fatalf("DeleteReportTemplate failed: %v", err)
+	}
+	html, err = svc.generateSummaryReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateSummaryReport failed: %v", err)
+	}
+	if html != standard {
+		t.Error("expected standard summary HTML without a default template")
+	}
+
+	// The built-in standup template renders the standard standup layout
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		AppName: "code", WindowTitle: "main.go", StartTime: now - 1800, EndTime: now - 600, DurationSeconds: 1200,
+	})
+	standup, err := svc.generateStandupReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateStandupReport failed: %v", err)
+	}
+	if !strings.Contains(standup, "Standup Report: Today") || !strings.Contains(standup, "width: 100%; background: #64748b;") {
+		t.Errorf("expected the standard standup layout, got:\n%s", standup)
+	}
+
+	standupID, err := svc.SaveReportTemplate("Standup", "standup", `<h2>Standup {{.Label}}</h2>`, "")
+	if err != nil {
+		t.Fatalf("SaveReportTemplate failed: %v", err)
+	}
+	if err := svc.SetDefaultTemplate(standupID); err != nil {
+		t.Fatalf("SetDefaultTemplate failed: %v", err)
+	}
+	standup, err = svc.generateStandupReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateStandupReport failed: %v", err)
+	}
+	if standup != "<h2>Standup Today</h2>" {
+		t.Errorf("expected custom standup template, got:\n%s", standup)
+	}
+	detailed, err := svc.generateDetailedReport(tr, false)
+	if err != nil {
+		t.Fatalf("generateDetailedReport failed: %v", err)
+	}
+	if strings.Contains(detailed, "<h2>Standup") {
+		t.Error("expected detailed report not to use the standup template")
+	}
+}
+
+func TestGenerateDiffReport(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	day1 := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local).Unix()
+	day2 := time.Date(2024, time.March, 6, 9, 0, 0, 0, time.Local).Unix()
+	focus := []struct {
+		start      int64
+		app, title string
+		minutes    int64
+	}{
+		{day1, "code", "main.go", 120},
+		{day1 + 2*3600, "firefox", "Docs", 60},
+		{day1 + 3*3600, "zoom", "Zoom Meeting", 30},
+		{day2, "code", "main.go", 120},
+		{day2 + 2*3600, "firefox", "Docs", 15},
+		{day2 + 3*3600, "spotify", "Music", 20},
+	}
+	for _, f := range focus {
+		store.SaveFocusEvent(&storage.WindowFocusEvent{
+			AppName: f.app, WindowTitle: f.title, StartTime: f.start, EndTime: f.start + f.minutes*60, DurationSeconds: float64(f.minutes * 60),
+		})
+	}
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/web", Name: "web", IsActive: true})
+	for i, ts := range []int64{day1, day2, day2 + 60, day2 + 120} {
+		store.SaveGitCommit(&storage.GitCommit{
+			CommitHash: fmt.Sprintf("hash%d", i), ShortHash: fmt.Sprintf("h%d", i), RepositoryID: repoID, Message: "Commit", Timestamp: ts,
+		})
+	}
+
+	tr1, _ := svc.ParseTimeRange("2024-03-05")
+	tr2, _ := svc.ParseTimeRange("2024-03-06")
+	before, err := svc.loadDiffMetrics(tr1)
+	if err != nil {
+		t.Fatalf("loadDiffMetrics failed: %v", err)
+	}
+	after, _ := svc.loadDiffMetrics(tr2)
+	if before.ActiveMinutes != 210 || before.CommitCount != 1 || before.MeetingMinutes != 30 || before.BrowserMinutes != 60 {
+		t.Errorf("unexpected metrics for the first range: %+v", before)
+	}
+	if after.ActiveMinutes != 155 || after.CommitCount != 3 || after.MeetingMinutes != 0 || after.BrowserMinutes != 15 {
+		t.Errorf("unexpected metrics for the second range: %+v", after)
+	}
+
+	tests := []struct {
+		before, after  float64
+		higherIsBetter bool
+		want           string
+	}{
+		{210, 155, true, diffRegression}, // -26%
+		{1, 3, true, diffImprovement},    // +200%
+		{30, 0, false, diffImprovement},  // Fewer meetings
+		{60, 15, false, diffImprovement}, // Less browsing
+		{100, 120, true, ""},             // Exactly 20% isn't highlighted
+		{100, 85, true, ""},
+		{0, 10, false, diffRegression}, // Anything from nothing
+		{0, 0, true, ""},
+	}
+	for _, tt := range tests {
+		if got := diffChange(tt.before, tt.after, tt.higherIsBetter); got != tt.want {
+			t.Errorf("diffChange(%v, %v, %v) = %q, want %q", tt.before, tt.after, tt.higherIsBetter, got, tt.want)
+		}
+	}
+
+	report, err := svc.GenerateDiffReport("2024-03-05", "2024-03-06")
+	if err != nil {
+		t.Fatalf("GenerateDiffReport failed: %v", err)
+	}
+	if report.ReportType != "diff" || report.ID == 0 {
+		t.Errorf("expected a saved diff report, got %+v", report)
+	}
+	for _, want := range []string{"-26%", "+200%", "color: #ef4444", "color: #22c55e"} {
+		if !strings.Contains(report.Content, want) {
+			t.Errorf("expected report to contain %q", want)
+		}
+	}
+	// Zoom was only used in the first range and Spotify only in the second
+	if got := strings.Count(report.Content, "<strong"); got != 2 {
+		t.Errorf("expected 2 apps highlighted as used in only one range, got %d", got)
+	}
+}
+
+func TestReportGoals(t *testing.T) {
+	svc, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	if err := svc.SetReportGoals(map[string]int64{"daily_screenshots": 10}); err == nil {
+		t.Error("expected error for an unsupported goal")
+	}
+	if err := svc.SetReportGoals(map[string]int64{"daily_commits": 0}); err == nil {
+		t.Error("expected error for a non-positive target")
+	}
+	goals := map[string]int64{"daily_active_minutes": 100, "weekly_commits": 14}
+	if err := svc.SetReportGoals(goals); err != nil {
+		t.Fatalf("SetReportGoals failed: %v", err)
+	}
+	saved, err := svc.GetReportGoals()
+	if err != nil {
+		t.Fatalf("GetReportGoals failed: %v", err)
+	}
+	if len(saved) != 2 || saved["daily_active_minutes"] != 100 || saved["weekly_commits"] != 14 {
+		t.Errorf("expected goals to be saved, got %v", saved)
+	}
+
+	day := time.Date(2024, time.March, 5, 0, 0, 0, 0, time.Local)
+	sessionID, _ := store.CreateSession(day.Add(9 * time.Hour).Unix())
+	store.EndSession(sessionID, day.Add(11*time.Hour).Unix())
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/web", Name: "web", IsActive: true})
+	for i := 0; i < 3; i++ {
+		store.SaveGitCommit(&storage.GitCommit{
+			CommitHash: fmt.Sprintf("hash%d", i), ShortHash: fmt.Sprintf("h%d", i), RepositoryID: repoID, Message: "Commit",
+			Timestamp: day.Add(10*time.Hour + time.Duration(i)*time.Minute).Unix(),
+		})
+	}
+
+	progress, err := svc.GetGoalProgressForRange(day.Unix(), day.AddDate(0, 0, 1).Unix()-1)
+	if err != nil {
+		t.Fatalf("GetGoalProgressForRange failed: %v", err)
+	}
+	if len(progress) != 2 {
+		t.Fatalf("expected progress for 2 goals, got %d", len(progress))
+	}
+	active, commits := progress[0], progress[1]
+	if active.GoalType != GoalTypeActiveMinutes || active.Target != 100 || active.Actual != 120 || !active.Achieved || active.Percentage != 120 {
+		t.Errorf("unexpected active time progress: %+v", active)
+	}
+	// A weekly goal of 14 commits is 2 a day
+	if commits.Period != "weekly" || commits.Target != 2 || commits.Actual != 3 || !commits.Achieved {
+		t.Errorf("unexpected commit progress: %+v", commits)
+	}
+
+	weekProgress, _ := svc.GetGoalProgressForRange(day.Unix(), day.AddDate(0, 0, 7).Unix()-1)
+	if len(weekProgress) != 2 || weekProgress[0].Target != 700 || weekProgress[1].Target != 14 || weekProgress[1].Achieved {
+		t.Errorf("expected targets scaled to a week, got %+v, %+v", weekProgress[0], weekProgress[1])
+	}
+
+	report, err := svc.GenerateReport("2024-03-05", "summary", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if !strings.Contains(report.Content, "📊 Goals Progress") || !strings.Contains(report.Content, "Weekly Commits") {
+		t.Error("expected report to include the goals progress section")
+	}
+
+	if err := svc.SetReportGoals(map[string]int64{}); err != nil {
+		t.Fatalf("SetReportGoals failed: %v", err)
+	}
+	if progress, _ := svc.GetGoalProgressForRange(day.Unix(), day.AddDate(0, 0, 1).Unix()-1); len(progress) != 0 {
+		t.Errorf("expected no progress after clearing goals, got %d", len(progress))
+	}
+}
+
+func TestAppendMergedFocusEvent(t *testing.T) {
+	session := sql.NullInt64{Int64: 1, Valid: true}
+	var events []*storage.WindowFocusEvent
+	for _, evt := range []*storage.WindowFocusEvent{
+		{AppName: "code", WindowTitle: "main.go", StartTime: 0, EndTime: 60, DurationSeconds: 60, SessionID: session},
+		{AppName: "code", WindowTitle: "main.go", StartTime: 60, EndTime: 90, DurationSeconds: 30, SessionID: session},
+		{AppName: "code", WindowTitle: "util.go", StartTime: 90, EndTime: 120, DurationSeconds: 30, SessionID: session},
+		{AppName: "code", WindowTitle: "util.go", StartTime: 300, EndTime: 330, DurationSeconds: 30, SessionID: session},
+	} {
+		events = appendMergedFocusEvent(events, evt)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events after merging, got %d", len(events))
+	}
+	if events[0].EndTime != 90 || events[0].DurationSeconds != 90 {
+		t.Errorf("expected contiguous main.go events merged, got %+v", events[0])
+	}
+	if events[2].StartTime != 300 {
+		t.Errorf("expected events separated by a gap to stay apart, got %+v", events[2])
+	}
+}
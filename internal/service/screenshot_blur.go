@@ -0,0 +1,245 @@
+package service
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/chai2010/webp"
+	"github.com/disintegration/imaging"
+
+	"traq/internal/storage"
+)
+
+const (
+	// blurSigma is the standard deviation of the Gaussian blur in pixels, enough to make
+	// screen text unreadable.
+	blurSigma = 8
+	// blurredScreenshotQuality is the WebP quality blurred screenshots are re-encoded with,
+	// the same as the default capture quality.
+	blurredScreenshotQuality = 80
+)
+
+// BlurRegion is a rectangle of a screenshot to blur, in image pixels.
+type BlurRegion struct {
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// BlurScreenshot blurs regions of a screenshot and overwrites its image and thumbnail.
+// Regions are clipped to the image. The original pixels can't be recovered.
+func (s *ScreenshotService) BlurScreenshot(screenshotID int64, regions []BlurRegion) error {
+	if len(regions) == 0 {
+		return fmt.Errorf("no regions to blur")
+	}
+	screenshot, err := s.store.GetScreenshot(screenshotID)
+	if err != nil {
+		return err
+	}
+	if screenshot == nil {
+		return fmt.Errorf("screenshot not found: %d", screenshotID)
+	}
+	_, err = s.blurScreenshot(screenshot, regions)
+	return err
+}
+
+// BlurAllScreenshotsForApp blurs the whole of every screenshot between start and end
+// taken while appName (matched case-insensitively) was in the foreground. Screenshots
+// that are already fully blurred are skipped. Returns the number of screenshots blurred.
+func (s *ScreenshotService) BlurAllScreenshotsForApp(appName string, start, end int64) (int, error) {
+	screenshots, err := s.store.GetScreenshotsByTimeRange(start, end)
+	if err != nil {
+		return 0, err
+	}
+
+	blurred := 0
+	for _, screenshot := range screenshots {
+		if !screenshot.AppName.Valid || !strings.EqualFold(screenshot.AppName.String, appName) {
+			continue
+		}
+		ok, err := s.blurScreenshot(screenshot, nil)
+		if err != nil {
+			return blurred, fmt.Errorf("failed to blur screenshot %d: %w", screenshot.ID, err)
+		}
+		if ok {
+			blurred++
+		}
+	}
+	return blurred, nil
+}
+
+// blurScreenshot blurs regions of a screenshot, or the whole image if regions is nil,
+// and records them. Returns false if the whole image was requested and is already blurred.
+func (s *ScreenshotService) blurScreenshot(screenshot *storage.Screenshot, regions []BlurRegion) (bool, error) {
+	src, err := readScreenshotImage(screenshot.Filepath)
+	if err != nil {
+		return false, err
+	}
+	img := toRGBA(src)
+	bounds := img.Bounds()
+
+	var rects []image.Rectangle
+	if regions == nil {
+		existing, err := s.store.GetScreenshotBlurRegions(screenshot.ID)
+		if err != nil {
+			return false, err
+		}
+		for _, r := range existing {
+			if image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height).Intersect(bounds) == bounds {
+				return false, nil
+			}
+		}
+		rects = []image.Rectangle{bounds}
+	}
+	for _, r := range regions {
+		if r.Width <= 0 || r.Height <= 0 {
+			return false, fmt.Errorf("invalid blur region size %dx%d", r.Width, r.Height)
+		}
+		rect := image.Rect(r.X, r.Y, r.X+r.Width, r.Y+r.Height).Intersect(bounds)
+		if rect.Empty() {
+			return false, fmt.Errorf("blur region at %d,%d is outside the %dx%d screenshot", r.X, r.Y, bounds.Dx(), bounds.Dy())
+		}
+		rects = append(rects, rect)
+	}
+
+	kernel := gaussianKernel(blurSigma)
+	records := make([]*storage.ScreenshotBlurRegion, len(rects))
+	for i, rect := range rects {
+		gaussianBlur(img, rect, kernel)
+		records[i] = &storage.ScreenshotBlurRegion{X: rect.Min.X, Y: rect.Min.Y, Width: rect.Dx(), Height: rect.Dy()}
+	}
+
+	if err := writeScreenshotImage(screenshot.Filepath, img); err != nil {
+		return false, err
+	}
+
+	// The thumbnail shows the same content, so it's regenerated at its current width
+	thumbPath := s.thumbnailPath(screenshot.Filepath)
+	if thumb, err := readScreenshotImage(thumbPath); err == nil {
+		resized := imaging.Resize(img, thumb.Bounds().Dx(), 0, imaging.Lanczos)
+		if err := writeScreenshotImage(thumbPath, resized); err != nil {
+			return false, err
+		}
+	}
+
+	return true, s.store.SaveScreenshotBlurRegions(screenshot.ID, records)
+}
+
+// readScreenshotImage decodes a WebP or PNG screenshot.
+func readScreenshotImage(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open screenshot: %w", err)
+	}
+	defer f.Close()
+
+	var img image.Image
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".webp":
+		img, err = webp.Decode(f)
+	case ".png":
+		img, err = png.Decode(f)
+	default:
+		return nil, fmt.Errorf("unsupported screenshot format: %s", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode screenshot: %w", err)
+	}
+	return img, nil
+}
+
+// writeScreenshotImage encodes a screenshot in the format of its extension, replacing the
+// file only once the new image is fully written.
+func writeScreenshotImage(path string, img image.Image) error {
+	tmpPath := path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create screenshot: %w", err)
+	}
+
+	if strings.ToLower(filepath.Ext(path)) == ".png" {
+		err = png.Encode(f, img)
+	} else {
+		err = webp.Encode(f, img, &webp.Options{Lossless: false, Quality: blurredScreenshotQuality})
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode screenshot: %w", err)
+	}
+	return os.Rename(tmpPath, path)
+}
+
+// toRGBA returns img as an *image.RGBA, converting it if needed.
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok {
+		return rgba
+	}
+	rgba := image.NewRGBA(img.Bounds())
+	draw.Draw(rgba, rgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return rgba
+}
+
+// gaussianKernel returns a normalized 1D Gaussian kernel covering three standard deviations.
+func gaussianKernel(sigma float64) []float64 {
+	radius := int(math.Ceil(3 * sigma))
+	kernel := make([]float64, 2*radius+1)
+	var sum float64
+	for i := range kernel {
+		d := float64(i - radius)
+		kernel[i] = math.Exp(-d * d / (2 * sigma * sigma))
+		sum += kernel[i]
+	}
+	for i := range kernel {
+		kernel[i] /= sum
+	}
+	return kernel
+}
+
+// gaussianBlur blurs a rectangle of img in place with a separable kernel. Samples are
+// clamped to the rectangle, so pixels outside it neither change nor bleed in.
+func gaussianBlur(img *image.RGBA, r image.Rectangle, kernel []float64) {
+	radius := len(kernel) / 2
+	w, h := r.Dx(), r.Dy()
+	tmp := make([]float64, w*h*4)
+
+	// Horizontal pass into tmp
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var acc [4]float64
+			for k, weight := range kernel {
+				i := img.PixOffset(r.Min.X+min(max(x+k-radius, 0), w-1), r.Min.Y+y)
+				for c := range acc {
+					acc[c] += weight * float64(img.Pix[i+c])
+				}
+			}
+			copy(tmp[(y*w+x)*4:], acc[:])
+		}
+	}
+
+	// Vertical pass back into the image
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var acc [4]float64
+			for k, weight := range kernel {
+				j := (min(max(y+k-radius, 0), h-1)*w + x) * 4
+				for c := range acc {
+					acc[c] += weight * tmp[j+c]
+				}
+			}
+			i := img.PixOffset(r.Min.X+x, r.Min.Y+y)
+			for c := range acc {
+				img.Pix[i+c] = uint8(math.Round(acc[c]))
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"traq/internal/storage"
 )
@@ -97,6 +98,71 @@ func (s *ScreenshotService) DeleteScreenshot(id int64) error {
 	return s.store.DeleteScreenshot(id)
 }
 
+// defaultStripIntervalMinutes is the filmstrip block length used when none is given.
+const defaultStripIntervalMinutes = 5
+
+// GetScreenshotStrip returns one screenshot per intervalMinutes block of an hour (0-23) on a
+// date (YYYY-MM-DD), for a thumbnail filmstrip. Each block uses the screenshot closest to
+// its midpoint; blocks without screenshots get a placeholder with IsMissing set and the
+// midpoint as its timestamp.
+func (s *ScreenshotService) GetScreenshotStrip(date string, hour int, intervalMinutes int) ([]*ScreenshotDisplay, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	if hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("invalid hour: %d", hour)
+	}
+	if intervalMinutes < 1 || intervalMinutes > 60 {
+		intervalMinutes = defaultStripIntervalMinutes
+	}
+
+	hourStart := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local).Unix()
+	hourEnd := hourStart + 3600
+	interval := int64(intervalMinutes) * 60
+
+	var screenshots []*storage.Screenshot
+	count, err := s.store.CountScreenshotsForHour(date, hour)
+	if err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		if screenshots, err = s.store.GetScreenshots(hourStart, hourEnd-1); err != nil {
+			return nil, err
+		}
+	}
+
+	var strip []*ScreenshotDisplay
+	i := 0
+	for blockStart := hourStart; blockStart < hourEnd; blockStart += interval {
+		blockEnd := min(blockStart+interval, hourEnd)
+		mid := blockStart + (blockEnd-blockStart)/2
+
+		// Screenshots are sorted by time, so each block's are the next run of the slice
+		var best *storage.Screenshot
+		for ; i < len(screenshots) && screenshots[i].Timestamp < blockEnd; i++ {
+			if best == nil || absInt64(screenshots[i].Timestamp-mid) < absInt64(best.Timestamp-mid) {
+				best = screenshots[i]
+			}
+		}
+
+		if best == nil {
+			strip = append(strip, &ScreenshotDisplay{Timestamp: mid, IsMissing: true})
+		} else {
+			strip = append(strip, toScreenshotDisplay(best))
+		}
+	}
+	return strip, nil
+}
+
+// absInt64 returns the absolute value of n.
+func absInt64(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
 // thumbnailPath derives the thumbnail path from the screenshot path.
 func (s *ScreenshotService) thumbnailPath(screenshotPath string) string {
 	// Convert "123456.webp" to "123456_thumb.webp"
@@ -107,16 +173,17 @@ func (s *ScreenshotService) thumbnailPath(screenshotPath string) string {
 
 // ScreenshotInfo contains detailed info about a screenshot.
 type ScreenshotInfo struct {
-	ID            int64  `json:"id"`
-	Timestamp     int64  `json:"timestamp"`
-	Filepath      string `json:"filepath"`
-	ThumbnailPath string `json:"thumbnailPath"`
-	Width         int    `json:"width"`
-	Height        int    `json:"height"`
-	WindowTitle   string `json:"windowTitle"`
-	AppName       string `json:"appName"`
-	SessionID     int64  `json:"sessionId"`
-	FileSize      int64  `json:"fileSize"`
+	ID            int64    `json:"id"`
+	Timestamp     int64    `json:"timestamp"`
+	Filepath      string   `json:"filepath"`
+	ThumbnailPath string   `json:"thumbnailPath"`
+	Width         int      `json:"width"`
+	Height        int      `json:"height"`
+	WindowTitle   string   `json:"windowTitle"`
+	AppName       string   `json:"appName"`
+	SessionID     int64    `json:"sessionId"`
+	FileSize      int64    `json:"fileSize"`
+	Annotations   []string `json:"annotations"`
 }
 
 // GetScreenshotInfo returns detailed info about a screenshot.
@@ -154,5 +221,59 @@ func (s *ScreenshotService) GetScreenshotInfo(id int64) (*ScreenshotInfo, error)
 		info.FileSize = stat.Size()
 	}
 
+	info.Annotations, err = s.store.GetScreenshotAnnotations(id)
+	if err != nil {
+		return nil, err
+	}
+
 	return info, nil
 }
+
+// ScreenshotSearchResult is a screenshot matched by an annotation search, with all its labels.
+type ScreenshotSearchResult struct {
+	Screenshot  *ScreenshotDisplay `json:"screenshot"`
+	Annotations []string           `json:"annotations"`
+}
+
+// AnnotateScreenshot adds a user label to a screenshot.
+func (s *ScreenshotService) AnnotateScreenshot(screenshotID int64, label string) error {
+	screenshot, err := s.store.GetScreenshot(screenshotID)
+	if err != nil {
+		return err
+	}
+	if screenshot == nil {
+		return fmt.Errorf("screenshot not found: %d", screenshotID)
+	}
+	return s.store.AnnotateScreenshot(screenshotID, label, "user")
+}
+
+// GetScreenshotAnnotations returns the labels on a screenshot.
+func (s *ScreenshotService) GetScreenshotAnnotations(screenshotID int64) ([]string, error) {
+	return s.store.GetScreenshotAnnotations(screenshotID)
+}
+
+// DeleteScreenshotAnnotation removes a label from a screenshot.
+func (s *ScreenshotService) DeleteScreenshotAnnotation(screenshotID int64, label string) error {
+	return s.store.DeleteScreenshotAnnotation(screenshotID, label)
+}
+
+// SearchScreenshotsByAnnotation finds screenshots in a time range carrying a label (case-insensitive).
+func (s *ScreenshotService) SearchScreenshotsByAnnotation(label string, start, end int64) ([]*ScreenshotSearchResult, error) {
+	screenshots, err := s.store.SearchScreenshotsByAnnotation(label, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*ScreenshotSearchResult, 0, len(screenshots))
+	for _, sc := range screenshots {
+		labels, err := s.store.GetScreenshotAnnotations(sc.ID)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &ScreenshotSearchResult{
+			Screenshot:  toScreenshotDisplay(sc),
+			Annotations: labels,
+		})
+	}
+	return results, nil
+}
@@ -1,9 +1,17 @@
 package service
 
 import (
+	"database/sql"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"traq/internal/storage"
 )
 
 // TestThumbnailPath_ValidPath tests that thumbnailPath generates correct path format.
@@ -180,3 +188,161 @@ func TestThumbnailPath_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestGetScreenshotStrip(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewScreenshotService(store, t.TempDir())
+
+	hour := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.Local)
+	save := func(offset time.Duration, path string) {
+		t.Helper()
+		if _, err := store.SaveScreenshot(&storage.Screenshot{Timestamp: hour.Add(offset).Unix(), Filepath: path, DHash: "d:1"}); err != nil {
+			t.Fatalf("failed to save screenshot: %v", err)
+		}
+	}
+	// First 15-minute block (midpoint 7:30): closest is at 8:00
+	save(1*time.Minute, "/s/a.webp")
+	save(8*time.Minute, "/s/b.webp")
+	save(14*time.Minute, "/s/c.webp")
+	// Second block is empty; third block has one screenshot
+	save(44*time.Minute, "/s/d.webp")
+	// Outside the hour
+	save(-time.Minute, "/s/before.webp")
+	save(time.Hour, "/s/after.webp")
+
+	strip, err := svc.GetScreenshotStrip("2026-03-10", 9, 15)
+	if err != nil {
+		t.Fatalf("GetScreenshotStrip failed: %v", err)
+	}
+	if len(strip) != 4 {
+		t.Fatalf("expected 4 blocks, got %d", len(strip))
+	}
+	want := []string{"/s/b.webp", "", "/s/d.webp", ""}
+	for i, shot := range strip {
+		if want[i] == "" {
+			if !shot.IsMissing || shot.Timestamp != hour.Add(time.Duration(i*15)*time.Minute+450*time.Second).Unix() {
+				t.Errorf("block %d: expected placeholder at the midpoint, got %+v", i, shot)
+			}
+		} else if shot.IsMissing || shot.Filepath != want[i] {
+			t.Errorf("block %d: expected %s, got %+v", i, want[i], shot)
+		}
+	}
+
+	if _, err := svc.GetScreenshotStrip("2026-03-10", 24, 15); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
+
+// writeCheckerboardPNG writes a black and white checkerboard of 1-pixel squares.
+func writeCheckerboardPNG(t *testing.T, path string, w, h int) {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.White)
+			} else {
+				img.Set(x, y, color.Black)
+			}
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBlurScreenshot(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	dir := t.TempDir()
+	svc := NewScreenshotService(store, dir)
+
+	path := filepath.Join(dir, "090000.png")
+	writeCheckerboardPNG(t, path, 64, 48)
+	writeCheckerboardPNG(t, svc.thumbnailPath(path), 32, 24)
+	original, err := readScreenshotImage(path)
+	if err != nil {
+		t.Fatalf("failed to read screenshot: %v", err)
+	}
+	id, _ := store.SaveScreenshot(&storage.Screenshot{Timestamp: 1000, Filepath: path, DHash: "d:1"})
+
+	if err := svc.BlurScreenshot(id, []BlurRegion{{X: 100, Y: 100, Width: 10, Height: 10}}); err == nil {
+		t.Error("expected error for a region outside the screenshot")
+	}
+	if err := svc.BlurScreenshot(id, []BlurRegion{{X: 10, Y: 10, Width: 20, Height: 16}}); err != nil {
+		t.Fatalf("BlurScreenshot failed: %v", err)
+	}
+
+	blurred, err := readScreenshotImage(path)
+	if err != nil {
+		t.Fatalf("failed to read blurred screenshot: %v", err)
+	}
+	// Inside the region, black and white squares blend into grey
+	for _, p := range []image.Point{{15, 15}, {20, 20}, {28, 24}} {
+		if blurred.At(p.X, p.Y) == original.At(p.X, p.Y) {
+			t.Errorf("expected pixel %v to change", p)
+		}
+		if r, _, _, _ := blurred.At(p.X, p.Y).RGBA(); r>>8 < 64 || r>>8 > 192 {
+			t.Errorf("expected pixel %v to be grey, got red %d", p, r>>8)
+		}
+	}
+	// Outside it, nothing changes
+	for _, p := range []image.Point{{0, 0}, {9, 10}, {30, 10}, {40, 40}} {
+		if blurred.At(p.X, p.Y) != original.At(p.X, p.Y) {
+			t.Errorf("expected pixel %v outside the region to be unchanged", p)
+		}
+	}
+
+	regions, err := store.GetScreenshotBlurRegions(id)
+	if err != nil {
+		t.Fatalf("GetScreenshotBlurRegions failed: %v", err)
+	}
+	if len(regions) != 1 || regions[0].X != 10 || regions[0].Width != 20 || regions[0].Height != 16 {
+		t.Errorf("expected the blurred region to be recorded, got %+v", regions)
+	}
+	if thumb, err := readScreenshotImage(svc.thumbnailPath(path)); err != nil || thumb.Bounds().Dx() != 32 {
+		t.Errorf("expected the thumbnail to be regenerated at its width, got %v", err)
+	}
+}
+
+func TestBlurAllScreenshotsForApp(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	dir := t.TempDir()
+	svc := NewScreenshotService(store, dir)
+
+	for i, app := range []string{"KeePassXC", "keepassxc", "code"} {
+		path := filepath.Join(dir, fmt.Sprintf("%d.png", i))
+		writeCheckerboardPNG(t, path, 16, 16)
+		store.SaveScreenshot(&storage.Screenshot{
+			Timestamp: 1000 + int64(i), Filepath: path, DHash: "d:1", AppName: sql.NullString{String: app, Valid: true},
+		})
+	}
+
+	count, err := svc.BlurAllScreenshotsForApp("KeePassXC", 0, 2000)
+	if err != nil {
+		t.Fatalf("BlurAllScreenshotsForApp failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 screenshots blurred, got %d", count)
+	}
+	blurred, _ := readScreenshotImage(filepath.Join(dir, "0.png"))
+	if r, _, _, _ := blurred.At(0, 0).RGBA(); r>>8 == 255 {
+		t.Error("expected the corner of a fully blurred screenshot to change")
+	}
+	untouched, _ := readScreenshotImage(filepath.Join(dir, "2.png"))
+	if r, _, _, _ := untouched.At(0, 0).RGBA(); r>>8 != 255 {
+		t.Error("expected screenshots of other apps to be unchanged")
+	}
+
+	// Already fully blurred screenshots are skipped
+	if count, _ := svc.BlurAllScreenshotsForApp("keepassxc", 0, 2000); count != 0 {
+		t.Errorf("expected no screenshots to be blurred again, got %d", count)
+	}
+}
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+// socialMediaTopics are the inferDomainTopic categories counted as social media use.
+var socialMediaTopics = map[string]bool{
+	"Social":   true,
+	"Shopping": true,
+}
+
+// socialMediaApps are desktop apps counted as social media use, matched against the app name.
+var socialMediaApps = []string{"slack", "discord", "steam"}
+
+// SocialMediaStats summarizes time spent on social, entertainment and shopping sites and apps.
+type SocialMediaStats struct {
+	TotalMinutes        int64            `json:"totalMinutes"`
+	DomainBreakdown     map[string]int64 `json:"domainBreakdown"` // Minutes per domain or app
+	PeakHour            int              `json:"peakHour"`        // Hour of day with the most use, -1 if none
+	AverageDailyMinutes float64          `json:"averageDailyMinutes"`
+	TrendPercent        float64          `json:"trendPercent"` // Change from the same number of days before
+}
+
+// isSocialMediaApp reports whether an app is a social media or gaming app.
+func isSocialMediaApp(appName string) bool {
+	lower := strings.ToLower(appName)
+	for _, app := range socialMediaApps {
+		if strings.Contains(lower, app) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSocialMediaUsage returns social media use between start and end, from visits to
+// social and shopping domains and focus time in social media apps.
+func (s *AnalyticsService) GetSocialMediaUsage(start, end int64) (*SocialMediaStats, error) {
+	breakdown, hourSeconds, err := s.socialMediaSeconds(start, end)
+	if err != nil {
+		return nil, err
+	}
+	previous, _, err := s.socialMediaSeconds(start-(end-start), start)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &SocialMediaStats{
+		DomainBreakdown: make(map[string]int64, len(breakdown)),
+		PeakHour:        -1,
+	}
+	var totalSeconds, previousSeconds int64
+	for name, seconds := range breakdown {
+		stats.DomainBreakdown[name] = seconds / 60
+		totalSeconds += seconds
+	}
+	for _, seconds := range previous {
+		previousSeconds += seconds
+	}
+	stats.TotalMinutes = totalSeconds / 60
+
+	var peakSeconds int64
+	for hour, seconds := range hourSeconds {
+		if seconds > peakSeconds {
+			peakSeconds = seconds
+			stats.PeakHour = hour
+		}
+	}
+
+	days := math.Max(1, math.Ceil(float64(end-start)/86400))
+	stats.AverageDailyMinutes = float64(totalSeconds) / 60 / days
+	if previousSeconds > 0 {
+		stats.TrendPercent = float64(totalSeconds-previousSeconds) / float64(previousSeconds) * 100
+	}
+	return stats, nil
+}
+
+// socialMediaSeconds returns the social media time between start and end by domain or
+// app, and by hour of day.
+func (s *AnalyticsService) socialMediaSeconds(start, end int64) (map[string]int64, [24]int64, error) {
+	breakdown := make(map[string]int64)
+	var hourSeconds [24]int64
+
+	visits, err := s.store.GetBrowserVisitsByTimeRange(start, end)
+	if err != nil {
+		return nil, hourSeconds, fmt.Errorf("failed to get browser visits: %w", err)
+	}
+	for _, visit := range visits {
+		domain := storage.NormalizeDomain(visit.Domain)
+		if !visit.VisitDurationSeconds.Valid || !socialMediaTopics[inferDomainTopic(domain)] {
+			continue
+		}
+		breakdown[domain] += visit.VisitDurationSeconds.Int64
+		addSecondsByHour(&hourSeconds, visit.Timestamp, visit.Timestamp+visit.VisitDurationSeconds.Int64)
+	}
+
+	events, err := s.store.GetWindowFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, hourSeconds, fmt.Errorf("failed to get focus events: %w", err)
+	}
+	for _, evt := range events {
+		if !isSocialMediaApp(evt.AppName) {
+			continue
+		}
+		breakdown[GetFriendlyAppName(evt.AppName)] += int64(clampedEventDuration(evt, start, end))
+		addSecondsByHour(&hourSeconds, max(evt.StartTime, start), min(evt.EndTime, end))
+	}
+	return breakdown, hourSeconds, nil
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"sync"
 	"time"
 
 	"traq/internal/inference"
@@ -15,14 +16,28 @@ import (
 type SummaryService struct {
 	store     *storage.Store
 	inference *inference.Service
+	completer TextCompleter // Model used for daily and weekly rollups; the inference service unless overridden
+
+	// annotateScreenshots reports whether summary tags should be added as AI screenshot annotations
+	annotateScreenshots func() bool
 }
 
 // NewSummaryService creates a new SummaryService
 func NewSummaryService(store *storage.Store, inf *inference.Service) *SummaryService {
-	return &SummaryService{
+	s := &SummaryService{
 		store:     store,
 		inference: inf,
 	}
+	if inf != nil {
+		s.completer = inf
+	}
+	return s
+}
+
+// SetScreenshotAnnotation enables AI screenshot annotations. When enabled returns true,
+// each generated summary's tags are added as "ai" annotations to the session's screenshots.
+func (s *SummaryService) SetScreenshotAnnotation(enabled func() bool) {
+	s.annotateScreenshots = enabled
 }
 
 // GenerateSummary generates a summary for a session
@@ -49,7 +64,11 @@ func (s *SummaryService) GenerateSummary(sessionID int64) (*storage.Summary, err
 	}
 
 	// Generate summary
-	result, err := s.inference.GenerateSummary(ctx)
+	prompt, err := s.buildPrompt(PromptSessionSummary, summaryPromptData{Activity: inference.SessionActivity(ctx)})
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.inference.GenerateSummaryFromPrompt(prompt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate summary: %w", err)
 	}
@@ -105,9 +124,104 @@ func (s *SummaryService) GenerateSummary(sessionID int64) (*storage.Summary, err
 		fmt.Printf("Warning: failed to link summary to session: %v\n", err)
 	}
 
+	if s.annotateScreenshots != nil && s.annotateScreenshots() {
+		s.annotateSessionScreenshots(screenshots, result.Tags)
+	}
+
 	return summary, nil
 }
 
+// Concurrent inference calls allowed during batch summary generation. Local engines
+// share the machine's CPU/GPU, so they run one at a time.
+const (
+	batchConcurrencyLocal = 1
+	batchConcurrencyCloud = 3
+)
+
+// GenerateBatchSummaries generates summaries for up to maxSessions ended sessions that don't
+// have one, most recent first (maxSessions <= 0 means all). onProgress, if set, is called after
+// each session with the number processed so far and the total. A failed session doesn't stop
+// the batch; the returned error reports the failures. Returns the number of summaries generated.
+func (s *SummaryService) GenerateBatchSummaries(maxSessions int, onProgress func(done, total int)) (int, error) {
+	if s.inference == nil {
+		return 0, fmt.Errorf("inference service not initialized")
+	}
+	status := s.inference.GetSetupStatus()
+	if !status.Ready {
+		return 0, fmt.Errorf("inference not ready: %s. %s", status.Issue, status.Suggestion)
+	}
+
+	sessions, err := s.store.GetSessionsWithoutSummary(maxSessions)
+	if err != nil {
+		return 0, err
+	}
+	total := len(sessions)
+	if total == 0 {
+		return 0, nil
+	}
+
+	concurrency := batchConcurrencyLocal
+	if s.inference.GetStatus().Engine == string(inference.EngineCloud) {
+		concurrency = batchConcurrencyCloud
+	}
+	sem := make(chan struct{}, concurrency)
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		done      int
+		generated int
+		failed    int
+		firstErr  error
+	)
+	for _, session := range sessions {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sessionID int64) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, err := s.GenerateSummary(sessionID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			done++
+			if err != nil {
+				failed++
+				if firstErr == nil {
+					firstErr = fmt.Errorf("session %d: %w", sessionID, err)
+				}
+			} else {
+				generated++
+			}
+			if onProgress != nil {
+				onProgress(done, total)
+			}
+		}(session.ID)
+	}
+	wg.Wait()
+
+	if failed > 0 {
+		return generated, fmt.Errorf("%d of %d summaries failed, first error: %w", failed, total, firstErr)
+	}
+	return generated, nil
+}
+
+// annotateSessionScreenshots labels screenshots with summary tags describing their content.
+// The "general" tag is skipped: it's the fallback used when the model response couldn't be parsed.
+func (s *SummaryService) annotateSessionScreenshots(screenshots []*storage.Screenshot, tags []string) {
+	for _, tag := range tags {
+		if tag == "" || tag == "general" {
+			continue
+		}
+		for _, ss := range screenshots {
+			if err := s.store.AnnotateScreenshot(ss.ID, tag, "ai"); err != nil {
+				fmt.Printf("Warning: failed to annotate screenshot %d: %v\n", ss.ID, err)
+			}
+		}
+	}
+}
+
 // RegenerateSummary regenerates a summary for a session (deletes existing)
 func (s *SummaryService) RegenerateSummary(sessionID int64) (*storage.Summary, error) {
 	// Delete existing summary if any
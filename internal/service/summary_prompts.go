@@ -0,0 +1,166 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"traq/internal/inference"
+	"traq/internal/storage"
+)
+
+// Summary prompt types that can be customized.
+const (
+	PromptSessionSummary = "session_summary"
+	PromptDailySummary   = "daily_summary"
+	PromptWeeklySummary  = "weekly_summary"
+)
+
+// SummaryPromptTypes lists the customizable summary prompts.
+var SummaryPromptTypes = []string{PromptSessionSummary, PromptDailySummary, PromptWeeklySummary}
+
+// DefaultSessionSummaryPrompt is sent to summarize a single session. The instructions
+// describe the JSON response the summary parser expects.
+const DefaultSessionSummaryPrompt = "Analyze this work session and provide a detailed summary.\n\n{{.Activity}}" + inference.SummaryInstructions
+
+// DefaultDailySummaryPrompt rolls a day's session summaries up into a narrative.
+const DefaultDailySummaryPrompt = `Below are summaries of {{.Subject}}, taken from a personal activity tracker.
+
+{{.Entries}}
+Write a single narrative summary of the day in 3-5 sentences, in the first person. Focus on the main themes and accomplishments rather than listing every entry. Respond with only the summary text, without a heading or preamble.
+`
+
+// DefaultWeeklySummaryPrompt rolls a week's daily summaries up into a narrative.
+const DefaultWeeklySummaryPrompt = `Below are summaries of {{.Subject}}, taken from a personal activity tracker.
+
+{{.Entries}}
+Write a single narrative summary of the week in 3-5 sentences, in the first person. Focus on the main themes and accomplishments rather than listing every entry. Respond with only the summary text, without a heading or preamble.
+`
+
+// summaryPromptSpec describes a prompt's default, the placeholder it must contain and
+// the template variables available to it.
+type summaryPromptSpec struct {
+	defaultTemplate string
+	required        string // Field that must appear as a {{.Field}} placeholder
+	help            string
+}
+
+var summaryPromptSpecs = map[string]summaryPromptSpec{
+	PromptSessionSummary: {
+		defaultTemplate: DefaultSessionSummaryPrompt,
+		required:        "Activity",
+		help: "{{.Activity}} (required): the session's duration, apps and windows, meetings, git commits, shell commands, file and browser activity.\n" +
+			"The response must be JSON with summary, explanation, projects, tags and confidence fields; keep the default instructions to get it.",
+	},
+	PromptDailySummary: {
+		defaultTemplate: DefaultDailySummaryPrompt,
+		required:        "Entries",
+		help: "{{.Entries}} (required): one line per session summary, with start time, length and tags.\n" +
+			"{{.Subject}}: what the entries cover, e.g. \"the work sessions of Monday, March 9, 2026\".",
+	},
+	PromptWeeklySummary: {
+		defaultTemplate: DefaultWeeklySummaryPrompt,
+		required:        "Entries",
+		help: "{{.Entries}} (required): one line per daily summary, prefixed with the day.\n" +
+			"{{.Subject}}: what the entries cover, e.g. \"each day of the week of March 9, 2026\".",
+	},
+}
+
+// summaryPromptData is the data summary prompt templates are executed with.
+type summaryPromptData struct {
+	Activity string // Session summaries only
+	Subject  string // Daily and weekly summaries only
+	Entries  string // Daily and weekly summaries only
+}
+
+// SummaryPrompt is a summary prompt as shown in settings.
+type SummaryPrompt struct {
+	Type     string `json:"type"`
+	Template string `json:"template"` // The custom template, or the default if none is set
+	Default  string `json:"default"`
+	Custom   bool   `json:"custom"`
+	Help     string `json:"help"` // Template variables available to this prompt
+}
+
+// GetDefaultPrompt returns the built-in template for a prompt type, or "" if the type is unknown.
+func (s *SummaryService) GetDefaultPrompt(promptType string) string {
+	return summaryPromptSpecs[promptType].defaultTemplate
+}
+
+// GetPrompts returns every summary prompt with its current template.
+func (s *SummaryService) GetPrompts() []*SummaryPrompt {
+	custom := loadCustomPrompts(s.store)
+	prompts := make([]*SummaryPrompt, 0, len(SummaryPromptTypes))
+	for _, promptType := range SummaryPromptTypes {
+		spec := summaryPromptSpecs[promptType]
+		p := &SummaryPrompt{Type: promptType, Template: spec.defaultTemplate, Default: spec.defaultTemplate, Help: spec.help}
+		if tmpl, ok := custom[promptType]; ok {
+			p.Template = tmpl
+			p.Custom = true
+		}
+		prompts = append(prompts, p)
+	}
+	return prompts
+}
+
+// SetCustomPrompt replaces the prompt sent for a prompt type. The template uses Go
+// template syntax and must contain the type's required placeholder, e.g. {{.Activity}}.
+func (s *SummaryService) SetCustomPrompt(promptType, tmpl string) error {
+	spec, ok := summaryPromptSpecs[promptType]
+	if !ok {
+		return fmt.Errorf("unknown prompt type: %s", promptType)
+	}
+	if strings.TrimSpace(tmpl) == "" {
+		return fmt.Errorf("prompt template is empty")
+	}
+	if !regexp.MustCompile(`{{-?\s*\.` + spec.required + `\s*-?}}`).MatchString(tmpl) {
+		return fmt.Errorf("prompt template must contain {{.%s}}", spec.required)
+	}
+	// Execute against sample data so unknown fields are caught now rather than at generation
+	if _, err := renderSummaryPrompt(tmpl, summaryPromptData{}); err != nil {
+		return err
+	}
+	return s.store.SetConfig("prompts."+promptType, tmpl)
+}
+
+// ResetPromptToDefault removes a prompt type's custom template.
+func (s *SummaryService) ResetPromptToDefault(promptType string) error {
+	if _, ok := summaryPromptSpecs[promptType]; !ok {
+		return fmt.Errorf("unknown prompt type: %s", promptType)
+	}
+	return s.store.DeleteConfig("prompts." + promptType)
+}
+
+// loadCustomPrompts reads the custom summary prompt templates, keyed by prompt type.
+func loadCustomPrompts(store *storage.Store) map[string]string {
+	custom := make(map[string]string)
+	for _, promptType := range SummaryPromptTypes {
+		if val, err := store.GetConfig("prompts." + promptType); err == nil && val != "" {
+			custom[promptType] = val
+		}
+	}
+	return custom
+}
+
+// buildPrompt renders the custom template for a prompt type, or the default if none is set.
+func (s *SummaryService) buildPrompt(promptType string, data summaryPromptData) (string, error) {
+	tmpl := s.GetDefaultPrompt(promptType)
+	if custom, ok := loadCustomPrompts(s.store)[promptType]; ok {
+		tmpl = custom
+	}
+	return renderSummaryPrompt(tmpl, data)
+}
+
+// renderSummaryPrompt executes a prompt template.
+func renderSummaryPrompt(tmpl string, data summaryPromptData) (string, error) {
+	t, err := template.New("prompt").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return sb.String(), nil
+}
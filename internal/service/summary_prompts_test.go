@@ -0,0 +1,135 @@
+package service
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/inference"
+	"traq/internal/storage"
+)
+
+func TestCustomSessionPromptSentToInference(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			w.Write([]byte(`{"models":[{"name":"test-model"}]}`))
+		case "/api/generate":
+			var req struct {
+				Prompt string `json:"prompt"`
+			}
+			json.NewDecoder(r.Body).Decode(&req)
+			received = req.Prompt
+			json.NewEncoder(w).Encode(map[string]string{"response": `{"summary":"Wrote prompt tests","tags":["go"]}`})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	inf := inference.NewService(&inference.Config{
+		Engine: inference.EngineOllama,
+		Ollama: &inference.OllamaConfig{Host: server.URL, Model: "test-model"},
+	})
+	svc := NewSummaryService(store, inf)
+
+	start := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.Local)
+	sessionID, _ := store.CreateSession(start.Unix())
+	store.EndSession(sessionID, start.Add(time.Hour).Unix())
+
+	// Default prompt
+	if _, err := svc.GenerateSummary(sessionID); err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+	if !strings.HasPrefix(received, "Analyze this work session") {
+		t.Errorf("expected the default prompt, got:\n%s", received)
+	}
+
+	if err := svc.SetCustomPrompt(PromptSessionSummary, "In one line, what did I do?\n{{ .Activity }}\nReply as JSON."); err != nil {
+		t.Fatalf("SetCustomPrompt failed: %v", err)
+	}
+	summary, err := svc.GenerateSummary(sessionID)
+	if err != nil {
+		t.Fatalf("GenerateSummary failed: %v", err)
+	}
+	if !strings.HasPrefix(received, "In one line, what did I do?\nSession Duration: 1h 0m") {
+		t.Errorf("expected the custom prompt with session activity, got:\n%s", received)
+	}
+	if strings.Contains(received, "Analyze this work session") {
+		t.Error("default prompt was sent alongside the custom one")
+	}
+	if summary.Summary != "Wrote prompt tests" {
+		t.Errorf("Summary = %q", summary.Summary)
+	}
+}
+
+func TestCustomRollupPrompt(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	completer := &mockCompleter{response: "A short week."}
+	svc := NewSummaryService(store, nil)
+	svc.completer = completer
+	store.SaveHierarchicalSummary(&storage.HierarchicalSummary{PeriodType: "day", PeriodDate: "2026-03-09", Summary: "Planned the sprint."})
+
+	if err := svc.SetCustomPrompt(PromptWeeklySummary, "Three bullet points for {{.Subject}}:\n{{.Entries}}"); err != nil {
+		t.Fatalf("SetCustomPrompt failed: %v", err)
+	}
+	if _, err := svc.GenerateWeeklySummaryFromDailies("2026-03-09"); err != nil {
+		t.Fatalf("GenerateWeeklySummaryFromDailies failed: %v", err)
+	}
+	want := "Three bullet points for each day of the week of March 9, 2026:\n- Monday, March 9: Planned the sprint.\n"
+	if completer.prompt != want {
+		t.Errorf("prompt = %q, want %q", completer.prompt, want)
+	}
+
+	prompts := svc.GetPrompts()
+	if len(prompts) != 3 || !prompts[2].Custom || prompts[0].Custom || prompts[2].Help == "" {
+		t.Errorf("unexpected prompts: %+v", prompts)
+	}
+
+	if err := svc.ResetPromptToDefault(PromptWeeklySummary); err != nil {
+		t.Fatalf("ResetPromptToDefault failed: %v", err)
+	}
+	svc.GenerateWeeklySummaryFromDailies("2026-03-09")
+	if !strings.HasPrefix(completer.prompt, "Below are summaries of each day of the week") {
+		t.Errorf("expected the default prompt after reset, got:\n%s", completer.prompt)
+	}
+}
+
+func TestSetCustomPromptValidation(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+	svc := NewSummaryService(store, nil)
+
+	tests := []struct {
+		name       string
+		promptType string
+		template   string
+	}{
+		{"unknown type", "monthly_summary", "{{.Entries}}"},
+		{"empty", PromptDailySummary, "  "},
+		{"missing placeholder", PromptDailySummary, "Summarize {{.Subject}}"},
+		{"wrong placeholder", PromptSessionSummary, "Summarize {{.Entries}}"},
+		{"unknown field", PromptDailySummary, "{{.Entries}} {{.Mood}}"},
+		{"syntax error", PromptDailySummary, "{{.Entries}} {{if}}"},
+	}
+	for _, tt := range tests {
+		if err := svc.SetCustomPrompt(tt.promptType, tt.template); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if custom := loadCustomPrompts(store); len(custom) != 0 {
+		t.Errorf("invalid templates were saved: %v", custom)
+	}
+	if svc.GetDefaultPrompt(PromptDailySummary) != DefaultDailySummaryPrompt || svc.GetDefaultPrompt("unknown") != "" {
+		t.Error("unexpected default prompt")
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// GenerateDailySummary rolls the session summaries for a date (YYYY-MM-DD) up into a daily
+// narrative, stored as the "day" hierarchical summary for that date. An existing summary is
+// replaced unless the user edited it. If no model is available, generation is skipped and the
+// existing summary (or nil) is returned.
+func (s *SummaryService) GenerateDailySummary(date string) (*storage.HierarchicalSummary, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	existing, err := s.store.GetHierarchicalSummary("day", date)
+	if err != nil {
+		return nil, err
+	}
+	if (existing != nil && existing.UserEdited) || !s.rollupReady() {
+		return existing, nil
+	}
+
+	sessions, err := s.store.GetSessionsByTimeRange(t.Unix(), t.AddDate(0, 0, 1).Unix()-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartTime < sessions[j].StartTime })
+
+	sessionIDs := make([]int64, len(sessions))
+	for i, sess := range sessions {
+		sessionIDs[i] = sess.ID
+	}
+	summaries, err := s.store.GetSummariesForSessions(sessionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session summaries: %w", err)
+	}
+
+	var entries []string
+	for _, sess := range sessions {
+		summary := summaries[sess.ID]
+		if summary == nil || strings.TrimSpace(summary.Summary) == "" {
+			continue
+		}
+		entry := fmt.Sprintf("%s (%s): %s", time.Unix(sess.StartTime, 0).Format("15:04"),
+			formatMinutes(sessionLengthSeconds(sess)/60), strings.TrimSpace(summary.Summary))
+		if len(summary.Tags) > 0 {
+			entry += fmt.Sprintf(" [%s]", strings.Join(summary.Tags, ", "))
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no session summaries for %s", date)
+	}
+
+	prompt, err := s.buildPrompt(PromptDailySummary, rollupPromptData("the work sessions of "+t.Format("Monday, January 2, 2006"), entries))
+	if err != nil {
+		return nil, err
+	}
+	return s.saveRollup("day", date, prompt)
+}
+
+// GenerateWeeklySummaryFromDailies rolls the daily summaries of the week starting on weekStart
+// (YYYY-MM-DD) up into a weekly narrative, stored as the "week" hierarchical summary for that
+// ISO week (YYYY-WXX). Days without a daily summary are left out. Like GenerateDailySummary,
+// user-edited summaries are kept and generation is skipped if no model is available.
+func (s *SummaryService) GenerateWeeklySummaryFromDailies(weekStart string) (*storage.HierarchicalSummary, error) {
+	start, err := time.ParseInLocation("2006-01-02", weekStart, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	year, week := start.ISOWeek()
+	periodDate := fmt.Sprintf("%d-W%02d", year, week)
+
+	existing, err := s.store.GetHierarchicalSummary("week", periodDate)
+	if err != nil {
+		return nil, err
+	}
+	if (existing != nil && existing.UserEdited) || !s.rollupReady() {
+		return existing, nil
+	}
+
+	var entries []string
+	for i := 0; i < 7; i++ {
+		day := start.AddDate(0, 0, i)
+		daily, err := s.store.GetHierarchicalSummary("day", day.Format("2006-01-02"))
+		if err != nil {
+			return nil, err
+		}
+		if daily != nil && strings.TrimSpace(daily.Summary) != "" {
+			entries = append(entries, fmt.Sprintf("%s: %s", day.Format("Monday, January 2"), strings.TrimSpace(daily.Summary)))
+		}
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("no daily summaries for the week of %s", weekStart)
+	}
+
+	prompt, err := s.buildPrompt(PromptWeeklySummary, rollupPromptData("each day of the week of "+start.Format("January 2, 2006"), entries))
+	if err != nil {
+		return nil, err
+	}
+	return s.saveRollup("week", periodDate, prompt)
+}
+
+// rollupReady reports whether a model is available to generate rollups.
+func (s *SummaryService) rollupReady() bool {
+	if s.completer == nil {
+		return false
+	}
+	if s.inference != nil {
+		return s.inference.GetSetupStatus().Ready
+	}
+	return true
+}
+
+// saveRollup sends a rollup prompt to the model and stores the response as the summary for a period.
+func (s *SummaryService) saveRollup(periodType, periodDate, prompt string) (*storage.HierarchicalSummary, error) {
+	response, err := s.completer.Complete(prompt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate %s summary: %w", periodType, err)
+	}
+	text := strings.TrimSpace(response)
+	if text == "" {
+		return nil, fmt.Errorf("model returned an empty %s summary", periodType)
+	}
+
+	hs := &storage.HierarchicalSummary{
+		PeriodType:  periodType,
+		PeriodDate:  periodDate,
+		Summary:     text,
+		GeneratedAt: time.Now().Unix(),
+	}
+	if _, err := s.store.SaveHierarchicalSummary(hs); err != nil {
+		return nil, err
+	}
+	return hs, nil
+}
+
+// rollupPromptData is the prompt data for combining lower-level summaries into one narrative.
+// subject describes what the entries summarize, e.g. "each day of the week of March 9, 2026".
+func rollupPromptData(subject string, entries []string) summaryPromptData {
+	var sb strings.Builder
+	for _, entry := range entries {
+		sb.WriteString("- " + entry + "\n")
+	}
+	return summaryPromptData{Subject: subject, Entries: sb.String()}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestGenerateDailySummary(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	completer := &mockCompleter{response: "  I spent the day on the report cache.\n"}
+	svc := NewSummaryService(store, nil)
+
+	day := time.Date(2026, time.March, 10, 9, 0, 0, 0, time.Local)
+	sessionID, _ := store.CreateSession(day.Unix())
+	store.EndSession(sessionID, day.Add(time.Hour).Unix())
+	store.SaveSummary(&storage.Summary{
+		SessionID: sql.NullInt64{Int64: sessionID, Valid: true},
+		Summary:   "Refactored the report cache",
+		Tags:      []string{"coding"},
+	})
+
+	// Skipped without a model
+	hs, err := svc.GenerateDailySummary("2026-03-10")
+	if err != nil || hs != nil {
+		t.Fatalf("expected generation to be skipped, got %+v, %v", hs, err)
+	}
+
+	svc.completer = completer
+	hs, err = svc.GenerateDailySummary("2026-03-10")
+	if err != nil {
+		t.Fatalf("GenerateDailySummary failed: %v", err)
+	}
+	if hs.PeriodType != "day" || hs.PeriodDate != "2026-03-10" || hs.Summary != "I spent the day on the report cache." {
+		t.Errorf("unexpected summary: %+v", hs)
+	}
+	if !strings.Contains(completer.prompt, "09:00 (1h): Refactored the report cache [coding]") {
+		t.Errorf("expected session summary in prompt, got:\n%s", completer.prompt)
+	}
+
+	// Regenerating updates the same row
+	completer.response = "A revised day."
+	again, err := svc.GenerateDailySummary("2026-03-10")
+	if err != nil {
+		t.Fatalf("GenerateDailySummary failed: %v", err)
+	}
+	if again.ID != hs.ID || again.Summary != "A revised day." {
+		t.Errorf("expected row %d to be updated, got %+v", hs.ID, again)
+	}
+
+	// User edits are kept
+	again.Summary = "My own words."
+	again.UserEdited = true
+	store.UpdateHierarchicalSummary(again)
+	kept, _ := svc.GenerateDailySummary("2026-03-10")
+	if kept.Summary != "My own words." || completer.calls != 2 {
+		t.Errorf("expected user-edited summary to be kept, got %+v after %d calls", kept, completer.calls)
+	}
+
+	if _, err := svc.GenerateDailySummary("2026-03-11"); err == nil {
+		t.Error("expected error for a day without session summaries")
+	}
+}
+
+func TestGenerateWeeklySummaryFromDailies(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	completer := &mockCompleter{response: "A productive week."}
+	svc := NewSummaryService(store, nil)
+	svc.completer = completer
+
+	store.SaveHierarchicalSummary(&storage.HierarchicalSummary{PeriodType: "day", PeriodDate: "2026-03-09", Summary: "Planned the sprint."})
+	store.SaveHierarchicalSummary(&storage.HierarchicalSummary{PeriodType: "day", PeriodDate: "2026-03-11", Summary: "Shipped the cache."})
+	store.SaveHierarchicalSummary(&storage.HierarchicalSummary{PeriodType: "day", PeriodDate: "2026-03-16", Summary: "Next week."})
+
+	hs, err := svc.GenerateWeeklySummaryFromDailies("2026-03-09")
+	if err != nil {
+		t.Fatalf("GenerateWeeklySummaryFromDailies failed: %v", err)
+	}
+	if hs.PeriodType != "week" || hs.PeriodDate != "2026-W11" || hs.Summary != "A productive week." {
+		t.Errorf("unexpected summary: %+v", hs)
+	}
+	for _, want := range []string{"Monday, March 9: Planned the sprint.", "Wednesday, March 11: Shipped the cache."} {
+		if !strings.Contains(completer.prompt, want) {
+			t.Errorf("expected %q in prompt, got:\n%s", want, completer.prompt)
+		}
+	}
+	if strings.Contains(completer.prompt, "Next week.") {
+		t.Error("expected days outside the week to be left out")
+	}
+
+	stored, _ := store.GetHierarchicalSummary("week", "2026-W11")
+	if stored == nil || stored.ID != hs.ID {
+		t.Errorf("expected weekly summary to be stored, got %+v", stored)
+	}
+
+	if _, err := svc.GenerateWeeklySummaryFromDailies("2026-04-06"); err == nil {
+		t.Error("expected error for a week without daily summaries")
+	}
+}
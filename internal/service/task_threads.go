@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// TaskThreadService groups sessions across days under long-running tasks.
+type TaskThreadService struct {
+	store    *storage.Store
+	timeline *TimelineService
+}
+
+// NewTaskThreadService creates a new TaskThreadService.
+func NewTaskThreadService(store *storage.Store, timeline *TimelineService) *TaskThreadService {
+	return &TaskThreadService{store: store, timeline: timeline}
+}
+
+// TaskThread is a task thread with the time spent on it.
+type TaskThread struct {
+	storage.TaskThread
+	TotalMinutes int64             `json:"totalMinutes"` // Active time across the thread's sessions
+	DaySpan      int               `json:"daySpan"`      // Calendar days from the first session to the last, 0 without sessions
+	Sessions     []*SessionSummary `json:"sessions"`     // Only set by GetThreadTimeline
+}
+
+// CreateThread creates an open task thread.
+func (s *TaskThreadService) CreateThread(name string) (int64, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return 0, fmt.Errorf("task thread name is required")
+	}
+	return s.store.CreateTaskThread(name)
+}
+
+// RenameThread changes a task thread's name.
+func (s *TaskThreadService) RenameThread(threadID int64, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return fmt.Errorf("task thread name is required")
+	}
+	return s.store.RenameTaskThread(threadID, name)
+}
+
+// AttachSession adds a session to an open task thread.
+func (s *TaskThreadService) AttachSession(threadID, sessionID int64) error {
+	thread, err := s.store.GetTaskThread(threadID)
+	if err != nil {
+		return err
+	}
+	if thread == nil {
+		return fmt.Errorf("task thread not found: %d", threadID)
+	}
+	if !thread.IsOpen {
+		return fmt.Errorf("task thread %q is closed", thread.Name)
+	}
+
+	sess, err := s.store.GetSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if sess == nil {
+		return fmt.Errorf("session not found: %d", sessionID)
+	}
+	return s.store.AttachSessionToTaskThread(threadID, sessionID)
+}
+
+// DetachSession removes a session from a task thread.
+func (s *TaskThreadService) DetachSession(threadID, sessionID int64) error {
+	return s.store.DetachSessionFromTaskThread(threadID, sessionID)
+}
+
+// CloseThread marks a task thread as done. Its sessions stay attached.
+func (s *TaskThreadService) CloseThread(threadID int64) error {
+	return s.store.CloseTaskThread(threadID)
+}
+
+// DeleteThread deletes a task thread, leaving its sessions in place.
+func (s *TaskThreadService) DeleteThread(threadID int64) error {
+	return s.store.DeleteTaskThread(threadID)
+}
+
+// GetThreads returns task threads with their time totals, newest first. Sessions are not
+// included; use GetThreadTimeline for a single thread's sessions.
+func (s *TaskThreadService) GetThreads(includeClosed bool) ([]*TaskThread, error) {
+	threads, err := s.store.GetTaskThreads(includeClosed)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*TaskThread, 0, len(threads))
+	for _, thread := range threads {
+		sessions, err := s.store.GetTaskThreadSessions(thread.ID)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, newTaskThread(thread, sessions))
+	}
+	return result, nil
+}
+
+// GetThreadTimeline returns a task thread with its sessions in chronological order, or nil
+// if it doesn't exist.
+func (s *TaskThreadService) GetThreadTimeline(threadID int64) (*TaskThread, error) {
+	thread, err := s.store.GetTaskThread(threadID)
+	if err != nil || thread == nil {
+		return nil, err
+	}
+	sessions, err := s.store.GetTaskThreadSessions(threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newTaskThread(thread, sessions)
+	for _, sess := range sessions {
+		result.Sessions = append(result.Sessions, s.timeline.sessionSummary(sess))
+	}
+	return result, nil
+}
+
+// newTaskThread totals the time and day span of a thread's sessions, which are ordered by start time.
+func newTaskThread(thread *storage.TaskThread, sessions []*storage.Session) *TaskThread {
+	result := &TaskThread{TaskThread: *thread, Sessions: []*SessionSummary{}}
+	if len(sessions) == 0 {
+		return result
+	}
+
+	result.TotalMinutes = sessionActiveSeconds(sessions, 0, math.MaxInt64) / 60
+
+	first := time.Unix(sessions[0].StartTime, 0)
+	last := first
+	for _, sess := range sessions {
+		end := time.Now()
+		if sess.EndTime.Valid {
+			end = time.Unix(sess.EndTime.Int64, 0)
+		}
+		if end.After(last) {
+			last = end
+		}
+	}
+	firstDay := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, time.Local)
+	lastDay := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, time.Local)
+	// Count calendar days rather than 24h periods, so DST changes don't shift the span
+	result.DaySpan = 1
+	for day := firstDay; day.Before(lastDay); day = day.AddDate(0, 0, 1) {
+		result.DaySpan++
+	}
+	return result
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskThreadService(t *testing.T) {
+	reports, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTaskThreadService(store, NewTimelineService(store))
+	session := func(start time.Time, minutes int) int64 {
+		t.Helper()
+		id, err := store.CreateSession(start.Unix())
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		store.EndSession(id, start.Add(time.Duration(minutes)*time.Minute).Unix())
+		return id
+	}
+
+	if _, err := svc.CreateThread("  "); err == nil {
+		t.Error("expected an error for an empty thread name")
+	}
+	threadID, err := svc.CreateThread("Implement OAuth feature")
+	if err != nil {
+		t.Fatalf("CreateThread failed: %v", err)
+	}
+
+	// Sessions on three consecutive days, attached out of order
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	first := session(today.AddDate(0, 0, -3).Add(10*time.Hour), 90)
+	second := session(today.AddDate(0, 0, -2).Add(14*time.Hour), 45)
+	third := session(today.AddDate(0, 0, -1).Add(9*time.Hour), 60)
+	for _, id := range []int64{third, first, second, first} {
+		if err := svc.AttachSession(threadID, id); err != nil {
+			t.Fatalf("AttachSession(%d) failed: %v", id, err)
+		}
+	}
+	if err := svc.AttachSession(threadID, 9999); err == nil {
+		t.Error("expected an error attaching a missing session")
+	}
+
+	thread, err := svc.GetThreadTimeline(threadID)
+	if err != nil {
+		t.Fatalf("GetThreadTimeline failed: %v", err)
+	}
+	if thread.Name != "Implement OAuth feature" || !thread.IsOpen {
+		t.Errorf("unexpected thread: %+v", thread.TaskThread)
+	}
+	if thread.TotalMinutes != 195 || thread.DaySpan != 3 {
+		t.Errorf("expected 195 minutes over 3 days, got %d over %d", thread.TotalMinutes, thread.DaySpan)
+	}
+	if len(thread.Sessions) != 3 || thread.Sessions[0].ID != first || thread.Sessions[2].ID != third {
+		t.Errorf("expected sessions %d, %d, %d in order, got %d sessions", first, second, third, len(thread.Sessions))
+	}
+
+	// Open threads are listed in the standup report
+	report, err := reports.GenerateReport("today", "standup", false)
+	if err != nil {
+		t.Fatalf("GenerateReport failed: %v", err)
+	}
+	if !strings.Contains(report.Content, "What I'm working on") ||
+		!strings.Contains(report.Content, "Implement OAuth feature") ||
+		!strings.Contains(report.Content, "3h 15m over 3 days") {
+		t.Error("expected the open thread in the standup report")
+	}
+
+	if err := svc.DetachSession(threadID, second); err != nil {
+		t.Fatalf("DetachSession failed: %v", err)
+	}
+	if err := svc.CloseThread(threadID); err != nil {
+		t.Fatalf("CloseThread failed: %v", err)
+	}
+	if err := svc.AttachSession(threadID, second); err == nil {
+		t.Error("expected an error attaching a session to a closed thread")
+	}
+
+	open, err := svc.GetThreads(false)
+	if err != nil {
+		t.Fatalf("GetThreads failed: %v", err)
+	}
+	if len(open) != 0 {
+		t.Errorf("expected no open threads, got %d", len(open))
+	}
+	all, err := svc.GetThreads(true)
+	if err != nil {
+		t.Fatalf("GetThreads failed: %v", err)
+	}
+	if len(all) != 1 || all[0].IsOpen || !all[0].ClosedAt.Valid || all[0].TotalMinutes != 150 || all[0].DaySpan != 3 {
+		t.Errorf("expected the closed thread with 150 minutes over 3 days, got %+v", all)
+	}
+
+	// Deleting a thread keeps its sessions
+	if err := svc.DeleteThread(threadID); err != nil {
+		t.Fatalf("DeleteThread failed: %v", err)
+	}
+	if thread, _ := svc.GetThreadTimeline(threadID); thread != nil {
+		t.Errorf("expected the thread to be deleted, got %+v", thread)
+	}
+	if sess, _ := store.GetSession(first); sess == nil {
+		t.Error("expected the thread's sessions to be kept")
+	}
+}
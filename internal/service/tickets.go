@@ -0,0 +1,200 @@
+package service
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"traq/internal/storage"
+)
+
+const (
+	// ticketMaxCommitGapMinutes is the longest gap between commits still counted as work
+	// on the later commit's tickets; longer gaps are assumed to span breaks.
+	ticketMaxCommitGapMinutes = 120
+	// ticketFirstCommitMinutes is the time credited for a commit with no recent predecessor.
+	ticketFirstCommitMinutes = 30
+)
+
+var (
+	// Jira keys are uppercase, e.g. "PROJ-123" or "https://acme.atlassian.net/browse/PROJ-123"
+	jiraTicketRe = regexp.MustCompile(`\b([A-Z][A-Z0-9]{1,9})-([1-9]\d*)\b`)
+	// Linear IDs look like Jira keys, so only links and lowercase branch names are recognized
+	linearURLRe    = regexp.MustCompile(`linear\.app/[\w-]+/issue/([A-Za-z][A-Za-z0-9]*)-([1-9]\d*)`)
+	linearBranchRe = regexp.MustCompile(`(?:^|/)([a-z][a-z0-9]*)-([1-9]\d*)(?:-|$)`)
+	githubURLRe    = regexp.MustCompile(`github\.com/([\w.-]+/[\w.-]+)/(?:issues|pull)/(\d+)`)
+	githubRefRe    = regexp.MustCompile(`(?:^|[\s(\[])([\w.-]+/[\w.-]+)?#(\d+)\b`)
+	gitlabURLRe    = regexp.MustCompile(`gitlab\.[\w.-]+/([\w./-]+?)/-/(issues|merge_requests)/(\d+)`)
+	gitlabMRRe     = regexp.MustCompile(`(?:^|[\s(\[])([\w.-]+/[\w.-]+)?!(\d+)\b`)
+
+	// nonTicketPrefixes look like Jira keys but are standards and encodings, e.g. "UTF-8".
+	nonTicketPrefixes = map[string]bool{
+		"UTF": true, "SHA": true, "ISO": true, "RFC": true, "CVE": true, "TLS": true,
+		"HTTP": true, "MD": true, "AES": true, "RSA": true, "ES": true, "GPT": true,
+	}
+)
+
+// TicketExtractor finds issue tracker tickets mentioned in commits.
+type TicketExtractor struct {
+	store *storage.Store
+}
+
+// NewTicketExtractor creates a new TicketExtractor.
+func NewTicketExtractor(store *storage.Store) *TicketExtractor {
+	return &TicketExtractor{store: store}
+}
+
+// ExtractFromCommit returns the tickets mentioned in a commit's message and branch name.
+// Bare uppercase keys like "ENG-42" are attributed to Jira; Linear tickets are recognized
+// from linear.app links and Linear-style branch names like "alice/eng-42-fix-login".
+func (e *TicketExtractor) ExtractFromCommit(commit *storage.GitCommit) ([]*storage.TicketReference, error) {
+	if commit == nil {
+		return nil, fmt.Errorf("commit is nil")
+	}
+
+	var refs []*storage.TicketReference
+	seen := make(map[string]bool)
+	add := func(system, ticketID, projectKey string) {
+		if seen[system+":"+ticketID] {
+			return
+		}
+		seen[system+":"+ticketID] = true
+		refs = append(refs, &storage.TicketReference{
+			CommitID:   commit.ID,
+			TicketID:   ticketID,
+			System:     system,
+			ProjectKey: projectKey,
+		})
+	}
+
+	message := commit.Message
+	if message == "" {
+		message = commit.MessageSubject
+	}
+	branch := ""
+	if commit.Branch.Valid {
+		branch = commit.Branch.String
+	}
+
+	// Linear first, so its IDs aren't also reported as Jira keys
+	linearIDs := make(map[string]bool)
+	for _, m := range linearURLRe.FindAllStringSubmatch(message, -1) {
+		key := strings.ToUpper(m[1])
+		linearIDs[key+"-"+m[2]] = true
+		add("linear", key+"-"+m[2], key)
+	}
+	for _, m := range linearBranchRe.FindAllStringSubmatch(branch, -1) {
+		key := strings.ToUpper(m[1])
+		linearIDs[key+"-"+m[2]] = true
+		add("linear", key+"-"+m[2], key)
+	}
+
+	for _, text := range []string{message, branch} {
+		for _, m := range jiraTicketRe.FindAllStringSubmatch(text, -1) {
+			if nonTicketPrefixes[m[1]] || linearIDs[m[0]] {
+				continue
+			}
+			add("jira", m[0], m[1])
+		}
+	}
+
+	for _, m := range githubURLRe.FindAllStringSubmatch(message, -1) {
+		add("github", m[1]+"#"+m[2], m[1])
+	}
+	for _, m := range githubRefRe.FindAllStringSubmatch(message, -1) {
+		add("github", m[1]+"#"+m[2], m[1])
+	}
+
+	for _, m := range gitlabURLRe.FindAllStringSubmatch(message, -1) {
+		sep := "#"
+		if m[2] == "merge_requests" {
+			sep = "!"
+		}
+		add("gitlab", m[1]+sep+m[3], m[1])
+	}
+	for _, m := range gitlabMRRe.FindAllStringSubmatch(message, -1) {
+		add("gitlab", m[1]+"!"+m[2], m[1])
+	}
+
+	return refs, nil
+}
+
+// RecordCommit saves the ticket references found in a saved commit.
+func (e *TicketExtractor) RecordCommit(commit *storage.GitCommit) error {
+	refs, err := e.ExtractFromCommit(commit)
+	if err != nil {
+		return err
+	}
+	for _, ref := range refs {
+		if _, err := e.store.SaveTicketReference(ref); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// TicketStat summarizes the commits and estimated time spent on a ticket.
+type TicketStat struct {
+	TicketID         string `json:"ticketId"`
+	System           string `json:"system"`
+	ProjectKey       string `json:"projectKey"`
+	CommitCount      int    `json:"commitCount"`
+	FirstCommit      int64  `json:"firstCommit"` // Unix timestamp
+	LastCommit       int64  `json:"lastCommit"`
+	EstimatedMinutes int64  `json:"estimatedMinutes"`
+}
+
+// GetTicketStats returns the tickets mentioned by commits in a time range with the time spent
+// on each, most time first. A commit's time is the gap since the previous commit in any
+// repository, up to two hours; a commit after a longer gap counts for 30 minutes. A commit
+// that mentions several tickets counts fully towards each.
+func (s *AnalyticsService) GetTicketStats(start, end int64) ([]*TicketStat, error) {
+	commits, err := s.store.GetGitCommitsByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+	refs, err := s.store.GetTicketReferencesByTimeRange(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	refsByCommit := make(map[int64][]*storage.TicketReference)
+	for _, ref := range refs {
+		refsByCommit[ref.CommitID] = append(refsByCommit[ref.CommitID], ref)
+	}
+
+	stats := []*TicketStat{}
+	byTicket := make(map[string]*TicketStat)
+	var prevTimestamp int64
+	for _, commit := range commits {
+		minutes := int64(ticketFirstCommitMinutes)
+		if gap := (commit.Timestamp - prevTimestamp) / 60; prevTimestamp > 0 && gap <= ticketMaxCommitGapMinutes {
+			minutes = gap
+		}
+		prevTimestamp = commit.Timestamp
+
+		for _, ref := range refsByCommit[commit.ID] {
+			key := ref.System + ":" + ref.TicketID
+			stat, ok := byTicket[key]
+			if !ok {
+				stat = &TicketStat{
+					TicketID:    ref.TicketID,
+					System:      ref.System,
+					ProjectKey:  ref.ProjectKey,
+					FirstCommit: commit.Timestamp,
+				}
+				byTicket[key] = stat
+				stats = append(stats, stat)
+			}
+			stat.CommitCount++
+			stat.LastCommit = commit.Timestamp
+			stat.EstimatedMinutes += minutes
+		}
+	}
+
+	sort.SliceStable(stats, func(i, j int) bool {
+		return stats[i].EstimatedMinutes > stats[j].EstimatedMinutes
+	})
+	return stats, nil
+}
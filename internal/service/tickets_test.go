@@ -0,0 +1,109 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"traq/internal/storage"
+)
+
+func TestTicketExtractor_ExtractFromCommit(t *testing.T) {
+	extractor := NewTicketExtractor(nil)
+
+	tests := []struct {
+		name    string
+		message string
+		branch  string
+		want    []string // system:ticketID
+	}{
+		{"jira key", "PROJ-123: fix login", "", []string{"jira:PROJ-123"}},
+		{"jira in branch", "fix login", "feature/PROJ-7-login", []string{"jira:PROJ-7"}},
+		{"encodings aren't tickets", "Read files as UTF-8 and hash with SHA-256", "", nil},
+		{"linear link", "Fix login\n\nhttps://linear.app/acme/issue/ENG-42/login", "", []string{"linear:ENG-42"}},
+		{"linear branch", "Fix login", "alice/eng-42-fix-login", []string{"linear:ENG-42"}},
+		{"github ref", "Fix crash (#12)", "", []string{"github:#12"}},
+		{"github repo ref", "Closes acme/web#5", "", []string{"github:acme/web#5"}},
+		{"github link", "See https://github.com/acme/web/issues/9", "", []string{"github:acme/web#9"}},
+		{"gitlab link", "See https://gitlab.com/acme/api/-/merge_requests/3", "", []string{"gitlab:acme/api!3"}},
+		{"gitlab mr ref", "Follow-up to !8", "", []string{"gitlab:!8"}},
+		{"several", "PROJ-1 and PROJ-2, PROJ-1 again (#4)", "", []string{"jira:PROJ-1", "jira:PROJ-2", "github:#4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit := &storage.GitCommit{ID: 1, Message: tt.message, Branch: storage.NullString(tt.branch)}
+			refs, err := extractor.ExtractFromCommit(commit)
+			if err != nil {
+				t.Fatalf("ExtractFromCommit failed: %v", err)
+			}
+			var got []string
+			for _, ref := range refs {
+				got = append(got, ref.System+":"+ref.TicketID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if _, err := extractor.ExtractFromCommit(nil); err == nil {
+		t.Error("expected error for nil commit")
+	}
+}
+
+func TestGetTicketStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewAnalyticsService(store)
+	extractor := NewTicketExtractor(store)
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/web", Name: "web", IsActive: true})
+	now := int64(1700000000)
+
+	commits := []struct {
+		offset  int64
+		message string
+	}{
+		{0, "PROJ-1: start login"},        // First commit: 30 minutes
+		{20 * 60, "PROJ-1: finish login"}, // 20 minutes after the previous commit
+		{50 * 60, "PROJ-2: tweak styles"}, // 30 minutes
+		{60 * 60, "Bump version"},         // No ticket
+		{5 * 3600, "PROJ-2: more styles"}, // After a long break: 30 minutes
+	}
+	for i, c := range commits {
+		commit := &storage.GitCommit{
+			Timestamp:      now + c.offset,
+			CommitHash:     "hash" + string(rune('0'+i)),
+			ShortHash:      "h" + string(rune('0'+i)),
+			RepositoryID:   repoID,
+			Message:        c.message,
+			MessageSubject: c.message,
+		}
+		id, err := store.SaveGitCommit(commit)
+		if err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+		commit.ID = id
+		if err := extractor.RecordCommit(commit); err != nil {
+			t.Fatalf("RecordCommit failed: %v", err)
+		}
+	}
+
+	stats, err := svc.GetTicketStats(now, now+6*3600)
+	if err != nil {
+		t.Fatalf("GetTicketStats failed: %v", err)
+	}
+	if len(stats) != 2 {
+		t.Fatalf("expected 2 tickets, got %d", len(stats))
+	}
+	if stats[0].TicketID != "PROJ-2" || stats[0].EstimatedMinutes != 60 || stats[0].CommitCount != 2 {
+		t.Errorf("unexpected stats for PROJ-2: %+v", stats[0])
+	}
+	if stats[1].TicketID != "PROJ-1" || stats[1].EstimatedMinutes != 50 || stats[1].LastCommit != now+20*60 {
+		t.Errorf("unexpected stats for PROJ-1: %+v", stats[1])
+	}
+
+	commitsForTicket, _ := store.GetCommitsByTicket("PROJ-2")
+	if len(commitsForTicket) != 2 {
+		t.Errorf("expected 2 commits for PROJ-2, got %d", len(commitsForTicket))
+	}
+}
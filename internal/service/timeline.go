@@ -9,7 +9,8 @@ import (
 
 // TimelineService provides timeline and session data.
 type TimelineService struct {
-	store *storage.Store
+	store        *storage.Store
+	heatmapCache yearlyHeatmapCache
 }
 
 // NewTimelineService creates a new TimelineService.
@@ -36,6 +37,12 @@ type SessionSummary struct {
 	HasBrowser      bool     `json:"hasBrowser"`
 	IsDraft         bool     `json:"isDraft"`     // True if this is an AI draft pending approval
 	DraftStatus     string   `json:"draftStatus"` // 'none', 'pending', 'accepted', 'rejected'
+
+	// Only set by GetActiveSession
+	IsActive              bool   `json:"isActive"`       // True for the session being tracked now
+	CurrentAppName        string `json:"currentAppName"` // Most recently focused app
+	CurrentWindowTitle    string `json:"currentWindowTitle"`
+	SessionElapsedSeconds int64  `json:"sessionElapsedSeconds"` // Time since StartTime
 }
 
 // ScreenshotDisplay is the service-layer type for screenshots with friendly app names.
@@ -48,6 +55,8 @@ type ScreenshotDisplay struct {
 	SessionID     int64  `json:"sessionId"`
 	MonitorWidth  int64  `json:"monitorWidth"`
 	MonitorHeight int64  `json:"monitorHeight"`
+	MonitorIndex  int    `json:"monitorIndex"`
+	IsMissing     bool   `json:"isMissing,omitempty"` // Placeholder for a filmstrip block with no screenshot
 }
 
 // toScreenshotDisplay converts a storage screenshot to a display screenshot with friendly app name.
@@ -56,9 +65,10 @@ func toScreenshotDisplay(s *storage.Screenshot) *ScreenshotDisplay {
 		return nil
 	}
 	d := &ScreenshotDisplay{
-		ID:        s.ID,
-		Timestamp: s.Timestamp,
-		Filepath:  s.Filepath,
+		ID:           s.ID,
+		Timestamp:    s.Timestamp,
+		Filepath:     s.Filepath,
+		MonitorIndex: s.MonitorIndex,
 	}
 	if s.WindowTitle.Valid {
 		d.WindowTitle = s.WindowTitle.String
@@ -107,6 +117,8 @@ type SessionContext struct {
 	GitCommits    []*storage.GitCommit         `json:"gitCommits"`
 	FileEvents    []*storage.FileEvent         `json:"fileEvents"`
 	BrowserVisits []*storage.BrowserVisit      `json:"browserVisits"`
+	AFKEvents     []*storage.AFKEvent          `json:"afkEvents"`
+	AFKSeconds    int64                        `json:"afkSeconds"` // AFK time within the session, part of its duration
 }
 
 // EntryBlock represents an activity with project assignment for the Entries lane
@@ -143,21 +155,7 @@ func (s *TimelineService) GetSessionsForDate(date string) ([]*SessionSummary, er
 
 	var summaries []*SessionSummary
 	for _, sess := range sessions {
-		summary := &SessionSummary{
-			ID:              sess.ID,
-			StartTime:       sess.StartTime,
-			ScreenshotCount: sess.ScreenshotCount,
-			IsOngoing:       !sess.EndTime.Valid,
-		}
-
-		if sess.EndTime.Valid {
-			endTime := sess.EndTime.Int64
-			summary.EndTime = &endTime
-		}
-		if sess.DurationSeconds.Valid {
-			duration := sess.DurationSeconds.Int64
-			summary.DurationSeconds = &duration
-		}
+		summary := s.sessionSummary(sess)
 
 		// Skip empty sessions (zero duration AND zero screenshots)
 		// These are sessions that were created but never captured any data
@@ -166,64 +164,113 @@ func (s *TimelineService) GetSessionsForDate(date string) ([]*SessionSummary, er
 			continue
 		}
 
-		// Get summary if exists
-		if sess.SummaryID.Valid {
-			sum, err := s.store.GetSummary(sess.SummaryID.Int64)
-			if err == nil && sum != nil {
-				summary.Summary = sum.Summary
-				if sum.Explanation.Valid {
-					summary.Explanation = sum.Explanation.String
-				}
-				if sum.Confidence.Valid {
-					summary.Confidence = sum.Confidence.String
-				}
-				summary.Tags = sum.Tags
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, nil
+}
+
+// sessionSummary builds the summary for a session, including its top apps and which
+// data sources have activity in it.
+func (s *TimelineService) sessionSummary(sess *storage.Session) *SessionSummary {
+	summary := &SessionSummary{
+		ID:              sess.ID,
+		StartTime:       sess.StartTime,
+		ScreenshotCount: sess.ScreenshotCount,
+		IsOngoing:       !sess.EndTime.Valid,
+	}
+
+	if sess.EndTime.Valid {
+		endTime := sess.EndTime.Int64
+		summary.EndTime = &endTime
+	}
+	if sess.DurationSeconds.Valid {
+		duration := sess.DurationSeconds.Int64
+		summary.DurationSeconds = &duration
+	}
+
+	// Get summary if exists
+	if sess.SummaryID.Valid {
+		sum, err := s.store.GetSummary(sess.SummaryID.Int64)
+		if err == nil && sum != nil {
+			summary.Summary = sum.Summary
+			if sum.Explanation.Valid {
+				summary.Explanation = sum.Explanation.String
+			}
+			if sum.Confidence.Valid {
+				summary.Confidence = sum.Confidence.String
 			}
+			summary.Tags = sum.Tags
 		}
+	}
 
-		// Get top apps from focus events (use friendly names, deduplicated)
-		focusEvents, _ := s.store.GetWindowFocusEventsBySession(sess.ID)
-		appDurations := make(map[string]float64)
-		for _, evt := range focusEvents {
-			friendlyName := GetFriendlyAppName(evt.AppName)
-			appDurations[friendlyName] += evt.DurationSeconds
-		}
-		// Sort by duration descending and take top 3
-		type appDur struct {
-			name     string
-			duration float64
-		}
-		appList := make([]appDur, 0, len(appDurations))
-		for app, dur := range appDurations {
-			appList = append(appList, appDur{app, dur})
-		}
-		sort.Slice(appList, func(i, j int) bool {
-			return appList[i].duration > appList[j].duration
-		})
-		for i, app := range appList {
-			if i >= 3 {
-				break
-			}
-			summary.TopApps = append(summary.TopApps, app.name)
+	// Get top apps from focus events (use friendly names, deduplicated)
+	focusEvents, _ := s.store.GetWindowFocusEventsBySession(sess.ID)
+	appDurations := make(map[string]float64)
+	for _, evt := range focusEvents {
+		friendlyName := GetFriendlyAppName(evt.AppName)
+		appDurations[friendlyName] += evt.DurationSeconds
+	}
+	// Sort by duration descending and take top 3
+	type appDur struct {
+		name     string
+		duration float64
+	}
+	appList := make([]appDur, 0, len(appDurations))
+	for app, dur := range appDurations {
+		appList = append(appList, appDur{app, dur})
+	}
+	sort.Slice(appList, func(i, j int) bool {
+		return appList[i].duration > appList[j].duration
+	})
+	for i, app := range appList {
+		if i >= 3 {
+			break
 		}
+		summary.TopApps = append(summary.TopApps, app.name)
+	}
 
-		// Check for data source presence
-		shellCmds, _ := s.store.GetShellCommandsBySession(sess.ID)
-		summary.HasShell = len(shellCmds) > 0
+	// Check for data source presence
+	shellCmds, _ := s.store.GetShellCommandsBySession(sess.ID)
+	summary.HasShell = len(shellCmds) > 0
 
-		gitCommits, _ := s.store.GetGitCommitsBySession(sess.ID)
-		summary.HasGit = len(gitCommits) > 0
+	gitCommits, _ := s.store.GetGitCommitsBySession(sess.ID)
+	summary.HasGit = len(gitCommits) > 0
 
-		fileEvents, _ := s.store.GetFileEventsBySession(sess.ID)
-		summary.HasFiles = len(fileEvents) > 0
+	fileEvents, _ := s.store.GetFileEventsBySession(sess.ID)
+	summary.HasFiles = len(fileEvents) > 0
 
-		browserVisits, _ := s.store.GetBrowserVisitsBySession(sess.ID)
-		summary.HasBrowser = len(browserVisits) > 0
+	browserVisits, _ := s.store.GetBrowserVisitsBySession(sess.ID)
+	summary.HasBrowser = len(browserVisits) > 0
 
-		summaries = append(summaries, summary)
+	return summary
+}
+
+// GetActiveSession returns the session being tracked now, or nil if there is none. If a crash
+// left several sessions open, the most recently started one is returned. The current app is
+// taken from the session's last recorded focus event.
+func (s *TimelineService) GetActiveSession() (*SessionSummary, error) {
+	sessions, err := s.store.GetOpenSessions()
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
 	}
+	sess := sessions[0]
 
-	return summaries, nil
+	summary := s.sessionSummary(sess)
+	summary.IsActive = true
+	summary.SessionElapsedSeconds = time.Now().Unix() - sess.StartTime
+
+	focusEvents, _ := s.store.GetFocusEventsBySession(sess.ID)
+	if len(focusEvents) > 0 {
+		last := focusEvents[len(focusEvents)-1]
+		summary.CurrentAppName = GetFriendlyAppName(last.AppName)
+		summary.CurrentWindowTitle = last.WindowTitle
+	}
+
+	return summary, nil
 }
 
 // GetScreenshotsForSession returns paginated screenshots for a session.
@@ -273,6 +320,7 @@ func (s *TimelineService) GetScreenshotsForSession(sessionID int64, page, perPag
 }
 
 // GetScreenshotsForHour returns screenshots for a specific hour with friendly app names.
+// Screenshots from every monitor are included, ordered by time and then monitor index.
 func (s *TimelineService) GetScreenshotsForHour(date string, hour int) ([]*ScreenshotDisplay, error) {
 	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
 	if err != nil {
@@ -340,9 +388,57 @@ func (s *TimelineService) GetSessionContext(sessionID int64) (*SessionContext, e
 	// Get browser visits
 	ctx.BrowserVisits, _ = s.store.GetBrowserVisitsBySession(sessionID)
 
+	// Get AFK events, counting only the time inside the session
+	ctx.AFKEvents, _ = s.store.GetAFKEventsBySession(sessionID)
+	sessionEnd := time.Now().Unix()
+	if session.EndTime.Valid {
+		sessionEnd = session.EndTime.Int64
+	}
+	for _, afk := range ctx.AFKEvents {
+		afkEnd := sessionEnd
+		if afk.EndTime.Valid {
+			afkEnd = min(afk.EndTime.Int64, sessionEnd)
+		}
+		ctx.AFKSeconds += max(0, afkEnd-max(afk.StartTime, session.StartTime))
+	}
+
 	return ctx, nil
 }
 
+// GetSessionContextBatch loads context for several sessions with one query per data
+// type instead of one GetSessionContext call per session. Only the summary, focus
+// events, shell commands and git commits are filled in; every requested session
+// gets an entry.
+func (s *TimelineService) GetSessionContextBatch(sessionIDs []int64) (map[int64]*SessionContext, error) {
+	summaries, err := s.store.GetSummariesForSessions(sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	focusEvents, err := s.store.GetWindowFocusEventsBySessions(sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	shellCommands, err := s.store.GetShellCommandsBySessions(sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+	gitCommits, err := s.store.GetGitCommitsBySessions(sessionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make(map[int64]*SessionContext, len(sessionIDs))
+	for _, id := range sessionIDs {
+		contexts[id] = &SessionContext{
+			Summary:       summaries[id],
+			FocusEvents:   focusEvents[id],
+			ShellCommands: shellCommands[id],
+			GitCommits:    gitCommits[id],
+		}
+	}
+	return contexts, nil
+}
+
 // GetRecentSessions returns the most recent sessions.
 func (s *TimelineService) GetRecentSessions(limit int) ([]*storage.Session, error) {
 	if limit < 1 || limit > 100 {
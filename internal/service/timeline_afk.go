@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	// afkBreakMinMinutes is the shortest AFK period counted as an intentional break.
+	afkBreakMinMinutes = 3
+	// afkLongBreakMinMinutes is the length at which a break counts as a long break.
+	afkLongBreakMinMinutes = 30
+)
+
+// AFKSummary describes the time spent away from the keyboard on a day.
+type AFKSummary struct {
+	TotalAFKMinutes   int64         `json:"totalAfkMinutes"`
+	LongestAFKMinutes int64         `json:"longestAfkMinutes"`
+	LongestAFKStart   int64         `json:"longestAfkStart"` // Unix timestamp, 0 if there were no AFK periods
+	AFKEvents         []*AFKBlock   `json:"afkEvents"`
+	Breaks            []*BreakEvent `json:"breaks"`     // AFK periods of 3-30 minutes
+	LongBreaks        []*BreakEvent `json:"longBreaks"` // AFK periods of 30 minutes or more
+}
+
+// BreakEvent is an AFK period long enough to count as a break.
+type BreakEvent struct {
+	Start           int64  `json:"start"`
+	End             int64  `json:"end"`
+	DurationMinutes int64  `json:"durationMinutes"`
+	TriggerType     string `json:"triggerType"`
+}
+
+// GetAFKSummary returns the away-time summary for a date (YYYY-MM-DD).
+func (s *TimelineService) GetAFKSummary(date string) (*AFKSummary, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+
+	dayStart := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+
+	afkEvents, err := s.store.GetAFKEventsByTimeRange(dayStart.Unix(), dayEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AFK events: %w", err)
+	}
+
+	blocks := buildAFKBlocks(afkEvents, dayStart.Unix(), dayEnd.Unix())
+	return summarizeAFKBlocks(flattenAFKBlocks(blocks)), nil
+}
+
+// summarizeAFKBlocks totals AFK blocks and classifies them into breaks and long breaks.
+func summarizeAFKBlocks(blocks []AFKBlock) *AFKSummary {
+	summary := &AFKSummary{
+		AFKEvents:  []*AFKBlock{},
+		Breaks:     []*BreakEvent{},
+		LongBreaks: []*BreakEvent{},
+	}
+
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].StartTime < blocks[j].StartTime
+	})
+
+	var totalSeconds, longestSeconds int64
+	for i := range blocks {
+		block := &blocks[i]
+		summary.AFKEvents = append(summary.AFKEvents, block)
+
+		seconds := block.EndTime - block.StartTime
+		totalSeconds += seconds
+		if seconds > longestSeconds {
+			longestSeconds = seconds
+			summary.LongestAFKStart = block.StartTime
+		}
+
+		minutes := seconds / 60
+		if minutes < afkBreakMinMinutes {
+			continue
+		}
+		brk := &BreakEvent{
+			Start:           block.StartTime,
+			End:             block.EndTime,
+			DurationMinutes: minutes,
+			TriggerType:     block.TriggerType,
+		}
+		if minutes >= afkLongBreakMinMinutes {
+			summary.LongBreaks = append(summary.LongBreaks, brk)
+		} else {
+			summary.Breaks = append(summary.Breaks, brk)
+		}
+	}
+
+	summary.TotalAFKMinutes = totalSeconds / 60
+	summary.LongestAFKMinutes = longestSeconds / 60
+	return summary
+}
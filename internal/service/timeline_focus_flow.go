@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// minFocusFlowTransitions is the fewest times a switch must happen in a day to be included
+// in FocusFlowData. One-off switches are noise in a Sankey diagram.
+const minFocusFlowTransitions = 2
+
+// FocusFlowData describes how focus moved between apps on a day.
+type FocusFlowData struct {
+	Transitions []AppTransition `json:"transitions"` // Most frequent first
+}
+
+// AppTransition is a switch from one app to another.
+type AppTransition struct {
+	FromApp              string  `json:"fromApp"`
+	ToApp                string  `json:"toApp"`
+	Count                int     `json:"count"`
+	TotalDurationSeconds float64 `json:"totalDurationSeconds"` // Time spent in ToApp after the switches
+}
+
+// GetFocusFlowData returns the app switches made on a date (YYYY-MM-DD) for a Sankey or
+// chord diagram. Switches made fewer than twice are left out.
+func (s *TimelineService) GetFocusFlowData(date string) (*FocusFlowData, error) {
+	dayStart, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	dayEnd := dayStart.AddDate(0, 0, 1).Add(-time.Second)
+
+	events, err := s.store.GetFocusEventsByTimeRange(dayStart.Unix(), dayEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+
+	flow := &FocusFlowData{Transitions: []AppTransition{}}
+	for _, t := range appTransitions(events, dayStart.Unix(), dayEnd.Unix()) {
+		if t.Count >= minFocusFlowTransitions {
+			flow.Transitions = append(flow.Transitions, t)
+		}
+	}
+	return flow, nil
+}
+
+// GetHourlyAppTransitions returns the app switches made during an hour (0-23) of a date,
+// for drilling into the focus flow. Every switch is included, however rare.
+func (s *TimelineService) GetHourlyAppTransitions(date string, hour int) ([]AppTransition, error) {
+	if hour < 0 || hour > 23 {
+		return nil, fmt.Errorf("invalid hour: %d", hour)
+	}
+	dayStart, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	hourStart := time.Date(dayStart.Year(), dayStart.Month(), dayStart.Day(), hour, 0, 0, 0, time.Local)
+	hourEnd := hourStart.Add(time.Hour - time.Second)
+
+	// Load from the start of the day so a switch early in the hour knows the app before it
+	events, err := s.store.GetFocusEventsByTimeRange(dayStart.Unix(), hourEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+
+	return appTransitions(events, hourStart.Unix(), hourEnd.Unix()), nil
+}
+
+// appTransitions counts switches between consecutive focus events (sorted by start time)
+// into an app that was focused in [start, end]. Window changes within the same app aren't
+// switches. Transitions are sorted by count, most frequent first.
+func appTransitions(events []*storage.WindowFocusEvent, start, end int64) []AppTransition {
+	type pair struct{ from, to string }
+	byPair := make(map[pair]*AppTransition)
+
+	for i := 1; i < len(events); i++ {
+		next := events[i]
+		if next.StartTime < start || next.StartTime > end {
+			continue
+		}
+		from, to := GetFriendlyAppName(events[i-1].AppName), GetFriendlyAppName(next.AppName)
+		if from == to {
+			continue
+		}
+
+		key := pair{from, to}
+		t, ok := byPair[key]
+		if !ok {
+			t = &AppTransition{FromApp: from, ToApp: to}
+			byPair[key] = t
+		}
+		t.Count++
+		t.TotalDurationSeconds += next.DurationSeconds
+	}
+
+	transitions := make([]AppTransition, 0, len(byPair))
+	for _, t := range byPair {
+		transitions = append(transitions, *t)
+	}
+	sort.Slice(transitions, func(i, j int) bool {
+		if transitions[i].Count != transitions[j].Count {
+			return transitions[i].Count > transitions[j].Count
+		}
+		if transitions[i].FromApp != transitions[j].FromApp {
+			return transitions[i].FromApp < transitions[j].FromApp
+		}
+		return transitions[i].ToApp < transitions[j].ToApp
+	})
+	return transitions
+}
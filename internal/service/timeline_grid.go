@@ -22,6 +22,7 @@ type TimelineGridData struct {
 	BrowserEvents    map[int][]BrowserEventDisplay             `json:"browserEvents"` // hour -> browser visits
 	AFKBlocks        map[int][]AFKBlock                        `json:"afkBlocks"` // hour -> AFK blocks
 	ActivityStates   []ActivityState                           `json:"activityStates"` // unified activity lane states
+	PinnedEvents     []storage.PinnedEvent                     `json:"pinnedEvents"`   // user-pinned highlights
 }
 
 // DayStats contains aggregated statistics for a day.
@@ -37,6 +38,15 @@ type DayStats struct {
 	DaySpan            *DaySpan                  `json:"daySpan"`            // First to last activity time
 	Breakdown          map[string]float64        `json:"breakdown"`          // category -> seconds
 	BreakdownPercent   map[string]float64        `json:"breakdownPercent"`   // category -> percentage
+	DeepWorkMinutes    int64                     `json:"deepWorkMinutes"`    // Total minutes in deep-work blocks (see GetDeepWorkBlocks)
+	AFKSummary         *AFKSummary               `json:"afkSummary"`         // Away-time breakdown (see GetAFKSummary)
+	CodeEditingStats   *CodeEditingStats         `json:"codeEditingStats"`   // Project file changes (see GetCodeEditingStats)
+
+	// Trends vs the previous day (percentage change, see percentChange)
+	FocusScoreTrend         float64 `json:"focusScoreTrend"`         // Change in LongestFocus
+	TotalSecondsTrend       float64 `json:"totalSecondsTrend"`       // Change in TotalSeconds
+	PreviousDayLongestFocus float64 `json:"previousDayLongestFocus"` // Previous day's longest focus seconds
+	PreviousDayTotalSeconds float64 `json:"previousDayTotalSeconds"` // Previous day's total focus seconds
 }
 
 // DaySpan represents the time range of activity in a day.
@@ -71,6 +81,7 @@ type ActivityBlock struct {
 	ProjectColor      string  `json:"projectColor,omitempty"`           // Project color for visual distinction
 	ProjectSource     string  `json:"projectSource,omitempty"`          // 'unassigned', 'user', 'rule', 'ai'
 	ProjectConfidence float64 `json:"projectConfidence,omitempty"`      // Confidence of project assignment (0-1)
+	Branch            string  `json:"branch,omitempty"`                 // Git branch checked out (IDE/terminal events only)
 }
 
 // SessionSummaryWithPosition extends SessionSummary with positioning info for the grid.
@@ -151,7 +162,7 @@ type AFKBlock struct {
 	StartTime       int64   `json:"startTime"`
 	EndTime         int64   `json:"endTime"`
 	DurationSeconds float64 `json:"durationSeconds"`
-	TriggerType     string  `json:"triggerType"`  // idle_timeout, system_sleep, manual
+	TriggerType     string  `json:"triggerType"`  // idle_timeout, screen_lock, display_off, sleep, manual
 	HourOffset      int     `json:"hourOffset"`   // Hour of day (0-23)
 	MinuteOffset    int     `json:"minuteOffset"` // Minute within hour (0-59)
 	PixelPosition   float64 `json:"pixelPosition"`// Vertical position in pixels (0-60)
@@ -176,6 +187,8 @@ type TimelineOptions struct {
 	MinDurationSeconds     int  // Filter out activities shorter than this duration (0 = no filter)
 	AppGrouping            bool // Whether to merge consecutive same-app activities
 	ContinuityMergeSeconds int  // Maximum gap in seconds between activities to still merge them
+
+	BrowserDomain string // Only include browser visits to this domain ("" = all domains)
 }
 
 // groupConsecutiveActivities merges consecutive activities from the same app.
@@ -354,6 +367,65 @@ func mergeActivePeriods(events []*storage.WindowFocusEvent, dayStart, dayEnd int
 	return merged
 }
 
+// buildAFKBlocks converts completed AFK events to grid blocks keyed by hour,
+// clipping each period to the [dayStart, dayEnd] range.
+func buildAFKBlocks(afkEvents []*storage.AFKEvent, dayStart, dayEnd int64) map[int][]AFKBlock {
+	afkBlocks := make(map[int][]AFKBlock)
+
+	for _, afk := range afkEvents {
+		// Skip if AFK event doesn't have an end time yet (ongoing)
+		if !afk.EndTime.Valid {
+			continue
+		}
+
+		// Clip AFK times to day boundaries
+		effectiveStart := afk.StartTime
+		if effectiveStart < dayStart {
+			effectiveStart = dayStart
+		}
+
+		effectiveEnd := afk.EndTime.Int64
+		if effectiveEnd > dayEnd {
+			effectiveEnd = dayEnd
+		}
+
+		// Skip if AFK doesn't overlap with this day after clipping
+		if effectiveStart >= effectiveEnd {
+			continue
+		}
+
+		startTime := time.Unix(effectiveStart, 0).In(time.Local)
+		hour := startTime.Hour()
+		minute := startTime.Minute()
+		pixelPosition := (float64(minute) / 60.0) * 60.0
+
+		// Use clipped duration for display
+		durationSeconds := float64(effectiveEnd - effectiveStart)
+		pixelHeight := (durationSeconds / 3600.0) * 60.0
+
+		// Ensure minimum visibility (4px)
+		if pixelHeight < 4 {
+			pixelHeight = 4
+		}
+
+		block := AFKBlock{
+			ID:              afk.ID,
+			StartTime:       effectiveStart,
+			EndTime:         effectiveEnd,
+			DurationSeconds: durationSeconds,
+			TriggerType:     afk.TriggerType,
+			HourOffset:      hour,
+			MinuteOffset:    minute,
+			PixelPosition:   pixelPosition,
+			PixelHeight:     pixelHeight,
+		}
+
+		afkBlocks[hour] = append(afkBlocks[hour], block)
+	}
+
+	return afkBlocks
+}
+
 // flattenAFKBlocks converts the hour-keyed AFKBlocks map to a flat slice.
 func flattenAFKBlocks(afkBlocks map[int][]AFKBlock) []AFKBlock {
 	var result []AFKBlock
@@ -373,6 +445,9 @@ type WeekTimelineData struct {
 	EndDate   string            `json:"endDate"`   // Sunday of the week (YYYY-MM-DD)
 	Days      []*WeekDayData    `json:"days"`      // 7 days (Mon-Sun)
 	WeekStats *WeekSummaryStats `json:"weekStats"` // Aggregated weekly stats
+
+	GitEvents   map[string][]GitEventDisplay   `json:"gitEvents"`   // date -> commits
+	ShellEvents map[string][]ShellEventDisplay `json:"shellEvents"` // date -> key shell commands
 }
 
 // WeekDayData represents a single day in the week view.
@@ -386,8 +461,13 @@ type WeekDayData struct {
 	HasAISummary      bool               `json:"hasAiSummary"`
 	ScreenshotCount   int64              `json:"screenshotCount"`
 	CategoryBreakdown map[string]float64 `json:"categoryBreakdown"` // category -> hours
+	CommitCount       int                `json:"commitCount"`
+	KeyCommandCount   int                `json:"keyCommandCount"` // Long-running or failed shell commands
 }
 
+// keyCommandMinSeconds is the duration above which a shell command is shown in the week view.
+const keyCommandMinSeconds = 5
+
 // WeekTimeBlock represents a 30-minute block in the week view.
 type WeekTimeBlock struct {
 	BlockIndex       int     `json:"blockIndex"`       // 0-47 (0 = 00:00-00:30, 47 = 23:30-00:00)
@@ -548,6 +628,7 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 			MinuteOffset:    minute,
 			PixelPosition:   pixelPosition,
 			PixelHeight:     pixelHeight,
+			Branch:          event.CurrentBranch.String,
 		}
 
 		// Populate project fields if event has a project assignment
@@ -701,49 +782,8 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 	}
 
 	for _, commit := range gitCommits {
-		commitTime := time.Unix(commit.Timestamp, 0).In(time.Local)
-		hour := commitTime.Hour()
-		minute := commitTime.Minute()
-		pixelPosition := (float64(minute) / 60.0) * 60.0
-
-		// Get repository name or use "Unknown" if not found
-		repoName := repoMap[commit.RepositoryID]
-		if repoName == "" {
-			repoName = "Unknown"
-		}
-
-		// Get branch name
-		branch := "main"
-		if commit.Branch.Valid && commit.Branch.String != "" {
-			branch = commit.Branch.String
-		}
-
-		// Get insertions and deletions
-		insertions := int64(0)
-		if commit.Insertions.Valid {
-			insertions = commit.Insertions.Int64
-		}
-		deletions := int64(0)
-		if commit.Deletions.Valid {
-			deletions = commit.Deletions.Int64
-		}
-
-		gitEvent := GitEventDisplay{
-			ID:             commit.ID,
-			Timestamp:      commit.Timestamp,
-			Message:        commit.Message,
-			MessageSubject: commit.MessageSubject,
-			ShortHash:      commit.ShortHash,
-			Repository:     repoName,
-			Branch:         branch,
-			Insertions:     insertions,
-			Deletions:      deletions,
-			HourOffset:     hour,
-			MinuteOffset:   minute,
-			PixelPosition:  pixelPosition,
-		}
-
-		gitEvents[hour] = append(gitEvents[hour], gitEvent)
+		gitEvent := newGitEventDisplay(commit, repoMap)
+		gitEvents[gitEvent.HourOffset] = append(gitEvents[gitEvent.HourOffset], gitEvent)
 	}
 
 	// Fetch shell commands for the day
@@ -757,43 +797,8 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 	shellEvents := make(map[int][]ShellEventDisplay)
 
 	for _, cmd := range shellCommands {
-		cmdTime := time.Unix(cmd.Timestamp, 0).In(time.Local)
-		hour := cmdTime.Hour()
-		minute := cmdTime.Minute()
-		pixelPosition := (float64(minute) / 60.0) * 60.0
-
-		// Get working directory or use empty string
-		workingDir := ""
-		if cmd.WorkingDirectory.Valid {
-			workingDir = cmd.WorkingDirectory.String
-		}
-
-		// Get exit code or use 0
-		exitCode := int64(0)
-		if cmd.ExitCode.Valid {
-			exitCode = cmd.ExitCode.Int64
-		}
-
-		// Get duration or use 0
-		duration := 0.0
-		if cmd.DurationSeconds.Valid {
-			duration = cmd.DurationSeconds.Float64
-		}
-
-		shellEvent := ShellEventDisplay{
-			ID:               cmd.ID,
-			Timestamp:        cmd.Timestamp,
-			Command:          cmd.Command,
-			ShellType:        cmd.ShellType,
-			WorkingDirectory: workingDir,
-			ExitCode:         exitCode,
-			DurationSeconds:  duration,
-			HourOffset:       hour,
-			MinuteOffset:     minute,
-			PixelPosition:    pixelPosition,
-		}
-
-		shellEvents[hour] = append(shellEvents[hour], shellEvent)
+		shellEvent := newShellEventDisplay(cmd)
+		shellEvents[shellEvent.HourOffset] = append(shellEvents[shellEvent.HourOffset], shellEvent)
 	}
 
 	// Fetch file events for the day
@@ -802,6 +807,7 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 		// Non-fatal: log and continue with empty file events
 		fileEvents_ = []*storage.FileEvent{}
 	}
+	dayStats.CodeEditingStats = summarizeCodeEditing(fileEvents_)
 
 	// Build file events map: hour -> events
 	fileEvents := make(map[int][]FileEventDisplay)
@@ -864,6 +870,9 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 	browserEvents := make(map[int][]BrowserEventDisplay)
 
 	for _, visit := range browserVisits_ {
+		if opts.BrowserDomain != "" && visit.Domain != opts.BrowserDomain {
+			continue
+		}
 		visitTime := time.Unix(visit.Timestamp, 0).In(time.Local)
 		hour := visitTime.Hour()
 		minute := visitTime.Minute()
@@ -906,62 +915,20 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 	}
 
 	// Build AFK blocks map: hour -> blocks
-	afkBlocks := make(map[int][]AFKBlock)
-
-	for _, afk := range afkEvents {
-		// Skip if AFK event doesn't have an end time yet (ongoing)
-		if !afk.EndTime.Valid {
-			continue
-		}
-
-		// Clip AFK times to day boundaries
-		effectiveStart := afk.StartTime
-		if effectiveStart < dayStart.Unix() {
-			effectiveStart = dayStart.Unix()
-		}
-
-		effectiveEnd := afk.EndTime.Int64
-		if effectiveEnd > dayEnd.Unix() {
-			effectiveEnd = dayEnd.Unix()
-		}
-
-		// Skip if AFK doesn't overlap with this day after clipping
-		if effectiveStart >= effectiveEnd {
-			continue
-		}
-
-		startTime := time.Unix(effectiveStart, 0).In(time.Local)
-		hour := startTime.Hour()
-		minute := startTime.Minute()
-		pixelPosition := (float64(minute) / 60.0) * 60.0
+	afkBlocks := buildAFKBlocks(afkEvents, dayStart.Unix(), dayEnd.Unix())
+	dayStats.AFKSummary = summarizeAFKBlocks(flattenAFKBlocks(afkBlocks))
 
-		// Use clipped duration for display
-		durationSeconds := float64(effectiveEnd - effectiveStart)
-		pixelHeight := (durationSeconds / 3600.0) * 60.0
+	// Calculate activity states for the unified Activity lane
+	activityStates := s.calculateActivityStates(focusEvents, flattenAFKBlocks(afkBlocks), dayStart.Unix(), dayEnd.Unix())
 
-		// Ensure minimum visibility (4px)
-		if pixelHeight < 4 {
-			pixelHeight = 4
+	// Fetch pinned events for the day
+	pinnedEvents := []storage.PinnedEvent{}
+	if pins, err := s.store.GetPinnedEvents(dayStart.Unix(), dayEnd.Unix()); err == nil {
+		for _, pin := range pins {
+			pinnedEvents = append(pinnedEvents, *pin)
 		}
-
-		block := AFKBlock{
-			ID:              afk.ID,
-			StartTime:       effectiveStart,
-			EndTime:         effectiveEnd,
-			DurationSeconds: durationSeconds,
-			TriggerType:     afk.TriggerType,
-			HourOffset:      hour,
-			MinuteOffset:    minute,
-			PixelPosition:   pixelPosition,
-			PixelHeight:     pixelHeight,
-		}
-
-		afkBlocks[hour] = append(afkBlocks[hour], block)
 	}
 
-	// Calculate activity states for the unified Activity lane
-	activityStates := s.calculateActivityStates(focusEvents, flattenAFKBlocks(afkBlocks), dayStart.Unix(), dayEnd.Unix())
-
 	return &TimelineGridData{
 		Date:             date,
 		DayStats:         dayStats,
@@ -975,6 +942,7 @@ func (s *TimelineService) GetTimelineGridDataWithOptions(date string, opts Timel
 		BrowserEvents:    browserEvents,
 		AFKBlocks:        afkBlocks,
 		ActivityStates:   activityStates,
+		PinnedEvents:     pinnedEvents,
 	}, nil
 }
 
@@ -1007,8 +975,15 @@ func (s *TimelineService) getSessionDominantCategory(sessionID int64, categories
 	return dominantCategory
 }
 
-// calculateDayStats computes aggregated statistics for the day.
+// calculateDayStats computes aggregated statistics for the day, with trends against the previous day.
 func (s *TimelineService) calculateDayStats(focusEvents []*storage.WindowFocusEvent, categories map[string]string, dayStart, dayEnd time.Time) *DayStats {
+	stats := s.computeDayStats(focusEvents, categories, dayStart, dayEnd)
+	s.applyDayStatsTrend(stats, dayStart)
+	return stats
+}
+
+// computeDayStats computes aggregated statistics for a single day.
+func (s *TimelineService) computeDayStats(focusEvents []*storage.WindowFocusEvent, categories map[string]string, dayStart, dayEnd time.Time) *DayStats {
 	if len(focusEvents) == 0 {
 		return &DayStats{
 			TotalSeconds:       0,
@@ -1258,6 +1233,12 @@ func (s *TimelineService) calculateDayStats(focusEvents []*storage.WindowFocusEv
 		}
 	}
 
+	// Sum deep-work blocks (focus-category runs with few context switches)
+	var deepWorkMinutes int64
+	for _, block := range detectDeepWorkBlocks(sortedEvents, categories, defaultDeepWorkMinutes, dayStartUnix, dayEndUnix) {
+		deepWorkMinutes += block.DurationMinutes
+	}
+
 	return &DayStats{
 		TotalSeconds:       totalSeconds,
 		TotalHours:         totalSeconds / 3600.0,
@@ -1270,7 +1251,27 @@ func (s *TimelineService) calculateDayStats(focusEvents []*storage.WindowFocusEv
 		DaySpan:            daySpan,
 		Breakdown:          breakdown,
 		BreakdownPercent:   breakdownPercent,
+		DeepWorkMinutes:    deepWorkMinutes,
+	}
+}
+
+// applyDayStatsTrend fills in the previous day's totals and the percentage change against them.
+// The previous day goes through computeDayStats too, so both days get the same AFK filtering and clamping.
+func (s *TimelineService) applyDayStatsTrend(stats *DayStats, dayStart time.Time) {
+	prevStart := dayStart.AddDate(0, 0, -1)
+	prevEnd := dayStart.Add(-time.Second)
+	prevEvents, err := s.store.GetFocusEventsByTimeRange(prevStart.Unix(), prevEnd.Unix())
+	if err != nil {
+		return
 	}
+
+	// Categories only shape the breakdown, which the trend doesn't use
+	prev := s.computeDayStats(prevEvents, nil, prevStart, prevEnd)
+
+	stats.PreviousDayTotalSeconds = prev.TotalSeconds
+	stats.PreviousDayLongestFocus = prev.LongestFocus
+	stats.TotalSecondsTrend = percentChange(int64(stats.TotalSeconds-prev.TotalSeconds), int64(prev.TotalSeconds))
+	stats.FocusScoreTrend = percentChange(int64(stats.LongestFocus-prev.LongestFocus), int64(prev.LongestFocus))
 }
 
 // ============================================================================
@@ -1320,6 +1321,37 @@ func (s *TimelineService) GetWeekTimelineData(startDate string) (*WeekTimelineDa
 		return nil, fmt.Errorf("failed to fetch app categories: %w", err)
 	}
 
+	// Fetch git commits and key shell commands for the week, grouped by day
+	gitEvents := make(map[string][]GitEventDisplay)
+	gitCommits, err := s.store.GetGitCommitsByTimeRange(weekStart.Unix(), weekEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch git commits: %w", err)
+	}
+	if len(gitCommits) > 0 {
+		repoMap := make(map[int64]string)
+		repos, _ := s.store.GetActiveGitRepositories()
+		for _, repo := range repos {
+			repoMap[repo.ID] = repo.Name
+		}
+		for _, commit := range gitCommits {
+			day := time.Unix(commit.Timestamp, 0).In(time.Local).Format("2006-01-02")
+			gitEvents[day] = append(gitEvents[day], newGitEventDisplay(commit, repoMap))
+		}
+	}
+
+	shellEvents := make(map[string][]ShellEventDisplay)
+	shellCommands, err := s.store.GetShellCommandsByTimeRange(weekStart.Unix(), weekEnd.Unix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch shell commands: %w", err)
+	}
+	for _, cmd := range shellCommands {
+		if !isKeyShellCommand(cmd) {
+			continue
+		}
+		day := time.Unix(cmd.Timestamp, 0).In(time.Local).Format("2006-01-02")
+		shellEvents[day] = append(shellEvents[day], newShellEventDisplay(cmd))
+	}
+
 	// Get today's date for comparison
 	today := time.Now().In(time.Local)
 	todayStr := today.Format("2006-01-02")
@@ -1504,6 +1536,8 @@ func (s *TimelineService) GetWeekTimelineData(startDate string) (*WeekTimelineDa
 			HasAISummary:      hasAISummary,
 			ScreenshotCount:   screenshotCount,
 			CategoryBreakdown: dayCategoryHours,
+			CommitCount:       len(gitEvents[dayStr]),
+			KeyCommandCount:   len(shellEvents[dayStr]),
 		}
 	}
 
@@ -1532,9 +1566,73 @@ func (s *TimelineService) GetWeekTimelineData(startDate string) (*WeekTimelineDa
 		EndDate:   sunday.Format("2006-01-02"),
 		Days:      days,
 		WeekStats: weekStats,
+
+		GitEvents:   gitEvents,
+		ShellEvents: shellEvents,
 	}, nil
 }
 
+// newGitEventDisplay converts a git commit for timeline display. repoNames maps repository IDs to names.
+func newGitEventDisplay(commit *storage.GitCommit, repoNames map[int64]string) GitEventDisplay {
+	commitTime := time.Unix(commit.Timestamp, 0).In(time.Local)
+	minute := commitTime.Minute()
+
+	// Get repository name or use "Unknown" if not found
+	repoName := repoNames[commit.RepositoryID]
+	if repoName == "" {
+		repoName = "Unknown"
+	}
+
+	// Get branch name
+	branch := "main"
+	if commit.Branch.Valid && commit.Branch.String != "" {
+		branch = commit.Branch.String
+	}
+
+	return GitEventDisplay{
+		ID:             commit.ID,
+		Timestamp:      commit.Timestamp,
+		Message:        commit.Message,
+		MessageSubject: commit.MessageSubject,
+		ShortHash:      commit.ShortHash,
+		Repository:     repoName,
+		Branch:         branch,
+		Insertions:     commit.Insertions.Int64,
+		Deletions:      commit.Deletions.Int64,
+		HourOffset:     commitTime.Hour(),
+		MinuteOffset:   minute,
+		PixelPosition:  (float64(minute) / 60.0) * 60.0,
+	}
+}
+
+// newShellEventDisplay converts a shell command for timeline display.
+func newShellEventDisplay(cmd *storage.ShellCommand) ShellEventDisplay {
+	cmdTime := time.Unix(cmd.Timestamp, 0).In(time.Local)
+	minute := cmdTime.Minute()
+
+	return ShellEventDisplay{
+		ID:               cmd.ID,
+		Timestamp:        cmd.Timestamp,
+		Command:          cmd.Command,
+		ShellType:        cmd.ShellType,
+		WorkingDirectory: cmd.WorkingDirectory.String,
+		ExitCode:         cmd.ExitCode.Int64,
+		DurationSeconds:  cmd.DurationSeconds.Float64,
+		HourOffset:       cmdTime.Hour(),
+		MinuteOffset:     minute,
+		PixelPosition:    (float64(minute) / 60.0) * 60.0,
+	}
+}
+
+// isKeyShellCommand reports whether a shell command is notable enough for the week view:
+// long-running or failed.
+func isKeyShellCommand(cmd *storage.ShellCommand) bool {
+	if cmd.DurationSeconds.Valid && cmd.DurationSeconds.Float64 > keyCommandMinSeconds {
+		return true
+	}
+	return cmd.ExitCode.Valid && cmd.ExitCode.Int64 != 0
+}
+
 // filterEventsByDay returns focus events that overlap with the given day.
 func filterEventsByDay(events []*storage.WindowFocusEvent, dayStart, dayEnd int64) []*storage.WindowFocusEvent {
 	var result []*storage.WindowFocusEvent
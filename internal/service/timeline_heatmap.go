@@ -0,0 +1,225 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// yearlyHeatmapCacheTTL is how long a yearly heatmap is reused.
+// Historical year data rarely changes, so an hour of staleness is acceptable.
+const yearlyHeatmapCacheTTL = time.Hour
+
+// YearlyHeatmap contains per-day activity for a GitHub-style contribution graph.
+type YearlyHeatmap struct {
+	Year         int           `json:"year"`
+	Days         []*HeatmapDay `json:"days"`         // One entry per day of the year (365 or 366)
+	MaxIntensity int           `json:"maxIntensity"` // Highest intensity of any day (0-4)
+}
+
+// HeatmapDay represents activity for a single day in the yearly heatmap.
+type HeatmapDay struct {
+	Date          string `json:"date"` // YYYY-MM-DD
+	ActiveMinutes int64  `json:"activeMinutes"`
+	Commits       int    `json:"commits"`
+	Screenshots   int64  `json:"screenshots"`
+	Intensity     int    `json:"intensity"` // 0-4, scaled to the most active day of the year
+}
+
+// yearlyHeatmapCache holds computed heatmaps keyed by year.
+type yearlyHeatmapCache struct {
+	mu      sync.Mutex
+	entries map[int]*yearlyHeatmapEntry
+}
+
+type yearlyHeatmapEntry struct {
+	heatmap   *YearlyHeatmap
+	expiresAt time.Time
+}
+
+// GetActivityHeatmapForYear returns per-day activity for a calendar year.
+// Data is loaded with one grouped query per source (focus events, commits, screenshots)
+// and cached for an hour.
+func (s *TimelineService) GetActivityHeatmapForYear(year int) (*YearlyHeatmap, error) {
+	if year < 1970 || year > 9999 {
+		return nil, fmt.Errorf("invalid year: %d", year)
+	}
+
+	s.heatmapCache.mu.Lock()
+	if entry, ok := s.heatmapCache.entries[year]; ok && time.Now().Before(entry.expiresAt) {
+		s.heatmapCache.mu.Unlock()
+		return entry.heatmap, nil
+	}
+	s.heatmapCache.mu.Unlock()
+
+	heatmap, err := s.buildActivityHeatmapForYear(year)
+	if err != nil {
+		return nil, err
+	}
+
+	s.heatmapCache.mu.Lock()
+	if s.heatmapCache.entries == nil {
+		s.heatmapCache.entries = make(map[int]*yearlyHeatmapEntry)
+	}
+	s.heatmapCache.entries[year] = &yearlyHeatmapEntry{
+		heatmap:   heatmap,
+		expiresAt: time.Now().Add(yearlyHeatmapCacheTTL),
+	}
+	s.heatmapCache.mu.Unlock()
+
+	return heatmap, nil
+}
+
+// buildActivityHeatmapForYear queries and assembles the heatmap for a year.
+func (s *TimelineService) buildActivityHeatmapForYear(year int) (*YearlyHeatmap, error) {
+	yearStart := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	yearEnd := yearStart.AddDate(1, 0, 0)
+	start, end := yearStart.Unix(), yearEnd.Unix()-1
+
+	focusSeconds, err := s.store.GetFocusSecondsByDay(start, end)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := s.store.CountGitCommitsByDay(start, end)
+	if err != nil {
+		return nil, err
+	}
+	screenshots, err := s.store.CountScreenshotsByDay(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	heatmap := &YearlyHeatmap{Year: year}
+	var maxMinutes int64
+	for day := yearStart; day.Before(yearEnd); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		hd := &HeatmapDay{
+			Date:          date,
+			ActiveMinutes: int64(focusSeconds[date] / 60),
+			Commits:       commits[date],
+			Screenshots:   screenshots[date],
+		}
+		if hd.ActiveMinutes > maxMinutes {
+			maxMinutes = hd.ActiveMinutes
+		}
+		heatmap.Days = append(heatmap.Days, hd)
+	}
+
+	// Calculate intensity (0-4) based on active minutes, same scale as the calendar heatmap
+	for _, day := range heatmap.Days {
+		if maxMinutes > 0 {
+			day.Intensity = int(float64(day.ActiveMinutes) / float64(maxMinutes) * 4)
+		}
+		// Any recorded activity should be visible, even if it rounds down to zero
+		if day.Intensity == 0 && (day.ActiveMinutes > 0 || day.Commits > 0 || day.Screenshots > 0) {
+			day.Intensity = 1
+		}
+		if day.Intensity > heatmap.MaxIntensity {
+			heatmap.MaxIntensity = day.Intensity
+		}
+	}
+
+	return heatmap, nil
+}
+
+// ProductivityHeatmap shows how productive each hour of a week was.
+type ProductivityHeatmap struct {
+	WeekStart string          `json:"weekStart"` // Monday, YYYY-MM-DD
+	Cells     [][]HeatmapCell `json:"cells"`     // 7 days (Monday first) x 24 hours
+	MaxScore  float64         `json:"maxScore"`  // Highest score of any cell, 0 if there's no data
+}
+
+// HeatmapCell is one hour of one day in a productivity heatmap.
+type HeatmapCell struct {
+	DayIndex      int     `json:"dayIndex"` // 0 is Monday
+	Hour          int     `json:"hour"`
+	Score         float64 `json:"score"` // Percentage of active time in "focus" apps, -1 if there's no data
+	ActiveMinutes float64 `json:"activeMinutes"`
+	IsAFK         bool    `json:"isAfk"` // More of the hour was spent away than active
+}
+
+// GetProductivityHeatmap returns a day x hour productivity matrix for the Monday-Sunday
+// week containing weekStart (YYYY-MM-DD). Each cell's score is the share of its active
+// time spent in apps categorized as "focus".
+func (s *TimelineService) GetProductivityHeatmap(weekStart string) (*ProductivityHeatmap, error) {
+	t, err := time.ParseInLocation("2006-01-02", weekStart, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	monday, nextMonday := goalPeriodBounds("weekly", t)
+	start, end := monday.Unix(), nextMonday.Unix()-1
+
+	focusEvents, err := s.store.GetFocusEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+	appNames := make(map[string]bool)
+	for _, evt := range focusEvents {
+		appNames[evt.AppName] = true
+	}
+	appNamesList := make([]string, 0, len(appNames))
+	for name := range appNames {
+		appNamesList = append(appNamesList, name)
+	}
+	categories, err := s.store.GetAppTimelineCategories(appNamesList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app categories: %w", err)
+	}
+	afkEvents, err := s.store.GetAFKEventsByTimeRange(start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AFK events: %w", err)
+	}
+
+	var activeSeconds, focusSeconds, afkSeconds [7][24]float64
+	for _, evt := range focusEvents {
+		from, to := max(evt.StartTime, start), min(evt.EndTime, end+1)
+		addSecondsByDayHour(&activeSeconds, from, to)
+		if categories[evt.AppName] == "focus" {
+			addSecondsByDayHour(&focusSeconds, from, to)
+		}
+	}
+	for _, afk := range afkEvents {
+		afkEnd := time.Now().Unix() // Ongoing
+		if afk.EndTime.Valid {
+			afkEnd = afk.EndTime.Int64
+		}
+		addSecondsByDayHour(&afkSeconds, max(afk.StartTime, start), min(afkEnd, end+1))
+	}
+
+	heatmap := &ProductivityHeatmap{
+		WeekStart: monday.Format("2006-01-02"),
+		Cells:     make([][]HeatmapCell, 7),
+	}
+	for day := range heatmap.Cells {
+		heatmap.Cells[day] = make([]HeatmapCell, 24)
+		for hour := range heatmap.Cells[day] {
+			cell := HeatmapCell{
+				DayIndex:      day,
+				Hour:          hour,
+				Score:         -1,
+				ActiveMinutes: activeSeconds[day][hour] / 60,
+				IsAFK:         afkSeconds[day][hour] > activeSeconds[day][hour],
+			}
+			if activeSeconds[day][hour] > 0 {
+				cell.Score = focusSeconds[day][hour] / activeSeconds[day][hour] * 100
+				heatmap.MaxScore = max(heatmap.MaxScore, cell.Score)
+			}
+			heatmap.Cells[day][hour] = cell
+		}
+	}
+	return heatmap, nil
+}
+
+// addSecondsByDayHour spreads the time from start to end over the Monday-first day of
+// the week and hour of day it falls in.
+func addSecondsByDayHour(cells *[7][24]float64, start, end int64) {
+	endTime := time.Unix(end, 0).In(time.Local)
+	for t := time.Unix(start, 0).In(time.Local); t.Before(endTime); {
+		nextHour := time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, time.Local).Add(time.Hour)
+		if nextHour.After(endTime) {
+			nextHour = endTime
+		}
+		cells[(int(t.Weekday())+6)%7][t.Hour()] += nextHour.Sub(t).Seconds()
+		t = nextHour
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"time"
+)
+
+// snapshotScreenshotWindowSeconds is how far from the requested time a screenshot may be.
+const snapshotScreenshotWindowSeconds = 60
+
+// ActivitySnapshot describes what was happening at a single moment, for the timeline scrubber.
+type ActivitySnapshot struct {
+	Timestamp       int64    `json:"timestamp"`
+	ActiveApp       string   `json:"activeApp"` // Friendly app name; empty if nothing was focused
+	WindowTitle     string   `json:"windowTitle"`
+	SessionID       int64    `json:"sessionId"` // 0 if outside any session
+	IsAFK           bool     `json:"isAfk"`
+	ScreenshotID    int64    `json:"screenshotId"`    // Nearest screenshot within 60 seconds, 0 if none
+	OngoingCommands []string `json:"ongoingCommands"` // Shell commands still running at the time
+}
+
+// GetActivitySummaryForTimeOfDay returns a snapshot of activity at hour:minute on a date (YYYY-MM-DD).
+func (s *TimelineService) GetActivitySummaryForTimeOfDay(date string, hour, minute int) (*ActivitySnapshot, error) {
+	t, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date format: %w", err)
+	}
+	if hour < 0 || hour > 23 || minute < 0 || minute > 59 {
+		return nil, fmt.Errorf("invalid time of day: %02d:%02d", hour, minute)
+	}
+	ts := time.Date(t.Year(), t.Month(), t.Day(), hour, minute, 0, 0, time.Local).Unix()
+
+	snapshot := &ActivitySnapshot{
+		Timestamp:       ts,
+		OngoingCommands: []string{},
+	}
+
+	focus, err := s.store.GetFocusEventAt(ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus event: %w", err)
+	}
+	if focus != nil {
+		snapshot.ActiveApp = GetFriendlyAppName(focus.AppName)
+		snapshot.WindowTitle = focus.WindowTitle
+		if focus.SessionID.Valid {
+			snapshot.SessionID = focus.SessionID.Int64
+		}
+	}
+
+	if snapshot.SessionID == 0 {
+		sessions, err := s.store.GetSessionsByTimeRange(ts, ts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+		}
+		if len(sessions) > 0 {
+			snapshot.SessionID = sessions[len(sessions)-1].ID
+		}
+	}
+
+	afkEvents, err := s.store.GetAFKEventsByTimeRange(ts, ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch AFK events: %w", err)
+	}
+	snapshot.IsAFK = len(afkEvents) > 0
+
+	screenshot, err := s.store.GetNearestScreenshot(ts, snapshotScreenshotWindowSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch screenshot: %w", err)
+	}
+	if screenshot != nil {
+		snapshot.ScreenshotID = screenshot.ID
+	}
+
+	commands, err := s.store.GetShellCommandsRunningAt(ts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch shell commands: %w", err)
+	}
+	for _, cmd := range commands {
+		snapshot.OngoingCommands = append(snapshot.OngoingCommands, cmd.Command)
+	}
+
+	return snapshot, nil
+}
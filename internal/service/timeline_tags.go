@@ -0,0 +1,54 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// TagTimelineEntry is the time spent in sessions with a tag on one day.
+type TagTimelineEntry struct {
+	Date         string `json:"date"` // YYYY-MM-DD
+	TotalMinutes int64  `json:"totalMinutes"`
+	Sessions     int    `json:"sessions"`
+}
+
+// GetTagTimeline returns per-day totals for sessions tagged with tag in [start, end],
+// ordered by date. Sessions count towards the day they started on, and their time is
+// clipped to the range; an ongoing session counts up to now.
+func (s *TimelineService) GetTagTimeline(tag string, start, end int64) ([]*TagTimelineEntry, error) {
+	sessions, err := s.store.GetSessionsByTag(tag, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions for tag: %w", err)
+	}
+
+	now := time.Now().Unix()
+	byDate := make(map[string]*TagTimelineEntry)
+	for _, sess := range sessions {
+		sessEnd := now
+		if sess.EndTime.Valid {
+			sessEnd = sess.EndTime.Int64
+		}
+		from, to := max(sess.StartTime, start), min(sessEnd, end)
+
+		date := time.Unix(sess.StartTime, 0).In(time.Local).Format("2006-01-02")
+		entry, ok := byDate[date]
+		if !ok {
+			entry = &TagTimelineEntry{Date: date}
+			byDate[date] = entry
+		}
+		entry.Sessions++
+		if to > from {
+			entry.TotalMinutes += (to - from) / 60
+		}
+	}
+
+	entries := make([]*TagTimelineEntry, 0, len(byDate))
+	for _, entry := range byDate {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Date < entries[j].Date
+	})
+	return entries, nil
+}
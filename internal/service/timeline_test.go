@@ -1,7 +1,12 @@
 package service
 
 import (
+	"database/sql"
+	"fmt"
 	"testing"
+	"time"
+
+	"traq/internal/storage"
 )
 
 // TestSessionSummary_NullEndTime_Duration tests that sessions with null end time
@@ -96,3 +101,848 @@ func TestSessionSummary_DurationCalculation(t *testing.T) {
 func ptrInt64(v int64) *int64 {
 	return &v
 }
+
+func TestGetActivityHeatmapForYear(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+
+	busy := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.Local).Unix()
+	quiet := time.Date(2024, time.March, 6, 10, 0, 0, 0, time.Local).Unix()
+
+	// Two hours of focus on the busy day, thirty minutes on the quiet day
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: busy, EndTime: busy + 7200, DurationSeconds: 7200})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: quiet, EndTime: quiet + 1800, DurationSeconds: 1800})
+
+	repoID, err := store.SaveGitRepository(&storage.GitRepository{Path: "/tmp/repo", Name: "repo", IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to save repository: %v", err)
+	}
+	for i, hash := range []string{"aaa111", "bbb222"} {
+		_, err := store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: busy + int64(i*60), CommitHash: hash, ShortHash: hash, RepositoryID: repoID,
+			Message: "commit", MessageSubject: "commit",
+		})
+		if err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+	}
+	if _, err := store.SaveScreenshot(&storage.Screenshot{Timestamp: busy + 30, Filepath: "a.webp", DHash: "0"}); err != nil {
+		t.Fatalf("failed to save screenshot: %v", err)
+	}
+
+	heatmap, err := svc.GetActivityHeatmapForYear(2024)
+	if err != nil {
+		t.Fatalf("GetActivityHeatmapForYear failed: %v", err)
+	}
+
+	// 2024 is a leap year
+	if len(heatmap.Days) != 366 {
+		t.Fatalf("expected 366 days, got %d", len(heatmap.Days))
+	}
+	if heatmap.Days[0].Date != "2024-01-01" || heatmap.Days[365].Date != "2024-12-31" {
+		t.Errorf("unexpected date range %s to %s", heatmap.Days[0].Date, heatmap.Days[365].Date)
+	}
+
+	busyDay := heatmap.Days[64] // March 5
+	if busyDay.Date != "2024-03-05" {
+		t.Fatalf("expected 2024-03-05 at index 64, got %s", busyDay.Date)
+	}
+	if busyDay.ActiveMinutes != 120 || busyDay.Commits != 2 || busyDay.Screenshots != 1 {
+		t.Errorf("busy day: got %d minutes, %d commits, %d screenshots", busyDay.ActiveMinutes, busyDay.Commits, busyDay.Screenshots)
+	}
+	if busyDay.Intensity != 4 {
+		t.Errorf("busy day: expected intensity 4, got %d", busyDay.Intensity)
+	}
+	if quietDay := heatmap.Days[65]; quietDay.Intensity != 1 {
+		t.Errorf("quiet day: expected intensity 1, got %d", quietDay.Intensity)
+	}
+	if heatmap.Days[0].Intensity != 0 {
+		t.Errorf("empty day: expected intensity 0, got %d", heatmap.Days[0].Intensity)
+	}
+	if heatmap.MaxIntensity != 4 {
+		t.Errorf("expected max intensity 4, got %d", heatmap.MaxIntensity)
+	}
+
+	// Repeated calls within the cache TTL return the cached heatmap
+	again, _ := svc.GetActivityHeatmapForYear(2024)
+	if again != heatmap {
+		t.Error("expected cached heatmap to be reused")
+	}
+
+	// Non-leap years have 365 days
+	heatmap2023, err := svc.GetActivityHeatmapForYear(2023)
+	if err != nil {
+		t.Fatalf("GetActivityHeatmapForYear(2023) failed: %v", err)
+	}
+	if len(heatmap2023.Days) != 365 {
+		t.Errorf("expected 365 days for 2023, got %d", len(heatmap2023.Days))
+	}
+}
+
+func TestDayStatsTrend(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+
+	day1 := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.Local).Unix()
+	day2 := time.Date(2024, time.March, 6, 10, 0, 0, 0, time.Local).Unix()
+	day3 := time.Date(2024, time.March, 7, 10, 0, 0, 0, time.Local).Unix()
+
+	// Two hours on day 1 (split by a 2-minute gap, still one streak), one hour on day 2, three on day 3
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day1, EndTime: day1 + 3600, DurationSeconds: 3600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day1 + 3720, EndTime: day1 + 7320, DurationSeconds: 3600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day2, EndTime: day2 + 3600, DurationSeconds: 3600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day3, EndTime: day3 + 10800, DurationSeconds: 10800})
+
+	data, err := svc.GetTimelineGridData("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	stats := data.DayStats
+	// The total covers the day span, so it includes the 2-minute gap
+	if stats.PreviousDayLongestFocus != 7200 || stats.PreviousDayTotalSeconds != 7320 {
+		t.Errorf("expected previous day longest=7200 total=7320, got longest=%.0f total=%.0f", stats.PreviousDayLongestFocus, stats.PreviousDayTotalSeconds)
+	}
+	if stats.FocusScoreTrend != -50 {
+		t.Errorf("expected focus trend of -50%%, got %.1f", stats.FocusScoreTrend)
+	}
+	if stats.TotalSecondsTrend >= 0 {
+		t.Errorf("expected negative total trend, got %.1f", stats.TotalSecondsTrend)
+	}
+
+	data, err = svc.GetTimelineGridData("2024-03-07")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	stats = data.DayStats
+	if stats.FocusScoreTrend != 200 {
+		t.Errorf("expected focus trend of +200%%, got %.1f", stats.FocusScoreTrend)
+	}
+	if stats.TotalSecondsTrend <= 0 {
+		t.Errorf("expected positive total trend, got %.1f", stats.TotalSecondsTrend)
+	}
+}
+
+func TestGetProductivityHeatmap(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	store.SetAppTimelineCategory("code", "focus")
+	store.SetAppTimelineCategory("slack", "comms")
+
+	// Wednesday 10:00-10:45 in code, 10:45-11:15 in slack
+	wednesday := time.Date(2024, time.March, 6, 10, 0, 0, 0, time.Local).Unix()
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "code", StartTime: wednesday, EndTime: wednesday + 2700, DurationSeconds: 2700})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "general", AppName: "slack", StartTime: wednesday + 2700, EndTime: wednesday + 4500, DurationSeconds: 1800})
+	// Away for most of 14:00
+	afk := wednesday + 4*3600
+	store.CreateAFKEvent(&storage.AFKEvent{StartTime: afk, EndTime: sql.NullInt64{Int64: afk + 3000, Valid: true}, TriggerType: "idle_timeout"})
+
+	heatmap, err := svc.GetProductivityHeatmap("2024-03-07")
+	if err != nil {
+		t.Fatalf("GetProductivityHeatmap failed: %v", err)
+	}
+	if heatmap.WeekStart != "2024-03-04" {
+		t.Errorf("expected the week to start on Monday 2024-03-04, got %s", heatmap.WeekStart)
+	}
+	if len(heatmap.Cells) != 7 || len(heatmap.Cells[0]) != 24 {
+		t.Fatalf("expected 7x24 cells, got %dx%d", len(heatmap.Cells), len(heatmap.Cells[0]))
+	}
+
+	ten, eleven := heatmap.Cells[2][10], heatmap.Cells[2][11]
+	if ten.DayIndex != 2 || ten.Hour != 10 || ten.ActiveMinutes != 60 || ten.Score != 75 {
+		t.Errorf("unexpected 10:00 cell: %+v", ten)
+	}
+	if eleven.ActiveMinutes != 15 || eleven.Score != 0 {
+		t.Errorf("expected an unproductive 11:00 cell, got %+v", eleven)
+	}
+	if empty := heatmap.Cells[0][10]; empty.Score != -1 || empty.IsAFK {
+		t.Errorf("expected an empty cell to have no score, got %+v", empty)
+	}
+	if !heatmap.Cells[2][14].IsAFK || heatmap.Cells[2][14].Score != -1 {
+		t.Errorf("expected 14:00 to be AFK, got %+v", heatmap.Cells[2][14])
+	}
+	if heatmap.MaxScore != 75 {
+		t.Errorf("expected max score 75, got %v", heatmap.MaxScore)
+	}
+
+	if _, err := svc.GetProductivityHeatmap("not-a-date"); err == nil {
+		t.Error("expected error for an invalid date")
+	}
+}
+
+func TestGetDeepWorkBlocks(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	store.SetAppTimelineCategory("code", "focus")
+	store.SetAppTimelineCategory("terminal", "focus")
+	store.SetAppTimelineCategory("slack", "comms")
+
+	save := func(app string, start, duration int64) {
+		t.Helper()
+		_, err := store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle: app, AppName: app, StartTime: start, EndTime: start + duration, DurationSeconds: float64(duration),
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	// Low-switch morning: 40 min in code, 20 in terminal, 10 back in code (2 switches)
+	morning := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local).Unix()
+	save("code", morning, 2400)
+	save("terminal", morning+2400, 1200)
+	save("code", morning+3600, 600)
+
+	// Slack interrupts, then a high-switch afternoon alternating apps every 3 minutes for an hour
+	save("slack", morning+4200, 600)
+	afternoon := time.Date(2024, time.March, 5, 14, 0, 0, 0, time.Local).Unix()
+	for i := int64(0); i < 20; i++ {
+		app := "code"
+		if i%2 == 1 {
+			app = "terminal"
+		}
+		save(app, afternoon+i*180, 180)
+	}
+
+	blocks, err := svc.GetDeepWorkBlocks("2024-03-05", 30)
+	if err != nil {
+		t.Fatalf("GetDeepWorkBlocks failed: %v", err)
+	}
+	if len(blocks) != 1 {
+		t.Fatalf("expected 1 deep-work block, got %d", len(blocks))
+	}
+	block := blocks[0]
+	if block.StartTime != morning || block.DurationMinutes != 70 || block.ContextSwitches != 2 {
+		t.Errorf("unexpected block: %+v", block)
+	}
+	if block.PrimaryApp != GetFriendlyAppName("code") {
+		t.Errorf("expected primary app %q, got %q", GetFriendlyAppName("code"), block.PrimaryApp)
+	}
+
+	// A higher threshold excludes the block
+	blocks, err = svc.GetDeepWorkBlocks("2024-03-05", 90)
+	if err != nil {
+		t.Fatalf("GetDeepWorkBlocks failed: %v", err)
+	}
+	if len(blocks) != 0 {
+		t.Errorf("expected no blocks of 90+ minutes, got %d", len(blocks))
+	}
+
+	data, err := svc.GetTimelineGridData("2024-03-05")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	if data.DayStats.DeepWorkMinutes != 70 {
+		t.Errorf("expected 70 deep-work minutes, got %d", data.DayStats.DeepWorkMinutes)
+	}
+}
+
+func TestGetActivitySummaryForTimeOfDay(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	at := time.Date(2024, time.March, 5, 10, 23, 0, 0, time.Local).Unix()
+
+	sessionID, _ := store.CreateSession(at - 3600)
+	store.EndSession(sessionID, at+3600)
+	store.SaveFocusEvent(&storage.WindowFocusEvent{
+		AppName: "code", WindowTitle: "main.go", StartTime: at - 600, EndTime: at + 600, DurationSeconds: 1200,
+		SessionID: sql.NullInt64{Int64: sessionID, Valid: true},
+	})
+	store.SaveScreenshot(&storage.Screenshot{Timestamp: at - 50, Filepath: "far.webp", DHash: "0"})
+	nearID, _ := store.SaveScreenshot(&storage.Screenshot{Timestamp: at + 20, Filepath: "near.webp", DHash: "1"})
+	store.SaveShellCommand(&storage.ShellCommand{Timestamp: at - 120, Command: "go test ./...", ShellType: "bash",
+		DurationSeconds: sql.NullFloat64{Float64: 300, Valid: true}})
+	store.SaveShellCommand(&storage.ShellCommand{Timestamp: at - 300, Command: "ls", ShellType: "bash",
+		DurationSeconds: sql.NullFloat64{Float64: 1, Valid: true}})
+
+	snapshot, err := svc.GetActivitySummaryForTimeOfDay("2024-03-05", 10, 23)
+	if err != nil {
+		t.Fatalf("GetActivitySummaryForTimeOfDay failed: %v", err)
+	}
+	if snapshot.WindowTitle != "main.go" || snapshot.ActiveApp != GetFriendlyAppName("code") {
+		t.Errorf("unexpected focus: %q / %q", snapshot.ActiveApp, snapshot.WindowTitle)
+	}
+	if snapshot.SessionID != sessionID {
+		t.Errorf("expected session %d, got %d", sessionID, snapshot.SessionID)
+	}
+	if snapshot.ScreenshotID != nearID {
+		t.Errorf("expected nearest screenshot %d, got %d", nearID, snapshot.ScreenshotID)
+	}
+	if len(snapshot.OngoingCommands) != 1 || snapshot.OngoingCommands[0] != "go test ./..." {
+		t.Errorf("expected only the running command, got %v", snapshot.OngoingCommands)
+	}
+	if snapshot.IsAFK {
+		t.Error("expected not AFK")
+	}
+
+	// Later that day: AFK, nothing focused, no screenshot nearby
+	afk := time.Date(2024, time.March, 5, 15, 0, 0, 0, time.Local).Unix()
+	store.CreateAFKEvent(&storage.AFKEvent{StartTime: afk - 600, EndTime: sql.NullInt64{Int64: afk + 600, Valid: true}, TriggerType: "idle_timeout"})
+
+	snapshot, err = svc.GetActivitySummaryForTimeOfDay("2024-03-05", 15, 0)
+	if err != nil {
+		t.Fatalf("GetActivitySummaryForTimeOfDay failed: %v", err)
+	}
+	if !snapshot.IsAFK || snapshot.ActiveApp != "" || snapshot.ScreenshotID != 0 || snapshot.SessionID != 0 {
+		t.Errorf("expected an empty AFK snapshot, got %+v", snapshot)
+	}
+
+	if _, err := svc.GetActivitySummaryForTimeOfDay("2024-03-05", 24, 0); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
+
+func TestGetActiveSession(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	if active, err := svc.GetActiveSession(); err != nil || active != nil {
+		t.Fatalf("expected no active session, got %+v (err %v)", active, err)
+	}
+
+	now := time.Now().Unix()
+	// A session left open by a crash, and the session started since
+	store.CreateSession(now - 7200)
+	sessionID, _ := store.CreateSession(now - 600)
+	for i, app := range []string{"firefox", "code"} {
+		store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle:     fmt.Sprintf("window %d", i),
+			AppName:         app,
+			StartTime:       now - 600 + int64(i*60),
+			EndTime:         now - 540 + int64(i*60),
+			DurationSeconds: 60,
+			SessionID:       sql.NullInt64{Int64: sessionID, Valid: true},
+		})
+	}
+
+	active, err := svc.GetActiveSession()
+	if err != nil {
+		t.Fatalf("GetActiveSession failed: %v", err)
+	}
+	if active == nil || active.ID != sessionID {
+		t.Fatalf("expected most recently started session %d, got %+v", sessionID, active)
+	}
+	if !active.IsActive || !active.IsOngoing {
+		t.Error("expected session to be active and ongoing")
+	}
+	if active.CurrentAppName != GetFriendlyAppName("code") || active.CurrentWindowTitle != "window 1" {
+		t.Errorf("expected last focused window, got %q %q", active.CurrentAppName, active.CurrentWindowTitle)
+	}
+	if active.SessionElapsedSeconds < 600 || active.SessionElapsedSeconds > 605 {
+		t.Errorf("expected ~600 elapsed seconds, got %d", active.SessionElapsedSeconds)
+	}
+}
+
+func TestGetFocusFlowData(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	day := time.Date(2024, time.March, 6, 9, 0, 0, 0, time.Local)
+	switches := []struct {
+		app     string
+		offset  time.Duration
+		seconds int64
+	}{
+		{"code", 0, 600},
+		{"firefox", 10 * time.Minute, 300},
+		{"code", 15 * time.Minute, 600},
+		{"firefox", 25 * time.Minute, 120},
+		{"slack", 27 * time.Minute, 60},
+		{"code", 60 * time.Minute, 300},
+		{"code", 65 * time.Minute, 60}, // Another window in the same app
+	}
+	for i, sw := range switches {
+		start := day.Add(sw.offset).Unix()
+		store.SaveFocusEvent(&storage.WindowFocusEvent{
+			WindowTitle:     fmt.Sprintf("window %d", i),
+			AppName:         sw.app,
+			StartTime:       start,
+			EndTime:         start + sw.seconds,
+			DurationSeconds: float64(sw.seconds),
+		})
+	}
+	code, firefox, slack := GetFriendlyAppName("code"), GetFriendlyAppName("firefox"), GetFriendlyAppName("slack")
+
+	flow, err := svc.GetFocusFlowData("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetFocusFlowData failed: %v", err)
+	}
+	// Switches made only once are noise
+	if len(flow.Transitions) != 1 {
+		t.Fatalf("expected 1 repeated transition, got %+v", flow.Transitions)
+	}
+	if tr := flow.Transitions[0]; tr.FromApp != code || tr.ToApp != firefox || tr.Count != 2 || tr.TotalDurationSeconds != 420 {
+		t.Errorf("unexpected transition: %+v", tr)
+	}
+
+	hourly, err := svc.GetHourlyAppTransitions("2024-03-06", 9)
+	if err != nil {
+		t.Fatalf("GetHourlyAppTransitions failed: %v", err)
+	}
+	if len(hourly) != 3 || hourly[0].ToApp != firefox || hourly[0].Count != 2 {
+		t.Errorf("expected 3 transitions led by %s -> %s, got %+v", code, firefox, hourly)
+	}
+
+	// The switch at 10:00 comes from the last app of the previous hour
+	hourly, _ = svc.GetHourlyAppTransitions("2024-03-06", 10)
+	if len(hourly) != 1 || hourly[0].FromApp != slack || hourly[0].ToApp != code {
+		t.Errorf("expected a single %s -> %s switch, got %+v", slack, code, hourly)
+	}
+
+	if _, err := svc.GetHourlyAppTransitions("2024-03-06", 24); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
+
+func TestGetAFKSummary(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	day := time.Date(2024, time.March, 6, 0, 0, 0, 0, time.Local)
+	afk := func(hour, minutes int, trigger string) {
+		start := day.Add(time.Duration(hour) * time.Hour).Unix()
+		store.CreateAFKEvent(&storage.AFKEvent{
+			StartTime:   start,
+			EndTime:     sql.NullInt64{Int64: start + int64(minutes*60), Valid: true},
+			TriggerType: trigger,
+		})
+	}
+	afk(9, 2, "idle_timeout")  // too short to be a break
+	afk(11, 10, "screen_lock") // break
+	afk(13, 45, "sleep")       // long break
+	afk(16, 29, "idle_timeout")
+	// Still ongoing, so not counted
+	store.CreateAFKEvent(&storage.AFKEvent{StartTime: day.Add(20 * time.Hour).Unix(), TriggerType: "idle_timeout"})
+
+	summary, err := svc.GetAFKSummary("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetAFKSummary failed: %v", err)
+	}
+	if summary.TotalAFKMinutes != 86 {
+		t.Errorf("expected 86 AFK minutes, got %d", summary.TotalAFKMinutes)
+	}
+	if summary.LongestAFKMinutes != 45 || summary.LongestAFKStart != day.Add(13*time.Hour).Unix() {
+		t.Errorf("unexpected longest AFK: %d min at %d", summary.LongestAFKMinutes, summary.LongestAFKStart)
+	}
+	if len(summary.AFKEvents) != 4 || summary.AFKEvents[0].StartTime != day.Add(9*time.Hour).Unix() {
+		t.Errorf("expected 4 AFK events in start order, got %+v", summary.AFKEvents)
+	}
+	if len(summary.Breaks) != 2 || summary.Breaks[0].TriggerType != "screen_lock" || summary.Breaks[1].DurationMinutes != 29 {
+		t.Errorf("unexpected breaks: %+v", summary.Breaks)
+	}
+	if len(summary.LongBreaks) != 1 || summary.LongBreaks[0].TriggerType != "sleep" || summary.LongBreaks[0].DurationMinutes != 45 {
+		t.Errorf("unexpected long breaks: %+v", summary.LongBreaks)
+	}
+
+	data, err := svc.GetTimelineGridData("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	if data.DayStats.AFKSummary == nil || data.DayStats.AFKSummary.TotalAFKMinutes != 86 {
+		t.Errorf("expected AFK summary in day stats, got %+v", data.DayStats.AFKSummary)
+	}
+
+	empty, err := svc.GetAFKSummary("2024-03-07")
+	if err != nil {
+		t.Fatalf("GetAFKSummary failed: %v", err)
+	}
+	if empty.TotalAFKMinutes != 0 || len(empty.AFKEvents) != 0 || empty.LongestAFKStart != 0 {
+		t.Errorf("expected empty summary, got %+v", empty)
+	}
+
+	if _, err := svc.GetAFKSummary("not-a-date"); err == nil {
+		t.Error("expected error for invalid date")
+	}
+}
+
+func TestGetCodeEditingStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	file := func(at time.Time, eventType, path, ext, category string) {
+		t.Helper()
+		if _, err := store.SaveFileEvent(&storage.FileEvent{
+			Timestamp:     at.Unix(),
+			EventType:     eventType,
+			FilePath:      path,
+			FileName:      path,
+			FileExtension: sql.NullString{String: ext, Valid: ext != ""},
+			WatchCategory: category,
+		}); err != nil {
+			t.Fatalf("SaveFileEvent failed: %v", err)
+		}
+	}
+	file(today.Add(9*time.Hour), "create", "/src/new.go", ".go", "projects")
+	file(today.Add(9*time.Hour+time.Minute), "modify", "/src/new.go", ".go", "projects")
+	file(today.Add(10*time.Hour), "modify", "/src/main.go", ".go", "projects")
+	file(today.Add(10*time.Hour+2*time.Minute), "modify", "/src/main.go", ".go", "projects")
+	file(today.Add(10*time.Hour+20*time.Minute), "modify", "/src/app.tsx", ".tsx", "projects")
+	file(today.Add(11*time.Hour), "delete", "/src/old.go", ".go", "projects")
+	file(today.Add(14*time.Hour), "create", "/home/me/Downloads/a.pdf", ".pdf", "downloads")
+	file(today.AddDate(0, 0, -2).Add(9*time.Hour), "modify", "/src/main.go", ".go", "projects")
+
+	stats, err := svc.GetCodeEditingStats(today.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetCodeEditingStats failed: %v", err)
+	}
+	if stats.TotalFilesEdited != 3 || stats.TotalFilesCreated != 1 || stats.TotalFilesDeleted != 1 {
+		t.Errorf("files edited/created/deleted = %d/%d/%d, want 3/1/1", stats.TotalFilesEdited, stats.TotalFilesCreated, stats.TotalFilesDeleted)
+	}
+	if stats.EditsByExtension[".go"] != 4 || stats.EditsByExtension[".tsx"] != 1 || stats.EditsByExtension[".pdf"] != 0 {
+		t.Errorf("unexpected edits by extension: %v", stats.EditsByExtension)
+	}
+	if stats.PeakEditingHour != 10 {
+		t.Errorf("PeakEditingHour = %d, want 10", stats.PeakEditingHour)
+	}
+	// 09:00, 10:00 and 10:20 windows plus the delete at 11:00
+	if stats.ActiveEditingMinutes != 20 {
+		t.Errorf("ActiveEditingMinutes = %d, want 20", stats.ActiveEditingMinutes)
+	}
+
+	data, err := svc.GetTimelineGridData(today.Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	if data.DayStats.CodeEditingStats == nil || data.DayStats.CodeEditingStats.TotalFilesEdited != 3 {
+		t.Errorf("expected code editing stats in day stats, got %+v", data.DayStats.CodeEditingStats)
+	}
+
+	empty, err := svc.GetCodeEditingStats(today.AddDate(0, 0, -1).Format("2006-01-02"))
+	if err != nil {
+		t.Fatalf("GetCodeEditingStats failed: %v", err)
+	}
+	if empty.PeakEditingHour != -1 || empty.ActiveEditingMinutes != 0 {
+		t.Errorf("expected empty stats, got %+v", empty)
+	}
+
+	trend, err := svc.GetCodeEditingTrend(3)
+	if err != nil {
+		t.Fatalf("GetCodeEditingTrend failed: %v", err)
+	}
+	if len(trend) != 3 || trend[0].Date != today.AddDate(0, 0, -2).Format("2006-01-02") {
+		t.Fatalf("unexpected trend days: %+v", trend)
+	}
+	if trend[0].FilesEdited != 1 || trend[1].EventCount != 0 || trend[2].EventCount != 6 || trend[2].ActiveEditingMinutes != 20 {
+		t.Errorf("unexpected trend: %+v %+v %+v", trend[0], trend[1], trend[2])
+	}
+}
+
+func TestGetTagTimeline(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	day1 := time.Date(2024, time.March, 11, 9, 0, 0, 0, time.Local).Unix()
+	day2 := time.Date(2024, time.March, 12, 9, 0, 0, 0, time.Local).Unix()
+	tagged := func(start int64, minutes int64, tags ...string) {
+		id, _ := store.CreateSession(start)
+		store.EndSession(id, start+minutes*60)
+		store.SaveSummary(&storage.Summary{
+			SessionID: sql.NullInt64{Int64: id, Valid: true}, Summary: "work", Tags: tags, ModelUsed: "test",
+		})
+	}
+	tagged(day1, 60, "coding")
+	tagged(day1+7200, 30, "coding", "review")
+	tagged(day1+14400, 45, "meeting")
+	tagged(day2, 20, "coding")
+
+	entries, err := svc.GetTagTimeline("coding", day1-3600, day2+86400)
+	if err != nil {
+		t.Fatalf("GetTagTimeline failed: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 days, got %+v", entries)
+	}
+	if entries[0].Date != "2024-03-11" || entries[0].TotalMinutes != 90 || entries[0].Sessions != 2 {
+		t.Errorf("unexpected first day: %+v", entries[0])
+	}
+	if entries[1].Date != "2024-03-12" || entries[1].TotalMinutes != 20 || entries[1].Sessions != 1 {
+		t.Errorf("unexpected second day: %+v", entries[1])
+	}
+
+	entries, err = svc.GetTagTimeline("unused", day1-3600, day2+86400)
+	if err != nil {
+		t.Fatalf("GetTagTimeline failed: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries for an unused tag, got %+v", entries)
+	}
+}
+
+func TestGetWeekTimelineData_GitAndShellOverlays(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+
+	repoID, err := store.SaveGitRepository(&storage.GitRepository{Path: "/tmp/repo", Name: "repo", IsActive: true})
+	if err != nil {
+		t.Fatalf("failed to save repository: %v", err)
+	}
+
+	// Commits on Monday (2), Wednesday, and Friday, plus one the following Monday
+	monday := time.Date(2024, time.March, 4, 10, 0, 0, 0, time.Local)
+	commitDays := []int{0, 0, 2, 4, 7}
+	for i, day := range commitDays {
+		hash := fmt.Sprintf("hash%d", i)
+		_, err := store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: monday.AddDate(0, 0, day).Unix() + int64(i*60), CommitHash: hash, ShortHash: hash,
+			RepositoryID: repoID, Message: "commit", MessageSubject: "commit",
+		})
+		if err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+	}
+
+	// Only slow or failed commands are key commands
+	tuesday := monday.AddDate(0, 0, 1).Unix()
+	store.SaveShellCommand(&storage.ShellCommand{Command: "ls", ShellType: "bash", Timestamp: tuesday,
+		ExitCode: sql.NullInt64{Int64: 0, Valid: true}, DurationSeconds: sql.NullFloat64{Float64: 0.1, Valid: true}})
+	store.SaveShellCommand(&storage.ShellCommand{Command: "go test ./...", ShellType: "bash", Timestamp: tuesday + 60,
+		ExitCode: sql.NullInt64{Int64: 0, Valid: true}, DurationSeconds: sql.NullFloat64{Float64: 42, Valid: true}})
+	store.SaveShellCommand(&storage.ShellCommand{Command: "make", ShellType: "bash", Timestamp: tuesday + 120,
+		ExitCode: sql.NullInt64{Int64: 2, Valid: true}, DurationSeconds: sql.NullFloat64{Float64: 1, Valid: true}})
+
+	data, err := svc.GetWeekTimelineData("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetWeekTimelineData failed: %v", err)
+	}
+
+	wantCommits := map[string]int{"2024-03-04": 2, "2024-03-06": 1, "2024-03-08": 1}
+	if len(data.GitEvents) != len(wantCommits) {
+		t.Errorf("expected commits on %d days, got %d", len(wantCommits), len(data.GitEvents))
+	}
+	for _, day := range data.Days {
+		if day.CommitCount != wantCommits[day.Date] {
+			t.Errorf("%s: expected %d commits, got %d", day.Date, wantCommits[day.Date], day.CommitCount)
+		}
+		if len(data.GitEvents[day.Date]) != day.CommitCount {
+			t.Errorf("%s: commit count %d doesn't match %d git events", day.Date, day.CommitCount, len(data.GitEvents[day.Date]))
+		}
+	}
+	if repo := data.GitEvents["2024-03-04"][0].Repository; repo != "repo" {
+		t.Errorf("expected repository name repo, got %s", repo)
+	}
+
+	if data.Days[1].KeyCommandCount != 2 {
+		t.Errorf("expected 2 key commands on Tuesday, got %d", data.Days[1].KeyCommandCount)
+	}
+	for _, cmd := range data.ShellEvents["2024-03-05"] {
+		if cmd.Command == "ls" {
+			t.Error("expected fast successful command to be excluded")
+		}
+	}
+}
+
+// seedSessionContexts creates sessions with a summary, focus events, shell commands and
+// git commits each, for the session context tests and benchmarks.
+func seedSessionContexts(tb testing.TB, store *storage.Store, sessions int) []int64 {
+	tb.Helper()
+
+	repoID, err := store.SaveGitRepository(&storage.GitRepository{Path: "/repo", Name: "repo", IsActive: true})
+	if err != nil {
+		tb.Fatalf("failed to save repository: %v", err)
+	}
+
+	now := time.Now().Unix()
+	var ids []int64
+	for i := 0; i < sessions; i++ {
+		start := now - int64((sessions-i)*3600)
+		sessionID, err := store.CreateSession(start)
+		if err != nil {
+			tb.Fatalf("failed to create session: %v", err)
+		}
+		sid := sql.NullInt64{Int64: sessionID, Valid: true}
+		store.SaveSummary(&storage.Summary{SessionID: sid, Summary: fmt.Sprintf("Session %d", i)})
+		for j := 0; j < 5; j++ {
+			ts := start + int64(j*60)
+			store.SaveFocusEvent(&storage.WindowFocusEvent{
+				WindowTitle: "main.go", AppName: "code", StartTime: ts, EndTime: ts + 60,
+				DurationSeconds: 60, SessionID: sid,
+			})
+			store.SaveShellCommand(&storage.ShellCommand{Timestamp: ts, Command: "go test", ShellType: "bash", SessionID: sid})
+		}
+		store.SaveGitCommit(&storage.GitCommit{
+			Timestamp: start + 300, CommitHash: fmt.Sprintf("hash%d", i), ShortHash: fmt.Sprintf("h%d", i),
+			RepositoryID: repoID, Message: "Fix", MessageSubject: "Fix", SessionID: sid,
+		})
+		ids = append(ids, sessionID)
+	}
+	return ids
+}
+
+func TestGetSessionContextBatch(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	ids := seedSessionContexts(t, store, 3)
+	emptySession, _ := store.CreateSession(time.Now().Unix())
+	ids = append(ids, emptySession)
+
+	contexts, err := svc.GetSessionContextBatch(ids)
+	if err != nil {
+		t.Fatalf("GetSessionContextBatch failed: %v", err)
+	}
+	if len(contexts) != len(ids) {
+		t.Fatalf("expected %d contexts, got %d", len(ids), len(contexts))
+	}
+	for i, id := range ids[:3] {
+		ctx := contexts[id]
+		if ctx.Summary == nil || ctx.Summary.Summary != fmt.Sprintf("Session %d", i) {
+			t.Errorf("session %d: unexpected summary %+v", id, ctx.Summary)
+		}
+		if len(ctx.FocusEvents) != 5 || len(ctx.ShellCommands) != 5 || len(ctx.GitCommits) != 1 {
+			t.Errorf("session %d: expected 5 focus events, 5 commands, 1 commit, got %d, %d, %d",
+				id, len(ctx.FocusEvents), len(ctx.ShellCommands), len(ctx.GitCommits))
+		}
+	}
+	if ctx := contexts[emptySession]; ctx == nil || ctx.Summary != nil || len(ctx.FocusEvents) != 0 {
+		t.Errorf("expected empty context for session without data, got %+v", ctx)
+	}
+}
+
+func TestGetSessionContext_AFKEvents(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	start := int64(1700000000)
+	before, _ := store.CreateSession(start - 3600)
+	store.EndSession(before, start-1800)
+	sessionID, _ := store.CreateSession(start)
+	store.EndSession(sessionID, start+3600)
+
+	// A 10-minute AFK period half an hour into the session
+	afkID, err := store.CreateAFKEvent(&storage.AFKEvent{
+		StartTime:   start + 1800,
+		SessionID:   sql.NullInt64{Int64: sessionID, Valid: true},
+		TriggerType: "idle_timeout",
+	})
+	if err != nil {
+		t.Fatalf("CreateAFKEvent failed: %v", err)
+	}
+	store.UpdateAFKEventEnd(afkID, start+2400)
+
+	ctx, err := svc.GetSessionContext(sessionID)
+	if err != nil {
+		t.Fatalf("GetSessionContext failed: %v", err)
+	}
+	if len(ctx.AFKEvents) != 1 || ctx.AFKSeconds != 600 {
+		t.Errorf("expected 1 AFK event of 600s, got %d events and %ds", len(ctx.AFKEvents), ctx.AFKSeconds)
+	}
+	if ctx, _ := svc.GetSessionContext(before); len(ctx.AFKEvents) != 0 || ctx.AFKSeconds != 0 {
+		t.Errorf("expected no AFK time in the earlier session, got %ds", ctx.AFKSeconds)
+	}
+}
+
+// BenchmarkSessionContextPerSession loads 20 sessions with GetSessionContext, which
+// runs up to 8 queries per session.
+func BenchmarkSessionContextPerSession(b *testing.B) {
+	_, store, cleanup := setupReportsTest(b)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	ids := seedSessionContexts(b, store, 20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := svc.GetSessionContext(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkSessionContextBatch loads the same 20 sessions with GetSessionContextBatch,
+// which runs 4 queries in total.
+func BenchmarkSessionContextBatch(b *testing.B) {
+	_, store, cleanup := setupReportsTest(b)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	ids := seedSessionContexts(b, store, 20)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := svc.GetSessionContextBatch(ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestGetTimelineGridData_BrowserDomainFilter(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+	at := time.Date(2024, time.March, 5, 10, 15, 0, 0, time.Local).Unix()
+	store.SaveBrowserVisit(&storage.BrowserVisit{Timestamp: at, URL: "https://github.com/a", Domain: "github.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&storage.BrowserVisit{Timestamp: at + 60, URL: "https://example.com", Domain: "example.com", Browser: "chrome"})
+
+	data, err := svc.GetTimelineGridDataWithOptions("2024-03-05", TimelineOptions{})
+	if err != nil {
+		t.Fatalf("GetTimelineGridDataWithOptions failed: %v", err)
+	}
+	if len(data.BrowserEvents[10]) != 2 {
+		t.Errorf("expected 2 browser events without a filter, got %d", len(data.BrowserEvents[10]))
+	}
+
+	data, err = svc.GetTimelineGridDataWithOptions("2024-03-05", TimelineOptions{BrowserDomain: "github.com"})
+	if err != nil {
+		t.Fatalf("GetTimelineGridDataWithOptions failed: %v", err)
+	}
+	if events := data.BrowserEvents[10]; len(events) != 1 || events[0].Domain != "github.com" {
+		t.Errorf("expected only the github.com visit, got %+v", events)
+	}
+}
+
+func TestDayStatsTrend_PreviousDayMatchesItsOwnStats(t *testing.T) {
+	_, store, cleanup := setupReportsTest(t)
+	defer cleanup()
+
+	svc := NewTimelineService(store)
+
+	day1 := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.Local).Unix()
+	day2 := time.Date(2024, time.March, 6, 10, 0, 0, 0, time.Local).Unix()
+	midnight := time.Date(2024, time.March, 5, 23, 30, 0, 0, time.Local).Unix()
+
+	// Day 1: an hour of work, an hour that overlaps an AFK period, and an event running past midnight
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day1, EndTime: day1 + 3600, DurationSeconds: 3600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day1 + 3600, EndTime: day1 + 7200, DurationSeconds: 3600})
+	store.CreateAFKEvent(&storage.AFKEvent{StartTime: day1 + 3600, EndTime: sql.NullInt64{Int64: day1 + 7200, Valid: true}, TriggerType: "idle_timeout"})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: midnight, EndTime: midnight + 3600, DurationSeconds: 3600})
+	store.SaveFocusEvent(&storage.WindowFocusEvent{WindowTitle: "main.go", AppName: "Code", StartTime: day2, EndTime: day2 + 3600, DurationSeconds: 3600})
+
+	prevData, err := svc.GetTimelineGridData("2024-03-05")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+	data, err := svc.GetTimelineGridData("2024-03-06")
+	if err != nil {
+		t.Fatalf("GetTimelineGridData failed: %v", err)
+	}
+
+	if data.DayStats.PreviousDayTotalSeconds != prevData.DayStats.TotalSeconds {
+		t.Errorf("previous day total = %.0f, want %.0f", data.DayStats.PreviousDayTotalSeconds, prevData.DayStats.TotalSeconds)
+	}
+	if data.DayStats.PreviousDayLongestFocus != prevData.DayStats.LongestFocus {
+		t.Errorf("previous day longest = %.0f, want %.0f", data.DayStats.PreviousDayLongestFocus, prevData.DayStats.LongestFocus)
+	}
+}
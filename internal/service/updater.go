@@ -1,7 +1,11 @@
 package service
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -22,12 +26,24 @@ type UpdateService struct {
 	repoOwner      string
 	repoName       string
 
-	mu            sync.RWMutex
-	updatePending bool
-	pendingInfo   *UpdateInfo
-	lastCheck     time.Time
-	checkInterval time.Duration
-	enabled       bool
+	// deltaManifestURL lists binary patches between versions; executablePath locates the
+	// binary they apply to.
+	deltaManifestURL string
+	executablePath   func() (string, error)
+
+	// canaryManifestURL describes the current canary build (see updater_canary.go).
+	canaryManifestURL string
+
+	mu               sync.RWMutex
+	updatePending    bool
+	pendingInfo      *UpdateInfo
+	lastCheck        time.Time
+	checkInterval    time.Duration
+	enabled          bool
+	downloadProgress float64 // Percent (0-100) of the current update download
+	canaryEnabled    bool
+	canaryFraction   float64 // Largest canary rollout fraction to accept
+	machineID        string
 
 	stopCh chan struct{}
 	doneCh chan struct{}
@@ -39,6 +55,7 @@ type UpdateInfo struct {
 	ReleaseNotes string `json:"releaseNotes"`
 	DownloadURL  string `json:"downloadUrl"`
 	PublishedAt  string `json:"publishedAt"`
+	Canary       bool   `json:"canary"` // A staged-rollout build rather than the latest release
 }
 
 // UpdateStatus represents the current update state.
@@ -50,6 +67,31 @@ type UpdateStatus struct {
 	Enabled        bool        `json:"enabled"`
 }
 
+// DeltaUpdateInfo describes a binary patch that upgrades one version to another.
+type DeltaUpdateInfo struct {
+	FromVersion     string `json:"fromVersion"`
+	ToVersion       string `json:"toVersion"`
+	PatchURL        string `json:"patchUrl"`
+	PatchSize       int64  `json:"patchSize"`
+	FromSHA256      string `json:"fromSha256"` // Hash of the binary the patch applies to
+	ToSHA256        string `json:"toSha256"`   // Hash of the patched binary
+	FullFallbackURL string `json:"fullFallbackUrl"`
+}
+
+// deltaManifest is the delta-manifest.json release asset listing the available patches.
+type deltaManifest struct {
+	Patches []deltaManifestEntry `json:"patches"`
+}
+
+// deltaManifestEntry is a patch for one platform's release asset.
+type deltaManifestEntry struct {
+	Asset string `json:"asset"` // Release asset name the patch applies to, e.g. traq-linux-amd64.AppImage
+	DeltaUpdateInfo
+}
+
+// errDeltaBaseMismatch is returned when the installed binary isn't the one a patch was built from.
+var errDeltaBaseMismatch = errors.New("installed binary doesn't match the patch base")
+
 // GitHubRelease represents the GitHub API response for a release.
 type GitHubRelease struct {
 	TagName     string        `json:"tag_name"`
@@ -76,6 +118,10 @@ func NewUpdateService(currentVersion, dataDir string) *UpdateService {
 		enabled:        true,
 		stopCh:         make(chan struct{}),
 		doneCh:         make(chan struct{}),
+
+		deltaManifestURL:  "https://github.com/hmahadik/traq/releases/latest/download/delta-manifest.json",
+		canaryManifestURL: "https://github.com/hmahadik/traq/releases/download/canary/canary-manifest.json",
+		executablePath:    currentExecutablePath,
 	}
 }
 
@@ -131,8 +177,8 @@ func (s *UpdateService) checkAndDownload() {
 		return
 	}
 
-	// Download the update
-	if err := s.DownloadUpdate(info); err != nil {
+	// Download the update, as a binary patch if one applies to this install
+	if err := s.downloadPreferringDelta(info); err != nil {
 		log.Printf("Update download failed: %v", err)
 		return
 	}
@@ -140,12 +186,30 @@ func (s *UpdateService) checkAndDownload() {
 	log.Printf("Update v%s downloaded and staged, will apply on next restart or AFK", info.Version)
 }
 
-// CheckForUpdate checks GitHub for a newer release.
+// CheckForUpdate checks GitHub for a newer release. When canaries are enabled and this
+// machine is in the current canary's cohort, a newer canary build is preferred.
 func (s *UpdateService) CheckForUpdate() (*UpdateInfo, error) {
 	s.mu.Lock()
 	s.lastCheck = time.Now()
 	s.mu.Unlock()
 
+	stable, err := s.checkLatestRelease()
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := s.checkCanaryUpdate(stable)
+	if err != nil {
+		log.Printf("Canary update check failed: %v", err)
+	} else if canary != nil {
+		return canary, nil
+	}
+	return stable, nil
+}
+
+// checkLatestRelease returns the latest GitHub release if it is newer than the running version.
+func (s *UpdateService) checkLatestRelease() (*UpdateInfo, error) {
+
 	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/releases/latest", s.repoOwner, s.repoName)
 
 	req, err := http.NewRequest("GET", url, nil)
@@ -198,20 +262,26 @@ func (s *UpdateService) CheckForUpdate() (*UpdateInfo, error) {
 	}, nil
 }
 
-// findAssetURL finds the download URL for the current platform.
-func (s *UpdateService) findAssetURL(assets []GitHubAsset) string {
-	var expectedName string
-
+// platformAssetName returns the release asset name for the current platform, or "" if unsupported.
+func platformAssetName() string {
 	switch runtime.GOOS {
 	case "linux":
-		expectedName = "traq-linux-amd64.AppImage"
+		return "traq-linux-amd64.AppImage"
 	case "darwin":
-		expectedName = "traq-macos-universal.zip"
+		return "traq-macos-universal.zip"
 	case "windows":
-		expectedName = "traq-windows-amd64-installer.exe"
+		return "traq-windows-amd64-installer.exe"
 	default:
 		return ""
 	}
+}
+
+// findAssetURL finds the download URL for the current platform.
+func (s *UpdateService) findAssetURL(assets []GitHubAsset) string {
+	expectedName := platformAssetName()
+	if expectedName == "" {
+		return ""
+	}
 
 	for _, asset := range assets {
 		if asset.Name == expectedName {
@@ -222,18 +292,140 @@ func (s *UpdateService) findAssetURL(assets []GitHubAsset) string {
 	return ""
 }
 
-// DownloadUpdate downloads the update to the staging folder.
-func (s *UpdateService) DownloadUpdate(info *UpdateInfo) error {
-	// Create updates directory
-	updatesDir := filepath.Join(s.dataDir, "updates")
-	if err := os.MkdirAll(updatesDir, 0755); err != nil {
+// CheckForDeltaUpdate checks the delta manifest for a patch from the current version to a
+// newer one on this platform. Returns nil if there is none.
+func (s *UpdateService) CheckForDeltaUpdate() (*DeltaUpdateInfo, error) {
+	req, err := http.NewRequest("GET", s.deltaManifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "traq-updater")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		// Release has no patches
+		return nil, nil
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("delta manifest returned status %d", resp.StatusCode)
+	}
+
+	var manifest deltaManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid delta manifest: %w", err)
+	}
+
+	// Pick the patch to the newest version
+	asset := platformAssetName()
+	var best *DeltaUpdateInfo
+	for i := range manifest.Patches {
+		entry := &manifest.Patches[i]
+		if entry.Asset != asset || entry.FromVersion != s.currentVersion || entry.PatchURL == "" {
+			continue
+		}
+		if !isNewerVersion(entry.ToVersion, s.currentVersion) {
+			continue
+		}
+		if best == nil || isNewerVersion(entry.ToVersion, best.ToVersion) {
+			best = &entry.DeltaUpdateInfo
+		}
+	}
+	return best, nil
+}
+
+// downloadPreferringDelta stages an update from a binary patch when one upgrades the
+// installed binary to info's version, falling back to downloading the full release.
+func (s *UpdateService) downloadPreferringDelta(info *UpdateInfo) error {
+	delta, err := s.CheckForDeltaUpdate()
+	if err != nil {
+		log.Printf("Delta update check failed: %v", err)
+	}
+	if delta == nil || delta.ToVersion != info.Version {
+		return s.DownloadUpdate(info)
+	}
+
+	if err := s.DownloadDeltaUpdate(delta, info); err != nil {
+		log.Printf("Delta update failed, downloading full release: %v", err)
+		full := *info
+		if delta.FullFallbackURL != "" {
+			full.DownloadURL = delta.FullFallbackURL
+		}
+		return s.DownloadUpdate(&full)
+	}
+	return nil
+}
+
+// DownloadDeltaUpdate downloads a binary patch, applies it to the installed binary, and
+// stages the result. Fails with errDeltaBaseMismatch if the installed binary isn't the
+// one the patch was built from.
+func (s *UpdateService) DownloadDeltaUpdate(delta *DeltaUpdateInfo, info *UpdateInfo) error {
+	exe, err := s.executablePath()
+	if err != nil {
+		return err
+	}
+	old, err := os.ReadFile(exe)
+	if err != nil {
+		return fmt.Errorf("failed to read installed binary: %w", err)
+	}
+	if delta.FromSHA256 == "" || !strings.EqualFold(sha256Hex(old), delta.FromSHA256) {
+		return errDeltaBaseMismatch
+	}
+	// A patched binary is only ever staged after checking it against the expected hash
+	if delta.ToSHA256 == "" {
+		return fmt.Errorf("delta update for %s has no target hash", delta.ToVersion)
+	}
+
+	s.setDownloadProgress(0)
+	resp, err := http.Get(delta.PatchURL)
+	if err != nil {
 		return err
 	}
+	defer resp.Body.Close()
 
-	// Download to temporary file first
-	stagingPath := filepath.Join(updatesDir, fmt.Sprintf("traq-%s.new", info.Version))
-	tmpPath := stagingPath + ".tmp"
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("patch download failed with status %d", resp.StatusCode)
+	}
+
+	size := resp.ContentLength
+	if size <= 0 {
+		size = delta.PatchSize
+	}
+	var patch bytes.Buffer
+	if _, err := io.Copy(&patch, s.trackProgress(resp.Body, size)); err != nil {
+		return err
+	}
+
+	patched, err := bspatch(old, patch.Bytes())
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(sha256Hex(patched), delta.ToSHA256) {
+		return fmt.Errorf("patched binary hash mismatch")
+	}
+
+	if err := s.stageUpdate(info.Version, bytes.NewReader(patched)); err != nil {
+		return err
+	}
+	s.markPending(info)
+	return nil
+}
 
+// GetUpdateDownloadProgress returns the percent (0-100) of the current or last update download.
+func (s *UpdateService) GetUpdateDownloadProgress() float64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.downloadProgress
+}
+
+// DownloadUpdate downloads the update to the staging folder.
+func (s *UpdateService) DownloadUpdate(info *UpdateInfo) error {
+	s.setDownloadProgress(0)
 	resp, err := http.Get(info.DownloadURL)
 	if err != nil {
 		return err
@@ -244,12 +436,31 @@ func (s *UpdateService) DownloadUpdate(info *UpdateInfo) error {
 		return fmt.Errorf("download failed with status %d", resp.StatusCode)
 	}
 
+	if err := s.stageUpdate(info.Version, s.trackProgress(resp.Body, resp.ContentLength)); err != nil {
+		return err
+	}
+	s.markPending(info)
+	return nil
+}
+
+// stageUpdate writes an update binary to the staging folder, where ApplyPendingUpdate finds it.
+func (s *UpdateService) stageUpdate(version string, r io.Reader) error {
+	// Create updates directory
+	updatesDir := filepath.Join(s.dataDir, "updates")
+	if err := os.MkdirAll(updatesDir, 0755); err != nil {
+		return err
+	}
+
+	// Write to temporary file first
+	stagingPath := filepath.Join(updatesDir, fmt.Sprintf("traq-%s.new", version))
+	tmpPath := stagingPath + ".tmp"
+
 	out, err := os.Create(tmpPath)
 	if err != nil {
 		return err
 	}
 
-	_, err = io.Copy(out, resp.Body)
+	_, err = io.Copy(out, r)
 	out.Close()
 	if err != nil {
 		os.Remove(tmpPath)
@@ -269,13 +480,57 @@ func (s *UpdateService) DownloadUpdate(info *UpdateInfo) error {
 		}
 	}
 
-	// Mark update as pending
+	return nil
+}
+
+// markPending records a staged update as ready to apply.
+func (s *UpdateService) markPending(info *UpdateInfo) {
 	s.mu.Lock()
 	s.updatePending = true
 	s.pendingInfo = info
+	s.downloadProgress = 100
+	s.mu.Unlock()
+}
+
+// setDownloadProgress records the download progress percent.
+func (s *UpdateService) setDownloadProgress(percent float64) {
+	s.mu.Lock()
+	s.downloadProgress = percent
 	s.mu.Unlock()
+}
 
-	return nil
+// trackProgress wraps a download body to update the download progress as it's read.
+// Progress isn't reported when the size is unknown.
+func (s *UpdateService) trackProgress(r io.Reader, size int64) io.Reader {
+	if size <= 0 {
+		return r
+	}
+	return &progressReader{r: r, total: size, report: s.setDownloadProgress}
+}
+
+// progressReader reports the percent of total bytes read.
+type progressReader struct {
+	r      io.Reader
+	read   int64
+	total  int64
+	report func(percent float64)
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	p.read += int64(n)
+	percent := float64(p.read) / float64(p.total) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	p.report(percent)
+	return n, err
+}
+
+// sha256Hex returns the hex-encoded SHA-256 of data.
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
 
 // HasPendingUpdate returns true if an update is staged and ready to apply.
@@ -312,21 +567,9 @@ func ApplyPendingUpdate(dataDir string) (bool, error) {
 		return false, nil // No pending update
 	}
 
-	// Get current executable path
-	// For AppImage, use APPIMAGE env var which points to the actual .AppImage file
-	exe := os.Getenv("APPIMAGE")
-	if exe == "" {
-		// Not running as AppImage, use regular executable path
-		var err error
-		exe, err = os.Executable()
-		if err != nil {
-			return false, err
-		}
-		// Resolve symlinks to get the real path
-		exe, err = filepath.EvalSymlinks(exe)
-		if err != nil {
-			return false, err
-		}
+	exe, err := currentExecutablePath()
+	if err != nil {
+		return false, err
 	}
 
 	// Create backup of current binary
@@ -357,6 +600,22 @@ func ApplyPendingUpdate(dataDir string) (bool, error) {
 	return true, nil
 }
 
+// currentExecutablePath returns the path of the installed binary that updates replace.
+func currentExecutablePath() (string, error) {
+	// For AppImage, use APPIMAGE env var which points to the actual .AppImage file
+	if exe := os.Getenv("APPIMAGE"); exe != "" {
+		return exe, nil
+	}
+
+	// Not running as AppImage, use regular executable path
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	// Resolve symlinks to get the real path
+	return filepath.EvalSymlinks(exe)
+}
+
 // ApplyAndRestart applies the pending update and restarts the application.
 func (s *UpdateService) ApplyAndRestart() error {
 	applied, err := ApplyPendingUpdate(s.dataDir)
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// canaryManifest is the canary-manifest.json published with each canary build. The
+// build is rolled out to CanaryFraction of the machines that opted into canaries.
+type canaryManifest struct {
+	Version        string        `json:"version"`
+	CanaryFraction float64       `json:"canary_fraction"` // 0-1
+	ReleaseNotes   string        `json:"release_notes"`
+	PublishedAt    string        `json:"published_at"`
+	Assets         []GitHubAsset `json:"assets"`
+}
+
+// IsInCanaryCohort reports whether a machine is among the given fraction (0-1) of
+// machines that receive a canary build. Machines are placed on [0, 1) by a hash of their
+// ID, so the assignment is stable across checks and restarts, and a machine in the cohort
+// stays in it as the fraction grows.
+func IsInCanaryCohort(machineID string, fraction float64) bool {
+	if fraction <= 0 || machineID == "" {
+		return false
+	}
+	if fraction >= 1 {
+		return true
+	}
+	sum := sha256.Sum256([]byte("traq-canary:" + machineID))
+	position := float64(binary.BigEndian.Uint64(sum[:8])) / (1 << 64)
+	return position < fraction
+}
+
+// SetCanary opts this machine into canary builds. A canary is only installed when its
+// manifest's rollout fraction is at most maxFraction and the machine falls in its cohort.
+func (s *UpdateService) SetCanary(enabled bool, maxFraction float64) {
+	s.mu.Lock()
+	s.canaryEnabled = enabled
+	s.canaryFraction = maxFraction
+	s.mu.Unlock()
+}
+
+// SetMachineID sets the ID used to place this machine in a canary cohort.
+func (s *UpdateService) SetMachineID(id string) {
+	s.mu.Lock()
+	s.machineID = id
+	s.mu.Unlock()
+}
+
+// checkCanaryUpdate returns the canary build if canaries are enabled, this machine is in
+// its cohort, and it is newer than both the running version and stable, the newer stable
+// release (nil if there is none). Returns nil otherwise.
+func (s *UpdateService) checkCanaryUpdate(stable *UpdateInfo) (*UpdateInfo, error) {
+	s.mu.RLock()
+	enabled, maxFraction, machineID := s.canaryEnabled, s.canaryFraction, s.machineID
+	s.mu.RUnlock()
+	if !enabled {
+		return nil, nil
+	}
+
+	req, err := http.NewRequest("GET", s.canaryManifestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "traq-updater")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == 404 {
+		// No canary build published
+		return nil, nil
+	}
+	if resp.StatusCode != 200 {
+		return nil, fmt.Errorf("canary manifest returned status %d", resp.StatusCode)
+	}
+
+	var manifest canaryManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("invalid canary manifest: %w", err)
+	}
+
+	if manifest.CanaryFraction > maxFraction || !IsInCanaryCohort(machineID, manifest.CanaryFraction) {
+		return nil, nil
+	}
+	if !isNewerVersion(manifest.Version, s.currentVersion) {
+		return nil, nil
+	}
+	if stable != nil && !isNewerVersion(manifest.Version, stable.Version) {
+		return nil, nil
+	}
+
+	assetURL := s.findAssetURL(manifest.Assets)
+	if assetURL == "" {
+		return nil, nil
+	}
+	return &UpdateInfo{
+		Version:      manifest.Version,
+		ReleaseNotes: manifest.ReleaseNotes,
+		DownloadURL:  assetURL,
+		PublishedAt:  manifest.PublishedAt,
+		Canary:       true,
+	}, nil
+}
@@ -0,0 +1,276 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Patch from deltaOldBinary to deltaNewBinary, generated with bsdiff 4.x's format.
+const deltaTestPatch = "QlNESUZGNDArAAAAAAAAACwAAAAAAAAAJQAAAAAAAABCWmg5MUFZJlNZYWr7PQAABeAASAgAECAAMM00GMilrji7kinChIMLV9noQlpoOTFBWSZTWcaHO4AAAABgAGAAEQAgADDNNBJoZKmTi7kinChIY0OdwABCWmg5MUFZJlNZCwjIoAAABNGAABBAAANgDMAgADEAMCADam4WhII8XckU4UJALCMigA=="
+
+const (
+	deltaOldBinary = "traq binary version 1.0.0\n"
+	deltaNewBinary = "traq binary version 1.1.0 with fixes\n"
+	deltaOldSHA256 = "971982f5159c498feaaf58d609a87e121ce8de6d86bed87391c7601ab50d76ea"
+	deltaNewSHA256 = "35cefcc6a10a67f88981f76e5df2a72bc233df98fe891a30faeff7a99aba0598"
+)
+
+func TestBspatch(t *testing.T) {
+	patch, _ := base64.StdEncoding.DecodeString(deltaTestPatch)
+
+	got, err := bspatch([]byte(deltaOldBinary), patch)
+	if err != nil {
+		t.Fatalf("bspatch failed: %v", err)
+	}
+	if string(got) != deltaNewBinary {
+		t.Errorf("expected %q, got %q", deltaNewBinary, got)
+	}
+
+	if _, err := bspatch([]byte(deltaOldBinary), patch[:40]); !errors.Is(err, errCorruptPatch) {
+		t.Errorf("expected errCorruptPatch for truncated patch, got %v", err)
+	}
+	if _, err := bspatch([]byte(deltaOldBinary), []byte("not a patch")); !errors.Is(err, errCorruptPatch) {
+		t.Errorf("expected errCorruptPatch for bad header, got %v", err)
+	}
+
+	// Block lengths that overflow when summed, and an oversized new file
+	header := func(ctrlLen, diffLen, newSize uint64) []byte {
+		h := []byte(bsdiffMagic)
+		for _, v := range []uint64{ctrlLen, diffLen, newSize} {
+			h = binary.LittleEndian.AppendUint64(h, v)
+		}
+		return append(h, make([]byte, 16)...)
+	}
+	malformed := map[string][]byte{
+		"overflowing lengths": header(math.MaxInt64-16, math.MaxInt64-16, 10),
+		"huge new size":       header(0, 0, math.MaxInt64),
+	}
+	for name, p := range malformed {
+		if _, err := bspatch([]byte(deltaOldBinary), p); !errors.Is(err, errCorruptPatch) {
+			t.Errorf("%s: expected errCorruptPatch, got %v", name, err)
+		}
+	}
+}
+
+// setupDeltaTest serves a delta manifest and patch, and installs deltaOldBinary as the
+// running executable.
+func setupDeltaTest(t *testing.T) (*UpdateService, *httptest.Server) {
+	t.Helper()
+	patch, _ := base64.StdEncoding.DecodeString(deltaTestPatch)
+
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	mux.HandleFunc("/delta-manifest.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(deltaManifest{Patches: []deltaManifestEntry{
+			{Asset: "traq-other-platform", DeltaUpdateInfo: DeltaUpdateInfo{
+				FromVersion: "1.0.0", ToVersion: "1.1.0", PatchURL: server.URL + "/other.patch",
+			}},
+			{Asset: platformAssetName(), DeltaUpdateInfo: DeltaUpdateInfo{
+				FromVersion: "0.9.0", ToVersion: "1.1.0", PatchURL: server.URL + "/old.patch",
+			}},
+			{Asset: platformAssetName(), DeltaUpdateInfo: DeltaUpdateInfo{
+				FromVersion:     "1.0.0",
+				ToVersion:       "1.1.0",
+				PatchURL:        server.URL + "/1.0.0-1.1.0.patch",
+				PatchSize:       int64(len(patch)),
+				FromSHA256:      deltaOldSHA256,
+				ToSHA256:        deltaNewSHA256,
+				FullFallbackURL: server.URL + "/full",
+			}},
+		}})
+	})
+	mux.HandleFunc("/1.0.0-1.1.0.patch", func(w http.ResponseWriter, r *http.Request) {
+		w.Write(patch)
+	})
+	mux.HandleFunc("/full", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(deltaNewBinary))
+	})
+
+	dir := t.TempDir()
+	exe := filepath.Join(dir, "traq")
+	if err := os.WriteFile(exe, []byte(deltaOldBinary), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	svc := NewUpdateService("1.0.0", dir)
+	svc.deltaManifestURL = server.URL + "/delta-manifest.json"
+	svc.executablePath = func() (string, error) { return exe, nil }
+	return svc, server
+}
+
+func TestDeltaUpdate(t *testing.T) {
+	svc, server := setupDeltaTest(t)
+
+	delta, err := svc.CheckForDeltaUpdate()
+	if err != nil {
+		t.Fatalf("CheckForDeltaUpdate failed: %v", err)
+	}
+	if delta == nil || delta.FromVersion != "1.0.0" || delta.ToVersion != "1.1.0" || delta.PatchSize != 169 {
+		t.Fatalf("expected 1.0.0 -> 1.1.0 patch, got %+v", delta)
+	}
+
+	info := &UpdateInfo{Version: "1.1.0", DownloadURL: server.URL + "/full"}
+	if err := svc.DownloadDeltaUpdate(delta, info); err != nil {
+		t.Fatalf("DownloadDeltaUpdate failed: %v", err)
+	}
+
+	staged, err := os.ReadFile(filepath.Join(svc.dataDir, "updates", "traq-1.1.0.new"))
+	if err != nil {
+		t.Fatalf("expected staged update: %v", err)
+	}
+	if string(staged) != deltaNewBinary {
+		t.Errorf("expected patched binary %q, got %q", deltaNewBinary, staged)
+	}
+	if status := svc.GetStatus(); !status.UpdatePending {
+		t.Error("expected update to be pending")
+	}
+	if progress := svc.GetUpdateDownloadProgress(); progress != 100 {
+		t.Errorf("expected progress 100, got %v", progress)
+	}
+}
+
+func TestDeltaUpdate_HashMismatchFallsBack(t *testing.T) {
+	svc, server := setupDeltaTest(t)
+
+	// A locally modified binary can't be patched
+	exe, _ := svc.executablePath()
+	if err := os.WriteFile(exe, []byte("traq binary version 1.0.0-custom\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	delta, err := svc.CheckForDeltaUpdate()
+	if err != nil || delta == nil {
+		t.Fatalf("expected delta update, got %+v, %v", delta, err)
+	}
+	info := &UpdateInfo{Version: "1.1.0", DownloadURL: server.URL + "/missing"}
+	if err := svc.DownloadDeltaUpdate(delta, info); !errors.Is(err, errDeltaBaseMismatch) {
+		t.Fatalf("expected errDeltaBaseMismatch, got %v", err)
+	}
+
+	// The full download uses the manifest's fallback URL
+	if err := svc.downloadPreferringDelta(info); err != nil {
+		t.Fatalf("downloadPreferringDelta failed: %v", err)
+	}
+	staged, err := os.ReadFile(filepath.Join(svc.dataDir, "updates", "traq-1.1.0.new"))
+	if err != nil || string(staged) != deltaNewBinary {
+		t.Errorf("expected full download to be staged, got %q, %v", staged, err)
+	}
+}
+
+func TestDeltaUpdate_RequiresTargetHash(t *testing.T) {
+	svc, server := setupDeltaTest(t)
+
+	delta, err := svc.CheckForDeltaUpdate()
+	if err != nil || delta == nil {
+		t.Fatalf("expected delta update, got %+v, %v", delta, err)
+	}
+	delta.ToSHA256 = ""
+	info := &UpdateInfo{Version: "1.1.0", DownloadURL: server.URL + "/full"}
+	if err := svc.DownloadDeltaUpdate(delta, info); err == nil {
+		t.Fatal("expected an error for a delta without a target hash")
+	}
+	if _, err := os.Stat(filepath.Join(svc.dataDir, "updates", "traq-1.1.0.new")); !os.IsNotExist(err) {
+		t.Errorf("expected nothing to be staged, got %v", err)
+	}
+}
+
+func TestIsInCanaryCohort(t *testing.T) {
+	// Assignment is stable, and a machine stays in the cohort as the rollout grows
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("machine-%d", i)
+		if IsInCanaryCohort(id, 0.3) != IsInCanaryCohort(id, 0.3) {
+			t.Fatalf("cohort assignment for %s is not stable", id)
+		}
+		if IsInCanaryCohort(id, 0.3) && !IsInCanaryCohort(id, 0.6) {
+			t.Errorf("%s left the cohort when the fraction grew", id)
+		}
+	}
+	if IsInCanaryCohort("machine-1", 0) || !IsInCanaryCohort("machine-1", 1) || IsInCanaryCohort("", 0.5) {
+		t.Error("unexpected cohort at the fraction bounds")
+	}
+
+	// Machines are spread uniformly: each tenth of the range holds about a tenth of them
+	const machines = 20000
+	var buckets [10]int
+	for i := 0; i < machines; i++ {
+		id := fmt.Sprintf("%08x-machine", i)
+		for b := 0; b < 10; b++ {
+			if IsInCanaryCohort(id, float64(b+1)/10) {
+				buckets[b]++
+				break
+			}
+		}
+	}
+	for b, n := range buckets {
+		if share := float64(n) / machines; math.Abs(share-0.1) > 0.01 {
+			t.Errorf("bucket %d holds %.3f of machines, want about 0.1", b, share)
+		}
+	}
+}
+
+func TestCheckCanaryUpdate(t *testing.T) {
+	manifest := canaryManifest{
+		Version:        "1.2.0-canary.1",
+		CanaryFraction: 0.5,
+		Assets:         []GitHubAsset{{Name: platformAssetName(), BrowserDownloadURL: "https://example.com/canary"}},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(manifest)
+	}))
+	defer server.Close()
+
+	// Find machines on either side of the cohort boundary
+	var inCohort, outOfCohort string
+	for i := 0; inCohort == "" || outOfCohort == ""; i++ {
+		id := fmt.Sprintf("machine-%d", i)
+		if IsInCanaryCohort(id, manifest.CanaryFraction) {
+			inCohort = id
+		} else {
+			outOfCohort = id
+		}
+	}
+
+	svc := NewUpdateService("1.1.0", t.TempDir())
+	svc.canaryManifestURL = server.URL
+	svc.SetMachineID(inCohort)
+
+	if info, err := svc.checkCanaryUpdate(nil); err != nil || info != nil {
+		t.Errorf("expected no canary while disabled, got %+v, %v", info, err)
+	}
+
+	svc.SetCanary(true, 1)
+	info, err := svc.checkCanaryUpdate(nil)
+	if err != nil {
+		t.Fatalf("checkCanaryUpdate failed: %v", err)
+	}
+	if info == nil || info.Version != "1.2.0-canary.1" || !info.Canary || info.DownloadURL != "https://example.com/canary" {
+		t.Fatalf("expected the canary build, got %+v", info)
+	}
+
+	// A newer stable release wins over the canary
+	if info, _ := svc.checkCanaryUpdate(&UpdateInfo{Version: "1.2.0"}); info != nil {
+		t.Errorf("expected stable 1.2.0 to win, got %+v", info)
+	}
+
+	// The rollout is wider than this machine accepts
+	svc.SetCanary(true, 0.25)
+	if info, _ := svc.checkCanaryUpdate(nil); info != nil {
+		t.Errorf("expected no canary above the accepted fraction, got %+v", info)
+	}
+
+	svc.SetCanary(true, 1)
+	svc.SetMachineID(outOfCohort)
+	if info, _ := svc.checkCanaryUpdate(nil); info != nil {
+		t.Errorf("expected no canary outside the cohort, got %+v", info)
+	}
+}
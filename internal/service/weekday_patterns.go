@@ -0,0 +1,209 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// defaultWeekdayPatternWeeks is the history analyzed when no number of weeks is given.
+const defaultWeekdayPatternWeeks = 4
+
+// WeekdayPatterns describes a typical day for each day of the week.
+type WeekdayPatterns struct {
+	Days      [7]*WeekdayProfile `json:"days"`      // Indexed by time.Weekday, Sunday first
+	DataWeeks int                `json:"dataWeeks"` // Weeks of history analyzed
+	Insights  []*Insight         `json:"insights"`  // e.g. "You're most productive on Tuesdays"
+}
+
+// WeekdayProfile averages one day of the week over the analyzed weeks. Averages include
+// days without activity; the typical hours and BestHour only consider active days.
+type WeekdayProfile struct {
+	Weekday                string  `json:"weekday"`    // e.g. "Monday"
+	ActiveDays             int     `json:"activeDays"` // Days with tracked sessions
+	AverageActiveMinutes   float64 `json:"averageActiveMinutes"`
+	AverageCommits         float64 `json:"averageCommits"`
+	AverageMeetingMinutes  float64 `json:"averageMeetingMinutes"`
+	AverageDeepWorkMinutes float64 `json:"averageDeepWorkMinutes"`
+	BestHour               int     `json:"bestHour"`         // Hour (0-23) with the most productive time, -1 if none
+	TypicalStartHour       float64 `json:"typicalStartHour"` // Average first session start, e.g. 9.5 for 9:30
+	TypicalEndHour         float64 `json:"typicalEndHour"`   // Average last session end
+}
+
+// GetWeekdayPatterns analyzes the last numWeeks full weeks, ending yesterday, and profiles
+// each day of the week. If numWeeks <= 0, four weeks are analyzed.
+func (s *AnalyticsService) GetWeekdayPatterns(numWeeks int) (*WeekdayPatterns, error) {
+	now := time.Now()
+	return s.weekdayPatterns(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local), numWeeks)
+}
+
+// weekdayPatterns computes weekday profiles for the numWeeks weeks before end (a local midnight).
+func (s *AnalyticsService) weekdayPatterns(end time.Time, numWeeks int) (*WeekdayPatterns, error) {
+	if numWeeks <= 0 {
+		numWeeks = defaultWeekdayPatternWeeks
+	}
+	start := end.AddDate(0, 0, -7*numWeeks)
+	rangeStart, rangeEnd := start.Unix(), end.Unix()-1
+
+	sessions, err := s.store.GetSessionsByTimeRange(rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+	events, err := s.store.GetWindowFocusEventsByTimeRange(rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch focus events: %w", err)
+	}
+	commits, err := s.store.GetGitCommitsByTimeRange(rangeStart, rangeEnd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch git commits: %w", err)
+	}
+
+	appNames := make(map[string]bool)
+	for _, evt := range events {
+		appNames[evt.AppName] = true
+	}
+	appNamesList := make([]string, 0, len(appNames))
+	for name := range appNames {
+		appNamesList = append(appNamesList, name)
+	}
+	timelineCategories, err := s.store.GetAppTimelineCategories(appNamesList)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch app categories: %w", err)
+	}
+	productive := make(map[string]bool, len(appNamesList))
+	for _, name := range appNamesList {
+		productive[name] = s.CategorizeApp(name) == CategoryProductive
+	}
+
+	type weekdayTotals struct {
+		activeMinutes, commits, meetingMinutes, deepWorkMinutes float64
+		activeDays                                              int
+		startHours, endHours                                    float64
+		productiveByHour                                        [24]float64
+	}
+	var totals [7]weekdayTotals
+
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		dayStart, dayEnd := day.Unix(), day.AddDate(0, 0, 1).Unix()-1
+		t := &totals[day.Weekday()]
+
+		var daySessions []*storage.Session
+		for _, sess := range sessions {
+			if sess.StartTime <= dayEnd && (!sess.EndTime.Valid || sess.EndTime.Int64 > dayStart) {
+				daySessions = append(daySessions, sess)
+			}
+		}
+		if activeSeconds := sessionActiveSeconds(daySessions, dayStart, dayEnd); activeSeconds > 0 {
+			t.activeMinutes += float64(activeSeconds) / 60
+			t.activeDays++
+			first, last := dayEnd, dayStart
+			for _, sess := range daySessions {
+				first = min(first, max(sess.StartTime, dayStart))
+				sessEnd := time.Now().Unix()
+				if sess.EndTime.Valid {
+					sessEnd = sess.EndTime.Int64
+				}
+				last = max(last, min(sessEnd, dayEnd))
+			}
+			t.startHours += hourOfDay(time.Unix(first, 0))
+			t.endHours += hourOfDay(time.Unix(last, 0))
+		}
+
+		for _, commit := range commits {
+			if commit.Timestamp >= dayStart && commit.Timestamp <= dayEnd {
+				t.commits++
+			}
+		}
+
+		var dayEvents []*storage.WindowFocusEvent
+		for _, evt := range events {
+			if evt.StartTime <= dayEnd && evt.EndTime > dayStart {
+				dayEvents = append(dayEvents, evt)
+			}
+		}
+		for _, meeting := range s.meetings.detect(dayEvents) {
+			t.meetingMinutes += meeting.DurationSeconds / 60
+		}
+		for _, block := range detectDeepWorkBlocks(dayEvents, timelineCategories, defaultDeepWorkMinutes, dayStart, dayEnd) {
+			t.deepWorkMinutes += float64(block.DurationMinutes)
+		}
+		for _, evt := range dayEvents {
+			if !productive[evt.AppName] {
+				continue
+			}
+			// Spread the event over the hours it covers
+			for cursor, to := max(evt.StartTime, dayStart), min(evt.EndTime, dayEnd+1); cursor < to; {
+				ct := time.Unix(cursor, 0)
+				next := min(time.Date(ct.Year(), ct.Month(), ct.Day(), ct.Hour()+1, 0, 0, 0, time.Local).Unix(), to)
+				t.productiveByHour[ct.Hour()] += float64(next - cursor)
+				cursor = next
+			}
+		}
+	}
+
+	patterns := &WeekdayPatterns{DataWeeks: numWeeks, Insights: []*Insight{}}
+	weeks := float64(numWeeks)
+	for weekday := range totals {
+		t := &totals[weekday]
+		profile := &WeekdayProfile{
+			Weekday:                time.Weekday(weekday).String(),
+			ActiveDays:             t.activeDays,
+			AverageActiveMinutes:   t.activeMinutes / weeks,
+			AverageCommits:         t.commits / weeks,
+			AverageMeetingMinutes:  t.meetingMinutes / weeks,
+			AverageDeepWorkMinutes: t.deepWorkMinutes / weeks,
+			BestHour:               -1,
+		}
+		if t.activeDays > 0 {
+			profile.TypicalStartHour = t.startHours / float64(t.activeDays)
+			profile.TypicalEndHour = t.endHours / float64(t.activeDays)
+		}
+		for hour, seconds := range t.productiveByHour {
+			if seconds > 0 && (profile.BestHour < 0 || seconds > t.productiveByHour[profile.BestHour]) {
+				profile.BestHour = hour
+			}
+		}
+		patterns.Days[weekday] = profile
+	}
+
+	patterns.Insights = weekdayInsights(patterns.Days)
+	return patterns, nil
+}
+
+// weekdayInsights points out the weekdays with the most deep work and the most meetings.
+func weekdayInsights(days [7]*WeekdayProfile) []*Insight {
+	var mostProductive, mostMeetings *WeekdayProfile
+	for _, day := range days {
+		if day.AverageDeepWorkMinutes > 0 && (mostProductive == nil || day.AverageDeepWorkMinutes > mostProductive.AverageDeepWorkMinutes) {
+			mostProductive = day
+		}
+		if day.AverageMeetingMinutes > 0 && (mostMeetings == nil || day.AverageMeetingMinutes > mostMeetings.AverageMeetingMinutes) {
+			mostMeetings = day
+		}
+	}
+
+	insights := []*Insight{}
+	if mostProductive != nil {
+		insights = append(insights, &Insight{
+			Type:     InsightWeekdayProductive,
+			Title:    fmt.Sprintf("You're most productive on %ss", mostProductive.Weekday),
+			Body:     fmt.Sprintf("%s of deep work on an average %s.", formatMinutes(int64(mostProductive.AverageDeepWorkMinutes)), mostProductive.Weekday),
+			Severity: InsightPositive,
+		})
+	}
+	if mostMeetings != nil {
+		insights = append(insights, &Insight{
+			Type:     InsightWeekdayMeetings,
+			Title:    fmt.Sprintf("%ss are your most meeting-heavy day", mostMeetings.Weekday),
+			Body:     fmt.Sprintf("%s of meetings on an average %s.", formatMinutes(int64(mostMeetings.AverageMeetingMinutes)), mostMeetings.Weekday),
+			Severity: InsightInfo,
+		})
+	}
+	return insights
+}
+
+// hourOfDay returns the local time of day of t in fractional hours, e.g. 9.5 for 9:30.
+func hourOfDay(t time.Time) float64 {
+	return float64(t.Hour()) + float64(t.Minute())/60 + float64(t.Second())/3600
+}
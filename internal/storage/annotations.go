@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AnnotateScreenshot adds a text label to a screenshot. annotatedBy is "user" or "ai".
+// Re-adding an existing label is a no-op, except that a user annotation takes over an AI one.
+func (s *Store) AnnotateScreenshot(screenshotID int64, label, annotatedBy string) error {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return fmt.Errorf("annotation label is empty")
+	}
+	if annotatedBy != "user" && annotatedBy != "ai" {
+		return fmt.Errorf("invalid annotation source: %s", annotatedBy)
+	}
+
+	_, err := s.db.Exec(`
+		INSERT INTO screenshot_annotations (screenshot_id, label, annotated_at, annotated_by)
+		VALUES (?, ?, strftime('%s', 'now'), ?)
+		ON CONFLICT(screenshot_id, label) DO UPDATE SET
+			annotated_by = excluded.annotated_by,
+			annotated_at = excluded.annotated_at
+		WHERE excluded.annotated_by = 'user' AND annotated_by = 'ai'`,
+		screenshotID, label, annotatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to annotate screenshot: %w", err)
+	}
+	return nil
+}
+
+// GetScreenshotAnnotations returns the labels on a screenshot, sorted alphabetically.
+func (s *Store) GetScreenshotAnnotations(screenshotID int64) ([]string, error) {
+	rows, err := s.db.Query(`
+		SELECT label FROM screenshot_annotations
+		WHERE screenshot_id = ?
+		ORDER BY label ASC`, screenshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screenshot annotations: %w", err)
+	}
+	defer rows.Close()
+
+	labels := []string{}
+	for rows.Next() {
+		var label string
+		if err := rows.Scan(&label); err != nil {
+			return nil, fmt.Errorf("failed to scan screenshot annotation: %w", err)
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+// DeleteScreenshotAnnotation removes a label from a screenshot.
+// Removing a label that isn't there is a no-op.
+func (s *Store) DeleteScreenshotAnnotation(screenshotID int64, label string) error {
+	_, err := s.db.Exec(`
+		DELETE FROM screenshot_annotations
+		WHERE screenshot_id = ? AND label = ?`,
+		screenshotID, strings.TrimSpace(label))
+	if err != nil {
+		return fmt.Errorf("failed to delete screenshot annotation: %w", err)
+	}
+	return nil
+}
+
+// SearchScreenshotsByAnnotation returns screenshots within a time range that carry a label
+// (case-insensitive), ordered by time.
+func (s *Store) SearchScreenshotsByAnnotation(label string, start, end int64) ([]*Screenshot, error) {
+	rows, err := s.db.Query(`
+		SELECT s.id, s.timestamp, s.filepath, s.dhash, s.window_title, s.app_name, s.window_class, s.process_pid,
+		       s.window_x, s.window_y, s.window_width, s.window_height,
+		       s.monitor_name, s.monitor_width, s.monitor_height, s.monitor_index, s.session_id, s.created_at, s.quality
+		FROM screenshots s
+		JOIN screenshot_annotations a ON a.screenshot_id = s.id
+		WHERE a.label = ? AND s.timestamp >= ? AND s.timestamp <= ?
+		ORDER BY s.timestamp ASC, s.monitor_index ASC`,
+		strings.TrimSpace(label), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search screenshots by annotation: %w", err)
+	}
+	defer rows.Close()
+
+	return scanScreenshots(rows)
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScreenshotAnnotations(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	firstID, err := store.SaveScreenshot(&Screenshot{Timestamp: now, Filepath: "/test/a.webp"})
+	if err != nil {
+		t.Fatalf("SaveScreenshot failed: %v", err)
+	}
+	secondID, err := store.SaveScreenshot(&Screenshot{Timestamp: now + 60, Filepath: "/test/b.webp"})
+	if err != nil {
+		t.Fatalf("SaveScreenshot failed: %v", err)
+	}
+
+	if err := store.AnnotateScreenshot(firstID, "Bug", "user"); err != nil {
+		t.Fatalf("AnnotateScreenshot failed: %v", err)
+	}
+	if err := store.AnnotateScreenshot(firstID, "design", "ai"); err != nil {
+		t.Fatalf("AnnotateScreenshot failed: %v", err)
+	}
+	// Labels are case-insensitive, so this is the same annotation
+	if err := store.AnnotateScreenshot(firstID, " bug ", "ai"); err != nil {
+		t.Fatalf("AnnotateScreenshot failed: %v", err)
+	}
+	if err := store.AnnotateScreenshot(secondID, "bug", "user"); err != nil {
+		t.Fatalf("AnnotateScreenshot failed: %v", err)
+	}
+
+	if err := store.AnnotateScreenshot(firstID, "  ", "user"); err == nil {
+		t.Error("expected error for empty label")
+	}
+	if err := store.AnnotateScreenshot(firstID, "x", "robot"); err == nil {
+		t.Error("expected error for invalid source")
+	}
+
+	labels, err := store.GetScreenshotAnnotations(firstID)
+	if err != nil {
+		t.Fatalf("GetScreenshotAnnotations failed: %v", err)
+	}
+	if len(labels) != 2 || labels[0] != "Bug" || labels[1] != "design" {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+
+	results, err := store.SearchScreenshotsByAnnotation("BUG", now-1, now+120)
+	if err != nil {
+		t.Fatalf("SearchScreenshotsByAnnotation failed: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != firstID || results[1].ID != secondID {
+		t.Errorf("expected both screenshots in time order, got %d results", len(results))
+	}
+	results, err = store.SearchScreenshotsByAnnotation("bug", now+30, now+120)
+	if err != nil {
+		t.Fatalf("SearchScreenshotsByAnnotation failed: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != secondID {
+		t.Errorf("expected only the second screenshot in range, got %d results", len(results))
+	}
+
+	if err := store.DeleteScreenshotAnnotation(firstID, "design"); err != nil {
+		t.Fatalf("DeleteScreenshotAnnotation failed: %v", err)
+	}
+	labels, _ = store.GetScreenshotAnnotations(firstID)
+	if len(labels) != 1 {
+		t.Errorf("expected 1 label after delete, got %v", labels)
+	}
+
+	// Annotations are removed with their screenshot
+	if err := store.DeleteScreenshot(secondID); err != nil {
+		t.Fatalf("DeleteScreenshot failed: %v", err)
+	}
+	labels, _ = store.GetScreenshotAnnotations(secondID)
+	if len(labels) != 0 {
+		t.Errorf("expected annotations to be deleted with screenshot, got %v", labels)
+	}
+}
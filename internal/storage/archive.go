@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressionSampleSize is how much of the database EstimateCompressedSize compresses.
+const compressionSampleSize = 1 << 20 // 1 MB
+
+// VacuumIntoCompressedFile writes a compacted copy of the database (VACUUM INTO) to destPath,
+// compressed with Zstandard. destPath should normally end in ".db.zst".
+// Returns the size reduction in bytes (original database size minus compressed size).
+func (s *Store) VacuumIntoCompressedFile(destPath string) (int64, error) {
+	originalSize, err := s.getDatabaseSize()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destPath), 0755); err != nil {
+		return 0, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+
+	// VACUUM INTO refuses to overwrite, so write to a fresh temporary file next to the destination
+	tmpPath := destPath + ".tmp"
+	os.Remove(tmpPath)
+	defer os.Remove(tmpPath)
+
+	if _, err := s.db.Exec("VACUUM INTO ?", tmpPath); err != nil {
+		return 0, fmt.Errorf("failed to vacuum into file: %w", err)
+	}
+
+	if err := compressFile(tmpPath, destPath); err != nil {
+		os.Remove(destPath)
+		return 0, err
+	}
+
+	info, err := os.Stat(destPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stat archive: %w", err)
+	}
+	return originalSize - info.Size(), nil
+}
+
+// EstimateCompressedSize estimates the size of a compressed archive by compressing the
+// first 1 MB of the database and extrapolating the ratio to the full file.
+func (s *Store) EstimateCompressedSize() (int64, error) {
+	size, err := s.getDatabaseSize()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get database size: %w", err)
+	}
+	if size == 0 {
+		return 0, nil
+	}
+
+	f, err := os.Open(s.dbPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open database: %w", err)
+	}
+	defer f.Close()
+
+	sample := make([]byte, compressionSampleSize)
+	n, err := io.ReadFull(f, sample)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return 0, fmt.Errorf("failed to read database sample: %w", err)
+	}
+	sample = sample[:n]
+
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create compressor: %w", err)
+	}
+	defer enc.Close()
+	compressed := enc.EncodeAll(sample, nil)
+
+	ratio := float64(len(compressed)) / float64(len(sample))
+	return int64(float64(size) * ratio), nil
+}
+
+// compressFile writes a Zstandard-compressed copy of src to dst.
+func compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open vacuumed database: %w", err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("failed to create archive: %w", err)
+	}
+	defer out.Close()
+
+	enc, err := zstd.NewWriter(out)
+	if err != nil {
+		return fmt.Errorf("failed to create compressor: %w", err)
+	}
+	if _, err := io.Copy(enc, in); err != nil {
+		enc.Close()
+		return fmt.Errorf("failed to compress archive: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return out.Close()
+}
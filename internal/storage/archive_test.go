@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestVacuumIntoCompressedFile(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	for i := 0; i < 200; i++ {
+		_, err := store.SaveFocusEvent(&WindowFocusEvent{
+			AppName:         "code",
+			WindowTitle:     strings.Repeat("main.go - traq ", 4),
+			StartTime:       int64(1000 + i*60),
+			EndTime:         int64(1060 + i*60),
+			DurationSeconds: 60,
+		})
+		if err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+	}
+
+	estimate, err := store.EstimateCompressedSize()
+	if err != nil {
+		t.Fatalf("EstimateCompressedSize failed: %v", err)
+	}
+	if estimate <= 0 {
+		t.Errorf("expected positive estimate, got %d", estimate)
+	}
+
+	dir := t.TempDir()
+	archivePath := filepath.Join(dir, "archive", "traq.db.zst")
+	saved, err := store.VacuumIntoCompressedFile(archivePath)
+	if err != nil {
+		t.Fatalf("VacuumIntoCompressedFile failed: %v", err)
+	}
+	if saved <= 0 {
+		t.Errorf("expected compressed archive to be smaller than the database, saved %d bytes", saved)
+	}
+	if _, err := os.Stat(archivePath + ".tmp"); !os.IsNotExist(err) {
+		t.Error("expected temporary vacuum file to be removed")
+	}
+
+	// Decompress and open the archive as a regular database
+	restoredPath := filepath.Join(dir, "restored.db")
+	in, err := os.Open(archivePath)
+	if err != nil {
+		t.Fatalf("failed to open archive: %v", err)
+	}
+	defer in.Close()
+	dec, err := zstd.NewReader(in)
+	if err != nil {
+		t.Fatalf("failed to create decompressor: %v", err)
+	}
+	defer dec.Close()
+	out, err := os.Create(restoredPath)
+	if err != nil {
+		t.Fatalf("failed to create restored db: %v", err)
+	}
+	if _, err := io.Copy(out, dec); err != nil {
+		t.Fatalf("failed to decompress archive: %v", err)
+	}
+	out.Close()
+
+	restored, err := NewStore(restoredPath)
+	if err != nil {
+		t.Fatalf("failed to open restored db: %v", err)
+	}
+	defer restored.Close()
+
+	var original, roundTripped int
+	store.db.QueryRow("SELECT COUNT(*) FROM window_focus_events").Scan(&original)
+	restored.db.QueryRow("SELECT COUNT(*) FROM window_focus_events").Scan(&roundTripped)
+	if original != 200 || roundTripped != original {
+		t.Errorf("expected 200 rows in both databases, got %d and %d", original, roundTripped)
+	}
+}
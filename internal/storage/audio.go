@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SaveAudioEvent saves an audio event to the database.
+func (s *Store) SaveAudioEvent(event *AudioEvent) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO audio_events (timestamp, app_name, device_name, duration_seconds, session_id)
+		VALUES (?, ?, ?, ?, ?)`,
+		event.Timestamp, event.AppName, event.DeviceName, event.DurationSeconds, event.SessionID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert audio event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetAudioEventsByTimeRange retrieves audio events within a time range.
+func (s *Store) GetAudioEventsByTimeRange(start, end int64) ([]*AudioEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, app_name, device_name, duration_seconds, session_id, created_at
+		FROM audio_events
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audio events by time: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAudioEvents(rows)
+}
+
+func scanAudioEvents(rows *sql.Rows) ([]*AudioEvent, error) {
+	var events []*AudioEvent
+	for rows.Next() {
+		event := &AudioEvent{}
+		err := rows.Scan(
+			&event.ID, &event.Timestamp, &event.AppName, &event.DeviceName,
+			&event.DurationSeconds, &event.SessionID, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan audio event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// defaultBlocklistedDomains are seeded into the browser domain blocklist on first run.
+var defaultBlocklistedDomains = []string{
+	"facebook.com",
+	"instagram.com",
+	"twitter.com",
+	"x.com",
+	"tiktok.com",
+	"reddit.com",
+	"snapchat.com",
+	"pinterest.com",
+	"tumblr.com",
+}
+
+// BlocklistedDomain is a browser domain flagged in reports.
+// The blocklist only annotates reports; it never blocks browsing.
+type BlocklistedDomain struct {
+	Domain  string `json:"domain"`
+	Reason  string `json:"reason"`
+	AddedAt int64  `json:"addedAt"`
+}
+
+// NormalizeDomain lowercases a domain and strips any scheme, path, port, and "www." prefix,
+// so "https://www.Reddit.com/r/golang" becomes "reddit.com".
+func NormalizeDomain(domain string) string {
+	d := strings.ToLower(strings.TrimSpace(domain))
+	if idx := strings.Index(d, "://"); idx >= 0 {
+		d = d[idx+3:]
+	}
+	if idx := strings.IndexAny(d, "/?#"); idx >= 0 {
+		d = d[:idx]
+	}
+	if idx := strings.Index(d, ":"); idx >= 0 {
+		d = d[:idx]
+	}
+	return strings.TrimPrefix(d, "www.")
+}
+
+// AddBlocklistedDomain adds a domain to the blocklist, updating the reason if it already exists.
+func (s *Store) AddBlocklistedDomain(domain, reason string) error {
+	domain = NormalizeDomain(domain)
+	if domain == "" {
+		return fmt.Errorf("domain is required")
+	}
+	_, err := s.db.Exec(`
+		INSERT INTO browser_domain_blocklist (domain, reason, added_at)
+		VALUES (?, ?, strftime('%s', 'now'))
+		ON CONFLICT(domain) DO UPDATE SET reason = excluded.reason`,
+		domain, reason)
+	if err != nil {
+		return fmt.Errorf("failed to add blocklisted domain: %w", err)
+	}
+	return nil
+}
+
+// RemoveBlocklistedDomain removes a domain from the blocklist.
+func (s *Store) RemoveBlocklistedDomain(domain string) error {
+	if _, err := s.db.Exec("DELETE FROM browser_domain_blocklist WHERE domain = ?", NormalizeDomain(domain)); err != nil {
+		return fmt.Errorf("failed to remove blocklisted domain: %w", err)
+	}
+	return nil
+}
+
+// GetBlocklistedDomains returns all blocklisted domains, sorted alphabetically.
+func (s *Store) GetBlocklistedDomains() ([]BlocklistedDomain, error) {
+	rows, err := s.db.Query(`
+		SELECT domain, reason, added_at
+		FROM browser_domain_blocklist
+		ORDER BY domain ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query blocklisted domains: %w", err)
+	}
+	defer rows.Close()
+
+	var domains []BlocklistedDomain
+	for rows.Next() {
+		var d BlocklistedDomain
+		if err := rows.Scan(&d.Domain, &d.Reason, &d.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan blocklisted domain: %w", err)
+		}
+		domains = append(domains, d)
+	}
+	return domains, rows.Err()
+}
+
+// ImportBlocklist adds domains to the blocklist, skipping blanks and domains already present.
+// Returns the number of domains added.
+func (s *Store) ImportBlocklist(domains []string) (int, error) {
+	added := 0
+	err := s.Transaction(func(tx *sql.Tx) error {
+		for _, domain := range domains {
+			domain = NormalizeDomain(domain)
+			if domain == "" {
+				continue
+			}
+			result, err := tx.Exec(`
+				INSERT OR IGNORE INTO browser_domain_blocklist (domain, reason, added_at)
+				VALUES (?, 'Imported', strftime('%s', 'now'))`, domain)
+			if err != nil {
+				return fmt.Errorf("failed to import blocklisted domain %s: %w", domain, err)
+			}
+			if n, _ := result.RowsAffected(); n > 0 {
+				added++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return added, nil
+}
@@ -0,0 +1,68 @@
+package storage
+
+import "testing"
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := map[string]string{
+		"reddit.com":                      "reddit.com",
+		"  WWW.Reddit.com ":               "reddit.com",
+		"https://www.reddit.com/r/golang": "reddit.com",
+		"news.ycombinator.com:443":        "news.ycombinator.com",
+		"":                                "",
+	}
+	for input, want := range tests {
+		if got := NormalizeDomain(input); got != want {
+			t.Errorf("NormalizeDomain(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestBrowserDomainBlocklist(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Defaults are seeded by the migration
+	domains, err := store.GetBlocklistedDomains()
+	if err != nil {
+		t.Fatalf("GetBlocklistedDomains failed: %v", err)
+	}
+	if len(domains) != len(defaultBlocklistedDomains) {
+		t.Fatalf("expected %d seeded domains, got %d", len(defaultBlocklistedDomains), len(domains))
+	}
+
+	if err := store.AddBlocklistedDomain("https://www.YouTube.com/", "Video"); err != nil {
+		t.Fatalf("AddBlocklistedDomain failed: %v", err)
+	}
+	if err := store.AddBlocklistedDomain("  ", ""); err == nil {
+		t.Error("expected error for empty domain")
+	}
+	if err := store.RemoveBlocklistedDomain("reddit.com"); err != nil {
+		t.Fatalf("RemoveBlocklistedDomain failed: %v", err)
+	}
+
+	added, err := store.ImportBlocklist([]string{"news.ycombinator.com", "youtube.com", "", "x.com", "lobste.rs"})
+	if err != nil {
+		t.Fatalf("ImportBlocklist failed: %v", err)
+	}
+	if added != 2 {
+		t.Errorf("expected 2 new domains, got %d", added)
+	}
+
+	domains, err = store.GetBlocklistedDomains()
+	if err != nil {
+		t.Fatalf("GetBlocklistedDomains failed: %v", err)
+	}
+	found := make(map[string]string)
+	for _, d := range domains {
+		found[d.Domain] = d.Reason
+	}
+	if found["youtube.com"] != "Video" {
+		t.Errorf("expected youtube.com with reason Video, got %q", found["youtube.com"])
+	}
+	if _, ok := found["reddit.com"]; ok {
+		t.Error("expected reddit.com to be removed")
+	}
+	if len(domains) != len(defaultBlocklistedDomains)+2 {
+		t.Errorf("expected %d domains, got %d", len(defaultBlocklistedDomains)+2, len(domains))
+	}
+}
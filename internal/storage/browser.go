@@ -1,18 +1,24 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 )
 
 // SaveBrowserVisit saves a browser visit to the database.
 func (s *Store) SaveBrowserVisit(visit *BrowserVisit) (int64, error) {
+	profile := visit.BrowserProfile
+	if profile == "" {
+		profile = "default"
+	}
+
 	result, err := s.db.Exec(`
 		INSERT INTO browser_history (
-			timestamp, url, title, domain, browser,
+			timestamp, url, title, domain, browser, browser_profile,
 			visit_duration_seconds, transition_type, session_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
-		visit.Timestamp, visit.URL, visit.Title, visit.Domain, visit.Browser,
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		visit.Timestamp, visit.URL, visit.Title, visit.Domain, visit.Browser, profile,
 		visit.VisitDurationSeconds, visit.TransitionType, visit.SessionID,
 	)
 	if err != nil {
@@ -30,7 +36,7 @@ func (s *Store) SaveBrowserVisit(visit *BrowserVisit) (int64, error) {
 // GetBrowserVisitsBySession retrieves all browser visits for a session.
 func (s *Store) GetBrowserVisitsBySession(sessionID int64) ([]*BrowserVisit, error) {
 	rows, err := s.db.Query(`
-		SELECT id, timestamp, url, title, domain, browser,
+		SELECT id, timestamp, url, title, domain, browser, browser_profile,
 		       visit_duration_seconds, transition_type, session_id, created_at
 		FROM browser_history
 		WHERE session_id = ?
@@ -45,8 +51,19 @@ func (s *Store) GetBrowserVisitsBySession(sessionID int64) ([]*BrowserVisit, err
 
 // GetBrowserVisitsByTimeRange retrieves browser visits within a time range.
 func (s *Store) GetBrowserVisitsByTimeRange(start, end int64) ([]*BrowserVisit, error) {
-	rows, err := s.db.Query(`
-		SELECT id, timestamp, url, title, domain, browser,
+	var visits []*BrowserVisit
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		visits, err = queryBrowserVisitsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return visits, err
+}
+
+// queryBrowserVisitsByTimeRange runs the GetBrowserVisitsByTimeRange query on db.
+func queryBrowserVisitsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*BrowserVisit, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, timestamp, url, title, domain, browser, browser_profile,
 		       visit_duration_seconds, transition_type, session_id, created_at
 		FROM browser_history
 		WHERE timestamp >= ? AND timestamp <= ?
@@ -118,12 +135,128 @@ func (s *Store) GetDomainStats(start, end int64) (map[string]int64, error) {
 	return stats, rows.Err()
 }
 
-// VisitExists checks if a visit with the given timestamp and URL already exists.
-func (s *Store) VisitExists(timestamp int64, url string, browser string) (bool, error) {
+// GetBrowserVisitsByDomain returns one page of a domain's visits within a time range, newest
+// first, along with the total number of matching visits.
+func (s *Store) GetBrowserVisitsByDomain(domain string, start, end int64, offset, limit int) ([]*BrowserVisit, int64, error) {
+	var total int64
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM browser_history
+		WHERE domain = ? AND timestamp >= ? AND timestamp <= ?`, domain, start, end).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count browser visits by domain: %w", err)
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, url, title, domain, browser, browser_profile,
+		       visit_duration_seconds, transition_type, session_id, created_at
+		FROM browser_history
+		WHERE domain = ? AND timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp DESC, id DESC
+		LIMIT ? OFFSET ?`, domain, start, end, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query browser visits by domain: %w", err)
+	}
+	defer rows.Close()
+
+	visits, err := scanBrowserVisits(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return visits, total, nil
+}
+
+// GetDomainVisitsByTimeRange retrieves a domain's visits within a time range, oldest first.
+func (s *Store) GetDomainVisitsByTimeRange(domain string, start, end int64) ([]*BrowserVisit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, url, title, domain, browser, browser_profile,
+		       visit_duration_seconds, transition_type, session_id, created_at
+		FROM browser_history
+		WHERE domain = ? AND timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC, id ASC`, domain, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query browser visits by domain: %w", err)
+	}
+	defer rows.Close()
+
+	return scanBrowserVisits(rows)
+}
+
+// GetUniqueDomainVisitCounts returns visit counts and total visit duration for each domain
+// and browser profile within a time range, most visited first.
+func (s *Store) GetUniqueDomainVisitCounts(start, end int64) ([]*DomainCount, error) {
+	rows, err := s.db.Query(`
+		SELECT domain, COALESCE(browser_profile, 'default'), COUNT(*), COUNT(DISTINCT url),
+		       COALESCE(SUM(visit_duration_seconds), 0)
+		FROM browser_history
+		WHERE timestamp >= ? AND timestamp <= ?
+		GROUP BY domain, COALESCE(browser_profile, 'default')
+		ORDER BY COUNT(*) DESC, domain ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query domain visit counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*DomainCount
+	for rows.Next() {
+		c := &DomainCount{}
+		if err := rows.Scan(&c.Domain, &c.BrowserProfile, &c.VisitCount, &c.UniqueVisits, &c.DurationSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan domain visit counts: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// GetDomainSampleTitles returns up to limit distinct page titles for each domain and browser
+// profile within a time range, most recently visited first. Results are keyed by domain, then profile.
+func (s *Store) GetDomainSampleTitles(start, end int64, limit int) (map[string]map[string][]string, error) {
+	rows, err := s.db.Query(`
+		WITH titles AS (
+			SELECT domain, COALESCE(browser_profile, 'default') AS profile, title,
+			       MAX(timestamp) AS last_visit
+			FROM browser_history
+			WHERE timestamp >= ? AND timestamp <= ? AND title IS NOT NULL AND title != ''
+			GROUP BY domain, profile, title
+		),
+		ranked AS (
+			SELECT domain, profile, title,
+			       ROW_NUMBER() OVER (PARTITION BY domain, profile ORDER BY last_visit DESC, title ASC) AS rn
+			FROM titles
+		)
+		SELECT domain, profile, title FROM ranked
+		WHERE rn <= ?
+		ORDER BY domain, profile, rn`, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query domain sample titles: %w", err)
+	}
+	defer rows.Close()
+
+	titles := make(map[string]map[string][]string)
+	for rows.Next() {
+		var domain, profile, title string
+		if err := rows.Scan(&domain, &profile, &title); err != nil {
+			return nil, fmt.Errorf("failed to scan domain sample title: %w", err)
+		}
+		if titles[domain] == nil {
+			titles[domain] = make(map[string][]string)
+		}
+		titles[domain][profile] = append(titles[domain][profile], title)
+	}
+	return titles, rows.Err()
+}
+
+// VisitExists checks if a visit with the given timestamp and URL already exists in a browser
+// profile. An empty profile means the browser's main profile, as in SaveBrowserVisit.
+func (s *Store) VisitExists(timestamp int64, url string, browser string, profile string) (bool, error) {
+	if profile == "" {
+		profile = "default"
+	}
+
 	var exists bool
 	err := s.db.QueryRow(`
-		SELECT EXISTS(SELECT 1 FROM browser_history WHERE timestamp = ? AND url = ? AND browser = ?)`,
-		timestamp, url, browser).Scan(&exists)
+		SELECT EXISTS(SELECT 1 FROM browser_history
+		              WHERE timestamp = ? AND url = ? AND browser = ? AND browser_profile = ?)`,
+		timestamp, url, browser, profile).Scan(&exists)
 	return exists, err
 }
 
@@ -160,8 +293,8 @@ func (s *Store) CountBrowserVisitsByTimeRange(start, end int64) (int64, error) {
 // GetAllBrowserVisits retrieves all browser visits (for search).
 func (s *Store) GetAllBrowserVisits() ([]*BrowserVisit, error) {
 	rows, err := s.db.Query(`
-		SELECT id, timestamp, url, title, domain, browser, visit_duration_seconds,
-		       transition_type, session_id, created_at
+		SELECT id, timestamp, url, title, domain, browser, browser_profile,
+		       visit_duration_seconds, transition_type, session_id, created_at
 		FROM browser_history
 		ORDER BY timestamp DESC`)
 	if err != nil {
@@ -181,6 +314,13 @@ func (s *Store) CountUniqueDomainsByTimeRange(start, end int64) (int64, error) {
 	return count, err
 }
 
+// CountUniqueDomains returns the number of distinct domains ever visited.
+func (s *Store) CountUniqueDomains() (int64, error) {
+	var count int64
+	err := s.db.QueryRow("SELECT COUNT(DISTINCT domain) FROM browser_history").Scan(&count)
+	return count, err
+}
+
 // DeleteBrowserVisit deletes a single browser visit by ID.
 func (s *Store) DeleteBrowserVisit(id int64) error {
 	result, err := s.db.Exec("DELETE FROM browser_history WHERE id = ?", id)
@@ -242,7 +382,7 @@ func scanBrowserVisits(rows *sql.Rows) ([]*BrowserVisit, error) {
 		visit := &BrowserVisit{}
 		err := rows.Scan(
 			&visit.ID, &visit.Timestamp, &visit.URL, &visit.Title, &visit.Domain, &visit.Browser,
-			&visit.VisitDurationSeconds, &visit.TransitionType, &visit.SessionID, &visit.CreatedAt,
+			&visit.BrowserProfile, &visit.VisitDurationSeconds, &visit.TransitionType, &visit.SessionID, &visit.CreatedAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan browser visit: %w", err)
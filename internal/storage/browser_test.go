@@ -2,6 +2,8 @@ package storage
 
 import (
 	"database/sql"
+	"fmt"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -27,6 +29,29 @@ func TestSaveBrowserVisit(t *testing.T) {
 	}
 }
 
+func TestBrowserVisitProfile(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://a.com", Domain: "a.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 1, URL: "https://b.com", Domain: "b.com", Browser: "chrome", BrowserProfile: "Work"})
+
+	visits, err := store.GetBrowserVisitsByTimeRange(now-1, now+2)
+	if err != nil {
+		t.Fatalf("failed to get visits: %v", err)
+	}
+	if len(visits) != 2 {
+		t.Fatalf("expected 2 visits, got %d", len(visits))
+	}
+	if visits[0].BrowserProfile != "default" {
+		t.Errorf("expected profile 'default' when unset, got %q", visits[0].BrowserProfile)
+	}
+	if visits[1].BrowserProfile != "Work" {
+		t.Errorf("expected profile 'Work', got %q", visits[1].BrowserProfile)
+	}
+}
+
 func TestGetBrowserVisitsBySession(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -121,18 +146,26 @@ func TestVisitExists(t *testing.T) {
 	}
 	store.SaveBrowserVisit(visit)
 
-	exists, err := store.VisitExists(now, "https://example.com/unique", "chrome")
+	exists, err := store.VisitExists(now, "https://example.com/unique", "chrome", "")
 	if err != nil {
 		t.Fatalf("failed to check visit exists: %v", err)
 	}
 	if !exists {
 		t.Error("expected visit to exist")
 	}
+	if exists, _ = store.VisitExists(now, "https://example.com/unique", "chrome", "default"); !exists {
+		t.Error("expected visit to exist in the default profile")
+	}
 
-	exists, _ = store.VisitExists(now, "https://nonexistent.com", "chrome")
+	exists, _ = store.VisitExists(now, "https://nonexistent.com", "chrome", "")
 	if exists {
 		t.Error("expected visit not to exist")
 	}
+
+	// The same visit in another profile isn't a duplicate
+	if exists, _ = store.VisitExists(now, "https://example.com/unique", "chrome", "Profile 1"); exists {
+		t.Error("expected visit not to exist in another profile")
+	}
 }
 
 func TestGetLastVisitTimestamp(t *testing.T) {
@@ -239,3 +272,104 @@ func TestCountUniqueDomainsByTimeRange(t *testing.T) {
 		t.Errorf("expected 3 unique domains, got %d", count)
 	}
 }
+
+func TestGetBrowserVisitsByDomain(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	for i := 0; i < 5; i++ {
+		store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + int64(i), URL: "https://github.com/traq", Domain: "github.com", Browser: "chrome"})
+	}
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://example.com", Domain: "example.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now - 3600, URL: "https://github.com/old", Domain: "github.com", Browser: "chrome"})
+
+	visits, total, err := store.GetBrowserVisitsByDomain("github.com", now-1, now+10, 0, 2)
+	if err != nil {
+		t.Fatalf("failed to get visits by domain: %v", err)
+	}
+	if total != 5 {
+		t.Errorf("expected total 5, got %d", total)
+	}
+	if len(visits) != 2 || visits[0].Timestamp != now+4 {
+		t.Fatalf("expected newest 2 visits, got %d", len(visits))
+	}
+
+	visits, _, _ = store.GetBrowserVisitsByDomain("github.com", now-1, now+10, 4, 2)
+	if len(visits) != 1 || visits[0].Timestamp != now {
+		t.Errorf("expected last page with the oldest visit, got %d visits", len(visits))
+	}
+}
+
+func TestGetDomainVisitsByTimeRange(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 5, URL: "https://github.com/b", Domain: "github.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://github.com/a", Domain: "github.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 1, URL: "https://example.com", Domain: "example.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 3600, URL: "https://github.com/later", Domain: "github.com", Browser: "chrome"})
+
+	visits, err := store.GetDomainVisitsByTimeRange("github.com", now, now+10)
+	if err != nil {
+		t.Fatalf("failed to get domain visits: %v", err)
+	}
+	if len(visits) != 2 || visits[0].URL != "https://github.com/a" || visits[1].URL != "https://github.com/b" {
+		t.Errorf("expected github.com visits in the range, oldest first, got %+v", visits)
+	}
+}
+
+func TestGetUniqueDomainVisitCounts(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://github.com/a", Domain: "github.com", Browser: "chrome",
+		VisitDurationSeconds: sql.NullInt64{Int64: 30, Valid: true}})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 1, URL: "https://github.com/a", Domain: "github.com", Browser: "chrome",
+		VisitDurationSeconds: sql.NullInt64{Int64: 15, Valid: true}})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + 2, URL: "https://github.com/b", Domain: "github.com", Browser: "chrome"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://example.com", Domain: "example.com", Browser: "chrome"})
+
+	counts, err := store.GetUniqueDomainVisitCounts(now-1, now+10)
+	if err != nil {
+		t.Fatalf("failed to get domain visit counts: %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 domains, got %d", len(counts))
+	}
+	gh := counts[0]
+	if gh.Domain != "github.com" || gh.VisitCount != 3 || gh.UniqueVisits != 2 || gh.DurationSeconds != 45 {
+		t.Errorf("unexpected github.com counts: %+v", gh)
+	}
+}
+
+func TestGetDomainSampleTitles(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	title := func(s string) sql.NullString { return sql.NullString{String: s, Valid: true} }
+	for i, tt := range []string{"Issues", "Pulls", "Issues", "Actions", "Wiki"} {
+		store.SaveBrowserVisit(&BrowserVisit{Timestamp: now + int64(i), URL: fmt.Sprintf("https://github.com/%d", i),
+			Title: title(tt), Domain: "github.com", Browser: "chrome"})
+	}
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://github.com/work", Title: title("Work"),
+		Domain: "github.com", Browser: "chrome", BrowserProfile: "Profile 1"})
+	store.SaveBrowserVisit(&BrowserVisit{Timestamp: now, URL: "https://example.com", Domain: "example.com", Browser: "chrome"})
+
+	titles, err := store.GetDomainSampleTitles(now-1, now+10, 3)
+	if err != nil {
+		t.Fatalf("failed to get domain sample titles: %v", err)
+	}
+	if got := titles["github.com"]["default"]; !reflect.DeepEqual(got, []string{"Wiki", "Actions", "Issues"}) {
+		t.Errorf("unexpected default profile titles: %v", got)
+	}
+	if got := titles["github.com"]["Profile 1"]; !reflect.DeepEqual(got, []string{"Work"}) {
+		t.Errorf("unexpected Profile 1 titles: %v", got)
+	}
+	if _, ok := titles["example.com"]; ok {
+		t.Errorf("expected no titles for example.com, got %v", titles["example.com"])
+	}
+}
@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CategorizationExportVersion is the schema version of the categorization export document.
+// Bump when the document layout changes in a way older importers can't read.
+const CategorizationExportVersion = 1
+
+// validAppCategories and validTimelineCategories mirror the CHECK constraints on
+// app_categories and app_categorization_rules.
+var (
+	validAppCategories      = map[string]bool{"productive": true, "neutral": true, "distracting": true}
+	validTimelineCategories = map[string]bool{"focus": true, "meetings": true, "comms": true, "other": true}
+)
+
+// CategorizationExport is a portable JSON document containing all app categorization rules.
+// It is used to pre-configure shared machines with the same categories.
+type CategorizationExport struct {
+	Version       int                       `json:"version"`
+	ExportedAt    int64                     `json:"exportedAt"`
+	AppCategories []CategorizationExportApp `json:"appCategories"` // Productivity categories (app_categories)
+	TimelineRules []CategorizationRule      `json:"timelineRules"` // Timeline v3 rules (app_categorization_rules)
+}
+
+// CategorizationExportApp is a productivity category entry in an export document.
+type CategorizationExportApp struct {
+	AppName  string `json:"appName"`
+	Category string `json:"category"`
+}
+
+// ExportCategorizationRules serializes all app categories and timeline categorization rules to JSON.
+func (s *Store) ExportCategorizationRules() (string, error) {
+	categories, err := s.GetAllAppCategories()
+	if err != nil {
+		return "", err
+	}
+	rules, err := s.GetCategorizationRules()
+	if err != nil {
+		return "", err
+	}
+
+	export := CategorizationExport{
+		Version:       CategorizationExportVersion,
+		ExportedAt:    time.Now().Unix(),
+		AppCategories: make([]CategorizationExportApp, 0, len(categories)),
+		TimelineRules: rules,
+	}
+	for _, cat := range categories {
+		export.AppCategories = append(export.AppCategories, CategorizationExportApp{
+			AppName:  cat.AppName,
+			Category: cat.Category,
+		})
+	}
+	if export.TimelineRules == nil {
+		export.TimelineRules = []CategorizationRule{}
+	}
+
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal categorization rules: %w", err)
+	}
+	return string(data), nil
+}
+
+// ImportCategorizationRules loads a document produced by ExportCategorizationRules.
+// Mode "merge" adds rules for apps that don't have one yet and leaves existing rules untouched.
+// Mode "replace" deletes all existing rules (including system defaults) before inserting.
+// Returns the number of rules inserted.
+func (s *Store) ImportCategorizationRules(data string, mode string) (int, error) {
+	if mode != "merge" && mode != "replace" {
+		return 0, fmt.Errorf("invalid import mode: %s (must be merge or replace)", mode)
+	}
+
+	var export CategorizationExport
+	if err := json.Unmarshal([]byte(data), &export); err != nil {
+		return 0, fmt.Errorf("failed to parse categorization rules: %w", err)
+	}
+	if export.Version < 1 || export.Version > CategorizationExportVersion {
+		return 0, fmt.Errorf("unsupported categorization export version: %d", export.Version)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if mode == "replace" {
+		if _, err := tx.Exec("DELETE FROM app_categories"); err != nil {
+			return 0, fmt.Errorf("failed to clear app categories: %w", err)
+		}
+		if _, err := tx.Exec("DELETE FROM app_categorization_rules"); err != nil {
+			return 0, fmt.Errorf("failed to clear categorization rules: %w", err)
+		}
+	}
+
+	imported := 0
+	for _, cat := range export.AppCategories {
+		if cat.AppName == "" {
+			continue
+		}
+		// Validate explicitly: INSERT OR IGNORE would silently skip CHECK violations
+		if !validAppCategories[cat.Category] {
+			return 0, fmt.Errorf("invalid category %q for app %s", cat.Category, cat.AppName)
+		}
+		result, err := tx.Exec(`
+			INSERT OR IGNORE INTO app_categories (app_name, category, created_at, updated_at)
+			VALUES (?, ?, strftime('%s', 'now'), strftime('%s', 'now'))`,
+			cat.AppName, cat.Category)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import app category for %s: %w", cat.AppName, err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			imported++
+		}
+	}
+
+	for _, rule := range export.TimelineRules {
+		if rule.AppName == "" {
+			continue
+		}
+		if !validTimelineCategories[rule.Category] {
+			return 0, fmt.Errorf("invalid timeline category %q for app %s", rule.Category, rule.AppName)
+		}
+		isSystemDefault := 0
+		if rule.IsSystemDefault {
+			isSystemDefault = 1
+		}
+		result, err := tx.Exec(`
+			INSERT OR IGNORE INTO app_categorization_rules (app_name, category, is_system_default, created_at)
+			VALUES (?, ?, ?, strftime('%s', 'now'))`,
+			rule.AppName, rule.Category, isSystemDefault)
+		if err != nil {
+			return 0, fmt.Errorf("failed to import categorization rule for %s: %w", rule.AppName, err)
+		}
+		if n, _ := result.RowsAffected(); n > 0 {
+			imported++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return imported, nil
+}
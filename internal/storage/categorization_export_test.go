@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"testing"
+)
+
+// seedCategorizationRules adds a few user-defined categories and timeline rules.
+func seedCategorizationRules(t *testing.T, store *Store) {
+	t.Helper()
+
+	if err := store.SetAppCategory("code", "productive"); err != nil {
+		t.Fatalf("failed to set app category: %v", err)
+	}
+	if err := store.SetAppCategory("youtube", "distracting"); err != nil {
+		t.Fatalf("failed to set app category: %v", err)
+	}
+	if err := store.SetAppTimelineCategory("code", "focus"); err != nil {
+		t.Fatalf("failed to set timeline category: %v", err)
+	}
+	if err := store.SetAppTimelineCategory("custom-chat", "comms"); err != nil {
+		t.Fatalf("failed to set timeline category: %v", err)
+	}
+}
+
+func TestCategorizationRules_RoundTripReplace(t *testing.T) {
+	src, cleanupSrc := testStore(t)
+	defer cleanupSrc()
+	seedCategorizationRules(t, src)
+
+	data, err := src.ExportCategorizationRules()
+	if err != nil {
+		t.Fatalf("ExportCategorizationRules failed: %v", err)
+	}
+
+	dst, cleanupDst := testStore(t)
+	defer cleanupDst()
+	if err := dst.SetAppCategory("slack", "neutral"); err != nil {
+		t.Fatalf("failed to set app category: %v", err)
+	}
+
+	n, err := dst.ImportCategorizationRules(data, "replace")
+	if err != nil {
+		t.Fatalf("ImportCategorizationRules failed: %v", err)
+	}
+
+	srcRules, _ := src.GetCategorizationRules()
+	if n != 2+len(srcRules) {
+		t.Errorf("expected %d rules imported, got %d", 2+len(srcRules), n)
+	}
+
+	// Existing entries are replaced by the imported set
+	categories, err := dst.GetAllAppCategories()
+	if err != nil {
+		t.Fatalf("GetAllAppCategories failed: %v", err)
+	}
+	if len(categories) != 2 {
+		t.Fatalf("expected 2 app categories, got %d", len(categories))
+	}
+	if cat, _ := dst.GetAppCategory("slack"); cat != nil {
+		t.Error("expected slack category to be removed by replace")
+	}
+	if cat, _ := dst.GetAppCategory("youtube"); cat == nil || cat.Category != "distracting" {
+		t.Errorf("expected youtube to be distracting, got %+v", cat)
+	}
+
+	dstRules, err := dst.GetCategorizationRules()
+	if err != nil {
+		t.Fatalf("GetCategorizationRules failed: %v", err)
+	}
+	if len(dstRules) != len(srcRules) {
+		t.Fatalf("expected %d timeline rules, got %d", len(srcRules), len(dstRules))
+	}
+	for i := range srcRules {
+		if dstRules[i].AppName != srcRules[i].AppName ||
+			dstRules[i].Category != srcRules[i].Category ||
+			dstRules[i].IsSystemDefault != srcRules[i].IsSystemDefault {
+			t.Errorf("rule %d mismatch: expected %+v, got %+v", i, srcRules[i], dstRules[i])
+		}
+	}
+}
+
+func TestCategorizationRules_RoundTripMerge(t *testing.T) {
+	src, cleanupSrc := testStore(t)
+	defer cleanupSrc()
+	seedCategorizationRules(t, src)
+
+	data, err := src.ExportCategorizationRules()
+	if err != nil {
+		t.Fatalf("ExportCategorizationRules failed: %v", err)
+	}
+
+	dst, cleanupDst := testStore(t)
+	defer cleanupDst()
+	if err := dst.SetAppCategory("code", "neutral"); err != nil {
+		t.Fatalf("failed to set app category: %v", err)
+	}
+	if err := dst.SetAppCategory("slack", "neutral"); err != nil {
+		t.Fatalf("failed to set app category: %v", err)
+	}
+
+	n, err := dst.ImportCategorizationRules(data, "merge")
+	if err != nil {
+		t.Fatalf("ImportCategorizationRules failed: %v", err)
+	}
+
+	// Only youtube and custom-chat are new; code and the system defaults already exist
+	if n != 2 {
+		t.Errorf("expected 2 rules imported, got %d", n)
+	}
+
+	// Existing entries are kept
+	if cat, _ := dst.GetAppCategory("code"); cat == nil || cat.Category != "neutral" {
+		t.Errorf("expected code to stay neutral, got %+v", cat)
+	}
+	if cat, _ := dst.GetAppCategory("slack"); cat == nil {
+		t.Error("expected slack category to be kept by merge")
+	}
+	if cat, _ := dst.GetAppCategory("youtube"); cat == nil || cat.Category != "distracting" {
+		t.Errorf("expected youtube to be distracting, got %+v", cat)
+	}
+	if category, _ := dst.GetAppTimelineCategory("custom-chat"); category != "comms" {
+		t.Errorf("expected custom-chat to be comms, got %s", category)
+	}
+
+	// Importing the same document again adds nothing
+	n, err = dst.ImportCategorizationRules(data, "merge")
+	if err != nil {
+		t.Fatalf("second ImportCategorizationRules failed: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("expected 0 rules on repeated merge, got %d", n)
+	}
+}
+
+func TestCategorizationRules_ImportInvalid(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	if _, err := store.ImportCategorizationRules(`{"version":1}`, "append"); err == nil {
+		t.Error("expected error for invalid mode")
+	}
+	if _, err := store.ImportCategorizationRules(`not json`, "merge"); err == nil {
+		t.Error("expected error for invalid JSON")
+	}
+	if _, err := store.ImportCategorizationRules(`{"version":99}`, "merge"); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+
+	// A bad category aborts the whole import
+	data := `{"version":1,"appCategories":[{"appName":"a","category":"productive"},{"appName":"b","category":"bogus"}]}`
+	if _, err := store.ImportCategorizationRules(data, "merge"); err == nil {
+		t.Error("expected error for invalid category")
+	}
+	if cat, _ := store.GetAppCategory("a"); cat != nil {
+		t.Error("expected failed import to be rolled back")
+	}
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SaveClipboardEvent saves a clipboard event to the database.
+func (s *Store) SaveClipboardEvent(event *ClipboardEvent) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO clipboard_events (timestamp, content_length, content_type, session_id)
+		VALUES (?, ?, ?, ?)`,
+		event.Timestamp, event.ContentLength, event.ContentType, event.SessionID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert clipboard event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetClipboardEventsByTimeRange retrieves clipboard events within a time range.
+func (s *Store) GetClipboardEventsByTimeRange(start, end int64) ([]*ClipboardEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, content_length, content_type, session_id, created_at
+		FROM clipboard_events
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clipboard events by time: %w", err)
+	}
+	defer rows.Close()
+
+	return scanClipboardEvents(rows)
+}
+
+// GetClipboardEventsBySession retrieves all clipboard events for a session.
+func (s *Store) GetClipboardEventsBySession(sessionID int64) ([]*ClipboardEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, content_length, content_type, session_id, created_at
+		FROM clipboard_events
+		WHERE session_id = ?
+		ORDER BY timestamp ASC`, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query clipboard events: %w", err)
+	}
+	defer rows.Close()
+
+	return scanClipboardEvents(rows)
+}
+
+// CountClipboardEventsByTimeRange returns the count of clipboard events in a time range.
+func (s *Store) CountClipboardEventsByTimeRange(start, end int64) (int64, error) {
+	var count int64
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM clipboard_events
+		WHERE timestamp >= ? AND timestamp <= ?`, start, end).Scan(&count)
+	return count, err
+}
+
+func scanClipboardEvents(rows *sql.Rows) ([]*ClipboardEvent, error) {
+	var events []*ClipboardEvent
+	for rows.Next() {
+		event := &ClipboardEvent{}
+		err := rows.Scan(
+			&event.ID, &event.Timestamp, &event.ContentLength,
+			&event.ContentType, &event.SessionID, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan clipboard event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// CreateProjectDetectionRule saves a new project detection rule.
+func (s *Store) CreateProjectDetectionRule(sourceType, pattern string, captureGroup int) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO project_detection_rules (source_type, pattern, capture_group)
+		VALUES (?, ?, ?)`, sourceType, pattern, captureGroup)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert detection rule: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetProjectDetectionRules returns the detection rules for a source type, or all rules
+// if sourceType is empty, in creation order.
+func (s *Store) GetProjectDetectionRules(sourceType string) ([]ProjectDetectionRule, error) {
+	rows, err := s.db.Query(`
+		SELECT id, source_type, pattern, capture_group, COALESCE(created_at, 0)
+		FROM project_detection_rules
+		WHERE ? = '' OR source_type = ?
+		ORDER BY id ASC`, sourceType, sourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query detection rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []ProjectDetectionRule
+	for rows.Next() {
+		var r ProjectDetectionRule
+		if err := rows.Scan(&r.ID, &r.SourceType, &r.Pattern, &r.CaptureGroup, &r.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan detection rule: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+// DeleteProjectDetectionRule removes a project detection rule.
+func (s *Store) DeleteProjectDetectionRule(id int64) error {
+	if _, err := s.db.Exec(`DELETE FROM project_detection_rules WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete detection rule: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// AllEvents holds every kind of tracked event for a time range.
+type AllEvents struct {
+	Sessions      []*Session
+	Screenshots   []*Screenshot
+	FocusEvents   []*WindowFocusEvent
+	GitCommits    []*GitCommit
+	ShellCommands []*ShellCommand
+	FileEvents    []*FileEvent
+	BrowserVisits []*BrowserVisit
+}
+
+// GetAllEventsForRange loads all event types for a time range, running the queries in parallel
+// on the read pool. Each field matches its GetXByTimeRange counterpart. Returns the first error
+// encountered, which cancels the other queries, or ctx's error if it's cancelled first.
+func (s *Store) GetAllEventsForRange(ctx context.Context, start, end int64) (*AllEvents, error) {
+	return s.getAllEventsForRange(ctx, start, end, true)
+}
+
+// GetAllEventsExceptFocusForRange is GetAllEventsForRange without focus events, for callers
+// that stream focus events with StreamWindowFocusEvents instead.
+func (s *Store) GetAllEventsExceptFocusForRange(ctx context.Context, start, end int64) (*AllEvents, error) {
+	return s.getAllEventsForRange(ctx, start, end, false)
+}
+
+func (s *Store) getAllEventsForRange(ctx context.Context, start, end int64, withFocus bool) (*AllEvents, error) {
+	events := &AllEvents{}
+	err := s.WithReadDB(func(db *sql.DB) error {
+		g, ctx := errgroup.WithContext(ctx)
+
+		g.Go(func() (err error) {
+			events.Sessions, err = querySessionsByTimeRange(ctx, db, start, end)
+			return err
+		})
+		g.Go(func() (err error) {
+			events.Screenshots, err = queryScreenshotsByTimeRange(ctx, db, start, end)
+			return err
+		})
+		if withFocus {
+			g.Go(func() (err error) {
+				events.FocusEvents, err = queryFocusEventsByTimeRange(ctx, db, start, end)
+				return err
+			})
+		}
+		g.Go(func() (err error) {
+			events.GitCommits, err = queryGitCommitsByTimeRange(ctx, db, start, end)
+			return err
+		})
+		g.Go(func() (err error) {
+			events.ShellCommands, err = queryShellCommandsByTimeRange(ctx, db, start, end)
+			return err
+		})
+		g.Go(func() (err error) {
+			events.FileEvents, err = queryFileEventsByTimeRange(ctx, db, start, end)
+			return err
+		})
+		g.Go(func() (err error) {
+			events.BrowserVisits, err = queryBrowserVisitsByTimeRange(ctx, db, start, end)
+			return err
+		})
+
+		return g.Wait()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetDataTimeRange returns the timestamps of the oldest and newest tracked data across
+// sessions, screenshots, focus events, commits, shell commands and browser visits.
+// Both are 0 if nothing has been tracked.
+func (s *Store) GetDataTimeRange() (oldest, newest int64, err error) {
+	err = s.db.QueryRow(`
+		SELECT COALESCE(MIN(first), 0), COALESCE(MAX(last), 0) FROM (
+			SELECT MIN(start_time) AS first, MAX(COALESCE(end_time, start_time)) AS last FROM sessions
+			UNION ALL SELECT MIN(timestamp), MAX(timestamp) FROM screenshots
+			UNION ALL SELECT MIN(start_time), MAX(end_time) FROM window_focus_events
+			UNION ALL SELECT MIN(timestamp), MAX(timestamp) FROM git_commits
+			UNION ALL SELECT MIN(timestamp), MAX(timestamp) FROM shell_commands
+			UNION ALL SELECT MIN(timestamp), MAX(timestamp) FROM browser_history
+		)`).Scan(&oldest, &newest)
+	return oldest, newest, err
+}
@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// seedWeek fills a store with a week of activity: per hour over a 10-hour workday,
+// one session, 120 screenshots, 30 focus events, 20 shell commands, 10 browser visits,
+// 5 file events, and a commit.
+func seedWeek(tb testing.TB) (*Store, int64, int64, func()) {
+	tb.Helper()
+
+	store, cleanup := testStore(tb)
+	repoID, err := store.SaveGitRepository(&GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	if err != nil {
+		cleanup()
+		tb.Fatalf("failed to save repository: %v", err)
+	}
+
+	weekStart := time.Date(2024, time.March, 4, 0, 0, 0, 0, time.Local)
+	for d := 0; d < 7; d++ {
+		for h := 8; h < 18; h++ {
+			hour := weekStart.AddDate(0, 0, d).Add(time.Duration(h) * time.Hour).Unix()
+			sessionID, _ := store.CreateSession(hour)
+			store.EndSession(sessionID, hour+3599)
+
+			for i := int64(0); i < 120; i++ {
+				store.SaveScreenshot(&Screenshot{Timestamp: hour + i*30, Filepath: fmt.Sprintf("%d.webp", hour+i*30), DHash: "0"})
+			}
+			for i := int64(0); i < 30; i++ {
+				store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "main.go", AppName: "code", StartTime: hour + i*120, EndTime: hour + i*120 + 120, DurationSeconds: 120})
+			}
+			for i := int64(0); i < 20; i++ {
+				store.SaveShellCommand(&ShellCommand{Timestamp: hour + i*180, Command: fmt.Sprintf("go test ./%d", i), ShellType: "bash"})
+			}
+			for i := int64(0); i < 10; i++ {
+				store.SaveBrowserVisit(&BrowserVisit{Timestamp: hour + i*360, URL: fmt.Sprintf("https://go.dev/%d/%d", hour, i), Domain: "go.dev", Browser: "firefox"})
+			}
+			for i := int64(0); i < 5; i++ {
+				store.SaveFileEvent(&FileEvent{Timestamp: hour + i*720, EventType: "modified", FilePath: "/src/traq/main.go", FileName: "main.go", Directory: "/src/traq", WatchCategory: "projects"})
+			}
+			store.SaveGitCommit(&GitCommit{RepositoryID: repoID, CommitHash: fmt.Sprintf("%040d", hour), ShortHash: fmt.Sprint(hour), Timestamp: hour + 1800, Message: "Work", MessageSubject: "Work"})
+		}
+	}
+
+	return store, weekStart.Unix(), weekStart.AddDate(0, 0, 7).Unix() - 1, cleanup
+}
+
+func TestGetAllEventsForRange(t *testing.T) {
+	store, start, end, cleanup := seedWeek(t)
+	defer cleanup()
+
+	events, err := store.GetAllEventsForRange(context.Background(), start, end)
+	if err != nil {
+		t.Fatalf("GetAllEventsForRange failed: %v", err)
+	}
+
+	counts := map[string][2]int{
+		"sessions":       {len(events.Sessions), 70},
+		"screenshots":    {len(events.Screenshots), 8400},
+		"focus events":   {len(events.FocusEvents), 2100},
+		"git commits":    {len(events.GitCommits), 70},
+		"shell commands": {len(events.ShellCommands), 1400},
+		"file events":    {len(events.FileEvents), 350},
+		"browser visits": {len(events.BrowserVisits), 700},
+	}
+	for name, c := range counts {
+		if c[0] != c[1] {
+			t.Errorf("%s: got %d, want %d", name, c[0], c[1])
+		}
+	}
+
+	// Nothing outside the range
+	events, err = store.GetAllEventsForRange(context.Background(), end+1, end+86400)
+	if err != nil {
+		t.Fatalf("GetAllEventsForRange failed: %v", err)
+	}
+	if len(events.Sessions)+len(events.Screenshots)+len(events.FocusEvents)+len(events.GitCommits)+
+		len(events.ShellCommands)+len(events.FileEvents)+len(events.BrowserVisits) != 0 {
+		t.Errorf("expected no events after the seeded week, got %+v", events)
+	}
+
+	// A cancelled context stops the queries
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := store.GetAllEventsForRange(ctx, start, end); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+// BenchmarkEventsSequential loads a week of events one source at a time.
+func BenchmarkEventsSequential(b *testing.B) {
+	store, start, end, cleanup := seedWeek(b)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		store.GetSessionsByTimeRange(start, end)
+		store.GetScreenshotsByTimeRange(start, end)
+		store.GetWindowFocusEventsByTimeRange(start, end)
+		store.GetGitCommitsByTimeRange(start, end)
+		store.GetShellCommandsByTimeRange(start, end)
+		store.GetFileEventsByTimeRange(start, end)
+		store.GetBrowserVisitsByTimeRange(start, end)
+	}
+}
+
+// BenchmarkGetAllEventsForRange loads the same week with the sources queried in parallel.
+func BenchmarkGetAllEventsForRange(b *testing.B) {
+	store, start, end, cleanup := seedWeek(b)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.GetAllEventsForRange(context.Background(), start, end); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
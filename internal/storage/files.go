@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // SaveFileEvent saves a file event to the database.
@@ -45,7 +47,27 @@ func (s *Store) GetFileEventsBySession(sessionID int64) ([]*FileEvent, error) {
 
 // GetFileEventsByTimeRange retrieves file events within a time range.
 func (s *Store) GetFileEventsByTimeRange(start, end int64) ([]*FileEvent, error) {
-	rows, err := s.db.Query(`
+	var events []*FileEvent
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		events, err = queryFileEventsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return events, err
+}
+
+// GetFileEventsByTimeRangeAndExtension retrieves file events within a time range, limited
+// to one file type if extension (e.g. ".go" or "go") is non-empty.
+func (s *Store) GetFileEventsByTimeRangeAndExtension(start, end int64, extension string) ([]*FileEvent, error) {
+	if extension == "" {
+		return s.GetFileEventsByTimeRange(start, end)
+	}
+	return s.GetFileEventsByExtension(extension, start, end)
+}
+
+// queryFileEventsByTimeRange runs the GetFileEventsByTimeRange query on db.
+func queryFileEventsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*FileEvent, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, timestamp, event_type, file_path, file_name, directory,
 		       file_extension, file_size_bytes, watch_category, old_path, session_id, created_at
 		FROM file_events
@@ -59,6 +81,62 @@ func (s *Store) GetFileEventsByTimeRange(start, end int64) ([]*FileEvent, error)
 	return scanFileEvents(rows)
 }
 
+// GetFileEventsByExtension retrieves file events for one file extension within a time range.
+// The extension may be given with or without its leading dot.
+func (s *Store) GetFileEventsByExtension(extension string, start, end int64) ([]*FileEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, event_type, file_path, file_name, directory,
+		       file_extension, file_size_bytes, watch_category, old_path, session_id, created_at
+		FROM file_events
+		WHERE file_extension = ? AND timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, normalizeFileExtension(extension), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query file events by extension: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFileEvents(rows)
+}
+
+// GetTopFileExtensions returns the file extensions with the most events in a time range,
+// with counts per event type. Events without an extension are ignored.
+func (s *Store) GetTopFileExtensions(start, end int64, limit int) ([]*ExtensionCount, error) {
+	rows, err := s.db.Query(`
+		SELECT file_extension,
+		       COUNT(*) as event_count,
+		       SUM(CASE WHEN event_type = 'create' THEN 1 ELSE 0 END),
+		       SUM(CASE WHEN event_type = 'modify' THEN 1 ELSE 0 END),
+		       SUM(CASE WHEN event_type = 'delete' THEN 1 ELSE 0 END)
+		FROM file_events
+		WHERE timestamp >= ? AND timestamp <= ?
+		  AND file_extension IS NOT NULL AND file_extension != ''
+		GROUP BY file_extension
+		ORDER BY event_count DESC, file_extension ASC
+		LIMIT ?`, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top file extensions: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*ExtensionCount
+	for rows.Next() {
+		c := &ExtensionCount{}
+		if err := rows.Scan(&c.Extension, &c.EventCount, &c.CreateCount, &c.ModifyCount, &c.DeleteCount); err != nil {
+			return nil, fmt.Errorf("failed to scan file extension count: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// normalizeFileExtension adds the leading dot file extensions are stored with.
+func normalizeFileExtension(extension string) string {
+	if extension != "" && !strings.HasPrefix(extension, ".") {
+		return "." + extension
+	}
+	return extension
+}
+
 // GetFileEventsByCategory retrieves file events for a specific category.
 func (s *Store) GetFileEventsByCategory(category string, limit int) ([]*FileEvent, error) {
 	rows, err := s.db.Query(`
@@ -221,3 +221,77 @@ func TestCountFileEventsByTimeRange(t *testing.T) {
 		t.Errorf("expected 3, got %d", count)
 	}
 }
+
+func TestGetFileEventsByExtensionAndTopExtensions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	save := func(offset int64, eventType, name, ext string) {
+		t.Helper()
+		event := &FileEvent{
+			Timestamp:     now + offset,
+			EventType:     eventType,
+			FilePath:      "/home/user/projects/traq/" + name,
+			FileName:      name,
+			Directory:     "/home/user/projects/traq",
+			WatchCategory: "projects",
+		}
+		if ext != "" {
+			event.FileExtension = sql.NullString{String: ext, Valid: true}
+		}
+		if _, err := store.SaveFileEvent(event); err != nil {
+			t.Fatalf("failed to save file event: %v", err)
+		}
+	}
+	save(0, "create", "main.go", ".go")
+	save(1, "modify", "main.go", ".go")
+	save(2, "modify", "main.go", ".go")
+	save(3, "delete", "old.go", ".go")
+	save(4, "create", "README.md", ".md")
+	save(5, "modify", "Makefile", "")
+	save(-7200, "modify", "early.go", ".go") // Outside the range
+
+	events, err := store.GetFileEventsByExtension("go", now-60, now+60)
+	if err != nil {
+		t.Fatalf("GetFileEventsByExtension failed: %v", err)
+	}
+	if len(events) != 4 {
+		t.Errorf("expected 4 .go events, got %d", len(events))
+	}
+
+	filtered, err := store.GetFileEventsByTimeRangeAndExtension(now-60, now+60, "md")
+	if err != nil {
+		t.Fatalf("GetFileEventsByTimeRangeAndExtension failed: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].FileName != "README.md" {
+		t.Errorf("expected only README.md, got %+v", filtered)
+	}
+	all, err := store.GetFileEventsByTimeRangeAndExtension(now-60, now+60, "")
+	if err != nil {
+		t.Fatalf("GetFileEventsByTimeRangeAndExtension failed: %v", err)
+	}
+	if unfiltered, _ := store.GetFileEventsByTimeRange(now-60, now+60); len(all) != len(unfiltered) {
+		t.Errorf("expected no extension to return all %d events, got %d", len(unfiltered), len(all))
+	}
+
+	top, err := store.GetTopFileExtensions(now-60, now+60, 10)
+	if err != nil {
+		t.Fatalf("GetTopFileExtensions failed: %v", err)
+	}
+	if len(top) != 2 {
+		t.Fatalf("expected 2 extensions, got %+v", top)
+	}
+	want := ExtensionCount{Extension: ".go", EventCount: 4, CreateCount: 1, ModifyCount: 2, DeleteCount: 1}
+	if *top[0] != want {
+		t.Errorf("expected %+v, got %+v", want, *top[0])
+	}
+	if top[1].Extension != ".md" || top[1].EventCount != 1 || top[1].CreateCount != 1 {
+		t.Errorf("unexpected second extension: %+v", top[1])
+	}
+
+	top, _ = store.GetTopFileExtensions(now-60, now+60, 1)
+	if len(top) != 1 {
+		t.Errorf("expected limit to apply, got %d extensions", len(top))
+	}
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
@@ -11,10 +12,12 @@ func (s *Store) SaveFocusEvent(event *WindowFocusEvent) (int64, error) {
 	result, err := s.db.Exec(`
 		INSERT INTO window_focus_events (
 			window_title, app_name, window_class,
-			start_time, end_time, duration_seconds, session_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			start_time, end_time, duration_seconds, session_id,
+			git_repository_id, current_branch
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		event.WindowTitle, event.AppName, event.WindowClass,
 		event.StartTime, event.EndTime, event.DurationSeconds, event.SessionID,
+		event.GitRepositoryID, event.CurrentBranch,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert focus event: %w", err)
@@ -33,7 +36,8 @@ func (s *Store) GetFocusEventsBySession(sessionID int64) ([]*WindowFocusEvent, e
 	rows, err := s.db.Query(`
 		SELECT id, window_title, app_name, window_class,
 		       start_time, end_time, duration_seconds, session_id, created_at,
-		       project_id, project_confidence, project_source
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
 		FROM window_focus_events
 		WHERE session_id = ?
 		ORDER BY start_time ASC`, sessionID)
@@ -45,14 +49,93 @@ func (s *Store) GetFocusEventsBySession(sessionID int64) ([]*WindowFocusEvent, e
 	return scanFocusEvents(rows)
 }
 
+// GetWindowFocusEventsBySessions retrieves focus events for multiple sessions in one query.
+// The result maps each session ID to its events ordered by start time; sessions
+// without events are omitted.
+func (s *Store) GetWindowFocusEventsBySessions(sessionIDs []int64) (map[int64][]*WindowFocusEvent, error) {
+	result := make(map[int64][]*WindowFocusEvent)
+	if len(sessionIDs) == 0 {
+		return result, nil
+	}
+
+	// Build placeholder string for IN clause
+	placeholders := ""
+	args := make([]interface{}, len(sessionIDs))
+	for i, id := range sessionIDs {
+		if i > 0 {
+			placeholders += ","
+		}
+		placeholders += "?"
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, window_title, app_name, window_class,
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
+		FROM window_focus_events
+		WHERE session_id IN (%s)
+		ORDER BY session_id, start_time ASC`, placeholders)
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus events by sessions: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanFocusEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, evt := range events {
+		result[evt.SessionID.Int64] = append(result[evt.SessionID.Int64], evt)
+	}
+
+	return result, nil
+}
+
 // GetFocusEventsByTimeRange retrieves focus events that overlap with a time range.
 // An event overlaps if it starts at or before the range ends AND ends after the range starts.
 // This correctly handles events that span midnight boundaries.
 func (s *Store) GetFocusEventsByTimeRange(start, end int64) ([]*WindowFocusEvent, error) {
-	rows, err := s.db.Query(`
+	var events []*WindowFocusEvent
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		events, err = queryFocusEventsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return events, err
+}
+
+// GetFocusDurationByTimeRanges returns the total focus time in seconds within each range,
+// keyed by range. Events are clamped to the range they overlap, and each range is summed
+// by its own aggregate query so no events are loaded.
+func (s *Store) GetFocusDurationByTimeRanges(ranges [][2]int64) (map[[2]int64]float64, error) {
+	result := make(map[[2]int64]float64, len(ranges))
+	err := s.WithReadDB(func(db *sql.DB) error {
+		for _, r := range ranges {
+			var total float64
+			err := db.QueryRow(`
+				SELECT COALESCE(SUM(MAX(0, MIN(end_time, ?) - MAX(start_time, ?))), 0)
+				FROM window_focus_events
+				WHERE start_time <= ? AND end_time > ?`, r[1], r[0], r[1], r[0]).Scan(&total)
+			if err != nil {
+				return fmt.Errorf("failed to sum focus duration: %w", err)
+			}
+			result[r] = total
+		}
+		return nil
+	})
+	return result, err
+}
+
+// queryFocusEventsByTimeRange runs the GetFocusEventsByTimeRange query on db.
+func queryFocusEventsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*WindowFocusEvent, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, window_title, app_name, window_class,
 		       start_time, end_time, duration_seconds, session_id, created_at,
-		       project_id, project_confidence, project_source
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
 		FROM window_focus_events
 		WHERE start_time <= ? AND end_time > ?
 		ORDER BY start_time ASC`, end, start)
@@ -64,6 +147,127 @@ func (s *Store) GetFocusEventsByTimeRange(start, end int64) ([]*WindowFocusEvent
 	return scanFocusEvents(rows)
 }
 
+// GetFocusEventsByBranch retrieves focus events recorded on a repository branch that overlap with a time range.
+func (s *Store) GetFocusEventsByBranch(repoID int64, branch string, start, end int64) ([]*WindowFocusEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, window_title, app_name, window_class,
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
+		FROM window_focus_events
+		WHERE git_repository_id = ? AND current_branch = ?
+		  AND start_time <= ? AND end_time > ?
+		ORDER BY start_time ASC`, repoID, branch, end, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus events by branch: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFocusEvents(rows)
+}
+
+// GetFocusEventsByProjectName retrieves focus events assigned to the named project
+// (matched case-insensitively) that overlap with a time range.
+func (s *Store) GetFocusEventsByProjectName(name string, start, end int64) ([]*WindowFocusEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT f.id, f.window_title, f.app_name, f.window_class,
+		       f.start_time, f.end_time, f.duration_seconds, f.session_id, f.created_at,
+		       f.project_id, f.project_confidence, f.project_source,
+		       f.git_repository_id, f.current_branch
+		FROM window_focus_events f
+		JOIN projects p ON p.id = f.project_id
+		WHERE p.name = ? COLLATE NOCASE
+		  AND f.start_time <= ? AND f.end_time > ?
+		ORDER BY f.start_time ASC`, name, end, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus events by project: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFocusEvents(rows)
+}
+
+// windowTitleLikePattern returns a LIKE pattern for a window title search. Patterns
+// without wildcards match anywhere in the title.
+func windowTitleLikePattern(pattern string) string {
+	if strings.ContainsAny(pattern, "%_") {
+		return pattern
+	}
+	return "%" + pattern + "%"
+}
+
+// GetFocusEventsByWindowTitle retrieves focus events whose window title matches a
+// case-insensitive LIKE pattern and that overlap with a time range.
+func (s *Store) GetFocusEventsByWindowTitle(pattern string, start, end int64) ([]*WindowFocusEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, window_title, app_name, window_class,
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
+		FROM window_focus_events
+		WHERE window_title LIKE ? COLLATE NOCASE
+		  AND start_time <= ? AND end_time > ?
+		ORDER BY start_time ASC`, windowTitleLikePattern(pattern), end, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus events by window title: %w", err)
+	}
+	defer rows.Close()
+
+	return scanFocusEvents(rows)
+}
+
+// GetWindowTitleFrequency returns the window titles with the most focus time in a time range.
+func (s *Store) GetWindowTitleFrequency(start, end int64, limit int) ([]*WindowTitleCount, error) {
+	rows, err := s.db.Query(`
+		SELECT window_title, MAX(app_name), COUNT(*), SUM(duration_seconds) as total_duration
+		FROM window_focus_events
+		WHERE start_time <= ? AND end_time > ? AND window_title != ''
+		GROUP BY window_title
+		ORDER BY total_duration DESC, window_title ASC
+		LIMIT ?`, end, start, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query window title frequency: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []*WindowTitleCount
+	for rows.Next() {
+		c := &WindowTitleCount{}
+		if err := rows.Scan(&c.WindowTitle, &c.AppName, &c.Count, &c.TotalSeconds); err != nil {
+			return nil, fmt.Errorf("failed to scan window title frequency: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// GetWindowTitleSecondsByDay returns focus time per local date ("2006-01-02") for windows
+// whose title matches a case-insensitive LIKE pattern. Events are attributed to the day
+// they started on.
+func (s *Store) GetWindowTitleSecondsByDay(pattern string, start, end int64) (map[string]float64, error) {
+	rows, err := s.db.Query(`
+		SELECT date(start_time, 'unixepoch', 'localtime') as day, SUM(duration_seconds)
+		FROM window_focus_events
+		WHERE window_title LIKE ? COLLATE NOCASE
+		  AND start_time >= ? AND start_time <= ?
+		GROUP BY day`, windowTitleLikePattern(pattern), start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query window time by day: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[string]float64)
+	for rows.Next() {
+		var day string
+		var seconds float64
+		if err := rows.Scan(&day, &seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan window time by day: %w", err)
+		}
+		days[day] = seconds
+	}
+	return days, rows.Err()
+}
+
 // GetAppUsageByTimeRange returns aggregated app usage statistics.
 // Uses overlap detection to correctly handle events spanning midnight boundaries.
 func (s *Store) GetAppUsageByTimeRange(start, end int64) (map[string]float64, error) {
@@ -130,21 +334,105 @@ func (s *Store) GetWindowFocusEventsByTimeRange(start, end int64) ([]*WindowFocu
 	return s.GetFocusEventsByTimeRange(start, end)
 }
 
+// GetTotalFocusSeconds returns the total duration of all focus events.
+func (s *Store) GetTotalFocusSeconds() (float64, error) {
+	var seconds float64
+	err := s.db.QueryRow("SELECT COALESCE(SUM(duration_seconds), 0) FROM window_focus_events").Scan(&seconds)
+	return seconds, err
+}
+
+// CountUniqueApps returns the number of distinct app names with focus events.
+func (s *Store) CountUniqueApps() (int64, error) {
+	var count int64
+	err := s.db.QueryRow("SELECT COUNT(DISTINCT app_name) FROM window_focus_events").Scan(&count)
+	return count, err
+}
+
+// CountFocusEventsByTimeRange returns the number of focus events that overlap with a time range.
+func (s *Store) CountFocusEventsByTimeRange(start, end int64) (int64, error) {
+	var count int64
+	err := s.db.QueryRow(`
+		SELECT COUNT(*) FROM window_focus_events
+		WHERE start_time <= ? AND end_time > ?`, end, start).Scan(&count)
+	return count, err
+}
+
+// StreamWindowFocusEvents calls fn for each focus event that overlaps with a time range,
+// oldest first, reading one row at a time instead of loading the whole range into memory.
+// Streaming stops at the first error returned by fn, or when ctx is cancelled. Rows are
+// read from the read pool, so fn may query the store.
+func (s *Store) StreamWindowFocusEvents(ctx context.Context, start, end int64, fn func(*WindowFocusEvent) error) error {
+	return s.WithReadDB(func(db *sql.DB) error {
+		rows, err := db.QueryContext(ctx, `
+			SELECT id, window_title, app_name, window_class,
+			       start_time, end_time, duration_seconds, session_id, created_at,
+			       project_id, project_confidence, project_source,
+			       git_repository_id, current_branch
+			FROM window_focus_events
+			WHERE start_time <= ? AND end_time > ?
+			ORDER BY start_time ASC`, end, start)
+		if err != nil {
+			return fmt.Errorf("failed to query focus events by time: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			event, err := scanFocusEvent(rows)
+			if err != nil {
+				return err
+			}
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		return rows.Err()
+	})
+}
+
 // GetWindowFocusEventsBySession is an alias for GetFocusEventsBySession.
 func (s *Store) GetWindowFocusEventsBySession(sessionID int64) ([]*WindowFocusEvent, error) {
 	return s.GetFocusEventsBySession(sessionID)
 }
 
+// GetFocusSecondsByDay returns total focus time per local date ("2006-01-02") in a time range.
+// Events are attributed to the day they started on.
+func (s *Store) GetFocusSecondsByDay(start, end int64) (map[string]float64, error) {
+	rows, err := s.db.Query(`
+		SELECT date(start_time, 'unixepoch', 'localtime') as day, SUM(duration_seconds)
+		FROM window_focus_events
+		WHERE start_time >= ? AND start_time <= ?
+		GROUP BY day`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus time by day: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[string]float64)
+	for rows.Next() {
+		var day string
+		var seconds float64
+		if err := rows.Scan(&day, &seconds); err != nil {
+			return nil, fmt.Errorf("failed to scan focus time by day: %w", err)
+		}
+		days[day] = seconds
+	}
+	return days, rows.Err()
+}
+
 // GetFocusEventByID retrieves a single focus event by ID.
 func (s *Store) GetFocusEventByID(id int64) (*WindowFocusEvent, error) {
 	event := &WindowFocusEvent{}
 	err := s.db.QueryRow(`
 		SELECT id, window_title, app_name, window_class,
-		       start_time, end_time, duration_seconds, session_id, created_at
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
 		FROM window_focus_events
 		WHERE id = ?`, id).Scan(
 		&event.ID, &event.WindowTitle, &event.AppName, &event.WindowClass,
 		&event.StartTime, &event.EndTime, &event.DurationSeconds, &event.SessionID, &event.CreatedAt,
+		&event.ProjectID, &event.ProjectConfidence, &event.ProjectSource,
+		&event.GitRepositoryID, &event.CurrentBranch,
 	)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("focus event not found: %d", id)
@@ -155,6 +443,30 @@ func (s *Store) GetFocusEventByID(id int64) (*WindowFocusEvent, error) {
 	return event, nil
 }
 
+// GetFocusEventAt retrieves the focus event whose [start_time, end_time] interval contains a timestamp.
+// If events touch at the timestamp, the later one wins. Returns nil if nothing was focused.
+func (s *Store) GetFocusEventAt(timestamp int64) (*WindowFocusEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, window_title, app_name, window_class,
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
+		FROM window_focus_events
+		WHERE start_time <= ? AND end_time >= ?
+		ORDER BY start_time DESC
+		LIMIT 1`, timestamp, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query focus event at time: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanFocusEvents(rows)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	return events[0], nil
+}
+
 // UpdateFocusEvent updates editable fields of a window focus event.
 // Duration is recalculated from the time range.
 func (s *Store) UpdateFocusEvent(id int64, windowTitle, appName string, startTime, endTime int64) error {
@@ -320,19 +632,119 @@ func (s *Store) GetFocusEventsForBackfill(startTime, endTime int64) ([]*WindowFo
 	return events, rows.Err()
 }
 
+// CompressFocusEvents merges runs of consecutive focus events in a session that are
+// separated by gaps shorter than thresholdSeconds. Only events for the same app, window
+// title, project, git branch and memory status are merged. Each run collapses into its
+// first event, which keeps the earliest start time, takes the latest end time, and sums the
+// durations. Events that are pinned, embedded or used as assignment examples are never
+// removed. Returns the number of events removed.
+func (s *Store) CompressFocusEvents(sessionID int64, thresholdSeconds float64) (int64, error) {
+	var removed int64
+	err := s.Transaction(func(tx *sql.Tx) error {
+		rows, err := tx.Query(`
+			SELECT f.id, f.window_title, f.app_name, f.start_time, f.end_time, f.duration_seconds,
+			       f.project_id, f.git_repository_id, f.current_branch, COALESCE(f.memory_status, 'active'),
+			       EXISTS(SELECT 1 FROM pinned_events p WHERE p.event_type = 'focus' AND p.event_id = f.id)
+			       OR EXISTS(SELECT 1 FROM activity_embeddings e WHERE e.event_type IN ('focus', 'activity') AND e.event_id = f.id)
+			       OR EXISTS(SELECT 1 FROM assignment_examples a WHERE a.event_type IN ('focus', 'activity') AND a.event_id = f.id)
+			FROM window_focus_events f
+			WHERE f.session_id = ?
+			ORDER BY f.start_time, f.id`, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to query focus events: %w", err)
+		}
+		var events []*WindowFocusEvent
+		referenced := make(map[int64]bool)
+		for rows.Next() {
+			e := &WindowFocusEvent{}
+			var isReferenced bool
+			if err := rows.Scan(&e.ID, &e.WindowTitle, &e.AppName, &e.StartTime, &e.EndTime, &e.DurationSeconds,
+				&e.ProjectID, &e.GitRepositoryID, &e.CurrentBranch, &e.MemoryStatus, &isReferenced); err != nil {
+				rows.Close()
+				return fmt.Errorf("failed to scan focus event: %w", err)
+			}
+			referenced[e.ID] = isReferenced
+			events = append(events, e)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		var head *WindowFocusEvent
+		var merged []int64
+		flush := func() error {
+			if head == nil || len(merged) == 0 {
+				return nil
+			}
+			if _, err := tx.Exec(`UPDATE window_focus_events SET end_time = ?, duration_seconds = ? WHERE id = ?`,
+				head.EndTime, head.DurationSeconds, head.ID); err != nil {
+				return fmt.Errorf("failed to update focus event: %w", err)
+			}
+			for _, id := range merged {
+				if _, err := tx.Exec(`DELETE FROM window_focus_events WHERE id = ?`, id); err != nil {
+					return fmt.Errorf("failed to delete focus event: %w", err)
+				}
+			}
+			removed += int64(len(merged))
+			merged = merged[:0]
+			return nil
+		}
+
+		for _, e := range events {
+			// Referenced events may still absorb later events, but are never merged away
+			if head != nil && !referenced[e.ID] && sameFocusActivity(head, e) &&
+				float64(e.StartTime-head.EndTime) < thresholdSeconds {
+				if e.EndTime > head.EndTime {
+					head.EndTime = e.EndTime
+				}
+				head.DurationSeconds += e.DurationSeconds
+				merged = append(merged, e.ID)
+				continue
+			}
+			if err := flush(); err != nil {
+				return err
+			}
+			head = e
+		}
+		return flush()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return removed, nil
+}
+
+// sameFocusActivity reports whether two focus events record the same activity and can be
+// merged without losing information.
+func sameFocusActivity(a, b *WindowFocusEvent) bool {
+	return a.AppName == b.AppName && a.WindowTitle == b.WindowTitle && a.ProjectID == b.ProjectID &&
+		a.GitRepositoryID == b.GitRepositoryID && a.CurrentBranch == b.CurrentBranch && a.MemoryStatus == b.MemoryStatus
+}
+
 func scanFocusEvents(rows *sql.Rows) ([]*WindowFocusEvent, error) {
 	var events []*WindowFocusEvent
 	for rows.Next() {
-		event := &WindowFocusEvent{}
-		err := rows.Scan(
-			&event.ID, &event.WindowTitle, &event.AppName, &event.WindowClass,
-			&event.StartTime, &event.EndTime, &event.DurationSeconds, &event.SessionID, &event.CreatedAt,
-			&event.ProjectID, &event.ProjectConfidence, &event.ProjectSource,
-		)
+		event, err := scanFocusEvent(rows)
 		if err != nil {
-			return nil, fmt.Errorf("failed to scan focus event: %w", err)
+			return nil, err
 		}
 		events = append(events, event)
 	}
 	return events, rows.Err()
 }
+
+// scanFocusEvent scans the current row of a focus event query.
+func scanFocusEvent(rows *sql.Rows) (*WindowFocusEvent, error) {
+	event := &WindowFocusEvent{}
+	err := rows.Scan(
+		&event.ID, &event.WindowTitle, &event.AppName, &event.WindowClass,
+		&event.StartTime, &event.EndTime, &event.DurationSeconds, &event.SessionID, &event.CreatedAt,
+		&event.ProjectID, &event.ProjectConfidence, &event.ProjectSource,
+		&event.GitRepositoryID, &event.CurrentBranch,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan focus event: %w", err)
+	}
+	return event, nil
+}
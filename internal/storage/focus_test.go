@@ -1,7 +1,9 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"testing"
 	"time"
 )
@@ -72,6 +74,103 @@ func TestGetFocusEventsBySession(t *testing.T) {
 	}
 }
 
+func TestGetWindowFocusEventsBySessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	session1, _ := store.CreateSession(now - 7200)
+	session2, _ := store.CreateSession(now - 3600)
+	emptySession, _ := store.CreateSession(now)
+
+	// Save out of order to verify per-session ordering by start time
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "b", AppName: "App", StartTime: now - 7000, EndTime: now - 6900, DurationSeconds: 100, SessionID: sql.NullInt64{Int64: session1, Valid: true}})
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "a", AppName: "App", StartTime: now - 7100, EndTime: now - 7000, DurationSeconds: 100, SessionID: sql.NullInt64{Int64: session1, Valid: true}})
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "c", AppName: "App", StartTime: now - 3500, EndTime: now - 3400, DurationSeconds: 100, SessionID: sql.NullInt64{Int64: session2, Valid: true}})
+
+	events, err := store.GetWindowFocusEventsBySessions([]int64{session1, session2, emptySession})
+	if err != nil {
+		t.Fatalf("failed to get focus events by sessions: %v", err)
+	}
+
+	if len(events[session1]) != 2 {
+		t.Fatalf("expected 2 events for session 1, got %d", len(events[session1]))
+	}
+	if events[session1][0].WindowTitle != "a" || events[session1][1].WindowTitle != "b" {
+		t.Errorf("expected session 1 events ordered by start time, got %q, %q", events[session1][0].WindowTitle, events[session1][1].WindowTitle)
+	}
+	if len(events[session2]) != 1 {
+		t.Errorf("expected 1 event for session 2, got %d", len(events[session2]))
+	}
+	if _, ok := events[emptySession]; ok {
+		t.Error("expected no entry for session without events")
+	}
+
+	empty, err := store.GetWindowFocusEventsBySessions(nil)
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected empty result for no sessions, got %v, %v", empty, err)
+	}
+}
+
+// seedFocusBenchmark creates sessions with focus events for the session-loading benchmarks.
+func seedFocusBenchmark(b *testing.B, sessions, eventsPerSession int) (*Store, []int64, func()) {
+	b.Helper()
+
+	store, cleanup := testStore(b)
+	now := time.Now().Unix()
+
+	var ids []int64
+	for i := 0; i < sessions; i++ {
+		start := now - int64((sessions-i)*3600)
+		sessionID, err := store.CreateSession(start)
+		if err != nil {
+			cleanup()
+			b.Fatalf("failed to create session: %v", err)
+		}
+		for j := 0; j < eventsPerSession; j++ {
+			store.SaveFocusEvent(&WindowFocusEvent{
+				WindowTitle:     "Window",
+				AppName:         "App",
+				StartTime:       start + int64(j*60),
+				EndTime:         start + int64(j*60+60),
+				DurationSeconds: 60,
+				SessionID:       sql.NullInt64{Int64: sessionID, Valid: true},
+			})
+		}
+		ids = append(ids, sessionID)
+	}
+
+	return store, ids, cleanup
+}
+
+// BenchmarkFocusEventsPerSession loads 50 sessions one query at a time.
+func BenchmarkFocusEventsPerSession(b *testing.B) {
+	store, ids, cleanup := seedFocusBenchmark(b, 50, 20)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, id := range ids {
+			if _, err := store.GetWindowFocusEventsBySession(id); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkFocusEventsBatch loads the same 50 sessions with a single query.
+func BenchmarkFocusEventsBatch(b *testing.B) {
+	store, ids, cleanup := seedFocusBenchmark(b, 50, 20)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.GetWindowFocusEventsBySessions(ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestGetFocusEventsByTimeRange(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -169,6 +268,36 @@ func TestGetFocusEventsByTimeRange_MidnightBoundary(t *testing.T) {
 	}
 }
 
+func TestGetFocusDurationByTimeRanges(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	midnight := int64(86400)
+	// One hour before midnight, two hours spanning it, and 30 minutes the next afternoon
+	for _, evt := range [][2]int64{{midnight - 7200, midnight - 3600}, {midnight - 3600, midnight + 3600}, {midnight + 43200, midnight + 45000}} {
+		store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle: "Work", AppName: "Code", StartTime: evt[0], EndTime: evt[1], DurationSeconds: float64(evt[1] - evt[0]),
+		})
+	}
+
+	day1 := [2]int64{0, midnight - 1}
+	day2 := [2]int64{midnight, 2*midnight - 1}
+	empty := [2]int64{3 * midnight, 4*midnight - 1}
+	totals, err := store.GetFocusDurationByTimeRanges([][2]int64{day1, day2, empty})
+	if err != nil {
+		t.Fatalf("GetFocusDurationByTimeRanges failed: %v", err)
+	}
+	if totals[day1] != 7199 {
+		t.Errorf("day 1: got %v seconds, want 7199", totals[day1])
+	}
+	if totals[day2] != 5400 {
+		t.Errorf("day 2: got %v seconds, want 5400", totals[day2])
+	}
+	if v, ok := totals[empty]; !ok || v != 0 {
+		t.Errorf("empty day: got %v (present %v), want 0", v, ok)
+	}
+}
+
 func TestGetAppUsageByTimeRange(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -247,6 +376,61 @@ func TestGetTopApps(t *testing.T) {
 	}
 }
 
+func TestWindowTitleDrillDown(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	day := time.Date(2026, time.March, 2, 9, 0, 0, 0, time.Local).Unix()
+	save := func(app, title string, start int64, seconds float64) {
+		store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle: title, AppName: app,
+			StartTime: start, EndTime: start + int64(seconds), DurationSeconds: seconds,
+		})
+	}
+	save("Code", "main.go - traq", day, 600)
+	save("Code", "main.go - traq", day+3600, 300)
+	save("Code", "README.md - traq", day+7200, 1200)
+	save("Firefox", "Pull Request #12 - GitHub", day+9000, 120)
+	save("Code", "main.go - traq", day+86400, 60)
+
+	events, err := store.GetFocusEventsByWindowTitle("MAIN.GO", day, day+86399)
+	if err != nil {
+		t.Fatalf("GetFocusEventsByWindowTitle failed: %v", err)
+	}
+	if len(events) != 2 || events[0].StartTime != day {
+		t.Errorf("expected 2 main.go events in start order, got %d", len(events))
+	}
+	// Explicit wildcards are used as given
+	if events, _ := store.GetFocusEventsByWindowTitle("%traq", day, day+86399); len(events) != 3 {
+		t.Errorf("expected 3 events ending in traq, got %d", len(events))
+	}
+	if events, _ := store.GetFocusEventsByWindowTitle("traq", day+86400, day+2*86400); len(events) != 1 {
+		t.Errorf("expected 1 event on the next day, got %d", len(events))
+	}
+
+	freq, err := store.GetWindowTitleFrequency(day, day+86399, 2)
+	if err != nil {
+		t.Fatalf("GetWindowTitleFrequency failed: %v", err)
+	}
+	if len(freq) != 2 {
+		t.Fatalf("expected 2 titles, got %d", len(freq))
+	}
+	if freq[0].WindowTitle != "README.md - traq" || freq[0].Count != 1 || freq[0].TotalSeconds != 1200 {
+		t.Errorf("unexpected top title: %+v", freq[0])
+	}
+	if freq[1].WindowTitle != "main.go - traq" || freq[1].AppName != "Code" || freq[1].Count != 2 || freq[1].TotalSeconds != 900 {
+		t.Errorf("unexpected second title: %+v", freq[1])
+	}
+
+	byDay, err := store.GetWindowTitleSecondsByDay("main.go", day-3600, day+2*86400)
+	if err != nil {
+		t.Fatalf("GetWindowTitleSecondsByDay failed: %v", err)
+	}
+	if byDay["2026-03-02"] != 900 || byDay["2026-03-03"] != 60 || len(byDay) != 2 {
+		t.Errorf("unexpected window time by day: %v", byDay)
+	}
+}
+
 func TestGetWindowFocusEventsByTimeRange_Alias(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -289,3 +473,247 @@ func TestGetWindowFocusEventsBySession_Alias(t *testing.T) {
 		t.Errorf("got %d events, want 1", len(events))
 	}
 }
+
+func TestGetFocusEventsByBranch(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, err := store.SaveGitRepository(&GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	if err != nil {
+		t.Fatalf("SaveGitRepository failed: %v", err)
+	}
+
+	now := time.Now().Unix()
+	save := func(start int64, branch string) {
+		t.Helper()
+		_, err := store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle: "main.go - traq", AppName: "code", StartTime: start, EndTime: start + 600, DurationSeconds: 600,
+			GitRepositoryID: sql.NullInt64{Int64: repoID, Valid: true},
+			CurrentBranch:   sql.NullString{String: branch, Valid: true},
+		})
+		if err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+	}
+	save(now-3000, "feature/branches")
+	save(now-2000, "main")
+	save(now-1000, "feature/branches")
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "Inbox", AppName: "firefox", StartTime: now - 500, EndTime: now, DurationSeconds: 500})
+
+	events, err := store.GetFocusEventsByBranch(repoID, "feature/branches", now-3600, now)
+	if err != nil {
+		t.Fatalf("GetFocusEventsByBranch failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events on the branch, got %d", len(events))
+	}
+	for _, evt := range events {
+		if evt.CurrentBranch.String != "feature/branches" || evt.GitRepositoryID.Int64 != repoID {
+			t.Errorf("unexpected git context: %+v", evt)
+		}
+	}
+
+	// Time range limits results
+	events, err = store.GetFocusEventsByBranch(repoID, "feature/branches", now-1500, now)
+	if err != nil {
+		t.Fatalf("GetFocusEventsByBranch failed: %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("expected 1 event in the narrower range, got %d", len(events))
+	}
+}
+
+func TestGetFocusEventsByProjectName(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	traq, err := store.CreateProject("Traq", "#6366f1", "")
+	if err != nil {
+		t.Fatalf("CreateProject failed: %v", err)
+	}
+	other, _ := store.CreateProject("Other", "#22c55e", "")
+
+	now := time.Now().Unix()
+	save := func(start int64, projectID int64) {
+		t.Helper()
+		id, err := store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle: "main.go", AppName: "code", StartTime: start, EndTime: start + 600, DurationSeconds: 600,
+		})
+		if err != nil {
+			t.Fatalf("SaveFocusEvent failed: %v", err)
+		}
+		if projectID > 0 {
+			if err := store.SetEventProject("focus", id, projectID, 1, "user"); err != nil {
+				t.Fatalf("SetEventProject failed: %v", err)
+			}
+		}
+	}
+	save(now-3000, traq.ID)
+	save(now-2000, other.ID)
+	save(now-1500, 0)
+	save(now-1000, traq.ID)
+
+	events, err := store.GetFocusEventsByProjectName("traq", now-3600, now)
+	if err != nil {
+		t.Fatalf("GetFocusEventsByProjectName failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events for the project, got %d", len(events))
+	}
+	for _, evt := range events {
+		if evt.ProjectID.Int64 != traq.ID {
+			t.Errorf("event %d assigned to project %d, want %d", evt.ID, evt.ProjectID.Int64, traq.ID)
+		}
+	}
+
+	events, _ = store.GetFocusEventsByProjectName("Traq", now-1200, now)
+	if len(events) != 1 {
+		t.Errorf("expected 1 event in the narrower range, got %d", len(events))
+	}
+	events, _ = store.GetFocusEventsByProjectName("Missing", now-3600, now)
+	if len(events) != 0 {
+		t.Errorf("expected no events for an unknown project, got %d", len(events))
+	}
+}
+
+func TestCompressFocusEvents(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	start := time.Now().Unix() - 1000
+	sessionID, _ := store.CreateSession(start)
+	otherSessionID, _ := store.CreateSession(start + 500)
+	inSession := sql.NullInt64{Int64: sessionID, Valid: true}
+	for i := int64(0); i < 100; i++ {
+		store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle:     "Tab",
+			AppName:         "firefox",
+			StartTime:       start + i,
+			EndTime:         start + i + 1,
+			DurationSeconds: 1,
+			SessionID:       inSession,
+		})
+	}
+	// A different app, then the browser again after a gap longer than the threshold
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "Terminal", AppName: "kitty", StartTime: start + 100, EndTime: start + 110, DurationSeconds: 10, SessionID: inSession})
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "Tab", AppName: "firefox", StartTime: start + 120, EndTime: start + 121, DurationSeconds: 1, SessionID: inSession})
+	// A different window title in the same app is kept separate
+	store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "Other Tab", AppName: "firefox", StartTime: start + 121, EndTime: start + 122, DurationSeconds: 1, SessionID: inSession})
+	// A pinned event is never merged away
+	pinnedID, _ := store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "Other Tab", AppName: "firefox", StartTime: start + 122, EndTime: start + 123, DurationSeconds: 1, SessionID: inSession})
+	if err := store.PinEvent("focus", pinnedID, ""); err != nil {
+		t.Fatalf("PinEvent failed: %v", err)
+	}
+	// Events in other sessions are left alone
+	for i := int64(0); i < 3; i++ {
+		store.SaveFocusEvent(&WindowFocusEvent{
+			WindowTitle: "Tab", AppName: "firefox", StartTime: start + 500 + i, EndTime: start + 501 + i, DurationSeconds: 1,
+			SessionID: sql.NullInt64{Int64: otherSessionID, Valid: true},
+		})
+	}
+
+	removed, err := store.CompressFocusEvents(sessionID, 3.0)
+	if err != nil {
+		t.Fatalf("CompressFocusEvents failed: %v", err)
+	}
+	if removed != 99 {
+		t.Errorf("expected 99 events removed, got %d", removed)
+	}
+
+	events, err := store.GetFocusEventsBySession(sessionID)
+	if err != nil {
+		t.Fatalf("GetFocusEventsBySession failed: %v", err)
+	}
+	if len(events) != 5 {
+		t.Fatalf("expected 5 events after compression, got %d", len(events))
+	}
+	merged := events[0]
+	if merged.AppName != "firefox" || merged.StartTime != start || merged.EndTime != start+100 {
+		t.Errorf("expected merged firefox event %d-%d, got %s %d-%d", start, start+100, merged.AppName, merged.StartTime, merged.EndTime)
+	}
+	if merged.DurationSeconds != 100 {
+		t.Errorf("expected merged duration 100, got %f", merged.DurationSeconds)
+	}
+	if events[4].ID != pinnedID {
+		t.Errorf("expected the pinned event %d to be kept, got %d", pinnedID, events[4].ID)
+	}
+	if other, _ := store.GetFocusEventsBySession(otherSessionID); len(other) != 3 {
+		t.Errorf("expected events in other sessions to be untouched, got %d", len(other))
+	}
+
+	// Compressing again is a no-op
+	if removed, _ := store.CompressFocusEvents(sessionID, 3.0); removed != 0 {
+		t.Errorf("expected no events removed on second pass, got %d", removed)
+	}
+}
+
+func TestStreamWindowFocusEvents(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	const total = 10000
+	base := time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC).Unix()
+	err := store.Transaction(func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare(`INSERT INTO window_focus_events (window_title, app_name, start_time, end_time, duration_seconds) VALUES (?, ?, ?, ?, 30)`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+		for i := 0; i < total; i++ {
+			start := base + int64(i*30)
+			if _, err := stmt.Exec("main.go", "code", start, start+30); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("failed to insert focus events: %v", err)
+	}
+	end := base + total*30
+
+	if count, err := store.CountFocusEventsByTimeRange(base, end); err != nil || count != total {
+		t.Fatalf("CountFocusEventsByTimeRange = %d, %v; want %d", count, err, total)
+	}
+
+	var seen int
+	var last int64
+	err = store.StreamWindowFocusEvents(context.Background(), base, end, func(evt *WindowFocusEvent) error {
+		if evt.StartTime < last {
+			t.Fatalf("events out of order at %d", seen)
+		}
+		last = evt.StartTime
+		seen++
+		return nil
+	})
+	if err != nil || seen != total {
+		t.Fatalf("streamed %d events (err %v), want %d", seen, err, total)
+	}
+
+	// Rows are handed over one at a time: stopping early reads no further
+	errStop := errors.New("stop")
+	seen = 0
+	err = store.StreamWindowFocusEvents(context.Background(), base, end, func(*WindowFocusEvent) error {
+		seen++
+		if seen == 10 {
+			return errStop
+		}
+		return nil
+	})
+	if !errors.Is(err, errStop) || seen != 10 {
+		t.Errorf("expected to stop after 10 events with errStop, got %d events, %v", seen, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	seen = 0
+	err = store.StreamWindowFocusEvents(ctx, base, end, func(*WindowFocusEvent) error {
+		seen++
+		if seen == 100 {
+			cancel()
+		}
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || seen >= total {
+		t.Errorf("expected cancellation to stop the stream, got %d events, %v", seen, err)
+	}
+}
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // SaveGitRepository saves or updates a git repository.
@@ -105,6 +107,61 @@ func (s *Store) SetGitRepositoryActive(id int64, active bool) error {
 	return err
 }
 
+// ReactivateGitRepository resumes polling a repository marked inactive.
+func (s *Store) ReactivateGitRepository(id int64) error {
+	_, err := s.db.Exec(`
+		UPDATE git_repositories SET is_active = 1, activated_at = strftime('%s', 'now')
+		WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to reactivate repository: %w", err)
+	}
+	return nil
+}
+
+// GetGitRepositoriesNotSeenSince retrieves active repositories with no commits since cutoffTime.
+// Repositories without any commits are judged by when they were registered, and reactivated
+// repositories count as seen when they were reactivated.
+func (s *Store) GetGitRepositoriesNotSeenSince(cutoffTime int64) ([]*GitRepository, error) {
+	rows, err := s.db.Query(`
+		SELECT r.id, r.path, r.name, r.remote_url, r.last_scanned, r.is_active, r.created_at
+		FROM git_repositories r
+		WHERE r.is_active = 1
+		  AND MAX(
+		      COALESCE((SELECT MAX(c.timestamp) FROM git_commits c WHERE c.repository_id = r.id), r.created_at),
+		      COALESCE(r.activated_at, 0)) < ?
+		ORDER BY r.name ASC`, cutoffTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query stale repositories: %w", err)
+	}
+	defer rows.Close()
+
+	var repos []*GitRepository
+	for rows.Next() {
+		repo := &GitRepository{}
+		err := rows.Scan(
+			&repo.ID, &repo.Path, &repo.Name, &repo.RemoteURL, &repo.LastScanned, &repo.IsActive, &repo.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan repository: %w", err)
+		}
+		repos = append(repos, repo)
+	}
+	return repos, rows.Err()
+}
+
+// MarkRepositoryInactive stops polling a repository. Registering or discovering it again
+// reactivates it.
+func (s *Store) MarkRepositoryInactive(repoID int64) error {
+	result, err := s.db.Exec("UPDATE git_repositories SET is_active = 0 WHERE id = ?", repoID)
+	if err != nil {
+		return fmt.Errorf("failed to mark repository inactive: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("repository %d not found", repoID)
+	}
+	return nil
+}
+
 // GetAllGitRepositories retrieves all repositories (both active and inactive).
 func (s *Store) GetAllGitRepositories() ([]*GitRepository, error) {
 	rows, err := s.db.Query(`
@@ -173,9 +230,59 @@ func (s *Store) GetGitCommitsBySession(sessionID int64) ([]*GitCommit, error) {
 	return scanGitCommits(rows)
 }
 
+// GetGitCommitsBySessions retrieves git commits for multiple sessions in one query.
+// The result maps each session ID to its commits ordered by timestamp; sessions
+// without commits are omitted.
+func (s *Store) GetGitCommitsBySessions(sessionIDs []int64) (map[int64][]*GitCommit, error) {
+	result := make(map[int64][]*GitCommit)
+	if len(sessionIDs) == 0 {
+		return result, nil
+	}
+
+	args := make([]interface{}, len(sessionIDs))
+	for i, id := range sessionIDs {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, timestamp, commit_hash, short_hash, repository_id, branch,
+		       message, message_subject, files_changed, insertions, deletions,
+		       author_name, author_email, is_merge, session_id, created_at,
+		       project_id, project_confidence, project_source
+		FROM git_commits
+		WHERE session_id IN (?%s)
+		ORDER BY session_id, timestamp ASC`, repeatPlaceholder(len(sessionIDs)-1))
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query git commits by sessions: %w", err)
+	}
+	defer rows.Close()
+
+	commits, err := scanGitCommits(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		result[commit.SessionID.Int64] = append(result[commit.SessionID.Int64], commit)
+	}
+
+	return result, nil
+}
+
 // GetGitCommitsByTimeRange retrieves git commits within a time range.
 func (s *Store) GetGitCommitsByTimeRange(start, end int64) ([]*GitCommit, error) {
-	rows, err := s.db.Query(`
+	var commits []*GitCommit
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		commits, err = queryGitCommitsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return commits, err
+}
+
+// queryGitCommitsByTimeRange runs the GetGitCommitsByTimeRange query on db.
+func queryGitCommitsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*GitCommit, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, timestamp, commit_hash, short_hash, repository_id, branch,
 		       message, message_subject, files_changed, insertions, deletions,
 		       author_name, author_email, is_merge, session_id, created_at,
@@ -235,6 +342,130 @@ func (s *Store) CountGitCommitsByTimeRange(start, end int64) (int64, error) {
 	return count, err
 }
 
+// GetGitCommitsByAuthor retrieves commits in a time range whose author name or email
+// matches author. Emails are matched case-insensitively.
+func (s *Store) GetGitCommitsByAuthor(author string, start, end int64) ([]*GitCommit, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, commit_hash, short_hash, repository_id, branch,
+		       message, message_subject, files_changed, insertions, deletions,
+		       author_name, author_email, is_merge, session_id, created_at,
+		       project_id, project_confidence, project_source
+		FROM git_commits
+		WHERE (author_name = ? OR author_email = ? COLLATE NOCASE)
+		  AND timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, author, author, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query git commits by author: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGitCommits(rows)
+}
+
+// AuthorStats holds the commits and line changes by one author.
+type AuthorStats struct {
+	Author      string `json:"author"` // Author name, or email if the name is unknown
+	Email       string `json:"email"`
+	CommitCount int64  `json:"commitCount"`
+	Insertions  int64  `json:"insertions"`
+	Deletions   int64  `json:"deletions"`
+}
+
+// GetTopGitAuthors returns the authors with the most commits in a time range, up to limit.
+// Commits are grouped by author email, falling back to name; commits with neither are skipped.
+func (s *Store) GetTopGitAuthors(start, end int64, limit int) ([]*AuthorStats, error) {
+	rows, err := s.db.Query(`
+		SELECT COALESCE(MAX(author_name), MAX(author_email)), COALESCE(MAX(author_email), ''),
+		       COUNT(*), COALESCE(SUM(insertions), 0), COALESCE(SUM(deletions), 0)
+		FROM git_commits
+		WHERE timestamp >= ? AND timestamp <= ?
+		  AND COALESCE(NULLIF(author_email, ''), NULLIF(author_name, '')) IS NOT NULL
+		GROUP BY LOWER(COALESCE(NULLIF(author_email, ''), author_name))
+		ORDER BY COUNT(*) DESC, SUM(insertions) + SUM(deletions) DESC
+		LIMIT ?`, start, end, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query top git authors: %w", err)
+	}
+	defer rows.Close()
+
+	var authors []*AuthorStats
+	for rows.Next() {
+		a := &AuthorStats{}
+		if err := rows.Scan(&a.Author, &a.Email, &a.CommitCount, &a.Insertions, &a.Deletions); err != nil {
+			return nil, fmt.Errorf("failed to scan git author: %w", err)
+		}
+		authors = append(authors, a)
+	}
+	return authors, rows.Err()
+}
+
+// CountGitCommitsByDay returns the number of commits per local date ("2006-01-02") in a time range.
+func (s *Store) CountGitCommitsByDay(start, end int64) (map[string]int, error) {
+	rows, err := s.db.Query(`
+		SELECT date(timestamp, 'unixepoch', 'localtime') as day, COUNT(*)
+		FROM git_commits
+		WHERE timestamp >= ? AND timestamp <= ?
+		GROUP BY day`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commits by day: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[string]int)
+	for rows.Next() {
+		var day string
+		var count int
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan commits by day: %w", err)
+		}
+		days[day] = count
+	}
+	return days, rows.Err()
+}
+
+// CommitDayStats holds the commits and line changes on a single day.
+type CommitDayStats struct {
+	Date        string // Local date, "2006-01-02"
+	CommitCount int
+	Insertions  int64
+	Deletions   int64
+}
+
+// GetCommitStatsByDay returns commit counts and line changes per local date in a time range,
+// keyed by date. If repoID is 0, commits from all repositories are included. If authorEmails
+// is non-empty, only commits by those emails (case-insensitive) or with no email are counted.
+func (s *Store) GetCommitStatsByDay(repoID, start, end int64, authorEmails []string) (map[string]*CommitDayStats, error) {
+	query := `
+		SELECT date(timestamp, 'unixepoch', 'localtime') as day, COUNT(*),
+		       COALESCE(SUM(insertions), 0), COALESCE(SUM(deletions), 0)
+		FROM git_commits
+		WHERE timestamp >= ? AND timestamp <= ? AND (? = 0 OR repository_id = ?)`
+	args := []interface{}{start, end, repoID, repoID}
+	if len(authorEmails) > 0 {
+		placeholders := make([]string, len(authorEmails))
+		for i, email := range authorEmails {
+			placeholders[i] = "?"
+			args = append(args, strings.ToLower(email))
+		}
+		query += fmt.Sprintf(` AND (author_email IS NULL OR author_email = '' OR LOWER(author_email) IN (%s))`, strings.Join(placeholders, ","))
+	}
+	rows, err := s.db.Query(query+` GROUP BY day`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commit stats by day: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[string]*CommitDayStats)
+	for rows.Next() {
+		day := &CommitDayStats{}
+		if err := rows.Scan(&day.Date, &day.CommitCount, &day.Insertions, &day.Deletions); err != nil {
+			return nil, fmt.Errorf("failed to scan commit stats by day: %w", err)
+		}
+		days[day.Date] = day
+	}
+	return days, rows.Err()
+}
+
 // GetAllGitCommits retrieves all git commits (for search).
 func (s *Store) GetAllGitCommits() ([]*GitCommit, error) {
 	rows, err := s.db.Query(`
@@ -144,6 +144,74 @@ func TestSetGitRepositoryActive(t *testing.T) {
 	}
 }
 
+func TestGetGitRepositoriesNotSeenSince(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	cutoff := now - 30*24*3600
+
+	save := func(name string, active bool) int64 {
+		id, err := store.SaveGitRepository(&GitRepository{Path: "/path/" + name, Name: name, IsActive: active})
+		if err != nil {
+			t.Fatalf("failed to save repository: %v", err)
+		}
+		return id
+	}
+	commit := func(repoID int64, hash string, timestamp int64) {
+		if _, err := store.SaveGitCommit(&GitCommit{
+			Timestamp:      timestamp,
+			CommitHash:     hash,
+			ShortHash:      hash,
+			RepositoryID:   repoID,
+			Message:        "msg",
+			MessageSubject: "msg",
+		}); err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+	}
+
+	recent := save("recent", true)
+	commit(recent, "a1", cutoff-3600)
+	commit(recent, "a2", now-3600)
+	stale := save("stale", true)
+	commit(stale, "b1", cutoff-3600)
+	inactive := save("inactive", true)
+	commit(inactive, "c1", cutoff-3600)
+	store.SetGitRepositoryActive(inactive, false)
+	save("new", true) // No commits, but registered just now
+
+	repos, err := store.GetGitRepositoriesNotSeenSince(cutoff)
+	if err != nil {
+		t.Fatalf("failed to get stale repositories: %v", err)
+	}
+	if len(repos) != 1 || repos[0].ID != stale {
+		t.Fatalf("expected only the stale repository, got %+v", repos)
+	}
+
+	if err := store.MarkRepositoryInactive(stale); err != nil {
+		t.Fatalf("failed to mark inactive: %v", err)
+	}
+	found, _ := store.GetGitRepository(stale)
+	if found.IsActive {
+		t.Error("expected IsActive=false")
+	}
+	if err := store.MarkRepositoryInactive(9999); err == nil {
+		t.Error("expected error for unknown repository")
+	}
+
+	// A reactivated repository isn't stale until cutoff passes its reactivation
+	if err := store.ReactivateGitRepository(stale); err != nil {
+		t.Fatalf("failed to reactivate: %v", err)
+	}
+	if found, _ := store.GetGitRepository(stale); !found.IsActive {
+		t.Error("expected IsActive=true after reactivation")
+	}
+	if repos, _ := store.GetGitRepositoriesNotSeenSince(cutoff); len(repos) != 0 {
+		t.Errorf("expected no stale repositories after reactivation, got %+v", repos)
+	}
+}
+
 func TestGetAllGitRepositories(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -225,6 +293,41 @@ func TestGetGitCommitsBySession(t *testing.T) {
 	}
 }
 
+func TestGetGitCommitsBySessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, _ := store.SaveGitRepository(&GitRepository{Path: "/path/test", Name: "test", IsActive: true})
+
+	now := time.Now().Unix()
+	session1, _ := store.CreateSession(now - 7200)
+	session2, _ := store.CreateSession(now - 3600)
+	emptySession, _ := store.CreateSession(now)
+
+	for i, sessionID := range []int64{session1, session2, session2} {
+		store.SaveGitCommit(&GitCommit{
+			Timestamp:      now + int64(i),
+			CommitHash:     "hash" + string(rune('0'+i)),
+			ShortHash:      "h" + string(rune('0'+i)),
+			RepositoryID:   repoID,
+			Message:        "Test",
+			MessageSubject: "Test",
+			SessionID:      sql.NullInt64{Int64: sessionID, Valid: true},
+		})
+	}
+
+	commits, err := store.GetGitCommitsBySessions([]int64{session1, session2, emptySession})
+	if err != nil {
+		t.Fatalf("GetGitCommitsBySessions failed: %v", err)
+	}
+	if len(commits[session1]) != 1 || len(commits[session2]) != 2 {
+		t.Errorf("expected 1 and 2 commits, got %d and %d", len(commits[session1]), len(commits[session2]))
+	}
+	if _, ok := commits[emptySession]; ok {
+		t.Error("expected no entry for session without commits")
+	}
+}
+
 func TestGetGitCommitsByTimeRange(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -373,3 +476,109 @@ func TestCountGitCommitsByTimeRange(t *testing.T) {
 		t.Errorf("expected 3, got %d", count)
 	}
 }
+
+func TestGetCommitStatsByDay(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoA, _ := store.SaveGitRepository(&GitRepository{Path: "/path/a", Name: "a", IsActive: true})
+	repoB, _ := store.SaveGitRepository(&GitRepository{Path: "/path/b", Name: "b", IsActive: true})
+
+	day := time.Date(2025, 3, 10, 12, 0, 0, 0, time.Local).Unix()
+	commits := []struct {
+		repoID     int64
+		offset     int64
+		insertions int64
+		deletions  int64
+	}{
+		{repoA, 0, 10, 2},
+		{repoA, 60, 5, 1},
+		{repoB, 120, 100, 50},
+		{repoA, 86400, 1, 0},
+	}
+	for i, c := range commits {
+		store.SaveGitCommit(&GitCommit{
+			Timestamp:      day + c.offset,
+			CommitHash:     "hash" + string(rune('0'+i)),
+			ShortHash:      "h" + string(rune('0'+i)),
+			RepositoryID:   c.repoID,
+			Message:        "Test",
+			MessageSubject: "Test",
+			Insertions:     NullInt64(c.insertions),
+			Deletions:      NullInt64(c.deletions),
+		})
+	}
+
+	all, err := store.GetCommitStatsByDay(0, day-3600, day+2*86400, nil)
+	if err != nil {
+		t.Fatalf("failed to get commit stats: %v", err)
+	}
+	if len(all) != 2 {
+		t.Fatalf("expected 2 days, got %d", len(all))
+	}
+	first := all["2025-03-10"]
+	if first == nil || first.CommitCount != 3 || first.Insertions != 115 || first.Deletions != 53 {
+		t.Errorf("unexpected stats for all repos: %+v", first)
+	}
+
+	onlyA, _ := store.GetCommitStatsByDay(repoA, day-3600, day+2*86400, nil)
+	if onlyA["2025-03-10"].CommitCount != 2 || onlyA["2025-03-11"].CommitCount != 1 {
+		t.Errorf("expected only repo A commits, got %+v %+v", onlyA["2025-03-10"], onlyA["2025-03-11"])
+	}
+}
+
+func TestGetGitCommitsByAuthor(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, _ := store.SaveGitRepository(&GitRepository{Path: "/src/shared", Name: "shared", IsActive: true})
+	commits := []struct {
+		hash, name, email string
+		insertions        int64
+	}{
+		{"a1", "Alex", "alex@example.com", 10},
+		{"a2", "Alex Doe", "Alex@Example.com", 5},
+		{"b1", "Sam", "sam@example.com", 100},
+		{"c1", "", "", 1},
+	}
+	for i, c := range commits {
+		store.SaveGitCommit(&GitCommit{
+			CommitHash: c.hash, ShortHash: c.hash, RepositoryID: repoID, Message: "Commit", Timestamp: 1000 + int64(i),
+			AuthorName:  sql.NullString{String: c.name, Valid: c.name != ""},
+			AuthorEmail: sql.NullString{String: c.email, Valid: c.email != ""},
+			Insertions:  sql.NullInt64{Int64: c.insertions, Valid: true},
+			Deletions:   sql.NullInt64{Int64: 1, Valid: true},
+		})
+	}
+
+	byEmail, err := store.GetGitCommitsByAuthor("alex@example.com", 0, 2000)
+	if err != nil {
+		t.Fatalf("GetGitCommitsByAuthor failed: %v", err)
+	}
+	if len(byEmail) != 2 {
+		t.Errorf("expected 2 commits by email, got %d", len(byEmail))
+	}
+	if byName, _ := store.GetGitCommitsByAuthor("Sam", 0, 2000); len(byName) != 1 || byName[0].CommitHash != "b1" {
+		t.Errorf("expected Sam's commit by name, got %v", byName)
+	}
+	if outside, _ := store.GetGitCommitsByAuthor("Sam", 2000, 3000); len(outside) != 0 {
+		t.Errorf("expected no commits outside the range, got %d", len(outside))
+	}
+
+	authors, err := store.GetTopGitAuthors(0, 2000, 10)
+	if err != nil {
+		t.Fatalf("GetTopGitAuthors failed: %v", err)
+	}
+	if len(authors) != 2 {
+		t.Fatalf("expected 2 authors, got %d", len(authors))
+	}
+	if authors[0].CommitCount != 2 || authors[0].Insertions != 15 || authors[0].Deletions != 2 {
+		t.Errorf("unexpected stats for the top author: %+v", authors[0])
+	}
+	if authors[1].Author != "Sam" || authors[1].CommitCount != 1 || authors[1].Insertions != 100 {
+		t.Errorf("unexpected stats for the second author: %+v", authors[1])
+	}
+	if limited, _ := store.GetTopGitAuthors(0, 2000, 1); len(limited) != 1 {
+		t.Errorf("expected limit to apply, got %d authors", len(limited))
+	}
+}
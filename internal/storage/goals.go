@@ -0,0 +1,166 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CreateGoal saves a new active goal.
+func (s *Store) CreateGoal(goal *Goal) (int64, error) {
+	if goal.Period != "daily" && goal.Period != "weekly" {
+		return 0, fmt.Errorf("invalid goal period: %s (must be daily or weekly)", goal.Period)
+	}
+
+	result, err := s.db.Exec(`
+		INSERT INTO goals (goal_type, target_value, period, active)
+		VALUES (?, ?, ?, 1)`,
+		goal.GoalType, goal.TargetValue, goal.Period,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert goal: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetGoal retrieves a goal by ID. Returns nil if not found.
+func (s *Store) GetGoal(id int64) (*Goal, error) {
+	goal := &Goal{}
+	var active int
+	err := s.db.QueryRow(`
+		SELECT id, goal_type, target_value, period, created_at, active
+		FROM goals
+		WHERE id = ?`, id).Scan(
+		&goal.ID, &goal.GoalType, &goal.TargetValue, &goal.Period, &goal.CreatedAt, &active,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goal: %w", err)
+	}
+	goal.Active = active == 1
+	return goal, nil
+}
+
+// GetActiveGoal retrieves the active goal of a type and period. Returns nil if none is set.
+func (s *Store) GetActiveGoal(goalType, period string) (*Goal, error) {
+	goal := &Goal{}
+	var active int
+	err := s.db.QueryRow(`
+		SELECT id, goal_type, target_value, period, created_at, active
+		FROM goals
+		WHERE goal_type = ? AND period = ? AND active = 1
+		ORDER BY id DESC
+		LIMIT 1`, goalType, period).Scan(
+		&goal.ID, &goal.GoalType, &goal.TargetValue, &goal.Period, &goal.CreatedAt, &active,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active goal: %w", err)
+	}
+	goal.Active = active == 1
+	return goal, nil
+}
+
+// DeactivateGoals marks all active goals of a type and period as inactive.
+// Inactive goals are kept so past progress can still be looked up by ID.
+func (s *Store) DeactivateGoals(goalType, period string) error {
+	_, err := s.db.Exec(`
+		UPDATE goals SET active = 0
+		WHERE goal_type = ? AND period = ? AND active = 1`,
+		goalType, period)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate goals: %w", err)
+	}
+	return nil
+}
+
+// ReplaceActiveGoal deactivates the active goals of goal's type and period and saves goal as
+// the new active one, in a single transaction so a failed insert keeps the old goal.
+func (s *Store) ReplaceActiveGoal(goal *Goal) (int64, error) {
+	if goal.Period != "daily" && goal.Period != "weekly" {
+		return 0, fmt.Errorf("invalid goal period: %s (must be daily or weekly)", goal.Period)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE goals SET active = 0
+		WHERE goal_type = ? AND period = ? AND active = 1`,
+		goal.GoalType, goal.Period); err != nil {
+		return 0, fmt.Errorf("failed to deactivate goals: %w", err)
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO goals (goal_type, target_value, period, active)
+		VALUES (?, ?, ?, 1)`,
+		goal.GoalType, goal.TargetValue, goal.Period,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert goal: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return id, nil
+}
+
+// RecordGoalAchievement records that a goal was reached on a date (YYYY-MM-DD).
+// Returns false if the achievement was already recorded for that date.
+func (s *Store) RecordGoalAchievement(date, goalType string, achievedAt int64) (bool, error) {
+	result, err := s.db.Exec(`
+		INSERT OR IGNORE INTO goal_achievements (date, goal_type, achieved_at)
+		VALUES (?, ?, ?)`,
+		date, goalType, achievedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to record goal achievement: %w", err)
+	}
+
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return n > 0, nil
+}
+
+// GetGoalAchievements retrieves achievements with dates between startDate and endDate
+// (YYYY-MM-DD, inclusive), ordered by date.
+func (s *Store) GetGoalAchievements(startDate, endDate string) ([]*GoalAchievement, error) {
+	rows, err := s.db.Query(`
+		SELECT id, date, goal_type, achieved_at
+		FROM goal_achievements
+		WHERE date >= ? AND date <= ?
+		ORDER BY date ASC, goal_type ASC`, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal achievements: %w", err)
+	}
+	defer rows.Close()
+
+	var achievements []*GoalAchievement
+	for rows.Next() {
+		a := &GoalAchievement{}
+		if err := rows.Scan(&a.ID, &a.Date, &a.GoalType, &a.AchievedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan goal achievement: %w", err)
+		}
+		achievements = append(achievements, a)
+	}
+	return achievements, rows.Err()
+}
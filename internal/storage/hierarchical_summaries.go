@@ -83,6 +83,30 @@ func (s *Store) ListHierarchicalSummaries(periodType string, limit int) ([]*Hier
 	return summaries, rows.Err()
 }
 
+// SaveHierarchicalSummary inserts a hierarchical summary, or replaces the existing summary
+// for the same period. Sets hs.ID to the row's ID.
+func (s *Store) SaveHierarchicalSummary(hs *HierarchicalSummary) (int64, error) {
+	userEdited := 0
+	if hs.UserEdited {
+		userEdited = 1
+	}
+
+	err := s.db.QueryRow(`
+		INSERT INTO hierarchical_summaries (period_type, period_date, summary, user_edited, generated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(period_type, period_date) DO UPDATE SET
+			summary = excluded.summary,
+			user_edited = excluded.user_edited,
+			generated_at = excluded.generated_at
+		RETURNING id`,
+		hs.PeriodType, hs.PeriodDate, hs.Summary, userEdited, hs.GeneratedAt,
+	).Scan(&hs.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to save hierarchical summary: %w", err)
+	}
+	return hs.ID, nil
+}
+
 // UpdateHierarchicalSummary updates an existing hierarchical summary.
 func (s *Store) UpdateHierarchicalSummary(hs *HierarchicalSummary) error {
 	userEdited := 0
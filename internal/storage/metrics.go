@@ -1,5 +1,7 @@
 package storage
 
+import "fmt"
+
 // AssignmentMetrics holds accuracy statistics for project assignments
 type AssignmentMetrics struct {
 	PeriodStart     int64   `json:"periodStart"`
@@ -65,3 +67,46 @@ func (s *Store) GetAssignmentMetrics(startTime, endTime int64) (*AssignmentMetri
 
 	return metrics, nil
 }
+
+// Data sources reported by GetDataSourceActivity.
+const (
+	DataSourceScreenshots = "screenshots"
+	DataSourceGit         = "git"
+	DataSourceFiles       = "files"
+	DataSourceBrowser     = "browser"
+	DataSourceShell       = "shell"
+)
+
+// dataSourceTables maps each data source to the table it records events in.
+var dataSourceTables = map[string]string{
+	DataSourceScreenshots: "screenshots",
+	DataSourceGit:         "git_commits",
+	DataSourceFiles:       "file_events",
+	DataSourceBrowser:     "browser_history",
+	DataSourceShell:       "shell_commands",
+}
+
+// DataSourceActivity summarizes the events a data source has recorded.
+type DataSourceActivity struct {
+	LastEventAt int64 `json:"lastEventAt"` // 0 if it has never recorded an event
+	EventCount  int64 `json:"eventCount"`  // Events since the requested time
+}
+
+// GetDataSourceActivity returns when each data source last recorded an event and how
+// many events it has recorded since a time.
+func (s *Store) GetDataSourceActivity(since int64) (map[string]*DataSourceActivity, error) {
+	activity := make(map[string]*DataSourceActivity, len(dataSourceTables))
+	for source, table := range dataSourceTables {
+		// Separate queries so both use the timestamp index
+		a := &DataSourceActivity{}
+		err := s.db.QueryRow(fmt.Sprintf(`SELECT COALESCE(MAX(timestamp), 0) FROM %s`, table)).Scan(&a.LastEventAt)
+		if err == nil {
+			err = s.db.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE timestamp >= ?`, table), since).Scan(&a.EventCount)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s activity: %w", source, err)
+		}
+		activity[source] = a
+	}
+	return activity, nil
+}
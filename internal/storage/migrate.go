@@ -0,0 +1,209 @@
+package storage
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// migration is a versioned schema change. Down undoes Up, and is nil if the change
+// can't be rolled back.
+type migration struct {
+	Version     int
+	Description string
+	Up          func(tx *sql.Tx) error
+	Down        func(tx *sql.Tx) error
+}
+
+// checksum identifies a migration, so a rollback can tell the migration it's about to
+// undo is the one that was applied.
+func (m migration) checksum() string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", m.Version, m.Description)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MigrationStatus describes how far the database schema is migrated.
+type MigrationStatus struct {
+	Current int `json:"current"`
+	Latest  int `json:"latest"`
+}
+
+// GetCurrentMigrationVersion returns the version of the latest applied migration.
+func (s *Store) GetCurrentMigrationVersion() (int, error) {
+	if err := s.ensureMigrationsTable(migrations); err != nil {
+		return 0, err
+	}
+	return s.currentMigrationVersion()
+}
+
+// GetMigrationStatus returns the current and latest schema versions.
+func (s *Store) GetMigrationStatus() (*MigrationStatus, error) {
+	current, err := s.GetCurrentMigrationVersion()
+	if err != nil {
+		return nil, err
+	}
+	return &MigrationStatus{Current: current, Latest: schemaVersion}, nil
+}
+
+// MigrateUp applies pending migrations up to and including targetVersion, or all of them
+// if targetVersion is 0. Each migration runs in its own transaction.
+func (s *Store) MigrateUp(targetVersion int) error {
+	return s.migrateUp(migrations, targetVersion)
+}
+
+// MigrateDown rolls back applied migrations, newest first, until targetVersion is the
+// latest one applied. Nothing is rolled back if a migration on the way can't be.
+func (s *Store) MigrateDown(targetVersion int) error {
+	return s.migrateDown(migrations, targetVersion)
+}
+
+func (s *Store) migrateUp(list []migration, targetVersion int) error {
+	latest := list[len(list)-1].Version
+	if targetVersion == 0 {
+		targetVersion = latest
+	}
+	if targetVersion < 1 || targetVersion > latest {
+		return fmt.Errorf("invalid migration version %d (latest is %d)", targetVersion, latest)
+	}
+
+	if err := s.ensureMigrationsTable(list); err != nil {
+		return err
+	}
+	currentVersion, err := s.currentMigrationVersion()
+	if err != nil {
+		return err
+	}
+
+	for _, m := range list {
+		if m.Version <= currentVersion || m.Version > targetVersion {
+			continue
+		}
+		err := s.Transaction(func(tx *sql.Tx) error {
+			if err := m.Up(tx); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`INSERT INTO schema_migrations (version, applied_at, checksum) VALUES (?, ?, ?)`,
+				m.Version, time.Now().Unix(), m.checksum())
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %d: %w", m.Version, err)
+		}
+	}
+	return nil
+}
+
+func (s *Store) migrateDown(list []migration, targetVersion int) error {
+	if targetVersion < 1 {
+		return fmt.Errorf("the base schema (version 1) can't be rolled back")
+	}
+	if err := s.ensureMigrationsTable(list); err != nil {
+		return err
+	}
+
+	rows, err := s.db.Query(`SELECT version, checksum FROM schema_migrations WHERE version > ? ORDER BY version DESC`, targetVersion)
+	if err != nil {
+		return fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+	var rollback []migration
+	for rows.Next() {
+		var version int
+		var checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to scan applied migration: %w", err)
+		}
+		m, ok := findMigration(list, version)
+		if !ok {
+			rows.Close()
+			return fmt.Errorf("migration %d is newer than this version of Traq", version)
+		}
+		if m.checksum() != checksum {
+			rows.Close()
+			return fmt.Errorf("migration %d was applied from a different definition", version)
+		}
+		if m.Down == nil {
+			rows.Close()
+			return fmt.Errorf("migration %d (%s) can't be rolled back", version, m.Description)
+		}
+		rollback = append(rollback, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to query applied migrations: %w", err)
+	}
+
+	for _, m := range rollback {
+		err := s.Transaction(func(tx *sql.Tx) error {
+			if err := m.Down(tx); err != nil {
+				return err
+			}
+			_, err := tx.Exec(`DELETE FROM schema_migrations WHERE version = ?`, m.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration %d: %w", m.Version, err)
+		}
+	}
+	return nil
+}
+
+// findMigration returns the migration with a version.
+func findMigration(list []migration, version int) (migration, bool) {
+	for _, m := range list {
+		if m.Version == version {
+			return m, true
+		}
+	}
+	return migration{}, false
+}
+
+// currentMigrationVersion returns the latest version in schema_migrations, or 0 if none are applied.
+func (s *Store) currentMigrationVersion() (int, error) {
+	var version int
+	err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, nil
+}
+
+// ensureMigrationsTable creates the schema_migrations table. Databases from before it
+// existed only recorded their version in schema_version, so every migration up to that
+// version is recorded as applied and the old table is dropped.
+func (s *Store) ensureMigrationsTable(list []migration) error {
+	_, err := s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at INTEGER NOT NULL,
+			checksum TEXT NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+
+	var legacyVersion int
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&legacyVersion); err != nil {
+		return nil // No schema_version table
+	}
+
+	return s.Transaction(func(tx *sql.Tx) error {
+		for _, m := range list {
+			if m.Version > legacyVersion {
+				break
+			}
+			_, err := tx.Exec(`INSERT OR IGNORE INTO schema_migrations (version, applied_at, checksum) VALUES (?, ?, ?)`,
+				m.Version, time.Now().Unix(), m.checksum())
+			if err != nil {
+				return fmt.Errorf("failed to record migration %d: %w", m.Version, err)
+			}
+		}
+		if _, err := tx.Exec(`DROP TABLE schema_version`); err != nil {
+			return fmt.Errorf("failed to drop schema_version table: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,240 @@
+package storage
+
+import (
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// schemaSQL returns the definitions of the tables and indexes in the database, except schema_migrations.
+func schemaSQL(t *testing.T, store *Store) string {
+	t.Helper()
+	rows, err := store.db.Query(`
+		SELECT sql FROM sqlite_master
+		WHERE sql IS NOT NULL AND name != 'schema_migrations'
+		ORDER BY type, name`)
+	if err != nil {
+		t.Fatalf("failed to query schema: %v", err)
+	}
+	defer rows.Close()
+
+	var defs []string
+	for rows.Next() {
+		var def string
+		rows.Scan(&def)
+		defs = append(defs, def)
+	}
+	return strings.Join(defs, ";\n")
+}
+
+func TestMigrateUpDown(t *testing.T) {
+	db, err := sql.Open(driverName, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	store := &Store{db: db}
+
+	list := []migration{
+		{1, "Create widgets", execStatements(`CREATE TABLE widgets (id INTEGER PRIMARY KEY, name TEXT)`), nil},
+		{2, "Add widget color", execStatements(`ALTER TABLE widgets ADD COLUMN color TEXT`), execStatements(`ALTER TABLE widgets DROP COLUMN color`)},
+		{3, "Create gadgets", execStatements(`CREATE TABLE gadgets (id INTEGER PRIMARY KEY)`), execStatements(`DROP TABLE gadgets`)},
+	}
+
+	if err := store.migrateUp(list, 1); err != nil {
+		t.Fatalf("migrateUp to 1 failed: %v", err)
+	}
+	version1 := schemaSQL(t, store)
+
+	if err := store.migrateUp(list, 0); err != nil {
+		t.Fatalf("migrateUp failed: %v", err)
+	}
+	if version, _ := store.currentMigrationVersion(); version != 3 {
+		t.Fatalf("expected version 3, got %d", version)
+	}
+	if schemaSQL(t, store) == version1 {
+		t.Fatal("expected migrations 2 and 3 to change the schema")
+	}
+
+	if err := store.migrateDown(list, 1); err != nil {
+		t.Fatalf("migrateDown failed: %v", err)
+	}
+	if version, _ := store.currentMigrationVersion(); version != 1 {
+		t.Errorf("expected version 1 after rollback, got %d", version)
+	}
+	if got := schemaSQL(t, store); got != version1 {
+		t.Errorf("expected schema of version 1 after rollback, got:\n%s\nwant:\n%s", got, version1)
+	}
+
+	// The base schema can't be rolled back
+	if err := store.migrateDown(list, 0); err == nil {
+		t.Error("expected error rolling back version 1")
+	}
+	if err := store.migrateUp(list, 4); err == nil {
+		t.Error("expected error migrating to an unknown version")
+	}
+}
+
+func TestMigrateDown_Irreversible(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	status, err := store.GetMigrationStatus()
+	if err != nil {
+		t.Fatalf("GetMigrationStatus failed: %v", err)
+	}
+	if status.Current != schemaVersion || status.Latest != schemaVersion {
+		t.Fatalf("expected a new database at version %d, got %+v", schemaVersion, status)
+	}
+
+	if err := store.MigrateDown(25); err != nil {
+		t.Fatalf("MigrateDown failed: %v", err)
+	}
+	var count int
+	store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('audio_events', 'ticket_references')`).Scan(&count)
+	if count != 0 {
+		t.Errorf("expected tables from migrations 34 and 35 to be dropped, %d remain", count)
+	}
+
+	// Migration 25 adds a foreign key column, so nothing before it can be rolled back
+	if err := store.MigrateDown(20); err == nil {
+		t.Error("expected error rolling back past migration 25")
+	}
+	if version, _ := store.GetCurrentMigrationVersion(); version != 25 {
+		t.Errorf("expected a failed rollback to leave version 25, got %d", version)
+	}
+
+	if err := store.MigrateUp(0); err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+	store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name IN ('audio_events', 'ticket_references')`).Scan(&count)
+	if count != 2 {
+		t.Errorf("expected tables from migrations 34 and 35 to be recreated, got %d", count)
+	}
+}
+
+func TestMigrations_LegacySchemaVersion(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Databases from before schema_migrations only recorded their version
+	store.db.Exec(`DELETE FROM schema_migrations`)
+	store.db.Exec(`CREATE TABLE schema_version (version INTEGER PRIMARY KEY, applied_at INTEGER)`)
+	store.db.Exec(`INSERT INTO schema_version (version) VALUES (30)`)
+
+	version, err := store.GetCurrentMigrationVersion()
+	if err != nil {
+		t.Fatalf("GetCurrentMigrationVersion failed: %v", err)
+	}
+	if version != 30 {
+		t.Errorf("expected version 30 from schema_version, got %d", version)
+	}
+
+	var count int
+	store.db.QueryRow(`SELECT COUNT(*) FROM schema_migrations`).Scan(&count)
+	if count != 30 {
+		t.Errorf("expected migrations 1-30 recorded, got %d", count)
+	}
+	store.db.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE name = 'schema_version'`).Scan(&count)
+	if count != 0 {
+		t.Error("expected schema_version to be dropped")
+	}
+}
+
+func TestMigrations_Versions(t *testing.T) {
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			t.Errorf("migration %d has version %d", i+1, m.Version)
+		}
+	}
+	if latest := migrations[len(migrations)-1].Version; latest != schemaVersion {
+		t.Errorf("latest migration is %d, schemaVersion is %d", latest, schemaVersion)
+	}
+}
+
+func TestMigrations_RollbackRestoresSchema(t *testing.T) {
+	// Migrations without a Down stop a full rollback to version 1, so each reversible
+	// migration is rolled back on its own, from a fresh database at the version before it
+	for _, m := range migrations[1:] {
+		if m.Down == nil {
+			continue
+		}
+		db, err := sql.Open(driverName, filepath.Join(t.TempDir(), "test.db"))
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		store := &Store{db: db}
+
+		if err := store.migrateUp(migrations, m.Version-1); err != nil {
+			t.Fatalf("migrateUp to %d failed: %v", m.Version-1, err)
+		}
+		before := schemaSQL(t, store)
+		if err := store.migrateUp(migrations, m.Version); err != nil {
+			t.Fatalf("migrateUp to %d failed: %v", m.Version, err)
+		}
+		if err := store.migrateDown(migrations, m.Version-1); err != nil {
+			t.Fatalf("migrateDown from %d failed: %v", m.Version, err)
+		}
+		if got := schemaSQL(t, store); got != before {
+			t.Errorf("rolling back migration %d (%s) didn't restore the schema of version %d", m.Version, m.Description, m.Version-1)
+		}
+		db.Close()
+	}
+}
+
+func TestMigrations_FullRollbackMatchesFreshSchema(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Roll back as far as the migrations allow
+	oldest := 1
+	for _, m := range migrations {
+		if m.Down == nil {
+			oldest = m.Version
+		}
+	}
+	if err := store.MigrateDown(oldest); err != nil {
+		t.Fatalf("MigrateDown to %d failed: %v", oldest, err)
+	}
+
+	db, err := sql.Open(driverName, filepath.Join(t.TempDir(), "fresh.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	defer db.Close()
+	fresh := &Store{db: db}
+	if err := fresh.migrateUp(migrations, oldest); err != nil {
+		t.Fatalf("migrateUp to %d failed: %v", oldest, err)
+	}
+	if got, want := schemaSQL(t, store), schemaSQL(t, fresh); got != want {
+		t.Errorf("expected rollback to match a fresh database at version %d, got:\n%s\nwant:\n%s", oldest, got, want)
+	}
+}
+
+func TestMigration47_ClearsDefaultShortcuts(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Rolling back restores the shortcuts migration 14 seeded; change one of them
+	if err := store.MigrateDown(46); err != nil {
+		t.Fatalf("MigrateDown failed: %v", err)
+	}
+	if err := store.SetConfig("shortcuts.show_window", "Shift+Alt+W"); err != nil {
+		t.Fatalf("SetConfig failed: %v", err)
+	}
+	if err := store.MigrateUp(0); err != nil {
+		t.Fatalf("MigrateUp failed: %v", err)
+	}
+
+	all, err := store.GetAllConfig()
+	if err != nil {
+		t.Fatalf("GetAllConfig failed: %v", err)
+	}
+	if _, ok := all["shortcuts.force_capture"]; ok {
+		t.Error("expected the default force_capture shortcut to be cleared")
+	}
+	if all["shortcuts.show_window"] != "Shift+Alt+W" {
+		t.Errorf("expected the changed show_window shortcut to be kept, got %q", all["shortcuts.show_window"])
+	}
+}
@@ -1,10 +1,11 @@
 package storage
 
 import (
+	"database/sql"
 	"fmt"
 )
 
-const schemaVersion = 12
+const schemaVersion = 47
 
 const schema = `
 -- ============================================================================
@@ -178,6 +179,7 @@ CREATE TABLE IF NOT EXISTS browser_history (
     title TEXT,
     domain TEXT NOT NULL,
     browser TEXT NOT NULL,
+    browser_profile TEXT NOT NULL DEFAULT 'default',
     visit_duration_seconds INTEGER,
     transition_type TEXT,
     session_id INTEGER REFERENCES sessions(id),
@@ -222,112 +224,98 @@ CREATE TABLE IF NOT EXISTS app_categories (
 
 CREATE INDEX IF NOT EXISTS idx_app_categories_name ON app_categories(app_name);
 CREATE INDEX IF NOT EXISTS idx_app_categories_category ON app_categories(category);
-
--- ============================================================================
--- Schema Version
--- ============================================================================
-
-CREATE TABLE IF NOT EXISTS schema_version (
-    version INTEGER PRIMARY KEY,
-    applied_at INTEGER DEFAULT (strftime('%s', 'now'))
-);
 `
 
+// migrations lists every schema migration in version order. Version 1 creates the base
+// schema; later migrations check for what they add, so they also apply cleanly to databases
+// created from an older base schema. Down is nil where the change can't be undone, such as
+// foreign key columns, which SQLite can't drop, and where the base schema already creates
+// what the migration adds, so dropping it would break version 1.
+var migrations = []migration{
+	{1, "Create the base schema", applyMigration1, nil},
+	{2, "Add window_class column to screenshots table", applyMigration2, nil},
+	{3, "Add process_pid column to screenshots table", applyMigration3, nil},
+	{4, "Add afk_events table", applyMigration4, nil},
+	{5, "Add app_categorization_rules table for timeline v3 grid view", applyMigration5, execStatements(`DROP TABLE IF EXISTS app_categorization_rules`)},
+	{6, "Add hierarchical_summaries and projects tables", applyMigration6, nil},
+	{7, "Add issue_reports table for crash/manual issue reporting", applyMigration7, execStatements(`DROP TABLE IF EXISTS issue_reports`)},
+	{8, "Add projects column to summaries table for AI-detected project breakdowns", applyMigration8, execStatements(`ALTER TABLE summaries DROP COLUMN projects`)},
+	{9, "Add project assignment support with learning", applyMigration9, nil},
+	{10, "Add memory_status for activity hiding and report config", applyMigration10, execStatements(
+		`DROP INDEX IF EXISTS idx_focus_memory_status`,
+		`ALTER TABLE window_focus_events DROP COLUMN memory_status`,
+		`DROP INDEX IF EXISTS idx_screenshot_memory_status`,
+		`ALTER TABLE screenshots DROP COLUMN memory_status`,
+		`DELETE FROM config WHERE key = 'reports.include_unassigned'`,
+	)},
+	{11, "Add activity_embeddings table for semantic similarity search", applyMigration11, execStatements(`DROP TABLE IF EXISTS activity_embeddings`)},
+	{12, "Add draft fields for AI summary approval workflow", applyMigration12, execStatements(
+		`DROP INDEX IF EXISTS idx_summaries_draft`,
+		`ALTER TABLE summaries DROP COLUMN is_draft`,
+		`ALTER TABLE summaries DROP COLUMN draft_status`,
+		`DROP INDEX IF EXISTS idx_focus_draft`,
+		`ALTER TABLE window_focus_events DROP COLUMN is_draft`,
+		`ALTER TABLE window_focus_events DROP COLUMN draft_status`,
+	)},
+	{13, "Add clipboard_events table for clipboard usage statistics", applyMigration13, execStatements(`DROP TABLE IF EXISTS clipboard_events`)},
+	{14, "Add default global keyboard shortcuts", applyMigration14, execStatements(`DELETE FROM config WHERE key IN ('shortcuts.force_capture', 'shortcuts.pause_resume', 'shortcuts.show_window', 'shortcuts.open_timeline')`)},
+	{15, "Add browser_profile column to browser_history table", applyMigration15, nil},
+	{16, "Add covering index for loading focus events by session", applyMigration16, execStatements(`DROP INDEX IF EXISTS idx_focus_events_session`)},
+	{17, "Add goals table for daily/weekly activity goals", applyMigration17, execStatements(`DROP TABLE IF EXISTS goals`)},
+	{18, "Add project_suggestion_cache table for AI project classification", applyMigration18, execStatements(`DROP TABLE IF EXISTS project_suggestion_cache`)},
+	{19, "Add pinned_events table for timeline highlights", applyMigration19, execStatements(`DROP TABLE IF EXISTS pinned_events`)},
+	{20, "Add browser_domain_blocklist table for report annotations", applyMigration20, execStatements(`DROP TABLE IF EXISTS browser_domain_blocklist`)},
+	{21, "Add parent_id and version to reports for report versioning", applyMigration21, nil},
+	{22, "Add monitor_index to screenshots for multi-monitor capture", applyMigration22, execStatements(`ALTER TABLE screenshots DROP COLUMN monitor_index`)},
+	{23, "Add goal_achievements table for once-per-day goal notifications", applyMigration23, execStatements(`DROP TABLE IF EXISTS goal_achievements`)},
+	{24, "Add screenshot_annotations table for text labels on screenshots", applyMigration24, execStatements(`DROP TABLE IF EXISTS screenshot_annotations`)},
+	{25, "Add git repository and branch to focus events for branch time tracking", applyMigration25, nil},
+	{26, "Add indexes for active repository lookups and per-repository commit ranges", applyMigration26, execStatements(
+		`DROP INDEX IF EXISTS idx_git_repos_active`,
+		`DROP INDEX IF EXISTS idx_git_repo_time`,
+	)},
+	{27, "Add vscode_language_events table for editor language tracking", applyMigration27, execStatements(`DROP TABLE IF EXISTS vscode_language_events`)},
+	{28, "Add profiles table for switching between sets of settings", applyMigration28, execStatements(`DROP TABLE IF EXISTS profiles`)},
+	{29, "Add network_events table for outbound web connections", applyMigration29, execStatements(`DROP TABLE IF EXISTS network_events`)},
+	{30, "Add project_detection_rules table for regex project extraction", applyMigration30, execStatements(`DROP TABLE IF EXISTS project_detection_rules`)},
+	{31, "Add file_events (file_extension, timestamp) index for per-extension queries", applyMigration31, execStatements(`DROP INDEX IF EXISTS idx_file_events_ext_time`)},
+	{32, "Add report_templates table with the built-in report layouts", applyMigration32, execStatements(`DROP TABLE IF EXISTS report_templates`)},
+	{33, "Add resource_snapshots table for monitoring Traq's own footprint", applyMigration33, execStatements(`DROP TABLE IF EXISTS resource_snapshots`)},
+	{34, "Add audio_events table for audio playback", applyMigration34, execStatements(`DROP TABLE IF EXISTS audio_events`)},
+	{35, "Add ticket_references table for tickets mentioned in commits", applyMigration35, execStatements(`DROP TABLE IF EXISTS ticket_references`)},
+	{36, "Add report_goals table for goal progress in reports", applyMigration36, execStatements(`DROP TABLE IF EXISTS report_goals`)},
+	{37, "Add screenshot_blur_regions table recording redacted screenshot areas", applyMigration37, execStatements(`DROP TABLE IF EXISTS screenshot_blur_regions`)},
+	{38, "Add timezone_changes table for system timezone changes", applyMigration38, execStatements(`DROP TABLE IF EXISTS timezone_changes`)},
+	{39, "Add quality to screenshots for adaptive capture quality", applyMigration39, execStatements(`ALTER TABLE screenshots DROP COLUMN quality`)},
+	{40, "Add window_focus_events (window_title, start_time) index for window drill-down", applyMigration40, execStatements(`DROP INDEX IF EXISTS idx_focus_window_title_time`)},
+	{41, "Add partial session indexes on searched focus, commit and shell columns", applyMigration41, execStatements(
+		`DROP INDEX IF EXISTS idx_focus_session_title`,
+		`DROP INDEX IF EXISTS idx_git_session_message`,
+		`DROP INDEX IF EXISTS idx_shell_session_command`,
+	)},
+	{42, "Add task_threads and task_thread_sessions tables for multi-day tasks", applyMigration42, execStatements(
+		`DROP TABLE IF EXISTS task_thread_sessions`,
+		`DROP TABLE IF EXISTS task_threads`,
+	)},
+	{43, "Add scheduled_reports table for periodic report generation", applyMigration43, execStatements(`DROP TABLE IF EXISTS scheduled_reports`)},
+	{44, "Add activated_at to git_repositories for reactivated repositories", applyMigration44, execStatements(`ALTER TABLE git_repositories DROP COLUMN activated_at`)},
+	{45, "Add duration_seconds to audio_events for the time each poll covers", applyMigration45, execStatements(`ALTER TABLE audio_events DROP COLUMN duration_seconds`)},
+	{46, "Ship the standard layouts in the built-in report templates", applyMigration46, rollbackMigration46},
+	{47, "Clear the default global keyboard shortcuts", applyMigration47, applyMigration14},
+}
+
 // Migrate applies any pending database migrations.
 func (s *Store) Migrate() error {
-	// Check current schema version
-	var currentVersion int
-	err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&currentVersion)
+	currentVersion, err := s.GetCurrentMigrationVersion()
 	if err != nil {
-		// Table doesn't exist yet, that's fine
-		currentVersion = 0
+		return err
 	}
-
 	if currentVersion >= schemaVersion {
 		return nil // Already up to date
 	}
 
-	// Apply schema
-	if _, err := s.db.Exec(schema); err != nil {
-		return fmt.Errorf("failed to apply schema: %w", err)
-	}
-
-	// Apply incremental migrations
-	if currentVersion < 2 {
-		// Migration v2: Add window_class column to screenshots table
-		if err := s.applyMigration2(); err != nil {
-			return fmt.Errorf("failed to apply migration 2: %w", err)
-		}
-	}
-	if currentVersion < 3 {
-		// Migration v3: Add process_pid column to screenshots table
-		if err := s.applyMigration3(); err != nil {
-			return fmt.Errorf("failed to apply migration 3: %w", err)
-		}
-	}
-	if currentVersion < 4 {
-		// Migration v4: Add afk_events table
-		if err := s.applyMigration4(); err != nil {
-			return fmt.Errorf("failed to apply migration 4: %w", err)
-		}
-	}
-	if currentVersion < 5 {
-		// Migration v5: Add app_categorization_rules table for timeline v3 grid view
-		if err := s.applyMigration5(); err != nil {
-			return fmt.Errorf("failed to apply migration 5: %w", err)
-		}
-	}
-	if currentVersion < 6 {
-		// Migration v6: Add hierarchical_summaries and projects tables
-		if err := s.applyMigration6(); err != nil {
-			return fmt.Errorf("failed to apply migration 6: %w", err)
-		}
-	}
-	if currentVersion < 7 {
-		// Migration v7: Add issue_reports table for crash/manual issue reporting
-		if err := s.applyMigration7(); err != nil {
-			return fmt.Errorf("failed to apply migration 7: %w", err)
-		}
-	}
-	if currentVersion < 8 {
-		// Migration v8: Add projects column to summaries table for AI-detected project breakdowns
-		if err := s.applyMigration8(); err != nil {
-			return fmt.Errorf("failed to apply migration 8: %w", err)
-		}
-	}
-	if currentVersion < 9 {
-		// Migration v9: Add project assignment support with learning
-		if err := s.applyMigration9(); err != nil {
-			return fmt.Errorf("failed to apply migration 9: %w", err)
-		}
-	}
-	if currentVersion < 10 {
-		// Migration v10: Add memory_status for activity hiding and report config
-		if err := s.applyMigration10(); err != nil {
-			return fmt.Errorf("failed to apply migration 10: %w", err)
-		}
-	}
-	if currentVersion < 11 {
-		// Migration v11: Add activity_embeddings table for semantic similarity search
-		if err := s.applyMigration11(); err != nil {
-			return fmt.Errorf("failed to apply migration 11: %w", err)
-		}
-	}
-	if currentVersion < 12 {
-		// Migration v12: Add draft fields for AI summary approval workflow
-		if err := s.applyMigration12(); err != nil {
-			return fmt.Errorf("failed to apply migration 12: %w", err)
-		}
-	}
-
-	// Record schema version
-	if currentVersion == 0 {
-		_, err = s.db.Exec("INSERT OR REPLACE INTO schema_version (version) VALUES (?)", schemaVersion)
-	} else {
-		_, err = s.db.Exec("UPDATE schema_version SET version = ?, applied_at = strftime('%s', 'now')", schemaVersion)
-	}
-	if err != nil {
-		return fmt.Errorf("failed to update schema version: %w", err)
+	if err := s.MigrateUp(schemaVersion); err != nil {
+		return err
 	}
 
 	// Run repair checks for tables that might be missing due to partial migrations
@@ -336,6 +324,18 @@ func (s *Store) Migrate() error {
 	return nil
 }
 
+// execStatements returns a migration step that executes SQL statements in order.
+func execStatements(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return fmt.Errorf("failed to execute %q: %w", stmt, err)
+			}
+		}
+		return nil
+	}
+}
+
 // repairMissingTables creates any tables or columns that might be missing due to partial migration failures.
 // This is a safety net for databases that have the schema version set but are missing tables/columns.
 func (s *Store) repairMissingTables() {
@@ -526,11 +526,19 @@ func (s *Store) repairSummariesTable() {
 	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_summaries_draft ON summaries(is_draft)`)
 }
 
+// applyMigration1 creates the base schema.
+func applyMigration1(tx *sql.Tx) error {
+	if _, err := tx.Exec(schema); err != nil {
+		return fmt.Errorf("failed to apply schema: %w", err)
+	}
+	return nil
+}
+
 // applyMigration2 adds window_class column to screenshots table.
-func (s *Store) applyMigration2() error {
+func applyMigration2(tx *sql.Tx) error {
 	// Check if column already exists
 	var count int
-	err := s.db.QueryRow(`
+	err := tx.QueryRow(`
 		SELECT COUNT(*) FROM pragma_table_info('screenshots') WHERE name = 'window_class'
 	`).Scan(&count)
 	if err != nil {
@@ -541,7 +549,7 @@ func (s *Store) applyMigration2() error {
 	}
 
 	// Add the window_class column
-	_, err = s.db.Exec(`ALTER TABLE screenshots ADD COLUMN window_class TEXT`)
+	_, err = tx.Exec(`ALTER TABLE screenshots ADD COLUMN window_class TEXT`)
 	if err != nil {
 		return fmt.Errorf("failed to add window_class column: %w", err)
 	}
@@ -549,10 +557,10 @@ func (s *Store) applyMigration2() error {
 }
 
 // applyMigration3 adds process_pid column to screenshots table.
-func (s *Store) applyMigration3() error {
+func applyMigration3(tx *sql.Tx) error {
 	// Check if column already exists
 	var count int
-	err := s.db.QueryRow(`
+	err := tx.QueryRow(`
 		SELECT COUNT(*) FROM pragma_table_info('screenshots') WHERE name = 'process_pid'
 	`).Scan(&count)
 	if err != nil {
@@ -563,25 +571,18 @@ func (s *Store) applyMigration3() error {
 	}
 
 	// Add the process_pid column
-	_, err = s.db.Exec(`ALTER TABLE screenshots ADD COLUMN process_pid INTEGER`)
+	_, err = tx.Exec(`ALTER TABLE screenshots ADD COLUMN process_pid INTEGER`)
 	if err != nil {
 		return fmt.Errorf("failed to add process_pid column: %w", err)
 	}
 	return nil
 }
 
-// GetSchemaVersion returns the current schema version.
-func (s *Store) GetSchemaVersion() (int, error) {
-	var version int
-	err := s.db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
-	return version, err
-}
-
 // applyMigration4 creates the afk_events table.
-func (s *Store) applyMigration4() error {
+func applyMigration4(tx *sql.Tx) error {
 	// Check if table already exists
 	var count int
-	err := s.db.QueryRow(`
+	err := tx.QueryRow(`
 		SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='afk_events'
 	`).Scan(&count)
 	if err != nil {
@@ -592,7 +593,7 @@ func (s *Store) applyMigration4() error {
 	}
 
 	// Create the afk_events table
-	_, err = s.db.Exec(`
+	_, err = tx.Exec(`
 		CREATE TABLE IF NOT EXISTS afk_events (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			start_time INTEGER NOT NULL,
@@ -612,10 +613,10 @@ func (s *Store) applyMigration4() error {
 }
 
 // applyMigration5 creates the app_categorization_rules table for timeline v3 grid view.
-func (s *Store) applyMigration5() error {
+func applyMigration5(tx *sql.Tx) error {
 	// Check if table already exists
 	var count int
-	err := s.db.QueryRow(`
+	err := tx.QueryRow(`
 		SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='app_categorization_rules'
 	`).Scan(&count)
 	if err != nil {
@@ -626,7 +627,7 @@ func (s *Store) applyMigration5() error {
 	}
 
 	// Create the app_categorization_rules table
-	_, err = s.db.Exec(`
+	_, err = tx.Exec(`
 		CREATE TABLE IF NOT EXISTS app_categorization_rules (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			app_name TEXT NOT NULL,
@@ -699,7 +700,7 @@ func (s *Store) applyMigration5() error {
 	}
 
 	for _, rule := range defaultRules {
-		_, err = s.db.Exec(`
+		_, err = tx.Exec(`
 			INSERT OR IGNORE INTO app_categorization_rules (app_name, category, is_system_default, created_at)
 			VALUES (?, ?, 1, strftime('%s', 'now'))
 		`, rule.appName, rule.category)
@@ -712,9 +713,9 @@ func (s *Store) applyMigration5() error {
 }
 
 // applyMigration6 creates the hierarchical_summaries and projects tables.
-func (s *Store) applyMigration6() error {
+func applyMigration6(tx *sql.Tx) error {
 	// Create hierarchical_summaries table
-	_, err := s.db.Exec(`
+	_, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS hierarchical_summaries (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			period_type TEXT NOT NULL CHECK(period_type IN ('day', 'week', 'month')),
@@ -732,7 +733,7 @@ func (s *Store) applyMigration6() error {
 	}
 
 	// Create projects table
-	_, err = s.db.Exec(`
+	_, err = tx.Exec(`
 		CREATE TABLE IF NOT EXISTS projects (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			name TEXT NOT NULL UNIQUE,
@@ -750,9 +751,9 @@ func (s *Store) applyMigration6() error {
 }
 
 // applyMigration7 creates the issue_reports table for crash/manual issue reporting.
-func (s *Store) applyMigration7() error {
+func applyMigration7(tx *sql.Tx) error {
 	// Create issue_reports table
-	_, err := s.db.Exec(`
+	_, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS issue_reports (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			report_type TEXT NOT NULL CHECK(report_type IN ('crash', 'manual')),
@@ -777,9 +778,9 @@ func (s *Store) applyMigration7() error {
 }
 
 // applyMigration8 adds the projects column to summaries table for AI-detected project breakdowns.
-func (s *Store) applyMigration8() error {
+func applyMigration8(tx *sql.Tx) error {
 	// Add projects column to summaries table (JSON array of project breakdowns)
-	_, err := s.db.Exec(`ALTER TABLE summaries ADD COLUMN projects TEXT`)
+	_, err := tx.Exec(`ALTER TABLE summaries ADD COLUMN projects TEXT`)
 	if err != nil {
 		// Column might already exist if schema was recreated
 		return nil
@@ -793,29 +794,29 @@ func (s *Store) applyMigration8() error {
 // - Creates project_patterns table for learned matching rules
 // - Creates assignment_examples table for few-shot AI learning
 // - Adds color and description columns to projects table
-func (s *Store) applyMigration9() error {
+func applyMigration9(tx *sql.Tx) error {
 	// 1. Add color and description columns to projects table
-	s.db.Exec(`ALTER TABLE projects ADD COLUMN color TEXT DEFAULT '#6366f1'`)
-	s.db.Exec(`ALTER TABLE projects ADD COLUMN description TEXT`)
+	tx.Exec(`ALTER TABLE projects ADD COLUMN color TEXT DEFAULT '#6366f1'`)
+	tx.Exec(`ALTER TABLE projects ADD COLUMN description TEXT`)
 
 	// 2. Add project assignment columns to screenshots
-	s.db.Exec(`ALTER TABLE screenshots ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
-	s.db.Exec(`ALTER TABLE screenshots ADD COLUMN project_confidence REAL DEFAULT 0.0`)
-	s.db.Exec(`ALTER TABLE screenshots ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
+	tx.Exec(`ALTER TABLE screenshots ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
+	tx.Exec(`ALTER TABLE screenshots ADD COLUMN project_confidence REAL DEFAULT 0.0`)
+	tx.Exec(`ALTER TABLE screenshots ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
 
 	// 3. Add project assignment columns to window_focus_events
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_confidence REAL DEFAULT 0.0`)
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_confidence REAL DEFAULT 0.0`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
 
 	// 4. Add project assignment columns to git_commits
-	s.db.Exec(`ALTER TABLE git_commits ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
-	s.db.Exec(`ALTER TABLE git_commits ADD COLUMN project_confidence REAL DEFAULT 0.0`)
-	s.db.Exec(`ALTER TABLE git_commits ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
+	tx.Exec(`ALTER TABLE git_commits ADD COLUMN project_id INTEGER REFERENCES projects(id)`)
+	tx.Exec(`ALTER TABLE git_commits ADD COLUMN project_confidence REAL DEFAULT 0.0`)
+	tx.Exec(`ALTER TABLE git_commits ADD COLUMN project_source TEXT DEFAULT 'unassigned'`)
 
 	// 5. Create project_patterns table for learned matching rules
 	// Use separate statements because SQLite/Go doesn't handle multi-statement well
-	_, err := s.db.Exec(`
+	_, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS project_patterns (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
@@ -834,11 +835,11 @@ func (s *Store) applyMigration9() error {
 	}
 
 	// Create indexes separately
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_project_patterns_lookup ON project_patterns(pattern_type, pattern_value)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_project_patterns_project ON project_patterns(project_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_project_patterns_lookup ON project_patterns(pattern_type, pattern_value)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_project_patterns_project ON project_patterns(project_id)`)
 
 	// 6. Create assignment_examples table for few-shot AI learning
-	_, err = s.db.Exec(`
+	_, err = tx.Exec(`
 		CREATE TABLE IF NOT EXISTS assignment_examples (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			project_id INTEGER NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
@@ -853,13 +854,13 @@ func (s *Store) applyMigration9() error {
 	}
 
 	// Create indexes separately
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_assignment_examples_project ON assignment_examples(project_id)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_assignment_examples_created ON assignment_examples(created_at)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_assignment_examples_project ON assignment_examples(project_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_assignment_examples_created ON assignment_examples(created_at)`)
 
 	// 7. Create indexes for project_id lookups on event tables
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshots_project ON screenshots(project_id)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_project ON window_focus_events(project_id)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_git_project ON git_commits(project_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshots_project ON screenshots(project_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_project ON window_focus_events(project_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_git_project ON git_commits(project_id)`)
 
 	return nil
 }
@@ -867,17 +868,17 @@ func (s *Store) applyMigration9() error {
 // applyMigration10 adds memory_status column to event tables and report config.
 // - memory_status: 'active' (default) or 'ignored' - used to hide activities from view
 // - reports.include_unassigned config: controls whether unassigned activities appear in reports
-func (s *Store) applyMigration10() error {
+func applyMigration10(tx *sql.Tx) error {
 	// 1. Add memory_status column to window_focus_events
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN memory_status TEXT NOT NULL DEFAULT 'active'`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_memory_status ON window_focus_events(memory_status)`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN memory_status TEXT NOT NULL DEFAULT 'active'`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_memory_status ON window_focus_events(memory_status)`)
 
 	// 2. Add memory_status column to screenshots
-	s.db.Exec(`ALTER TABLE screenshots ADD COLUMN memory_status TEXT NOT NULL DEFAULT 'active'`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshot_memory_status ON screenshots(memory_status)`)
+	tx.Exec(`ALTER TABLE screenshots ADD COLUMN memory_status TEXT NOT NULL DEFAULT 'active'`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshot_memory_status ON screenshots(memory_status)`)
 
 	// 3. Add default config for report behavior
-	_, err := s.db.Exec(`INSERT OR IGNORE INTO config (key, value) VALUES ('reports.include_unassigned', 'true')`)
+	_, err := tx.Exec(`INSERT OR IGNORE INTO config (key, value) VALUES ('reports.include_unassigned', 'true')`)
 	if err != nil {
 		return fmt.Errorf("failed to insert reports.include_unassigned config: %w", err)
 	}
@@ -887,9 +888,9 @@ func (s *Store) applyMigration10() error {
 
 // applyMigration11 creates the activity_embeddings table for semantic similarity search.
 // This table stores vector embeddings for activity context, enabling intelligent project assignment.
-func (s *Store) applyMigration11() error {
+func applyMigration11(tx *sql.Tx) error {
 	// Create activity_embeddings table
-	_, err := s.db.Exec(`
+	_, err := tx.Exec(`
 		CREATE TABLE IF NOT EXISTS activity_embeddings (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			event_type TEXT NOT NULL,
@@ -906,8 +907,8 @@ func (s *Store) applyMigration11() error {
 	}
 
 	// Create indexes
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_embeddings_event ON activity_embeddings(event_type, event_id)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_embeddings_hash ON activity_embeddings(context_hash)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_embeddings_event ON activity_embeddings(event_type, event_id)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_embeddings_hash ON activity_embeddings(context_hash)`)
 
 	return nil
 }
@@ -916,18 +917,713 @@ func (s *Store) applyMigration11() error {
 // - is_draft: 0 = committed, 1 = draft (pending user approval)
 // - draft_status: 'none' | 'pending' | 'accepted' | 'rejected'
 // Applied to summaries table for AI-generated session summaries.
-func (s *Store) applyMigration12() error {
+func applyMigration12(tx *sql.Tx) error {
 	// 1. Add draft fields to summaries table
-	s.db.Exec(`ALTER TABLE summaries ADD COLUMN is_draft INTEGER DEFAULT 0`)
-	s.db.Exec(`ALTER TABLE summaries ADD COLUMN draft_status TEXT DEFAULT 'none'`)
+	tx.Exec(`ALTER TABLE summaries ADD COLUMN is_draft INTEGER DEFAULT 0`)
+	tx.Exec(`ALTER TABLE summaries ADD COLUMN draft_status TEXT DEFAULT 'none'`)
 
 	// 2. Add draft fields to window_focus_events for project assignment drafts
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN is_draft INTEGER DEFAULT 0`)
-	s.db.Exec(`ALTER TABLE window_focus_events ADD COLUMN draft_status TEXT DEFAULT 'none'`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN is_draft INTEGER DEFAULT 0`)
+	tx.Exec(`ALTER TABLE window_focus_events ADD COLUMN draft_status TEXT DEFAULT 'none'`)
 
 	// 3. Create indexes for efficient draft queries
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_summaries_draft ON summaries(is_draft)`)
-	s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_draft ON window_focus_events(is_draft)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_summaries_draft ON summaries(is_draft)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_draft ON window_focus_events(is_draft)`)
+
+	return nil
+}
+
+// applyMigration13 creates the clipboard_events table.
+// Only the content length and type are recorded - never the clipboard content itself.
+func applyMigration13(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS clipboard_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			content_length INTEGER NOT NULL,
+			content_type TEXT NOT NULL CHECK(content_type IN ('text', 'image', 'other')),
+			session_id INTEGER REFERENCES sessions(id),
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create clipboard_events table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_clipboard_timestamp ON clipboard_events(timestamp)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_clipboard_session ON clipboard_events(session_id)`)
+
+	return nil
+}
+
+// applyMigration14 seeds the default global keyboard shortcuts.
+// Existing values are left alone so user customizations survive re-running the migration.
+func applyMigration14(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		INSERT OR IGNORE INTO config (key, value) VALUES
+			('shortcuts.force_capture', 'Ctrl+Shift+P'),
+			('shortcuts.pause_resume', 'Ctrl+Shift+Space'),
+			('shortcuts.show_window', 'Ctrl+Shift+T'),
+			('shortcuts.open_timeline', 'Ctrl+Shift+L')
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to insert default keyboard shortcuts: %w", err)
+	}
+
+	return nil
+}
+
+// applyMigration15 adds browser_profile column to browser_history table.
+// Existing visits predate profile tracking and are assigned to the 'default' profile.
+func applyMigration15(tx *sql.Tx) error {
+	// Check if column already exists (fresh databases get it from the schema)
+	var count int
+	err := tx.QueryRow(`
+		SELECT COUNT(*) FROM pragma_table_info('browser_history') WHERE name = 'browser_profile'
+	`).Scan(&count)
+	if err != nil {
+		return fmt.Errorf("failed to check column existence: %w", err)
+	}
+
+	if count == 0 {
+		_, err = tx.Exec(`ALTER TABLE browser_history ADD COLUMN browser_profile TEXT NOT NULL DEFAULT 'default'`)
+		if err != nil {
+			return fmt.Errorf("failed to add browser_profile column: %w", err)
+		}
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_browser_profile ON browser_history(browser_profile)`)
+
+	return nil
+}
+
+// applyMigration16 adds a (session_id, start_time) index on window_focus_events.
+// Session lookups filter by session_id and order by start_time, so the composite
+// index serves both without a separate sort.
+func applyMigration16(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_events_session ON window_focus_events(session_id, start_time)`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_focus_events_session index: %w", err)
+	}
+	return nil
+}
+
+// applyMigration17 creates the goals table.
+func applyMigration17(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS goals (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			goal_type TEXT NOT NULL,
+			target_value INTEGER NOT NULL,
+			period TEXT NOT NULL CHECK(period IN ('daily', 'weekly')),
+			created_at INTEGER DEFAULT (strftime('%s', 'now')),
+			active INTEGER DEFAULT 1
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create goals table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_goals_active ON goals(active, goal_type, period)`)
+
+	return nil
+}
+
+// applyMigration18 creates the project_suggestion_cache table.
+// AI classifications are cached by window title hash so each title is only sent to the model once.
+func applyMigration18(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS project_suggestion_cache (
+			title_hash TEXT PRIMARY KEY,
+			window_title TEXT NOT NULL,
+			project_name TEXT NOT NULL DEFAULT '',
+			confidence REAL NOT NULL DEFAULT 0,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create project_suggestion_cache table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration19 creates the pinned_events table.
+// Pins reference events in other tables by type and ID, so an event can be pinned at most once.
+func applyMigration19(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS pinned_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			event_type TEXT NOT NULL,
+			event_id INTEGER NOT NULL,
+			note TEXT NOT NULL DEFAULT '',
+			pinned_at INTEGER DEFAULT (strftime('%s', 'now')),
+			UNIQUE(event_type, event_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create pinned_events table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration20 creates the browser_domain_blocklist table and seeds common social media domains.
+func applyMigration20(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS browser_domain_blocklist (
+			domain TEXT PRIMARY KEY,
+			reason TEXT NOT NULL DEFAULT '',
+			added_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create browser_domain_blocklist table: %w", err)
+	}
+
+	for _, domain := range defaultBlocklistedDomains {
+		_, err = tx.Exec(`
+			INSERT OR IGNORE INTO browser_domain_blocklist (domain, reason, added_at)
+			VALUES (?, 'Social media', strftime('%s', 'now'))
+		`, domain)
+		if err != nil {
+			return fmt.Errorf("failed to seed blocklisted domain %s: %w", domain, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration21 adds parent_id and version columns to reports.
+// Re-generated reports become new versions pointing at the original (version 1) report.
+func applyMigration21(tx *sql.Tx) error {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('reports') WHERE name = 'parent_id'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE reports ADD COLUMN parent_id INTEGER REFERENCES reports(id) ON DELETE CASCADE`); err != nil {
+			return fmt.Errorf("failed to add reports.parent_id column: %w", err)
+		}
+	}
+
+	err = tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('reports') WHERE name = 'version'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE reports ADD COLUMN version INTEGER NOT NULL DEFAULT 1`); err != nil {
+			return fmt.Errorf("failed to add reports.version column: %w", err)
+		}
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_reports_parent ON reports(parent_id)`)
+
+	return nil
+}
+
+// applyMigration22 adds a monitor_index column to screenshots.
+// Existing rows were all captured from a single monitor and default to index 0.
+func applyMigration22(tx *sql.Tx) error {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('screenshots') WHERE name = 'monitor_index'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE screenshots ADD COLUMN monitor_index INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("failed to add screenshots.monitor_index column: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyMigration23 creates the goal_achievements table.
+func applyMigration23(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS goal_achievements (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			date TEXT NOT NULL,
+			goal_type TEXT NOT NULL,
+			achieved_at INTEGER NOT NULL,
+			UNIQUE(date, goal_type)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create goal_achievements table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration24 creates the screenshot_annotations table.
+// Labels compare case-insensitively so "Bug" and "bug" are the same annotation.
+func applyMigration24(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS screenshot_annotations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			screenshot_id INTEGER NOT NULL REFERENCES screenshots(id) ON DELETE CASCADE,
+			label TEXT NOT NULL COLLATE NOCASE,
+			annotated_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now')),
+			annotated_by TEXT NOT NULL DEFAULT 'user' CHECK(annotated_by IN ('user', 'ai')),
+			UNIQUE(screenshot_id, label)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create screenshot_annotations table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshot_annotations_label ON screenshot_annotations(label)`)
+
+	return nil
+}
+
+// applyMigration25 adds the git repository and checked-out branch to window_focus_events,
+// recorded for IDE and terminal windows working inside a tracked repository.
+func applyMigration25(tx *sql.Tx) error {
+	columns := []struct{ name, def string }{
+		{"git_repository_id", "INTEGER REFERENCES git_repositories(id) ON DELETE SET NULL"},
+		{"current_branch", "TEXT"},
+	}
+	for _, col := range columns {
+		var count int
+		err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('window_focus_events') WHERE name = ?`, col.name).Scan(&count)
+		if err == nil && count == 0 {
+			if _, err := tx.Exec(fmt.Sprintf(`ALTER TABLE window_focus_events ADD COLUMN %s %s`, col.name, col.def)); err != nil {
+				return fmt.Errorf("failed to add window_focus_events.%s column: %w", col.name, err)
+			}
+		}
+	}
+
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_branch ON window_focus_events(git_repository_id, current_branch, start_time)`)
+	if err != nil {
+		return fmt.Errorf("failed to create focus branch index: %w", err)
+	}
+	return nil
+}
+
+// applyMigration26 indexes git_repositories by active status, so polling doesn't scan the
+// whole table, and git_commits by repository and time for stale-repository and report queries.
+func applyMigration26(tx *sql.Tx) error {
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_git_repos_active ON git_repositories(is_active, name)`); err != nil {
+		return fmt.Errorf("failed to create active repositories index: %w", err)
+	}
+	if _, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_git_repo_time ON git_commits(repository_id, timestamp)`); err != nil {
+		return fmt.Errorf("failed to create repository commit time index: %w", err)
+	}
+	return nil
+}
+
+// applyMigration27 creates the vscode_language_events table, recording languages detected
+// from VS Code settings and workspace configuration.
+func applyMigration27(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS vscode_language_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			language TEXT NOT NULL,
+			workspace TEXT,
+			session_id INTEGER REFERENCES sessions(id),
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create vscode_language_events table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_vscode_language_timestamp ON vscode_language_events(timestamp)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_vscode_language_session ON vscode_language_events(session_id)`)
+
+	return nil
+}
+
+// applyMigration28 creates the profiles table and seeds the default work and personal profiles.
+// At most one profile is active; work starts active so the current settings belong to it.
+func applyMigration28(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS profiles (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+			config_json TEXT NOT NULL DEFAULT '{}',
+			is_active INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create profiles table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		INSERT OR IGNORE INTO profiles (name, config_json, is_active)
+		VALUES ('work', '{}', 1), ('personal', '{}', 0)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to seed default profiles: %w", err)
+	}
+	return nil
+}
+
+// applyMigration29 creates the network_events table for new TCP connections to external web hosts.
+func applyMigration29(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS network_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			hostname TEXT NOT NULL,
+			process_name TEXT,
+			bytes_sent INTEGER NOT NULL DEFAULT 0,
+			bytes_received INTEGER NOT NULL DEFAULT 0,
+			session_id INTEGER REFERENCES sessions(id),
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create network_events table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_network_timestamp ON network_events(timestamp)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_network_session ON network_events(session_id)`)
+
+	return nil
+}
+
+// applyMigration30 creates the project_detection_rules table for regex-based project extraction.
+func applyMigration30(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS project_detection_rules (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			source_type TEXT NOT NULL,
+			pattern TEXT NOT NULL,
+			capture_group INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create project_detection_rules table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_detection_rules_source ON project_detection_rules(source_type)`)
+
+	return nil
+}
+
+// applyMigration31 indexes file events by extension and time for file type analysis.
+func applyMigration31(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_file_events_ext_time ON file_events(file_extension, timestamp)`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_file_events_ext_time index: %w", err)
+	}
+	return nil
+}
+
+// applyMigration32 creates the report_templates table and seeds a default built-in
+// template for each templated report type. The built-in templates render the
+// standard layout, exposed to templates as DefaultHTML and DefaultMarkdown.
+func applyMigration32(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS report_templates (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			report_type TEXT NOT NULL,
+			template_html TEXT NOT NULL DEFAULT '',
+			template_markdown TEXT NOT NULL DEFAULT '',
+			is_default INTEGER NOT NULL DEFAULT 0,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create report_templates table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_report_templates_type ON report_templates(report_type, is_default)`)
+
+	for _, t := range []struct{ name, reportType string }{
+		{"Built-in Summary", "summary"},
+		{"Built-in Standup", "standup"},
+	} {
+		_, err := tx.Exec(`
+			INSERT INTO report_templates (name, report_type, template_html, template_markdown, is_default)
+			SELECT ?, ?, '{{.DefaultHTML}}', '{{.DefaultMarkdown}}', 1
+			WHERE NOT EXISTS (SELECT 1 FROM report_templates WHERE report_type = ?)`,
+			t.name, t.reportType, t.reportType)
+		if err != nil {
+			return fmt.Errorf("failed to seed report templates: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration33 creates the resource_snapshots table, written by the daemon every
+// 15 minutes.
+func applyMigration33(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS resource_snapshots (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			cpu_percent REAL NOT NULL DEFAULT 0,
+			memory_mb REAL NOT NULL DEFAULT 0,
+			db_size_mb REAL NOT NULL DEFAULT 0,
+			screenshot_dir_size_mb REAL NOT NULL DEFAULT 0,
+			open_fds INTEGER NOT NULL DEFAULT 0,
+			goroutines INTEGER NOT NULL DEFAULT 0
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create resource_snapshots table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_resource_snapshots_timestamp ON resource_snapshots(timestamp)`)
+
+	return nil
+}
+
+// applyMigration34 creates the audio_events table, recorded while audio is playing.
+func applyMigration34(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS audio_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			app_name TEXT NOT NULL DEFAULT '',
+			device_name TEXT NOT NULL DEFAULT '',
+			session_id INTEGER REFERENCES sessions(id),
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create audio_events table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_audio_timestamp ON audio_events(timestamp)`)
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_audio_session ON audio_events(session_id)`)
+
+	return nil
+}
+
+// applyMigration35 creates the ticket_references table, linking commits to the issue tracker
+// tickets their messages mention.
+func applyMigration35(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS ticket_references (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			commit_id INTEGER NOT NULL REFERENCES git_commits(id) ON DELETE CASCADE,
+			ticket_id TEXT NOT NULL,
+			system TEXT NOT NULL CHECK(system IN ('jira', 'linear', 'github', 'gitlab')),
+			project_key TEXT NOT NULL DEFAULT '',
+			UNIQUE(commit_id, system, ticket_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket_references table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_ticket_references_ticket ON ticket_references(ticket_id)`)
+
+	return nil
+}
 
+// applyMigration36 creates the report_goals table, holding the targets reports show progress against.
+func applyMigration36(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS report_goals (
+			goal_type TEXT PRIMARY KEY,
+			target INTEGER NOT NULL CHECK(target > 0),
+			updated_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create report_goals table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration37 creates the screenshot_blur_regions table, recording the areas of
+// screenshots that were blurred for privacy. Blurring overwrites the image file, so
+// the originals can't be restored.
+func applyMigration37(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS screenshot_blur_regions (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			screenshot_id INTEGER NOT NULL REFERENCES screenshots(id) ON DELETE CASCADE,
+			x INTEGER NOT NULL,
+			y INTEGER NOT NULL,
+			width INTEGER NOT NULL,
+			height INTEGER NOT NULL,
+			blurred_at INTEGER NOT NULL DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create screenshot_blur_regions table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_screenshot_blur_regions_screenshot ON screenshot_blur_regions(screenshot_id)`)
+
+	return nil
+}
+
+// applyMigration38 creates the timezone_changes table, recording when the system timezone
+// changed so stats can be shown in the timezone the user was in.
+func applyMigration38(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS timezone_changes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			timestamp INTEGER NOT NULL,
+			from_tz TEXT NOT NULL,
+			to_tz TEXT NOT NULL,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create timezone_changes table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_timezone_changes_timestamp ON timezone_changes(timestamp)`)
+
+	return nil
+}
+
+// applyMigration39 adds a quality column to screenshots, the WebP quality each one was
+// encoded with. Existing rows default to 0 (unknown).
+func applyMigration39(tx *sql.Tx) error {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('screenshots') WHERE name = 'quality'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE screenshots ADD COLUMN quality INTEGER NOT NULL DEFAULT 0`); err != nil {
+			return fmt.Errorf("failed to add screenshots.quality column: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyMigration40 indexes focus events by window title and time for window-level drill-down.
+func applyMigration40(tx *sql.Tx) error {
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_focus_window_title_time ON window_focus_events(window_title, start_time)`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_focus_window_title_time index: %w", err)
+	}
+	return nil
+}
+
+// applyMigration41 adds partial indexes for session search, covering the searched column of
+// each source's session-attributed rows so the per-session EXISTS checks avoid the base table.
+func applyMigration41(tx *sql.Tx) error {
+	return execStatements(
+		`CREATE INDEX IF NOT EXISTS idx_focus_session_title ON window_focus_events(session_id, window_title) WHERE session_id IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_git_session_message ON git_commits(session_id, message) WHERE session_id IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_shell_session_command ON shell_commands(session_id, command) WHERE session_id IS NOT NULL`,
+	)(tx)
+}
+
+// applyMigration42 creates the task_threads table and its task_thread_sessions junction
+// table, grouping sessions under long-running tasks.
+func applyMigration42(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS task_threads (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL,
+			created_at INTEGER DEFAULT (strftime('%s', 'now')),
+			closed_at INTEGER,
+			is_open INTEGER NOT NULL DEFAULT 1
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create task_threads table: %w", err)
+	}
+
+	_, err = tx.Exec(`
+		CREATE TABLE IF NOT EXISTS task_thread_sessions (
+			thread_id INTEGER NOT NULL REFERENCES task_threads(id),
+			session_id INTEGER NOT NULL REFERENCES sessions(id),
+			attached_at INTEGER DEFAULT (strftime('%s', 'now')),
+			PRIMARY KEY (thread_id, session_id)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create task_thread_sessions table: %w", err)
+	}
+
+	tx.Exec(`CREATE INDEX IF NOT EXISTS idx_task_thread_sessions_session ON task_thread_sessions(session_id)`)
+
+	return nil
+}
+
+// applyMigration43 creates the scheduled_reports table, holding reports generated on a cron
+// schedule and posted to webhooks.
+func applyMigration43(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		CREATE TABLE IF NOT EXISTS scheduled_reports (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			report_type TEXT NOT NULL,
+			time_range TEXT NOT NULL,
+			cron_expression TEXT NOT NULL,
+			recipients TEXT NOT NULL DEFAULT '[]',
+			format TEXT NOT NULL DEFAULT 'html',
+			last_run_at INTEGER,
+			created_at INTEGER DEFAULT (strftime('%s', 'now'))
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduled_reports table: %w", err)
+	}
+	return nil
+}
+
+// applyMigration44 adds activated_at to git_repositories, recording when an inactive
+// repository was last reactivated so it isn't immediately judged stale again.
+func applyMigration44(tx *sql.Tx) error {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('git_repositories') WHERE name = 'activated_at'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE git_repositories ADD COLUMN activated_at INTEGER`); err != nil {
+			return fmt.Errorf("failed to add git_repositories.activated_at column: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyMigration45 adds duration_seconds to audio_events, the time since the previous audio
+// poll. Existing events were recorded every 30 seconds.
+func applyMigration45(tx *sql.Tx) error {
+	var count int
+	err := tx.QueryRow(`SELECT COUNT(*) FROM pragma_table_info('audio_events') WHERE name = 'duration_seconds'`).Scan(&count)
+	if err == nil && count == 0 {
+		if _, err := tx.Exec(`ALTER TABLE audio_events ADD COLUMN duration_seconds INTEGER NOT NULL DEFAULT 30`); err != nil {
+			return fmt.Errorf("failed to add audio_events.duration_seconds column: %w", err)
+		}
+	}
+	return nil
+}
+
+// applyMigration46 replaces the {{.DefaultHTML}} placeholder in the built-in report templates
+// with the standard layouts, so they can be copied and edited.
+func applyMigration46(tx *sql.Tx) error {
+	for _, reportType := range []string{"summary", "standup"} {
+		_, err := tx.Exec(`
+			UPDATE report_templates SET template_html = ?
+			WHERE report_type = ? AND template_html = '{{.DefaultHTML}}'`,
+			BuiltinReportTemplateHTML(reportType), reportType)
+		if err != nil {
+			return fmt.Errorf("failed to update built-in %s template: %w", reportType, err)
+		}
+	}
+	return nil
+}
+
+// rollbackMigration46 restores the {{.DefaultHTML}} placeholder in the built-in report templates.
+func rollbackMigration46(tx *sql.Tx) error {
+	for _, reportType := range []string{"summary", "standup"} {
+		_, err := tx.Exec(`
+			UPDATE report_templates SET template_html = '{{.DefaultHTML}}'
+			WHERE report_type = ? AND template_html = ?`,
+			reportType, BuiltinReportTemplateHTML(reportType))
+		if err != nil {
+			return fmt.Errorf("failed to restore built-in %s template: %w", reportType, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration47 unbinds the global keyboard shortcuts migration 14 seeded, which took
+// keys like Ctrl+Shift+T from other apps. Shortcuts the user changed are kept.
+func applyMigration47(tx *sql.Tx) error {
+	_, err := tx.Exec(`
+		DELETE FROM config WHERE (key, value) IN (VALUES
+			('shortcuts.force_capture', 'Ctrl+Shift+P'),
+			('shortcuts.pause_resume', 'Ctrl+Shift+Space'),
+			('shortcuts.show_window', 'Ctrl+Shift+T'),
+			('shortcuts.open_timeline', 'Ctrl+Shift+L'))
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to clear default keyboard shortcuts: %w", err)
+	}
 	return nil
 }
@@ -22,11 +22,15 @@ type Screenshot struct {
 	MonitorName       sql.NullString  `json:"monitorName"`
 	MonitorWidth      sql.NullInt64   `json:"monitorWidth"`
 	MonitorHeight     sql.NullInt64   `json:"monitorHeight"`
+	MonitorIndex      int             `json:"monitorIndex"` // Display index the screenshot was captured from
+	Quality           int             `json:"quality"`      // WebP quality it was encoded with, 0 if unknown
 	SessionID         sql.NullInt64   `json:"sessionId"`
 	ProjectID         sql.NullInt64   `json:"projectId"`
 	ProjectConfidence sql.NullFloat64 `json:"projectConfidence"`
 	ProjectSource     sql.NullString  `json:"projectSource"` // 'unassigned', 'user', 'rule', 'ai'
 	MemoryStatus      string          `json:"memoryStatus"`  // 'active' or 'ignored'
+	GitRepositoryID   sql.NullInt64   `json:"gitRepositoryId"` // Repository the IDE/terminal was working in
+	CurrentBranch     sql.NullString  `json:"currentBranch"`   // Branch checked out when the event started
 	CreatedAt         int64           `json:"createdAt"`
 }
 
@@ -80,6 +84,8 @@ type WindowFocusEvent struct {
 	ProjectConfidence sql.NullFloat64 `json:"projectConfidence"`
 	ProjectSource     sql.NullString  `json:"projectSource"` // 'unassigned', 'user', 'rule', 'ai'
 	MemoryStatus      string          `json:"memoryStatus"`  // 'active' or 'ignored'
+	GitRepositoryID   sql.NullInt64   `json:"gitRepositoryId"` // Repository an IDE/terminal was working in
+	CurrentBranch     sql.NullString  `json:"currentBranch"`   // Branch checked out when the event started
 	CreatedAt         int64           `json:"createdAt"`
 }
 
@@ -131,6 +137,15 @@ type GitCommit struct {
 	CreatedAt         int64           `json:"createdAt"`
 }
 
+// TicketReference links a git commit to an issue tracker ticket mentioned in it.
+type TicketReference struct {
+	ID         int64  `json:"id"`
+	CommitID   int64  `json:"commitId"`
+	TicketID   string `json:"ticketId"`   // e.g. "PROJ-123", "owner/repo#42"
+	System     string `json:"system"`     // 'jira', 'linear', 'github', 'gitlab'
+	ProjectKey string `json:"projectKey"` // Jira/Linear key or repository path, empty if unknown
+}
+
 // FileEvent represents a file system event.
 type FileEvent struct {
 	ID            int64          `json:"id"`
@@ -147,6 +162,23 @@ type FileEvent struct {
 	CreatedAt     int64          `json:"createdAt"`
 }
 
+// ExtensionCount aggregates the file events for one file extension.
+type ExtensionCount struct {
+	Extension   string `json:"extension"`
+	EventCount  int64  `json:"eventCount"`
+	CreateCount int64  `json:"createCount"`
+	ModifyCount int64  `json:"modifyCount"`
+	DeleteCount int64  `json:"deleteCount"`
+}
+
+// WindowTitleCount aggregates the focus events for one window title.
+type WindowTitleCount struct {
+	WindowTitle  string  `json:"windowTitle"`
+	AppName      string  `json:"appName"` // App the title was most recently seen in
+	Count        int64   `json:"count"`
+	TotalSeconds float64 `json:"totalSeconds"`
+}
+
 // BrowserVisit represents a browser history entry.
 type BrowserVisit struct {
 	ID                   int64          `json:"id"`
@@ -154,13 +186,126 @@ type BrowserVisit struct {
 	URL                  string         `json:"url"`
 	Title                sql.NullString `json:"title"`
 	Domain               string         `json:"domain"`
-	Browser              string         `json:"browser"` // chrome, firefox, safari, edge
+	Browser              string         `json:"browser"`        // chrome, firefox, safari, edge
+	BrowserProfile       string         `json:"browserProfile"` // Profile name, "default" for the browser's main profile
 	VisitDurationSeconds sql.NullInt64  `json:"visitDurationSeconds"`
 	TransitionType       sql.NullString `json:"transitionType"`
 	SessionID            sql.NullInt64  `json:"sessionId"`
 	CreatedAt            int64          `json:"createdAt"`
 }
 
+// DomainCount aggregates the browser visits to a domain for one browser profile.
+type DomainCount struct {
+	Domain          string `json:"domain"`
+	BrowserProfile  string `json:"browserProfile"`
+	VisitCount      int64  `json:"visitCount"`
+	UniqueVisits    int64  `json:"uniqueVisits"` // Distinct URLs visited
+	DurationSeconds int64  `json:"durationSeconds"`
+}
+
+// ClipboardEvent represents a clipboard content change.
+// The clipboard content itself is never stored, only its size and type.
+type ClipboardEvent struct {
+	ID            int64         `json:"id"`
+	Timestamp     int64         `json:"timestamp"`
+	ContentLength int64         `json:"contentLength"`
+	ContentType   string        `json:"contentType"` // text, image, other
+	SessionID     sql.NullInt64 `json:"sessionId"`
+	CreatedAt     int64         `json:"createdAt"`
+}
+
+// VSCodeLanguageEvent records a language detected from VS Code settings or workspace configuration.
+type VSCodeLanguageEvent struct {
+	ID        int64          `json:"id"`
+	Timestamp int64          `json:"timestamp"`
+	Language  string         `json:"language"`  // VS Code language identifier, e.g. go, typescript
+	Workspace sql.NullString `json:"workspace"` // Workspace folder, null for user settings
+	SessionID sql.NullInt64  `json:"sessionId"`
+	CreatedAt int64          `json:"createdAt"`
+}
+
+// NetworkEvent represents a new TCP connection to an external web host (port 80 or 443).
+type NetworkEvent struct {
+	ID            int64          `json:"id"`
+	Timestamp     int64          `json:"timestamp"`
+	Hostname      string         `json:"hostname"`    // Reverse DNS name, or the IP if it has none
+	ProcessName   sql.NullString `json:"processName"` // Process that opened the connection, if known
+	BytesSent     int64          `json:"bytesSent"`
+	BytesReceived int64          `json:"bytesReceived"`
+	SessionID     sql.NullInt64  `json:"sessionId"`
+	CreatedAt     int64          `json:"createdAt"`
+}
+
+// AudioEvent records an app playing audio at a poll of the audio output.
+type AudioEvent struct {
+	ID              int64         `json:"id"`
+	Timestamp       int64         `json:"timestamp"`
+	AppName         string        `json:"appName"`         // Empty where the platform can't tell which app is playing
+	DeviceName      string        `json:"deviceName"`      // Output device, e.g. "HDA Intel PCH" or "MacBook Pro Speakers"
+	DurationSeconds int64         `json:"durationSeconds"` // Playback the event stands for: the time since the previous poll
+	SessionID       sql.NullInt64 `json:"sessionId"`
+	CreatedAt       int64         `json:"createdAt"`
+}
+
+// Goal represents an activity target, such as 180 active minutes per day.
+type Goal struct {
+	ID          int64  `json:"id"`
+	GoalType    string `json:"goalType"` // e.g. "active_minutes"
+	TargetValue int64  `json:"targetValue"`
+	Period      string `json:"period"` // daily, weekly
+	CreatedAt   int64  `json:"createdAt"`
+	Active      bool   `json:"active"`
+}
+
+// GoalAchievement records the first time a goal was reached on a day.
+type GoalAchievement struct {
+	ID         int64  `json:"id"`
+	Date       string `json:"date"`     // YYYY-MM-DD
+	GoalType   string `json:"goalType"` // e.g. "deep_work"
+	AchievedAt int64  `json:"achievedAt"`
+}
+
+// TaskThread groups sessions, possibly across many days, under one long-running task
+// such as "Implement OAuth feature".
+type TaskThread struct {
+	ID        int64         `json:"id"`
+	Name      string        `json:"name"`
+	CreatedAt int64         `json:"createdAt"`
+	ClosedAt  sql.NullInt64 `json:"closedAt"` // Set when the thread is closed
+	IsOpen    bool          `json:"isOpen"`
+}
+
+// ScheduledReport generates a report on a cron schedule and posts it to webhook recipients.
+type ScheduledReport struct {
+	ID             int64         `json:"id"`
+	ReportType     string        `json:"reportType"`     // summary, detailed, standup, journal, ai_narrative
+	TimeRange      string        `json:"timeRange"`      // e.g. "yesterday", "last week"
+	CronExpression string        `json:"cronExpression"` // "@daily", "@weekly", "@monthly" or 5-field cron
+	Recipients     []string      `json:"recipients"`     // Webhook URLs
+	Format         string        `json:"format"`         // html, markdown
+	LastRunAt      sql.NullInt64 `json:"lastRunAt"`      // Due time the schedule last fired for
+	CreatedAt      int64         `json:"createdAt"`
+}
+
+// PinnedEvent is a timeline event the user marked as important, with an optional note.
+// Only the field matching EventType is populated with the original event data.
+type PinnedEvent struct {
+	ID        int64  `json:"id"`
+	EventType string `json:"eventType"` // focus, screenshot, git, shell, browser, file
+	EventID   int64  `json:"eventId"`
+	Note      string `json:"note"`
+	PinnedAt  int64  `json:"pinnedAt"`
+	Timestamp int64  `json:"timestamp"` // When the pinned event happened
+	Title     string `json:"title"`     // Short description of the pinned event
+
+	Focus      *WindowFocusEvent `json:"focus,omitempty"`
+	Screenshot *Screenshot       `json:"screenshot,omitempty"`
+	Git        *GitCommit        `json:"git,omitempty"`
+	Shell      *ShellCommand     `json:"shell,omitempty"`
+	Browser    *BrowserVisit     `json:"browser,omitempty"`
+	File       *FileEvent        `json:"file,omitempty"`
+}
+
 // Report represents a generated report.
 type Report struct {
 	ID         int64          `json:"id"`
@@ -173,6 +318,8 @@ type Report struct {
 	StartTime  sql.NullInt64  `json:"startTime"`
 	EndTime    sql.NullInt64  `json:"endTime"`
 	CreatedAt  int64          `json:"createdAt"`
+	ParentID   sql.NullInt64  `json:"parentId"` // Original report this is a re-generated version of
+	Version    int            `json:"version"`  // 1 for the original report
 }
 
 // AFKEvent represents an away-from-keyboard period.
@@ -181,7 +328,7 @@ type AFKEvent struct {
 	StartTime   int64         `json:"startTime"`
 	EndTime     sql.NullInt64 `json:"endTime"`     // NULL while AFK is ongoing
 	SessionID   sql.NullInt64 `json:"sessionId"`
-	TriggerType string        `json:"triggerType"` // idle_timeout, system_sleep, manual
+	TriggerType string        `json:"triggerType"` // idle_timeout, screen_lock, display_off, sleep, manual
 	CreatedAt   int64         `json:"createdAt"`
 }
 
@@ -196,6 +343,15 @@ type HierarchicalSummary struct {
 	CreatedAt   int64  `json:"createdAt"`
 }
 
+// Profile is a named set of settings, such as "work" or "personal", that can be switched between.
+type Profile struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	ConfigJSON string `json:"configJson"` // Settings applied when the profile is activated
+	IsActive   bool   `json:"isActive"`
+	CreatedAt  int64  `json:"createdAt"`
+}
+
 // Project represents a tracked project.
 type Project struct {
 	ID                int64  `json:"id"`
@@ -221,6 +377,39 @@ type ProjectPattern struct {
 	CreatedAt    int64   `json:"createdAt"`
 }
 
+// ProjectDetectionRule extracts a project name from an activity with a regex.
+type ProjectDetectionRule struct {
+	ID           int64  `json:"id"`
+	SourceType   string `json:"sourceType"`   // 'commit_message'
+	Pattern      string `json:"pattern"`
+	CaptureGroup int    `json:"captureGroup"` // 0 = full match, 1+ = capture group index
+	CreatedAt    int64  `json:"createdAt"`
+}
+
+// ReportTemplate is a user-editable layout for a report type.
+// TemplateHTML is an html/template and TemplateMarkdown a text/template.
+type ReportTemplate struct {
+	ID               int64  `json:"id"`
+	Name             string `json:"name"`
+	ReportType       string `json:"reportType"` // summary, standup
+	TemplateHTML     string `json:"templateHtml"`
+	TemplateMarkdown string `json:"templateMarkdown"`
+	IsDefault        bool   `json:"isDefault"` // Used when generating reports of this type
+	CreatedAt        int64  `json:"createdAt"`
+}
+
+// ResourceSnapshot records Traq's own resource usage at a point in time.
+type ResourceSnapshot struct {
+	ID                  int64   `json:"id"`
+	Timestamp           int64   `json:"timestamp"`
+	CPUPercent          float64 `json:"cpuPercent"`
+	MemoryMB            float64 `json:"memoryMb"`
+	DBSizeMB            float64 `json:"dbSizeMb"`
+	ScreenshotDirSizeMB float64 `json:"screenshotDirSizeMb"`
+	OpenFileDescriptors int     `json:"openFileDescriptors"`
+	GoRoutineCount      int     `json:"goRoutineCount"`
+}
+
 // AssignmentExample stores user assignment context for few-shot learning.
 type AssignmentExample struct {
 	ID          int64  `json:"id"`
@@ -337,3 +526,23 @@ type EmbeddingContext struct {
 	Domain      string `json:"domain,omitempty"`
 	FilePath    string `json:"filePath,omitempty"`
 }
+
+// ScreenshotBlurRegion records an area of a screenshot that was blurred for privacy.
+type ScreenshotBlurRegion struct {
+	ID           int64 `json:"id"`
+	ScreenshotID int64 `json:"screenshotId"`
+	X            int   `json:"x"`
+	Y            int   `json:"y"`
+	Width        int   `json:"width"`
+	Height       int   `json:"height"`
+	BlurredAt    int64 `json:"blurredAt"`
+}
+
+// TimezoneChange records a change of the system timezone, e.g. while traveling.
+type TimezoneChange struct {
+	ID        int64  `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	FromTZ    string `json:"fromTz"` // IANA name, e.g. "Europe/Berlin"
+	ToTZ      string `json:"toTz"`
+	CreatedAt int64  `json:"createdAt"`
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SaveNetworkEvent saves a network event to the database.
+func (s *Store) SaveNetworkEvent(event *NetworkEvent) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO network_events (timestamp, hostname, process_name, bytes_sent, bytes_received, session_id)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		event.Timestamp, event.Hostname, event.ProcessName, event.BytesSent, event.BytesReceived, event.SessionID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert network event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetNetworkEventsByTimeRange retrieves network events within a time range.
+func (s *Store) GetNetworkEventsByTimeRange(start, end int64) ([]*NetworkEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, hostname, process_name, bytes_sent, bytes_received, session_id, created_at
+		FROM network_events
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query network events by time: %w", err)
+	}
+	defer rows.Close()
+
+	return scanNetworkEvents(rows)
+}
+
+func scanNetworkEvents(rows *sql.Rows) ([]*NetworkEvent, error) {
+	var events []*NetworkEvent
+	for rows.Next() {
+		event := &NetworkEvent{}
+		err := rows.Scan(
+			&event.ID, &event.Timestamp, &event.Hostname, &event.ProcessName,
+			&event.BytesSent, &event.BytesReceived, &event.SessionID, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan network event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,206 @@
+package storage
+
+import "fmt"
+
+// pinnedEventTables maps pinnable event types to the table holding the event.
+var pinnedEventTables = map[string]string{
+	"focus":      "window_focus_events",
+	"screenshot": "screenshots",
+	"git":        "git_commits",
+	"shell":      "shell_commands",
+	"browser":    "browser_history",
+	"file":       "file_events",
+}
+
+// normalizePinnedEventType validates an event type, accepting "activity" as the frontend name for focus events.
+func normalizePinnedEventType(eventType string) (string, error) {
+	if eventType == "activity" {
+		eventType = "focus"
+	}
+	if _, ok := pinnedEventTables[eventType]; !ok {
+		return "", fmt.Errorf("unknown event type: %s", eventType)
+	}
+	return eventType, nil
+}
+
+// PinEvent pins an event. Pinning an already pinned event updates its note.
+func (s *Store) PinEvent(eventType string, eventID int64, note string) error {
+	eventType, err := normalizePinnedEventType(eventType)
+	if err != nil {
+		return err
+	}
+
+	// Make sure the event exists so pins never dangle
+	var exists bool
+	if err := s.db.QueryRow(fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE id = ?)", pinnedEventTables[eventType]), eventID).Scan(&exists); err != nil {
+		return fmt.Errorf("failed to check event: %w", err)
+	}
+	if !exists {
+		return fmt.Errorf("%s event not found: %d", eventType, eventID)
+	}
+
+	_, err = s.db.Exec(`
+		INSERT INTO pinned_events (event_type, event_id, note, pinned_at)
+		VALUES (?, ?, ?, strftime('%s', 'now'))
+		ON CONFLICT(event_type, event_id) DO UPDATE SET note = excluded.note`,
+		eventType, eventID, note)
+	if err != nil {
+		return fmt.Errorf("failed to pin event: %w", err)
+	}
+	return nil
+}
+
+// UnpinEvent removes a pin. Unpinning an event that isn't pinned is a no-op.
+func (s *Store) UnpinEvent(eventType string, eventID int64) error {
+	eventType, err := normalizePinnedEventType(eventType)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Exec("DELETE FROM pinned_events WHERE event_type = ? AND event_id = ?", eventType, eventID); err != nil {
+		return fmt.Errorf("failed to unpin event: %w", err)
+	}
+	return nil
+}
+
+// GetPinnedEvents retrieves pinned events whose underlying event happened within a time range,
+// ordered by event time. Pins whose event has since been deleted are skipped.
+func (s *Store) GetPinnedEvents(start, end int64) ([]*PinnedEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, event_type, event_id, note, pinned_at, event_time FROM (
+			SELECT p.id, p.event_type, p.event_id, p.note, p.pinned_at,
+			       CASE p.event_type
+			           WHEN 'focus' THEN (SELECT start_time FROM window_focus_events WHERE id = p.event_id)
+			           WHEN 'screenshot' THEN (SELECT timestamp FROM screenshots WHERE id = p.event_id)
+			           WHEN 'git' THEN (SELECT timestamp FROM git_commits WHERE id = p.event_id)
+			           WHEN 'shell' THEN (SELECT timestamp FROM shell_commands WHERE id = p.event_id)
+			           WHEN 'browser' THEN (SELECT timestamp FROM browser_history WHERE id = p.event_id)
+			           WHEN 'file' THEN (SELECT timestamp FROM file_events WHERE id = p.event_id)
+			       END AS event_time
+			FROM pinned_events p
+		)
+		WHERE event_time >= ? AND event_time <= ?
+		ORDER BY event_time ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query pinned events: %w", err)
+	}
+
+	var pins []*PinnedEvent
+	for rows.Next() {
+		pin := &PinnedEvent{}
+		if err := rows.Scan(&pin.ID, &pin.EventType, &pin.EventID, &pin.Note, &pin.PinnedAt, &pin.Timestamp); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan pinned event: %w", err)
+		}
+		pins = append(pins, pin)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return nil, err
+	}
+	rows.Close()
+
+	// Hydrate after closing rows so the follow-up queries don't need a second connection
+	for _, pin := range pins {
+		if err := s.hydratePinnedEvent(pin); err != nil {
+			return nil, err
+		}
+	}
+	return pins, nil
+}
+
+// hydratePinnedEvent loads the original event data and a display title for a pin.
+func (s *Store) hydratePinnedEvent(pin *PinnedEvent) error {
+	switch pin.EventType {
+	case "focus":
+		evt, err := s.GetFocusEventByID(pin.EventID)
+		if err != nil {
+			return err
+		}
+		pin.Focus = evt
+		pin.Title = fmt.Sprintf("%s: %s", evt.AppName, evt.WindowTitle)
+	case "screenshot":
+		sc, err := s.GetScreenshot(pin.EventID)
+		if err != nil || sc == nil {
+			return err
+		}
+		pin.Screenshot = sc
+		pin.Title = sc.WindowTitle.String
+		if pin.Title == "" {
+			pin.Title = "Screenshot"
+		}
+	case "git":
+		rows, err := s.db.Query(`
+			SELECT id, timestamp, commit_hash, short_hash, repository_id, branch,
+			       message, message_subject, files_changed, insertions, deletions,
+			       author_name, author_email, is_merge, session_id, created_at,
+			       project_id, project_confidence, project_source
+			FROM git_commits WHERE id = ?`, pin.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to query pinned git commit: %w", err)
+		}
+		commits, err := scanGitCommits(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(commits) > 0 {
+			pin.Git = commits[0]
+			pin.Title = commits[0].MessageSubject
+		}
+	case "shell":
+		rows, err := s.db.Query(`
+			SELECT id, timestamp, command, shell_type, working_directory,
+			       exit_code, duration_seconds, hostname, session_id, created_at
+			FROM shell_commands WHERE id = ?`, pin.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to query pinned shell command: %w", err)
+		}
+		cmds, err := scanShellCommands(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(cmds) > 0 {
+			pin.Shell = cmds[0]
+			pin.Title = cmds[0].Command
+		}
+	case "browser":
+		rows, err := s.db.Query(`
+			SELECT id, timestamp, url, title, domain, browser, browser_profile,
+			       visit_duration_seconds, transition_type, session_id, created_at
+			FROM browser_history WHERE id = ?`, pin.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to query pinned browser visit: %w", err)
+		}
+		visits, err := scanBrowserVisits(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(visits) > 0 {
+			pin.Browser = visits[0]
+			pin.Title = visits[0].Title.String
+			if pin.Title == "" {
+				pin.Title = visits[0].URL
+			}
+		}
+	case "file":
+		rows, err := s.db.Query(`
+			SELECT id, timestamp, event_type, file_path, file_name, directory,
+			       file_extension, file_size_bytes, watch_category, old_path, session_id, created_at
+			FROM file_events WHERE id = ?`, pin.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to query pinned file event: %w", err)
+		}
+		files, err := scanFileEvents(rows)
+		rows.Close()
+		if err != nil {
+			return err
+		}
+		if len(files) > 0 {
+			pin.File = files[0]
+			pin.Title = files[0].FilePath
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPinnedEvents(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	focusID, err := store.SaveFocusEvent(&WindowFocusEvent{
+		AppName:         "code",
+		WindowTitle:     "main.go - traq",
+		StartTime:       now,
+		EndTime:         now + 60,
+		DurationSeconds: 60,
+	})
+	if err != nil {
+		t.Fatalf("SaveFocusEvent failed: %v", err)
+	}
+	shellID, err := store.SaveShellCommand(&ShellCommand{Timestamp: now - 100, Command: "make release", ShellType: "bash"})
+	if err != nil {
+		t.Fatalf("SaveShellCommand failed: %v", err)
+	}
+
+	if err := store.PinEvent("activity", focusID, "Fixed the bug"); err != nil {
+		t.Fatalf("PinEvent failed: %v", err)
+	}
+	if err := store.PinEvent("shell", shellID, ""); err != nil {
+		t.Fatalf("PinEvent failed: %v", err)
+	}
+	// Pinning again updates the note
+	if err := store.PinEvent("shell", shellID, "Shipped v1.0"); err != nil {
+		t.Fatalf("PinEvent failed: %v", err)
+	}
+
+	if err := store.PinEvent("focus", 9999, ""); err == nil {
+		t.Error("expected error pinning a missing event")
+	}
+	if err := store.PinEvent("clipboard", focusID, ""); err == nil {
+		t.Error("expected error for unknown event type")
+	}
+
+	pins, err := store.GetPinnedEvents(now-200, now+200)
+	if err != nil {
+		t.Fatalf("GetPinnedEvents failed: %v", err)
+	}
+	if len(pins) != 2 {
+		t.Fatalf("expected 2 pinned events, got %d", len(pins))
+	}
+	// Ordered by event time
+	if pins[0].EventType != "shell" || pins[0].Shell == nil || pins[0].Title != "make release" || pins[0].Note != "Shipped v1.0" {
+		t.Errorf("unexpected first pin: %+v", pins[0])
+	}
+	if pins[1].EventType != "focus" || pins[1].Focus == nil || pins[1].Timestamp != now || pins[1].Note != "Fixed the bug" {
+		t.Errorf("unexpected second pin: %+v", pins[1])
+	}
+
+	// Range is based on when the event happened
+	pins, err = store.GetPinnedEvents(now-10, now+10)
+	if err != nil {
+		t.Fatalf("GetPinnedEvents failed: %v", err)
+	}
+	if len(pins) != 1 || pins[0].EventID != focusID {
+		t.Errorf("expected only the focus pin, got %d pins", len(pins))
+	}
+
+	if err := store.UnpinEvent("focus", focusID); err != nil {
+		t.Fatalf("UnpinEvent failed: %v", err)
+	}
+	pins, err = store.GetPinnedEvents(now-200, now+200)
+	if err != nil {
+		t.Fatalf("GetPinnedEvents failed: %v", err)
+	}
+	if len(pins) != 1 {
+		t.Errorf("expected 1 pinned event after unpin, got %d", len(pins))
+	}
+}
@@ -0,0 +1,101 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CreateProfile creates a new inactive profile with the given settings.
+func (s *Store) CreateProfile(name, configJSON string) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO profiles (name, config_json, is_active)
+		VALUES (?, ?, 0)`, name, configJSON)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create profile: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetProfile retrieves a profile by ID.
+func (s *Store) GetProfile(id int64) (*Profile, error) {
+	return scanProfile(s.db.QueryRow(`
+		SELECT id, name, config_json, is_active, created_at
+		FROM profiles WHERE id = ?`, id))
+}
+
+// GetActiveProfile retrieves the active profile, or nil if none is active.
+func (s *Store) GetActiveProfile() (*Profile, error) {
+	return scanProfile(s.db.QueryRow(`
+		SELECT id, name, config_json, is_active, created_at
+		FROM profiles WHERE is_active = 1
+		LIMIT 1`))
+}
+
+// GetProfiles retrieves all profiles in creation order.
+func (s *Store) GetProfiles() ([]*Profile, error) {
+	rows, err := s.db.Query(`
+		SELECT id, name, config_json, is_active, created_at
+		FROM profiles
+		ORDER BY id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query profiles: %w", err)
+	}
+	defer rows.Close()
+
+	var profiles []*Profile
+	for rows.Next() {
+		p := &Profile{}
+		if err := rows.Scan(&p.ID, &p.Name, &p.ConfigJSON, &p.IsActive, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan profile: %w", err)
+		}
+		profiles = append(profiles, p)
+	}
+	return profiles, rows.Err()
+}
+
+// UpdateProfileConfig replaces a profile's settings.
+func (s *Store) UpdateProfileConfig(id int64, configJSON string) error {
+	_, err := s.db.Exec("UPDATE profiles SET config_json = ? WHERE id = ?", configJSON, id)
+	if err != nil {
+		return fmt.Errorf("failed to update profile config: %w", err)
+	}
+	return nil
+}
+
+// SetActiveProfile marks a profile as the only active profile.
+func (s *Store) SetActiveProfile(id int64) error {
+	return s.Transaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec("UPDATE profiles SET is_active = 0 WHERE is_active = 1"); err != nil {
+			return fmt.Errorf("failed to deactivate profiles: %w", err)
+		}
+		result, err := tx.Exec("UPDATE profiles SET is_active = 1 WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("failed to activate profile: %w", err)
+		}
+		if n, _ := result.RowsAffected(); n == 0 {
+			return fmt.Errorf("profile %d not found", id)
+		}
+		return nil
+	})
+}
+
+// DeleteProfile deletes a profile.
+func (s *Store) DeleteProfile(id int64) error {
+	_, err := s.db.Exec("DELETE FROM profiles WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete profile: %w", err)
+	}
+	return nil
+}
+
+func scanProfile(row *sql.Row) (*Profile, error) {
+	p := &Profile{}
+	err := row.Scan(&p.ID, &p.Name, &p.ConfigJSON, &p.IsActive, &p.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,62 @@
+package storage
+
+import "testing"
+
+func TestProfiles(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Migration seeds work (active) and personal
+	profiles, err := store.GetProfiles()
+	if err != nil {
+		t.Fatalf("GetProfiles failed: %v", err)
+	}
+	if len(profiles) != 2 || profiles[0].Name != "work" || profiles[1].Name != "personal" {
+		t.Fatalf("expected seeded work and personal profiles, got %+v", profiles)
+	}
+	active, _ := store.GetActiveProfile()
+	if active == nil || active.Name != "work" {
+		t.Fatalf("expected work to be active, got %+v", active)
+	}
+
+	id, err := store.CreateProfile("travel", `{"settings":{"capture.interval":"120"}}`)
+	if err != nil {
+		t.Fatalf("CreateProfile failed: %v", err)
+	}
+	if _, err := store.CreateProfile("Travel", "{}"); err == nil {
+		t.Error("expected error for duplicate profile name")
+	}
+
+	if err := store.SetActiveProfile(id); err != nil {
+		t.Fatalf("SetActiveProfile failed: %v", err)
+	}
+	active, _ = store.GetActiveProfile()
+	if active == nil || active.ID != id {
+		t.Fatalf("expected travel to be active, got %+v", active)
+	}
+	if work, _ := store.GetProfile(profiles[0].ID); work.IsActive {
+		t.Error("expected work to be deactivated")
+	}
+
+	// A missing profile leaves the active one unchanged
+	if err := store.SetActiveProfile(9999); err == nil {
+		t.Error("expected error for unknown profile")
+	}
+	if active, _ = store.GetActiveProfile(); active == nil || active.ID != id {
+		t.Errorf("expected travel to stay active, got %+v", active)
+	}
+
+	if err := store.UpdateProfileConfig(id, "{}"); err != nil {
+		t.Fatalf("UpdateProfileConfig failed: %v", err)
+	}
+	if p, _ := store.GetProfile(id); p.ConfigJSON != "{}" {
+		t.Errorf("expected updated config, got %s", p.ConfigJSON)
+	}
+
+	if err := store.DeleteProfile(profiles[1].ID); err != nil {
+		t.Fatalf("DeleteProfile failed: %v", err)
+	}
+	if p, _ := store.GetProfile(profiles[1].ID); p != nil {
+		t.Error("expected personal profile to be deleted")
+	}
+}
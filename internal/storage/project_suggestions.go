@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ProjectSuggestionCacheEntry is a cached AI project classification for a window title.
+// An empty ProjectName means the model found no matching project.
+type ProjectSuggestionCacheEntry struct {
+	TitleHash   string  `json:"titleHash"`
+	WindowTitle string  `json:"windowTitle"`
+	ProjectName string  `json:"projectName"`
+	Confidence  float64 `json:"confidence"`
+	CreatedAt   int64   `json:"createdAt"`
+}
+
+// GetProjectSuggestionCache retrieves a cached classification by window title. Returns nil if not cached.
+func (s *Store) GetProjectSuggestionCache(windowTitle string) (*ProjectSuggestionCacheEntry, error) {
+	entry := &ProjectSuggestionCacheEntry{}
+	err := s.db.QueryRow(`
+		SELECT title_hash, window_title, project_name, confidence, created_at
+		FROM project_suggestion_cache
+		WHERE title_hash = ?`, HashContext(windowTitle)).Scan(
+		&entry.TitleHash, &entry.WindowTitle, &entry.ProjectName, &entry.Confidence, &entry.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project suggestion cache: %w", err)
+	}
+	return entry, nil
+}
+
+// SaveProjectSuggestionCache stores an AI classification for a window title, replacing any previous entry.
+func (s *Store) SaveProjectSuggestionCache(windowTitle, projectName string, confidence float64) error {
+	_, err := s.db.Exec(`
+		INSERT INTO project_suggestion_cache (title_hash, window_title, project_name, confidence, created_at)
+		VALUES (?, ?, ?, ?, strftime('%s', 'now'))
+		ON CONFLICT(title_hash) DO UPDATE SET
+			project_name = excluded.project_name,
+			confidence = excluded.confidence,
+			created_at = excluded.created_at`,
+		HashContext(windowTitle), windowTitle, projectName, confidence)
+	if err != nil {
+		return fmt.Errorf("failed to save project suggestion cache: %w", err)
+	}
+	return nil
+}
+
+// ClearProjectSuggestionCache removes all cached AI classifications.
+// Should be called when projects are renamed or removed.
+func (s *Store) ClearProjectSuggestionCache() error {
+	if _, err := s.db.Exec("DELETE FROM project_suggestion_cache"); err != nil {
+		return fmt.Errorf("failed to clear project suggestion cache: %w", err)
+	}
+	return nil
+}
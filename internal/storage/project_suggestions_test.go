@@ -0,0 +1,43 @@
+package storage
+
+import "testing"
+
+func TestProjectSuggestionCache(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	entry, err := store.GetProjectSuggestionCache("main.go - traq")
+	if err != nil {
+		t.Fatalf("GetProjectSuggestionCache failed: %v", err)
+	}
+	if entry != nil {
+		t.Fatalf("expected cache miss, got %+v", entry)
+	}
+
+	if err := store.SaveProjectSuggestionCache("main.go - traq", "traq", 0.5); err != nil {
+		t.Fatalf("SaveProjectSuggestionCache failed: %v", err)
+	}
+	// Saving again replaces the entry
+	if err := store.SaveProjectSuggestionCache("main.go - traq", "", 0); err != nil {
+		t.Fatalf("SaveProjectSuggestionCache failed: %v", err)
+	}
+
+	entry, err = store.GetProjectSuggestionCache("main.go - traq")
+	if err != nil {
+		t.Fatalf("GetProjectSuggestionCache failed: %v", err)
+	}
+	if entry == nil || entry.ProjectName != "" || entry.WindowTitle != "main.go - traq" {
+		t.Errorf("unexpected cache entry: %+v", entry)
+	}
+
+	if err := store.ClearProjectSuggestionCache(); err != nil {
+		t.Fatalf("ClearProjectSuggestionCache failed: %v", err)
+	}
+	entry, err = store.GetProjectSuggestionCache("main.go - traq")
+	if err != nil {
+		t.Fatalf("GetProjectSuggestionCache failed: %v", err)
+	}
+	if entry != nil {
+		t.Errorf("expected cache to be cleared, got %+v", entry)
+	}
+}
@@ -415,7 +415,9 @@ func (s *Store) GetUnassignedActivities(startTime, endTime int64, limit int) ([]
 func (s *Store) GetFocusEventsByProject(startTime, endTime int64, projectID int64) ([]*WindowFocusEvent, error) {
 	rows, err := s.db.Query(`
 		SELECT id, window_title, app_name, window_class,
-		       start_time, end_time, duration_seconds, session_id, created_at
+		       start_time, end_time, duration_seconds, session_id, created_at,
+		       project_id, project_confidence, project_source,
+		       git_repository_id, current_branch
 		FROM window_focus_events
 		WHERE start_time <= ? AND end_time > ?
 		  AND project_id = ?
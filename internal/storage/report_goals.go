@@ -0,0 +1,46 @@
+package storage
+
+import "fmt"
+
+// GetReportGoals returns the report goal targets by goal type.
+func (s *Store) GetReportGoals() (map[string]int64, error) {
+	rows, err := s.db.Query(`SELECT goal_type, target FROM report_goals`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report goals: %w", err)
+	}
+	defer rows.Close()
+
+	goals := make(map[string]int64)
+	for rows.Next() {
+		var goalType string
+		var target int64
+		if err := rows.Scan(&goalType, &target); err != nil {
+			return nil, fmt.Errorf("failed to scan report goal: %w", err)
+		}
+		goals[goalType] = target
+	}
+	return goals, rows.Err()
+}
+
+// SetReportGoals replaces all report goals with the given targets by goal type.
+func (s *Store) SetReportGoals(goals map[string]int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM report_goals`); err != nil {
+		return fmt.Errorf("failed to clear report goals: %w", err)
+	}
+	for goalType, target := range goals {
+		if _, err := tx.Exec(`INSERT INTO report_goals (goal_type, target) VALUES (?, ?)`, goalType, target); err != nil {
+			return fmt.Errorf("failed to insert report goal %s: %w", goalType, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package storage
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+)
+
+// builtinReportTemplates holds the HTML layouts of the built-in report templates, seeded
+// into report_templates by migration. The report service executes them with its TemplateData.
+//
+//go:embed templates/*.html.tmpl
+var builtinReportTemplates embed.FS
+
+// BuiltinReportTemplateHTML returns the built-in HTML template for a report type, or "" if
+// the report type has none.
+func BuiltinReportTemplateHTML(reportType string) string {
+	b, err := builtinReportTemplates.ReadFile("templates/" + reportType + ".html.tmpl")
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// SaveReportTemplate saves a new report template. It doesn't become the default.
+func (s *Store) SaveReportTemplate(t *ReportTemplate) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO report_templates (name, report_type, template_html, template_markdown, is_default)
+		VALUES (?, ?, ?, ?, 0)`, t.Name, t.ReportType, t.TemplateHTML, t.TemplateMarkdown)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert report template: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetReportTemplates returns all report templates ordered by report type and creation.
+func (s *Store) GetReportTemplates() ([]*ReportTemplate, error) {
+	rows, err := s.db.Query(`
+		SELECT id, name, report_type, template_html, template_markdown, is_default, COALESCE(created_at, 0)
+		FROM report_templates
+		ORDER BY report_type ASC, id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*ReportTemplate
+	for rows.Next() {
+		t := &ReportTemplate{}
+		if err := rows.Scan(&t.ID, &t.Name, &t.ReportType, &t.TemplateHTML, &t.TemplateMarkdown, &t.IsDefault, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan report template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	return templates, rows.Err()
+}
+
+// GetDefaultReportTemplate returns the default template for a report type, or nil if none is set.
+func (s *Store) GetDefaultReportTemplate(reportType string) (*ReportTemplate, error) {
+	t := &ReportTemplate{}
+	err := s.db.QueryRow(`
+		SELECT id, name, report_type, template_html, template_markdown, is_default, COALESCE(created_at, 0)
+		FROM report_templates
+		WHERE report_type = ? AND is_default = 1
+		ORDER BY id DESC LIMIT 1`, reportType).Scan(
+		&t.ID, &t.Name, &t.ReportType, &t.TemplateHTML, &t.TemplateMarkdown, &t.IsDefault, &t.CreatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get default report template: %w", err)
+	}
+	return t, nil
+}
+
+// SetDefaultReportTemplate makes a template the default for its report type,
+// clearing the previous default.
+func (s *Store) SetDefaultReportTemplate(id int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var reportType string
+	err = tx.QueryRow(`SELECT report_type FROM report_templates WHERE id = ?`, id).Scan(&reportType)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("report template %d not found", id)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get report template: %w", err)
+	}
+
+	if _, err := tx.Exec(`UPDATE report_templates SET is_default = (id = ?) WHERE report_type = ?`, id, reportType); err != nil {
+		return fmt.Errorf("failed to set default report template: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// DeleteReportTemplate removes a report template. Reports of a type without a
+// default template use the standard layout.
+func (s *Store) DeleteReportTemplate(id int64) error {
+	if _, err := s.db.Exec(`DELETE FROM report_templates WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete report template: %w", err)
+	}
+	return nil
+}
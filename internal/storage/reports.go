@@ -7,12 +7,17 @@ import (
 
 // SaveReport saves a report to the database.
 func (s *Store) SaveReport(report *Report) (int64, error) {
+	version := report.Version
+	if version < 1 {
+		version = 1
+	}
+
 	result, err := s.db.Exec(`
 		INSERT INTO reports (
-			title, time_range, report_type, format, content, filepath, start_time, end_time
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			title, time_range, report_type, format, content, filepath, start_time, end_time, parent_id, version
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		report.Title, report.TimeRange, report.ReportType, report.Format,
-		report.Content, report.Filepath, report.StartTime, report.EndTime,
+		report.Content, report.Filepath, report.StartTime, report.EndTime, report.ParentID, version,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert report: %w", err)
@@ -30,10 +35,12 @@ func (s *Store) SaveReport(report *Report) (int64, error) {
 func (s *Store) GetReport(id int64) (*Report, error) {
 	report := &Report{}
 	err := s.db.QueryRow(`
-		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at
+		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at,
+		       parent_id, version
 		FROM reports WHERE id = ?`, id).Scan(
 		&report.ID, &report.Title, &report.TimeRange, &report.ReportType, &report.Format,
 		&report.Content, &report.Filepath, &report.StartTime, &report.EndTime, &report.CreatedAt,
+		&report.ParentID, &report.Version,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -47,7 +54,8 @@ func (s *Store) GetReport(id int64) (*Report, error) {
 // GetAllReports retrieves all reports, most recent first.
 func (s *Store) GetAllReports() ([]*Report, error) {
 	rows, err := s.db.Query(`
-		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at
+		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at,
+		       parent_id, version
 		FROM reports
 		ORDER BY created_at DESC`)
 	if err != nil {
@@ -61,6 +69,7 @@ func (s *Store) GetAllReports() ([]*Report, error) {
 		err := rows.Scan(
 			&report.ID, &report.Title, &report.TimeRange, &report.ReportType, &report.Format,
 			&report.Content, &report.Filepath, &report.StartTime, &report.EndTime, &report.CreatedAt,
+			&report.ParentID, &report.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan report: %w", err)
@@ -73,7 +82,8 @@ func (s *Store) GetAllReports() ([]*Report, error) {
 // GetRecentReports retrieves the most recent N reports.
 func (s *Store) GetRecentReports(limit int) ([]*Report, error) {
 	rows, err := s.db.Query(`
-		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at
+		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at,
+		       parent_id, version
 		FROM reports
 		ORDER BY created_at DESC
 		LIMIT ?`, limit)
@@ -88,6 +98,83 @@ func (s *Store) GetRecentReports(limit int) ([]*Report, error) {
 		err := rows.Scan(
 			&report.ID, &report.Title, &report.TimeRange, &report.ReportType, &report.Format,
 			&report.Content, &report.Filepath, &report.StartTime, &report.EndTime, &report.CreatedAt,
+			&report.ParentID, &report.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// GetReportsCreatedBetween retrieves the reports created between start and end (inclusive), oldest first.
+func (s *Store) GetReportsCreatedBetween(start, end int64) ([]*Report, error) {
+	rows, err := s.db.Query(`
+		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at,
+		       parent_id, version
+		FROM reports
+		WHERE created_at BETWEEN ? AND ?
+		ORDER BY created_at ASC, id ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*Report
+	for rows.Next() {
+		report := &Report{}
+		err := rows.Scan(
+			&report.ID, &report.Title, &report.TimeRange, &report.ReportType, &report.Format,
+			&report.Content, &report.Filepath, &report.StartTime, &report.EndTime, &report.CreatedAt,
+			&report.ParentID, &report.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+// FindOriginalReport returns the original (version 1) report with the given title, type and time range,
+// or nil if none exists.
+func (s *Store) FindOriginalReport(title, reportType string, startTime, endTime int64) (*Report, error) {
+	var id int64
+	err := s.db.QueryRow(`
+		SELECT id FROM reports
+		WHERE title = ? AND report_type = ? AND start_time = ? AND end_time = ? AND parent_id IS NULL
+		ORDER BY id ASC
+		LIMIT 1`, title, reportType, startTime, endTime).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find original report: %w", err)
+	}
+	return s.GetReport(id)
+}
+
+// GetReportVersions retrieves an original report and all of its versions, oldest first.
+func (s *Store) GetReportVersions(originalID int64) ([]*Report, error) {
+	rows, err := s.db.Query(`
+		SELECT id, title, time_range, report_type, format, content, filepath, start_time, end_time, created_at,
+		       parent_id, version
+		FROM reports
+		WHERE id = ? OR parent_id = ?
+		ORDER BY version ASC, id ASC`, originalID, originalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report versions: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []*Report
+	for rows.Next() {
+		report := &Report{}
+		err := rows.Scan(
+			&report.ID, &report.Title, &report.TimeRange, &report.ReportType, &report.Format,
+			&report.Content, &report.Filepath, &report.StartTime, &report.EndTime, &report.CreatedAt,
+			&report.ParentID, &report.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan report: %w", err)
@@ -97,7 +184,19 @@ func (s *Store) GetRecentReports(limit int) ([]*Report, error) {
 	return reports, rows.Err()
 }
 
-// DeleteReport deletes a report by ID.
+// GetLatestReportVersion returns the highest version number of an original report and its versions.
+func (s *Store) GetLatestReportVersion(originalID int64) (int, error) {
+	var version int
+	err := s.db.QueryRow(`
+		SELECT COALESCE(MAX(version), 0) FROM reports
+		WHERE id = ? OR parent_id = ?`, originalID, originalID).Scan(&version)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest report version: %w", err)
+	}
+	return version, nil
+}
+
+// DeleteReport deletes a report by ID. Deleting an original report also deletes its versions.
 func (s *Store) DeleteReport(id int64) error {
 	_, err := s.db.Exec("DELETE FROM reports WHERE id = ?", id)
 	if err != nil {
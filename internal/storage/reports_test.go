@@ -156,3 +156,65 @@ func TestCountReports(t *testing.T) {
 		t.Errorf("expected 3, got %d", count)
 	}
 }
+
+func TestReportTemplates(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Migration seeds a built-in default for summary and standup
+	builtin, err := store.GetDefaultReportTemplate("summary")
+	if err != nil {
+		t.Fatalf("GetDefaultReportTemplate failed: %v", err)
+	}
+	if builtin == nil || builtin.TemplateHTML != BuiltinReportTemplateHTML("summary") {
+		t.Fatalf("expected built-in summary template, got %+v", builtin)
+	}
+	if tmpl, _ := store.GetDefaultReportTemplate("standup"); tmpl == nil || tmpl.TemplateHTML != BuiltinReportTemplateHTML("standup") {
+		t.Errorf("expected built-in standup template, got %+v", tmpl)
+	}
+
+	id, err := store.SaveReportTemplate(&ReportTemplate{
+		Name:         "Compact",
+		ReportType:   "summary",
+		TemplateHTML: "<p>{{.TotalMinutes}}</p>",
+	})
+	if err != nil {
+		t.Fatalf("SaveReportTemplate failed: %v", err)
+	}
+	if tmpl, _ := store.GetDefaultReportTemplate("summary"); tmpl.ID != builtin.ID {
+		t.Error("expected a saved template not to become the default")
+	}
+
+	if err := store.SetDefaultReportTemplate(id); err != nil {
+		t.Fatalf("SetDefaultReportTemplate failed: %v", err)
+	}
+	tmpl, _ := store.GetDefaultReportTemplate("summary")
+	if tmpl == nil || tmpl.ID != id {
+		t.Fatalf("expected template %d to be the default, got %+v", id, tmpl)
+	}
+
+	templates, err := store.GetReportTemplates()
+	if err != nil {
+		t.Fatalf("GetReportTemplates failed: %v", err)
+	}
+	defaults := 0
+	for _, tmpl := range templates {
+		if tmpl.ReportType == "summary" && tmpl.IsDefault {
+			defaults++
+		}
+	}
+	if len(templates) != 3 || defaults != 1 {
+		t.Errorf("expected 3 templates with one summary default, got %d templates, %d defaults", len(templates), defaults)
+	}
+
+	if err := store.SetDefaultReportTemplate(9999); err == nil {
+		t.Error("expected error for unknown template")
+	}
+
+	if err := store.DeleteReportTemplate(id); err != nil {
+		t.Fatalf("DeleteReportTemplate failed: %v", err)
+	}
+	if tmpl, _ := store.GetDefaultReportTemplate("summary"); tmpl != nil {
+		t.Errorf("expected no summary default after deleting it, got %+v", tmpl)
+	}
+}
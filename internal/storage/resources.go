@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// SaveResourceSnapshot records a resource usage snapshot.
+func (s *Store) SaveResourceSnapshot(snap *ResourceSnapshot) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO resource_snapshots (
+			timestamp, cpu_percent, memory_mb, db_size_mb,
+			screenshot_dir_size_mb, open_fds, goroutines
+		) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		snap.Timestamp, snap.CPUPercent, snap.MemoryMB, snap.DBSizeMB,
+		snap.ScreenshotDirSizeMB, snap.OpenFileDescriptors, snap.GoRoutineCount,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert resource snapshot: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetResourceSnapshots returns snapshots taken in [start, end], oldest first.
+func (s *Store) GetResourceSnapshots(start, end int64) ([]*ResourceSnapshot, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, cpu_percent, memory_mb, db_size_mb,
+		       screenshot_dir_size_mb, open_fds, goroutines
+		FROM resource_snapshots
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query resource snapshots: %w", err)
+	}
+	defer rows.Close()
+
+	var snapshots []*ResourceSnapshot
+	for rows.Next() {
+		snap := &ResourceSnapshot{}
+		if err := rows.Scan(&snap.ID, &snap.Timestamp, &snap.CPUPercent, &snap.MemoryMB, &snap.DBSizeMB,
+			&snap.ScreenshotDirSizeMB, &snap.OpenFileDescriptors, &snap.GoRoutineCount); err != nil {
+			return nil, fmt.Errorf("failed to scan resource snapshot: %w", err)
+		}
+		snapshots = append(snapshots, snap)
+	}
+	return snapshots, rows.Err()
+}
+
+// DeleteResourceSnapshotsBefore removes snapshots older than a timestamp.
+// Returns the number of snapshots deleted.
+func (s *Store) DeleteResourceSnapshotsBefore(timestamp int64) (int64, error) {
+	result, err := s.db.Exec(`DELETE FROM resource_snapshots WHERE timestamp < ?`, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete resource snapshots: %w", err)
+	}
+	return result.RowsAffected()
+}
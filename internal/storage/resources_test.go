@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"testing"
+)
+
+func TestResourceSnapshots(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	for i, ts := range []int64{1000, 2000, 3000} {
+		_, err := store.SaveResourceSnapshot(&ResourceSnapshot{
+			Timestamp:           ts,
+			CPUPercent:          float64(i) + 0.5,
+			MemoryMB:            64,
+			DBSizeMB:            12.5,
+			ScreenshotDirSizeMB: 300,
+			OpenFileDescriptors: 20 + i,
+			GoRoutineCount:      40,
+		})
+		if err != nil {
+			t.Fatalf("SaveResourceSnapshot failed: %v", err)
+		}
+	}
+
+	snapshots, err := store.GetResourceSnapshots(1500, 3000)
+	if err != nil {
+		t.Fatalf("GetResourceSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 2 {
+		t.Fatalf("expected 2 snapshots, got %d", len(snapshots))
+	}
+	if snapshots[0].Timestamp != 2000 || snapshots[0].CPUPercent != 1.5 || snapshots[0].OpenFileDescriptors != 21 {
+		t.Errorf("unexpected first snapshot: %+v", snapshots[0])
+	}
+
+	deleted, err := store.DeleteResourceSnapshotsBefore(2500)
+	if err != nil {
+		t.Fatalf("DeleteResourceSnapshotsBefore failed: %v", err)
+	}
+	if deleted != 2 {
+		t.Errorf("expected 2 snapshots deleted, got %d", deleted)
+	}
+}
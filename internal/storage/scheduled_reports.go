@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// CreateScheduledReport saves a new report schedule.
+func (s *Store) CreateScheduledReport(schedule *ScheduledReport) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO scheduled_reports (report_type, time_range, cron_expression, recipients, format)
+		VALUES (?, ?, ?, ?, ?)`,
+		schedule.ReportType, schedule.TimeRange, schedule.CronExpression, ToJSONString(schedule.Recipients), schedule.Format,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert scheduled report: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetScheduledReports retrieves all report schedules, oldest first.
+func (s *Store) GetScheduledReports() ([]*ScheduledReport, error) {
+	rows, err := s.db.Query(`
+		SELECT id, report_type, time_range, cron_expression, recipients, format, last_run_at, created_at
+		FROM scheduled_reports
+		ORDER BY id ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query scheduled reports: %w", err)
+	}
+	defer rows.Close()
+
+	var schedules []*ScheduledReport
+	for rows.Next() {
+		schedule := &ScheduledReport{}
+		var recipients sql.NullString
+		if err := rows.Scan(
+			&schedule.ID, &schedule.ReportType, &schedule.TimeRange, &schedule.CronExpression,
+			&recipients, &schedule.Format, &schedule.LastRunAt, &schedule.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan scheduled report: %w", err)
+		}
+		schedule.Recipients = ParseJSONStringArray(recipients)
+		schedules = append(schedules, schedule)
+	}
+	return schedules, rows.Err()
+}
+
+// DeleteScheduledReport deletes a report schedule.
+func (s *Store) DeleteScheduledReport(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM scheduled_reports WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete scheduled report: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("scheduled report not found: %d", id)
+	}
+	return nil
+}
+
+// SetScheduledReportLastRun records when a report schedule last fired.
+func (s *Store) SetScheduledReportLastRun(id, lastRunAt int64) error {
+	if _, err := s.db.Exec(`UPDATE scheduled_reports SET last_run_at = ? WHERE id = ?`, lastRunAt, id); err != nil {
+		return fmt.Errorf("failed to update scheduled report: %w", err)
+	}
+	return nil
+}
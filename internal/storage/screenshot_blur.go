@@ -0,0 +1,49 @@
+package storage
+
+import "fmt"
+
+// SaveScreenshotBlurRegions records the areas of a screenshot that were blurred.
+func (s *Store) SaveScreenshotBlurRegions(screenshotID int64, regions []*ScreenshotBlurRegion) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, r := range regions {
+		_, err := tx.Exec(`
+			INSERT INTO screenshot_blur_regions (screenshot_id, x, y, width, height)
+			VALUES (?, ?, ?, ?, ?)`, screenshotID, r.X, r.Y, r.Width, r.Height)
+		if err != nil {
+			return fmt.Errorf("failed to insert screenshot blur region: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// GetScreenshotBlurRegions returns the blurred areas of a screenshot, oldest first.
+func (s *Store) GetScreenshotBlurRegions(screenshotID int64) ([]*ScreenshotBlurRegion, error) {
+	rows, err := s.db.Query(`
+		SELECT id, screenshot_id, x, y, width, height, blurred_at
+		FROM screenshot_blur_regions
+		WHERE screenshot_id = ?
+		ORDER BY id ASC`, screenshotID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screenshot blur regions: %w", err)
+	}
+	defer rows.Close()
+
+	var regions []*ScreenshotBlurRegion
+	for rows.Next() {
+		r := &ScreenshotBlurRegion{}
+		if err := rows.Scan(&r.ID, &r.ScreenshotID, &r.X, &r.Y, &r.Width, &r.Height, &r.BlurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan screenshot blur region: %w", err)
+		}
+		regions = append(regions, r)
+	}
+	return regions, rows.Err()
+}
@@ -1,9 +1,11 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 )
 
 // SaveScreenshot saves a screenshot to the database.
@@ -12,11 +14,11 @@ func (s *Store) SaveScreenshot(sc *Screenshot) (int64, error) {
 		INSERT INTO screenshots (
 			timestamp, filepath, dhash, window_title, app_name, window_class, process_pid,
 			window_x, window_y, window_width, window_height,
-			monitor_name, monitor_width, monitor_height, session_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			monitor_name, monitor_width, monitor_height, monitor_index, session_id, quality
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		sc.Timestamp, sc.Filepath, sc.DHash, sc.WindowTitle, sc.AppName, sc.WindowClass, sc.ProcessPID,
 		sc.WindowX, sc.WindowY, sc.WindowWidth, sc.WindowHeight,
-		sc.MonitorName, sc.MonitorWidth, sc.MonitorHeight, sc.SessionID,
+		sc.MonitorName, sc.MonitorWidth, sc.MonitorHeight, sc.MonitorIndex, sc.SessionID, sc.Quality,
 	)
 	if err != nil {
 		return 0, fmt.Errorf("failed to insert screenshot: %w", err)
@@ -47,11 +49,11 @@ func (s *Store) GetScreenshot(id int64) (*Screenshot, error) {
 	err := s.db.QueryRow(`
 		SELECT id, timestamp, filepath, dhash, window_title, app_name, window_class, process_pid,
 		       window_x, window_y, window_width, window_height,
-		       monitor_name, monitor_width, monitor_height, session_id, created_at
+		       monitor_name, monitor_width, monitor_height, monitor_index, session_id, created_at, quality
 		FROM screenshots WHERE id = ?`, id).Scan(
 		&sc.ID, &sc.Timestamp, &sc.Filepath, &sc.DHash, &sc.WindowTitle, &sc.AppName, &sc.WindowClass, &sc.ProcessPID,
 		&sc.WindowX, &sc.WindowY, &sc.WindowWidth, &sc.WindowHeight,
-		&sc.MonitorName, &sc.MonitorWidth, &sc.MonitorHeight, &sc.SessionID, &sc.CreatedAt,
+		&sc.MonitorName, &sc.MonitorWidth, &sc.MonitorHeight, &sc.MonitorIndex, &sc.SessionID, &sc.CreatedAt, &sc.Quality,
 	)
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -62,15 +64,49 @@ func (s *Store) GetScreenshot(id int64) (*Screenshot, error) {
 	return sc, nil
 }
 
+// GetNearestScreenshot retrieves the screenshot closest to a timestamp, at most maxDistance seconds away.
+// Returns nil if there is none.
+func (s *Store) GetNearestScreenshot(timestamp, maxDistance int64) (*Screenshot, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, filepath, dhash, window_title, app_name, window_class, process_pid,
+		       window_x, window_y, window_width, window_height,
+		       monitor_name, monitor_width, monitor_height, monitor_index, session_id, created_at, quality
+		FROM screenshots
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY ABS(timestamp - ?) ASC, monitor_index ASC
+		LIMIT 1`, timestamp-maxDistance, timestamp+maxDistance, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nearest screenshot: %w", err)
+	}
+	defer rows.Close()
+
+	screenshots, err := scanScreenshots(rows)
+	if err != nil || len(screenshots) == 0 {
+		return nil, err
+	}
+	return screenshots[0], nil
+}
+
 // GetScreenshots retrieves screenshots within a time range.
 func (s *Store) GetScreenshots(start, end int64) ([]*Screenshot, error) {
-	rows, err := s.db.Query(`
+	var screenshots []*Screenshot
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		screenshots, err = queryScreenshotsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return screenshots, err
+}
+
+// queryScreenshotsByTimeRange runs the GetScreenshots query on db.
+func queryScreenshotsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*Screenshot, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, timestamp, filepath, dhash, window_title, app_name, window_class, process_pid,
 		       window_x, window_y, window_width, window_height,
-		       monitor_name, monitor_width, monitor_height, session_id, created_at
+		       monitor_name, monitor_width, monitor_height, monitor_index, session_id, created_at, quality
 		FROM screenshots
 		WHERE timestamp >= ? AND timestamp <= ?
-		ORDER BY timestamp ASC`, start, end)
+		ORDER BY timestamp ASC, monitor_index ASC`, start, end)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query screenshots: %w", err)
 	}
@@ -84,10 +120,10 @@ func (s *Store) GetScreenshotsBySession(sessionID int64) ([]*Screenshot, error)
 	rows, err := s.db.Query(`
 		SELECT id, timestamp, filepath, dhash, window_title, app_name, window_class, process_pid,
 		       window_x, window_y, window_width, window_height,
-		       monitor_name, monitor_width, monitor_height, session_id, created_at
+		       monitor_name, monitor_width, monitor_height, monitor_index, session_id, created_at, quality
 		FROM screenshots
 		WHERE session_id = ?
-		ORDER BY timestamp ASC`, sessionID)
+		ORDER BY timestamp ASC, monitor_index ASC`, sessionID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query screenshots by session: %w", err)
 	}
@@ -126,6 +162,96 @@ func (s *Store) CountScreenshotsByTimeRange(start, end int64) (int64, error) {
 	return count, err
 }
 
+// CountScreenshotsForHour returns the number of screenshots taken in one local hour (0-23)
+// of a date (YYYY-MM-DD).
+func (s *Store) CountScreenshotsForHour(date string, hour int) (int64, error) {
+	day, err := time.ParseInLocation("2006-01-02", date, time.Local)
+	if err != nil {
+		return 0, fmt.Errorf("invalid date format: %w", err)
+	}
+	if hour < 0 || hour > 23 {
+		return 0, fmt.Errorf("invalid hour: %d", hour)
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), hour, 0, 0, 0, time.Local)
+	return s.CountScreenshotsByTimeRange(start.Unix(), start.Add(time.Hour).Unix()-1)
+}
+
+// CountScreenshotsByDay returns the number of screenshots per local date ("2006-01-02") in a time range.
+func (s *Store) CountScreenshotsByDay(start, end int64) (map[string]int64, error) {
+	rows, err := s.db.Query(`
+		SELECT date(timestamp, 'unixepoch', 'localtime') as day, COUNT(*)
+		FROM screenshots
+		WHERE timestamp >= ? AND timestamp <= ?
+		GROUP BY day`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screenshots by day: %w", err)
+	}
+	defer rows.Close()
+
+	days := make(map[string]int64)
+	for rows.Next() {
+		var day string
+		var count int64
+		if err := rows.Scan(&day, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan screenshots by day: %w", err)
+		}
+		days[day] = count
+	}
+	return days, rows.Err()
+}
+
+// QualityStats summarizes the quality screenshots were encoded with over a time range.
+type QualityStats struct {
+	ScreenshotCount int              `json:"screenshotCount"` // Screenshots with a recorded quality
+	AverageQuality  float64          `json:"averageQuality"`
+	MinQuality      int              `json:"minQuality"`
+	MaxQuality      int              `json:"maxQuality"`
+	Hourly          []*QualityBucket `json:"hourly"` // Hours with screenshots, oldest first
+}
+
+// QualityBucket is the average screenshot quality in one hour.
+type QualityBucket struct {
+	Hour            int64   `json:"hour"` // Unix timestamp of the start of the hour
+	AverageQuality  float64 `json:"averageQuality"`
+	ScreenshotCount int     `json:"screenshotCount"`
+}
+
+// GetScreenshotQualityStats returns the quality of screenshots in a time range, hour by
+// hour. Screenshots taken before quality was recorded are skipped.
+func (s *Store) GetScreenshotQualityStats(start, end int64) (*QualityStats, error) {
+	rows, err := s.db.Query(`
+		SELECT (timestamp / 3600) * 3600 AS hour, AVG(quality), COUNT(*), MIN(quality), MAX(quality)
+		FROM screenshots
+		WHERE timestamp >= ? AND timestamp <= ? AND quality > 0
+		GROUP BY hour
+		ORDER BY hour ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query screenshot quality: %w", err)
+	}
+	defer rows.Close()
+
+	stats := &QualityStats{Hourly: []*QualityBucket{}}
+	var total float64
+	for rows.Next() {
+		b := &QualityBucket{}
+		var minQuality, maxQuality int
+		if err := rows.Scan(&b.Hour, &b.AverageQuality, &b.ScreenshotCount, &minQuality, &maxQuality); err != nil {
+			return nil, fmt.Errorf("failed to scan screenshot quality: %w", err)
+		}
+		if stats.ScreenshotCount == 0 || minQuality < stats.MinQuality {
+			stats.MinQuality = minQuality
+		}
+		stats.MaxQuality = max(stats.MaxQuality, maxQuality)
+		stats.ScreenshotCount += b.ScreenshotCount
+		total += b.AverageQuality * float64(b.ScreenshotCount)
+		stats.Hourly = append(stats.Hourly, b)
+	}
+	if stats.ScreenshotCount > 0 {
+		stats.AverageQuality = total / float64(stats.ScreenshotCount)
+	}
+	return stats, rows.Err()
+}
+
 func scanScreenshots(rows *sql.Rows) ([]*Screenshot, error) {
 	var screenshots []*Screenshot
 	for rows.Next() {
@@ -133,7 +259,7 @@ func scanScreenshots(rows *sql.Rows) ([]*Screenshot, error) {
 		err := rows.Scan(
 			&sc.ID, &sc.Timestamp, &sc.Filepath, &sc.DHash, &sc.WindowTitle, &sc.AppName, &sc.WindowClass, &sc.ProcessPID,
 			&sc.WindowX, &sc.WindowY, &sc.WindowWidth, &sc.WindowHeight,
-			&sc.MonitorName, &sc.MonitorWidth, &sc.MonitorHeight, &sc.SessionID, &sc.CreatedAt,
+			&sc.MonitorName, &sc.MonitorWidth, &sc.MonitorHeight, &sc.MonitorIndex, &sc.SessionID, &sc.CreatedAt, &sc.Quality,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan screenshot: %w", err)
@@ -133,6 +133,38 @@ func TestGetScreenshots(t *testing.T) {
 	}
 }
 
+func TestGetScreenshots_MultipleMonitors(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+
+	// One capture tick across three monitors, saved out of order
+	for _, idx := range []int{2, 0, 1} {
+		sc := &Screenshot{
+			Timestamp:    now,
+			Filepath:     "/test/screenshot.webp",
+			MonitorIndex: idx,
+		}
+		if _, err := store.SaveScreenshot(sc); err != nil {
+			t.Fatalf("failed to save screenshot for monitor %d: %v", idx, err)
+		}
+	}
+
+	screenshots, err := store.GetScreenshots(now, now)
+	if err != nil {
+		t.Fatalf("failed to get screenshots: %v", err)
+	}
+	if len(screenshots) != 3 {
+		t.Fatalf("got %d screenshots, want 3", len(screenshots))
+	}
+	for i, sc := range screenshots {
+		if sc.MonitorIndex != i {
+			t.Errorf("screenshot %d: got MonitorIndex=%d, want %d", i, sc.MonitorIndex, i)
+		}
+	}
+}
+
 func TestGetScreenshotsBySession(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -261,3 +293,58 @@ func TestCountScreenshotsByTimeRange(t *testing.T) {
 		t.Errorf("expected 3, got %d", count)
 	}
 }
+
+func TestCountScreenshotsForHour(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	hour := time.Date(2026, time.March, 10, 14, 0, 0, 0, time.Local)
+	for _, ts := range []time.Time{hour, hour.Add(59 * time.Minute), hour.Add(time.Hour), hour.Add(-time.Second)} {
+		store.SaveScreenshot(&Screenshot{Timestamp: ts.Unix(), Filepath: "/test/s.webp", DHash: "d:1"})
+	}
+
+	count, err := store.CountScreenshotsForHour("2026-03-10", 14)
+	if err != nil {
+		t.Fatalf("CountScreenshotsForHour failed: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("expected 2 screenshots in the hour, got %d", count)
+	}
+	if _, err := store.CountScreenshotsForHour("2026-03-10", 24); err == nil {
+		t.Error("expected error for invalid hour")
+	}
+}
+
+func TestGetScreenshotQualityStats(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	hour := time.Date(2026, time.March, 10, 14, 0, 0, 0, time.UTC).Unix()
+	for _, sc := range []struct {
+		offset  int64
+		quality int
+	}{
+		{0, 80}, {600, 60}, {3600, 40}, {3700, 0}, // Quality 0 predates recording
+	} {
+		store.SaveScreenshot(&Screenshot{Timestamp: hour + sc.offset, Filepath: "/test/s.webp", Quality: sc.quality})
+	}
+
+	stats, err := store.GetScreenshotQualityStats(hour, hour+7200)
+	if err != nil {
+		t.Fatalf("GetScreenshotQualityStats failed: %v", err)
+	}
+	if stats.ScreenshotCount != 3 || stats.AverageQuality != 60 || stats.MinQuality != 40 || stats.MaxQuality != 80 {
+		t.Errorf("stats = %+v, want 3 screenshots averaging 60 (40-80)", stats)
+	}
+	if len(stats.Hourly) != 2 {
+		t.Fatalf("expected 2 hourly buckets, got %d", len(stats.Hourly))
+	}
+	if b := stats.Hourly[0]; b.Hour != hour || b.AverageQuality != 70 || b.ScreenshotCount != 2 {
+		t.Errorf("first hour = %+v, want 2 screenshots averaging 70", b)
+	}
+
+	sc, _ := store.GetScreenshots(hour, hour)
+	if len(sc) != 1 || sc[0].Quality != 80 {
+		t.Errorf("expected saved quality to be read back, got %+v", sc)
+	}
+}
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 // CreateSession creates a new session with the given start time.
@@ -100,6 +102,22 @@ func (s *Store) GetCurrentSession() (*Session, error) {
 	return sess, nil
 }
 
+// GetOpenSessions retrieves sessions that haven't ended, most recently started first.
+// Normally there is at most one; more are left behind if the app crashes.
+func (s *Store) GetOpenSessions() ([]*Session, error) {
+	rows, err := s.db.Query(`
+		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
+		FROM sessions
+		WHERE end_time IS NULL
+		ORDER BY start_time DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open sessions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
+
 // GetSessionsForDate retrieves all sessions for a specific date.
 func (s *Store) GetSessionsForDate(year, month, day int) ([]*Session, error) {
 	dateStr := fmt.Sprintf("%04d-%02d-%02d", year, month, day)
@@ -132,6 +150,27 @@ func (s *Store) GetRecentSessions(limit int) ([]*Session, error) {
 	return scanSessions(rows)
 }
 
+// GetSessionsWithoutSummary retrieves ended sessions that have no summary, most recent first.
+// A limit of zero or less returns all of them.
+func (s *Store) GetSessionsWithoutSummary(limit int) ([]*Session, error) {
+	if limit <= 0 {
+		limit = -1 // SQLite treats a negative LIMIT as no limit
+	}
+	rows, err := s.db.Query(`
+		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
+		FROM sessions
+		WHERE end_time IS NOT NULL
+		  AND NOT EXISTS (SELECT 1 FROM summaries WHERE summaries.session_id = sessions.id)
+		ORDER BY start_time DESC
+		LIMIT ?`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions without summary: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
+
 // GetLastEndedSession returns the most recently ended session.
 func (s *Store) GetLastEndedSession() (*Session, error) {
 	sess := &Session{}
@@ -171,8 +210,243 @@ func (s *Store) DeleteSession(id int64) error {
 	}
 	defer tx.Rollback()
 
+	if err := deleteSessionTx(tx, id); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteShortSessions deletes ended sessions shorter than minDurationSeconds that recorded
+// nothing but focus events, along with those focus events. Sessions holding any other data
+// are kept. Returns the number of sessions deleted.
+func (s *Store) DeleteShortSessions(minDurationSeconds int64) (int64, error) {
+	if minDurationSeconds <= 0 {
+		return 0, nil
+	}
+
+	rows, err := s.db.Query(`
+		SELECT id FROM sessions
+		WHERE end_time IS NOT NULL AND duration_seconds IS NOT NULL AND duration_seconds < ?`,
+		minDurationSeconds)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query short sessions: %w", err)
+	}
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan session id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var deleted int64
+	for _, id := range ids {
+		ok, err := deleteEmptySessionTx(tx, id)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			deleted++
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteEmptySession deletes a session and its focus events if it recorded nothing else.
+// Returns true if the session was deleted.
+func (s *Store) DeleteEmptySession(id int64) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	deleted, err := deleteEmptySessionTx(tx, id)
+	if err != nil || !deleted {
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return true, nil
+}
+
+// sessionDataTables are the tables, besides window_focus_events, whose rows make a session
+// worth keeping regardless of its length.
+var sessionDataTables = []string{
+	"summaries",
+	"screenshots",
+	"afk_events",
+	"shell_commands",
+	"git_commits",
+	"file_events",
+	"browser_history",
+	"issue_reports",
+	"clipboard_events",
+	"vscode_language_events",
+	"network_events",
+	"audio_events",
+	"task_thread_sessions",
+}
+
+// deleteEmptySessionTx deletes a session and its focus events within tx, unless any other
+// data refers to it. Returns true if the session was deleted.
+func deleteEmptySessionTx(tx *sql.Tx, id int64) (bool, error) {
+	for _, table := range sessionDataTables {
+		var hasData bool
+		if err := tx.QueryRow(fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE session_id = ?)", table), id).Scan(&hasData); err != nil {
+			return false, fmt.Errorf("failed to check %s: %w", table, err)
+		}
+		if hasData {
+			return false, nil
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM window_focus_events WHERE session_id = ?", id); err != nil {
+		return false, fmt.Errorf("failed to delete from window_focus_events: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM sessions WHERE id = ?", id); err != nil {
+		return false, fmt.Errorf("failed to delete session: %w", err)
+	}
+	return true, nil
+}
+
+// sessionEventTables are the tables whose rows belong to a session through session_id and
+// move with it when sessions are merged.
+var sessionEventTables = []string{
+	"window_focus_events",
+	"afk_events",
+	"shell_commands",
+	"git_commits",
+	"file_events",
+	"browser_history",
+	"issue_reports",
+	"clipboard_events",
+	"vscode_language_events",
+	"network_events",
+	"audio_events",
+	"screenshots",
+}
+
+// MergeSessions merges secondaryIDs into primaryID in a single transaction. The primary
+// session is widened to span the earliest start and latest end of all the sessions, their
+// events, screenshots and task threads are reassigned to it, and the secondary sessions
+// (with their now-stale summaries) are deleted. If any of the sessions is still active, the
+// merged session stays active.
+func (s *Store) MergeSessions(primaryID int64, secondaryIDs []int64) error {
+	if len(secondaryIDs) == 0 {
+		return fmt.Errorf("no sessions to merge")
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var startTime int64
+	var endTime sql.NullInt64
+	if err := tx.QueryRow(`SELECT start_time, end_time FROM sessions WHERE id = ?`, primaryID).Scan(&startTime, &endTime); err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("session not found: %d", primaryID)
+		}
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+	active := !endTime.Valid
+
+	seen := map[int64]bool{primaryID: true}
+	for _, id := range secondaryIDs {
+		if seen[id] {
+			return fmt.Errorf("session %d listed more than once", id)
+		}
+		seen[id] = true
+
+		var start int64
+		var end sql.NullInt64
+		if err := tx.QueryRow(`SELECT start_time, end_time FROM sessions WHERE id = ?`, id).Scan(&start, &end); err != nil {
+			if err == sql.ErrNoRows {
+				return fmt.Errorf("session not found: %d", id)
+			}
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		startTime = min(startTime, start)
+		if !end.Valid {
+			active = true
+		} else if end.Int64 > endTime.Int64 {
+			endTime = end
+		}
+	}
+
+	for _, id := range secondaryIDs {
+		for _, table := range sessionEventTables {
+			if _, err := tx.Exec(fmt.Sprintf("UPDATE %s SET session_id = ? WHERE session_id = ?", table), primaryID, id); err != nil {
+				return fmt.Errorf("failed to reassign %s: %w", table, err)
+			}
+		}
+		if _, err := tx.Exec(`
+			INSERT OR IGNORE INTO task_thread_sessions (thread_id, session_id)
+			SELECT thread_id, ? FROM task_thread_sessions WHERE session_id = ?`, primaryID, id); err != nil {
+			return fmt.Errorf("failed to reassign task threads: %w", err)
+		}
+		if err := deleteSessionTx(tx, id); err != nil {
+			return err
+		}
+	}
+
+	if active {
+		_, err = tx.Exec(`
+			UPDATE sessions
+			SET start_time = ?, end_time = NULL, duration_seconds = NULL,
+			    screenshot_count = (SELECT COUNT(*) FROM screenshots WHERE session_id = ?)
+			WHERE id = ?`, startTime, primaryID, primaryID)
+	} else {
+		_, err = tx.Exec(`
+			UPDATE sessions
+			SET start_time = ?, end_time = ?, duration_seconds = ?,
+			    screenshot_count = (SELECT COUNT(*) FROM screenshots WHERE session_id = ?)
+			WHERE id = ?`, startTime, endTime.Int64, endTime.Int64-startTime, primaryID, primaryID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update merged session: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// deleteSessionTx deletes a session and its related data within tx.
+func deleteSessionTx(tx *sql.Tx, id int64) error {
 	// Clear the session's summary_id reference first (sessions.summary_id -> summaries.id)
-	_, err = tx.Exec("UPDATE sessions SET summary_id = NULL WHERE id = ?", id)
+	_, err := tx.Exec("UPDATE sessions SET summary_id = NULL WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("failed to clear session summary reference: %w", err)
 	}
@@ -187,6 +461,11 @@ func (s *Store) DeleteSession(id int64) error {
 		"file_events",
 		"browser_history",
 		"issue_reports",
+		"clipboard_events",
+		"vscode_language_events",
+		"network_events",
+		"audio_events",
+		"task_thread_sessions",
 		"screenshots",
 	}
 
@@ -203,10 +482,6 @@ func (s *Store) DeleteSession(id int64) error {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
 
-	if err = tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
-	}
-
 	return nil
 }
 
@@ -217,6 +492,14 @@ func (s *Store) CountSessions() (int64, error) {
 	return count, err
 }
 
+// CountTrackedDays returns the number of distinct local dates on which a session started.
+func (s *Store) CountTrackedDays() (int64, error) {
+	var count int64
+	err := s.db.QueryRow(`
+		SELECT COUNT(DISTINCT date(start_time, 'unixepoch', 'localtime')) FROM sessions`).Scan(&count)
+	return count, err
+}
+
 // CloseOrphanedSessions closes any sessions that have no end_time and started more than
 // maxAgeSeconds ago. This handles zombie sessions from crashes or multiple instances.
 // Returns the number of sessions closed.
@@ -264,7 +547,18 @@ func (s *Store) GetTotalActiveTime() (int64, error) {
 // A session overlaps if it starts at or before the range ends AND (ends after the range starts OR is ongoing).
 // This correctly handles sessions that span midnight boundaries.
 func (s *Store) GetSessionsByTimeRange(start, end int64) ([]*Session, error) {
-	rows, err := s.db.Query(`
+	var sessions []*Session
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		sessions, err = querySessionsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return sessions, err
+}
+
+// querySessionsByTimeRange runs the GetSessionsByTimeRange query on db.
+func querySessionsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*Session, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
 		FROM sessions
 		WHERE start_time <= ? AND (end_time IS NULL OR end_time > ?)
@@ -277,6 +571,145 @@ func (s *Store) GetSessionsByTimeRange(start, end int64) ([]*Session, error) {
 	return scanSessions(rows)
 }
 
+// GetSessionsByTimeRanges retrieves the sessions overlapping each [start, end] range with a single
+// query over the combined span, partitioned per range in memory. Every requested range is present
+// in the result, with a nil slice if no sessions overlap it. Sessions are ordered by start time.
+func (s *Store) GetSessionsByTimeRanges(ranges [][2]int64) (map[[2]int64][]*Session, error) {
+	result := make(map[[2]int64][]*Session, len(ranges))
+	if len(ranges) == 0 {
+		return result, nil
+	}
+
+	minStart, maxEnd := ranges[0][0], ranges[0][1]
+	for _, r := range ranges {
+		result[r] = nil
+		minStart = min(minStart, r[0])
+		maxEnd = max(maxEnd, r[1])
+	}
+
+	sessions, err := s.GetSessionsByTimeRange(minStart, maxEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same overlap test as GetSessionsByTimeRange, applied per range
+	for r := range result {
+		for _, sess := range sessions {
+			if sess.StartTime <= r[1] && (!sess.EndTime.Valid || sess.EndTime.Int64 > r[0]) {
+				result[r] = append(result[r], sess)
+			}
+		}
+	}
+	return result, nil
+}
+
+// GetSessionsByTag retrieves the sessions overlapping [start, end] whose summary has the given tag.
+func (s *Store) GetSessionsByTag(tag string, start, end int64) ([]*Session, error) {
+	return s.GetSessionsByTags([]string{tag}, "any", start, end)
+}
+
+// GetSessionsByTags retrieves the sessions overlapping [start, end] whose summary tags match.
+// operator is "any" to match sessions with at least one of the tags, or "all" to require every tag.
+// Tags are read from the summaries.tags JSON array with json_each; sessions are ordered by start time.
+func (s *Store) GetSessionsByTags(tags []string, operator string, start, end int64) ([]*Session, error) {
+	if operator != "any" && operator != "all" {
+		return nil, fmt.Errorf("invalid tag operator %q: must be \"any\" or \"all\"", operator)
+	}
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	// Deduplicate so "all" compares against the number of distinct tags
+	seen := make(map[string]bool, len(tags))
+	args := []interface{}{end, start}
+	for _, tag := range tags {
+		if !seen[tag] {
+			seen[tag] = true
+			args = append(args, tag)
+		}
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(seen)), ",")
+
+	having := "COUNT(DISTINCT tag.value) >= 1"
+	if operator == "all" {
+		having = "COUNT(DISTINCT tag.value) = ?"
+		args = append(args, len(seen))
+	}
+
+	rows, err := s.db.Query(fmt.Sprintf(`
+		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
+		FROM sessions
+		WHERE start_time <= ? AND (end_time IS NULL OR end_time > ?)
+		  AND id IN (
+			SELECT sm.session_id
+			FROM summaries sm,
+			     json_each(CASE WHEN json_valid(sm.tags) THEN sm.tags ELSE '[]' END) tag
+			WHERE tag.value IN (%s)
+			GROUP BY sm.session_id
+			HAVING %s)
+		ORDER BY start_time ASC`, placeholders, having), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions by tags: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
+
+// GetSessionsByFocusPattern retrieves the sessions overlapping [start, end] with at least one
+// focus event in appName whose window title matches windowPattern (SQLite LIKE, case-insensitive).
+// An empty appName matches any app; a windowPattern without wildcards matches anywhere in the title.
+func (s *Store) GetSessionsByFocusPattern(appName, windowPattern string, start, end int64) ([]*Session, error) {
+	if appName == "" {
+		appName = "%"
+	}
+	rows, err := s.db.Query(`
+		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
+		FROM sessions
+		WHERE start_time <= ? AND (end_time IS NULL OR end_time > ?)
+		  AND EXISTS (
+			SELECT 1 FROM window_focus_events f
+			WHERE f.session_id = sessions.id
+			  AND f.app_name LIKE ?
+			  AND f.window_title LIKE ?)
+		ORDER BY start_time ASC`, end, start, appName, windowTitleLikePattern(windowPattern))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions by focus pattern: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
+
+// GetSessionsContaining retrieves the sessions overlapping [start, end] in which query appears,
+// case-insensitively, in a focused window title, a git commit message or a shell command.
+func (s *Store) GetSessionsContaining(query string, start, end int64) ([]*Session, error) {
+	if query == "" {
+		return nil, nil
+	}
+	// Match query literally, not as a LIKE pattern
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+
+	rows, err := s.db.Query(`
+		SELECT id, start_time, end_time, duration_seconds, screenshot_count, summary_id, created_at
+		FROM sessions
+		WHERE start_time <= ? AND (end_time IS NULL OR end_time > ?)
+		  AND (
+			EXISTS (SELECT 1 FROM window_focus_events f
+			        WHERE f.session_id = sessions.id AND f.window_title LIKE ? ESCAPE '\')
+			OR EXISTS (SELECT 1 FROM git_commits g
+			           WHERE g.session_id = sessions.id AND g.message LIKE ? ESCAPE '\')
+			OR EXISTS (SELECT 1 FROM shell_commands c
+			           WHERE c.session_id = sessions.id AND c.command LIKE ? ESCAPE '\'))
+		ORDER BY start_time ASC`, end, start, pattern, pattern, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sessions containing %q: %w", query, err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
+
 func scanSessions(rows *sql.Rows) ([]*Session, error) {
 	var sessions []*Session
 	for rows.Next() {
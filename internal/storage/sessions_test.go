@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -130,6 +132,28 @@ func TestGetCurrentSession(t *testing.T) {
 	}
 }
 
+func TestGetOpenSessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	older, _ := store.CreateSession(now - 7200)
+	ended, _ := store.CreateSession(now - 3600)
+	store.EndSession(ended, now-1800)
+	newer, _ := store.CreateSession(now - 600)
+
+	sessions, err := store.GetOpenSessions()
+	if err != nil {
+		t.Fatalf("failed to get open sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 open sessions, got %d", len(sessions))
+	}
+	if sessions[0].ID != newer || sessions[1].ID != older {
+		t.Errorf("expected most recently started first, got %d then %d", sessions[0].ID, sessions[1].ID)
+	}
+}
+
 func TestGetRecentSessions(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -255,6 +279,41 @@ func TestSetSessionSummary(t *testing.T) {
 	}
 }
 
+func TestGetSessionsWithoutSummary(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		start := now - int64((3-i)*3600)
+		id, err := store.CreateSession(start)
+		if err != nil {
+			t.Fatalf("failed to create session: %v", err)
+		}
+		store.EndSession(id, start+1800)
+		ids = append(ids, id)
+	}
+	// An ongoing session isn't ready for a summary
+	store.CreateSession(now)
+
+	// Summarize the middle session
+	store.SaveSummary(&Summary{SessionID: sql.NullInt64{Int64: ids[1], Valid: true}, Summary: "done", ModelUsed: "test-model"})
+
+	sessions, err := store.GetSessionsWithoutSummary(0)
+	if err != nil {
+		t.Fatalf("GetSessionsWithoutSummary failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != ids[2] || sessions[1].ID != ids[0] {
+		t.Fatalf("expected sessions %d and %d, most recent first, got %+v", ids[2], ids[0], sessions)
+	}
+
+	sessions, _ = store.GetSessionsWithoutSummary(1)
+	if len(sessions) != 1 || sessions[0].ID != ids[2] {
+		t.Errorf("expected only the most recent session with limit 1, got %+v", sessions)
+	}
+}
+
 // TestEndSession_InvalidEndTime tests that EndSession rejects invalid end times
 // that would result in negative durations.
 func TestEndSession_InvalidEndTime(t *testing.T) {
@@ -366,6 +425,104 @@ func TestGetSessionsByTimeRange(t *testing.T) {
 	}
 }
 
+func TestGetSessionsByTimeRanges(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+
+	id1, _ := store.CreateSession(now - 7200)
+	store.EndSession(id1, now-5400)
+
+	id2, _ := store.CreateSession(now - 3600)
+	store.EndSession(id2, now-1800)
+
+	id3, _ := store.CreateSession(now - 900)
+	store.EndSession(id3, now)
+
+	all := [2]int64{now - 7200, now}
+	lastHour := [2]int64{now - 3600, now}
+	empty := [2]int64{now - 20000, now - 10000}
+
+	// Duplicate ranges share one entry
+	byRange, err := store.GetSessionsByTimeRanges([][2]int64{all, lastHour, empty, lastHour})
+	if err != nil {
+		t.Fatalf("GetSessionsByTimeRanges failed: %v", err)
+	}
+	if len(byRange) != 3 {
+		t.Errorf("got %d ranges, want 3", len(byRange))
+	}
+	if got := byRange[all]; len(got) != 3 || got[0].ID != id1 || got[2].ID != id3 {
+		t.Errorf("expected sessions %d..%d in order for full range, got %d sessions", id1, id3, len(got))
+	}
+	if got := byRange[lastHour]; len(got) != 2 {
+		t.Errorf("got %d sessions in last hour, want 2", len(got))
+	}
+	if got, ok := byRange[empty]; !ok || len(got) != 0 {
+		t.Errorf("expected empty range to be present with no sessions, got %v (present=%v)", got, ok)
+	}
+
+	// Matches the single-range query
+	single, _ := store.GetSessionsByTimeRange(lastHour[0], lastHour[1])
+	if len(single) != len(byRange[lastHour]) {
+		t.Errorf("batched query returned %d sessions, single query %d", len(byRange[lastHour]), len(single))
+	}
+}
+
+// seedDailySessions creates sessionsPerDay sessions on each of the last days days,
+// returning one [start, end] range per day.
+func seedDailySessions(b *testing.B, days, sessionsPerDay int) (*Store, [][2]int64, func()) {
+	b.Helper()
+
+	store, cleanup := testStore(b)
+	today := time.Now().Truncate(24 * time.Hour)
+
+	var ranges [][2]int64
+	for d := 0; d < days; d++ {
+		dayStart := today.AddDate(0, 0, -d).Unix()
+		for i := 0; i < sessionsPerDay; i++ {
+			start := dayStart + int64(i*3600)
+			id, err := store.CreateSession(start)
+			if err != nil {
+				cleanup()
+				b.Fatalf("failed to create session: %v", err)
+			}
+			store.EndSession(id, start+1800)
+		}
+		ranges = append(ranges, [2]int64{dayStart, dayStart + 86399})
+	}
+
+	return store, ranges, cleanup
+}
+
+// BenchmarkSessionsPerRange loads sessions for 30 daily summaries one query at a time.
+func BenchmarkSessionsPerRange(b *testing.B) {
+	store, ranges, cleanup := seedDailySessions(b, 30, 8)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, r := range ranges {
+			if _, err := store.GetSessionsByTimeRange(r[0], r[1]); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkSessionsByTimeRanges loads the same 30 days with a single query.
+func BenchmarkSessionsByTimeRanges(b *testing.B) {
+	store, ranges, cleanup := seedDailySessions(b, 30, 8)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.GetSessionsByTimeRanges(ranges); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestCloseOrphanedSessions(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -410,3 +567,287 @@ func TestCloseOrphanedSessions(t *testing.T) {
 		t.Error("closed session should still have end_time")
 	}
 }
+
+func TestDeleteShortSessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+
+	shortID, _ := store.CreateSession(now - 100)
+	store.EndSession(shortID, now-90) // 10 seconds
+
+	longID, _ := store.CreateSession(now - 600)
+	store.EndSession(longID, now-300) // 5 minutes
+
+	openID, _ := store.CreateSession(now - 5) // still open
+
+	// Short, but holds a screenshot
+	keptID, _ := store.CreateSession(now - 200)
+	store.EndSession(keptID, now-195)
+	store.SaveScreenshot(&Screenshot{Timestamp: now - 198, Filepath: "kept.webp", SessionID: sql.NullInt64{Int64: keptID, Valid: true}})
+
+	_, err := store.SaveFocusEvent(&WindowFocusEvent{
+		WindowTitle:     "main.go",
+		AppName:         "code",
+		StartTime:       now - 100,
+		EndTime:         now - 90,
+		DurationSeconds: 10,
+		SessionID:       sql.NullInt64{Int64: shortID, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("failed to save focus event: %v", err)
+	}
+
+	deleted, err := store.DeleteShortSessions(30)
+	if err != nil {
+		t.Fatalf("DeleteShortSessions failed: %v", err)
+	}
+	if deleted != 1 {
+		t.Errorf("got %d deleted sessions, want 1", deleted)
+	}
+
+	if sess, _ := store.GetSession(shortID); sess != nil {
+		t.Error("10s session should be deleted with a 30s threshold")
+	}
+	if sess, _ := store.GetSession(longID); sess == nil {
+		t.Error("5m session should be kept")
+	}
+	if sess, _ := store.GetSession(openID); sess == nil {
+		t.Error("open session should be kept")
+	}
+	if sess, _ := store.GetSession(keptID); sess == nil {
+		t.Error("short session with a screenshot should be kept")
+	}
+
+	events, err := store.GetFocusEventsBySession(shortID)
+	if err != nil {
+		t.Fatalf("GetFocusEventsBySession failed: %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("got %d focus events for deleted session, want 0", len(events))
+	}
+
+	// A zero threshold disables the filter
+	deleted, err = store.DeleteShortSessions(0)
+	if err != nil {
+		t.Fatalf("DeleteShortSessions(0) failed: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("got %d deleted sessions with threshold 0, want 0", deleted)
+	}
+}
+
+func TestMergeSessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, err := store.SaveGitRepository(&GitRepository{Path: "/src/traq", Name: "traq"})
+	if err != nil {
+		t.Fatalf("SaveGitRepository failed: %v", err)
+	}
+
+	// One working hour split in three by system sleep
+	start := time.Date(2024, time.March, 5, 9, 0, 0, 0, time.Local).Unix()
+	var ids []int64
+	for i := int64(0); i < 3; i++ {
+		begin := start + i*1200
+		id, _ := store.CreateSession(begin)
+		store.EndSession(id, begin+1000)
+		ids = append(ids, id)
+
+		sessionID := sql.NullInt64{Int64: id, Valid: true}
+		store.SaveFocusEvent(&WindowFocusEvent{WindowTitle: "main.go", AppName: "code", StartTime: begin, EndTime: begin + 600, DurationSeconds: 600, SessionID: sessionID})
+		store.SaveScreenshot(&Screenshot{Timestamp: begin + 30, Filepath: fmt.Sprintf("%d.webp", begin), DHash: "0", SessionID: sessionID})
+		store.SaveGitCommit(&GitCommit{RepositoryID: repoID, CommitHash: fmt.Sprintf("%040d", begin), ShortHash: fmt.Sprint(begin), Timestamp: begin + 60, Message: "Work", MessageSubject: "Work", SessionID: sessionID})
+		store.SaveShellCommand(&ShellCommand{Timestamp: begin + 90, Command: "go test ./...", ShellType: "bash", SessionID: sessionID})
+		store.SaveFileEvent(&FileEvent{Timestamp: begin + 120, EventType: "modified", FilePath: "/src/traq/main.go", FileName: "main.go", Directory: "/src/traq", WatchCategory: "projects", SessionID: sessionID})
+	}
+	threadID, _ := store.CreateTaskThread("Merge sessions")
+	store.AttachSessionToTaskThread(threadID, ids[2])
+
+	if err := store.MergeSessions(ids[0], nil); err == nil {
+		t.Error("expected an error merging no sessions")
+	}
+	if err := store.MergeSessions(ids[0], []int64{ids[1], 9999}); err == nil {
+		t.Error("expected an error merging a missing session")
+	}
+	if sess, _ := store.GetSession(ids[1]); sess == nil {
+		t.Fatal("a failed merge should leave the sessions in place")
+	}
+
+	if err := store.MergeSessions(ids[0], ids[1:]); err != nil {
+		t.Fatalf("MergeSessions failed: %v", err)
+	}
+
+	merged, err := store.GetSession(ids[0])
+	if err != nil || merged == nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+	if merged.StartTime != start || merged.EndTime.Int64 != start+3400 || merged.DurationSeconds.Int64 != 3400 {
+		t.Errorf("expected the merged session to span %d-%d, got %+v", start, start+3400, merged)
+	}
+	if merged.ScreenshotCount != 3 {
+		t.Errorf("expected 3 screenshots on the merged session, got %d", merged.ScreenshotCount)
+	}
+	for _, id := range ids[1:] {
+		if sess, _ := store.GetSession(id); sess != nil {
+			t.Errorf("expected session %d to be deleted", id)
+		}
+	}
+
+	focus, _ := store.GetWindowFocusEventsBySession(ids[0])
+	screenshots, _ := store.GetScreenshotsBySession(ids[0])
+	commits, _ := store.GetGitCommitsBySession(ids[0])
+	commands, _ := store.GetShellCommandsBySession(ids[0])
+	files, _ := store.GetFileEventsBySession(ids[0])
+	if len(focus) != 3 || len(screenshots) != 3 || len(commits) != 3 || len(commands) != 3 || len(files) != 3 {
+		t.Errorf("expected 3 of each event on the primary session, got focus=%d screenshots=%d commits=%d commands=%d files=%d",
+			len(focus), len(screenshots), len(commits), len(commands), len(files))
+	}
+	threadSessions, _ := store.GetTaskThreadSessions(threadID)
+	if len(threadSessions) != 1 || threadSessions[0].ID != ids[0] {
+		t.Errorf("expected the task thread to follow the merged session, got %d sessions", len(threadSessions))
+	}
+}
+
+func TestGetSessionsByTags(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	tagged := func(start, end int64, tags ...string) int64 {
+		t.Helper()
+		id, _ := store.CreateSession(start)
+		store.EndSession(id, end)
+		if _, err := store.SaveSummary(&Summary{
+			SessionID: sql.NullInt64{Int64: id, Valid: true},
+			Summary:   "work",
+			Tags:      tags,
+			ModelUsed: "test-model",
+		}); err != nil {
+			t.Fatalf("failed to save summary: %v", err)
+		}
+		return id
+	}
+	both := tagged(now-7200, now-5400, "coding", "review")
+	coding := tagged(now-3600, now-1800, "coding")
+	meeting := tagged(now-900, now-600, "meeting")
+	tagged(now-86400*3, now-86400*3+600, "coding") // Outside the range
+
+	sessions, err := store.GetSessionsByTag("coding", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsByTag failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != both || sessions[1].ID != coding {
+		t.Errorf("expected sessions %d and %d for coding, got %+v", both, coding, sessions)
+	}
+
+	sessions, err = store.GetSessionsByTags([]string{"review", "meeting"}, "any", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsByTags(any) failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != both || sessions[1].ID != meeting {
+		t.Errorf("expected sessions %d and %d for review|meeting, got %+v", both, meeting, sessions)
+	}
+
+	sessions, err = store.GetSessionsByTags([]string{"coding", "review", "coding"}, "all", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsByTags(all) failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != both {
+		t.Errorf("expected only session %d for coding&review, got %+v", both, sessions)
+	}
+
+	if _, err := store.GetSessionsByTags([]string{"coding"}, "xor", now-10800, now); err == nil {
+		t.Error("expected error for invalid operator")
+	}
+}
+
+func TestGetSessionsByFocusPattern(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	session := func(start, end int64, app, title string) int64 {
+		t.Helper()
+		id, _ := store.CreateSession(start)
+		store.EndSession(id, end)
+		if _, err := store.SaveFocusEvent(&WindowFocusEvent{
+			AppName: app, WindowTitle: title, StartTime: start, EndTime: start + 60, DurationSeconds: 60,
+			SessionID: sql.NullInt64{Int64: id, Valid: true},
+		}); err != nil {
+			t.Fatalf("failed to save focus event: %v", err)
+		}
+		return id
+	}
+	editing := session(now-7200, now-5400, "code", "sessions.go - traq - Visual Studio Code")
+	browsing := session(now-3600, now-1800, "firefox", "traq pull requests - Mozilla Firefox")
+	session(now-900, now-600, "code", "README.md - notes")
+	session(now-86400*3, now-86400*3+600, "code", "main.go - traq") // Outside the range
+
+	sessions, err := store.GetSessionsByFocusPattern("", "TRAQ", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsByFocusPattern failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != editing || sessions[1].ID != browsing {
+		t.Errorf("expected sessions %d and %d for any app in traq, got %+v", editing, browsing, sessions)
+	}
+
+	sessions, err = store.GetSessionsByFocusPattern("code", "%.go - traq%", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsByFocusPattern failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != editing {
+		t.Errorf("expected only session %d for Go files in traq, got %+v", editing, sessions)
+	}
+}
+
+func TestGetSessionsContaining(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	session := func(start, end int64) sql.NullInt64 {
+		id, _ := store.CreateSession(start)
+		store.EndSession(id, end)
+		return sql.NullInt64{Int64: id, Valid: true}
+	}
+	focused := session(now-7200, now-6600)
+	store.SaveFocusEvent(&WindowFocusEvent{
+		AppName: "firefox", WindowTitle: "Rate limiter design - Google Docs", StartTime: now - 7200, EndTime: now - 7100,
+		SessionID: focused,
+	})
+	committed := session(now-5400, now-4800)
+	repoID, _ := store.SaveGitRepository(&GitRepository{Path: "/src/api", Name: "api", IsActive: true})
+	store.SaveGitCommit(&GitCommit{
+		Timestamp: now - 5000, CommitHash: "abc", ShortHash: "abc", RepositoryID: repoID,
+		Message: "Add token bucket rate limiter", SessionID: committed,
+	})
+	shelled := session(now-3600, now-3000)
+	store.SaveShellCommand(&ShellCommand{
+		Timestamp: now - 3500, Command: "go test ./ratelimit/... -run RATE_LIMITER", ShellType: "bash", SessionID: shelled,
+	})
+	session(now-1800, now-1200) // Nothing matching
+
+	sessions, err := store.GetSessionsContaining("rate limiter", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsContaining failed: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0].ID != focused.Int64 || sessions[1].ID != committed.Int64 {
+		t.Errorf("expected sessions %d and %d, got %+v", focused.Int64, committed.Int64, sessions)
+	}
+
+	// LIKE wildcards in the query match literally
+	sessions, err = store.GetSessionsContaining("rate_limiter", now-10800, now)
+	if err != nil {
+		t.Fatalf("GetSessionsContaining failed: %v", err)
+	}
+	if len(sessions) != 1 || sessions[0].ID != shelled.Int64 {
+		t.Errorf("expected only session %d for rate_limiter, got %+v", shelled.Int64, sessions)
+	}
+
+	if sessions, _ := store.GetSessionsContaining("", now-10800, now); len(sessions) != 0 {
+		t.Errorf("expected no sessions for an empty query, got %+v", sessions)
+	}
+}
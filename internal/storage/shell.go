@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 )
@@ -43,9 +44,57 @@ func (s *Store) GetShellCommandsBySession(sessionID int64) ([]*ShellCommand, err
 	return scanShellCommands(rows)
 }
 
+// GetShellCommandsBySessions retrieves shell commands for multiple sessions in one query.
+// The result maps each session ID to its commands ordered by timestamp; sessions
+// without commands are omitted.
+func (s *Store) GetShellCommandsBySessions(sessionIDs []int64) (map[int64][]*ShellCommand, error) {
+	result := make(map[int64][]*ShellCommand)
+	if len(sessionIDs) == 0 {
+		return result, nil
+	}
+
+	args := make([]interface{}, len(sessionIDs))
+	for i, id := range sessionIDs {
+		args[i] = id
+	}
+
+	query := fmt.Sprintf(`
+		SELECT id, timestamp, command, shell_type, working_directory,
+		       exit_code, duration_seconds, hostname, session_id, created_at
+		FROM shell_commands
+		WHERE session_id IN (?%s)
+		ORDER BY session_id, timestamp ASC`, repeatPlaceholder(len(sessionIDs)-1))
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shell commands by sessions: %w", err)
+	}
+	defer rows.Close()
+
+	commands, err := scanShellCommands(rows)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range commands {
+		result[cmd.SessionID.Int64] = append(result[cmd.SessionID.Int64], cmd)
+	}
+
+	return result, nil
+}
+
 // GetShellCommandsByTimeRange retrieves shell commands within a time range.
 func (s *Store) GetShellCommandsByTimeRange(start, end int64) ([]*ShellCommand, error) {
-	rows, err := s.db.Query(`
+	var commands []*ShellCommand
+	err := s.WithReadDB(func(db *sql.DB) error {
+		var err error
+		commands, err = queryShellCommandsByTimeRange(context.Background(), db, start, end)
+		return err
+	})
+	return commands, err
+}
+
+// queryShellCommandsByTimeRange runs the GetShellCommandsByTimeRange query on db.
+func queryShellCommandsByTimeRange(ctx context.Context, db *sql.DB, start, end int64) ([]*ShellCommand, error) {
+	rows, err := db.QueryContext(ctx, `
 		SELECT id, timestamp, command, shell_type, working_directory,
 		       exit_code, duration_seconds, hostname, session_id, created_at
 		FROM shell_commands
@@ -59,6 +108,27 @@ func (s *Store) GetShellCommandsByTimeRange(start, end int64) ([]*ShellCommand,
 	return scanShellCommands(rows)
 }
 
+// maxShellCommandSeconds bounds how far back GetShellCommandsRunningAt looks for long-running commands.
+const maxShellCommandSeconds = 24 * 60 * 60
+
+// GetShellCommandsRunningAt retrieves commands that started at or before a timestamp and were
+// still running then, based on their recorded duration. Commands without a duration are skipped.
+func (s *Store) GetShellCommandsRunningAt(timestamp int64) ([]*ShellCommand, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, command, shell_type, working_directory,
+		       exit_code, duration_seconds, hostname, session_id, created_at
+		FROM shell_commands
+		WHERE timestamp >= ? AND timestamp <= ?
+		  AND duration_seconds IS NOT NULL AND timestamp + duration_seconds >= ?
+		ORDER BY timestamp ASC`, timestamp-maxShellCommandSeconds, timestamp, timestamp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query running shell commands: %w", err)
+	}
+	defer rows.Close()
+
+	return scanShellCommands(rows)
+}
+
 // GetRecentShellCommands retrieves the most recent N shell commands.
 func (s *Store) GetRecentShellCommands(limit int) ([]*ShellCommand, error) {
 	rows, err := s.db.Query(`
@@ -134,6 +204,90 @@ func (s *Store) DeleteShellCommand(id int64) error {
 	return nil
 }
 
+// CommandFrequency contains usage statistics for a base command (e.g. "git", "go").
+type CommandFrequency struct {
+	BaseCommand     string  `json:"baseCommand"` // First word of the command
+	TotalCount      int64   `json:"totalCount"`
+	SuccessCount    int64   `json:"successCount"`    // exit_code = 0
+	FailureCount    int64   `json:"failureCount"`    // exit_code != 0 (commands without an exit code count as neither)
+	AverageDuration float64 `json:"averageDuration"` // Seconds, over commands with a recorded duration
+	LastUsed        int64   `json:"lastUsed"`
+}
+
+// GetShellCommandFrequency returns the most used base commands in a time range, ordered by count.
+// A limit of 0 returns all commands.
+func (s *Store) GetShellCommandFrequency(start, end int64, limit int) ([]*CommandFrequency, error) {
+	query := `
+		WITH base AS (
+			SELECT
+				CASE WHEN instr(trim(command), ' ') > 0
+					THEN substr(trim(command), 1, instr(trim(command), ' ') - 1)
+					ELSE trim(command)
+				END AS base_command,
+				exit_code, duration_seconds, timestamp
+			FROM shell_commands
+			WHERE timestamp >= ? AND timestamp <= ?
+		)
+		SELECT base_command,
+		       COUNT(*),
+		       SUM(CASE WHEN exit_code = 0 THEN 1 ELSE 0 END),
+		       SUM(CASE WHEN exit_code != 0 THEN 1 ELSE 0 END),
+		       COALESCE(AVG(duration_seconds), 0),
+		       MAX(timestamp)
+		FROM base
+		WHERE base_command != ''
+		GROUP BY base_command
+		ORDER BY COUNT(*) DESC, MAX(timestamp) DESC`
+	args := []interface{}{start, end}
+	if limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shell command frequency: %w", err)
+	}
+	defer rows.Close()
+
+	var frequencies []*CommandFrequency
+	for rows.Next() {
+		freq := &CommandFrequency{}
+		if err := rows.Scan(&freq.BaseCommand, &freq.TotalCount, &freq.SuccessCount, &freq.FailureCount,
+			&freq.AverageDuration, &freq.LastUsed); err != nil {
+			return nil, fmt.Errorf("failed to scan shell command frequency: %w", err)
+		}
+		frequencies = append(frequencies, freq)
+	}
+	return frequencies, rows.Err()
+}
+
+// GetShellCommandsByWorkingDirectory returns the number of commands run in each directory in a time range.
+// Commands without a recorded working directory are not included.
+func (s *Store) GetShellCommandsByWorkingDirectory(start, end int64) (map[string]int64, error) {
+	rows, err := s.db.Query(`
+		SELECT working_directory, COUNT(*)
+		FROM shell_commands
+		WHERE timestamp >= ? AND timestamp <= ?
+		  AND working_directory IS NOT NULL AND working_directory != ''
+		GROUP BY working_directory`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shell commands by directory: %w", err)
+	}
+	defer rows.Close()
+
+	dirs := make(map[string]int64)
+	for rows.Next() {
+		var dir string
+		var count int64
+		if err := rows.Scan(&dir, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan shell directory count: %w", err)
+		}
+		dirs[dir] = count
+	}
+	return dirs, rows.Err()
+}
+
 // DeleteShellCommands deletes multiple shell commands by ID.
 func (s *Store) DeleteShellCommands(ids []int64) error {
 	if len(ids) == 0 {
@@ -52,6 +52,36 @@ func TestGetShellCommandsBySession(t *testing.T) {
 	}
 }
 
+func TestGetShellCommandsBySessions(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	session1, _ := store.CreateSession(now - 7200)
+	session2, _ := store.CreateSession(now - 3600)
+	emptySession, _ := store.CreateSession(now)
+
+	for i, sessionID := range []int64{session1, session1, session2} {
+		store.SaveShellCommand(&ShellCommand{
+			Timestamp: now + int64(i),
+			Command:   "echo test",
+			ShellType: "bash",
+			SessionID: sql.NullInt64{Int64: sessionID, Valid: true},
+		})
+	}
+
+	commands, err := store.GetShellCommandsBySessions([]int64{session1, session2, emptySession})
+	if err != nil {
+		t.Fatalf("GetShellCommandsBySessions failed: %v", err)
+	}
+	if len(commands[session1]) != 2 || len(commands[session2]) != 1 {
+		t.Errorf("expected 2 and 1 commands, got %d and %d", len(commands[session1]), len(commands[session2]))
+	}
+	if _, ok := commands[emptySession]; ok {
+		t.Error("expected no entry for session without commands")
+	}
+}
+
 func TestGetShellCommandsByTimeRange(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
@@ -169,3 +199,110 @@ func TestCountShellCommandsByTimeRange(t *testing.T) {
 		t.Errorf("expected 3, got %d", count)
 	}
 }
+
+func TestGetShellCommandFrequency(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := int64(1700000000)
+	fixtures := []struct {
+		command  string
+		dir      string
+		exitCode sql.NullInt64
+		duration sql.NullFloat64
+	}{
+		{"git status", "/home/user/traq", sql.NullInt64{Int64: 0, Valid: true}, sql.NullFloat64{Float64: 0.5, Valid: true}},
+		{"git push origin main", "/home/user/traq", sql.NullInt64{Int64: 1, Valid: true}, sql.NullFloat64{Float64: 2.5, Valid: true}},
+		{"  git log", "/home/user/traq", sql.NullInt64{Int64: 0, Valid: true}, sql.NullFloat64{}},
+		{"go test ./...", "/home/user/traq", sql.NullInt64{Int64: 0, Valid: true}, sql.NullFloat64{Float64: 10, Valid: true}},
+		{"go build", "/home/user/other", sql.NullInt64{Int64: 2, Valid: true}, sql.NullFloat64{Float64: 4, Valid: true}},
+		{"ls", "", sql.NullInt64{}, sql.NullFloat64{}},
+	}
+	for i, f := range fixtures {
+		cmd := &ShellCommand{
+			Timestamp:        now + int64(i),
+			Command:          f.command,
+			ShellType:        "bash",
+			WorkingDirectory: sql.NullString{String: f.dir, Valid: f.dir != ""},
+			ExitCode:         f.exitCode,
+			DurationSeconds:  f.duration,
+		}
+		if _, err := store.SaveShellCommand(cmd); err != nil {
+			t.Fatalf("failed to save shell command: %v", err)
+		}
+	}
+
+	// Outside the range
+	store.SaveShellCommand(&ShellCommand{Timestamp: now - 100, Command: "git fetch", ShellType: "bash"})
+
+	freqs, err := store.GetShellCommandFrequency(now, now+100, 0)
+	if err != nil {
+		t.Fatalf("GetShellCommandFrequency failed: %v", err)
+	}
+	if len(freqs) != 3 {
+		t.Fatalf("expected 3 base commands, got %d", len(freqs))
+	}
+
+	git := freqs[0]
+	if git.BaseCommand != "git" || git.TotalCount != 3 {
+		t.Fatalf("expected git with 3 uses first, got %+v", git)
+	}
+	if git.SuccessCount != 2 || git.FailureCount != 1 {
+		t.Errorf("expected 2 successes and 1 failure, got %d/%d", git.SuccessCount, git.FailureCount)
+	}
+	if git.AverageDuration != 1.5 {
+		t.Errorf("expected average duration 1.5, got %f", git.AverageDuration)
+	}
+	if git.LastUsed != now+2 {
+		t.Errorf("expected last used %d, got %d", now+2, git.LastUsed)
+	}
+
+	goCmd := freqs[1]
+	if goCmd.BaseCommand != "go" || goCmd.TotalCount != 2 || goCmd.FailureCount != 1 {
+		t.Errorf("unexpected go stats: %+v", goCmd)
+	}
+
+	// Commands without exit code count as neither success nor failure
+	ls := freqs[2]
+	if ls.BaseCommand != "ls" || ls.SuccessCount != 0 || ls.FailureCount != 0 || ls.AverageDuration != 0 {
+		t.Errorf("unexpected ls stats: %+v", ls)
+	}
+
+	limited, err := store.GetShellCommandFrequency(now, now+100, 1)
+	if err != nil {
+		t.Fatalf("GetShellCommandFrequency with limit failed: %v", err)
+	}
+	if len(limited) != 1 || limited[0].BaseCommand != "git" {
+		t.Errorf("expected only git with limit 1, got %+v", limited)
+	}
+}
+
+func TestGetShellCommandsByWorkingDirectory(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	now := int64(1700000000)
+	dirs := []string{"/home/user/traq", "/home/user/traq", "/tmp", ""}
+	for i, dir := range dirs {
+		cmd := &ShellCommand{
+			Timestamp:        now + int64(i),
+			Command:          "ls",
+			ShellType:        "bash",
+			WorkingDirectory: sql.NullString{String: dir, Valid: dir != ""},
+		}
+		if _, err := store.SaveShellCommand(cmd); err != nil {
+			t.Fatalf("failed to save shell command: %v", err)
+		}
+	}
+
+	counts, err := store.GetShellCommandsByWorkingDirectory(now, now+100)
+	if err != nil {
+		t.Fatalf("GetShellCommandsByWorkingDirectory failed: %v", err)
+	}
+	if len(counts) != 2 {
+		t.Fatalf("expected 2 directories, got %d: %v", len(counts), counts)
+	}
+	if counts["/home/user/traq"] != 2 || counts["/tmp"] != 1 {
+		t.Errorf("unexpected directory counts: %v", counts)
+	}
+}
@@ -6,12 +6,42 @@ import (
 	"os"
 	"path/filepath"
 
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
-// Store manages the SQLite database connection.
+// driverName is the SQLite driver registered with connectionPragmas applied to every connection.
+const driverName = "sqlite3_traq"
+
+// connectionPragmas tune each new connection. WAL journal mode is persistent and set via the DSN.
+var connectionPragmas = []string{
+	"PRAGMA synchronous=NORMAL",
+	"PRAGMA cache_size=-65536", // 64 MB
+	"PRAGMA temp_store=MEMORY",
+	"PRAGMA mmap_size=268435456", // 256 MB
+}
+
+// readPoolSize is the number of connections in the read-only pool. WAL lets them
+// read concurrently with each other and with the single writer.
+const readPoolSize = 4
+
+func init() {
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			for _, pragma := range connectionPragmas {
+				if _, err := conn.Exec(pragma, nil); err != nil {
+					return fmt.Errorf("failed to apply %q: %w", pragma, err)
+				}
+			}
+			return nil
+		},
+	})
+}
+
+// Store manages the SQLite database connections: a single write connection and a
+// read-only pool for queries routed through WithReadDB.
 type Store struct {
 	db     *sql.DB
+	readDB *sql.DB
 	dbPath string
 }
 
@@ -24,11 +54,15 @@ func NewStore(dbPath string) (*Store, error) {
 	}
 
 	// Open database with CGO SQLite driver
-	db, err := sql.Open("sqlite3", dbPath+"?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=ON")
+	db, err := sql.Open(driverName, dbPath+"?_journal_mode=WAL&_busy_timeout=5000&_foreign_keys=ON")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
+	// SQLite allows one writer at a time; a single connection avoids SQLITE_BUSY between them.
+	// Range queries for reports and the timeline read from the read pool instead.
+	db.SetMaxOpenConns(1)
+
 	// Verify connection
 	if err := db.Ping(); err != nil {
 		db.Close()
@@ -46,17 +80,41 @@ func NewStore(dbPath string) (*Store, error) {
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
+	// Open the read pool once the schema exists
+	readDB, err := sql.Open(driverName, dbPath+"?_busy_timeout=5000&_query_only=1")
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to open read pool: %w", err)
+	}
+	readDB.SetMaxOpenConns(readPoolSize)
+	if err := readDB.Ping(); err != nil {
+		readDB.Close()
+		db.Close()
+		return nil, fmt.Errorf("failed to ping read pool: %w", err)
+	}
+	store.readDB = readDB
+
 	return store, nil
 }
 
-// Close closes the database connection.
+// Close closes the database connections.
 func (s *Store) Close() error {
+	if s.readDB != nil {
+		s.readDB.Close()
+	}
 	if s.db != nil {
 		return s.db.Close()
 	}
 	return nil
 }
 
+// WithReadDB runs fn against the read-only connection pool. Use it for read queries
+// that can run concurrently with each other and with writes. Reads only see committed
+// data, so don't use it for queries inside a Transaction.
+func (s *Store) WithReadDB(fn func(*sql.DB) error) error {
+	return fn(s.readDB)
+}
+
 // DB returns the underlying database connection for advanced queries.
 func (s *Store) DB() *sql.DB {
 	return s.db
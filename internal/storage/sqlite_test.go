@@ -1,14 +1,17 @@
 package storage
 
 import (
+	"context"
 	"database/sql"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 )
 
 // testStore creates a temporary store for testing.
-func testStore(t *testing.T) (*Store, func()) {
+func testStore(t testing.TB) (*Store, func()) {
 	t.Helper()
 
 	dir, err := os.MkdirTemp("", "traq-test-*")
@@ -169,3 +172,111 @@ type testError struct {
 func (e *testError) Error() string {
 	return e.msg
 }
+
+func TestWithReadDB(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	if _, err := store.CreateSession(time.Now().Unix()); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	err := store.WithReadDB(func(db *sql.DB) error {
+		var count int
+		if err := db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&count); err != nil {
+			return err
+		}
+		if count != 1 {
+			t.Errorf("expected committed session to be visible, got %d", count)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithReadDB failed: %v", err)
+	}
+
+	// The read pool is query-only
+	err = store.WithReadDB(func(db *sql.DB) error {
+		_, err := db.Exec("DELETE FROM sessions")
+		return err
+	})
+	if err == nil {
+		t.Error("expected write through read pool to fail")
+	}
+}
+
+func TestRangeQueriesDontWaitForWriter(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// Hold the single write connection in an open transaction
+	tx, err := store.db.Begin()
+	if err != nil {
+		t.Fatalf("Begin failed: %v", err)
+	}
+	defer tx.Rollback()
+
+	done := make(chan error, 1)
+	go func() {
+		end := time.Now().Unix()
+		_, err := store.GetAllEventsForRange(context.Background(), end-3600, end)
+		if err == nil {
+			_, err = store.BatchGetSummaries([]int64{1, 2, 3})
+		}
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("range queries failed: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("range queries waited for the write connection")
+	}
+}
+
+// concurrentReaders is the number of goroutines issuing timeline queries in the read benchmarks.
+const concurrentReaders = 8
+
+// benchmarkConcurrentReads runs query from concurrentReaders goroutines b.N times.
+func benchmarkConcurrentReads(b *testing.B, query func(start, end int64) error) {
+	end := time.Now().Unix()
+	start := end - 50*3600
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var wg sync.WaitGroup
+		for g := 0; g < concurrentReaders; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := query(start, end); err != nil {
+					b.Error(err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+}
+
+// BenchmarkConcurrentTimelineReadsWriteConn runs timeline queries on the single write connection.
+func BenchmarkConcurrentTimelineReadsWriteConn(b *testing.B) {
+	store, _, cleanup := seedFocusBenchmark(b, 50, 20)
+	defer cleanup()
+
+	benchmarkConcurrentReads(b, func(start, end int64) error {
+		_, err := queryFocusEventsByTimeRange(context.Background(), store.db, start, end)
+		return err
+	})
+}
+
+// BenchmarkConcurrentTimelineReadsReadPool runs the same queries through the read pool.
+func BenchmarkConcurrentTimelineReadsReadPool(b *testing.B) {
+	store, _, cleanup := seedFocusBenchmark(b, 50, 20)
+	defer cleanup()
+
+	benchmarkConcurrentReads(b, func(start, end int64) error {
+		_, err := store.GetFocusEventsByTimeRange(start, end)
+		return err
+	})
+}
@@ -137,13 +137,52 @@ func (s *Store) CountSummaries() (int64, error) {
 	return count, err
 }
 
+// summaryBatchTempTableThreshold is the number of sessions above which BatchGetSummaries
+// joins against a temporary table instead of binding every ID in an IN clause.
+const summaryBatchTempTableThreshold = 50
+
 // GetSummariesForSessions retrieves summaries for multiple sessions in a single query.
 // Returns a map of sessionID -> Summary for efficient lookup.
 func (s *Store) GetSummariesForSessions(sessionIDs []int64) (map[int64]*Summary, error) {
+	return s.BatchGetSummaries(sessionIDs)
+}
+
+// BatchGetSummaries returns the most recent summary of each session, keyed by session ID.
+// Large batches are loaded by inserting the IDs into a temporary table and joining on
+// it, which stays fast where a long IN clause doesn't. Small batches are read from the
+// read pool; temporary tables need a writable connection, so large ones use the writer.
+func (s *Store) BatchGetSummaries(sessionIDs []int64) (map[int64]*Summary, error) {
 	if len(sessionIDs) == 0 {
 		return make(map[int64]*Summary), nil
 	}
 
+	var summaries []*Summary
+	var err error
+	if len(sessionIDs) > summaryBatchTempTableThreshold {
+		summaries, err = s.querySummariesByTempTable(sessionIDs)
+	} else {
+		summaries, err = s.querySummariesByIn(sessionIDs)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Build map, keeping only the most recent summary per session
+	summaryMap := make(map[int64]*Summary)
+	for _, sum := range summaries {
+		if sum.SessionID.Valid {
+			// Only store if not already present (already sorted by created_at DESC)
+			if _, exists := summaryMap[sum.SessionID.Int64]; !exists {
+				summaryMap[sum.SessionID.Int64] = sum
+			}
+		}
+	}
+
+	return summaryMap, nil
+}
+
+// querySummariesByIn loads the summaries of sessions with an IN clause, from the read pool.
+func (s *Store) querySummariesByIn(sessionIDs []int64) ([]*Summary, error) {
 	// Build IN clause with placeholders
 	placeholders := make([]interface{}, len(sessionIDs))
 	for i, id := range sessionIDs {
@@ -159,29 +198,57 @@ func (s *Store) GetSummariesForSessions(sessionIDs []int64) (map[int64]*Summary,
 		ORDER BY session_id, created_at DESC`,
 		repeatPlaceholder(len(sessionIDs)-1))
 
-	rows, err := s.db.Query(query, placeholders...)
+	var summaries []*Summary
+	err := s.WithReadDB(func(db *sql.DB) error {
+		rows, err := db.Query(query, placeholders...)
+		if err != nil {
+			return fmt.Errorf("failed to query summaries for sessions: %w", err)
+		}
+		defer rows.Close()
+
+		summaries, err = scanSummaries(rows)
+		return err
+	})
+	return summaries, err
+}
+
+// querySummariesByTempTable loads the summaries of sessions by joining on a temporary
+// table of their IDs. Temporary tables belong to a connection, so the table is filled
+// and read in one transaction, which is rolled back to leave it empty.
+func (s *Store) querySummariesByTempTable(sessionIDs []int64) ([]*Summary, error) {
+	tx, err := s.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to query summaries for sessions: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	summaries, err := scanSummaries(rows)
+	if _, err := tx.Exec(`CREATE TEMP TABLE IF NOT EXISTS temp_session_ids (session_id INTEGER PRIMARY KEY)`); err != nil {
+		return nil, fmt.Errorf("failed to create temp session table: %w", err)
+	}
+	stmt, err := tx.Prepare(`INSERT OR IGNORE INTO temp_session_ids VALUES (?)`)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to prepare session insert: %w", err)
 	}
-
-	// Build map, keeping only the most recent summary per session
-	summaryMap := make(map[int64]*Summary)
-	for _, sum := range summaries {
-		if sum.SessionID.Valid {
-			// Only store if not already present (already sorted by created_at DESC)
-			if _, exists := summaryMap[sum.SessionID.Int64]; !exists {
-				summaryMap[sum.SessionID.Int64] = sum
-			}
+	defer stmt.Close()
+	for _, id := range sessionIDs {
+		if _, err := stmt.Exec(id); err != nil {
+			return nil, fmt.Errorf("failed to insert session id: %w", err)
 		}
 	}
 
-	return summaryMap, nil
+	rows, err := tx.Query(`
+		SELECT s.id, s.session_id, s.summary, s.explanation, s.confidence, s.tags,
+		       s.model_used, s.inference_time_ms, s.screenshot_ids, s.context_json, s.created_at,
+		       s.projects
+		FROM summaries s
+		JOIN temp_session_ids t ON t.session_id = s.session_id
+		ORDER BY s.session_id, s.created_at DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query summaries for sessions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSummaries(rows)
 }
 
 // repeatPlaceholder returns a string with N comma-separated "?" placeholders.
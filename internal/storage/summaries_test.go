@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -203,6 +204,108 @@ func TestGetSummariesForSessions(t *testing.T) {
 	}
 }
 
+func TestBatchGetSummaries_LargeBatch(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	// 200 sessions is past the temp table threshold; every third has no summary
+	// and every fifth has an older summary that should be ignored
+	var sessionIDs []int64
+	for i := 0; i < 200; i++ {
+		sessionID, _ := store.CreateSession(time.Now().Unix())
+		sessionIDs = append(sessionIDs, sessionID)
+		if i%3 == 0 {
+			continue
+		}
+		if i%5 == 0 {
+			id, _ := store.SaveSummary(&Summary{SessionID: sql.NullInt64{Int64: sessionID, Valid: true}, Summary: "old"})
+			store.db.Exec(`UPDATE summaries SET created_at = created_at - 3600 WHERE id = ?`, id)
+		}
+		store.SaveSummary(&Summary{
+			SessionID: sql.NullInt64{Int64: sessionID, Valid: true},
+			Summary:   fmt.Sprintf("Summary %d", sessionID),
+		})
+	}
+
+	summariesMap, err := store.BatchGetSummaries(sessionIDs)
+	if err != nil {
+		t.Fatalf("BatchGetSummaries failed: %v", err)
+	}
+	for i, sessionID := range sessionIDs {
+		sum, ok := summariesMap[sessionID]
+		if i%3 == 0 {
+			if ok {
+				t.Errorf("session %d should not have a summary", sessionID)
+			}
+			continue
+		}
+		if !ok || sum.Summary != fmt.Sprintf("Summary %d", sessionID) {
+			t.Errorf("session %d: got %+v, want its latest summary", sessionID, sum)
+		}
+	}
+
+	// Both strategies agree, and the temp table is left empty
+	inSummaries, err := store.querySummariesByIn(sessionIDs)
+	if err != nil {
+		t.Fatalf("querySummariesByIn failed: %v", err)
+	}
+	tempSummaries, err := store.querySummariesByTempTable(append(sessionIDs, sessionIDs[1]))
+	if err != nil {
+		t.Fatalf("querySummariesByTempTable failed: %v", err)
+	}
+	if len(inSummaries) != len(tempSummaries) {
+		t.Errorf("IN found %d summaries, temp table found %d", len(inSummaries), len(tempSummaries))
+	}
+	if summaries, _ := store.querySummariesByTempTable(sessionIDs[:1]); len(summaries) != 0 {
+		t.Errorf("expected temp table to be emptied between queries, got %d summaries", len(summaries))
+	}
+}
+
+// seedSummaryBenchmark creates sessions that each have a summary.
+func seedSummaryBenchmark(b *testing.B, sessions int) (*Store, []int64, func()) {
+	b.Helper()
+
+	store, cleanup := testStore(b)
+
+	var ids []int64
+	for i := 0; i < sessions; i++ {
+		sessionID, err := store.CreateSession(time.Now().Unix())
+		if err != nil {
+			cleanup()
+			b.Fatalf("failed to create session: %v", err)
+		}
+		store.SaveSummary(&Summary{SessionID: sql.NullInt64{Int64: sessionID, Valid: true}, Summary: "Summary"})
+		ids = append(ids, sessionID)
+	}
+	return store, ids, cleanup
+}
+
+// BenchmarkSummariesByIn loads 100 sessions' summaries with an IN clause.
+func BenchmarkSummariesByIn(b *testing.B) {
+	store, ids, cleanup := seedSummaryBenchmark(b, 100)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.querySummariesByIn(ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkSummariesByTempTable loads the same 100 sessions' summaries with a temp table join.
+func BenchmarkSummariesByTempTable(b *testing.B) {
+	store, ids, cleanup := seedSummaryBenchmark(b, 100)
+	defer cleanup()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := store.querySummariesByTempTable(ids); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
 func TestGetSummariesForSessions_Empty(t *testing.T) {
 	store, cleanup := testStore(t)
 	defer cleanup()
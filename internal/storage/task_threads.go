@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// CreateTaskThread saves a new open task thread.
+func (s *Store) CreateTaskThread(name string) (int64, error) {
+	result, err := s.db.Exec(`INSERT INTO task_threads (name, is_open) VALUES (?, 1)`, name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert task thread: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetTaskThread retrieves a task thread by ID. Returns nil if not found.
+func (s *Store) GetTaskThread(id int64) (*TaskThread, error) {
+	thread := &TaskThread{}
+	var isOpen int
+	err := s.db.QueryRow(`
+		SELECT id, name, created_at, closed_at, is_open
+		FROM task_threads
+		WHERE id = ?`, id).Scan(
+		&thread.ID, &thread.Name, &thread.CreatedAt, &thread.ClosedAt, &isOpen,
+	)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get task thread: %w", err)
+	}
+	thread.IsOpen = isOpen == 1
+	return thread, nil
+}
+
+// GetTaskThreads retrieves task threads, newest first. Closed threads are only included
+// if includeClosed is set.
+func (s *Store) GetTaskThreads(includeClosed bool) ([]*TaskThread, error) {
+	rows, err := s.db.Query(`
+		SELECT id, name, created_at, closed_at, is_open
+		FROM task_threads
+		WHERE is_open = 1 OR ?
+		ORDER BY created_at DESC, id DESC`, includeClosed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task threads: %w", err)
+	}
+	defer rows.Close()
+
+	var threads []*TaskThread
+	for rows.Next() {
+		thread := &TaskThread{}
+		var isOpen int
+		if err := rows.Scan(&thread.ID, &thread.Name, &thread.CreatedAt, &thread.ClosedAt, &isOpen); err != nil {
+			return nil, fmt.Errorf("failed to scan task thread: %w", err)
+		}
+		thread.IsOpen = isOpen == 1
+		threads = append(threads, thread)
+	}
+	return threads, rows.Err()
+}
+
+// RenameTaskThread changes a task thread's name.
+func (s *Store) RenameTaskThread(id int64, name string) error {
+	result, err := s.db.Exec(`UPDATE task_threads SET name = ? WHERE id = ?`, name, id)
+	if err != nil {
+		return fmt.Errorf("failed to rename task thread: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		return fmt.Errorf("task thread not found: %d", id)
+	}
+	return nil
+}
+
+// CloseTaskThread marks a task thread as closed. Closing a closed thread is a no-op.
+func (s *Store) CloseTaskThread(id int64) error {
+	result, err := s.db.Exec(`
+		UPDATE task_threads SET is_open = 0, closed_at = ?
+		WHERE id = ? AND is_open = 1`, time.Now().Unix(), id)
+	if err != nil {
+		return fmt.Errorf("failed to close task thread: %w", err)
+	}
+	if n, _ := result.RowsAffected(); n == 0 {
+		thread, err := s.GetTaskThread(id)
+		if err != nil {
+			return err
+		}
+		if thread == nil {
+			return fmt.Errorf("task thread not found: %d", id)
+		}
+	}
+	return nil
+}
+
+// DeleteTaskThread deletes a task thread. Its sessions are detached, not deleted.
+func (s *Store) DeleteTaskThread(id int64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM task_thread_sessions WHERE thread_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to detach task thread sessions: %w", err)
+	}
+	if _, err := tx.Exec(`DELETE FROM task_threads WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete task thread: %w", err)
+	}
+	return tx.Commit()
+}
+
+// AttachSessionToTaskThread adds a session to a task thread. Attaching a session that is
+// already in the thread is a no-op. A session may belong to several threads.
+func (s *Store) AttachSessionToTaskThread(threadID, sessionID int64) error {
+	_, err := s.db.Exec(`
+		INSERT OR IGNORE INTO task_thread_sessions (thread_id, session_id)
+		VALUES (?, ?)`, threadID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to attach session to task thread: %w", err)
+	}
+	return nil
+}
+
+// DetachSessionFromTaskThread removes a session from a task thread.
+func (s *Store) DetachSessionFromTaskThread(threadID, sessionID int64) error {
+	_, err := s.db.Exec(`
+		DELETE FROM task_thread_sessions WHERE thread_id = ? AND session_id = ?`, threadID, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to detach session from task thread: %w", err)
+	}
+	return nil
+}
+
+// GetTaskThreadSessions retrieves the sessions attached to a task thread, ordered by start time.
+func (s *Store) GetTaskThreadSessions(threadID int64) ([]*Session, error) {
+	rows, err := s.db.Query(`
+		SELECT s.id, s.start_time, s.end_time, s.duration_seconds, s.screenshot_count, s.summary_id, s.created_at
+		FROM sessions s
+		JOIN task_thread_sessions ts ON ts.session_id = s.id
+		WHERE ts.thread_id = ?
+		ORDER BY s.start_time ASC`, threadID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query task thread sessions: %w", err)
+	}
+	defer rows.Close()
+
+	return scanSessions(rows)
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"fmt"
+)
+
+// SaveTicketReference links a commit to a ticket. Saving the same link twice is a no-op.
+func (s *Store) SaveTicketReference(ref *TicketReference) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO ticket_references (commit_id, ticket_id, system, project_key)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT(commit_id, system, ticket_id) DO NOTHING`,
+		ref.CommitID, ref.TicketID, ref.System, ref.ProjectKey,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert ticket reference: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetTicketReferencesByTimeRange retrieves references from commits made within a time range.
+func (s *Store) GetTicketReferencesByTimeRange(start, end int64) ([]*TicketReference, error) {
+	rows, err := s.db.Query(`
+		SELECT t.id, t.commit_id, t.ticket_id, t.system, t.project_key
+		FROM ticket_references t
+		JOIN git_commits c ON c.id = t.commit_id
+		WHERE c.timestamp >= ? AND c.timestamp <= ?
+		ORDER BY c.timestamp ASC, t.id ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket references: %w", err)
+	}
+	defer rows.Close()
+
+	var refs []*TicketReference
+	for rows.Next() {
+		ref := &TicketReference{}
+		if err := rows.Scan(&ref.ID, &ref.CommitID, &ref.TicketID, &ref.System, &ref.ProjectKey); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket reference: %w", err)
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+// GetCommitsByTicket retrieves the commits that mention a ticket, oldest first.
+func (s *Store) GetCommitsByTicket(ticketID string) ([]*GitCommit, error) {
+	rows, err := s.db.Query(`
+		SELECT DISTINCT c.id, c.timestamp, c.commit_hash, c.short_hash, c.repository_id, c.branch,
+		       c.message, c.message_subject, c.files_changed, c.insertions, c.deletions,
+		       c.author_name, c.author_email, c.is_merge, c.session_id, c.created_at,
+		       c.project_id, c.project_confidence, c.project_source
+		FROM git_commits c
+		JOIN ticket_references t ON t.commit_id = c.id
+		WHERE t.ticket_id = ?
+		ORDER BY c.timestamp ASC`, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query commits by ticket: %w", err)
+	}
+	defer rows.Close()
+
+	return scanGitCommits(rows)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTicketReferences(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, _ := store.SaveGitRepository(&GitRepository{Path: "/path/test", Name: "test", IsActive: true})
+	now := time.Now().Unix()
+	var commitIDs []int64
+	for i := 0; i < 3; i++ {
+		id, err := store.SaveGitCommit(&GitCommit{
+			Timestamp:      now + int64(i*60),
+			CommitHash:     "hash" + string(rune('0'+i)),
+			ShortHash:      "h" + string(rune('0'+i)),
+			RepositoryID:   repoID,
+			Message:        "PROJ-1: work",
+			MessageSubject: "PROJ-1: work",
+		})
+		if err != nil {
+			t.Fatalf("failed to save commit: %v", err)
+		}
+		commitIDs = append(commitIDs, id)
+	}
+
+	refs := []*TicketReference{
+		{CommitID: commitIDs[0], TicketID: "PROJ-1", System: "jira", ProjectKey: "PROJ"},
+		{CommitID: commitIDs[0], TicketID: "PROJ-1", System: "jira", ProjectKey: "PROJ"}, // Duplicate
+		{CommitID: commitIDs[2], TicketID: "PROJ-1", System: "jira", ProjectKey: "PROJ"},
+		{CommitID: commitIDs[1], TicketID: "owner/repo#7", System: "github", ProjectKey: "owner/repo"},
+	}
+	for _, ref := range refs {
+		if _, err := store.SaveTicketReference(ref); err != nil {
+			t.Fatalf("failed to save ticket reference: %v", err)
+		}
+	}
+	if _, err := store.SaveTicketReference(&TicketReference{CommitID: commitIDs[0], TicketID: "X-1", System: "trello"}); err == nil {
+		t.Error("expected unknown ticket system to be rejected")
+	}
+
+	commits, err := store.GetCommitsByTicket("PROJ-1")
+	if err != nil {
+		t.Fatalf("failed to get commits by ticket: %v", err)
+	}
+	if len(commits) != 2 || commits[0].ID != commitIDs[0] || commits[1].ID != commitIDs[2] {
+		t.Errorf("expected first and last commits, got %+v", commits)
+	}
+
+	inRange, err := store.GetTicketReferencesByTimeRange(now, now+60)
+	if err != nil {
+		t.Fatalf("failed to get ticket references: %v", err)
+	}
+	if len(inRange) != 2 {
+		t.Errorf("expected 2 references in range, got %d", len(inRange))
+	}
+
+	// References are removed with their commit
+	store.DeleteGitCommit(commitIDs[0])
+	if commits, _ := store.GetCommitsByTicket("PROJ-1"); len(commits) != 1 {
+		t.Errorf("expected 1 commit after deleting one, got %d", len(commits))
+	}
+}
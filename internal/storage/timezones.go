@@ -0,0 +1,36 @@
+package storage
+
+import "fmt"
+
+// SaveTimezoneChange records a change of the system timezone.
+func (s *Store) SaveTimezoneChange(change *TimezoneChange) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO timezone_changes (timestamp, from_tz, to_tz)
+		VALUES (?, ?, ?)`, change.Timestamp, change.FromTZ, change.ToTZ)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert timezone change: %w", err)
+	}
+	return result.LastInsertId()
+}
+
+// GetTimezoneChanges returns all recorded timezone changes, most recent first.
+func (s *Store) GetTimezoneChanges() ([]*TimezoneChange, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, from_tz, to_tz, COALESCE(created_at, 0)
+		FROM timezone_changes
+		ORDER BY timestamp DESC, id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timezone changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*TimezoneChange
+	for rows.Next() {
+		c := &TimezoneChange{}
+		if err := rows.Scan(&c.ID, &c.Timestamp, &c.FromTZ, &c.ToTZ, &c.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan timezone change: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SaveVSCodeLanguageEvent saves a VS Code language event to the database.
+func (s *Store) SaveVSCodeLanguageEvent(event *VSCodeLanguageEvent) (int64, error) {
+	result, err := s.db.Exec(`
+		INSERT INTO vscode_language_events (timestamp, language, workspace, session_id)
+		VALUES (?, ?, ?, ?)`,
+		event.Timestamp, event.Language, event.Workspace, event.SessionID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert vscode language event: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetVSCodeLanguageEventsByTimeRange retrieves VS Code language events within a time range.
+func (s *Store) GetVSCodeLanguageEventsByTimeRange(start, end int64) ([]*VSCodeLanguageEvent, error) {
+	rows, err := s.db.Query(`
+		SELECT id, timestamp, language, workspace, session_id, created_at
+		FROM vscode_language_events
+		WHERE timestamp >= ? AND timestamp <= ?
+		ORDER BY timestamp ASC`, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vscode language events by time: %w", err)
+	}
+	defer rows.Close()
+
+	return scanVSCodeLanguageEvents(rows)
+}
+
+func scanVSCodeLanguageEvents(rows *sql.Rows) ([]*VSCodeLanguageEvent, error) {
+	var events []*VSCodeLanguageEvent
+	for rows.Next() {
+		event := &VSCodeLanguageEvent{}
+		err := rows.Scan(
+			&event.ID, &event.Timestamp, &event.Language,
+			&event.Workspace, &event.SessionID, &event.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vscode language event: %w", err)
+		}
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
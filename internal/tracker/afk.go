@@ -6,15 +6,27 @@ import (
 	"traq/internal/platform"
 )
 
+// AFK trigger types, recording why an away period started.
+const (
+	AFKTriggerIdleTimeout = "idle_timeout" // No input for the configured timeout
+	AFKTriggerScreenLock  = "screen_lock"  // Screen locked
+	AFKTriggerDisplayOff  = "display_off"  // Display powered off
+	AFKTriggerSleep       = "sleep"        // System slept (no polls for longer than the poll interval plus the timeout)
+	AFKTriggerManual      = "manual"       // Tracking paused by the user
+)
+
 // AFKDetector detects when the user is away from keyboard.
 type AFKDetector struct {
-	platform   platform.Platform
-	timeout    time.Duration
-	isAFK      bool
-	lastActive time.Time
-	afkStart   time.Time
-	onAFK      func()
-	onReturn   func()
+	platform     platform.Platform
+	timeout      time.Duration
+	pollInterval time.Duration // Expected time between polls
+	isAFK        bool
+	trigger      string
+	lastActive   time.Time
+	afkStart     time.Time
+	lastPoll     time.Time
+	onAFK        func()
+	onReturn     func()
 }
 
 // NewAFKDetector creates a new AFKDetector.
@@ -38,39 +50,99 @@ func (d *AFKDetector) SetTimeout(timeout time.Duration) {
 	d.timeout = timeout
 }
 
+// SetPollInterval sets how often Poll is expected to run, so that a normal gap between polls
+// is never mistaken for a sleep.
+func (d *AFKDetector) SetPollInterval(interval time.Duration) {
+	d.pollInterval = interval
+}
+
 // Poll checks the current AFK status. Returns true if state changed.
+//
+// A locked screen or powered-off display counts as away immediately; otherwise the user is
+// away once idle for the timeout. A gap between polls longer than the poll interval plus the
+// timeout means the system slept, which is recorded as an away period ending now. A manual away period only
+// ends with ForceReturn.
 func (d *AFKDetector) Poll() bool {
+	// Wall clock time: the monotonic clock doesn't advance while the system sleeps
+	now := time.Now().Round(0)
+	lastPoll := d.lastPoll
+	d.lastPoll = now
+	if d.isAFK && d.trigger == AFKTriggerManual {
+		return false
+	}
+
 	lastInput, err := d.platform.GetLastInputTime()
 	if err != nil {
 		// If we can't get input time, assume not AFK
 		return false
 	}
 
+	slept := false
+	if !d.isAFK && !lastPoll.IsZero() && now.Sub(lastPoll) >= d.pollInterval+d.timeout {
+		slept = true
+		d.enterAFK(AFKTriggerSleep, lastPoll)
+	}
+
 	idleDuration := time.Since(lastInput)
 	wasAFK := d.isAFK
+	locked, displayOff := d.screenState()
 
-	if idleDuration >= d.timeout {
+	if locked || displayOff || idleDuration >= d.timeout {
 		// User is AFK
 		if !d.isAFK {
-			d.isAFK = true
-			d.afkStart = lastInput
-			if d.onAFK != nil {
-				d.onAFK()
+			trigger := AFKTriggerIdleTimeout
+			start := lastInput
+			switch {
+			case locked:
+				trigger, start = AFKTriggerScreenLock, now
+			case displayOff:
+				trigger, start = AFKTriggerDisplayOff, now
 			}
+			d.enterAFK(trigger, start)
 		}
 	} else {
 		// User is active
 		if d.isAFK {
 			d.isAFK = false
+			d.trigger = ""
 			d.afkStart = time.Time{}
 			if d.onReturn != nil {
 				d.onReturn()
 			}
 		}
-		d.lastActive = time.Now()
+		d.lastActive = now
 	}
 
-	return d.isAFK != wasAFK
+	return slept || d.isAFK != wasAFK
+}
+
+// enterAFK marks the start of an away period and fires the AFK callback.
+func (d *AFKDetector) enterAFK(trigger string, start time.Time) {
+	d.isAFK = true
+	d.trigger = trigger
+	d.afkStart = start
+	if d.onAFK != nil {
+		d.onAFK()
+	}
+}
+
+// screenState returns whether the screen is locked and whether the display is off, on
+// platforms that can report it. Errors count as unlocked and on.
+func (d *AFKDetector) screenState() (locked, displayOff bool) {
+	screen, ok := d.platform.(platform.ScreenStateProvider)
+	if !ok {
+		return false, false
+	}
+	locked, err := screen.IsScreenLocked()
+	if err != nil {
+		locked = false
+	}
+	if !locked {
+		if displayOff, err = screen.IsDisplayOff(); err != nil {
+			displayOff = false
+		}
+	}
+	return locked, displayOff
 }
 
 // IsAFK returns the current AFK status.
@@ -83,6 +155,14 @@ func (d *AFKDetector) GetLastActiveTime() time.Time {
 	return d.lastActive
 }
 
+// Trigger returns why the current AFK period started, or "" if not AFK.
+func (d *AFKDetector) Trigger() string {
+	if !d.isAFK {
+		return ""
+	}
+	return d.trigger
+}
+
 // GetAFKStartTime returns when the current AFK period started.
 // Returns zero time if not currently AFK.
 func (d *AFKDetector) GetAFKStartTime() time.Time {
@@ -113,18 +193,16 @@ func (d *AFKDetector) GetIdleDuration() time.Duration {
 // Reset resets the AFK state.
 func (d *AFKDetector) Reset() {
 	d.isAFK = false
+	d.trigger = ""
 	d.lastActive = time.Now()
 	d.afkStart = time.Time{}
+	d.lastPoll = time.Time{}
 }
 
-// ForceAFK forces the AFK state (for testing or manual control).
+// ForceAFK forces a manual AFK period, which lasts until ForceReturn.
 func (d *AFKDetector) ForceAFK() {
 	if !d.isAFK {
-		d.isAFK = true
-		d.afkStart = time.Now()
-		if d.onAFK != nil {
-			d.onAFK()
-		}
+		d.enterAFK(AFKTriggerManual, time.Now())
 	}
 }
 
@@ -132,6 +210,7 @@ func (d *AFKDetector) ForceAFK() {
 func (d *AFKDetector) ForceReturn() {
 	if d.isAFK {
 		d.isAFK = false
+		d.trigger = ""
 		d.afkStart = time.Time{}
 		d.lastActive = time.Now()
 		if d.onReturn != nil {
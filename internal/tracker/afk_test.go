@@ -277,3 +277,110 @@ func TestAFKDetector_ForceReturn(t *testing.T) {
 		t.Error("callback should not be called when already active")
 	}
 }
+
+// mockScreenPlatform adds screen lock and display power state to MockPlatform.
+type mockScreenPlatform struct {
+	*MockPlatform
+	locked     bool
+	displayOff bool
+}
+
+func (m *mockScreenPlatform) IsScreenLocked() (bool, error) { return m.locked, nil }
+func (m *mockScreenPlatform) IsDisplayOff() (bool, error)   { return m.displayOff, nil }
+
+func TestAFKDetector_Poll_Triggers(t *testing.T) {
+	mock := &mockScreenPlatform{MockPlatform: NewMockPlatform()}
+	detector := NewAFKDetector(mock, 5*time.Minute)
+
+	// A locked screen is AFK even with recent input
+	mock.locked = true
+	if !detector.Poll() || detector.Trigger() != AFKTriggerScreenLock {
+		t.Errorf("expected screen_lock AFK, got %q", detector.Trigger())
+	}
+	mock.locked = false
+	if !detector.Poll() || detector.IsAFK() {
+		t.Error("expected return after unlock")
+	}
+
+	mock.displayOff = true
+	if !detector.Poll() || detector.Trigger() != AFKTriggerDisplayOff {
+		t.Errorf("expected display_off AFK, got %q", detector.Trigger())
+	}
+	mock.displayOff = false
+	detector.Poll()
+
+	mock.SetLastInputTime(time.Now().Add(-10 * time.Minute))
+	if !detector.Poll() || detector.Trigger() != AFKTriggerIdleTimeout {
+		t.Errorf("expected idle_timeout AFK, got %q", detector.Trigger())
+	}
+}
+
+func TestAFKDetector_Poll_Sleep(t *testing.T) {
+	mock := NewMockPlatform()
+	detector := NewAFKDetector(mock, 5*time.Minute)
+
+	var triggers []string
+	var starts []time.Time
+	returns := 0
+	detector.SetCallbacks(func() {
+		triggers = append(triggers, detector.Trigger())
+		starts = append(starts, detector.GetAFKStartTime())
+	}, func() { returns++ })
+
+	// No poll for longer than the timeout, and input right after waking
+	lastPoll := time.Now().Add(-time.Hour)
+	detector.lastPoll = lastPoll
+	if !detector.Poll() {
+		t.Error("expected state change after sleep")
+	}
+	if len(triggers) != 1 || triggers[0] != AFKTriggerSleep || !starts[0].Equal(lastPoll) {
+		t.Errorf("expected sleep AFK starting at the last poll, got %v %v", triggers, starts)
+	}
+	if returns != 1 || detector.IsAFK() {
+		t.Error("expected immediate return after waking with input")
+	}
+}
+
+func TestAFKDetector_Poll_IntervalLongerThanTimeout(t *testing.T) {
+	mock := NewMockPlatform()
+	detector := NewAFKDetector(mock, time.Minute)
+	detector.SetPollInterval(2 * time.Minute)
+
+	afkCount := 0
+	detector.SetCallbacks(func() { afkCount++ }, func() {})
+
+	// Regular polls two minutes apart, with the user active throughout
+	for i := 0; i < 3; i++ {
+		detector.lastPoll = time.Now().Add(-2 * time.Minute)
+		if detector.Poll() {
+			t.Errorf("poll %d: expected no state change for a regular poll gap", i)
+		}
+	}
+	if afkCount != 0 || detector.IsAFK() {
+		t.Errorf("expected no sleep detected, got %d AFK periods", afkCount)
+	}
+
+	// A gap longer than the interval plus the timeout is still a sleep
+	detector.lastPoll = time.Now().Add(-4 * time.Minute)
+	if !detector.Poll() || afkCount != 1 {
+		t.Errorf("expected a sleep after a long gap, got %d AFK periods", afkCount)
+	}
+}
+
+func TestAFKDetector_ManualHoldsUntilReturn(t *testing.T) {
+	mock := NewMockPlatform()
+	detector := NewAFKDetector(mock, 5*time.Minute)
+
+	detector.ForceAFK()
+	if detector.Trigger() != AFKTriggerManual {
+		t.Errorf("expected manual trigger, got %q", detector.Trigger())
+	}
+	// Input doesn't end a manual AFK period
+	if detector.Poll() || !detector.IsAFK() {
+		t.Error("expected manual AFK to hold despite recent input")
+	}
+	detector.ForceReturn()
+	if detector.IsAFK() || detector.Trigger() != "" {
+		t.Error("expected ForceReturn to end manual AFK")
+	}
+}
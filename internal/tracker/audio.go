@@ -0,0 +1,171 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// AudioPollInterval is how often the audio output is checked. Each audio event stands for
+// the time since the previous check.
+const AudioPollInterval = 30 * time.Second
+
+// audioPollTolerance lets a tick arriving slightly early still poll, so a tick interval
+// equal to AudioPollInterval doesn't skip every other tick.
+const audioPollTolerance = time.Second
+
+// AudioPlayback is an app playing audio through an output device.
+type AudioPlayback struct {
+	AppName    string // Empty if the playing app couldn't be determined
+	DeviceName string
+}
+
+// AudioReader lists the audio currently playing on the system.
+type AudioReader interface {
+	ReadPlayback() ([]*AudioPlayback, error)
+}
+
+// AudioMonitor records which apps are playing audio, and through which output device.
+type AudioMonitor struct {
+	store        *storage.Store
+	reader       AudioReader
+	pollInterval time.Duration
+	tickInterval time.Duration // How often Poll is called
+	lastPoll     time.Time
+}
+
+// NewAudioMonitor creates a new AudioMonitor.
+// If reader is nil, the system's audio devices are used.
+func NewAudioMonitor(store *storage.Store, reader AudioReader) *AudioMonitor {
+	if reader == nil {
+		reader = &systemAudioReader{}
+	}
+	return &AudioMonitor{
+		store:        store,
+		reader:       reader,
+		pollInterval: AudioPollInterval,
+	}
+}
+
+// SetTickInterval sets how often Poll is called, so the time between polls can be told
+// apart from a gap in polling, such as an AFK period.
+func (m *AudioMonitor) SetTickInterval(interval time.Duration) {
+	m.tickInterval = interval
+}
+
+// Poll records an audio event for each app playing audio, lasting the time since the
+// previous check. After the first check or a gap in polling, an event lasts one interval.
+// Nothing is recorded while the output is silent. Calls made within the poll interval of
+// the previous check are ignored.
+func (m *AudioMonitor) Poll(sessionID int64) ([]*storage.AudioEvent, error) {
+	now := time.Now()
+	elapsed := now.Sub(m.lastPoll)
+	if !m.lastPoll.IsZero() && elapsed < m.pollInterval-audioPollTolerance {
+		return nil, nil
+	}
+	interval := max(m.pollInterval, m.tickInterval)
+	duration := interval
+	if !m.lastPoll.IsZero() && elapsed < 2*interval {
+		duration = elapsed
+	}
+	m.lastPoll = now
+
+	playing, err := m.reader.ReadPlayback()
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*storage.AudioEvent
+	seen := make(map[AudioPlayback]bool)
+	for _, p := range playing {
+		// An app may have several streams open on the same device
+		if seen[*p] {
+			continue
+		}
+		seen[*p] = true
+
+		event := &storage.AudioEvent{
+			Timestamp:       now.Unix(),
+			AppName:         p.AppName,
+			DeviceName:      p.DeviceName,
+			DurationSeconds: int64(duration.Round(time.Second) / time.Second),
+			SessionID:       sql.NullInt64{Int64: sessionID, Valid: sessionID > 0},
+		}
+		id, err := m.store.SaveAudioEvent(event)
+		if err != nil {
+			return events, err
+		}
+		event.ID = id
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// systemAudioReader reads playback using ALSA's /proc/asound on Linux and CoreAudio on macOS.
+type systemAudioReader struct{}
+
+// ReadPlayback lists the audio currently playing. Other platforms aren't supported.
+func (r *systemAudioReader) ReadPlayback() ([]*AudioPlayback, error) {
+	return readSystemPlayback()
+}
+
+// readProcAsound lists running ALSA playback streams from procRoot/asound. The app is the
+// stream's owner, which is the sound server (e.g. pipewire) when one is in use.
+func readProcAsound(procRoot string) ([]*AudioPlayback, error) {
+	statuses, err := filepath.Glob(filepath.Join(procRoot, "asound", "card*", "pcm*p", "sub*", "status"))
+	if err != nil {
+		return nil, err
+	}
+
+	var playing []*AudioPlayback
+	for _, status := range statuses {
+		data, err := os.ReadFile(status)
+		if err != nil {
+			continue
+		}
+		fields := parseAsoundFields(data)
+		if fields["state"] != "RUNNING" {
+			continue
+		}
+
+		p := &AudioPlayback{}
+		if pid := fields["owner_pid"]; pid != "" {
+			if comm, err := os.ReadFile(filepath.Join(procRoot, pid, "comm")); err == nil {
+				p.AppName = strings.TrimSpace(string(comm))
+			}
+		}
+
+		// status lives in cardN/pcmXp/subY; the device name is in pcmXp/info
+		pcmDir := filepath.Dir(filepath.Dir(status))
+		if info, err := os.ReadFile(filepath.Join(pcmDir, "info")); err == nil {
+			p.DeviceName = parseAsoundFields(info)["name"]
+		}
+		if p.DeviceName == "" {
+			if id, err := os.ReadFile(filepath.Join(filepath.Dir(pcmDir), "id")); err == nil {
+				p.DeviceName = strings.TrimSpace(string(id))
+			}
+		}
+		playing = append(playing, p)
+	}
+	return playing, nil
+}
+
+// parseAsoundFields parses the "key: value" lines of a /proc/asound status or info file.
+func parseAsoundFields(data []byte) map[string]string {
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		fields[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	return fields
+}
@@ -0,0 +1,56 @@
+//go:build darwin
+
+package tracker
+
+/*
+#cgo LDFLAGS: -framework CoreAudio -framework CoreFoundation
+#include <CoreAudio/CoreAudio.h>
+
+// defaultOutputPlaying returns 1 if the default output device is in use by any process,
+// 0 if not, and -1 on error. The device's name is copied into name.
+static int defaultOutputPlaying(char *name, int nameLen) {
+	AudioObjectPropertyAddress addr = {
+		kAudioHardwarePropertyDefaultOutputDevice,
+		kAudioObjectPropertyScopeGlobal,
+		kAudioObjectPropertyElementMain
+	};
+	AudioDeviceID device = kAudioObjectUnknown;
+	UInt32 size = sizeof(device);
+	if (AudioObjectGetPropertyData(kAudioObjectSystemObject, &addr, 0, NULL, &size, &device) != noErr ||
+		device == kAudioObjectUnknown) {
+		return -1;
+	}
+
+	UInt32 running = 0;
+	size = sizeof(running);
+	addr.mSelector = kAudioDevicePropertyDeviceIsRunningSomewhere;
+	if (AudioObjectGetPropertyData(device, &addr, 0, NULL, &size, &running) != noErr) {
+		return -1;
+	}
+
+	CFStringRef deviceName = NULL;
+	size = sizeof(deviceName);
+	addr.mSelector = kAudioObjectPropertyName;
+	if (AudioObjectGetPropertyData(device, &addr, 0, NULL, &size, &deviceName) == noErr && deviceName != NULL) {
+		CFStringGetCString(deviceName, name, nameLen, kCFStringEncodingUTF8);
+		CFRelease(deviceName);
+	}
+	return running ? 1 : 0;
+}
+*/
+import "C"
+
+import "fmt"
+
+// readSystemPlayback reports whether the default output device is playing. CoreAudio doesn't
+// say which app is playing, so AppName is left empty.
+func readSystemPlayback() ([]*AudioPlayback, error) {
+	var name [256]C.char
+	switch C.defaultOutputPlaying(&name[0], C.int(len(name))) {
+	case -1:
+		return nil, fmt.Errorf("failed to query default output device")
+	case 0:
+		return nil, nil
+	}
+	return []*AudioPlayback{{DeviceName: C.GoString(&name[0])}}, nil
+}
@@ -0,0 +1,13 @@
+//go:build !darwin
+
+package tracker
+
+import "runtime"
+
+// readSystemPlayback lists the audio playing on Linux. Other platforms aren't supported.
+func readSystemPlayback() ([]*AudioPlayback, error) {
+	if runtime.GOOS != "linux" {
+		return nil, nil
+	}
+	return readProcAsound("/proc")
+}
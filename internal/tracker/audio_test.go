@@ -0,0 +1,124 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type mockAudioReader struct {
+	playing []*AudioPlayback
+}
+
+func (r *mockAudioReader) ReadPlayback() ([]*AudioPlayback, error) {
+	return r.playing, nil
+}
+
+func TestAudioMonitor_Poll(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	reader := &mockAudioReader{}
+	m := NewAudioMonitor(store, reader)
+	m.pollInterval = 0
+
+	// Nothing is recorded while the output is silent
+	if events, _ := m.Poll(0); len(events) != 0 {
+		t.Fatalf("expected no events while silent, got %d", len(events))
+	}
+
+	sessionID, _ := store.CreateSession(time.Now().Unix())
+	reader.playing = []*AudioPlayback{
+		{AppName: "spotify", DeviceName: "HDA Intel PCH"},
+		{AppName: "spotify", DeviceName: "HDA Intel PCH"}, // Second stream from the same app
+		{AppName: "firefox", DeviceName: "HDA Intel PCH"},
+	}
+	events, err := m.Poll(sessionID)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected one event per playing app, got %+v", events)
+	}
+	if events[0].AppName != "spotify" || events[0].SessionID.Int64 != sessionID {
+		t.Errorf("expected spotify event in session %d, got %+v", sessionID, events[0])
+	}
+
+	saved, _ := store.GetAudioEventsByTimeRange(0, time.Now().Unix()+1)
+	if len(saved) != 2 {
+		t.Errorf("expected 2 saved events, got %d", len(saved))
+	}
+
+	// Calls within the poll interval are skipped
+	m.pollInterval = time.Hour
+	if events, _ := m.Poll(sessionID); len(events) != 0 {
+		t.Errorf("expected poll within interval to be skipped, got %d events", len(events))
+	}
+}
+
+func TestAudioMonitor_PollDuration(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	reader := &mockAudioReader{playing: []*AudioPlayback{{AppName: "spotify", DeviceName: "HDA Intel PCH"}}}
+	m := NewAudioMonitor(store, reader)
+	m.SetTickInterval(time.Minute)
+
+	// The first poll counts as one tick interval
+	if events, _ := m.Poll(0); len(events) != 1 || events[0].DurationSeconds != 60 {
+		t.Fatalf("expected a 60s event on the first poll, got %+v", events)
+	}
+
+	// A tick arriving slightly early still polls, and counts the time since the last poll
+	m.lastPoll = time.Now().Add(-AudioPollInterval + 100*time.Millisecond)
+	if events, _ := m.Poll(0); len(events) != 1 || events[0].DurationSeconds != 30 {
+		t.Errorf("expected a 30s event for an early tick, got %+v", events)
+	}
+	m.lastPoll = time.Now().Add(-90 * time.Second)
+	if events, _ := m.Poll(0); len(events) != 1 || events[0].DurationSeconds != 90 {
+		t.Errorf("expected a 90s event after a late tick, got %+v", events)
+	}
+
+	// A gap in polling, e.g. while AFK, isn't counted as playback
+	m.lastPoll = time.Now().Add(-time.Hour)
+	if events, _ := m.Poll(0); len(events) != 1 || events[0].DurationSeconds != 60 {
+		t.Errorf("expected a 60s event after a gap, got %+v", events)
+	}
+}
+
+func TestReadProcAsound(t *testing.T) {
+	root := t.TempDir()
+	pcm := filepath.Join(root, "asound", "card0", "pcm0p")
+	os.MkdirAll(filepath.Join(pcm, "sub0"), 0755)
+	os.MkdirAll(filepath.Join(pcm, "sub1"), 0755)
+	os.WriteFile(filepath.Join(root, "asound", "card0", "id"), []byte("PCH\n"), 0644)
+	os.WriteFile(filepath.Join(pcm, "info"), []byte("card: 0\ndevice: 0\nid: ALC295 Analog\nname: ALC295 Analog\nstream: PLAYBACK\n"), 0644)
+	os.WriteFile(filepath.Join(pcm, "sub0", "status"), []byte("state: RUNNING\nowner_pid   : 42\ntrigger_time: 1234.5\n"), 0644)
+	os.WriteFile(filepath.Join(pcm, "sub1", "status"), []byte("closed\n"), 0644)
+	os.MkdirAll(filepath.Join(root, "42"), 0755)
+	os.WriteFile(filepath.Join(root, "42", "comm"), []byte("pipewire\n"), 0644)
+
+	// A capture stream isn't playback
+	capture := filepath.Join(root, "asound", "card0", "pcm0c", "sub0")
+	os.MkdirAll(capture, 0755)
+	os.WriteFile(filepath.Join(capture, "status"), []byte("state: RUNNING\nowner_pid   : 42\n"), 0644)
+
+	playing, err := readProcAsound(root)
+	if err != nil {
+		t.Fatalf("readProcAsound failed: %v", err)
+	}
+	if len(playing) != 1 {
+		t.Fatalf("expected 1 running playback stream, got %d", len(playing))
+	}
+	if playing[0].AppName != "pipewire" || playing[0].DeviceName != "ALC295 Analog" {
+		t.Errorf("unexpected playback: %+v", playing[0])
+	}
+
+	// Falls back to the card ID without a pcm info file
+	os.Remove(filepath.Join(pcm, "info"))
+	playing, _ = readProcAsound(root)
+	if len(playing) != 1 || playing[0].DeviceName != "PCH" {
+		t.Errorf("expected card ID as device name, got %+v", playing)
+	}
+}
@@ -47,6 +47,11 @@ func (t *BrowserTracker) SetEnabledBrowsers(browsers []string) {
 	t.browsers = browsers
 }
 
+// GetEnabledBrowsers returns the browsers being tracked.
+func (t *BrowserTracker) GetEnabledBrowsers() []string {
+	return t.browsers
+}
+
 // SetExcludedDomains sets which domains to exclude from tracking.
 func (t *BrowserTracker) SetExcludedDomains(domains []string) {
 	t.excludedDomains = domains
@@ -89,7 +94,7 @@ func (t *BrowserTracker) shouldExcludeDomain(domain string) bool {
 	return false
 }
 
-// Poll reads new browser history entries.
+// Poll reads new browser history entries from every profile of each enabled browser.
 func (t *BrowserTracker) Poll(sessionID int64) ([]*storage.BrowserVisit, error) {
 	checkpoint, err := t.loadCheckpoint()
 	if err != nil {
@@ -97,64 +102,91 @@ func (t *BrowserTracker) Poll(sessionID int64) ([]*storage.BrowserVisit, error)
 	}
 
 	browserPaths := t.platform.GetBrowserHistoryPaths()
+	profilePaths := t.profileHistoryPaths(browserPaths)
 	var allVisits []*storage.BrowserVisit
 
 	for _, browser := range t.browsers {
-		histPath, ok := browserPaths[browser]
-		if !ok || histPath == "" {
-			continue
+		for _, histPath := range profilePaths[browser] {
+			// The main profile keeps the browser's checkpoint from before profiles were tracked
+			checkpointKey := browser
+			if histPath != browserPaths[browser] {
+				checkpointKey = browser + ":" + filepath.Base(filepath.Dir(histPath))
+			}
+			visits := t.pollHistoryFile(browser, histPath, checkpointKey, checkpoint, sessionID)
+			allVisits = append(allVisits, visits...)
 		}
+	}
+
+	t.saveCheckpoint(checkpoint)
+
+	return allVisits, nil
+}
+
+// pollHistoryFile saves the visits in one profile's history database since its checkpoint,
+// stored under checkpointKey, and advances the checkpoint.
+func (t *BrowserTracker) pollHistoryFile(browser, histPath, checkpointKey string, checkpoint *BrowserCheckpoint, sessionID int64) []*storage.BrowserVisit {
+	// Check if history file exists
+	if _, err := os.Stat(histPath); os.IsNotExist(err) {
+		return nil
+	}
+
+	lastTimestamp := checkpoint.LastTimestamps[checkpointKey]
+
+	// Apply history limit if no checkpoint exists and limit is set
+	if lastTimestamp == 0 && t.historyLimitDays > 0 {
+		limitTime := time.Now().AddDate(0, 0, -t.historyLimitDays)
+		lastTimestamp = limitTime.Unix()
+	}
 
-		// Check if history file exists
-		if _, err := os.Stat(histPath); os.IsNotExist(err) {
+	visits, newTimestamp, err := t.readBrowserHistory(browser, histPath, lastTimestamp, sessionID)
+	if err != nil {
+		return nil // Log but continue with other browsers
+	}
+	profile := browserProfileFromPath(browser, histPath)
+
+	var saved []*storage.BrowserVisit
+	for _, visit := range visits {
+		// Check if domain should be excluded
+		if t.shouldExcludeDomain(visit.Domain) {
 			continue
 		}
 
-		lastTimestamp := checkpoint.LastTimestamps[browser]
+		visit.BrowserProfile = profile
 
-		// Apply history limit if no checkpoint exists and limit is set
-		if lastTimestamp == 0 && t.historyLimitDays > 0 {
-			limitTime := time.Now().AddDate(0, 0, -t.historyLimitDays)
-			lastTimestamp = limitTime.Unix()
+		// Check for duplicate
+		exists, _ := t.store.VisitExists(visit.Timestamp, visit.URL, visit.Browser, visit.BrowserProfile)
+		if exists {
+			continue
 		}
 
-		visits, newTimestamp, err := t.readBrowserHistory(browser, histPath, lastTimestamp, sessionID)
+		id, err := t.store.SaveBrowserVisit(visit)
 		if err != nil {
-			continue // Log but continue with other browsers
+			continue
 		}
+		visit.ID = id
+		saved = append(saved, visit)
+	}
 
-		if len(visits) > 0 {
-			// Save visits
-			for _, visit := range visits {
-				// Check if domain should be excluded
-				if t.shouldExcludeDomain(visit.Domain) {
-					continue
-				}
-
-				// Check for duplicate
-				exists, _ := t.store.VisitExists(visit.Timestamp, visit.URL, visit.Browser)
-				if exists {
-					continue
-				}
-
-				id, err := t.store.SaveBrowserVisit(visit)
-				if err != nil {
-					continue
-				}
-				visit.ID = id
-				allVisits = append(allVisits, visit)
-			}
+	// Update checkpoint
+	if len(visits) > 0 && newTimestamp > checkpoint.LastTimestamps[checkpointKey] {
+		checkpoint.LastTimestamps[checkpointKey] = newTimestamp
+	}
+	return saved
+}
 
-			// Update checkpoint
-			if newTimestamp > checkpoint.LastTimestamps[browser] {
-				checkpoint.LastTimestamps[browser] = newTimestamp
-			}
+// profileHistoryPaths returns the history database of each browser profile, or just each
+// browser's main profile on platforms that can't find the others.
+func (t *BrowserTracker) profileHistoryPaths(browserPaths map[string]string) map[string][]string {
+	if provider, ok := t.platform.(platform.BrowserProfilesProvider); ok {
+		return provider.GetBrowserProfileHistoryPaths()
+	}
+	paths := make(map[string][]string, len(browserPaths))
+	for browser, path := range browserPaths {
+		if path != "" {
+			paths[browser] = []string{path}
 		}
 	}
-
-	t.saveCheckpoint(checkpoint)
-
-	return allVisits, nil
+	return paths
 }
 
 // readBrowserHistory reads history from a browser's database.
@@ -376,6 +408,36 @@ func (t *BrowserTracker) GetVisitsForSession(sessionID int64) ([]*storage.Browse
 	return t.store.GetBrowserVisitsBySession(sessionID)
 }
 
+// browserProfileFromPath derives the profile name from a browser history file path.
+// The browser's main profile is reported as "default" to match visits recorded
+// before profiles were tracked.
+func browserProfileFromPath(browser, histPath string) string {
+	dir := filepath.Base(filepath.Dir(histPath))
+
+	switch browser {
+	case "safari":
+		return "default" // Safari has a single history database
+	case "firefox":
+		// Firefox profile directories are named "<salt>.<profile name>"
+		if i := strings.Index(dir, "."); i >= 0 {
+			dir = dir[i+1:]
+		}
+		if dir == "default-release" {
+			return "default"
+		}
+	default:
+		// Chromium-based browsers keep each profile in "Default", "Profile 1", ...
+		if dir == "Default" {
+			return "default"
+		}
+	}
+
+	if dir == "" || dir == "." || dir == string(filepath.Separator) {
+		return "default"
+	}
+	return dir
+}
+
 // extractDomain extracts the domain from a URL.
 func extractDomain(rawURL string) string {
 	u, err := url.Parse(rawURL)
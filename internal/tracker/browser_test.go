@@ -32,6 +32,19 @@ func (m *MockBrowserPlatform) ShowNotification(title, body string) error { retur
 func (m *MockBrowserPlatform) SetAutoStart(enabled bool) error           { return nil }
 func (m *MockBrowserPlatform) IsAutoStartEnabled() (bool, error)         { return false, nil }
 func (m *MockBrowserPlatform) GetSystemTheme() string                    { return "light" }
+func (m *MockBrowserPlatform) MachineID() string                         { return "test-machine" }
+func (m *MockBrowserPlatform) RegisterHotkey(string, func()) error       { return nil }
+func (m *MockBrowserPlatform) UnregisterHotkey(string) error             { return nil }
+
+// MockProfilesPlatform also reports every browser profile.
+type MockProfilesPlatform struct {
+	MockBrowserPlatform
+	profilePaths map[string][]string
+}
+
+func (m *MockProfilesPlatform) GetBrowserProfileHistoryPaths() map[string][]string {
+	return m.profilePaths
+}
 
 // setupBrowserTestStore creates a test store for browser tests.
 func setupBrowserTestStore(t *testing.T) (*storage.Store, func()) {
@@ -392,6 +405,64 @@ func TestBrowserTracker_Poll_CheckpointPersistence(t *testing.T) {
 	}
 }
 
+func TestBrowserTracker_Poll_Profiles(t *testing.T) {
+	store, cleanup := setupBrowserTestStore(t)
+	defer cleanup()
+
+	tempDir := t.TempDir()
+	userData := filepath.Join(tempDir, "google-chrome")
+	defaultPath := filepath.Join(userData, "Default", "History")
+	workPath := filepath.Join(userData, "Profile 1", "History")
+	for _, path := range []string{defaultPath, workPath} {
+		os.MkdirAll(filepath.Dir(path), 0755)
+	}
+
+	baseTime := time.Now().Add(-1 * time.Hour).Unix()
+	type visit = struct {
+		URL       string
+		Title     string
+		Timestamp int64
+	}
+	if err := createTestChromiumDB(defaultPath, []visit{{"https://github.com", "GitHub", baseTime}}); err != nil {
+		t.Fatalf("Failed to create test Chrome DB: %v", err)
+	}
+	// The work profile's visit is older than the default profile's checkpoint
+	if err := createTestChromiumDB(workPath, []visit{{"https://jira.example.com", "Jira", baseTime - 600}}); err != nil {
+		t.Fatalf("Failed to create test Chrome DB: %v", err)
+	}
+
+	mockPlat := &MockProfilesPlatform{
+		MockBrowserPlatform: MockBrowserPlatform{browserPaths: map[string]string{"chrome": defaultPath}},
+		profilePaths:        map[string][]string{"chrome": {defaultPath, workPath}},
+	}
+	tracker := NewBrowserTracker(mockPlat, store, tempDir)
+	tracker.SetEnabledBrowsers([]string{"chrome"})
+
+	visits, err := tracker.Poll(0)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	profiles := map[string]string{}
+	for _, v := range visits {
+		profiles[v.Domain] = v.BrowserProfile
+	}
+	if len(visits) != 2 || profiles["github.com"] != "default" || profiles["jira.example.com"] != "Profile 1" {
+		t.Errorf("expected one visit from each profile, got %v", profiles)
+	}
+
+	// Each profile keeps its own checkpoint; the main one keeps the browser's
+	checkpoint, err := tracker.loadCheckpoint()
+	if err != nil {
+		t.Fatalf("loadCheckpoint failed: %v", err)
+	}
+	if checkpoint.LastTimestamps["chrome"] != baseTime || checkpoint.LastTimestamps["chrome:Profile 1"] != baseTime-600 {
+		t.Errorf("unexpected checkpoints: %v", checkpoint.LastTimestamps)
+	}
+	if visits, _ := tracker.Poll(0); len(visits) != 0 {
+		t.Errorf("expected no visits on the next poll, got %d", len(visits))
+	}
+}
+
 func TestBrowserTracker_Poll_NoBrowserPaths(t *testing.T) {
 	store, cleanup := setupBrowserTestStore(t)
 	defer cleanup()
@@ -716,6 +787,28 @@ func TestExtractDomain(t *testing.T) {
 	}
 }
 
+func TestBrowserProfileFromPath(t *testing.T) {
+	tests := []struct {
+		browser  string
+		path     string
+		expected string
+	}{
+		{"chrome", "/home/user/.config/google-chrome/Default/History", "default"},
+		{"chrome", "/home/user/.config/google-chrome/Profile 1/History", "Profile 1"},
+		{"edge", "/home/user/.config/microsoft-edge/Default/History", "default"},
+		{"firefox", "/home/user/.mozilla/firefox/abc123.default-release/places.sqlite", "default"},
+		{"firefox", "/home/user/.mozilla/firefox/xyz789.work/places.sqlite", "work"},
+		{"safari", "/Users/user/Library/Safari/History.db", "default"},
+	}
+
+	for _, tt := range tests {
+		result := browserProfileFromPath(tt.browser, tt.path)
+		if result != tt.expected {
+			t.Errorf("browserProfileFromPath(%q, %q) = %q, expected %q", tt.browser, tt.path, result, tt.expected)
+		}
+	}
+}
+
 func TestBrowserTracker_DomainExclusion(t *testing.T) {
 	store, cleanup := setupBrowserTestStore(t)
 	defer cleanup()
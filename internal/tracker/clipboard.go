@@ -0,0 +1,175 @@
+package tracker
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// ClipboardContent holds a snapshot of the clipboard.
+// Data is only used to detect changes and measure length - it is never persisted.
+type ClipboardContent struct {
+	Data []byte
+	Type string // "text", "image", or "other"
+}
+
+// ClipboardReader reads the current clipboard contents.
+type ClipboardReader interface {
+	ReadClipboard() (*ClipboardContent, error)
+}
+
+// ClipboardTracker records clipboard change statistics.
+// For privacy, only the content length and type are stored, never the content.
+type ClipboardTracker struct {
+	store        *storage.Store
+	reader       ClipboardReader
+	pollInterval time.Duration
+	lastPoll     time.Time
+	lastHash     string
+	primed       bool // True once the initial clipboard state has been observed
+}
+
+// NewClipboardTracker creates a new ClipboardTracker.
+// If reader is nil, the system clipboard is used.
+func NewClipboardTracker(store *storage.Store, reader ClipboardReader) *ClipboardTracker {
+	if reader == nil {
+		reader = &systemClipboardReader{}
+	}
+	return &ClipboardTracker{
+		store:        store,
+		reader:       reader,
+		pollInterval: 30 * time.Second,
+	}
+}
+
+// Poll checks the clipboard for changes and records an event if it changed.
+// Calls made within the poll interval of the previous check are ignored.
+func (t *ClipboardTracker) Poll(sessionID int64) (*storage.ClipboardEvent, error) {
+	now := time.Now()
+	if !t.lastPoll.IsZero() && now.Sub(t.lastPoll) < t.pollInterval {
+		return nil, nil
+	}
+	t.lastPoll = now
+
+	content, err := t.reader.ReadClipboard()
+	if err != nil {
+		return nil, err
+	}
+	if content == nil || len(content.Data) == 0 {
+		return nil, nil
+	}
+
+	sum := sha256.Sum256(content.Data)
+	hash := hex.EncodeToString(sum[:])
+	if hash == t.lastHash {
+		return nil, nil
+	}
+	t.lastHash = hash
+
+	// Don't record whatever was on the clipboard before tracking started
+	if !t.primed {
+		t.primed = true
+		return nil, nil
+	}
+
+	contentType := content.Type
+	if contentType != "text" && contentType != "image" {
+		contentType = "other"
+	}
+
+	event := &storage.ClipboardEvent{
+		Timestamp:     now.Unix(),
+		ContentLength: int64(len(content.Data)),
+		ContentType:   contentType,
+		SessionID:     sql.NullInt64{Int64: sessionID, Valid: sessionID > 0},
+	}
+
+	id, err := t.store.SaveClipboardEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	event.ID = id
+
+	return event, nil
+}
+
+// systemClipboardReader reads the clipboard using platform command-line tools.
+type systemClipboardReader struct{}
+
+// ReadClipboard reads the current clipboard contents.
+func (r *systemClipboardReader) ReadClipboard() (*ClipboardContent, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		return readClipboardText(exec.Command("pbpaste"))
+	case "windows":
+		return readClipboardText(exec.Command("powershell", "-NoProfile", "-Command", "Get-Clipboard -Raw"))
+	default:
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			return readClipboardWithTypes(
+				exec.Command("wl-paste", "--list-types"),
+				func(mime string) *exec.Cmd { return exec.Command("wl-paste", "--no-newline", "--type", mime) },
+			)
+		}
+		return readClipboardWithTypes(
+			exec.Command("xclip", "-selection", "clipboard", "-o", "-t", "TARGETS"),
+			func(mime string) *exec.Cmd { return exec.Command("xclip", "-selection", "clipboard", "-o", "-t", mime) },
+		)
+	}
+}
+
+// readClipboardText runs a command that prints the clipboard text.
+func readClipboardText(cmd *exec.Cmd) (*ClipboardContent, error) {
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clipboard: %w", err)
+	}
+	return &ClipboardContent{Data: out, Type: "text"}, nil
+}
+
+// readClipboardWithTypes lists the available clipboard targets and reads the most relevant one.
+// Images take precedence over text since apps often offer a text fallback for copied images.
+func readClipboardWithTypes(listCmd *exec.Cmd, readCmd func(mime string) *exec.Cmd) (*ClipboardContent, error) {
+	out, err := listCmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list clipboard types: %w", err)
+	}
+
+	var textType, imageType, otherType string
+	for _, line := range strings.Split(string(out), "\n") {
+		mime := strings.TrimSpace(line)
+		switch {
+		case mime == "":
+			continue
+		case strings.HasPrefix(mime, "image/") && imageType == "":
+			imageType = mime
+		case (mime == "UTF8_STRING" || strings.HasPrefix(mime, "text/plain")) && textType == "":
+			textType = mime
+		case strings.Contains(mime, "/") && otherType == "":
+			otherType = mime
+		}
+	}
+
+	contentType, mime := "other", otherType
+	if imageType != "" {
+		contentType, mime = "image", imageType
+	} else if textType != "" {
+		contentType, mime = "text", textType
+	}
+	if mime == "" {
+		return nil, nil
+	}
+
+	data, err := readCmd(mime).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read clipboard: %w", err)
+	}
+	return &ClipboardContent{Data: data, Type: contentType}, nil
+}
@@ -0,0 +1,192 @@
+package tracker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// mockClipboardReader implements ClipboardReader for testing.
+type mockClipboardReader struct {
+	content *ClipboardContent
+	err     error
+	reads   int
+}
+
+func (m *mockClipboardReader) ReadClipboard() (*ClipboardContent, error) {
+	m.reads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.content, nil
+}
+
+func (m *mockClipboardReader) set(data, contentType string) {
+	m.content = &ClipboardContent{Data: []byte(data), Type: contentType}
+}
+
+func setupClipboardTest(t *testing.T) (*storage.Store, *mockClipboardReader, *ClipboardTracker, func()) {
+	t.Helper()
+
+	tmpDir, err := os.MkdirTemp("", "traq-clipboard-test-*")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+
+	store, err := storage.NewStore(filepath.Join(tmpDir, "test.db"))
+	if err != nil {
+		os.RemoveAll(tmpDir)
+		t.Fatalf("Failed to create store: %v", err)
+	}
+
+	reader := &mockClipboardReader{}
+	tracker := NewClipboardTracker(store, reader)
+	tracker.pollInterval = 0 // Poll on every call in tests
+
+	cleanup := func() {
+		store.Close()
+		os.RemoveAll(tmpDir)
+	}
+
+	return store, reader, tracker, cleanup
+}
+
+func TestClipboardTracker_RecordsChanges(t *testing.T) {
+	store, reader, tracker, cleanup := setupClipboardTest(t)
+	defer cleanup()
+
+	sessionID, err := store.CreateSession(time.Now().Unix())
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	// First read only primes the tracker - pre-existing content isn't a change
+	reader.set("already on clipboard", "text")
+	event, err := tracker.Poll(sessionID)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if event != nil {
+		t.Error("Expected no event for initial clipboard contents")
+	}
+
+	reader.set("hello world", "text")
+	event, err = tracker.Poll(sessionID)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if event == nil {
+		t.Fatal("Expected event after clipboard change")
+	}
+	if event.ContentLength != 11 {
+		t.Errorf("Expected content length 11, got %d", event.ContentLength)
+	}
+	if event.ContentType != "text" {
+		t.Errorf("Expected content type 'text', got %q", event.ContentType)
+	}
+	if !event.SessionID.Valid || event.SessionID.Int64 != sessionID {
+		t.Errorf("Expected session ID %d, got %v", sessionID, event.SessionID)
+	}
+
+	// Unchanged clipboard should not produce another event
+	event, _ = tracker.Poll(sessionID)
+	if event != nil {
+		t.Error("Expected no event when clipboard is unchanged")
+	}
+
+	reader.set("\x89PNG fake image bytes", "image")
+	tracker.Poll(sessionID)
+
+	events, err := store.GetClipboardEventsBySession(sessionID)
+	if err != nil {
+		t.Fatalf("Failed to get clipboard events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 clipboard events, got %d", len(events))
+	}
+	if events[1].ContentType != "image" {
+		t.Errorf("Expected second event type 'image', got %q", events[1].ContentType)
+	}
+}
+
+func TestClipboardTracker_UnknownTypeStoredAsOther(t *testing.T) {
+	store, reader, tracker, cleanup := setupClipboardTest(t)
+	defer cleanup()
+
+	reader.set("first", "text")
+	tracker.Poll(0)
+
+	reader.set("<b>rich</b>", "text/html")
+	event, err := tracker.Poll(0)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if event == nil {
+		t.Fatal("Expected event after clipboard change")
+	}
+	if event.ContentType != "other" {
+		t.Errorf("Expected content type 'other', got %q", event.ContentType)
+	}
+	if event.SessionID.Valid {
+		t.Error("Expected NULL session ID when no session is active")
+	}
+
+	count, _ := store.CountClipboardEventsByTimeRange(0, time.Now().Unix()+1)
+	if count != 1 {
+		t.Errorf("Expected 1 clipboard event, got %d", count)
+	}
+}
+
+func TestClipboardTracker_RespectsPollInterval(t *testing.T) {
+	_, reader, tracker, cleanup := setupClipboardTest(t)
+	defer cleanup()
+
+	tracker.pollInterval = time.Hour
+	reader.set("text", "text")
+
+	tracker.Poll(0)
+	tracker.Poll(0)
+	tracker.Poll(0)
+
+	if reader.reads != 1 {
+		t.Errorf("Expected 1 clipboard read within poll interval, got %d", reader.reads)
+	}
+}
+
+func TestClipboardTracker_ReaderError(t *testing.T) {
+	_, reader, tracker, cleanup := setupClipboardTest(t)
+	defer cleanup()
+
+	reader.err = errors.New("no clipboard tool available")
+
+	event, err := tracker.Poll(0)
+	if err == nil {
+		t.Error("Expected error from failing reader")
+	}
+	if event != nil {
+		t.Error("Expected no event on reader error")
+	}
+}
+
+func TestClipboardTracker_EmptyClipboardIgnored(t *testing.T) {
+	store, reader, tracker, cleanup := setupClipboardTest(t)
+	defer cleanup()
+
+	reader.set("primed", "text")
+	tracker.Poll(0)
+
+	reader.set("", "text")
+	event, _ := tracker.Poll(0)
+	if event != nil {
+		t.Error("Expected no event for empty clipboard")
+	}
+
+	count, _ := store.CountClipboardEventsByTimeRange(0, time.Now().Unix()+1)
+	if count != 0 {
+		t.Errorf("Expected 0 clipboard events, got %d", count)
+	}
+}
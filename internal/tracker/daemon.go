@@ -2,13 +2,19 @@ package tracker
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/getsentry/sentry-go"
+	"traq/internal/broadcast"
 	"traq/internal/platform"
 	"traq/internal/storage"
 )
@@ -21,8 +27,56 @@ type DaemonConfig struct {
 	Quality            int
 	DuplicateThreshold int
 	DataDir            string
-	MonitorMode        string // "active_window", "primary", "specific"
+	MonitorMode        string // "active_window", "primary", "specific", "all"
 	MonitorIndex       int    // Only used when MonitorMode is "specific"
+	ClipboardTracking  bool   // Record clipboard change statistics (never content)
+
+	NetworkTrackingEnabled bool // Record new connections to external web hosts
+	AudioTrackingEnabled   bool // Record apps playing audio and their output device
+
+	// Files whose presence marks a directory as a project for AutoWatchProjects
+	ProjectManifestFiles []string
+
+	// Focus event compression, run when a session closes
+	CompressionEnabled          bool
+	CompressionThresholdSeconds float64 // Max gap between same-app focus events to merge
+
+	// Sessions shorter than this are discarded when they close; 0 keeps every session
+	MinSessionDurationSeconds int
+
+	// Lower screenshot quality, down to MinCaptureQuality, while the CPU load per core
+	// is above CPULoadThreshold
+	AdaptiveCaptureQuality bool
+	MinCaptureQuality      int
+	CPULoadThreshold       float64
+}
+
+// DefaultCompressionThresholdSeconds is the default max gap for merging focus events.
+const DefaultCompressionThresholdSeconds = 3.0
+
+// DefaultMinSessionDurationSeconds is the default minimum length of a kept session.
+const DefaultMinSessionDurationSeconds = 30
+
+// Defaults for adaptive capture quality.
+const (
+	DefaultMinCaptureQuality = 40
+	DefaultCPULoadThreshold  = 0.8
+)
+
+// DefaultProjectManifestFiles are the manifests that mark a directory as a project.
+var DefaultProjectManifestFiles = []string{
+	"package.json",
+	"go.mod",
+	"Cargo.toml",
+	"pyproject.toml",
+	"setup.py",
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"Gemfile",
+	"composer.json",
+	"mix.exs",
+	"CMakeLists.txt",
 }
 
 // DefaultDaemonConfig returns a default configuration.
@@ -36,9 +90,34 @@ func DefaultDaemonConfig(dataDir string) *DaemonConfig {
 		DataDir:            dataDir,
 		MonitorMode:        "active_window",
 		MonitorIndex:       0,
+
+		ProjectManifestFiles: DefaultProjectManifestFiles,
+
+		CompressionEnabled:          true,
+		CompressionThresholdSeconds: DefaultCompressionThresholdSeconds,
+
+		MinSessionDurationSeconds: DefaultMinSessionDurationSeconds,
+
+		MinCaptureQuality: DefaultMinCaptureQuality,
+		CPULoadThreshold:  DefaultCPULoadThreshold,
 	}
 }
 
+const (
+	// maintenanceInterval is how often the daemon runs housekeeping jobs.
+	maintenanceInterval = 7 * 24 * time.Hour
+	// staleRepositoryDays is how long a repository can go without commits before polling stops.
+	staleRepositoryDays = 30
+	// projectScanDepth is how many levels below a search path AutoWatchProjects looks for projects.
+	projectScanDepth = 3
+
+	// projectSearchPathsKey is the config key holding the paths AutoWatchProjects scans, as JSON.
+	projectSearchPathsKey = "files.projectSearchPaths"
+	// projectsScannedAtKey is the config key holding when the project search paths were last
+	// scanned, as a Unix timestamp.
+	projectsScannedAtKey = "files.projectsScannedAt"
+)
+
 // ActivitySavedCallback is called after a new activity is saved to the database.
 // eventType: "screenshot", "focus", or "git"
 // eventID: the database ID of the saved event
@@ -46,30 +125,84 @@ func DefaultDaemonConfig(dataDir string) *DaemonConfig {
 // gitRepo: repository path (empty for non-git events)
 type ActivitySavedCallback func(eventType string, eventID int64, appName, windowTitle, gitRepo string)
 
+// AFKEndCallback is called when the user returns from AFK.
+// afkDuration: how long the user was away
+// sessionCount: number of sessions that were active while the user was away
+type AFKEndCallback func(afkDuration time.Duration, sessionCount int)
+
+// SessionEndCallback is called after a session ends, on AFK or when the daemon stops.
+type SessionEndCallback func(sessionID int64)
+
+// CommitSavedCallback is called from the git polling goroutine after a new commit is saved.
+type CommitSavedCallback func(commit *storage.GitCommit)
+
+// ActiveSessionChangedCallback is called when the session being tracked starts, ends or is
+// replaced. sessionID is 0 when no session is active.
+type ActiveSessionChangedCallback func(sessionID int64)
+
 // Daemon is the main tracking daemon.
 type Daemon struct {
-	config  *DaemonConfig
-	store   *storage.Store
-	plat    platform.Platform
-	capture *ScreenCapture
-	window  *WindowTracker
-	afk     *AFKDetector
-	session *SessionManager
-	shell   *ShellTracker
-	git     *GitTracker
-	files   *FileTracker
-	browser *BrowserTracker
+	config    *DaemonConfig
+	store     *storage.Store
+	plat      platform.Platform
+	capture   *ScreenCapture
+	window    *WindowTracker
+	afk       *AFKDetector
+	session   *SessionManager
+	shell     *ShellTracker
+	git       *GitTracker
+	files     *FileTracker
+	browser   *BrowserTracker
+	clipboard *ClipboardTracker
+	vscode    *VSCodePoller
+	network   *NetworkMonitor
+	audio     *AudioMonitor
+	resources *ResourceMonitor
+	load      *SystemLoadMonitor
 
 	running         bool
 	paused          bool
 	stopCh          chan struct{}
+	intervalCh      chan int // New capture interval in seconds, consumed by the run loop
 	mu              sync.RWMutex
-	lastDHash       string
-	currentAFKID    int64 // Track ongoing AFK event ID
+	lastDHashes     map[int]string // Last screenshot dhash per monitor index
+	currentAFKID    int64          // Track ongoing AFK event ID
+	afkStartedAt    time.Time      // When the ongoing AFK period began
+	lastMaintenance time.Time      // When weekly maintenance last ran
+	vscodeWorkspace string         // Repository open in the focused VS Code window, if any
+
+	// Paths scanned by AutoWatchProjects and when they were last scanned, kept in config so
+	// the weekly rescan survives restarts
+	projectSearchPaths []string
+	projectsScannedAt  time.Time
+
+	componentErrors map[string]string // Last error from each data source, keyed by storage.DataSource*
+
+	// System timezone at the previous tick, "" if it couldn't be determined
+	timezone string
+
+	// Receives focus, AFK and screenshot events for live updates, if set
+	events *broadcast.Hub
 
 	// Activity auto-assignment callback
 	onActivitySaved ActivitySavedCallback
 
+	// Called when the user returns from AFK
+	onAFKEnd AFKEndCallback
+
+	// Called after a session ends
+	onSessionEnd SessionEndCallback
+
+	// Called when the active session changes, checked after every tick
+	onActiveSessionChanged ActiveSessionChangedCallback
+	activeSessionID        int64 // Active session at the previous check
+
+	// Called after every capture interval tick, e.g. to refresh the tray
+	onTick func()
+
+	// Runs scheduled jobs such as report delivery, called after every tick with the tick time
+	onScheduledJobs func(now time.Time)
+
 	// Auto-update support
 	onUpdateReady       func() bool // Returns true if update is pending
 	onUpdateApply       func()      // Called to apply update and restart
@@ -94,6 +227,18 @@ func NewDaemon(config *DaemonConfig, store *storage.Store, plat platform.Platfor
 	// BrowserTracker for tracking browser history
 	browser := NewBrowserTracker(plat, store, config.DataDir)
 
+	// ClipboardTracker only runs when enabled in config
+	clipboard := NewClipboardTracker(store, nil)
+
+	// VSCodePoller records languages from VS Code settings files
+	vscode := NewVSCodePoller(store)
+
+	// NetworkMonitor only runs when enabled in config
+	network := NewNetworkMonitor(store, nil)
+
+	// AudioMonitor only runs when enabled in config
+	audio := NewAudioMonitor(store, nil)
+
 	d := &Daemon{
 		config:            config,
 		store:             store,
@@ -106,13 +251,25 @@ func NewDaemon(config *DaemonConfig, store *storage.Store, plat platform.Platfor
 		git:               git,
 		files:             files,
 		browser:           browser,
+		clipboard:         clipboard,
+		vscode:            vscode,
+		network:           network,
+		audio:             audio,
+		resources:         NewResourceMonitor(store, plat, config.DataDir),
+		load:              NewSystemLoadMonitor(),
 		stopCh:            make(chan struct{}),
+		intervalCh:        make(chan int, 1),
+		lastDHashes:       make(map[int]string),
+		componentErrors:   make(map[string]string),
+		timezone:          SystemTimezone(),
 		afkRestartMinutes: 10, // Default: restart after 10 min AFK with pending update
 	}
 
 	// Set up AFK callbacks
 	afk.SetCallbacks(d.onAFK, d.onReturn)
 
+	d.loadProjectSearch()
+
 	return d, nil
 }
 
@@ -152,9 +309,14 @@ func (d *Daemon) Start() error {
 	// Start file tracker with current session
 	if d.files != nil {
 		d.files.SetSessionID(session.ID)
-		d.files.Start()
+		d.setComponentError(storage.DataSourceFiles, d.files.Start())
 	}
 
+	// Time since a previous run isn't a sleep gap
+	d.afk.Reset()
+
+	d.offerShellHook()
+
 	go d.run()
 	return nil
 }
@@ -179,7 +341,13 @@ func (d *Daemon) Stop() error {
 	d.window.FlushCurrentFocus()
 
 	// End current session
+	session := d.session.GetCurrentSession()
 	d.session.EndSession()
+	if d.discardShortSession(session) {
+		session = nil
+	}
+	d.compressFocusEvents(session)
+	d.notifySessionEnd(session)
 
 	d.mu.Lock()
 	d.running = false
@@ -203,7 +371,8 @@ func (d *Daemon) IsPaused() bool {
 }
 
 // Pause pauses screenshot capture without stopping the daemon.
-// The daemon continues running but skips capture operations.
+// The daemon continues running but skips capture operations. From the next tick the pause
+// is recorded as a manual AFK period, ending the current session.
 func (d *Daemon) Pause() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -217,6 +386,31 @@ func (d *Daemon) Resume() {
 	d.paused = false
 }
 
+// SetCaptureInterval changes the screenshot interval without restarting the daemon.
+// A running daemon switches to the new interval immediately, keeping its session and state.
+func (d *Daemon) SetCaptureInterval(seconds int) error {
+	if seconds <= 0 {
+		return fmt.Errorf("invalid capture interval: %d seconds", seconds)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.config.Interval = time.Duration(seconds) * time.Second
+
+	// Replace any pending change so the run loop only sees the latest interval. Callers are
+	// serialized by the lock, so the send never blocks, even when the loop isn't running.
+	select {
+	case <-d.intervalCh:
+	default:
+	}
+	select {
+	case d.intervalCh <- seconds:
+	default:
+	}
+
+	return nil
+}
+
 // GetStatus returns the current daemon status.
 func (d *Daemon) GetStatus() *DaemonStatus {
 	d.mu.RLock()
@@ -232,6 +426,63 @@ func (d *Daemon) GetStatus() *DaemonStatus {
 	}
 }
 
+// StatusDetail describes the health of a data source.
+type StatusDetail struct {
+	Active        bool   `json:"active"`        // The data source is set up and being polled
+	LastEventAt   int64  `json:"lastEventAt"`   // 0 if it has never recorded an event
+	EventsLast24h int64  `json:"eventsLast24h"` // Events recorded in the last 24 hours
+	ErrorMessage  string `json:"errorMessage"`  // Last error, cleared by the next successful poll
+}
+
+// GetComponentHealthSummary returns the health of each data source, keyed by
+// storage.DataSource*.
+func (d *Daemon) GetComponentHealthSummary() map[string]StatusDetail {
+	d.mu.RLock()
+	tracking := d.running && !d.paused
+	errs := make(map[string]string, len(d.componentErrors))
+	for component, msg := range d.componentErrors {
+		errs[component] = msg
+	}
+	d.mu.RUnlock()
+
+	repos, _ := d.store.GetActiveGitRepositories()
+	active := map[string]bool{
+		storage.DataSourceScreenshots: tracking,
+		storage.DataSourceGit:         tracking && len(repos) > 0,
+		storage.DataSourceFiles:       tracking && d.files != nil && len(d.files.GetWatchedDirectories()) > 0,
+		storage.DataSourceBrowser:     tracking && len(d.browser.GetEnabledBrowsers()) > 0,
+		storage.DataSourceShell:       tracking && d.shell.GetHistoryPath() != "",
+	}
+	if d.files == nil {
+		errs[storage.DataSourceFiles] = "file watcher could not be created"
+	}
+
+	activity, err := d.store.GetDataSourceActivity(time.Now().Add(-24 * time.Hour).Unix())
+	summary := make(map[string]StatusDetail, len(active))
+	for component, isActive := range active {
+		detail := StatusDetail{Active: isActive, ErrorMessage: errs[component]}
+		if a, ok := activity[component]; ok {
+			detail.LastEventAt = a.LastEventAt
+			detail.EventsLast24h = a.EventCount
+		} else if err != nil && detail.ErrorMessage == "" {
+			detail.ErrorMessage = err.Error()
+		}
+		summary[component] = detail
+	}
+	return summary
+}
+
+// setComponentError records the result of a data source's latest poll.
+func (d *Daemon) setComponentError(component string, err error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if err != nil {
+		d.componentErrors[component] = err.Error()
+	} else {
+		delete(d.componentErrors, component)
+	}
+}
+
 // DaemonStatus represents the current status of the daemon.
 type DaemonStatus struct {
 	Running         bool
@@ -251,16 +502,48 @@ func (d *Daemon) run() {
 		}
 	}()
 
-	ticker := time.NewTicker(d.config.Interval)
-	defer ticker.Stop()
+	d.runTicker(func() {
+		d.tick()
+		d.checkActiveSession()
+
+		d.mu.RLock()
+		onTick := d.onTick
+		onScheduledJobs := d.onScheduledJobs
+		d.mu.RUnlock()
+		if onTick != nil {
+			go onTick()
+		}
+		if onScheduledJobs != nil {
+			go onScheduledJobs(time.Now())
+		}
+	})
+}
+
+// runTicker calls tick once per capture interval until the daemon is stopped.
+// Interval changes from SetCaptureInterval swap in a new ticker without leaving the loop.
+func (d *Daemon) runTicker(tick func()) {
+	d.mu.RLock()
+	interval := d.config.Interval
+	d.mu.RUnlock()
+
+	ticker := time.NewTicker(interval)
+	defer func() { ticker.Stop() }()
 
 	// Initial tick
-	d.tick()
+	tick()
 
 	for {
 		select {
 		case <-ticker.C:
-			d.tick()
+			tick()
+		case seconds := <-d.intervalCh:
+			ticker.Stop()
+			// Drain a tick that fired before Stop so it isn't delivered late
+			select {
+			case <-ticker.C:
+			default:
+			}
+			ticker = time.NewTicker(time.Duration(seconds) * time.Second)
 		case <-d.stopCh:
 			return
 		}
@@ -268,6 +551,30 @@ func (d *Daemon) run() {
 }
 
 func (d *Daemon) tick() {
+	// Resource snapshots are recorded while paused or AFK too
+	d.resources.Poll()
+	// So are timezone changes, e.g. when the user lands in another timezone
+	d.checkTimezone(SystemTimezone())
+
+	// A pause is recorded as a manual AFK period, which ends on resume. Input isn't polled
+	// while paused, since returning input would start a session with capture paused.
+	d.mu.RLock()
+	paused := d.paused
+	interval := d.config.Interval
+	d.mu.RUnlock()
+	d.afk.SetPollInterval(interval)
+	if paused {
+		if !d.afk.IsAFK() {
+			d.afk.ForceAFK()
+			return
+		}
+		d.checkAutoUpdate()
+		return
+	}
+	if !paused && d.afk.Trigger() == AFKTriggerManual {
+		d.afk.ForceReturn()
+	}
+
 	// Check AFK status
 	stateChanged := d.afk.Poll()
 	if stateChanged {
@@ -282,14 +589,6 @@ func (d *Daemon) tick() {
 		return
 	}
 
-	// Don't capture if paused
-	d.mu.RLock()
-	paused := d.paused
-	d.mu.RUnlock()
-	if paused {
-		return
-	}
-
 	// Ensure we have an active session
 	session, err := d.session.EnsureSession()
 	if err != nil {
@@ -301,20 +600,137 @@ func (d *Daemon) tick() {
 	windowInfo, changed, err := d.window.Poll()
 	if err == nil && changed {
 		d.window.RecordFocusChange(windowInfo, session.ID)
+		d.publish(EventFocus, &FocusEventPayload{
+			Timestamp:   time.Now().Unix(),
+			AppName:     windowInfo.AppName,
+			WindowTitle: windowInfo.Title,
+		})
+
+		// Note the branch when an IDE or terminal starts focusing inside a tracked repository
+		repo, branch := d.git.BranchForWindow(windowInfo)
+		if repo != nil {
+			d.window.SetGitContext(repo.ID, branch)
+		}
+
+		d.vscodeWorkspace = ""
+		if repo != nil && isVSCodeWindow(windowInfo) {
+			d.vscodeWorkspace = repo.Path
+		}
 	}
 
-	// Capture screenshot based on monitor mode configuration
-	var result *CaptureResult
-	monitorIndex := d.getMonitorIndexForCapture(windowInfo)
-	result, err = d.capture.CaptureMonitor(monitorIndex)
+	// Capture screenshots based on monitor mode configuration
+	d.capture.SetQuality(d.captureQuality())
+	timestamp := time.Now().Unix()
+	activeIndex := d.getMonitorIndexForCapture(windowInfo)
+	for _, monitorIndex := range d.getMonitorIndexesForCapture(windowInfo) {
+		// Window context only describes the monitor the active window is on
+		var info *platform.WindowInfo
+		if monitorIndex == activeIndex {
+			info = windowInfo
+		}
+		d.captureScreenshot(monitorIndex, timestamp, info, session.ID)
+	}
+
+	// Poll shell hooks and history for new commands
+	_, hookErr := d.shell.PollHookLog(d.shellHookLogPath(), session.ID)
+	_, err = d.shell.Poll(session.ID)
+	d.setComponentError(storage.DataSourceShell, errors.Join(hookErr, err))
+
+	// Poll git repositories for new commits
+	_, err = d.git.Poll(session.ID)
+	d.setComponentError(storage.DataSourceGit, err)
+
+	// Poll browser history for new visits
+	_, err = d.browser.Poll(session.ID)
+	d.setComponentError(storage.DataSourceBrowser, err)
+
+	// Poll clipboard for changes (if enabled)
+	d.mu.RLock()
+	clipboardEnabled := d.config.ClipboardTracking
+	networkEnabled := d.config.NetworkTrackingEnabled
+	audioEnabled := d.config.AudioTrackingEnabled
+	d.mu.RUnlock()
+	if clipboardEnabled {
+		d.clipboard.Poll(session.ID)
+	}
+
+	// Poll for new connections to web hosts (if enabled)
+	if networkEnabled {
+		d.network.Poll(session.ID)
+	}
+
+	// Poll for apps playing audio (if enabled)
+	if audioEnabled {
+		d.audio.SetTickInterval(interval)
+		d.audio.Poll(session.ID)
+	}
+
+	// Poll VS Code settings for the languages being worked on
+	d.vscode.Poll(session.ID, d.vscodeWorkspace)
+
+	now := time.Now()
+	d.maintainIfDue(now)
+	d.rescanProjectsIfDue(now)
+}
+
+// maintainIfDue runs housekeeping jobs once per maintenanceInterval. The first run waits a
+// full interval after startup, so a launch after a long break doesn't mark repositories
+// stale before their new commits can be recorded.
+func (d *Daemon) maintainIfDue(now time.Time) {
+	if d.lastMaintenance.IsZero() {
+		d.lastMaintenance = now
+		return
+	}
+	if now.Sub(d.lastMaintenance) < maintenanceInterval {
+		return
+	}
+	d.lastMaintenance = now
+
+	// Stop polling repositories that haven't had a commit in a while
+	if stale, err := d.git.GetStaleRepositories(staleRepositoryDays); err == nil {
+		for _, repo := range stale {
+			d.store.MarkRepositoryInactive(repo.ID)
+		}
+	}
+}
+
+// rescanProjectsIfDue rescans the project search paths to pick up projects created since
+// the last scan, once per maintenanceInterval.
+func (d *Daemon) rescanProjectsIfDue(now time.Time) {
+	d.mu.RLock()
+	searchPaths, scannedAt := d.projectSearchPaths, d.projectsScannedAt
+	d.mu.RUnlock()
+	if len(searchPaths) == 0 || now.Sub(scannedAt) < maintenanceInterval {
+		return
+	}
+	d.AutoWatchProjects(searchPaths)
+}
+
+// loadProjectSearch restores the project search paths and last scan time saved by AutoWatchProjects.
+func (d *Daemon) loadProjectSearch() {
+	if val, err := d.store.GetConfig(projectSearchPathsKey); err == nil && val != "" {
+		json.Unmarshal([]byte(val), &d.projectSearchPaths)
+	}
+	if val, err := d.store.GetConfig(projectsScannedAtKey); err == nil && val != "" {
+		if ts, err := strconv.ParseInt(val, 10, 64); err == nil {
+			d.projectsScannedAt = time.Unix(ts, 0)
+		}
+	}
+}
+
+// captureScreenshot captures one monitor and saves it unless it duplicates the previous
+// screenshot of that monitor. windowInfo may be nil for monitors without the active window.
+func (d *Daemon) captureScreenshot(monitorIndex int, timestamp int64, windowInfo *platform.WindowInfo, sessionID int64) {
+	result, err := d.capture.CaptureMonitor(monitorIndex)
+	d.setComponentError(storage.DataSourceScreenshots, err)
 	if err != nil {
 		// Log error but continue
 		return
 	}
 
-	// Check for duplicate
-	if d.lastDHash != "" {
-		similar, _ := d.capture.AreSimilar(d.lastDHash, result.DHash)
+	// Check for duplicate against the last screenshot of the same monitor
+	if lastDHash := d.lastDHashes[monitorIndex]; lastDHash != "" {
+		similar, _ := d.capture.AreSimilar(lastDHash, result.DHash)
 		if similar {
 			// Skip duplicate screenshot - clean up the saved files
 			os.Remove(result.Filepath)
@@ -324,17 +740,19 @@ func (d *Daemon) tick() {
 			return
 		}
 	}
-	d.lastDHash = result.DHash
+	d.lastDHashes[monitorIndex] = result.DHash
 
 	// Save to database
 	sc := &storage.Screenshot{
-		Timestamp:     time.Now().Unix(),
+		Timestamp:     timestamp,
 		Filepath:      result.Filepath,
 		DHash:         result.DHash,
 		MonitorName:   sql.NullString{String: result.MonitorName, Valid: true},
 		MonitorWidth:  sql.NullInt64{Int64: int64(result.Width), Valid: true},
 		MonitorHeight: sql.NullInt64{Int64: int64(result.Height), Valid: true},
-		SessionID:     sql.NullInt64{Int64: session.ID, Valid: true},
+		MonitorIndex:  result.MonitorIndex,
+		SessionID:     sql.NullInt64{Int64: sessionID, Valid: true},
+		Quality:       result.Quality,
 	}
 
 	// Add window info if available
@@ -350,6 +768,9 @@ func (d *Daemon) tick() {
 	}
 
 	scID, _ := d.store.SaveScreenshot(sc)
+	if scID > 0 {
+		d.publish(EventScreenshot, &ScreenshotEventPayload{ID: scID, Timestamp: timestamp, AppName: sc.AppName.String})
+	}
 
 	// Auto-assign screenshot to project if callback is set
 	if scID > 0 && d.onActivitySaved != nil {
@@ -361,15 +782,26 @@ func (d *Daemon) tick() {
 		}
 		go d.onActivitySaved("screenshot", scID, appName, windowTitle, "")
 	}
+}
 
-	// Poll shell history for new commands
-	d.shell.Poll(session.ID)
-
-	// Poll git repositories for new commits
-	d.git.Poll(session.ID)
+// captureQuality returns the screenshot quality to use, lowered under CPU load when
+// adaptive capture quality is enabled.
+func (d *Daemon) captureQuality() int {
+	d.mu.RLock()
+	quality := d.config.Quality
+	adaptive := d.config.AdaptiveCaptureQuality
+	minQuality := d.config.MinCaptureQuality
+	threshold := d.config.CPULoadThreshold
+	d.mu.RUnlock()
 
-	// Poll browser history for new visits
-	d.browser.Poll(session.ID)
+	if !adaptive || d.load == nil {
+		return quality
+	}
+	load, err := d.load.GetCPULoad()
+	if err != nil {
+		return quality
+	}
+	return adaptiveCaptureQuality(quality, minQuality, load, threshold)
 }
 
 // checkAutoUpdate checks if we should auto-restart to apply a pending update.
@@ -426,14 +858,34 @@ func (d *Daemon) onAFK() {
 
 	// End current session
 	d.session.HandleAFK()
+	if d.discardShortSession(session) {
+		session = nil
+		sessionID = sql.NullInt64{}
+	}
+	d.compressFocusEvents(session)
+	d.notifySessionEnd(session)
 
 	// Clear duplicate detection
-	d.lastDHash = ""
+	d.lastDHashes = make(map[int]string)
+
+	// Idle periods started at the last input and sleeps at the last poll before the gap,
+	// both before they were noticed. Neither starts before the session they ended.
+	start := d.afk.GetAFKStartTime()
+	if start.IsZero() {
+		start = time.Now()
+	}
+	if session != nil && start.Unix() < session.StartTime {
+		start = time.Unix(session.StartTime, 0)
+	}
+	trigger := d.afk.Trigger()
+	d.mu.Lock()
+	d.afkStartedAt = start
+	d.mu.Unlock()
 
 	afkEvent := &storage.AFKEvent{
-		StartTime:   time.Now().Unix(),
+		StartTime:   start.Unix(),
 		SessionID:   sessionID,
-		TriggerType: "idle_timeout",
+		TriggerType: trigger,
 	}
 	id, err := d.store.CreateAFKEvent(afkEvent)
 	if err == nil {
@@ -441,6 +893,80 @@ func (d *Daemon) onAFK() {
 		d.currentAFKID = id
 		d.mu.Unlock()
 	}
+	d.publish(EventAFKStart, &AFKEventPayload{Timestamp: start.Unix(), Trigger: trigger})
+}
+
+// notifySessionEnd invokes the session end callback for a session that just ended.
+func (d *Daemon) notifySessionEnd(session *storage.Session) {
+	d.mu.RLock()
+	onSessionEnd := d.onSessionEnd
+	d.mu.RUnlock()
+	if onSessionEnd != nil && session != nil {
+		go onSessionEnd(session.ID)
+	}
+}
+
+// checkActiveSession invokes the active session callback if the session being tracked has
+// changed since the previous check.
+func (d *Daemon) checkActiveSession() {
+	sessionID := d.session.GetCurrentSessionID()
+
+	d.mu.Lock()
+	changed := sessionID != d.activeSessionID
+	d.activeSessionID = sessionID
+	onChanged := d.onActiveSessionChanged
+	d.mu.Unlock()
+
+	if changed && onChanged != nil {
+		go onChanged(sessionID)
+	}
+}
+
+// discardShortSession deletes a just-ended session that lasted less than the configured
+// minimum and recorded nothing but focus events. Sessions with screenshots, commits or any
+// other data are kept. Returns true if the session was deleted.
+func (d *Daemon) discardShortSession(session *storage.Session) bool {
+	d.mu.RLock()
+	minSeconds := int64(d.config.MinSessionDurationSeconds)
+	d.mu.RUnlock()
+	if minSeconds <= 0 || session == nil {
+		return false
+	}
+
+	ended, err := d.store.GetSession(session.ID)
+	if err != nil || ended == nil || !ended.DurationSeconds.Valid {
+		return false
+	}
+	if ended.DurationSeconds.Int64 >= minSeconds {
+		return false
+	}
+
+	deleted, err := d.store.DeleteEmptySession(session.ID)
+	if err != nil {
+		fmt.Printf("Failed to discard short session %d: %v\n", session.ID, err)
+		return false
+	}
+	return deleted
+}
+
+// compressFocusEvents merges short, identical focus events in a session once it has closed.
+func (d *Daemon) compressFocusEvents(session *storage.Session) {
+	d.mu.RLock()
+	enabled := d.config.CompressionEnabled
+	threshold := d.config.CompressionThresholdSeconds
+	d.mu.RUnlock()
+	if !enabled || threshold <= 0 || session == nil {
+		return
+	}
+
+	removed, err := d.store.CompressFocusEvents(session.ID, threshold)
+	if err != nil {
+		fmt.Printf("Focus event compression failed: %v\n", err)
+		return
+	}
+	if removed > 0 {
+		fmt.Printf("Compressed focus events: merged %d short events\n", removed)
+	}
 }
 
 func (d *Daemon) onReturn() {
@@ -449,10 +975,21 @@ func (d *Daemon) onReturn() {
 	afkID := d.currentAFKID
 	d.currentAFKID = 0
 	d.autoUpdateAttempted = false
+	afkStartedAt := d.afkStartedAt
+	d.afkStartedAt = time.Time{}
+	onAFKEnd := d.onAFKEnd
 	d.mu.Unlock()
 
+	now := time.Now()
 	if afkID > 0 {
-		d.store.UpdateAFKEventEnd(afkID, time.Now().Unix())
+		d.store.UpdateAFKEventEnd(afkID, now.Unix())
+	}
+	d.publish(EventAFKEnd, &AFKEventPayload{Timestamp: now.Unix()})
+
+	// Count sessions active during the AFK period before the return session starts
+	if onAFKEnd != nil && !afkStartedAt.IsZero() {
+		sessions, _ := d.store.GetSessionsByTimeRange(afkStartedAt.Unix(), now.Unix())
+		go onAFKEnd(now.Sub(afkStartedAt), len(sessions))
 	}
 
 	// Start new session
@@ -488,7 +1025,7 @@ func (d *Daemon) getMonitorIndexForCapture(windowInfo *platform.WindowInfo) int
 		}
 		// Fall back to primary if configured monitor not available
 		return 0
-	default: // "active_window" or empty (default)
+	default: // "active_window", "all" or empty (default)
 		// Use the window's location to determine monitor
 		if windowInfo != nil && (windowInfo.Width > 0 || windowInfo.Height > 0) {
 			return GetMonitorForWindow(windowInfo.X, windowInfo.Y, windowInfo.Width, windowInfo.Height)
@@ -498,6 +1035,25 @@ func (d *Daemon) getMonitorIndexForCapture(windowInfo *platform.WindowInfo) int
 	}
 }
 
+// getMonitorIndexesForCapture returns the monitor indexes to capture on each tick.
+// In "all" mode every connected monitor is captured; otherwise a single monitor is.
+func (d *Daemon) getMonitorIndexesForCapture(windowInfo *platform.WindowInfo) []int {
+	d.mu.RLock()
+	mode := d.config.MonitorMode
+	d.mu.RUnlock()
+
+	if mode == "all" {
+		if n := GetMonitorCount(); n > 0 {
+			indexes := make([]int, n)
+			for i := range indexes {
+				indexes[i] = i
+			}
+			return indexes
+		}
+	}
+	return []int{d.getMonitorIndexForCapture(windowInfo)}
+}
+
 // UpdateConfig updates the daemon configuration.
 func (d *Daemon) UpdateConfig(config *DaemonConfig) {
 	d.mu.Lock()
@@ -539,8 +1095,53 @@ func (d *Daemon) SetOnActivitySaved(fn ActivitySavedCallback) {
 	d.git.onActivitySaved = fn
 }
 
+// SetOnCommitSaved sets the callback invoked after each new git commit is saved.
+func (d *Daemon) SetOnCommitSaved(fn CommitSavedCallback) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.git.onCommitSaved = fn
+}
+
+// SetOnAFKEnd sets the callback invoked when the user returns from AFK.
+func (d *Daemon) SetOnAFKEnd(fn AFKEndCallback) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onAFKEnd = fn
+}
+
+// SetOnSessionEnd sets the callback invoked after a session ends.
+func (d *Daemon) SetOnSessionEnd(fn SessionEndCallback) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onSessionEnd = fn
+}
+
+// SetOnActiveSessionChanged sets the callback invoked when the active session starts, ends
+// or is replaced.
+func (d *Daemon) SetOnActiveSessionChanged(fn ActiveSessionChangedCallback) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onActiveSessionChanged = fn
+}
+
+// SetOnTick sets a callback invoked after every capture interval tick,
+// including ticks skipped because the user is AFK or capture is paused.
+func (d *Daemon) SetOnTick(fn func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onTick = fn
+}
+
+// SetOnScheduledJobs sets a callback that runs scheduled jobs after every capture interval
+// tick, including ticks skipped because the user is AFK or capture is paused.
+func (d *Daemon) SetOnScheduledJobs(fn func(now time.Time)) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.onScheduledJobs = fn
+}
+
 // SetMonitorMode sets the monitor selection mode.
-// mode can be "active_window", "primary", or "specific".
+// mode can be "active_window", "primary", "specific", or "all".
 func (d *Daemon) SetMonitorMode(mode string) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
@@ -610,6 +1211,86 @@ func (d *Daemon) GetShellExcludePatterns() []string {
 	return d.shell.GetExcludePatterns()
 }
 
+// GenerateShellHook returns the hook script for a shell ("zsh" or "fish") and the path
+// it's installed to.
+func (d *Daemon) GenerateShellHook(shell string) (string, string, error) {
+	hook, ok := shellHooks[shell]
+	if !ok {
+		return "", "", fmt.Errorf("shell hooks aren't supported for %q", shell)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get home directory: %w", err)
+	}
+	return hook.Script(d.shellHookLogPath()), hook.InstallPath(home), nil
+}
+
+// InstallShellHook writes the hook script for a shell and returns its path. The zsh hook
+// is also sourced from ~/.zshrc.
+func (d *Daemon) InstallShellHook(shell string) (string, error) {
+	script, path, err := d.GenerateShellHook(shell)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create hook directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0644); err != nil {
+		return "", fmt.Errorf("failed to write shell hook: %w", err)
+	}
+
+	if shell == "zsh" {
+		home, _ := os.UserHomeDir()
+		zshrc := filepath.Join(home, ".zshrc")
+		source := "source " + quoteSh(path)
+		existing, _ := os.ReadFile(zshrc)
+		if !strings.Contains(string(existing), source) {
+			f, err := os.OpenFile(zshrc, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+			if err != nil {
+				return "", fmt.Errorf("failed to open .zshrc: %w", err)
+			}
+			defer f.Close()
+			if _, err := f.WriteString("\n# Traq shell hook\n" + source + "\n"); err != nil {
+				return "", fmt.Errorf("failed to update .zshrc: %w", err)
+			}
+		}
+	}
+	return path, nil
+}
+
+// GetShellHookStatus returns whether a hook is available and installed for the user's shell.
+func (d *Daemon) GetShellHookStatus() *ShellHookStatus {
+	status := &ShellHookStatus{Shell: d.shell.GetShellType()}
+	if _, path, err := d.GenerateShellHook(status.Shell); err == nil {
+		status.Supported = true
+		status.InstallPath = path
+		_, err := os.Stat(path)
+		status.Installed = err == nil
+	}
+	return status
+}
+
+// offerShellHook logs an offer to install the hook for the user's shell, which records more
+// than its history file. The offer is only made once.
+func (d *Daemon) offerShellHook() {
+	if shown, _ := d.store.GetConfig(shellHookOfferShownKey); shown == "true" {
+		return
+	}
+	status := d.GetShellHookStatus()
+	if !status.Supported || status.Installed {
+		return
+	}
+	log.Printf("Shell hook for %s isn't installed; install it from Settings to record exit codes and durations", status.Shell)
+	if err := d.store.SetConfig(shellHookOfferShownKey, "true"); err != nil {
+		log.Printf("Warning: failed to save shell hook offer: %v", err)
+	}
+}
+
+// shellHookLogPath returns the file shell hooks append commands to.
+func (d *Daemon) shellHookLogPath() string {
+	return filepath.Join(d.config.DataDir, ShellHookLogName)
+}
+
 // ForceCapture forces an immediate screenshot capture.
 func (d *Daemon) ForceCapture() (*CaptureResult, error) {
 	windowInfo, _, _ := d.window.Poll()
@@ -632,6 +1313,16 @@ func (d *Daemon) GetTrackedRepositories() ([]*storage.GitRepository, error) {
 	return d.git.GetRepositories()
 }
 
+// GetStaleGitRepositories returns active repositories with no commits in the last days days.
+func (d *Daemon) GetStaleGitRepositories(days int) ([]*storage.GitRepository, error) {
+	return d.git.GetStaleRepositories(days)
+}
+
+// MarkGitRepositoriesInactive stops polling the given repositories.
+func (d *Daemon) MarkGitRepositoriesInactive(ids []int64) error {
+	return d.git.MarkRepositoriesInactive(ids)
+}
+
 // DiscoverGitRepositories searches for git repositories in the given paths.
 func (d *Daemon) DiscoverGitRepositories(searchPaths []string, maxDepth int) ([]*storage.GitRepository, error) {
 	return d.git.DiscoverRepositories(searchPaths, maxDepth)
@@ -686,6 +1377,45 @@ func (d *Daemon) AutoWatchDownloads() {
 	}
 }
 
+// AutoWatchProjects scans searchPaths for project directories, identified by the configured
+// manifest files, and watches them under the "projects" category. The paths are saved and
+// rescanned weekly. Returns the number of newly watched projects.
+func (d *Daemon) AutoWatchProjects(searchPaths []string) (int, error) {
+	if d.files == nil {
+		return 0, fmt.Errorf("file tracker not initialized")
+	}
+
+	now := time.Now()
+	d.mu.Lock()
+	d.projectSearchPaths = searchPaths
+	d.projectsScannedAt = now
+	manifests := d.config.ProjectManifestFiles
+	d.mu.Unlock()
+	if data, err := json.Marshal(searchPaths); err == nil {
+		if err := d.store.SetConfig(projectSearchPathsKey, string(data)); err != nil {
+			log.Printf("Warning: failed to save project search paths: %v", err)
+		}
+	}
+	if err := d.store.SetConfig(projectsScannedAtKey, strconv.FormatInt(now.Unix(), 10)); err != nil {
+		log.Printf("Warning: failed to save project scan time: %v", err)
+	}
+	if len(manifests) == 0 {
+		manifests = DefaultProjectManifestFiles
+	}
+
+	watched := 0
+	for _, dir := range findProjectDirs(searchPaths, manifests, projectScanDepth, d.files.shouldExcludeDir) {
+		if d.files.IsWatched(dir) {
+			continue
+		}
+		if err := d.files.WatchDirectoryWithCategory(dir, "projects"); err != nil {
+			return watched, err
+		}
+		watched++
+	}
+	return watched, nil
+}
+
 // SetFileExcludePatterns sets directory patterns to exclude from file tracking.
 func (d *Daemon) SetFileExcludePatterns(patterns []string) {
 	if d.files == nil {
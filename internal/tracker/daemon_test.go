@@ -0,0 +1,444 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"traq/internal/storage"
+)
+
+func TestDaemon_SetCaptureIntervalLiveReload(t *testing.T) {
+	d := &Daemon{
+		config:     &DaemonConfig{Interval: time.Hour},
+		stopCh:     make(chan struct{}),
+		intervalCh: make(chan int, 1),
+	}
+
+	ticks := make(chan struct{}, 10)
+	go d.runTicker(func() { ticks <- struct{}{} })
+	defer close(d.stopCh)
+
+	// Initial tick fires immediately
+	select {
+	case <-ticks:
+	case <-time.After(time.Second):
+		t.Fatal("expected initial tick")
+	}
+
+	// Shorten the interval shortly after startup; the hour-long ticker must not block it
+	time.AfterFunc(50*time.Millisecond, func() {
+		if err := d.SetCaptureInterval(1); err != nil {
+			t.Errorf("SetCaptureInterval failed: %v", err)
+		}
+	})
+
+	select {
+	case <-ticks:
+	case <-time.After(3 * time.Second):
+		t.Fatal("expected tick after interval change")
+	}
+
+	d.mu.RLock()
+	interval := d.config.Interval
+	d.mu.RUnlock()
+	if interval != time.Second {
+		t.Errorf("expected config interval 1s, got %v", interval)
+	}
+}
+
+func TestDaemon_SetCaptureIntervalInvalid(t *testing.T) {
+	d := &Daemon{
+		config:     &DaemonConfig{Interval: 30 * time.Second},
+		intervalCh: make(chan int, 1),
+	}
+
+	if err := d.SetCaptureInterval(0); err == nil {
+		t.Error("expected error for zero interval")
+	}
+	if d.config.Interval != 30*time.Second {
+		t.Errorf("expected interval to be unchanged, got %v", d.config.Interval)
+	}
+
+	// Without a running loop, only the latest pending change is kept
+	d.SetCaptureInterval(60)
+	d.SetCaptureInterval(90)
+	if got := <-d.intervalCh; got != 90 {
+		t.Errorf("expected pending interval 90, got %d", got)
+	}
+
+	// Concurrent changes don't block when nothing consumes them
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(seconds int) {
+			defer wg.Done()
+			d.SetCaptureInterval(seconds)
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("concurrent SetCaptureInterval calls blocked")
+	}
+	if len(d.intervalCh) != 1 {
+		t.Errorf("expected one pending interval, got %d", len(d.intervalCh))
+	}
+}
+
+func TestDaemon_MaintainIfDue(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	d := &Daemon{store: store, git: NewGitTracker(store, t.TempDir())}
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/path/old", Name: "old", IsActive: true})
+	store.SaveGitCommit(&storage.GitCommit{
+		Timestamp:      time.Now().AddDate(0, 0, -60).Unix(),
+		CommitHash:     "abc",
+		ShortHash:      "abc",
+		RepositoryID:   repoID,
+		Message:        "old work",
+		MessageSubject: "old work",
+	})
+
+	// The first tick only schedules maintenance
+	now := time.Now()
+	d.maintainIfDue(now)
+	if repo, _ := store.GetGitRepository(repoID); !repo.IsActive {
+		t.Error("maintenance should not run on the first tick")
+	}
+	d.maintainIfDue(now.Add(time.Hour))
+	if repo, _ := store.GetGitRepository(repoID); !repo.IsActive {
+		t.Error("maintenance should not run within the interval")
+	}
+	d.maintainIfDue(now.Add(maintenanceInterval))
+	if repo, _ := store.GetGitRepository(repoID); repo.IsActive {
+		t.Error("expected repository without recent commits to be marked inactive after the interval")
+	}
+
+	// Not due again until another interval has passed
+	store.SetGitRepositoryActive(repoID, true)
+	d.maintainIfDue(now.Add(maintenanceInterval + time.Hour))
+	if repo, _ := store.GetGitRepository(repoID); !repo.IsActive {
+		t.Error("maintenance should not run again within the interval")
+	}
+}
+
+func TestDaemon_AutoWatchProjects(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	files, err := NewFileTracker(store)
+	if err != nil {
+		t.Fatalf("NewFileTracker failed: %v", err)
+	}
+	defer files.Close()
+	d := &Daemon{
+		config: &DaemonConfig{ProjectManifestFiles: DefaultProjectManifestFiles},
+		store:  store,
+		git:    NewGitTracker(store, t.TempDir()),
+		files:  files,
+	}
+
+	root := t.TempDir()
+	mkfile := func(rel string) {
+		path := filepath.Join(root, rel)
+		os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mkfile("web/app/package.json")
+	mkfile("web/app/packages/lib/package.json") // Nested in a project
+	mkfile("web/node_modules/dep/package.json") // Excluded directory
+	mkfile("go/svc/go.mod")
+	mkfile("a/b/rust/Cargo.toml")         // 3 levels deep
+	mkfile("a/b/c/python/pyproject.toml") // Too deep
+	mkfile(".config/tool/go.mod")         // Hidden directory
+	mkfile("notes/readme.md")
+
+	count, err := d.AutoWatchProjects([]string{root})
+	if err != nil {
+		t.Fatalf("AutoWatchProjects failed: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("expected 3 projects, got %d: %v", count, files.GetWatchedDirectories())
+	}
+	for _, dir := range []string{"web/app", "web/app/packages/lib", "go/svc", "a/b/rust"} {
+		if !files.IsWatched(filepath.Join(root, dir)) {
+			t.Errorf("expected %s to be watched", dir)
+		}
+	}
+	for _, dir := range []string{"web/node_modules/dep", "a/b/c/python", ".config/tool", "notes"} {
+		if files.IsWatched(filepath.Join(root, dir)) {
+			t.Errorf("expected %s not to be watched", dir)
+		}
+	}
+	if got := files.categoryFor(filepath.Join(root, "go/svc/main.go")); got != "projects" {
+		t.Errorf("expected projects category, got %q", got)
+	}
+	if got := files.categoryFor(filepath.Join(root, "notes/readme.md")); got != "" {
+		t.Errorf("expected no category outside projects, got %q", got)
+	}
+
+	// Rescanning doesn't re-add watched projects
+	if count, _ := d.AutoWatchProjects([]string{root}); count != 0 {
+		t.Errorf("expected no new projects on rescan, got %d", count)
+	}
+
+	// The search paths and scan time are saved, so the weekly rescan survives a restart
+	restarted := &Daemon{config: d.config, store: store, git: d.git, files: files}
+	restarted.loadProjectSearch()
+	if len(restarted.projectSearchPaths) != 1 || restarted.projectSearchPaths[0] != root {
+		t.Fatalf("expected saved search paths [%s], got %v", root, restarted.projectSearchPaths)
+	}
+	mkfile("new/Gemfile")
+	restarted.rescanProjectsIfDue(time.Now())
+	if files.IsWatched(filepath.Join(root, "new")) {
+		t.Error("expected no rescan within a week of the last scan")
+	}
+	restarted.rescanProjectsIfDue(time.Now().Add(maintenanceInterval))
+	if !files.IsWatched(filepath.Join(root, "new")) {
+		t.Error("expected the weekly rescan to watch the new project")
+	}
+}
+
+func TestDaemon_PauseRecordsManualAFK(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	d, err := NewDaemon(&DaemonConfig{DataDir: t.TempDir(), Interval: time.Minute, AFKTimeout: 5 * time.Minute}, store, NewMockPlatform())
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+	if _, err := d.session.StartSession(); err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+
+	d.Pause()
+	d.tick()
+	if !d.afk.IsAFK() {
+		t.Fatal("expected pause to start an AFK period")
+	}
+	events, _ := store.GetAFKEventsByTimeRange(0, time.Now().Unix()+1)
+	if len(events) != 1 || events[0].TriggerType != AFKTriggerManual {
+		t.Fatalf("expected one manual AFK event, got %+v", events)
+	}
+
+	// The next tick ends the period the same way (and then captures, so it isn't run here)
+	d.Resume()
+	d.afk.ForceReturn()
+	event, _ := store.GetAFKEvent(events[0].ID)
+	if event == nil || !event.EndTime.Valid {
+		t.Error("expected resume to close the manual AFK event")
+	}
+}
+
+func TestDaemon_PauseWhileIdleIgnoresInput(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	platform := NewMockPlatform()
+	d, err := NewDaemon(&DaemonConfig{DataDir: t.TempDir(), Interval: time.Minute, AFKTimeout: 5 * time.Minute}, store, platform)
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+	if _, err := d.session.StartSession(); err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+
+	// Go idle, then pause
+	platform.SetLastInputTime(time.Now().Add(-10 * time.Minute))
+	d.tick()
+	if d.afk.Trigger() != AFKTriggerIdleTimeout {
+		t.Fatalf("expected an idle AFK period, got %q", d.afk.Trigger())
+	}
+	d.Pause()
+
+	// Input while paused doesn't end the AFK period or start a session
+	platform.SetLastInputTime(time.Now())
+	d.tick()
+	if !d.afk.IsAFK() {
+		t.Error("expected to stay AFK while paused")
+	}
+	if id := d.session.GetCurrentSessionID(); id != 0 {
+		t.Errorf("expected no session while paused, got %d", id)
+	}
+}
+
+func TestDaemon_DiscardsShortSessionOnAFK(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	d, err := NewDaemon(&DaemonConfig{DataDir: t.TempDir(), Interval: time.Minute, AFKTimeout: 5 * time.Minute, MinSessionDurationSeconds: 30}, store, NewMockPlatform())
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+
+	// A session that has only been open for 10 seconds
+	id, _ := store.CreateSession(time.Now().Unix() - 10)
+	if _, err := d.session.StartSession(); err != nil {
+		t.Fatalf("StartSession failed: %v", err)
+	}
+
+	d.onAFK()
+
+	if sess, _ := store.GetSession(id); sess != nil {
+		t.Error("expected 10s session to be discarded with a 30s minimum")
+	}
+	events, _ := store.GetAFKEventsByTimeRange(0, time.Now().Unix()+1)
+	if len(events) != 1 || events[0].SessionID.Valid {
+		t.Errorf("expected one AFK event without a session, got %+v", events)
+	}
+}
+
+func TestDaemon_IdleAFKFallsInsideSession(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	mock := NewMockPlatform()
+	d, err := NewDaemon(&DaemonConfig{DataDir: t.TempDir(), Interval: time.Minute, AFKTimeout: 5 * time.Minute}, store, mock)
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+
+	// A session open for 20 minutes whose last input was 6 minutes ago
+	now := time.Now()
+	id, _ := store.CreateSession(now.Add(-20 * time.Minute).Unix())
+	d.session.currentSession, _ = store.GetSession(id)
+	lastInput := now.Add(-6 * time.Minute)
+	mock.SetLastInputTime(lastInput)
+
+	d.afk.Poll()
+	events, _ := store.GetAFKEventsByTimeRange(0, now.Unix()+1)
+	if len(events) != 1 || events[0].TriggerType != AFKTriggerIdleTimeout {
+		t.Fatalf("expected one idle AFK event, got %+v", events)
+	}
+	sess, _ := store.GetSession(id)
+	if events[0].StartTime != lastInput.Unix() || events[0].StartTime > sess.EndTime.Int64 {
+		t.Errorf("expected the AFK period to start at the last input (%d) within the session ending %d, got %d",
+			lastInput.Unix(), sess.EndTime.Int64, events[0].StartTime)
+	}
+
+	// Returning closes the period and keeps its session
+	mock.SetLastInputTime(time.Now())
+	d.afk.Poll()
+	event, _ := store.GetAFKEvent(events[0].ID)
+	if !event.EndTime.Valid || !event.SessionID.Valid || event.SessionID.Int64 != id {
+		t.Errorf("expected a closed AFK event in session %d, got %+v", id, event)
+	}
+}
+
+func TestDaemon_ActiveSessionChanged(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	d, err := NewDaemon(&DaemonConfig{DataDir: t.TempDir(), Interval: time.Minute, AFKTimeout: 5 * time.Minute}, store, NewMockPlatform())
+	if err != nil {
+		t.Fatalf("NewDaemon failed: %v", err)
+	}
+
+	changes := make(chan int64, 4)
+	d.SetOnActiveSessionChanged(func(sessionID int64) { changes <- sessionID })
+
+	expectChange := func(want int64) {
+		t.Helper()
+		select {
+		case got := <-changes:
+			if got != want {
+				t.Errorf("expected change to session %d, got %d", want, got)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("expected change to session %d", want)
+		}
+	}
+
+	session, _ := d.session.StartSession()
+	d.checkActiveSession()
+	expectChange(session.ID)
+
+	// No change, no callback
+	d.checkActiveSession()
+	d.session.EndSession()
+	d.checkActiveSession()
+	expectChange(0)
+}
+
+func TestDaemon_GetComponentHealthSummary(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	dataDir := t.TempDir()
+	d := &Daemon{
+		store:           store,
+		running:         true,
+		git:             NewGitTracker(store, dataDir),
+		browser:         NewBrowserTracker(&mockPlatformShell{}, store, dataDir),
+		shell:           NewShellTracker(&mockPlatformShell{historyPath: filepath.Join(dataDir, "missing_history")}, store, dataDir),
+		componentErrors: make(map[string]string),
+	}
+
+	now := time.Now().Unix()
+	store.SaveShellCommand(&storage.ShellCommand{Timestamp: now - 3600, Command: "go test ./...", ShellType: "bash"})
+	store.SaveShellCommand(&storage.ShellCommand{Timestamp: now - 48*3600, Command: "make", ShellType: "bash"})
+	_, err := d.shell.Poll(0)
+	d.setComponentError(storage.DataSourceShell, err)
+
+	health := d.GetComponentHealthSummary()
+	if len(health) != 5 {
+		t.Fatalf("expected 5 components, got %d", len(health))
+	}
+	shell := health[storage.DataSourceShell]
+	if !shell.Active || shell.LastEventAt != now-3600 || shell.EventsLast24h != 1 {
+		t.Errorf("unexpected shell health: %+v", shell)
+	}
+	if shell.ErrorMessage == "" {
+		t.Error("expected the missing history file to be reported")
+	}
+	if git := health[storage.DataSourceGit]; git.Active || git.LastEventAt != 0 {
+		t.Errorf("expected git to be inactive without repositories, got %+v", git)
+	}
+	if files := health[storage.DataSourceFiles]; files.Active || files.ErrorMessage == "" {
+		t.Errorf("expected the missing file watcher to be reported, got %+v", files)
+	}
+
+	// A successful poll clears the error
+	d.setComponentError(storage.DataSourceShell, nil)
+	if msg := d.GetComponentHealthSummary()[storage.DataSourceShell].ErrorMessage; msg != "" {
+		t.Errorf("expected error to be cleared, got %q", msg)
+	}
+
+	d.paused = true
+	if d.GetComponentHealthSummary()[storage.DataSourceScreenshots].Active {
+		t.Error("expected screenshots to be inactive while paused")
+	}
+}
+
+func TestDaemon_CheckTimezone(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+	d := &Daemon{store: store, timezone: "Europe/Berlin"}
+
+	d.checkTimezone("Europe/Berlin")
+	d.checkTimezone("")
+	d.checkTimezone("Asia/Tokyo")
+	d.checkTimezone("Asia/Tokyo")
+
+	changes, err := store.GetTimezoneChanges()
+	if err != nil {
+		t.Fatalf("GetTimezoneChanges failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("expected 1 timezone change, got %d", len(changes))
+	}
+	if changes[0].FromTZ != "Europe/Berlin" || changes[0].ToTZ != "Asia/Tokyo" {
+		t.Errorf("change = %s -> %s, want Europe/Berlin -> Asia/Tokyo", changes[0].FromTZ, changes[0].ToTZ)
+	}
+	if d.timezone != "Asia/Tokyo" {
+		t.Errorf("timezone = %q, want Asia/Tokyo", d.timezone)
+	}
+
+	if err := d.RecordTimezoneChange("Asia/Tokyo", "Asia/Tokyo", time.Now().Unix()); err == nil {
+		t.Error("expected error for unchanged timezone")
+	}
+}
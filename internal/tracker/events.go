@@ -0,0 +1,48 @@
+package tracker
+
+import "traq/internal/broadcast"
+
+// Event types published to the daemon's event hub.
+const (
+	EventFocus      = "focus"
+	EventAFKStart   = "afk_start"
+	EventAFKEnd     = "afk_end"
+	EventScreenshot = "screenshot"
+)
+
+// FocusEventPayload is published when the focused window changes.
+type FocusEventPayload struct {
+	Timestamp   int64  `json:"timestamp"`
+	AppName     string `json:"appName"`
+	WindowTitle string `json:"windowTitle"`
+}
+
+// AFKEventPayload is published when the user goes AFK or returns.
+type AFKEventPayload struct {
+	Timestamp int64  `json:"timestamp"`
+	Trigger   string `json:"trigger,omitempty"` // Only set for afk_start
+}
+
+// ScreenshotEventPayload is published after a screenshot is saved.
+type ScreenshotEventPayload struct {
+	ID        int64  `json:"id"`
+	Timestamp int64  `json:"timestamp"`
+	AppName   string `json:"appName"`
+}
+
+// SetEventHub sets the hub that focus, AFK and screenshot events are published to.
+func (d *Daemon) SetEventHub(hub *broadcast.Hub) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.events = hub
+}
+
+// publish sends an event to the event hub, if one is set.
+func (d *Daemon) publish(eventType string, payload any) {
+	d.mu.RLock()
+	hub := d.events
+	d.mu.RUnlock()
+	if hub != nil {
+		hub.Publish(eventType, payload)
+	}
+}
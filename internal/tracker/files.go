@@ -17,6 +17,7 @@ type FileTracker struct {
 	store           *storage.Store
 	watcher         *fsnotify.Watcher
 	watchedDirs     map[string]bool
+	watchCategories map[string]string // Watched root directory -> category, e.g. "projects"
 	excludePatterns []string
 	excludeExts     map[string]bool
 	allowedExts     map[string]bool // If non-empty, only track these extensions
@@ -38,9 +39,10 @@ func NewFileTracker(store *storage.Store) (*FileTracker, error) {
 	}
 
 	return &FileTracker{
-		store:       store,
-		watcher:     watcher,
-		watchedDirs: make(map[string]bool),
+		store:           store,
+		watcher:         watcher,
+		watchedDirs:     make(map[string]bool),
+		watchCategories: make(map[string]string),
 		excludePatterns: []string{
 			".git",
 			"node_modules",
@@ -129,11 +131,23 @@ func (t *FileTracker) SetSessionID(id int64) {
 
 // WatchDirectory adds a directory to watch (recursively).
 func (t *FileTracker) WatchDirectory(path string) error {
+	return t.WatchDirectoryWithCategory(path, "")
+}
+
+// WatchDirectoryWithCategory adds a directory to watch (recursively), recording events
+// under it with the given watch category.
+func (t *FileTracker) WatchDirectoryWithCategory(path, category string) error {
 	absPath, err := filepath.Abs(path)
 	if err != nil {
 		return err
 	}
 
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if category != "" {
+		t.watchCategories[absPath] = category
+	}
+
 	// Walk directory and add watches
 	return filepath.Walk(absPath, func(walkPath string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -169,6 +183,9 @@ func (t *FileTracker) UnwatchDirectory(path string) error {
 		return err
 	}
 
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
 	// Remove all watches under this path
 	for watchedPath := range t.watchedDirs {
 		if strings.HasPrefix(watchedPath, absPath) {
@@ -176,6 +193,11 @@ func (t *FileTracker) UnwatchDirectory(path string) error {
 			delete(t.watchedDirs, watchedPath)
 		}
 	}
+	for root := range t.watchCategories {
+		if strings.HasPrefix(root, absPath) {
+			delete(t.watchCategories, root)
+		}
+	}
 
 	return nil
 }
@@ -285,7 +307,9 @@ func (t *FileTracker) handleEvent(event fsnotify.Event) {
 		if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
 			if !t.shouldExcludeDir(event.Name) {
 				t.watcher.Add(event.Name)
+				t.mu.Lock()
 				t.watchedDirs[event.Name] = true
+				t.mu.Unlock()
 			}
 		}
 	case event.Op&fsnotify.Write == fsnotify.Write:
@@ -293,10 +317,12 @@ func (t *FileTracker) handleEvent(event fsnotify.Event) {
 	case event.Op&fsnotify.Remove == fsnotify.Remove:
 		eventType = "delete"
 		// Remove watch if directory
+		t.mu.Lock()
 		if t.watchedDirs[event.Name] {
 			t.watcher.Remove(event.Name)
 			delete(t.watchedDirs, event.Name)
 		}
+		t.mu.Unlock()
 	case event.Op&fsnotify.Rename == fsnotify.Rename:
 		eventType = "rename"
 	case event.Op&fsnotify.Chmod == fsnotify.Chmod:
@@ -316,6 +342,8 @@ func (t *FileTracker) handleEvent(event fsnotify.Event) {
 		SessionID: sql.NullInt64{Int64: sessionID, Valid: sessionID > 0},
 		FileName:  filepath.Base(event.Name),
 		Directory: filepath.Dir(event.Name),
+
+		WatchCategory: t.categoryFor(event.Name),
 	}
 
 	// Set file extension from path (doesn't need the file to exist)
@@ -402,8 +430,72 @@ func (t *FileTracker) shouldExcludeDir(path string) bool {
 	return false
 }
 
+// categoryFor returns the watch category of the most specific watched root containing path.
+func (t *FileTracker) categoryFor(path string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	var category string
+	longest := -1
+	for root, c := range t.watchCategories {
+		if (path == root || strings.HasPrefix(path, root+string(filepath.Separator))) && len(root) > longest {
+			category, longest = c, len(root)
+		}
+	}
+	return category
+}
+
+// IsWatched reports whether a directory is being watched.
+func (t *FileTracker) IsWatched(path string) bool {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.watchedDirs[absPath]
+}
+
+// findProjectDirs finds directories up to maxDepth below the search paths that contain one of
+// the manifest files. Projects nested inside another project aren't returned separately.
+func findProjectDirs(searchPaths, manifests []string, maxDepth int, exclude func(path string) bool) []string {
+	var projects []string
+	for _, searchPath := range searchPaths {
+		rootPath, err := filepath.Abs(expandPath(searchPath))
+		if err != nil {
+			continue
+		}
+
+		filepath.WalkDir(rootPath, func(path string, d os.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil // Skip inaccessible directories and files
+			}
+			if path != rootPath && (strings.HasPrefix(d.Name(), ".") || exclude(path)) {
+				return filepath.SkipDir
+			}
+
+			for _, manifest := range manifests {
+				if _, err := os.Stat(filepath.Join(path, manifest)); err == nil {
+					projects = append(projects, path)
+					return filepath.SkipDir
+				}
+			}
+
+			// Calculate current depth relative to root
+			relPath, _ := filepath.Rel(rootPath, path)
+			if relPath != "." && len(strings.Split(relPath, string(os.PathSeparator))) >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+	}
+	return projects
+}
+
 // GetWatchedDirectories returns the list of watched directories.
 func (t *FileTracker) GetWatchedDirectories() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
 	dirs := make([]string, 0, len(t.watchedDirs))
 	for dir := range t.watchedDirs {
 		dirs = append(dirs, dir)
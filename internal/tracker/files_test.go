@@ -1,6 +1,7 @@
 package tracker
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -748,3 +749,42 @@ func TestFileTracker_Close(t *testing.T) {
 	// Starting after close should... actually fsnotify might panic
 	// So we don't test that case
 }
+
+func TestFileTracker_ConcurrentWatchAndEvents(t *testing.T) {
+	store, tmpDir := setupFileTestDB(t)
+	defer os.RemoveAll(tmpDir)
+	defer store.Close()
+
+	tracker, err := NewFileTracker(store)
+	if err != nil {
+		t.Fatalf("NewFileTracker failed: %v", err)
+	}
+	defer tracker.Close()
+
+	watchDir := filepath.Join(tmpDir, "watch")
+	os.MkdirAll(watchDir, 0755)
+	tracker.WatchDirectory(watchDir)
+	tracker.Start()
+
+	// Projects are registered from the daemon's goroutine while the watcher handles events
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 20; i++ {
+			project := filepath.Join(watchDir, fmt.Sprintf("project%d", i))
+			os.MkdirAll(project, 0755)
+			tracker.WatchDirectoryWithCategory(project, "projects")
+		}
+	}()
+	for i := 0; i < 20; i++ {
+		os.WriteFile(filepath.Join(watchDir, fmt.Sprintf("file%d.txt", i)), []byte("x"), 0644)
+	}
+	<-done
+
+	if !tracker.IsWatched(filepath.Join(watchDir, "project19")) {
+		t.Error("expected project directory to be watched")
+	}
+	if got := tracker.categoryFor(filepath.Join(watchDir, "project3", "main.go")); got != "projects" {
+		t.Errorf("categoryFor = %q, want projects", got)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	"traq/internal/platform"
 	"traq/internal/storage"
 )
 
@@ -21,6 +22,7 @@ type GitTracker struct {
 	checkpointFile  string
 	maxCommits      int
 	onActivitySaved ActivitySavedCallback
+	onCommitSaved   CommitSavedCallback
 }
 
 // GitCheckpoint stores the last seen commit for each repository.
@@ -42,7 +44,7 @@ func (t *GitTracker) SetMaxCommits(max int) {
 	t.maxCommits = max
 }
 
-// RegisterRepository adds a repository to track.
+// RegisterRepository adds a repository to track, reactivating it if it was marked inactive.
 func (t *GitTracker) RegisterRepository(path string) (*storage.GitRepository, error) {
 	// Resolve to absolute path
 	absPath, err := filepath.Abs(path)
@@ -62,6 +64,12 @@ func (t *GitTracker) RegisterRepository(path string) (*storage.GitRepository, er
 	// Check if already registered
 	existing, err := t.store.GetGitRepositoryByPath(gitRoot)
 	if err == nil && existing != nil {
+		if !existing.IsActive {
+			if err := t.store.ReactivateGitRepository(existing.ID); err != nil {
+				return nil, err
+			}
+			existing.IsActive = true
+		}
 		return existing, nil
 	}
 
@@ -88,6 +96,23 @@ func (t *GitTracker) UnregisterRepository(repoID int64) error {
 	return t.store.SetGitRepositoryActive(repoID, false)
 }
 
+// GetStaleRepositories returns active repositories with no commits in the last days days.
+func (t *GitTracker) GetStaleRepositories(days int) ([]*storage.GitRepository, error) {
+	cutoff := time.Now().AddDate(0, 0, -days).Unix()
+	return t.store.GetGitRepositoriesNotSeenSince(cutoff)
+}
+
+// MarkRepositoriesInactive stops polling the given repositories until they're registered
+// or discovered again.
+func (t *GitTracker) MarkRepositoriesInactive(ids []int64) error {
+	for _, id := range ids {
+		if err := t.store.MarkRepositoryInactive(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Poll scans all registered repositories for new commits.
 func (t *GitTracker) Poll(sessionID int64) ([]*storage.GitCommit, error) {
 	repos, err := t.store.GetActiveGitRepositories()
@@ -126,6 +151,9 @@ func (t *GitTracker) Poll(sessionID int64) ([]*storage.GitCommit, error) {
 				if t.onActivitySaved != nil {
 					go t.onActivitySaved("git", id, "", "", repo.Path)
 				}
+				if t.onCommitSaved != nil {
+					t.onCommitSaved(commit)
+				}
 			}
 
 			// Update checkpoint with newest commit
@@ -220,6 +248,117 @@ func (t *GitTracker) getCurrentBranch(repoPath string) string {
 	return strings.TrimSpace(string(branchOutput))
 }
 
+// branchTrackedApps are the IDEs and terminals (lowercase app names) whose focus events
+// record the checked-out git branch.
+var branchTrackedApps = map[string]bool{
+	"code": true, "cursor": true, "vscodium": true, "zed": true, "sublime_text": true,
+	"idea": true, "goland": true, "pycharm": true, "webstorm": true, "intellij idea": true,
+	"vim": true, "nvim": true, "gvim": true, "emacs": true,
+	"terminal": true, "gnome-terminal": true, "gnome-terminal-server": true, "konsole": true,
+	"alacritty": true, "kitty": true, "wezterm": true, "xterm": true, "tilix": true,
+	"terminator": true, "foot": true, "ghostty": true, "iterm2": true, "windowsterminal": true,
+}
+
+// BranchForWindow returns the tracked repository a focused IDE or terminal window is working in,
+// and the branch checked out there. The working directory of the window's process is checked
+// first; a repository path or name in the window title is used as a fallback, since terminal
+// emulators rarely share their shell's directory. Returns nil for other windows or no match.
+func (t *GitTracker) BranchForWindow(info *platform.WindowInfo) (*storage.GitRepository, string) {
+	if info == nil || !branchTrackedApps[strings.ToLower(info.AppName)] {
+		return nil, ""
+	}
+
+	repos, err := t.store.GetActiveGitRepositories()
+	if err != nil || len(repos) == 0 {
+		return nil, ""
+	}
+
+	repo := repoContainingPath(repos, processWorkingDir(info.PID))
+	if repo == nil {
+		repo = repoFromWindowTitle(repos, info.Title)
+	}
+	if repo == nil {
+		return nil, ""
+	}
+
+	branch := t.getCurrentBranch(repo.Path)
+	if branch == "" {
+		return nil, ""
+	}
+	return repo, branch
+}
+
+// processWorkingDir returns the working directory of a process, or "" if unavailable.
+// Only Linux exposes this without extra tooling.
+func processWorkingDir(pid int) string {
+	if pid <= 0 {
+		return ""
+	}
+	dir, err := os.Readlink(fmt.Sprintf("/proc/%d/cwd", pid))
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// repoContainingPath returns the innermost repository containing path, or nil.
+func repoContainingPath(repos []*storage.GitRepository, path string) *storage.GitRepository {
+	if path == "" {
+		return nil
+	}
+	var best *storage.GitRepository
+	for _, repo := range repos {
+		if path == repo.Path || strings.HasPrefix(path, repo.Path+string(filepath.Separator)) {
+			if best == nil || len(repo.Path) > len(best.Path) {
+				best = repo
+			}
+		}
+	}
+	return best
+}
+
+// repoFromWindowTitle matches a window title against repository paths (including "~/" forms,
+// as shown by most terminals) and names (as shown by IDEs, e.g. "main.go - traq - Visual Studio Code").
+func repoFromWindowTitle(repos []*storage.GitRepository, title string) *storage.GitRepository {
+	var best *storage.GitRepository
+	for _, word := range strings.Fields(title) {
+		word = strings.TrimRight(word, ":$#>")
+		if strings.HasPrefix(word, "~/") || strings.HasPrefix(word, "/") {
+			if repo := repoContainingPath(repos, expandPath(word)); repo != nil && (best == nil || len(repo.Path) > len(best.Path)) {
+				best = repo
+			}
+		}
+	}
+	if best != nil {
+		return best
+	}
+
+	for _, part := range splitWindowTitle(title) {
+		for _, repo := range repos {
+			if repo.Name != "" && part == repo.Name {
+				return repo
+			}
+		}
+	}
+	return nil
+}
+
+// splitWindowTitle splits a window title on the separators apps put between its parts.
+func splitWindowTitle(title string) []string {
+	parts := []string{title}
+	for _, sep := range []string{" - ", " — ", " – "} {
+		var next []string
+		for _, part := range parts {
+			next = append(next, strings.Split(part, sep)...)
+		}
+		parts = next
+	}
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts
+}
+
 // getCommitDiffStats returns file change statistics for a commit.
 func (t *GitTracker) getCommitDiffStats(repoPath, hash string) commitStats {
 	stats := commitStats{}
@@ -263,6 +402,30 @@ func (t *GitTracker) findGitRoot(path string) (string, error) {
 	return strings.TrimSpace(string(output)), nil
 }
 
+// GitUserEmails returns the user's git emails: the global user.email plus the effective
+// user.email of each repository, since a repository can set its own. Unset values are skipped.
+func GitUserEmails(repoPaths []string) []string {
+	seen := make(map[string]bool)
+	var emails []string
+	add := func(args ...string) {
+		output, err := exec.Command("git", args...).Output()
+		if err != nil {
+			return
+		}
+		email := strings.TrimSpace(string(output))
+		if email != "" && !seen[strings.ToLower(email)] {
+			seen[strings.ToLower(email)] = true
+			emails = append(emails, email)
+		}
+	}
+
+	add("config", "--global", "user.email")
+	for _, path := range repoPaths {
+		add("-C", path, "config", "user.email")
+	}
+	return emails
+}
+
 // getRemoteURL returns the origin remote URL.
 func (t *GitTracker) getRemoteURL(repoPath string) string {
 	cmd := exec.Command("git", "-C", repoPath, "remote", "get-url", "origin")
@@ -323,7 +486,7 @@ func (t *GitTracker) GetRecentCommits(limit int) ([]*storage.GitCommit, error) {
 }
 
 // DiscoverRepositories searches for git repositories in the given paths up to maxDepth.
-// It returns a list of newly discovered repositories (excluding already tracked ones).
+// It returns the newly discovered and reactivated repositories, skipping ones already being tracked.
 func (t *GitTracker) DiscoverRepositories(searchPaths []string, maxDepth int) ([]*storage.GitRepository, error) {
 	if maxDepth <= 0 {
 		maxDepth = 3 // Default depth
@@ -348,13 +511,13 @@ func (t *GitTracker) DiscoverRepositories(searchPaths []string, maxDepth int) ([
 		}
 
 		for _, repoPath := range repos {
-			// Check if already tracked
+			// Skip repos already being tracked
 			existing, err := t.store.GetGitRepositoryByPath(repoPath)
-			if err == nil && existing != nil {
-				continue // Skip already tracked repos
+			if err == nil && existing != nil && existing.IsActive {
+				continue
 			}
 
-			// Register the new repository
+			// Register the new repository, or reactivate an inactive one
 			repo, err := t.RegisterRepository(repoPath)
 			if err != nil {
 				continue
@@ -4,9 +4,11 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"traq/internal/platform"
 	"traq/internal/storage"
 )
 
@@ -154,6 +156,21 @@ func TestGitTracker_RegisterRepository_AlreadyRegistered(t *testing.T) {
 	if len(repos) != 1 {
 		t.Errorf("Expected 1 repository, got %d", len(repos))
 	}
+
+	// Registering an inactive repository reactivates it
+	if err := tracker.MarkRepositoriesInactive([]int64{repo1.ID}); err != nil {
+		t.Fatalf("MarkRepositoriesInactive failed: %v", err)
+	}
+	repo3, err := tracker.RegisterRepository(repoPath)
+	if err != nil {
+		t.Fatalf("Third registration failed: %v", err)
+	}
+	if repo3.ID != repo1.ID || !repo3.IsActive {
+		t.Errorf("Expected repo %d to be reactivated, got %+v", repo1.ID, repo3)
+	}
+	if active, _ := store.GetActiveGitRepositories(); len(active) != 1 {
+		t.Errorf("Expected 1 active repository, got %d", len(active))
+	}
 }
 
 func TestGitTracker_RegisterRepository_NotGitRepo(t *testing.T) {
@@ -727,3 +744,68 @@ func TestGitTracker_SetMaxCommits(t *testing.T) {
 		t.Errorf("Expected max 2 commits (MaxCommits=2), got %d", len(commits))
 	}
 }
+
+func TestGitTracker_BranchForWindow(t *testing.T) {
+	store, tmpDir := setupGitTestDB(t)
+	defer os.RemoveAll(tmpDir)
+	defer store.Close()
+
+	repoPath := createTestGitRepo(t, tmpDir, "branchy")
+	addCommitToRepo(t, repoPath, "Initial commit")
+	cmd := exec.Command("git", "checkout", "-b", "feature/branches")
+	cmd.Dir = repoPath
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("Failed to create branch: %v", err)
+	}
+
+	tracker := NewGitTracker(store, tmpDir)
+	registered, err := tracker.RegisterRepository(repoPath)
+	if err != nil {
+		t.Fatalf("RegisterRepository failed: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		window *platform.WindowInfo
+		want   bool
+	}{
+		{"terminal with path in title", &platform.WindowInfo{AppName: "gnome-terminal-server", Title: "user@host: " + filepath.Join(repoPath, "internal")}, true},
+		{"IDE with repo name in title", &platform.WindowInfo{AppName: "Code", Title: "main.go - branchy - Visual Studio Code"}, true},
+		{"IDE outside any repo", &platform.WindowInfo{AppName: "code", Title: "notes.md - scratch - Visual Studio Code"}, false},
+		{"browser mentioning the repo", &platform.WindowInfo{AppName: "firefox", Title: "branchy - GitHub"}, false},
+		{"nil window", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, branch := tracker.BranchForWindow(tt.window)
+			if !tt.want {
+				if repo != nil {
+					t.Errorf("expected no repository, got %s on %s", repo.Path, branch)
+				}
+				return
+			}
+			if repo == nil || repo.ID != registered.ID {
+				t.Fatalf("expected repository %d, got %+v", registered.ID, repo)
+			}
+			if branch != "feature/branches" {
+				t.Errorf("expected branch 'feature/branches', got %q", branch)
+			}
+		})
+	}
+}
+
+func TestGitUserEmails_IncludesRepositoryEmails(t *testing.T) {
+	tmpDir := t.TempDir()
+	// Point the global config at an empty file so the test doesn't depend on the machine
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(tmpDir, "gitconfig"))
+	os.WriteFile(filepath.Join(tmpDir, "gitconfig"), []byte("[user]\n\temail = me@home.example\n"), 0644)
+
+	repoPath := createTestGitRepo(t, tmpDir, "work")
+	plain := createTestGitRepo(t, tmpDir, "plain")
+	exec.Command("git", "-C", plain, "config", "--unset", "user.email").Run()
+
+	emails := GitUserEmails([]string{repoPath, plain, filepath.Join(tmpDir, "missing")})
+	if strings.Join(emails, ",") != "me@home.example,test@example.com" {
+		t.Errorf("expected the global and repository emails, got %v", emails)
+	}
+}
@@ -21,6 +21,7 @@ type MockPlatform struct {
 	openURLCalled     bool
 	notificationCalls []struct{ Title, Body string }
 	autoStartEnabled  bool
+	hotkeys           map[string]func()
 }
 
 func NewMockPlatform() *MockPlatform {
@@ -113,3 +114,23 @@ func (m *MockPlatform) IsAutoStartEnabled() (bool, error) {
 func (m *MockPlatform) GetSystemTheme() string {
 	return "light"
 }
+
+// MachineID returns a fixed mock machine ID.
+func (m *MockPlatform) MachineID() string {
+	return "test-machine"
+}
+
+// RegisterHotkey records the hotkey handler for testing.
+func (m *MockPlatform) RegisterHotkey(shortcut string, handler func()) error {
+	if m.hotkeys == nil {
+		m.hotkeys = make(map[string]func())
+	}
+	m.hotkeys[shortcut] = handler
+	return nil
+}
+
+// UnregisterHotkey removes a recorded hotkey handler.
+func (m *MockPlatform) UnregisterHotkey(shortcut string) error {
+	delete(m.hotkeys, shortcut)
+	return nil
+}
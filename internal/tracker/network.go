@@ -0,0 +1,348 @@
+package tracker
+
+import (
+	"bufio"
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// Connection is an established TCP connection observed on the system.
+type Connection struct {
+	LocalAddr     string
+	RemoteIP      net.IP
+	RemotePort    int
+	ProcessName   string // Empty if the owning process couldn't be determined
+	BytesSent     int64  // Zero where the platform doesn't report per-connection byte counts
+	BytesReceived int64
+}
+
+// ConnectionReader lists the system's established TCP connections.
+type ConnectionReader interface {
+	ReadConnections() ([]*Connection, error)
+}
+
+// NetworkMonitor records new TCP connections to external web hosts (ports 80 and 443),
+// resolving remote addresses to hostnames with reverse DNS lookups.
+type NetworkMonitor struct {
+	store        *storage.Store
+	reader       ConnectionReader
+	lookupAddr   func(addr string) ([]string, error)
+	pollInterval time.Duration
+	lastPoll     time.Time
+	open         map[string]bool   // Connections seen in the previous poll
+	hostnames    map[string]string // IP -> resolved hostname
+	primed       bool              // True once the initial connections have been observed
+}
+
+// NewNetworkMonitor creates a new NetworkMonitor.
+// If reader is nil, the system's connection table is used.
+func NewNetworkMonitor(store *storage.Store, reader ConnectionReader) *NetworkMonitor {
+	if reader == nil {
+		reader = &systemConnectionReader{}
+	}
+	return &NetworkMonitor{
+		store:        store,
+		reader:       reader,
+		lookupAddr:   net.LookupAddr,
+		pollInterval: 30 * time.Second,
+		open:         make(map[string]bool),
+		hostnames:    make(map[string]string),
+	}
+}
+
+// Poll records connections to external web hosts opened since the previous poll.
+// Calls made within the poll interval of the previous check are ignored.
+func (m *NetworkMonitor) Poll(sessionID int64) ([]*storage.NetworkEvent, error) {
+	now := time.Now()
+	if !m.lastPoll.IsZero() && now.Sub(m.lastPoll) < m.pollInterval {
+		return nil, nil
+	}
+	m.lastPoll = now
+
+	conns, err := m.reader.ReadConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	current := make(map[string]bool)
+	var opened []*Connection
+	for _, conn := range conns {
+		if !isWebPort(conn.RemotePort) || !isExternalIP(conn.RemoteIP) {
+			continue
+		}
+		key := conn.LocalAddr + "->" + net.JoinHostPort(conn.RemoteIP.String(), strconv.Itoa(conn.RemotePort))
+		current[key] = true
+		if !m.open[key] {
+			opened = append(opened, conn)
+		}
+	}
+	m.open = current
+
+	// Don't record connections that were already open before tracking started
+	if !m.primed {
+		m.primed = true
+		return nil, nil
+	}
+
+	var events []*storage.NetworkEvent
+	for _, conn := range opened {
+		event := &storage.NetworkEvent{
+			Timestamp:     now.Unix(),
+			Hostname:      m.hostname(conn.RemoteIP),
+			ProcessName:   sql.NullString{String: conn.ProcessName, Valid: conn.ProcessName != ""},
+			BytesSent:     conn.BytesSent,
+			BytesReceived: conn.BytesReceived,
+			SessionID:     sql.NullInt64{Int64: sessionID, Valid: sessionID > 0},
+		}
+		id, err := m.store.SaveNetworkEvent(event)
+		if err != nil {
+			return events, err
+		}
+		event.ID = id
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// hostname resolves an IP to a hostname, caching the result. Falls back to the IP.
+func (m *NetworkMonitor) hostname(ip net.IP) string {
+	addr := ip.String()
+	if name, ok := m.hostnames[addr]; ok {
+		return name
+	}
+	name := addr
+	if names, err := m.lookupAddr(addr); err == nil && len(names) > 0 {
+		name = strings.TrimSuffix(names[0], ".")
+	}
+	m.hostnames[addr] = name
+	return name
+}
+
+// isWebPort reports whether a remote port is HTTP or HTTPS.
+func isWebPort(port int) bool {
+	return port == 80 || port == 443
+}
+
+// isExternalIP reports whether an IP is a public internet address.
+func isExternalIP(ip net.IP) bool {
+	return ip != nil && !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsMulticast()
+}
+
+// systemConnectionReader reads TCP connections using /proc on Linux and netstat on macOS.
+type systemConnectionReader struct{}
+
+// ReadConnections lists established TCP connections. Other platforms aren't supported.
+func (r *systemConnectionReader) ReadConnections() ([]*Connection, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return readProcNetTCP("/proc")
+	case "darwin":
+		out, err := exec.Command("netstat", "-anv", "-p", "tcp").Output()
+		if err != nil {
+			return nil, fmt.Errorf("failed to run netstat: %w", err)
+		}
+		return parseNetstat(out, darwinProcessName), nil
+	default:
+		return nil, nil
+	}
+}
+
+// tcpEstablished is the connection state code for ESTABLISHED in /proc/net/tcp.
+const tcpEstablished = "01"
+
+// readProcNetTCP reads established IPv4 and IPv6 connections from procRoot/net/tcp{,6}.
+// /proc/net/tcp doesn't report byte counts, so they're left at zero.
+func readProcNetTCP(procRoot string) ([]*Connection, error) {
+	var conns []*Connection
+	inodes := make(map[string]*Connection)
+	for _, name := range []string{"tcp", "tcp6"} {
+		data, err := os.ReadFile(filepath.Join(procRoot, "net", name))
+		if err != nil {
+			if name == "tcp" {
+				return nil, fmt.Errorf("failed to read /proc/net/tcp: %w", err)
+			}
+			continue
+		}
+		for _, entry := range parseProcNetTCP(data) {
+			conns = append(conns, entry.conn)
+			// Only web connections need their owning process looked up
+			if isWebPort(entry.conn.RemotePort) && entry.inode != "0" {
+				inodes[entry.inode] = entry.conn
+			}
+		}
+	}
+	if len(inodes) > 0 {
+		resolveSocketProcesses(procRoot, inodes)
+	}
+	return conns, nil
+}
+
+// procNetTCPEntry is an established connection parsed from /proc/net/tcp with its socket inode.
+type procNetTCPEntry struct {
+	conn  *Connection
+	inode string
+}
+
+// parseProcNetTCP parses the established connections in a /proc/net/tcp or tcp6 file.
+func parseProcNetTCP(data []byte) []procNetTCPEntry {
+	var entries []procNetTCPEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Scan() // Skip header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 10 || fields[3] != tcpEstablished {
+			continue
+		}
+		remoteIP, remotePort, ok := parseProcNetAddr(fields[2])
+		if !ok {
+			continue
+		}
+		entries = append(entries, procNetTCPEntry{
+			conn: &Connection{
+				LocalAddr:  fields[1],
+				RemoteIP:   remoteIP,
+				RemotePort: remotePort,
+			},
+			inode: fields[9],
+		})
+	}
+	return entries
+}
+
+// parseProcNetAddr parses a /proc/net/tcp address like "0100007F:01BB". The IP is hex-encoded
+// as 32-bit words in host byte order.
+func parseProcNetAddr(s string) (net.IP, int, bool) {
+	hexIP, hexPort, ok := strings.Cut(s, ":")
+	if !ok {
+		return nil, 0, false
+	}
+	raw, err := hex.DecodeString(hexIP)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil, 0, false
+	}
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return nil, 0, false
+	}
+
+	ip := make(net.IP, len(raw))
+	for i := 0; i < len(raw); i += 4 {
+		binary.BigEndian.PutUint32(ip[i:], binary.LittleEndian.Uint32(raw[i:]))
+	}
+	return ip, int(port), true
+}
+
+// resolveSocketProcesses sets ProcessName on connections by finding the processes that hold
+// their socket inodes open. Processes owned by other users can't be inspected and are skipped.
+func resolveSocketProcesses(procRoot string, inodes map[string]*Connection) {
+	pids, _ := filepath.Glob(filepath.Join(procRoot, "[0-9]*"))
+	for _, pidDir := range pids {
+		fds, err := os.ReadDir(filepath.Join(pidDir, "fd"))
+		if err != nil {
+			continue
+		}
+		for _, fd := range fds {
+			link, err := os.Readlink(filepath.Join(pidDir, "fd", fd.Name()))
+			if err != nil || !strings.HasPrefix(link, "socket:[") {
+				continue
+			}
+			conn, ok := inodes[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")]
+			if !ok || conn.ProcessName != "" {
+				continue
+			}
+			if comm, err := os.ReadFile(filepath.Join(pidDir, "comm")); err == nil {
+				conn.ProcessName = strings.TrimSpace(string(comm))
+			}
+		}
+	}
+}
+
+// parseNetstat parses established connections from macOS `netstat -anv -p tcp` output.
+// Columns are located by header name since they vary between macOS versions; processName
+// resolves a pid when the output doesn't include process names.
+func parseNetstat(out []byte, processName func(pid int) string) []*Connection {
+	var conns []*Connection
+	columns := map[string]int{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "Proto" {
+			// "Local Address" and "Foreign Address" are one column each in the data rows
+			offset := 0
+			for i, name := range fields {
+				if name == "Address" {
+					offset++
+					continue
+				}
+				columns[name] = i - offset
+			}
+			continue
+		}
+		if !strings.HasPrefix(fields[0], "tcp") || len(fields) < 6 || fields[5] != "ESTABLISHED" {
+			continue
+		}
+
+		remoteIP, remotePort, ok := parseNetstatAddr(fields[4])
+		if !ok {
+			continue
+		}
+		conn := &Connection{LocalAddr: fields[3], RemoteIP: remoteIP, RemotePort: remotePort}
+		if i, ok := columns["rxbytes"]; ok && i < len(fields) {
+			conn.BytesReceived, _ = strconv.ParseInt(fields[i], 10, 64)
+		}
+		if i, ok := columns["txbytes"]; ok && i < len(fields) {
+			conn.BytesSent, _ = strconv.ParseInt(fields[i], 10, 64)
+		}
+		if i, ok := columns["process:pid"]; ok && i < len(fields) {
+			if name, _, ok := strings.Cut(fields[i], ":"); ok {
+				conn.ProcessName = name
+			}
+		} else if i, ok := columns["pid"]; ok && i < len(fields) && processName != nil {
+			if pid, err := strconv.Atoi(fields[i]); err == nil {
+				conn.ProcessName = processName(pid)
+			}
+		}
+		conns = append(conns, conn)
+	}
+	return conns
+}
+
+// parseNetstatAddr parses a netstat address like "17.253.144.10.443" or "2606:4700::6810.443".
+func parseNetstatAddr(s string) (net.IP, int, bool) {
+	i := strings.LastIndex(s, ".")
+	if i < 0 {
+		return nil, 0, false
+	}
+	ip := net.ParseIP(s[:i])
+	port, err := strconv.Atoi(s[i+1:])
+	if ip == nil || err != nil {
+		return nil, 0, false
+	}
+	return ip, port, true
+}
+
+// darwinProcessName looks up a process name with ps.
+func darwinProcessName(pid int) string {
+	out, err := exec.Command("ps", "-o", "comm=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return ""
+	}
+	return filepath.Base(strings.TrimSpace(string(out)))
+}
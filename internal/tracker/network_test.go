@@ -0,0 +1,144 @@
+package tracker
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type mockConnectionReader struct {
+	conns []*Connection
+}
+
+func (r *mockConnectionReader) ReadConnections() ([]*Connection, error) {
+	return r.conns, nil
+}
+
+func TestNetworkMonitor_Poll(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	reader := &mockConnectionReader{}
+	m := NewNetworkMonitor(store, reader)
+	m.pollInterval = 0
+	lookups := 0
+	m.lookupAddr = func(addr string) ([]string, error) {
+		lookups++
+		if addr == "140.82.112.3" {
+			return []string{"lb-140-82-112-3-iad.github.com."}, nil
+		}
+		return nil, fmt.Errorf("no PTR record")
+	}
+
+	github := &Connection{LocalAddr: "a", RemoteIP: net.ParseIP("140.82.112.3"), RemotePort: 443, ProcessName: "firefox"}
+	reader.conns = []*Connection{github}
+
+	// Connections open before tracking started aren't recorded
+	if events, _ := m.Poll(1); len(events) != 0 {
+		t.Fatalf("expected no events on first poll, got %d", len(events))
+	}
+
+	sessionID, _ := store.CreateSession(time.Now().Unix())
+	reader.conns = []*Connection{
+		github,
+		{LocalAddr: "b", RemoteIP: net.ParseIP("140.82.112.3"), RemotePort: 443, ProcessName: "git"},
+		{LocalAddr: "c", RemoteIP: net.ParseIP("93.184.215.14"), RemotePort: 80},
+		{LocalAddr: "d", RemoteIP: net.ParseIP("93.184.215.14"), RemotePort: 22}, // Not a web port
+		{LocalAddr: "e", RemoteIP: net.ParseIP("192.168.1.10"), RemotePort: 443}, // Private
+		{LocalAddr: "f", RemoteIP: net.ParseIP("127.0.0.1"), RemotePort: 80},     // Loopback
+	}
+	events, err := m.Poll(sessionID)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 new web connections, got %+v", events)
+	}
+	if events[0].Hostname != "lb-140-82-112-3-iad.github.com" || events[0].ProcessName.String != "git" {
+		t.Errorf("expected resolved github host from git, got %+v", events[0])
+	}
+	if events[1].Hostname != "93.184.215.14" || events[1].ProcessName.Valid {
+		t.Errorf("expected unresolved IP without process, got %+v", events[1])
+	}
+	if lookups != 2 {
+		t.Errorf("expected hostnames to be cached, got %d lookups", lookups)
+	}
+
+	// A closed and reopened connection is recorded again
+	reader.conns = nil
+	m.Poll(sessionID)
+	reader.conns = []*Connection{github}
+	if events, _ := m.Poll(sessionID); len(events) != 1 {
+		t.Errorf("expected reopened connection to be recorded, got %d", len(events))
+	}
+
+	saved, _ := store.GetNetworkEventsByTimeRange(0, time.Now().Unix()+1)
+	if len(saved) != 3 {
+		t.Errorf("expected 3 saved events, got %d", len(saved))
+	}
+}
+
+func TestReadProcNetTCP(t *testing.T) {
+	root := t.TempDir()
+	os.MkdirAll(filepath.Join(root, "net"), 0755)
+	os.WriteFile(filepath.Join(root, "net", "tcp"), []byte(
+		"  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"+
+			"   0: 0100007F:BC6E 0370528C:01BB 01 00000000:00000000 02:000013F2 00000000  1000        0 71606 2 0 20 4 0 17 -1\n"+
+			"   1: 00000000:07E8 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 662 1 0 100 0 0 10 0\n"+
+			"   2: 0100007F:BC70 0370528C:0016 01 00000000:00000000 02:000013F2 00000000  1000        0 71608 2 0 20 4 0 17 -1\n"), 0644)
+	os.WriteFile(filepath.Join(root, "net", "tcp6"), []byte(
+		"  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n"+
+			"   0: 00000000000000000000000001000000:C350 004706260000000000000000AE108C68:01BB 01 00000000:00000000 00:00000000 00000000  1000        0 71700 1 0 20 4 0 17 -1\n"), 0644)
+
+	// Process 42 owns the first socket
+	os.MkdirAll(filepath.Join(root, "42", "fd"), 0755)
+	os.WriteFile(filepath.Join(root, "42", "comm"), []byte("firefox\n"), 0644)
+	os.Symlink("socket:[71606]", filepath.Join(root, "42", "fd", "3"))
+
+	conns, err := readProcNetTCP(root)
+	if err != nil {
+		t.Fatalf("readProcNetTCP failed: %v", err)
+	}
+	if len(conns) != 3 {
+		t.Fatalf("expected 3 established connections, got %d", len(conns))
+	}
+	if got := conns[0].RemoteIP.String(); got != "140.82.112.3" || conns[0].RemotePort != 443 {
+		t.Errorf("expected 140.82.112.3:443, got %s:%d", got, conns[0].RemotePort)
+	}
+	if conns[0].ProcessName != "firefox" {
+		t.Errorf("expected firefox to own the connection, got %q", conns[0].ProcessName)
+	}
+	if conns[1].RemotePort != 22 {
+		t.Errorf("expected ssh connection on port 22, got %d", conns[1].RemotePort)
+	}
+	if got := conns[2].RemoteIP.String(); got != "2606:4700::688c:10ae" || conns[2].RemotePort != 443 {
+		t.Errorf("expected [2606:4700::688c:10ae]:443, got %s:%d", got, conns[2].RemotePort)
+	}
+}
+
+func TestParseNetstat(t *testing.T) {
+	out := []byte(`Active Internet connections (including servers)
+Proto Recv-Q Send-Q  Local Address          Foreign Address        (state)      rxbytes      txbytes  rhiwat  shiwat    pid   epid state  options
+tcp4       0      0  192.168.1.5.52001      140.82.112.3.443       ESTABLISHED     5000         1200  131072  131768    812      0 00102 00000008
+tcp6       0      0  2001:db8::5.52002      2606:4700::6810.443    ESTABLISHED      300          100  131072  131768    913      0 00102 00000008
+tcp4       0      0  *.22                   *.*                    LISTEN             0            0  131072  131072     77      0 00100 00000006
+`)
+	names := map[int]string{812: "Safari", 913: "curl"}
+	conns := parseNetstat(out, func(pid int) string { return names[pid] })
+	if len(conns) != 2 {
+		t.Fatalf("expected 2 established connections, got %d", len(conns))
+	}
+	c := conns[0]
+	if c.RemoteIP.String() != "140.82.112.3" || c.RemotePort != 443 || c.ProcessName != "Safari" {
+		t.Errorf("unexpected connection %+v", c)
+	}
+	if c.BytesReceived != 5000 || c.BytesSent != 1200 {
+		t.Errorf("expected 5000 received, 1200 sent, got %d/%d", c.BytesReceived, c.BytesSent)
+	}
+	if conns[1].RemoteIP.String() != "2606:4700::6810" || conns[1].ProcessName != "curl" {
+		t.Errorf("unexpected IPv6 connection %+v", conns[1])
+	}
+}
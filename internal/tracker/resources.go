@@ -0,0 +1,152 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"time"
+
+	"traq/internal/platform"
+	"traq/internal/storage"
+)
+
+const (
+	// resourceSnapshotInterval is how often the daemon records a resource snapshot.
+	resourceSnapshotInterval = 15 * time.Minute
+	// resourceSnapshotRetention is how long resource snapshots are kept.
+	resourceSnapshotRetention = 30 * 24 * time.Hour
+)
+
+// processStart approximates when the process started, for the first CPU sample.
+var processStart = time.Now()
+
+// ResourceUsage is Traq's own resource footprint at a point in time.
+type ResourceUsage struct {
+	CPUPercent          float64 `json:"cpuPercent"` // Percent of one core since the previous sample
+	MemoryMB            float64 `json:"memoryMb"`   // Memory obtained from the OS by the Go runtime
+	DBSizeMB            float64 `json:"dbSizeMb"`   // Including the WAL and shared-memory files
+	ScreenshotDirSizeMB float64 `json:"screenshotDirSizeMb"`
+	OpenFileDescriptors int     `json:"openFileDescriptors"` // 0 where the platform can't report it
+	GoRoutineCount      int     `json:"goRoutineCount"`
+}
+
+// ResourceMonitor measures Traq's resource usage and records periodic snapshots.
+type ResourceMonitor struct {
+	store            *storage.Store
+	plat             platform.Platform
+	dataDir          string
+	snapshotInterval time.Duration
+	lastSnapshot     time.Time
+
+	mu          sync.Mutex
+	lastCPUTime time.Duration // Process CPU time at the previous sample
+	lastSample  time.Time
+}
+
+// NewResourceMonitor creates a new ResourceMonitor for the data directory.
+func NewResourceMonitor(store *storage.Store, plat platform.Platform, dataDir string) *ResourceMonitor {
+	return &ResourceMonitor{
+		store:            store,
+		plat:             plat,
+		dataDir:          dataDir,
+		snapshotInterval: resourceSnapshotInterval,
+		lastSample:       processStart,
+	}
+}
+
+// Usage measures current resource usage. CPU usage is averaged over the time since
+// the previous call, or since the process started on the first call.
+func (m *ResourceMonitor) Usage() *ResourceUsage {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	usage := &ResourceUsage{
+		CPUPercent:          m.cpuPercent(),
+		MemoryMB:            bytesToMB(int64(mem.Sys)),
+		ScreenshotDirSizeMB: bytesToMB(dirSize(filepath.Join(m.dataDir, "screenshots"))),
+		GoRoutineCount:      runtime.NumGoroutine(),
+	}
+
+	if m.store != nil {
+		var dbSize int64
+		for _, suffix := range []string{"", "-wal", "-shm"} {
+			if info, err := os.Stat(m.store.Path() + suffix); err == nil {
+				dbSize += info.Size()
+			}
+		}
+		usage.DBSizeMB = bytesToMB(dbSize)
+	}
+
+	if stats, ok := m.plat.(platform.ProcessStatsProvider); ok {
+		usage.OpenFileDescriptors, _ = stats.OpenFileDescriptors()
+	}
+
+	return usage
+}
+
+// cpuPercent returns CPU usage since the previous sample as a percent of one core.
+func (m *ResourceMonitor) cpuPercent() float64 {
+	stats, ok := m.plat.(platform.ProcessStatsProvider)
+	if !ok {
+		return 0
+	}
+	cpuTime, err := stats.ProcessCPUTime()
+	if err != nil {
+		return 0
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	elapsed := now.Sub(m.lastSample)
+	used := cpuTime - m.lastCPUTime
+	m.lastCPUTime, m.lastSample = cpuTime, now
+	if elapsed <= 0 || used < 0 {
+		return 0
+	}
+	return float64(used) / float64(elapsed) * 100
+}
+
+// Poll records a snapshot if snapshotInterval has passed since the last one, and prunes
+// snapshots older than the retention period.
+func (m *ResourceMonitor) Poll() {
+	now := time.Now()
+	if !m.lastSnapshot.IsZero() && now.Sub(m.lastSnapshot) < m.snapshotInterval {
+		return
+	}
+	m.lastSnapshot = now
+
+	usage := m.Usage()
+	m.store.SaveResourceSnapshot(&storage.ResourceSnapshot{
+		Timestamp:           now.Unix(),
+		CPUPercent:          usage.CPUPercent,
+		MemoryMB:            usage.MemoryMB,
+		DBSizeMB:            usage.DBSizeMB,
+		ScreenshotDirSizeMB: usage.ScreenshotDirSizeMB,
+		OpenFileDescriptors: usage.OpenFileDescriptors,
+		GoRoutineCount:      usage.GoRoutineCount,
+	})
+	m.store.DeleteResourceSnapshotsBefore(now.Add(-resourceSnapshotRetention).Unix())
+}
+
+// dirSize returns the total size of the files under path.
+func dirSize(path string) int64 {
+	var size int64
+	filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil // Skip files we can't access
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// bytesToMB converts a byte count to megabytes.
+func bytesToMB(bytes int64) float64 {
+	return float64(bytes) / (1024 * 1024)
+}
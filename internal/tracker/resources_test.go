@@ -0,0 +1,79 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// processStatsPlatform is a MockPlatform that reports process stats.
+type processStatsPlatform struct {
+	*MockPlatform
+	cpuTime time.Duration
+	fds     int
+}
+
+func (p *processStatsPlatform) ProcessCPUTime() (time.Duration, error) { return p.cpuTime, nil }
+func (p *processStatsPlatform) OpenFileDescriptors() (int, error)      { return p.fds, nil }
+
+func TestResourceMonitor_Usage(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	dataDir := t.TempDir()
+	shotDir := filepath.Join(dataDir, "screenshots", "2024", "03")
+	os.MkdirAll(shotDir, 0755)
+	os.WriteFile(filepath.Join(shotDir, "a.webp"), make([]byte, 512*1024), 0644)
+	os.WriteFile(filepath.Join(shotDir, "b.webp"), make([]byte, 512*1024), 0644)
+
+	plat := &processStatsPlatform{MockPlatform: NewMockPlatform(), fds: 17}
+	m := NewResourceMonitor(store, plat, dataDir)
+	m.Usage()
+
+	// Half a second of CPU over one second of wall time is 50%
+	m.lastSample = time.Now().Add(-time.Second)
+	plat.cpuTime += 500 * time.Millisecond
+	usage := m.Usage()
+
+	if usage.CPUPercent < 40 || usage.CPUPercent > 51 {
+		t.Errorf("expected CPU around 50%%, got %.1f", usage.CPUPercent)
+	}
+	if usage.ScreenshotDirSizeMB != 1 {
+		t.Errorf("expected 1 MB of screenshots, got %.2f", usage.ScreenshotDirSizeMB)
+	}
+	if usage.DBSizeMB <= 0 || usage.MemoryMB <= 0 || usage.GoRoutineCount <= 0 {
+		t.Errorf("expected database size, memory and goroutines to be measured, got %+v", usage)
+	}
+	if usage.OpenFileDescriptors != 17 {
+		t.Errorf("expected 17 open file descriptors, got %d", usage.OpenFileDescriptors)
+	}
+}
+
+func TestResourceMonitor_Poll(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	m := NewResourceMonitor(store, NewMockPlatform(), t.TempDir())
+	m.Poll()
+	m.Poll() // Within the snapshot interval, so not recorded
+
+	now := time.Now().Unix()
+	snapshots, err := store.GetResourceSnapshots(now-60, now+60)
+	if err != nil {
+		t.Fatalf("GetResourceSnapshots failed: %v", err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("expected 1 snapshot, got %d", len(snapshots))
+	}
+	if snapshots[0].CPUPercent != 0 {
+		t.Errorf("expected no CPU usage without process stats, got %.1f", snapshots[0].CPUPercent)
+	}
+
+	m.lastSnapshot = time.Now().Add(-resourceSnapshotInterval)
+	m.Poll()
+	snapshots, _ = store.GetResourceSnapshots(now-60, now+60)
+	if len(snapshots) != 2 {
+		t.Errorf("expected a second snapshot after the interval, got %d", len(snapshots))
+	}
+}
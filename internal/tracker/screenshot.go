@@ -31,6 +31,7 @@ type CaptureResult struct {
 	Height        int
 	MonitorIndex  int
 	MonitorName   string
+	Quality       int // WebP quality the screenshot was encoded with
 }
 
 // NewScreenCapture creates a new ScreenCapture instance.
@@ -51,6 +52,13 @@ func (c *ScreenCapture) SetThumbnailWidth(width int) {
 	c.thumbnailWidth = width
 }
 
+// SetQuality sets the WebP quality (1-100) of subsequent captures.
+func (c *ScreenCapture) SetQuality(quality int) {
+	if quality > 0 && quality <= 100 {
+		c.quality = quality
+	}
+}
+
 // SetDuplicateThreshold sets the hamming distance threshold for duplicate detection.
 func (c *ScreenCapture) SetDuplicateThreshold(threshold int) {
 	c.duplicateThresh = threshold
@@ -115,6 +123,7 @@ func (c *ScreenCapture) Capture() (*CaptureResult, error) {
 		Height:        bounds.Dy(),
 		MonitorIndex:  monitorIndex,
 		MonitorName:   fmt.Sprintf("Display %d", monitorIndex),
+		Quality:       c.quality,
 	}, nil
 }
 
@@ -168,6 +177,7 @@ func (c *ScreenCapture) CaptureMonitor(monitorIndex int) (*CaptureResult, error)
 		Height:        bounds.Dy(),
 		MonitorIndex:  monitorIndex,
 		MonitorName:   fmt.Sprintf("Display %d", monitorIndex),
+		Quality:       c.quality,
 	}, nil
 }
 
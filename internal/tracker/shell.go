@@ -125,22 +125,78 @@ func (t *ShellTracker) Poll(sessionID int64) ([]*storage.ShellCommand, error) {
 	}
 
 	shellType := t.GetShellType()
+	return t.pollFile(histPath, sessionID, func(scanner *bufio.Scanner) []*storage.ShellCommand {
+		switch shellType {
+		case "zsh":
+			return t.parseZshHistory(scanner)
+		case "fish":
+			return t.parseFishHistory(scanner)
+		default: // bash
+			return t.parseBashHistory(scanner)
+		}
+	})
+}
+
+// PollHookLog reads new commands recorded by a shell hook (see ShellHook) and saves them.
+// Hooks record commands as they finish, so they're read before the history file to keep
+// the exit code, working directory and duration that history doesn't have.
+//
+// The log holds every command in plaintext, including ones the exclude patterns drop, so
+// it's deleted once read. It's moved aside first so commands the hook appends meanwhile
+// start a new log rather than being lost; a log left over from an interrupted poll is
+// read before the current one is moved.
+func (t *ShellTracker) PollHookLog(path string, sessionID int64) ([]*storage.ShellCommand, error) {
+	pending := path + ".pending"
+	if _, err := os.Stat(pending); os.IsNotExist(err) {
+		if err := os.Rename(path, pending); err != nil {
+			if os.IsNotExist(err) {
+				return nil, nil // No hook installed, or nothing recorded since the last poll
+			}
+			return nil, err
+		}
+	}
 
+	commands, _, err := t.parseHistory(pending, 0, t.parseHookLog)
+	if err != nil {
+		return nil, err
+	}
+	saved := t.saveCommands(commands, sessionID)
+	if err := os.Remove(pending); err != nil {
+		return saved, err
+	}
+	return saved, nil
+}
+
+// pollFile reads commands added to a file since the last poll with parse, and saves
+// the ones that aren't excluded or already saved.
+func (t *ShellTracker) pollFile(path string, sessionID int64, parse func(*bufio.Scanner) []*storage.ShellCommand) ([]*storage.ShellCommand, error) {
 	// Load checkpoint
 	checkpoint, err := t.loadCheckpoint()
 	if err != nil {
 		checkpoint = &ShellCheckpoint{Offsets: make(map[string]int64)}
 	}
 
-	offset := checkpoint.Offsets[histPath]
+	offset := checkpoint.Offsets[path]
 
 	// Parse history
-	commands, newOffset, err := t.parseHistory(histPath, shellType, offset)
+	commands, newOffset, err := t.parseHistory(path, offset, parse)
 	if err != nil {
 		return nil, err
 	}
 
-	// Filter and save commands
+	saved := t.saveCommands(commands, sessionID)
+
+	// Update checkpoint
+	checkpoint.Offsets[path] = newOffset
+	t.saveCheckpoint(checkpoint)
+
+	return saved, nil
+}
+
+// saveCommands saves the commands that aren't excluded or already saved, and returns them.
+// Exclude patterns only apply here: commands are read from the history file or hook log
+// whether or not they match.
+func (t *ShellTracker) saveCommands(commands []*storage.ShellCommand, sessionID int64) []*storage.ShellCommand {
 	var saved []*storage.ShellCommand
 	for _, cmd := range commands {
 		if t.shouldExclude(cmd.Command) {
@@ -161,15 +217,10 @@ func (t *ShellTracker) Poll(sessionID int64) ([]*storage.ShellCommand, error) {
 		cmd.ID = id
 		saved = append(saved, cmd)
 	}
-
-	// Update checkpoint
-	checkpoint.Offsets[histPath] = newOffset
-	t.saveCheckpoint(checkpoint)
-
-	return saved, nil
+	return saved
 }
 
-func (t *ShellTracker) parseHistory(path, shellType string, offset int64) ([]*storage.ShellCommand, int64, error) {
+func (t *ShellTracker) parseHistory(path string, offset int64, parse func(*bufio.Scanner) []*storage.ShellCommand) ([]*storage.ShellCommand, int64, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, offset, err
@@ -193,17 +244,8 @@ func (t *ShellTracker) parseHistory(path, shellType string, offset int64) ([]*st
 		file.Seek(offset, 0)
 	}
 
-	var commands []*storage.ShellCommand
 	scanner := bufio.NewScanner(file)
-
-	switch shellType {
-	case "zsh":
-		commands = t.parseZshHistory(scanner)
-	case "fish":
-		commands = t.parseFishHistory(scanner)
-	default: // bash
-		commands = t.parseBashHistory(scanner)
-	}
+	commands := parse(scanner)
 
 	// Get new offset
 	newOffset, _ := file.Seek(0, 1)
@@ -329,6 +371,41 @@ func (t *ShellTracker) parseFishHistory(scanner *bufio.Scanner) []*storage.Shell
 	return commands
 }
 
+// parseHookLog parses the log written by shell hooks.
+// Format: start\tduration\texit_code\tshell\tcwd\tcommand
+func (t *ShellTracker) parseHookLog(scanner *bufio.Scanner) []*storage.ShellCommand {
+	var commands []*storage.ShellCommand
+	hostname, _ := os.Hostname()
+
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), "\t", 6)
+		if len(fields) != 6 || fields[5] == "" {
+			continue
+		}
+
+		timestamp, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		cmd := &storage.ShellCommand{
+			Timestamp:        timestamp,
+			Command:          fields[5],
+			ShellType:        fields[3],
+			WorkingDirectory: sql.NullString{String: fields[4], Valid: fields[4] != ""},
+			Hostname:         sql.NullString{String: hostname, Valid: hostname != ""},
+		}
+		if duration, err := strconv.ParseFloat(fields[1], 64); err == nil {
+			cmd.DurationSeconds = sql.NullFloat64{Float64: duration, Valid: true}
+		}
+		if exitCode, err := strconv.ParseInt(fields[2], 10, 64); err == nil {
+			cmd.ExitCode = sql.NullInt64{Int64: exitCode, Valid: true}
+		}
+		commands = append(commands, cmd)
+	}
+
+	return commands
+}
+
 func (t *ShellTracker) shouldExclude(command string) bool {
 	for _, pattern := range t.excludePatterns {
 		if pattern.MatchString(command) {
@@ -0,0 +1,127 @@
+package tracker
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ShellHookLogName is the file in the data directory that shell hooks append commands to.
+// Each line is: start time, duration in seconds, exit code, shell, working directory and
+// command, separated by tabs. Hooks skip commands the shell keeps out of its history, but
+// exclude patterns only apply when commands are saved, so the log is deleted after each poll.
+const ShellHookLogName = "shell_hooks.log"
+
+// shellHookOfferShownKey is the config key set once the daemon has offered to install a
+// shell hook, so the offer isn't repeated on every start.
+const shellHookOfferShownKey = "shell.hookOfferShown"
+
+// ShellHook generates a script that hooks into a shell to record each command as it
+// finishes, with details history files don't keep: exit code, working directory and duration.
+type ShellHook interface {
+	// Script returns the hook script, which appends to logPath.
+	Script(logPath string) string
+	// InstallPath returns where the script is installed under the home directory.
+	InstallPath(home string) string
+}
+
+// ZshHook records commands with zsh's preexec and precmd hooks.
+// The script has to be sourced from ~/.zshrc.
+type ZshHook struct{}
+
+// Script returns the zsh hook script.
+func (ZshHook) Script(logPath string) string {
+	return `# Traq shell hook for zsh. Records each command with its exit code,
+# working directory and duration. Load it from ~/.zshrc with:
+#   source ~/.traq_zsh_hook.zsh
+
+zmodload zsh/datetime
+
+__traq_log=` + quoteSh(logPath) + `
+
+__traq_preexec() {
+  # Skip commands zsh keeps out of history (HIST_IGNORE_SPACE, HISTORY_IGNORE)
+  [[ -o histignorespace && $1 == ' '* ]] && return
+  [[ -n $HISTORY_IGNORE && $1 == ${~HISTORY_IGNORE} ]] && return
+  __traq_cmd=$1
+  __traq_start=$EPOCHREALTIME
+}
+
+__traq_precmd() {
+  local exit_code=$?
+  [[ -z $__traq_start ]] && return
+  local duration=$(( EPOCHREALTIME - __traq_start ))
+  printf '%d\t%.3f\t%d\tzsh\t%s\t%s\n' ${__traq_start%.*} $duration $exit_code \
+    "${PWD//$'\t'/ }" "${__traq_cmd//[$'\t\n']/ }" >> $__traq_log
+  unset __traq_start __traq_cmd
+}
+
+autoload -Uz add-zsh-hook
+add-zsh-hook preexec __traq_preexec
+add-zsh-hook precmd __traq_precmd
+`
+}
+
+// InstallPath returns ~/.traq_zsh_hook.zsh.
+func (ZshHook) InstallPath(home string) string {
+	return filepath.Join(home, ".traq_zsh_hook.zsh")
+}
+
+// FishHook records commands with fish's fish_preexec and fish_postexec events.
+type FishHook struct{}
+
+// Script returns the fish hook script.
+func (FishHook) Script(logPath string) string {
+	return `# Traq shell hook for fish. Records each command with its exit code,
+# working directory and duration.
+
+function __traq_preexec --on-event fish_preexec
+    # Skip commands fish keeps out of history: a leading space, or fish_should_add_to_history
+    string match -q ' *' -- $argv[1]; and return
+    if functions -q fish_should_add_to_history; and not fish_should_add_to_history $argv[1]
+        return
+    end
+    set -g __traq_start (date +%s)
+end
+
+function __traq_postexec --on-event fish_postexec
+    set -l exit_code $status
+    set -q __traq_start; or return
+    set -l cmd (string replace -ra '[\t\n]' ' ' -- $argv[1])
+    set -l dir (string replace -a \t ' ' -- $PWD)
+    printf '%d\t%s\t%d\tfish\t%s\t%s\n' $__traq_start (math $CMD_DURATION / 1000) $exit_code \
+        "$dir" "$cmd" >> ` + quoteFish(logPath) + `
+    set -e __traq_start
+end
+`
+}
+
+// InstallPath returns ~/.config/fish/conf.d/traq.fish. Fish only autoloads files in
+// functions/ when a function is called by name, so event handlers go in conf.d.
+func (FishHook) InstallPath(home string) string {
+	return filepath.Join(home, ".config", "fish", "conf.d", "traq.fish")
+}
+
+// shellHooks are the shells hooks can be generated for.
+var shellHooks = map[string]ShellHook{
+	"zsh":  ZshHook{},
+	"fish": FishHook{},
+}
+
+// ShellHookStatus describes the hook for the user's shell.
+type ShellHookStatus struct {
+	Shell       string `json:"shell"`       // Shell detected from $SHELL, or the configured shell type
+	Supported   bool   `json:"supported"`   // A hook can be generated for the shell
+	Installed   bool   `json:"installed"`   // The hook script exists at its install path
+	InstallPath string `json:"installPath"` // Where the hook is installed
+}
+
+// quoteSh quotes a string for a POSIX shell or zsh.
+func quoteSh(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// quoteFish quotes a string for fish.
+func quoteFish(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return "'" + strings.ReplaceAll(s, "'", `\'`) + "'"
+}
@@ -1,8 +1,10 @@
 package tracker
 
 import (
+	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -29,6 +31,9 @@ func (m *mockPlatformShell) ShowNotification(title, body string) error   { retur
 func (m *mockPlatformShell) SetAutoStart(enabled bool) error             { return nil }
 func (m *mockPlatformShell) IsAutoStartEnabled() (bool, error)           { return false, nil }
 func (m *mockPlatformShell) GetSystemTheme() string                      { return "light" }
+func (m *mockPlatformShell) MachineID() string                           { return "test-machine" }
+func (m *mockPlatformShell) RegisterHotkey(string, func()) error         { return nil }
+func (m *mockPlatformShell) UnregisterHotkey(string) error               { return nil }
 
 func setupShellTestDB(t *testing.T) (*storage.Store, string) {
 	tmpDir, err := os.MkdirTemp("", "traq-shell-test-*")
@@ -780,3 +785,141 @@ go build -v ./...
 		t.Error("Expected hostname to be set")
 	}
 }
+
+func TestShellTracker_PollHookLog(t *testing.T) {
+	store, tmpDir := setupShellTestDB(t)
+	defer os.RemoveAll(tmpDir)
+	defer store.Close()
+
+	tracker := NewShellTracker(&mockPlatformShell{shellType: "zsh"}, store, tmpDir)
+	logPath := filepath.Join(tmpDir, ShellHookLogName)
+
+	// No hook installed yet
+	if saved, err := tracker.PollHookLog(logPath, 0); err != nil || len(saved) != 0 {
+		t.Fatalf("expected nothing without a hook log, got %d commands, err %v", len(saved), err)
+	}
+
+	log := "1704067200\t12.500\t1\tzsh\t/home/user/src/traq\tgo test ./...\n" +
+		"1704067260\t0.2\t0\tfish\t/tmp\tmake build\n" +
+		"malformed line\n" +
+		"1704067300\t0.1\t0\tzsh\t/tmp\tls\n" // Excluded
+	if err := os.WriteFile(logPath, []byte(log), 0644); err != nil {
+		t.Fatalf("failed to write hook log: %v", err)
+	}
+
+	saved, err := tracker.PollHookLog(logPath, 0)
+	if err != nil {
+		t.Fatalf("PollHookLog failed: %v", err)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("expected 2 commands, got %d", len(saved))
+	}
+	cmd := saved[0]
+	if cmd.Command != "go test ./..." || cmd.ShellType != "zsh" || cmd.Timestamp != 1704067200 {
+		t.Errorf("unexpected command: %+v", cmd)
+	}
+	if cmd.ExitCode.Int64 != 1 || cmd.DurationSeconds.Float64 != 12.5 || cmd.WorkingDirectory.String != "/home/user/src/traq" {
+		t.Errorf("expected exit code 1, 12.5s in /home/user/src/traq, got %+v", cmd)
+	}
+	if saved[1].ShellType != "fish" || !saved[1].ExitCode.Valid || saved[1].ExitCode.Int64 != 0 {
+		t.Errorf("unexpected fish command: %+v", saved[1])
+	}
+
+	// The log is deleted once read, so excluded commands don't stay on disk
+	if _, err := os.Stat(logPath); !os.IsNotExist(err) {
+		t.Errorf("expected the hook log to be removed, got %v", err)
+	}
+
+	// A log left by an interrupted poll is read before the current one
+	os.WriteFile(logPath+".pending", []byte("1704067400\t1.0\t0\tzsh\t/tmp\tgit push\n"), 0644)
+	os.WriteFile(logPath, []byte("1704067500\t1.0\t0\tzsh\t/tmp\tgit pull\n"), 0644)
+	saved, _ = tracker.PollHookLog(logPath, 0)
+	if len(saved) != 1 || saved[0].Command != "git push" {
+		t.Errorf("expected only the pending command, got %d", len(saved))
+	}
+	saved, _ = tracker.PollHookLog(logPath, 0)
+	if len(saved) != 1 || saved[0].Command != "git pull" {
+		t.Errorf("expected the new command, got %d", len(saved))
+	}
+}
+
+func TestDaemon_InstallShellHook(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dataDir := filepath.Join(home, "it's data")
+	d := &Daemon{
+		config: &DaemonConfig{DataDir: dataDir},
+		shell:  NewShellTracker(&mockPlatformShell{shellType: "zsh"}, nil, dataDir),
+	}
+
+	if status := d.GetShellHookStatus(); !status.Supported || status.Installed {
+		t.Fatalf("expected an available, uninstalled zsh hook, got %+v", status)
+	}
+
+	script, path, err := d.GenerateShellHook("zsh")
+	if err != nil {
+		t.Fatalf("GenerateShellHook failed: %v", err)
+	}
+	if path != filepath.Join(home, ".traq_zsh_hook.zsh") {
+		t.Errorf("unexpected install path %s", path)
+	}
+	logPath := filepath.Join(dataDir, ShellHookLogName)
+	if !strings.Contains(script, quoteSh(logPath)) || !strings.Contains(script, "add-zsh-hook precmd") {
+		t.Errorf("expected script to log to %s from precmd, got:\n%s", logPath, script)
+	}
+	if !strings.Contains(script, "histignorespace") {
+		t.Error("expected script to honor HIST_IGNORE_SPACE")
+	}
+
+	// Installing twice sources the hook from .zshrc once
+	for i := 0; i < 2; i++ {
+		if _, err := d.InstallShellHook("zsh"); err != nil {
+			t.Fatalf("InstallShellHook failed: %v", err)
+		}
+	}
+	zshrc, _ := os.ReadFile(filepath.Join(home, ".zshrc"))
+	if strings.Count(string(zshrc), "source ") != 1 {
+		t.Errorf("expected one source line in .zshrc, got:\n%s", zshrc)
+	}
+	if status := d.GetShellHookStatus(); !status.Installed {
+		t.Error("expected the hook to be installed")
+	}
+
+	if _, path, _ := d.GenerateShellHook("fish"); path != filepath.Join(home, ".config", "fish", "conf.d", "traq.fish") {
+		t.Errorf("unexpected fish install path %s", path)
+	}
+	if _, _, err := d.GenerateShellHook("tcsh"); err == nil {
+		t.Error("expected error for an unsupported shell")
+	}
+}
+
+func TestDaemon_OfferShellHookOnce(t *testing.T) {
+	store, tmpDir := setupShellTestDB(t)
+	defer os.RemoveAll(tmpDir)
+	defer store.Close()
+	t.Setenv("HOME", tmpDir)
+	d := &Daemon{
+		config: &DaemonConfig{DataDir: tmpDir},
+		store:  store,
+		shell:  NewShellTracker(&mockPlatformShell{shellType: "zsh"}, nil, tmpDir),
+	}
+
+	var buf strings.Builder
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	d.offerShellHook()
+	if !strings.Contains(buf.String(), "Shell hook for zsh isn't installed") {
+		t.Errorf("expected an offer to install the zsh hook, got %q", buf.String())
+	}
+	if shown, _ := store.GetConfig(shellHookOfferShownKey); shown != "true" {
+		t.Errorf("expected the offer to be recorded, got %q", shown)
+	}
+
+	// Later starts don't repeat it
+	buf.Reset()
+	d.offerShellHook()
+	if buf.Len() != 0 {
+		t.Errorf("expected no second offer, got %q", buf.String())
+	}
+}
@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// SystemLoadMonitor reads how busy the system's CPUs are.
+type SystemLoadMonitor struct {
+	readLoadAverage func() (float64, error) // 1-minute load average
+	numCPU          int
+}
+
+// NewSystemLoadMonitor creates a SystemLoadMonitor for this machine.
+func NewSystemLoadMonitor() *SystemLoadMonitor {
+	return &SystemLoadMonitor{readLoadAverage: readSystemLoadAverage, numCPU: runtime.NumCPU()}
+}
+
+// GetCPULoad returns the 1-minute load average per CPU, so 1.0 means every core was
+// busy. Only Linux and macOS are supported.
+func (m *SystemLoadMonitor) GetCPULoad() (float64, error) {
+	load, err := m.readLoadAverage()
+	if err != nil {
+		return 0, err
+	}
+	return load / float64(max(m.numCPU, 1)), nil
+}
+
+// readProcLoadAvg reads the 1-minute load average from /proc/loadavg.
+func readProcLoadAvg(path string) (float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read load average: %w", err)
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("empty load average")
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid load average %q: %w", fields[0], err)
+	}
+	return load, nil
+}
+
+// adaptiveCaptureQuality lowers the capture quality as CPU load rises above threshold,
+// reaching minQuality at twice the threshold. Below the threshold quality is unchanged.
+func adaptiveCaptureQuality(quality, minQuality int, load, threshold float64) int {
+	if threshold <= 0 || load <= threshold || minQuality >= quality {
+		return quality
+	}
+	pressure := min((load-threshold)/threshold, 1)
+	return quality - int(math.Round(float64(quality-minQuality)*pressure))
+}
@@ -0,0 +1,19 @@
+//go:build darwin
+
+package tracker
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import "fmt"
+
+// readSystemLoadAverage returns the 1-minute load average from getloadavg.
+func readSystemLoadAverage() (float64, error) {
+	var loads [1]C.double
+	if C.getloadavg(&loads[0], 1) != 1 {
+		return 0, fmt.Errorf("getloadavg failed")
+	}
+	return float64(loads[0]), nil
+}
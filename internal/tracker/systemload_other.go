@@ -0,0 +1,17 @@
+//go:build !darwin
+
+package tracker
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// readSystemLoadAverage returns the 1-minute load average on Linux. Other platforms
+// aren't supported.
+func readSystemLoadAverage() (float64, error) {
+	if runtime.GOOS != "linux" {
+		return 0, fmt.Errorf("load average not supported on %s", runtime.GOOS)
+	}
+	return readProcLoadAvg("/proc/loadavg")
+}
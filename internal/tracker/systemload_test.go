@@ -0,0 +1,75 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadProcLoadAvg(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "loadavg")
+	os.WriteFile(path, []byte("2.50 1.75 1.20 3/812 41235\n"), 0644)
+
+	load, err := readProcLoadAvg(path)
+	if err != nil {
+		t.Fatalf("readProcLoadAvg failed: %v", err)
+	}
+	if load != 2.5 {
+		t.Errorf("load = %v, want 2.5", load)
+	}
+
+	os.WriteFile(path, []byte("busy\n"), 0644)
+	if _, err := readProcLoadAvg(path); err == nil {
+		t.Error("expected error for malformed load average")
+	}
+}
+
+func TestSystemLoadMonitor_GetCPULoad(t *testing.T) {
+	m := &SystemLoadMonitor{readLoadAverage: func() (float64, error) { return 3, nil }, numCPU: 4}
+	load, err := m.GetCPULoad()
+	if err != nil {
+		t.Fatalf("GetCPULoad failed: %v", err)
+	}
+	if load != 0.75 {
+		t.Errorf("load = %v, want 0.75 per CPU", load)
+	}
+}
+
+func TestAdaptiveCaptureQuality(t *testing.T) {
+	tests := []struct {
+		name      string
+		load      float64
+		threshold float64
+		want      int
+	}{
+		{"below threshold", 0.5, 0.8, 80},
+		{"at threshold", 0.8, 0.8, 80},
+		{"halfway to double", 1.2, 0.8, 60},
+		{"double threshold", 1.6, 0.8, 40},
+		{"far above threshold", 5, 0.8, 40},
+		{"no threshold", 5, 0, 80},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := adaptiveCaptureQuality(80, 40, tt.load, tt.threshold); got != tt.want {
+				t.Errorf("quality = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDaemon_CaptureQuality(t *testing.T) {
+	config := DefaultDaemonConfig(t.TempDir())
+	d := &Daemon{
+		config: config,
+		load:   &SystemLoadMonitor{readLoadAverage: func() (float64, error) { return 8, nil }, numCPU: 4},
+	}
+
+	if q := d.captureQuality(); q != config.Quality {
+		t.Errorf("quality = %d with adaptive quality off, want %d", q, config.Quality)
+	}
+	config.AdaptiveCaptureQuality = true
+	if q := d.captureQuality(); q != config.MinCaptureQuality {
+		t.Errorf("quality = %d under heavy load, want %d", q, config.MinCaptureQuality)
+	}
+}
@@ -0,0 +1,58 @@
+package tracker
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"traq/internal/storage"
+)
+
+// SystemTimezone returns the IANA name of the system timezone, e.g. "Europe/Berlin", or ""
+// if it can't be determined. Unlike time.Local, which is fixed when the process starts,
+// this reflects changes made while Traq is running. Only $TZ and /etc/localtime are
+// checked, so changes aren't detected on Windows.
+func SystemTimezone() string {
+	if tz := os.Getenv("TZ"); tz != "" {
+		return strings.TrimPrefix(tz, ":")
+	}
+	target, err := os.Readlink("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+		return name
+	}
+	return ""
+}
+
+// RecordTimezoneChange records that the system timezone changed from fromTZ to toTZ.
+func (d *Daemon) RecordTimezoneChange(fromTZ, toTZ string, timestamp int64) error {
+	if fromTZ == "" || toTZ == "" {
+		return fmt.Errorf("timezone names are required")
+	}
+	if fromTZ == toTZ {
+		return fmt.Errorf("timezone didn't change: %s", toTZ)
+	}
+	_, err := d.store.SaveTimezoneChange(&storage.TimezoneChange{
+		Timestamp: timestamp,
+		FromTZ:    fromTZ,
+		ToTZ:      toTZ,
+	})
+	return err
+}
+
+// checkTimezone records a timezone change if current differs from the timezone seen at
+// the previous check. An undetectable timezone ("") is ignored.
+func (d *Daemon) checkTimezone(current string) {
+	if current == "" || current == d.timezone {
+		return
+	}
+	if d.timezone != "" {
+		if err := d.RecordTimezoneChange(d.timezone, current, time.Now().Unix()); err != nil {
+			fmt.Printf("Failed to record timezone change: %v\n", err)
+		}
+	}
+	d.timezone = current
+}
@@ -0,0 +1,245 @@
+package tracker
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"traq/internal/platform"
+	"traq/internal/storage"
+)
+
+// vscodeApps are the lowercase app names of VS Code and forks that read .vscode settings.
+var vscodeApps = map[string]bool{"code": true, "cursor": true, "vscodium": true}
+
+// extensionLanguages maps extension IDs commonly recommended in .vscode/extensions.json
+// to the language they support.
+var extensionLanguages = map[string]string{
+	"golang.go":                "go",
+	"ms-python.python":         "python",
+	"ms-python.vscode-pylance": "python",
+	"ms-toolsai.jupyter":       "python",
+	"rust-lang.rust-analyzer":  "rust",
+	"ms-vscode.cpptools":       "cpp",
+	"redhat.java":              "java",
+	"vscjava.vscode-java-pack": "java",
+	"ms-dotnettools.csharp":    "csharp",
+	"dbaeumer.vscode-eslint":   "javascript",
+	"svelte.svelte-vscode":     "svelte",
+	"vue.volar":                "vue",
+	"shopify.ruby-lsp":         "ruby",
+	"rebornix.ruby":            "ruby",
+	"dart-code.dart-code":      "dart",
+	"dart-code.flutter":        "dart",
+	"hashicorp.terraform":      "terraform",
+	"elixir-lsp.elixir-ls":     "elixir",
+	"haskell.haskell":          "haskell",
+	"ziglang.vscode-zig":       "zig",
+	"ms-vscode.powershell":     "powershell",
+}
+
+// languageOverrideKey matches language-specific settings keys like "[go]" or "[typescript][typescriptreact]".
+var languageOverrideKey = regexp.MustCompile(`\[([^\[\]]+)\]`)
+
+// VSCodePoller detects the languages being worked on in VS Code by polling its settings
+// files for changes. It reads the user settings and the active workspace's .vscode/*.json
+// files, extracting language-specific overrides, file associations, and recommended extensions.
+type VSCodePoller struct {
+	store            *storage.Store
+	userSettingsPath string
+	pollInterval     time.Duration
+	lastPoll         time.Time
+	workspace        string               // Last workspace languages were recorded for
+	modTimes         map[string]time.Time // Watched file -> modification time when last seen
+}
+
+// NewVSCodePoller creates a new VSCodePoller watching ~/.vscode/settings.json.
+func NewVSCodePoller(store *storage.Store) *VSCodePoller {
+	home, _ := os.UserHomeDir()
+	return &VSCodePoller{
+		store:            store,
+		userSettingsPath: filepath.Join(home, ".vscode", "settings.json"),
+		pollInterval:     time.Minute,
+		modTimes:         make(map[string]time.Time),
+	}
+}
+
+// Poll records the languages referenced by VS Code settings when the active workspace changes
+// or a watched settings file is modified. workspace is the folder open in the focused VS Code
+// window, or "" if VS Code isn't focused; the previous workspace stays active until another
+// one is focused. Calls made within the poll interval of the previous check are ignored.
+func (p *VSCodePoller) Poll(sessionID int64, workspace string) ([]*storage.VSCodeLanguageEvent, error) {
+	now := time.Now()
+	if !p.lastPoll.IsZero() && now.Sub(p.lastPoll) < p.pollInterval {
+		return nil, nil
+	}
+	p.lastPoll = now
+
+	changed := false
+	if workspace != "" && workspace != p.workspace {
+		p.workspace = workspace
+		changed = true
+	}
+
+	files := []string{p.userSettingsPath}
+	if p.workspace != "" {
+		matches, _ := filepath.Glob(filepath.Join(p.workspace, ".vscode", "*.json"))
+		files = append(files, matches...)
+	}
+
+	var languages []string
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		// Files seen for the first time don't count as changes, so startup doesn't record
+		if last, seen := p.modTimes[path]; seen && !info.ModTime().Equal(last) {
+			changed = true
+		}
+		p.modTimes[path] = info.ModTime()
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		languages = append(languages, extractVSCodeLanguages(data)...)
+	}
+	if !changed || len(languages) == 0 {
+		return nil, nil
+	}
+
+	var events []*storage.VSCodeLanguageEvent
+	for _, language := range uniqueSorted(languages) {
+		event := &storage.VSCodeLanguageEvent{
+			Timestamp: now.Unix(),
+			Language:  language,
+			Workspace: sql.NullString{String: p.workspace, Valid: p.workspace != ""},
+			SessionID: sql.NullInt64{Int64: sessionID, Valid: sessionID > 0},
+		}
+		id, err := p.store.SaveVSCodeLanguageEvent(event)
+		if err != nil {
+			return events, err
+		}
+		event.ID = id
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// isVSCodeWindow reports whether a window belongs to VS Code or a fork.
+func isVSCodeWindow(info *platform.WindowInfo) bool {
+	return info != nil && vscodeApps[strings.ToLower(info.AppName)]
+}
+
+// extractVSCodeLanguages returns the language identifiers referenced by a VS Code settings,
+// extensions, or launch file: "editor.language", "[language]" override keys,
+// "files.associations" values, and recommended language extensions.
+func extractVSCodeLanguages(data []byte) []string {
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(stripJSONC(data), &settings); err != nil {
+		return nil
+	}
+
+	var languages []string
+	for key, value := range settings {
+		switch {
+		case key == "editor.language":
+			var language string
+			if json.Unmarshal(value, &language) == nil && language != "" {
+				languages = append(languages, language)
+			}
+		case key == "files.associations":
+			var associations map[string]string
+			if json.Unmarshal(value, &associations) == nil {
+				for _, language := range associations {
+					languages = append(languages, language)
+				}
+			}
+		case key == "recommendations":
+			var extensions []string
+			if json.Unmarshal(value, &extensions) == nil {
+				for _, ext := range extensions {
+					if language, ok := extensionLanguages[strings.ToLower(ext)]; ok {
+						languages = append(languages, language)
+					}
+				}
+			}
+		case strings.HasPrefix(key, "["):
+			for _, m := range languageOverrideKey.FindAllStringSubmatch(key, -1) {
+				languages = append(languages, m[1])
+			}
+		}
+	}
+	return uniqueSorted(languages)
+}
+
+// stripJSONC removes comments and trailing commas, which VS Code allows in its JSON files.
+func stripJSONC(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				i++
+				out = append(out, data[i])
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		case c == '}' || c == ']':
+			// Drop a trailing comma before the closing bracket
+			j := len(out) - 1
+			for j >= 0 && (out[j] == ' ' || out[j] == '\t' || out[j] == '\n' || out[j] == '\r') {
+				j--
+			}
+			if j >= 0 && out[j] == ',' {
+				out = append(out[:j], out[j+1:]...)
+			}
+			out = append(out, c)
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// uniqueSorted returns the distinct non-empty strings in values, sorted.
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, v := range values {
+		if v != "" && !seen[v] {
+			seen[v] = true
+			result = append(result, v)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
@@ -0,0 +1,90 @@
+package tracker
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExtractVSCodeLanguages(t *testing.T) {
+	data := []byte(`{
+		// Comments and trailing commas are allowed
+		"editor.fontSize": 14,
+		"[go]": {"editor.formatOnSave": true},
+		"[typescript][typescriptreact]": {"editor.tabSize": 2},
+		"files.associations": {"*.tmpl": "html"},
+		/* block comment */
+		"recommendations": ["golang.go", "Rust-Lang.rust-analyzer", "eamodio.gitlens"],
+		"http.proxy": "http://example.com//not-a-comment",
+	}`)
+
+	got := extractVSCodeLanguages(data)
+	want := []string{"go", "html", "rust", "typescript", "typescriptreact"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("extractVSCodeLanguages = %v, want %v", got, want)
+	}
+
+	if got := extractVSCodeLanguages([]byte(`not json`)); got != nil {
+		t.Errorf("expected nil for invalid JSON, got %v", got)
+	}
+}
+
+func TestVSCodePoller_Poll(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	home := t.TempDir()
+	userSettings := filepath.Join(home, "settings.json")
+	os.WriteFile(userSettings, []byte(`{"[markdown]": {}}`), 0644)
+
+	workspace := t.TempDir()
+	os.MkdirAll(filepath.Join(workspace, ".vscode"), 0755)
+	os.WriteFile(filepath.Join(workspace, ".vscode", "settings.json"), []byte(`{"[go]": {}}`), 0644)
+
+	sessionID, _ := store.CreateSession(time.Now().Unix())
+	p := NewVSCodePoller(store)
+	p.userSettingsPath = userSettings
+	p.pollInterval = 0
+
+	// User settings seen for the first time aren't recorded
+	events, err := p.Poll(sessionID, "")
+	if err != nil || len(events) != 0 {
+		t.Fatalf("expected no events on first poll, got %v (err %v)", events, err)
+	}
+
+	// Focusing a workspace records its languages along with the user settings
+	events, err = p.Poll(sessionID, workspace)
+	if err != nil {
+		t.Fatalf("Poll failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Language != "go" || events[1].Language != "markdown" {
+		t.Fatalf("expected go and markdown events, got %+v", events)
+	}
+	if events[0].Workspace.String != workspace {
+		t.Errorf("expected workspace %s, got %s", workspace, events[0].Workspace.String)
+	}
+
+	// Nothing changed, and switching away from VS Code keeps the workspace
+	if events, _ := p.Poll(sessionID, ""); len(events) != 0 {
+		t.Errorf("expected no events without changes, got %d", len(events))
+	}
+
+	// Editing a workspace file records again
+	later := time.Now().Add(time.Minute)
+	os.WriteFile(filepath.Join(workspace, ".vscode", "extensions.json"), []byte(`{"recommendations": ["ms-python.python"]}`), 0644)
+	os.Chtimes(filepath.Join(workspace, ".vscode", "settings.json"), later, later)
+	events, _ = p.Poll(sessionID, "")
+	if len(events) != 3 {
+		t.Fatalf("expected go, markdown, and python events after edit, got %+v", events)
+	}
+
+	saved, err := store.GetVSCodeLanguageEventsByTimeRange(0, time.Now().Unix()+1)
+	if err != nil {
+		t.Fatalf("GetVSCodeLanguageEventsByTimeRange failed: %v", err)
+	}
+	if len(saved) != 5 {
+		t.Errorf("expected 5 saved events, got %d", len(saved))
+	}
+}
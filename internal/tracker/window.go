@@ -23,6 +23,10 @@ type WindowFocus struct {
 	WindowClass string
 	StartTime   time.Time
 	SessionID   int64
+
+	// Git context for IDE/terminal windows working inside a tracked repository
+	GitRepositoryID int64
+	Branch          string
 }
 
 // NewWindowTracker creates a new WindowTracker.
@@ -66,6 +70,8 @@ func (t *WindowTracker) RecordFocusChange(newWindow *platform.WindowInfo, sessio
 				EndTime:         now.Unix(),
 				DurationSeconds: duration,
 				SessionID:       sql.NullInt64{Int64: t.currentFocus.SessionID, Valid: t.currentFocus.SessionID > 0},
+				GitRepositoryID: sql.NullInt64{Int64: t.currentFocus.GitRepositoryID, Valid: t.currentFocus.GitRepositoryID > 0},
+				CurrentBranch:   sql.NullString{String: t.currentFocus.Branch, Valid: t.currentFocus.Branch != ""},
 			}
 
 			eventID, err := t.store.SaveFocusEvent(event)
@@ -108,6 +114,8 @@ func (t *WindowTracker) FlushCurrentFocus() error {
 			EndTime:         now.Unix(),
 			DurationSeconds: duration,
 			SessionID:       sql.NullInt64{Int64: t.currentFocus.SessionID, Valid: t.currentFocus.SessionID > 0},
+			GitRepositoryID: sql.NullInt64{Int64: t.currentFocus.GitRepositoryID, Valid: t.currentFocus.GitRepositoryID > 0},
+			CurrentBranch:   sql.NullString{String: t.currentFocus.Branch, Valid: t.currentFocus.Branch != ""},
 		}
 
 		eventID, err := t.store.SaveFocusEvent(event)
@@ -133,6 +141,14 @@ func (t *WindowTracker) Reset() {
 	t.currentFocus = nil
 }
 
+// SetGitContext records the repository and branch the current focus is working on.
+func (t *WindowTracker) SetGitContext(repoID int64, branch string) {
+	if t.currentFocus != nil {
+		t.currentFocus.GitRepositoryID = repoID
+		t.currentFocus.Branch = branch
+	}
+}
+
 // UpdateSessionID updates the session ID for the current focus.
 func (t *WindowTracker) UpdateSessionID(sessionID int64) {
 	if t.currentFocus != nil {
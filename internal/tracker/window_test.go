@@ -290,3 +290,32 @@ func TestWindowTracker_UpdateSessionID_NoFocus(t *testing.T) {
 	// Should not panic when no current focus
 	tracker.UpdateSessionID(42)
 }
+
+func TestWindowTracker_SetGitContext(t *testing.T) {
+	store, cleanup := testStore(t)
+	defer cleanup()
+
+	repoID, _ := store.SaveGitRepository(&storage.GitRepository{Path: "/src/traq", Name: "traq", IsActive: true})
+	sessionID, _ := store.CreateSession(time.Now().Unix())
+
+	tracker := NewWindowTracker(NewMockPlatform(), store)
+	tracker.RecordFocusChange(&platform.WindowInfo{Title: "main.go - traq", AppName: "code"}, sessionID)
+	tracker.SetGitContext(repoID, "main")
+
+	// Backdate the focus start so the flushed event is long enough to save
+	tracker.GetCurrentFocus().StartTime = time.Now().Add(-time.Minute)
+	if err := tracker.FlushCurrentFocus(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, err := store.GetFocusEventsBySession(sessionID)
+	if err != nil {
+		t.Fatalf("failed to get focus events: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	if events[0].GitRepositoryID.Int64 != repoID || events[0].CurrentBranch.String != "main" {
+		t.Errorf("got repo=%v branch=%v, want %d/main", events[0].GitRepositoryID, events[0].CurrentBranch, repoID)
+	}
+}
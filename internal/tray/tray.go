@@ -3,6 +3,9 @@ package tray
 import (
 	"context"
 	_ "embed"
+	"fmt"
+	"runtime"
+	"strings"
 	"sync"
 
 	"fyne.io/systray"
@@ -11,24 +14,55 @@ import (
 //go:embed icon.png
 var iconData []byte
 
+// Productivity status icons, shown in place of the app icon once a score is known.
+var (
+	//go:embed icons/productivity_high.png
+	productivityHighIcon []byte
+	//go:embed icons/productivity_medium.png
+	productivityMediumIcon []byte
+	//go:embed icons/productivity_low.png
+	productivityLowIcon []byte
+	//go:embed icons/productivity_unknown.png
+	productivityUnknownIcon []byte
+)
+
+// NoProductivityData is passed to SetProductivityIcon when there is no activity to score.
+const NoProductivityData = -1
+
+// customIconsSupported reports whether the PNG status icons can be shown. The Windows
+// tray only accepts ICO data, so there the score is shown in the tooltip alone.
+var customIconsSupported = runtime.GOOS != "windows"
+
+// defaultTooltip is shown when hovering the tray icon.
+const defaultTooltip = "Traq - Activity Tracker"
+
 // Tray manages the system tray icon and menu.
 type Tray struct {
 	mu sync.Mutex
 
 	// Callbacks
-	onShowWindow  func()
-	onQuit        func()
-	onPause       func()
-	onResume      func()
-	onForce       func()
+	onShowWindow    func()
+	onQuit          func()
+	onPause         func()
+	onResume        func()
+	onForce         func()
+	onSwitchProfile func(id int64)
 
 	// State
-	isPaused    bool
-	isCapturing bool
+	isPaused        bool
+	isCapturing     bool
+	profiles        []Profile
+	activeProfileID int64
+	ready           bool
+	icon            []byte
+	goalTooltip     string
+	scoreTooltip    string
 
 	// Menu items (for updating state)
 	mPauseResume *systray.MenuItem
 	mCapturing   *systray.MenuItem
+	mProfile     *systray.MenuItem
+	profileItems map[int64]*systray.MenuItem
 
 	// Context for shutdown
 	ctx    context.Context
@@ -42,20 +76,30 @@ type Config struct {
 	OnPause      func()
 	OnResume     func()
 	OnForce      func()
+	// OnSwitchProfile is called when a profile is picked from the Profile submenu.
+	OnSwitchProfile func(id int64)
+}
+
+// Profile is a settings profile listed in the tray menu.
+type Profile struct {
+	ID   int64
+	Name string
 }
 
 // New creates a new Tray instance.
 func New(cfg Config) *Tray {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Tray{
-		onShowWindow: cfg.OnShowWindow,
-		onQuit:       cfg.OnQuit,
-		onPause:      cfg.OnPause,
-		onResume:     cfg.OnResume,
-		onForce:      cfg.OnForce,
-		isCapturing:  true,
-		ctx:          ctx,
-		cancel:       cancel,
+		onShowWindow:    cfg.OnShowWindow,
+		onQuit:          cfg.OnQuit,
+		onPause:         cfg.OnPause,
+		onResume:        cfg.OnResume,
+		onForce:         cfg.OnForce,
+		onSwitchProfile: cfg.OnSwitchProfile,
+		profileItems:    make(map[int64]*systray.MenuItem),
+		isCapturing:     true,
+		ctx:             ctx,
+		cancel:          cancel,
 	}
 }
 
@@ -107,15 +151,151 @@ func (t *Tray) SetCapturing(capturing bool) {
 	}
 }
 
+// SetGoalProgress shows active-minute goal progress in the tray tooltip.
+// A zero target restores the default tooltip.
+func (t *Tray) SetGoalProgress(actualMinutes, targetMinutes int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.goalTooltip = ""
+	if targetMinutes > 0 {
+		t.goalTooltip = fmt.Sprintf("Active: %d/%d min", actualMinutes, targetMinutes)
+	}
+	systray.SetTooltip(t.tooltip())
+}
+
+// SetProductivityIcon shows today's productivity score (0-100) as the tray icon: a green
+// check from 80, a yellow warning from 40 and a red cross below that. NoProductivityData
+// shows a gray question mark. The percentage is also added to the tooltip.
+func (t *Tray) SetProductivityIcon(score int) {
+	icon, label := productivityIcon(score)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.scoreTooltip = label
+	if customIconsSupported {
+		t.icon = icon
+		if t.ready {
+			systray.SetIcon(icon)
+		}
+	}
+	systray.SetTooltip(t.tooltip())
+}
+
+// productivityIcon returns the status icon and tooltip line for a productivity score.
+func productivityIcon(score int) ([]byte, string) {
+	switch {
+	case score < 0:
+		return productivityUnknownIcon, "Productivity: no data yet"
+	case score >= 80:
+		return productivityHighIcon, fmt.Sprintf("Productivity: %d%%", score)
+	case score >= 40:
+		return productivityMediumIcon, fmt.Sprintf("Productivity: %d%%", score)
+	default:
+		return productivityLowIcon, fmt.Sprintf("Productivity: %d%%", score)
+	}
+}
+
+// tooltip builds the tray tooltip from the goal and productivity state. Must be called with mu held.
+func (t *Tray) tooltip() string {
+	lines := []string{defaultTooltip}
+	for _, line := range []string{t.goalTooltip, t.scoreTooltip} {
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// SetProfiles updates the Profile submenu and shows the active profile's name.
+// It can be called before the tray is ready; the menu is built once it is.
+func (t *Tray) SetProfiles(profiles []Profile, activeID int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.profiles = profiles
+	t.activeProfileID = activeID
+	if t.mProfile != nil {
+		t.renderProfiles()
+	}
+}
+
+// renderProfiles syncs the Profile submenu with the profile state. Must be called with mu held.
+func (t *Tray) renderProfiles() {
+	if len(t.profiles) == 0 {
+		t.mProfile.Hide()
+		return
+	}
+
+	title := "Profile"
+	seen := make(map[int64]bool, len(t.profiles))
+	for _, p := range t.profiles {
+		seen[p.ID] = true
+		active := p.ID == t.activeProfileID
+		if active {
+			title = "Profile: " + p.Name
+		}
+
+		item, ok := t.profileItems[p.ID]
+		if !ok {
+			item = t.mProfile.AddSubMenuItemCheckbox(p.Name, "Switch to the "+p.Name+" profile", active)
+			t.profileItems[p.ID] = item
+			go t.handleProfileClicks(p.ID, item)
+		}
+		item.SetTitle(p.Name)
+		item.Show()
+		if active {
+			item.Check()
+		} else {
+			item.Uncheck()
+		}
+	}
+
+	// systray can't remove menu items, so hide those for deleted profiles
+	for id, item := range t.profileItems {
+		if !seen[id] {
+			item.Hide()
+		}
+	}
+
+	t.mProfile.SetTitle(title)
+	t.mProfile.Show()
+}
+
+// handleProfileClicks switches to a profile whenever its submenu item is clicked.
+func (t *Tray) handleProfileClicks(id int64, item *systray.MenuItem) {
+	for {
+		select {
+		case <-t.ctx.Done():
+			return
+		case <-item.ClickedCh:
+			if t.onSwitchProfile != nil {
+				t.onSwitchProfile(id)
+			}
+		}
+	}
+}
+
 func (t *Tray) onReady() {
-	systray.SetIcon(iconData)
+	t.mu.Lock()
+	t.ready = true
+	icon := iconData
+	if t.icon != nil {
+		icon = t.icon
+	}
+	systray.SetIcon(icon)
 	systray.SetTitle("Traq")
-	systray.SetTooltip("Traq - Activity Tracker")
+	systray.SetTooltip(t.tooltip())
+	t.mu.Unlock()
 
 	// Status indicator (disabled, just for display)
 	t.mCapturing = systray.AddMenuItem("● Capturing", "Current capture status")
 	t.mCapturing.Disable()
 
+	// Active profile, with a submenu to switch profiles
+	t.mu.Lock()
+	t.mProfile = systray.AddMenuItem("Profile", "Switch settings profile")
+	t.renderProfiles()
+	t.mu.Unlock()
+
 	systray.AddSeparator()
 
 	// Show Window
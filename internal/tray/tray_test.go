@@ -0,0 +1,50 @@
+package tray
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestProductivityIcon(t *testing.T) {
+	tests := []struct {
+		score int
+		icon  []byte
+		label string
+	}{
+		{100, productivityHighIcon, "Productivity: 100%"},
+		{80, productivityHighIcon, "Productivity: 80%"},
+		{79, productivityMediumIcon, "Productivity: 79%"},
+		{40, productivityMediumIcon, "Productivity: 40%"},
+		{39, productivityLowIcon, "Productivity: 39%"},
+		{0, productivityLowIcon, "Productivity: 0%"},
+		{NoProductivityData, productivityUnknownIcon, "Productivity: no data yet"},
+	}
+	for _, tt := range tests {
+		icon, label := productivityIcon(tt.score)
+		if !bytes.Equal(icon, tt.icon) {
+			t.Errorf("productivityIcon(%d) returned the wrong icon", tt.score)
+		}
+		if label != tt.label {
+			t.Errorf("productivityIcon(%d) label = %q, want %q", tt.score, label, tt.label)
+		}
+	}
+
+	for _, icon := range [][]byte{productivityHighIcon, productivityMediumIcon, productivityLowIcon, productivityUnknownIcon} {
+		if _, err := png.Decode(bytes.NewReader(icon)); err != nil {
+			t.Errorf("embedded icon is not a valid PNG: %v", err)
+		}
+	}
+}
+
+func TestTooltip(t *testing.T) {
+	tr := New(Config{})
+	if got := tr.tooltip(); got != defaultTooltip {
+		t.Errorf("tooltip = %q, want %q", got, defaultTooltip)
+	}
+	tr.goalTooltip = "Active: 30/240 min"
+	tr.scoreTooltip = "Productivity: 85%"
+	if got, want := tr.tooltip(), defaultTooltip+"\nActive: 30/240 min\nProductivity: 85%"; got != want {
+		t.Errorf("tooltip = %q, want %q", got, want)
+	}
+}
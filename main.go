@@ -2,7 +2,11 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
 	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -10,8 +14,10 @@ import (
 	"strings"
 	"time"
 
+	"traq/internal/broadcast"
 	"traq/internal/platform"
 	"traq/internal/service"
+	"traq/internal/storage"
 	"traq/internal/tray"
 
 	"github.com/getsentry/sentry-go"
@@ -24,6 +30,9 @@ import (
 //go:embed all:frontend/dist
 var assets embed.FS
 
+// focusEventsPath is where the live event stream is served.
+const focusEventsPath = "/events/focus"
+
 // screenshotHandler serves screenshot files from the data directory
 type screenshotHandler struct {
 	dataDir string
@@ -64,6 +73,72 @@ func (h *screenshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, fullPath)
 }
 
+// focusEventsHandler streams daemon events as Server-Sent Events so the frontend can
+// update the timeline without polling. Each event's name is its type ("focus",
+// "afk_start", "afk_end" or "screenshot") and its data is the JSON-encoded payload.
+// Requests must carry the stream's token, since any local process or web page can
+// reach the port.
+type focusEventsHandler struct {
+	app   *App
+	token string
+}
+
+func (h *focusEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if h.app.Config == nil || h.app.events == nil {
+		http.NotFound(w, r)
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("token")), []byte(h.token)) != 1 {
+		http.Error(w, "Invalid token", http.StatusForbidden)
+		return
+	}
+	cfg, err := h.app.Config.GetConfig()
+	if err != nil || !cfg.System.EnableSSE {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events, unsubscribe, err := h.app.events.Subscribe(cfg.System.MaxSSEConnections)
+	if errors.Is(err, broadcast.ErrTooManySubscribers) {
+		http.Error(w, "Too many event stream connections", http.StatusServiceUnavailable)
+		return
+	}
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// The webview's origin differs from the stream's; the token already gates access
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case evt, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(evt.Data)
+			if err != nil {
+				log.Printf("Failed to encode %s event: %v", evt.Type, err)
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", evt.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
 // SentryDSN is the Sentry data source name for crash reporting
 const SentryDSN = "https://5bad525b80919fbf0be0f8617d24d259@o4510716123348992.ingest.us.sentry.io/4510716130623488"
 
@@ -151,8 +226,38 @@ func main() {
 						log.Printf("Force capture failed: %v", err)
 					}
 				},
+				OnSwitchProfile: func(id int64) {
+					if err := app.SwitchProfile(id); err != nil {
+						log.Printf("Profile switch failed: %v", err)
+					}
+				},
 			})
 			go sysTray.Run()
+
+			// Show daily goal progress in the tray tooltip
+			app.watchGoalProgress(func(progress *service.GoalProgress) {
+				if progress == nil {
+					sysTray.SetGoalProgress(0, 0)
+					return
+				}
+				sysTray.SetGoalProgress(progress.Actual, progress.Target)
+			})
+
+			// Show today's productivity score as the tray icon
+			app.watchProductivity(sysTray.SetProductivityIcon)
+
+			// Show the active profile in the tray menu
+			app.watchProfiles(func(profiles []*storage.Profile, active *storage.Profile) {
+				items := make([]tray.Profile, len(profiles))
+				for i, p := range profiles {
+					items[i] = tray.Profile{ID: p.ID, Name: p.Name}
+				}
+				var activeID int64
+				if active != nil {
+					activeID = active.ID
+				}
+				sysTray.SetProfiles(items, activeID)
+			})
 		},
 		OnShutdown: func(ctx context.Context) {
 			// Quit the system tray
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"bufio"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"traq/internal/broadcast"
+	"traq/internal/platform"
+	"traq/internal/service"
+	"traq/internal/storage"
+)
+
+// testPlatform provides the platform calls reading the config makes.
+type testPlatform struct {
+	platform.Platform
+	dataDir string
+}
+
+func (p *testPlatform) DataDir() string { return p.dataDir }
+
+func TestFocusEventStream(t *testing.T) {
+	dir := t.TempDir()
+	store, err := storage.NewStore(filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatalf("NewStore failed: %v", err)
+	}
+	defer store.Close()
+
+	app := &App{Config: service.NewConfigService(store, &testPlatform{dataDir: dir}, nil), events: broadcast.NewHub()}
+	if err := app.startEventServer(); err != nil {
+		t.Fatalf("startEventServer failed: %v", err)
+	}
+	defer app.eventServer.Close()
+
+	if url, err := app.GetFocusEventStream(); err != nil || url != "" {
+		t.Fatalf("expected no stream while disabled, got %q, %v", url, err)
+	}
+	if err := app.Config.UpdateConfig(map[string]interface{}{"system": map[string]interface{}{"enableSSE": true}}); err != nil {
+		t.Fatalf("UpdateConfig failed: %v", err)
+	}
+	url, err := app.GetFocusEventStream()
+	if err != nil || !strings.HasPrefix(url, "http://127.0.0.1:") {
+		t.Fatalf("unexpected stream URL %q, %v", url, err)
+	}
+
+	// The stream is refused without its token
+	resp, err := http.Get(strings.Split(url, "?")[0])
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusForbidden {
+		t.Errorf("status without token = %d, want 403", resp.StatusCode)
+	}
+
+	// Events are flushed to the client as they're published
+	resp, err = http.Get(url)
+	if err != nil {
+		t.Fatalf("GET failed: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("unexpected response: %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	for deadline := time.Now().Add(5 * time.Second); app.events.SubscriberCount() == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("client never subscribed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	app.events.Publish("focus", map[string]string{"app": "Firefox"})
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	want := []string{"event: focus", `data: {"app":"Firefox"}`}
+	for _, w := range want {
+		select {
+		case line := <-lines:
+			if line != w {
+				t.Fatalf("got line %q, want %q", line, w)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %q", w)
+		}
+	}
+}
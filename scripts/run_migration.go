@@ -27,7 +27,7 @@ func main() {
 	}
 
 	// Check current version
-	currentVersion, err := store.GetSchemaVersion()
+	currentVersion, err := store.GetCurrentMigrationVersion()
 	if err != nil {
 		log.Printf("Error getting schema version: %v", err)
 	}
@@ -40,7 +40,7 @@ func main() {
 	}
 
 	// Check new version
-	newVersion, err := store.GetSchemaVersion()
+	newVersion, err := store.GetCurrentMigrationVersion()
 	if err != nil {
 		log.Fatal(err)
 	}